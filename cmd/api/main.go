@@ -24,7 +24,7 @@ func main() {
 		xlog.Fatalf(ctx, "failed to setup app: %v", err)
 	}
 
-	healthCheck := health.NewHealthCheck()
+	healthCheck := health.NewHealthCheck(s.Readiness)
 	balanceService := services.NewReconBalanceService(s.Service)
 
 	httpServer := http.NewHTTPServer(ctx, s.Config, s.NewRelic,
@@ -38,17 +38,33 @@ func main() {
 		s.Service.File,
 		s.Service.MasterData,
 		balanceService,
+		s.Service.ReconException,
 		s.Service.DLQProcessor,
 		s.Service.WalletAccount,
 		s.Service.WalletTrx,
 		s.Metrics,
 		s.Service.MoneyFlowCalc,
+		s.Service.MoneyFlowBusinessRule,
+		s.Service.Tax,
+		s.Service.Export,
+		s.Service.LedgerReport,
+		s.Service.TransactionMetric,
+		s.Service.Approval,
 		healthCheck,
 	)
 
+	businessMetrics, err := setup.NewBusinessMetricsCollector(s)
+	if err != nil {
+		xlog.Fatalf(ctx, "failed to setup business metrics: %v", err)
+	}
+
 	starters = append(starters, httpServer.Start())
 	stoppers = append(stoppers, stopperContract...) // Added FIRST → Will stop LAST (Kafka, DB, Cache)
-	stoppers = append(stoppers, httpServer.Stop())  // Added LAST → Will stop FIRST (HTTP)
+	if businessMetrics != nil {
+		starters = append(starters, businessMetrics.Start())
+		stoppers = append(stoppers, businessMetrics.Stop()) // stopped before the DB and Kafka it reads
+	}
+	stoppers = append(stoppers, httpServer.Stop()) // Added LAST → Will stop FIRST (HTTP)
 
 	xlog.Info(ctx, "starting services in background...")
 	graceful.StartProcessAtBackground(starters...)
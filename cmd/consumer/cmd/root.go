@@ -4,15 +4,19 @@ import (
 	"context"
 	"log"
 	"os"
+	"strings"
 
 	"bitbucket.org/Amartha/go-fp-transaction/cmd/setup"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/graceful"
+	kafkacommon "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/readiness"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer"
 	kafkaconsumer "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/kafka"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/health"
 
 	xlog "bitbucket.org/Amartha/go-x/log"
 
+	"github.com/Shopify/sarama"
 	"github.com/spf13/cobra"
 )
 
@@ -34,16 +38,17 @@ func Execute() {
 func init() {
 	rootCmd.AddCommand(runJobCmd)
 
-	runJobCmd.Flags().StringP(runConsumerCmdName, "n", "", "job name")
+	runJobCmd.Flags().StringP(runConsumerCmdName, "n", "", "comma separated consumer names or all")
 	runJobCmd.MarkFlagRequired(runConsumerCmdName)
 }
 
 var (
 	runJobCmd = &cobra.Command{
-		Use:     "run",
-		Short:   "Run consumer",
-		Long:    `Run consumer for handling message transaction or dlq, available consumer type: transaction, transaction.dlq`,
-		Example: "consumer run -n={consumer-type-name}",
+		Use:   "run",
+		Short: "Run consumer",
+		Long: `Run consumers for handling message transaction or dlq in a single process, they share the setup, database pools and producers.
+Available consumer names: ` + strings.Join(consumer.Names, ", ") + `, or all to run every consumer`,
+		Example: "consumer run -n={consumer-type-name}\nconsumer run -n=account_mutation,hvt_balance_update\nconsumer run -n=all",
 		Run:     runConsumer,
 	}
 	runConsumerCmdName = "name"
@@ -56,43 +61,80 @@ func runConsumer(ccmd *cobra.Command, args []string) {
 		stoppers []graceful.ProcessStopper
 	)
 
-	consumerName, _ := ccmd.Flags().GetString(runConsumerCmdName)
-	xlog.Infof(ctx, "initializing consumer: %s", consumerName)
+	nameFlag, _ := ccmd.Flags().GetString(runConsumerCmdName)
+	consumerNames, err := consumer.ParseNames(nameFlag)
+	if err != nil {
+		log.Fatalf("invalid consumer name: %v", err)
+	}
+
+	processName := strings.Join(consumerNames, ",")
+	if len(consumerNames) == len(consumer.Names) {
+		processName = consumer.AllNames
+	}
+	xlog.Infof(ctx, "initializing consumer: %s", processName)
 
-	// Step 1: Initialize setup
-	s, stopperContract, err := setup.Init("consumer-" + consumerName)
+	// Step 1: Initialize setup, shared by every consumer of the process
+	s, stopperContract, err := setup.Init("consumer-" + processName)
 	if err != nil {
 		log.Fatalf("failed to setup app: %v", err)
 	}
 
-	// Step 2: Create Kafka consumer
-	consumerProcess, consumerStopper, err := consumer.NewKafkaConsumer(ctx, consumerName, s.Config, s.Service, s.RepoCache, s)
+	// Step 2: Create Kafka consumers
+	consumerProcesses, consumerStoppers, err := consumer.NewKafkaConsumers(ctx, consumerNames, s.Config, s.Service, s.RepoCache, s)
 	if err != nil {
 		// Only stop setup resources, not consumer resources (they don't exist yet)
 		xlog.Fatalf(ctx, "failed to setup consumer: %v", err)
 	}
 
-	// Step 3: Create health check server
-	check := health.NewHealthCheck()
-	healthCheckProcess := kafkaconsumer.NewHTTPServer(ctx, s.Config, s.Metrics, check)
+	// Step 3: Create health check server, readiness includes lag of every consumer group
+	admin, err := sarama.NewClusterAdminFromClient(s.KafkaClient)
+	if err != nil {
+		xlog.Fatalf(ctx, "failed to create kafka cluster admin: %v", err)
+	}
+
+	adminConsumers := make(map[string]kafkaconsumer.Consumer, len(consumerProcesses))
+	for _, name := range consumerNames {
+		process := consumerProcesses[name]
+		adminConsumers[name] = process
+
+		component := readiness.ComponentConsumerGroup
+		if len(consumerNames) > 1 {
+			component += "_" + name
+		}
+
+		s.Readiness.Register(component, readiness.ConsumerGroup(s.KafkaClient, admin, process.ConsumerGroup(), process.Topics(), readiness.ConsumerGroupOptions{
+			MaxLag:      s.Config.Readiness.ConsumerMaxLag,
+			MaxIdleTime: s.Config.Readiness.ConsumerMaxIdleTime,
+		}))
+	}
+
+	check := health.NewHealthCheck(s.Readiness)
+	consumerAdmin := kafkaconsumer.NewAdmin(adminConsumers, kafkacommon.NewOffsetReader(s.KafkaClient, admin))
+	healthCheckProcess := kafkaconsumer.NewHTTPServer(ctx, s.Config, s.Metrics, check, consumerAdmin)
 
 	// Step 4: Collect all starters and stoppers
-	starters = append(starters, consumerProcess.Start(), healthCheckProcess.Start())
+	for _, name := range consumerNames {
+		starters = append(starters, consumerProcesses[name].Start())
+	}
+	starters = append(starters, healthCheckProcess.Start())
+
 	// Since graceful.StopProcess() calls slices.Reverse(), we append in OPPOSITE order:
-	stoppers = append(stoppers, stopperContract...)        // Added FIRST → Will stop LAST (Kafka producers, DB, Cache)
-	stoppers = append(stoppers, consumerStopper...)        // Added 2nd → Will stop 3rd (Consumer resources)
-	stoppers = append(stoppers, consumerProcess.Stop())    // Added 3rd → Will stop 2nd (Kafka consumer)
+	stoppers = append(stoppers, stopperContract...)  // Added FIRST → Will stop LAST (Kafka producers, DB, Cache)
+	stoppers = append(stoppers, consumerStoppers...) // Added 2nd → Will stop 3rd (Consumer resources)
+	for _, name := range consumerNames {
+		stoppers = append(stoppers, consumerProcesses[name].Stop()) // Added 3rd → Will stop 2nd (Kafka consumers)
+	}
 	stoppers = append(stoppers, healthCheckProcess.Stop()) // Added LAST → Will stop FIRST (Health check HTTP)
 
 	xlog.Info(ctx, "starting consumer services in background...")
 	graceful.StartProcessAtBackground(starters...)
 
-	xlog.Infof(ctx, "consumer %s started, waiting for shutdown signal...", consumerName)
+	xlog.Infof(ctx, "consumer %s started, waiting for shutdown signal...", processName)
 
 	// Block until shutdown signal is received (includes 10 second sleep)
 	graceful.StopProcessAtBackground(ctx)
 	check.Shutdown()
 	graceful.StopProcess(ctx, s.Config.App.GracefulTimeout, stoppers...)
 
-	xlog.Infof(ctx, "consumer %s stopped successfully!", consumerName)
+	xlog.Infof(ctx, "consumer %s stopped successfully!", processName)
 }
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"hash/fnv"
+	"net/http"
 	"os"
 	"time"
 
@@ -19,10 +20,16 @@ import (
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/flag"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/graceful"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/idgenerator"
+	kafkacommon "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/messaging"
 	cMetrics "bitbucket.org/Amartha/go-fp-transaction/internal/common/metrics"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/payment"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/publisher"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/queueunicorn"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/readiness"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/tracing"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/transaction_notification"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/webhook"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
 	kafkaRecon "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/kafka_recon"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
@@ -32,6 +39,7 @@ import (
 	xlog "bitbucket.org/Amartha/go-x/log"
 
 	"cloud.google.com/go/compute/metadata"
+	"github.com/Shopify/sarama"
 	"github.com/newrelic/go-agent/v3/integrations/nrzap"
 	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/redis/go-redis/v9"
@@ -45,11 +53,14 @@ type Setup struct {
 	WriteDB          *sql.DB
 	ReadDB           *sql.DB
 	Cache            *redis.Client
+	RepoSQL          *repositories.Repository
 	RepoCache        repositories.CacheRepository
 	RepoCloudStorage repositories.CloudStorageRepository
 	Service          *services.Services
 	PublisherClient  *PublisherClient
 	Metrics          cMetrics.Metrics
+	KafkaClient      sarama.Client
+	Readiness        *readiness.Readiness
 }
 
 func Init(command string) (setup *Setup, stopper []graceful.ProcessStopper, err error) {
@@ -104,6 +115,14 @@ func Init(command string) (setup *Setup, stopper []graceful.ProcessStopper, err
 
 	newRelic := setupNR(ctx, cfg)
 
+	// tracing is shut down after the servers and consumers, so their last spans are exported
+	shutdownTracing, err := tracing.Init(ctx, cfg, command)
+	if err != nil {
+		err = fmt.Errorf("failed to setup tracing: %w", err)
+		return
+	}
+	stopper = append(stopper, func(ctx context.Context) error { return shutdownTracing(ctx) })
+
 	// metrics
 	mtc := cMetrics.New()
 
@@ -186,15 +205,24 @@ func Init(command string) (setup *Setup, stopper []graceful.ProcessStopper, err
 
 	accountingClient := accounting.New(cfg.GoAccounting, mtc, cacheAccounting, cacheListAccounting)
 
+	paymentClient := payment.New(cfg.GoPayment, mtc)
+	if cfg.MoneyFlowDisbursement.UseFakePaymentClient {
+		paymentClient = payment.NewFake()
+	}
+
+	webhookClient := webhook.New(cfg.Webhook)
+
 	// register repository
 	sqlRepo := repositories.NewSQLRepository(writeDB, readDB, cfg, flagClient, accountingClient)
 	cacheRepo := repositories.NewCacheRepository(cache)
 
-	masterDataRepo, err := repositories.NewGCSMasterDataRepository(&cfg)
+	masterDataRepo, masterDataStopper, err := setupMasterData(cfg, sqlRepo)
 	if err != nil {
-		err = fmt.Errorf("failed connect to gcs master data: %w", err)
 		return
 	}
+	if masterDataStopper != nil {
+		stopper = append(stopper, masterDataStopper)
+	}
 
 	masterDataRepo.RefreshDataPeriodically(ctx, time.Minute)
 
@@ -249,6 +277,8 @@ func Init(command string) (setup *Setup, stopper []graceful.ProcessStopper, err
 
 	walletTransactionAsync := publisher.NewPublisher(producer, cfg.MessageBroker.KafkaConsumer.TopicProcessWalletTransaction)
 
+	exportPub := publisher.NewPublisher(producer, cfg.MessageBroker.KafkaConsumer.TopicExport)
+
 	publisherClient := PublisherClient{
 		TransactionNotification: transaction_notification.NewTransactionNotificationPublisher(
 			cfg,
@@ -275,11 +305,26 @@ func Init(command string) (setup *Setup, stopper []graceful.ProcessStopper, err
 		balanceHVTPub,
 		publisherClient.TransactionNotification,
 		walletTransactionAsync,
+		exportPub,
 		accountingClient,
+		paymentClient,
+		webhookClient,
 		flagClient,
 		mtc,
 	)
 
+	srv.MoneyFlowBusinessRule.RefreshPeriodically(ctx, time.Minute)
+	srv.Tax.RefreshPeriodically(ctx, time.Minute)
+
+	kafkaClient, err := setupKafkaClient(cfg)
+	if err != nil {
+		err = fmt.Errorf("unable to create kafka client: %w", err)
+		return
+	}
+	stopper = append(stopper, func(ctx context.Context) error { return kafkaClient.Close() })
+
+	ready := setupReadiness(cfg, writeDB, readDB, cache, kafkaClient, masterDataRepo)
+
 	return &Setup{
 		Config:           cfg,
 		NewRelic:         newRelic,
@@ -287,13 +332,130 @@ func Init(command string) (setup *Setup, stopper []graceful.ProcessStopper, err
 		ReadDB:           readDB,
 		Cache:            cache,
 		Service:          srv,
+		RepoSQL:          sqlRepo,
 		RepoCache:        cacheRepo,
 		RepoCloudStorage: cloudStorageRepo,
 		PublisherClient:  &publisherClient,
 		Metrics:          mtc,
+		KafkaClient:      kafkaClient,
+		Readiness:        ready,
 	}, stopper, nil
 }
 
+// setupKafkaClient create kafka client shared by readiness checkers
+func setupKafkaClient(cfg config.Config) (sarama.Client, error) {
+	saramaCfg, err := messaging.CreateSaramaConsumerConfig(cfg.MessageBroker.KafkaConsumer, "[readiness] ")
+	if err != nil {
+		return nil, err
+	}
+
+	return sarama.NewClient(cfg.MessageBroker.KafkaConsumer.Brokers, saramaCfg)
+}
+
+// setupReadiness register checkers of the dependencies shared by every command,
+// command specific checker e.g. consumer group lag is registered by the command
+func setupReadiness(
+	cfg config.Config,
+	writeDB, readDB *sql.DB,
+	cache *redis.Client,
+	kafkaClient sarama.Client,
+	masterDataRepo repositories.MasterDataRepository,
+) *readiness.Readiness {
+	const defaultMasterDataMaxAge = 5 * time.Minute
+
+	masterDataMaxAge := cfg.Readiness.MasterDataMaxAge
+	if masterDataMaxAge <= 0 {
+		masterDataMaxAge = defaultMasterDataMaxAge
+	}
+
+	ready := readiness.New(cfg.Readiness)
+	ready.Register(readiness.ComponentPostgresWrite, readiness.PingDB(writeDB))
+	ready.Register(readiness.ComponentPostgresRead, readiness.PingDB(readDB))
+	ready.Register(readiness.ComponentRedis, readiness.PingRedis(cache))
+	ready.Register(readiness.ComponentKafka, readiness.KafkaBrokers(kafkaClient))
+	ready.Register(readiness.ComponentMasterData, readiness.MasterDataFreshness(masterDataRepo.LastRefreshedAt, masterDataMaxAge))
+
+	if cfg.FeatureFlagSDKConfig.URL != "" {
+		ready.Register(readiness.ComponentFeatureFlag, readiness.HTTPEndpoint(http.DefaultClient, cfg.FeatureFlagSDKConfig.URL))
+	}
+
+	return ready
+}
+
+// NewBusinessMetricsCollector create the collector of the ledger health gauges, it is nil when business metrics are disabled
+func NewBusinessMetricsCollector(s *Setup) (*cMetrics.Collector, error) {
+	if !s.Config.BusinessMetrics.Enabled {
+		return nil, nil
+	}
+
+	admin, err := sarama.NewClusterAdminFromClient(s.KafkaClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka cluster admin: %w", err)
+	}
+
+	kafkaCfg := s.Config.MessageBroker.KafkaConsumer
+	business := s.Metrics.GetBusinessPrometheus()
+	ledgerHealthRepo := s.RepoSQL.GetLedgerHealthRepository()
+
+	var dlqTopics []string
+	for _, topic := range []string{
+		kafkaCfg.TopicDLQ,
+		kafkaCfg.TopicAccountMutationDLQ,
+		kafkaCfg.TopicBalanceHvtDLQ,
+		kafkaCfg.TopicProcessWalletTransactionDLQ,
+		kafkaCfg.TopicMoneyFlowCalcDLQ,
+	} {
+		if topic != "" && !slices.Contains(dlqTopics, topic) {
+			dlqTopics = append(dlqTopics, topic)
+		}
+	}
+
+	collector := cMetrics.NewCollector(s.Config.BusinessMetrics, business)
+	collector.Register("reserved_wallet_transactions", func(ctx context.Context) error {
+		summaries, err := ledgerHealthRepo.GetReservedWalletTransactions(ctx)
+		if err != nil {
+			return err
+		}
+
+		business.SetReservedWalletTransactions(summaries, time.Now())
+		return nil
+	})
+	collector.Register("money_flow_summaries", func(ctx context.Context) error {
+		summaries, err := ledgerHealthRepo.GetMoneyFlowSummaries(ctx)
+		if err != nil {
+			return err
+		}
+
+		business.SetMoneyFlowSummaries(summaries)
+		return nil
+	})
+	collector.Register("hvt_balance_update_backlog", func(ctx context.Context) error {
+		lags, err := kafkacommon.GroupLag(s.KafkaClient, admin, kafkaCfg.ConsumerGroupBalanceHvt, []string{kafkaCfg.TopicBalanceHVT})
+		if err != nil {
+			return err
+		}
+
+		var backlog int64
+		for _, lag := range lags {
+			backlog += lag.Lag
+		}
+
+		business.SetHVTBalanceUpdateBacklog(backlog)
+		return nil
+	})
+	collector.Register("dlq_messages", func(ctx context.Context) error {
+		messages, err := kafkacommon.TopicMessages(s.KafkaClient, dlqTopics)
+		if err != nil {
+			return err
+		}
+
+		business.SetDLQMessages(messages)
+		return nil
+	})
+
+	return collector, nil
+}
+
 func setupPostgres(conf config.Config) (*sql.DB, *sql.DB, error) {
 	writeDB, err := initDB(conf.Postgres.Write)
 	if err != nil {
@@ -308,6 +470,38 @@ func setupPostgres(conf config.Config) (*sql.DB, *sql.DB, error) {
 	return writeDB, readDB, nil
 }
 
+// setupMasterData create master data repository of the configured backend,
+// the returned stopper is nil when there is nothing to close
+func setupMasterData(cfg config.Config, sqlRepo *repositories.Repository) (repositories.MasterDataRepository, graceful.ProcessStopper, error) {
+	switch cfg.MasterData.Backend {
+	case "", repositories.MasterDataBackendGCS:
+		masterDataRepo, err := repositories.NewGCSMasterDataRepository(&cfg)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed connect to gcs master data: %w", err)
+		}
+
+		return masterDataRepo, nil, nil
+	case repositories.MasterDataBackendPostgres:
+		listener, err := repositories.NewMasterDataListener(postgresDSN(cfg.Postgres.Write))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to listen postgres master data: %w", err)
+		}
+
+		return repositories.NewSQLMasterDataRepository(sqlRepo, listener),
+			func(ctx context.Context) error { return listener.Close() },
+			nil
+	default:
+		return nil, nil, fmt.Errorf("unknown master data backend: %s", cfg.MasterData.Backend)
+	}
+}
+
+func postgresDSN(pgConf config.Database) string {
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s search_path=%s sslmode=disable",
+		pgConf.DbHost, pgConf.DbPort, pgConf.DbUser, pgConf.DbPass, pgConf.DbName, pgConf.DbSchema,
+	)
+}
+
 func initDB(pgConf config.Database) (*sql.DB, error) {
 	const (
 		DefaultMaxOpen     = 10
@@ -315,12 +509,7 @@ func initDB(pgConf config.Database) (*sql.DB, error) {
 		DefaultMaxLifetime = 3 // minutes
 	)
 
-	dsName := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s search_path=%s sslmode=disable",
-		pgConf.DbHost, pgConf.DbPort, pgConf.DbUser, pgConf.DbPass, pgConf.DbName, pgConf.DbSchema,
-	)
-
-	db, err := sql.Open("nrpgx", dsName)
+	db, err := sql.Open("nrpgx", postgresDSN(pgConf))
 	if err != nil {
 		return nil, err
 	}
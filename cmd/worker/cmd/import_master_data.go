@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/cmd/setup"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	importMasterDataCmd = &cobra.Command{
+		Use:     "import-master-data",
+		Short:   "Import master data from GCS json files into postgres",
+		Long:    `Copy order types and vat revenue configs from the configured GCS files into postgres master data tables, existing data in postgres is replaced.`,
+		Example: "worker import-master-data -a={actor}",
+		Run:     importMasterData,
+	}
+	importMasterDataCmdActor = "actor"
+)
+
+func importMasterData(ccmd *cobra.Command, args []string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	actor, _ := ccmd.Flags().GetString(importMasterDataCmdActor)
+
+	s, _, err := setup.Init("job")
+	if err != nil {
+		xlog.Fatalf(ctx, "failed to setup app: %v", err)
+	}
+
+	defer func() {
+		s.WriteDB.Close()
+		s.ReadDB.Close()
+		s.Cache.Close()
+		s.RepoCloudStorage.Close()
+	}()
+
+	src, err := repositories.NewGCSMasterDataRepository(&s.Config)
+	if err != nil {
+		xlog.Fatalf(ctx, "failed connect to gcs master data: %v", err)
+	}
+	// first load is synchronous, background refresh is stopped by cancel
+	src.RefreshDataPeriodically(ctx, time.Hour)
+
+	dst := repositories.NewSQLMasterDataRepository(s.RepoSQL, nil)
+
+	err = repositories.CopyMasterData(models.WithMasterDataActor(ctx, actor), src, dst)
+	if err != nil {
+		xlog.Fatalf(ctx, "failed to import master data: %v", err)
+	}
+
+	xlog.Info(ctx, "master data imported!")
+}
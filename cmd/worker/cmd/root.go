@@ -48,6 +48,12 @@ func init() {
 	runJobCmd.Flags().StringP(runJobCmdFileName, "f", "", "file name")
 	runJobCmd.Flags().StringP(runJobCmdBucketName, "b", "", "bucket name")
 	runJobCmd.Flags().BoolP(runJobCmdFlagPublish, "p", false, "flag publish")
+	runJobCmd.Flags().StringP(runJobCmdStartDate, "s", "", "backfill start date")
+	runJobCmd.Flags().StringP(runJobCmdEndDate, "e", "", "backfill end date")
+	runJobCmd.Flags().StringP(runJobCmdReportName, "r", "", "scheduled report name")
+
+	rootCmd.AddCommand(importMasterDataCmd)
+	importMasterDataCmd.Flags().StringP(importMasterDataCmdActor, "a", "gcs-import", "actor recorded in master data audit")
 }
 
 var (
@@ -82,6 +88,9 @@ var (
 	runJobCmdFileName    = "file"
 	runJobCmdBucketName  = "bucket"
 	runJobCmdFlagPublish = "publishToAcuanNotif"
+	runJobCmdStartDate   = "start-date"
+	runJobCmdEndDate     = "end-date"
+	runJobCmdReportName  = "report"
 )
 
 func runJob(ccmd *cobra.Command, args []string) {
@@ -95,6 +104,9 @@ func runJob(ccmd *cobra.Command, args []string) {
 	fileName, _ := ccmd.Flags().GetString(runJobCmdFileName)
 	bucketName, _ := ccmd.Flags().GetString(runJobCmdBucketName)
 	flagPublishAcuan, _ := ccmd.Flags().GetBool(runJobCmdFlagPublish)
+	startDate, _ := ccmd.Flags().GetString(runJobCmdStartDate)
+	endDate, _ := ccmd.Flags().GetString(runJobCmdEndDate)
+	reportName, _ := ccmd.Flags().GetString(runJobCmdReportName)
 
 	s, _, err := setup.Init("job")
 	if err != nil {
@@ -116,6 +128,9 @@ func runJob(ccmd *cobra.Command, args []string) {
 		FileName:         fileName,
 		BucketName:       bucketName,
 		FlagPublishAcuan: flagPublishAcuan,
+		StartDate:        startDate,
+		EndDate:          endDate,
+		ReportName:       reportName,
 	})
 	xlog.Info(ctx, "job server stopped!")
 }
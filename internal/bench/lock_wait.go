@@ -0,0 +1,67 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// queryLockWaiting count the other backends of the database which are waiting on a lock, e.g. FOR UPDATE of balance
+const queryLockWaiting = `SELECT count(*) FROM pg_stat_activity
+WHERE datname = current_database() AND pid <> pg_backend_pid() AND wait_event_type = 'Lock'`
+
+// LockWait is the waiting on locks sampled from pg_stat_activity while the scenario is run
+type LockWait struct {
+	// TotalMs is the estimated time spent by all backends waiting on lock, every waiting backend of a sample counts as one interval
+	TotalMs float64 `json:"totalMs"`
+	// MaxWaiting is the most backends waiting on lock in one sample
+	MaxWaiting int `json:"maxWaiting"`
+	Samples    int `json:"samples"`
+}
+
+// startLockSampler sample the lock waiting every interval until the returned function is called
+func startLockSampler(ctx context.Context, db *sql.DB, interval time.Duration) func() (LockWait, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	var (
+		lockWait LockWait
+		waited   time.Duration
+		err      error
+	)
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			var waiting int
+			if errQuery := db.QueryRowContext(ctx, queryLockWaiting).Scan(&waiting); errQuery != nil {
+				if ctx.Err() == nil {
+					err = errQuery
+				}
+				return
+			}
+
+			lockWait.Samples++
+			lockWait.MaxWaiting = max(lockWait.MaxWaiting, waiting)
+			waited += time.Duration(waiting) * interval
+		}
+	}()
+
+	return func() (LockWait, error) {
+		cancel()
+		<-done
+
+		lockWait.TotalMs = milliseconds(waited)
+		return lockWait, err
+	}
+}
@@ -0,0 +1,152 @@
+package bench
+
+import (
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"time"
+)
+
+// Report is the json output of the benchmark, commit is taken from the build info so runs of different commits can be compared
+type Report struct {
+	Commit    string           `json:"commit,omitempty"`
+	Modified  bool             `json:"modified,omitempty"`
+	GoVersion string           `json:"goVersion"`
+	StartedAt time.Time        `json:"startedAt"`
+	Config    Config           `json:"config"`
+	Scenarios []ScenarioReport `json:"scenarios"`
+}
+
+// ScenarioReport is the result of a scenario, latency only counts the succeeded operations
+type ScenarioReport struct {
+	Scenario   string  `json:"scenario"`
+	Operations int     `json:"operations"`
+	Succeeded  int     `json:"succeeded"`
+	Failed     int     `json:"failed"`
+	Deadlocks  int     `json:"deadlocks"`
+	DurationMs float64 `json:"durationMs"`
+	// Throughput is the succeeded operations per second
+	Throughput float64 `json:"throughput"`
+
+	Latency Latency `json:"latency"`
+	// Steps is the latency of every call of operation which has more than one, e.g. create and commit of reserved transaction
+	Steps    map[string]Latency `json:"steps,omitempty"`
+	LockWait LockWait           `json:"lockWait"`
+	Errors   map[string]int     `json:"errors,omitempty"`
+}
+
+type Latency struct {
+	P50Ms  float64 `json:"p50Ms"`
+	P90Ms  float64 `json:"p90Ms"`
+	P99Ms  float64 `json:"p99Ms"`
+	MaxMs  float64 `json:"maxMs"`
+	MeanMs float64 `json:"meanMs"`
+}
+
+func NewReport(cfg Config) Report {
+	report := Report{
+		GoVersion: runtime.Version(),
+		StartedAt: time.Now().UTC(),
+		Config:    cfg,
+	}
+
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				report.Commit = setting.Value
+			case "vcs.modified":
+				report.Modified = setting.Value == "true"
+			}
+		}
+	}
+
+	return report
+}
+
+// NewLatency compute the percentiles of durations with the nearest-rank method
+func NewLatency(durations []time.Duration) Latency {
+	if len(durations) == 0 {
+		return Latency{}
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+
+	return Latency{
+		P50Ms:  milliseconds(percentile(sorted, 50)),
+		P90Ms:  milliseconds(percentile(sorted, 90)),
+		P99Ms:  milliseconds(percentile(sorted, 99)),
+		MaxMs:  milliseconds(sorted[len(sorted)-1]),
+		MeanMs: milliseconds(total / time.Duration(len(sorted))),
+	}
+}
+
+// percentile of sorted durations, the smallest value which p percent of durations is less than or equal to
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+func newScenarioReport(scenario string, results []result, elapsed time.Duration, lockWait LockWait) ScenarioReport {
+	report := ScenarioReport{
+		Scenario:   scenario,
+		Operations: len(results),
+		DurationMs: milliseconds(elapsed),
+		LockWait:   lockWait,
+	}
+
+	var total, create, commit []time.Duration
+	for _, res := range results {
+		if res.err != nil {
+			report.Failed++
+			if isDeadlock(res.err) {
+				report.Deadlocks++
+			}
+			report.addError(errorMessage(res.err))
+			continue
+		}
+
+		report.Succeeded++
+		total = append(total, res.total)
+		if res.commit > 0 {
+			create = append(create, res.create)
+			commit = append(commit, res.commit)
+		}
+	}
+
+	report.Latency = NewLatency(total)
+	if len(commit) > 0 {
+		report.Steps = map[string]Latency{
+			stepCreate: NewLatency(create),
+			stepCommit: NewLatency(commit),
+		}
+	}
+
+	if elapsed > 0 {
+		report.Throughput = float64(report.Succeeded) / elapsed.Seconds()
+	}
+
+	return report
+}
+
+func (r *ScenarioReport) addError(msg string) {
+	if r.Errors == nil {
+		r.Errors = map[string]int{}
+	}
+
+	if _, ok := r.Errors[msg]; !ok && len(r.Errors) >= maxErrorMessages {
+		msg = otherErrors
+	}
+
+	r.Errors[msg]++
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,75 @@
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewLatency(t *testing.T) {
+	t.Run("empty durations", func(t *testing.T) {
+		assert.Equal(t, Latency{}, NewLatency(nil))
+	})
+
+	t.Run("percentiles of 1 to 100 ms", func(t *testing.T) {
+		var durations []time.Duration
+		for i := 100; i >= 1; i-- {
+			durations = append(durations, time.Duration(i)*time.Millisecond)
+		}
+
+		assert.Equal(t, Latency{
+			P50Ms:  50,
+			P90Ms:  90,
+			P99Ms:  99,
+			MaxMs:  100,
+			MeanMs: 50.5,
+		}, NewLatency(durations))
+	})
+
+	t.Run("single duration", func(t *testing.T) {
+		latency := NewLatency([]time.Duration{3 * time.Millisecond})
+		assert.Equal(t, float64(3), latency.P50Ms)
+		assert.Equal(t, float64(3), latency.P99Ms)
+	})
+}
+
+func Test_newScenarioReport(t *testing.T) {
+	errDeadlock := errors.New("ERROR: deadlock detected (SQLSTATE 40P01)")
+	results := []result{
+		{total: 10 * time.Millisecond, create: 4 * time.Millisecond, commit: 6 * time.Millisecond},
+		{total: 20 * time.Millisecond, create: 8 * time.Millisecond, commit: 12 * time.Millisecond},
+		{total: time.Millisecond, err: fmt.Errorf("unable to update balance: %w", errDeadlock)},
+		{total: time.Millisecond, err: fmt.Errorf("ref bench-1: %w", errors.New("insufficient balance"))},
+		{total: time.Millisecond, err: fmt.Errorf("ref bench-2: %w", errors.New("insufficient balance"))},
+	}
+
+	report := newScenarioReport(ScenarioReserveCommit, results, 2*time.Second, LockWait{TotalMs: 5, MaxWaiting: 1, Samples: 10})
+
+	assert.Equal(t, ScenarioReserveCommit, report.Scenario)
+	assert.Equal(t, 5, report.Operations)
+	assert.Equal(t, 2, report.Succeeded)
+	assert.Equal(t, 3, report.Failed)
+	assert.Equal(t, 1, report.Deadlocks)
+	assert.Equal(t, float64(1), report.Throughput)
+	assert.Equal(t, float64(20), report.Latency.MaxMs)
+	assert.Equal(t, float64(12), report.Steps[stepCommit].MaxMs)
+	assert.Equal(t, map[string]int{
+		errDeadlock.Error():    1,
+		"insufficient balance": 2,
+	}, report.Errors)
+}
+
+func Test_ScenarioReport_addError(t *testing.T) {
+	var report ScenarioReport
+	for i := range maxErrorMessages + 2 {
+		report.addError(fmt.Sprintf("error %d", i))
+	}
+	report.addError("error 0")
+
+	assert.Len(t, report.Errors, maxErrorMessages+1)
+	assert.Equal(t, 2, report.Errors["error 0"])
+	assert.Equal(t, 2, report.Errors[otherErrors])
+}
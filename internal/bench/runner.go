@@ -0,0 +1,258 @@
+package bench
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	stepCreate = "create"
+	stepCommit = "commit"
+
+	// maxErrorMessages is the number of distinct error messages kept in the report, the rest is counted as other
+	maxErrorMessages = 10
+	otherErrors      = "other"
+)
+
+// Runner drive the wallet transaction service with the workloads of the config
+type Runner struct {
+	cfg                 Config
+	samplerDB           *sql.DB
+	accountService      services.AccountService
+	walletTrxService    services.WalletTrxService
+	systemAccountNumber string
+}
+
+// result is the outcome of an operation, duration of the steps is zero when they are not run
+type result struct {
+	total  time.Duration
+	create time.Duration
+	commit time.Duration
+	err    error
+}
+
+// NewRunner create the runner, samplerDB is only used to sample the lock waiting and must not be
+// the pool of the services, otherwise the sampler waits for a connection behind the workers
+func NewRunner(
+	cfg Config,
+	samplerDB *sql.DB,
+	accountService services.AccountService,
+	walletTrxService services.WalletTrxService,
+	systemAccountNumber string,
+) (*Runner, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return &Runner{
+		cfg:                 cfg,
+		samplerDB:           samplerDB,
+		accountService:      accountService,
+		walletTrxService:    walletTrxService,
+		systemAccountNumber: systemAccountNumber,
+	}, nil
+}
+
+// Run create the synthetic accounts and run the scenarios one after another,
+// accounts are funded before the scenario and the funding is not measured
+func (r *Runner) Run(ctx context.Context) (Report, error) {
+	report := NewReport(r.cfg)
+
+	if err := r.createAccounts(ctx); err != nil {
+		return report, err
+	}
+
+	for _, scenario := range r.cfg.Scenarios {
+		workload := r.cfg.Generate(scenario)
+
+		if err := r.fund(ctx, workload); err != nil {
+			return report, fmt.Errorf("failed to fund accounts of %s: %w", scenario, err)
+		}
+
+		xlog.Info(ctx, "[BENCH] running scenario",
+			xlog.String("scenario", scenario),
+			xlog.Int("operations", len(workload.Operations)),
+			xlog.Int("workers", r.cfg.Workers))
+
+		scenarioReport, err := r.runWorkload(ctx, workload)
+		if err != nil {
+			return report, fmt.Errorf("failed to run %s: %w", scenario, err)
+		}
+
+		report.Scenarios = append(report.Scenarios, scenarioReport)
+	}
+
+	return report, nil
+}
+
+func (r *Runner) createAccounts(ctx context.Context) error {
+	accountNumbers := append([]string{r.systemAccountNumber}, r.cfg.AccountNumbers()...)
+
+	for _, accountNumber := range accountNumbers {
+		_, err := r.accountService.Create(ctx, models.CreateAccount{
+			AccountNumber:   accountNumber,
+			Name:            "Bench " + accountNumber,
+			OwnerID:         accountNumber[len(accountNumber)-8:],
+			CategoryCode:    "211",
+			SubCategoryCode: "10000",
+			EntityCode:      "001",
+			Currency:        models.IDRCurrency,
+			Status:          "active",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create account %s: %w", accountNumber, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) fund(ctx context.Context, workload Workload) error {
+	for _, accountNumber := range r.cfg.AccountNumbers() {
+		amount, ok := workload.Funding[accountNumber]
+		if !ok {
+			continue
+		}
+
+		_, err := r.walletTrxService.CreateTransaction(ctx, r.newRequest(Operation{
+			RefNumber:       fmt.Sprintf("bench-funding-%s-%d-%s", workload.Scenario, r.cfg.Seed, accountNumber),
+			TransactionType: transactionTypeCashIn,
+			AccountNumber:   accountNumber,
+			Amount:          amount,
+		}))
+		if err != nil {
+			return fmt.Errorf("failed to fund account %s: %w", accountNumber, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Runner) runWorkload(ctx context.Context, workload Workload) (ScenarioReport, error) {
+	operations := make(chan Operation)
+	results := make([][]result, r.cfg.Workers)
+
+	stopSampler := startLockSampler(ctx, r.samplerDB, r.cfg.LockSampleInterval)
+	startTime := time.Now()
+
+	var wg sync.WaitGroup
+	for i := range r.cfg.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for op := range operations {
+				results[i] = append(results[i], r.execute(ctx, op))
+			}
+		}()
+	}
+
+	for _, op := range workload.Operations {
+		operations <- op
+	}
+	close(operations)
+	wg.Wait()
+
+	elapsed := time.Since(startTime)
+	lockWait, err := stopSampler()
+	if err != nil {
+		return ScenarioReport{}, fmt.Errorf("failed to sample lock wait: %w", err)
+	}
+
+	var all []result
+	for _, workerResults := range results {
+		all = append(all, workerResults...)
+	}
+
+	return newScenarioReport(workload.Scenario, all, elapsed, lockWait), nil
+}
+
+// execute create the transaction of op and commit it when it is reserved
+func (r *Runner) execute(ctx context.Context, op Operation) (res result) {
+	startTime := time.Now()
+	defer func() {
+		res.total = time.Since(startTime)
+	}()
+
+	created, err := r.walletTrxService.CreateTransaction(ctx, r.newRequest(op))
+	res.create = time.Since(startTime)
+	if err != nil {
+		res.err = err
+		return res
+	}
+
+	if !op.Reserved {
+		return res
+	}
+
+	commitTime := time.Now()
+	_, err = r.walletTrxService.ProcessReservedTransaction(ctx, models.UpdateStatusWalletTransactionRequest{
+		TransactionId:   created.ID,
+		Action:          models.TransactionRequestCommitStatus,
+		TransactionTime: commitTime,
+	})
+	res.commit = time.Since(commitTime)
+	res.err = err
+
+	return res
+}
+
+func (r *Runner) newRequest(op Operation) models.CreateWalletTransactionRequest {
+	amount := models.Amount{
+		ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(op.Amount)),
+		Currency:     models.IDRCurrency,
+	}
+
+	flow := models.TransactionFlowCashIn
+	switch op.TransactionType {
+	case transactionTypeTransfer:
+		flow = models.TransactionFlowTransfer
+	case transactionTypeCashOut:
+		flow = models.TransactionFlowCashOut
+	}
+
+	return models.CreateWalletTransactionRequest{
+		IsReserved:               op.Reserved,
+		AccountNumber:            op.AccountNumber,
+		DestinationAccountNumber: op.DestinationAccountNumber,
+		RefNumber:                op.RefNumber,
+		TransactionType:          op.TransactionType,
+		TransactionFlow:          flow,
+		TransactionTime:          time.Now().Format(time.RFC3339),
+		NetAmount:                amount,
+		Amounts:                  []models.AmountDetail{{Type: op.TransactionType, Amount: &amount}},
+		Description:              "benchmark",
+	}
+}
+
+// isDeadlock check the postgres error code 40P01 in the message, the driver error is not always kept in the chain
+func isDeadlock(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := err.Error()
+	return strings.Contains(msg, "40P01") || strings.Contains(msg, "deadlock detected")
+}
+
+// errorMessage group the errors by the innermost message, the wrapped messages contain ids of the operation
+func errorMessage(err error) string {
+	for {
+		inner := errors.Unwrap(err)
+		if inner == nil {
+			return err.Error()
+		}
+		err = inner
+	}
+}
@@ -0,0 +1,158 @@
+package bench
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+)
+
+// scenarios of the benchmark, each one is a workload on the balance engine
+const (
+	// ScenarioHotAccount cash-in many transactions to few accounts, every transaction lock the same balance rows
+	ScenarioHotAccount = "hot_account"
+	// ScenarioFanOut transfer from few source accounts to many destination accounts
+	ScenarioFanOut = "fan_out"
+	// ScenarioReserveCommit reserve a cash-out and commit it, the balance is locked twice per operation
+	ScenarioReserveCommit = "reserve_commit"
+)
+
+// Scenarios is every supported scenario in the order they are run by default
+var Scenarios = []string{ScenarioHotAccount, ScenarioFanOut, ScenarioReserveCommit}
+
+// transaction types used by the workloads, they are accepted without master data
+const (
+	transactionTypeCashIn   = "TUPVA"
+	transactionTypeTransfer = "ITRTF"
+	transactionTypeCashOut  = "COTWC"
+)
+
+// Config is the workload and the concurrency of the benchmark, the same config and seed always generate the same operations
+type Config struct {
+	Seed       uint64   `json:"seed"`
+	Scenarios  []string `json:"scenarios"`
+	Operations int      `json:"operations"`
+	Workers    int      `json:"workers"`
+	Accounts   int      `json:"accounts"`
+
+	// HotAccounts is the number of accounts receiving every transaction of ScenarioHotAccount
+	HotAccounts int `json:"hotAccounts"`
+	// FanOutSources is the number of accounts sending every transfer of ScenarioFanOut
+	FanOutSources int `json:"fanOutSources"`
+
+	// LockSampleInterval is how often the waiting on locks is sampled from pg_stat_activity
+	LockSampleInterval time.Duration `json:"lockSampleInterval"`
+}
+
+// DefaultConfig is a run of every scenario small enough for a laptop
+func DefaultConfig() Config {
+	return Config{
+		Seed:               1,
+		Scenarios:          slices.Clone(Scenarios),
+		Operations:         1000,
+		Workers:            16,
+		Accounts:           100,
+		HotAccounts:        1,
+		FanOutSources:      1,
+		LockSampleInterval: 10 * time.Millisecond,
+	}
+}
+
+// Validate check the config before the accounts are created
+func (c Config) Validate() error {
+	if len(c.Scenarios) == 0 {
+		return errors.New("no scenario to run")
+	}
+
+	for _, scenario := range c.Scenarios {
+		if !slices.Contains(Scenarios, scenario) {
+			return fmt.Errorf("unknown scenario %s, supported scenarios are %v", scenario, Scenarios)
+		}
+	}
+
+	switch {
+	case c.Operations <= 0:
+		return errors.New("operations must be positive")
+	case c.Workers <= 0:
+		return errors.New("workers must be positive")
+	case c.Accounts < 2:
+		return errors.New("accounts must be at least 2")
+	case c.HotAccounts <= 0 || c.HotAccounts > c.Accounts:
+		return fmt.Errorf("hot accounts must be between 1 and %d", c.Accounts)
+	case c.FanOutSources <= 0 || c.FanOutSources >= c.Accounts:
+		return fmt.Errorf("fan-out sources must be between 1 and %d", c.Accounts-1)
+	case c.LockSampleInterval <= 0:
+		return errors.New("lock sample interval must be positive")
+	}
+
+	return nil
+}
+
+// Operation is a wallet transaction of the workload, reserved one is committed right after it is created
+type Operation struct {
+	RefNumber                string
+	TransactionType          string
+	AccountNumber            string
+	DestinationAccountNumber string
+	Amount                   int64
+	Reserved                 bool
+}
+
+// Workload is the operations of a scenario and the balance each account needs before they are run
+type Workload struct {
+	Scenario   string
+	Operations []Operation
+	Funding    map[string]int64
+}
+
+// AccountNumbers return the synthetic account numbers of the config
+func (c Config) AccountNumbers() []string {
+	accounts := make([]string, c.Accounts)
+	for i := range accounts {
+		accounts[i] = fmt.Sprintf("9990%010d", i+1)
+	}
+
+	return accounts
+}
+
+// Generate return the workload of scenario, the random source is seeded by the seed and the scenario
+// so adding a scenario to the run does not change the operations of the others
+func (c Config) Generate(scenario string) Workload {
+	rnd := rand.New(rand.NewPCG(c.Seed, uint64(slices.Index(Scenarios, scenario))))
+	accounts := c.AccountNumbers()
+
+	w := Workload{
+		Scenario:   scenario,
+		Operations: make([]Operation, c.Operations),
+		Funding:    map[string]int64{},
+	}
+
+	for i := range w.Operations {
+		op := Operation{
+			RefNumber: fmt.Sprintf("bench-%s-%d-%d", scenario, c.Seed, i),
+			// amount is a multiple of 1000 between 1000 and 100000
+			Amount: (rnd.Int64N(100) + 1) * 1000,
+		}
+
+		switch scenario {
+		case ScenarioHotAccount:
+			op.TransactionType = transactionTypeCashIn
+			op.AccountNumber = accounts[rnd.IntN(c.HotAccounts)]
+		case ScenarioFanOut:
+			op.TransactionType = transactionTypeTransfer
+			op.AccountNumber = accounts[rnd.IntN(c.FanOutSources)]
+			op.DestinationAccountNumber = accounts[c.FanOutSources+rnd.IntN(c.Accounts-c.FanOutSources)]
+			w.Funding[op.AccountNumber] += op.Amount
+		case ScenarioReserveCommit:
+			op.TransactionType = transactionTypeCashOut
+			op.AccountNumber = accounts[rnd.IntN(c.Accounts)]
+			op.Reserved = true
+			w.Funding[op.AccountNumber] += op.Amount
+		}
+
+		w.Operations[i] = op
+	}
+
+	return w
+}
@@ -0,0 +1,106 @@
+package bench
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Config_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr bool
+	}{
+		{
+			name:   "success - default config",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:    "failed - unknown scenario",
+			modify:  func(cfg *Config) { cfg.Scenarios = []string{"unknown"} },
+			wantErr: true,
+		},
+		{
+			name:    "failed - no worker",
+			modify:  func(cfg *Config) { cfg.Workers = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "failed - more hot accounts than accounts",
+			modify:  func(cfg *Config) { cfg.HotAccounts = cfg.Accounts + 1 },
+			wantErr: true,
+		},
+		{
+			name:    "failed - every account is fan-out source",
+			modify:  func(cfg *Config) { cfg.FanOutSources = cfg.Accounts },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(&cfg)
+
+			err := cfg.Validate()
+			assert.Equal(t, tt.wantErr, err != nil, "error: %v", err)
+		})
+	}
+}
+
+func Test_Config_Generate(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Operations = 200
+	cfg.Accounts = 10
+	cfg.HotAccounts = 2
+	cfg.FanOutSources = 3
+
+	t.Run("same seed generate same operations", func(t *testing.T) {
+		for _, scenario := range Scenarios {
+			assert.Equal(t, cfg.Generate(scenario), cfg.Generate(scenario))
+		}
+	})
+
+	t.Run("different seed generate different operations", func(t *testing.T) {
+		other := cfg
+		other.Seed = cfg.Seed + 1
+
+		assert.NotEqual(t, cfg.Generate(ScenarioHotAccount).Operations, other.Generate(ScenarioHotAccount).Operations)
+	})
+
+	t.Run("hot account target only hot accounts", func(t *testing.T) {
+		accounts := cfg.AccountNumbers()
+		w := cfg.Generate(ScenarioHotAccount)
+
+		require.Len(t, w.Operations, cfg.Operations)
+		assert.Empty(t, w.Funding)
+		for _, op := range w.Operations {
+			assert.Contains(t, accounts[:cfg.HotAccounts], op.AccountNumber)
+			assert.Equal(t, transactionTypeCashIn, op.TransactionType)
+		}
+	})
+
+	t.Run("fan out is funded by the transferred amount", func(t *testing.T) {
+		accounts := cfg.AccountNumbers()
+		w := cfg.Generate(ScenarioFanOut)
+
+		sent := map[string]int64{}
+		for _, op := range w.Operations {
+			assert.Contains(t, accounts[:cfg.FanOutSources], op.AccountNumber)
+			assert.Contains(t, accounts[cfg.FanOutSources:], op.DestinationAccountNumber)
+			sent[op.AccountNumber] += op.Amount
+		}
+		assert.Equal(t, sent, w.Funding)
+	})
+
+	t.Run("reserve commit is reserved", func(t *testing.T) {
+		w := cfg.Generate(ScenarioReserveCommit)
+
+		for _, op := range w.Operations {
+			assert.True(t, op.Reserved)
+			assert.Equal(t, transactionTypeCashOut, op.TransactionType)
+		}
+	})
+}
@@ -77,8 +77,8 @@ func New(
 }
 
 func (c client) GetInvestedAccountNumber(ctx context.Context, cihAccountNumber string) (res string, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	startTime := time.Now()
 	url := fmt.Sprintf("%s/api/v1/lender-accounts/%s", c.baseURL, cihAccountNumber)
@@ -138,8 +138,8 @@ func (c client) GetInvestedAccountNumber(ctx context.Context, cihAccountNumber s
 }
 
 func (c client) GetReceivableAccountNumber(ctx context.Context, cihAccountNumber string) (res string, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	startTime := time.Now()
 	url := fmt.Sprintf("%s/api/v1/lender-accounts/%s", c.baseURL, cihAccountNumber)
@@ -199,8 +199,8 @@ func (c client) GetReceivableAccountNumber(ctx context.Context, cihAccountNumber
 }
 
 func (c client) GetLoanAdvancePayment(ctx context.Context, loanAccountNumber string) (res string, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	startTime := time.Now()
 	url := fmt.Sprintf("%s/api/v1/loan-accounts/advance-account/%s", c.baseURL, loanAccountNumber)
@@ -253,8 +253,8 @@ func (c client) GetLoanAdvancePayment(ctx context.Context, loanAccountNumber str
 }
 
 func (c client) GetLoanPartnerAccounts(ctx context.Context, loanAccountNumber string, loanKind string) (res ResponseGetListAccountNumber, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	startTime := time.Now()
 	url := fmt.Sprintf("%s/api/v1/loan-partner-accounts", c.baseURL)
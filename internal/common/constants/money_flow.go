@@ -7,6 +7,20 @@ const (
 	MoneyFlowStatusSuccess    = "SUCCESSFUL"
 	MoneyFlowStatusFailed     = "FAILED"
 	MoneyFlowStatusRejected   = "REJECTED"
+
+	// MoneyFlowStatusDisbursing is set by automatic disbursement while the transfer is requested to payment API,
+	// summary in this status is not sent again by other run until the claim is expired
+	MoneyFlowStatusDisbursing = "DISBURSING"
+)
+
+// Money Flow Summary Event Sources
+const (
+	MoneyFlowEventSourceAPI        = "API"
+	MoneyFlowEventSourcePapaStream = "PAPA_STREAM"
+	MoneyFlowEventSourceSystem     = "SYSTEM"
+
+	// MoneyFlowDisbursementActor is recorded as actor of transitions made by automatic disbursement
+	MoneyFlowDisbursementActor = "auto-disbursement"
 )
 
 // Log Prefixes
@@ -16,6 +30,8 @@ const (
 	LogPrefixMoneyFlowCalc      = "[MONEY-FLOW-CALC]"
 	LogPrefixMoneyFlowUpdate    = "[MONEY-FLOW-UPDATE]"
 	LogPrefixMoneyFlowProcessor = "[MONEY-FLOW-PROCESSOR]"
+
+	LogPrefixMoneyFlowDisbursement = "[MONEY-FLOW-DISBURSEMENT]"
 )
 
 // Error Messages
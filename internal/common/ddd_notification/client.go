@@ -5,12 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
 
 	xlog "bitbucket.org/Amartha/go-x/log"
 	"bitbucket.org/Amartha/go-x/log/ctxdata"
 	"github.com/go-resty/resty/v2"
-	"github.com/newrelic/go-agent/v3/newrelic"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
@@ -26,19 +24,19 @@ type client struct {
 }
 
 func New(cfg config.Config) DDDNotification {
-	retryWaitTime := time.Duration(cfg.DDDNotification.RetryWaitTime) * time.Millisecond
-	restyClient := resty.New().SetRetryCount(cfg.DDDNotification.RetryCount).SetRetryWaitTime(retryWaitTime)
-	restyClient.SetTransport(newrelic.NewRoundTripper(restyClient.GetClient().Transport))
+	// sending email is not idempotent, the request is not retried so a timed out request does not send it twice
+	restyClient := resty.New()
+	restyClient.SetTransport(monitoring.NewMiddlewareRoundTripper(restyClient.GetClient().Transport))
 
 	return &client{
 		cfg:        cfg,
-		httpClient: resty.New(),
+		httpClient: restyClient,
 	}
 }
 
 func (c *client) SendEmail(ctx context.Context, request RequestEmail) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	path := "/api/v1/email/mandrill"
 	url := fmt.Sprintf("%s%s", c.cfg.DDDNotification.BaseUrl, path)
@@ -76,6 +76,26 @@ var (
 	ErrUnsupportedTransactionFlow                     = errors.New("transaction flow is not refund")
 	ErrInvalidRefundData                              = errors.New("invalid refund transaction data")
 	ErrRefundAmountHigherThanOriginalAmount           = errors.New("refund amount is greater than original wallet amount")
+	ErrReconExceptionInvalidState                     = errors.New("recon exception state transition is not allowed")
+	ErrReconExceptionClosed                           = errors.New("recon exception is already closed")
+	ErrMoneyFlowBusinessRuleNotDraft                  = errors.New("only draft business rules can be changed")
+	ErrInvalidMoneyFlowBusinessRules                  = errors.New("invalid money flow business rules")
+	ErrMoneyFlowEventOutOfOrder                       = errors.New("papa event is older than the last recorded event")
+	ErrMoneyFlowSummaryDisbursing                     = errors.New("money flow summary is still being disbursed")
+	ErrCaptureAmountExceedsRemaining                  = errors.New("capture amount is greater than remaining reserved amount")
+	ErrReservedTransactionAlreadyCaptured             = errors.New("reserved transaction is already partially captured")
+	ErrMasterDataVersionConflict                      = errors.New("master data has been changed by another request")
+	ErrInvalidTaxRate                                 = errors.New("invalid tax rate")
+	ErrInvalidDateRange                               = errors.New("end date must not be before start date")
+	ErrExportDateRangeExceeded                        = errors.New("export date range is too long")
+	ErrInvalidReportDefinition                        = errors.New("invalid report definition")
+	ErrReportNotFound                                 = errors.New("scheduled report not found")
+	ErrMetricDateRangeExceeded                        = errors.New("metric date range is too long")
+	ErrApprovalNotPending                             = errors.New("approval is already decided")
+	ErrApprovalExpired                                = errors.New("approval is expired")
+	ErrApprovalSameActor                              = errors.New("approval must be decided by another user than the maker")
+	ErrUnsupportedApprovalOperation                   = errors.New("unsupported approval operation")
+	ErrAmbiguousRefNumber                             = errors.New("refNumber is used by more than one wallet transaction, transactionType is required")
 )
 
 type WrapError struct {
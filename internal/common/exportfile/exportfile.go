@@ -0,0 +1,77 @@
+// Package exportfile write rows into a file of the requested format.
+// Rows are streamed into the underlying writer, so the exported file is not limited by memory.
+package exportfile
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+type Format string
+
+const (
+	FormatCSV     Format = "CSV"
+	FormatXLSX    Format = "XLSX"
+	FormatParquet Format = "PARQUET"
+)
+
+// Valid return true when the format is supported
+func (f Format) Valid() bool {
+	return f == FormatCSV || f == FormatXLSX || f == FormatParquet
+}
+
+// Extension return file extension of the format without leading dot
+func (f Format) Extension() string {
+	switch f {
+	case FormatXLSX:
+		return "xlsx"
+	case FormatParquet:
+		return "parquet"
+	default:
+		return "csv"
+	}
+}
+
+type Writer interface {
+	Write(row []string) error
+
+	// Close finalize the file, the underlying writer is not closed
+	Close() error
+}
+
+// New create writer of the format and write the header
+func New(format Format, w io.Writer, header []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, header)
+	case FormatXLSX:
+		return newXLSXWriter(w, header), nil
+	case FormatParquet:
+		return newParquetWriter(w, header, parquetRowGroupSize)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, header []string) (*csvWriter, error) {
+	cw := &csvWriter{w: csv.NewWriter(w)}
+	if err := cw.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write header: %w", err)
+	}
+
+	return cw, nil
+}
+
+func (c *csvWriter) Write(row []string) error {
+	return c.w.Write(row)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,127 @@
+package exportfile
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+var testHeader = []string{"Transaction ID", "Amount"}
+
+func TestNew(t *testing.T) {
+	_, err := New("JSON", io.Discard, testHeader)
+	assert.Error(t, err)
+
+	assert.True(t, FormatParquet.Valid())
+	assert.False(t, Format("JSON").Valid())
+
+	assert.Equal(t, "csv", FormatCSV.Extension())
+	assert.Equal(t, "xlsx", FormatXLSX.Extension())
+	assert.Equal(t, "parquet", FormatParquet.Extension())
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := New(FormatCSV, &buf, testHeader)
+	require.NoError(t, err)
+
+	require.NoError(t, w.Write([]string{"trx-1", "1000"}))
+	require.NoError(t, w.Write([]string{"trx-2", "2,5"}))
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "Transaction ID,Amount\ntrx-1,1000\ntrx-2,\"2,5\"\n", buf.String())
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := newXLSXWriter(&buf, testHeader)
+	w.maxRows = 3
+
+	require.NoError(t, w.Write([]string{"trx-1", "1000"}))
+	require.NoError(t, w.Write([]string{"trx-2", "<&>"}))
+	require.NoError(t, w.Write([]string{"trx-3", "3000"}))
+	require.NoError(t, w.Close())
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer f.Close()
+
+	assert.Equal(t, []string{"Sheet1", "Sheet2"}, f.GetSheetList())
+
+	// every sheet start with header
+	sheet1, err := f.GetRows("Sheet1")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{testHeader, {"trx-1", "1000"}, {"trx-2", "<&>"}}, sheet1)
+
+	sheet2, err := f.GetRows("Sheet2")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{testHeader, {"trx-3", "3000"}}, sheet2)
+}
+
+func TestXLSXWriter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	w := newXLSXWriter(&buf, testHeader)
+	require.NoError(t, w.Close())
+
+	f, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer f.Close()
+
+	rows, err := f.GetRows("Sheet1")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{testHeader}, rows)
+}
+
+func TestParquetWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := newParquetWriter(&buf, testHeader, 2)
+	require.NoError(t, err)
+
+	rows := [][]string{{"trx-1", "1000"}, {"trx-2", ""}, {"trx-3", "3000"}}
+	for _, row := range rows {
+		require.NoError(t, w.Write(row))
+	}
+	assert.Error(t, w.Write([]string{"trx-4"}))
+	require.NoError(t, w.Close())
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), f.NumRows())
+	assert.Len(t, f.RowGroups(), 2)
+
+	// columns keep the header order
+	fields := f.Schema().Fields()
+	require.Len(t, fields, 2)
+	assert.Equal(t, "Transaction ID", fields[0].Name())
+	assert.Equal(t, "Amount", fields[1].Name())
+	assert.True(t, fields[0].Required())
+	assert.Equal(t, parquet.String().Type(), fields[0].Type())
+
+	reader := parquet.NewReader(f)
+	defer reader.Close()
+
+	var got [][]string
+	buffer := make([]parquet.Row, 10)
+	n, err := reader.ReadRows(buffer)
+	require.ErrorIs(t, err, io.EOF)
+	for _, row := range buffer[:n] {
+		values := make([]string, 0, len(row))
+		for _, value := range row {
+			values = append(values, value.String())
+		}
+		got = append(got, values)
+	}
+
+	assert.Equal(t, rows, got)
+}
+
+func TestParquetWriter_InvalidHeader(t *testing.T) {
+	_, err := newParquetWriter(io.Discard, []string{"Amount", "a,b"}, parquetRowGroupSize)
+	assert.Error(t, err)
+}
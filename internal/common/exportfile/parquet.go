@@ -0,0 +1,69 @@
+package exportfile
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetRowGroupSize is the number of rows buffered in memory before written as a row group
+const parquetRowGroupSize = 50_000
+
+// parquetWriter write every column as required UTF8 string without compression,
+// compression is expected to be applied on the whole file (gzip).
+type parquetWriter struct {
+	w       *parquet.Writer
+	columns int
+}
+
+func newParquetWriter(w io.Writer, header []string, rowGroupSize int64) (*parquetWriter, error) {
+	schema, err := parquetSchemaOf(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parquetWriter{
+		w:       parquet.NewWriter(w, schema, parquet.MaxRowsPerRowGroup(rowGroupSize)),
+		columns: len(header),
+	}, nil
+}
+
+func (p *parquetWriter) Write(row []string) error {
+	if len(row) != p.columns {
+		return fmt.Errorf("row has %d columns, expected %d", len(row), p.columns)
+	}
+
+	values := make(parquet.Row, len(row))
+	for i, value := range row {
+		values[i] = parquet.ByteArrayValue([]byte(value)).Level(0, 0, i)
+	}
+
+	_, err := p.w.WriteRows([]parquet.Row{values})
+	return err
+}
+
+func (p *parquetWriter) Close() error {
+	return p.w.Close()
+}
+
+// parquetSchemaOf build schema with a string column for every header, the schema is derived from
+// a struct instead of parquet.Group because the group sort its columns by name
+func parquetSchemaOf(header []string) (*parquet.Schema, error) {
+	fields := make([]reflect.StructField, len(header))
+	for i, name := range header {
+		if name == "" || strings.Contains(name, ",") {
+			return nil, fmt.Errorf("invalid parquet column name %q", name)
+		}
+
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("Column%d", i),
+			Type: reflect.TypeOf(""),
+			Tag:  reflect.StructTag(fmt.Sprintf(`parquet:%q`, name)),
+		}
+	}
+
+	return parquet.SchemaOf(reflect.New(reflect.StructOf(fields)).Interface()), nil
+}
@@ -0,0 +1,107 @@
+package exportfile
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxMaxRows is the row limit of a worksheet, rows after the limit are written into the next worksheet
+const xlsxMaxRows = 1_048_576
+
+// xlsxWriter stream rows into worksheets with excelize stream writer, excelize keep the worksheet
+// in a temporary file once it grows large so the exported file is not limited by memory.
+// The workbook is written into the underlying writer on Close.
+type xlsxWriter struct {
+	w       io.Writer
+	file    *excelize.File
+	header  []string
+	maxRows int
+
+	sheets int
+	rows   int
+	sheet  *excelize.StreamWriter
+}
+
+func newXLSXWriter(w io.Writer, header []string) *xlsxWriter {
+	return &xlsxWriter{
+		w:       w,
+		file:    excelize.NewFile(),
+		header:  header,
+		maxRows: xlsxMaxRows,
+	}
+}
+
+func (x *xlsxWriter) Write(row []string) error {
+	if x.sheet == nil || x.rows >= x.maxRows {
+		if err := x.nextSheet(); err != nil {
+			return err
+		}
+	}
+
+	return x.writeRow(row)
+}
+
+func (x *xlsxWriter) nextSheet() error {
+	if x.sheet != nil {
+		if err := x.sheet.Flush(); err != nil {
+			return fmt.Errorf("failed to flush sheet: %w", err)
+		}
+	}
+
+	x.sheets++
+	name := fmt.Sprintf("Sheet%d", x.sheets)
+	if x.sheets > 1 {
+		if _, err := x.file.NewSheet(name); err != nil {
+			return fmt.Errorf("failed to create sheet: %w", err)
+		}
+	}
+
+	sheet, err := x.file.NewStreamWriter(name)
+	if err != nil {
+		return fmt.Errorf("failed to create sheet writer: %w", err)
+	}
+
+	x.sheet = sheet
+	x.rows = 0
+
+	// every sheet start with header
+	return x.writeRow(x.header)
+}
+
+func (x *xlsxWriter) writeRow(row []string) error {
+	cell, err := excelize.CoordinatesToCellName(1, x.rows+1)
+	if err != nil {
+		return err
+	}
+
+	values := make([]interface{}, len(row))
+	for i, value := range row {
+		values[i] = value
+	}
+
+	if err := x.sheet.SetRow(cell, values); err != nil {
+		return err
+	}
+
+	x.rows++
+	return nil
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+
+	// empty export still has a worksheet with the header
+	if x.sheet == nil {
+		if err := x.nextSheet(); err != nil {
+			return err
+		}
+	}
+
+	if err := x.sheet.Flush(); err != nil {
+		return fmt.Errorf("failed to flush sheet: %w", err)
+	}
+
+	return x.file.Write(x.w)
+}
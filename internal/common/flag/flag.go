@@ -20,6 +20,11 @@ type Job struct {
 	BucketName       string
 	FlagPublishAcuan bool
 	FileName         string
+
+	// StartDate, EndDate and ReportName are used by RunScheduledReports to backfill reports
+	StartDate  string
+	EndDate    string
+	ReportName string
 }
 
 type Client interface {
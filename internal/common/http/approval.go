@@ -0,0 +1,42 @@
+package http
+
+import (
+	"net/http"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ApprovalActor return the verified user who make the request, the client is used when the request
+// is not made by a verified user, see models.WithUser
+func ApprovalActor(c echo.Context) (actor, clientID string) {
+	clientID = c.Request().Header.Get(models.ClientIdHeader)
+
+	actor = models.GetUser(c.Request().Context())
+	if actor == "" {
+		actor = clientID
+	}
+
+	return actor, clientID
+}
+
+// SubmitApprovalResponse store the request as pending approval instead of executing the operation,
+// the operation is executed when another user approve it
+func SubmitApprovalResponse(c echo.Context, approvalSvc services.ApprovalService, operation models.ApprovalOperation, payload any, description string) error {
+	actor, clientID := ApprovalActor(c)
+
+	approval, err := approvalSvc.Submit(c.Request().Context(), models.SubmitApprovalRequest{
+		Operation:   operation,
+		Payload:     payload,
+		Description: description,
+		Maker:       actor,
+		ClientID:    clientID,
+	})
+	if err != nil {
+		return RestErrorResponse(c, http.StatusInternalServerError, err)
+	}
+
+	return RestSuccessResponse(c, http.StatusAccepted, approval.ToModelResponse())
+}
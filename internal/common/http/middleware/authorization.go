@@ -1,26 +1,97 @@
 package middleware
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	httpUtil "bitbucket.org/Amartha/go-fp-transaction/internal/common/http"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 
 	"github.com/labstack/echo/v4"
 )
 
+// InternalAuth authenticate the client by X-Client-Id and its api key in X-Secret-Key,
+// then authorize the route by the scopes of the client. X-Ngmis-Username is only verified for client which is trusted to assert it.
+// When no client is registered in config, the shared secret key is used and X-Client-Id is trusted as sent
 func (m *AppMiddleware) InternalAuth(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
-		secretKey := c.Request().Header.Get("X-Secret-Key")
+		secretKey := c.Request().Header.Get(models.SecretKeyHeader)
+		clientID := c.Request().Header.Get(models.ClientIdHeader)
 		statusCode := http.StatusUnauthorized
 		if secretKey == "" {
 			return httpUtil.RestErrorResponse(c, statusCode, fmt.Errorf("%s", "required secret key"))
 		}
 
-		if secretKey != m.conf.SecretKey {
+		if len(m.conf.Auth.Clients) == 0 {
+			if secretKey != m.conf.SecretKey {
+				return httpUtil.RestErrorResponse(c, statusCode, fmt.Errorf("%s", "invalid secret key"))
+			}
+
+			return next(withClientID(c, clientID))
+		}
+
+		if clientID == "" {
+			return httpUtil.RestErrorResponse(c, statusCode, fmt.Errorf("%s", "required client id"))
+		}
+
+		client, ok := m.conf.Auth.Clients[clientID]
+		if !ok || !isValidAPIKey(client.Keys, secretKey, time.Now()) {
 			return httpUtil.RestErrorResponse(c, statusCode, fmt.Errorf("%s", "invalid secret key"))
 		}
 
+		scope := requiredScope(c.Request().Method, c.Path())
+		if !hasScope(client.Scopes, scope) {
+			return httpUtil.RestErrorResponse(c, http.StatusForbidden, fmt.Errorf("client does not have scope %s", scope))
+		}
+
+		c = withClientID(c, clientID)
+		if client.TrustUserHeader {
+			c.SetRequest(c.Request().WithContext(models.WithUser(c.Request().Context(), c.Request().Header.Get(models.CtxKeyNgmisHeader))))
+		}
+
 		return next(c)
 	}
 }
+
+// withClientID put the authenticated client into request context,
+// the header is also set so handlers reading X-Client-Id get the same client
+func withClientID(c echo.Context, clientID string) echo.Context {
+	if clientID == "" {
+		return c
+	}
+
+	req := c.Request()
+	req.Header.Set(models.ClientIdHeader, clientID)
+	c.SetRequest(req.WithContext(models.WithClientID(req.Context(), clientID)))
+
+	return c
+}
+
+// isValidAPIKey compare the hash of api key with every key of the client which is valid at the time
+func isValidAPIKey(keys []config.APIKeyConfig, apiKey string, now time.Time) bool {
+	sum := sha256.Sum256([]byte(apiKey))
+	hash := []byte(hex.EncodeToString(sum[:]))
+
+	valid := false
+	for _, key := range keys {
+		if !key.ValidFrom.IsZero() && now.Before(key.ValidFrom) {
+			continue
+		}
+
+		if !key.ValidUntil.IsZero() && !now.Before(key.ValidUntil) {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare(hash, []byte(strings.ToLower(key.Hash))) == 1 {
+			valid = true
+		}
+	}
+
+	return valid
+}
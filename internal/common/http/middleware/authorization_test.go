@@ -0,0 +1,246 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAppMiddleware_InternalAuth(t *testing.T) {
+	now := time.Now()
+	authConfig := config.AuthConfig{
+		Clients: map[string]config.APIClientConfig{
+			"wallet-client": {
+				Scopes: []string{models.ScopeWalletWrite},
+				Keys: []config.APIKeyConfig{
+					{ID: "old", Hash: hashAPIKey("old-key"), ValidUntil: now.Add(time.Hour)},
+					{ID: "new", Hash: hashAPIKey("new-key"), ValidFrom: now.Add(-time.Hour)},
+					{ID: "expired", Hash: hashAPIKey("expired-key"), ValidUntil: now.Add(-time.Hour)},
+				},
+			},
+			"admin-client": {
+				Scopes: []string{models.ScopeAll},
+				Keys:   []config.APIKeyConfig{{ID: "admin", Hash: hashAPIKey("admin-key")}},
+			},
+			"backoffice": {
+				Scopes:          []string{models.ScopeAll},
+				Keys:            []config.APIKeyConfig{{ID: "backoffice", Hash: hashAPIKey("backoffice-key")}},
+				TrustUserHeader: true,
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		auth         config.AuthConfig
+		method       string
+		path         string
+		clientID     string
+		secretKey    string
+		username     string
+		wantStatus   int
+		wantClientID string
+		wantUser     string
+	}{
+		{
+			name:         "shared secret key",
+			method:       http.MethodPost,
+			path:         "/api/v1/wallet-transactions",
+			clientID:     "any-client",
+			secretKey:    "shared-secret",
+			username:     "finance.lead",
+			wantStatus:   http.StatusOK,
+			wantClientID: "any-client",
+		},
+		{
+			name:       "invalid shared secret key",
+			method:     http.MethodPost,
+			path:       "/api/v1/wallet-transactions",
+			secretKey:  "wrong-secret",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "required secret key",
+			auth:       authConfig,
+			method:     http.MethodPost,
+			path:       "/api/v1/wallet-transactions",
+			clientID:   "wallet-client",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "required client id",
+			auth:       authConfig,
+			method:     http.MethodPost,
+			path:       "/api/v1/wallet-transactions",
+			secretKey:  "new-key",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "unknown client",
+			auth:       authConfig,
+			method:     http.MethodPost,
+			path:       "/api/v1/wallet-transactions",
+			clientID:   "unknown-client",
+			secretKey:  "new-key",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "shared secret key is not accepted when clients are registered",
+			auth:       authConfig,
+			method:     http.MethodPost,
+			path:       "/api/v1/wallet-transactions",
+			clientID:   "wallet-client",
+			secretKey:  "shared-secret",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:         "new key during rotation",
+			auth:         authConfig,
+			method:       http.MethodPost,
+			path:         "/api/v1/wallet-transactions",
+			clientID:     "wallet-client",
+			secretKey:    "new-key",
+			wantStatus:   http.StatusOK,
+			wantClientID: "wallet-client",
+		},
+		{
+			name:         "old key during rotation",
+			auth:         authConfig,
+			method:       http.MethodPost,
+			path:         "/api/v1/wallet-transactions",
+			clientID:     "wallet-client",
+			secretKey:    "old-key",
+			wantStatus:   http.StatusOK,
+			wantClientID: "wallet-client",
+		},
+		{
+			name:       "expired key",
+			auth:       authConfig,
+			method:     http.MethodPost,
+			path:       "/api/v1/wallet-transactions",
+			clientID:   "wallet-client",
+			secretKey:  "expired-key",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "missing scope",
+			auth:       authConfig,
+			method:     http.MethodPatch,
+			path:       "/api/v1/accounts/:accountNumber",
+			clientID:   "wallet-client",
+			secretKey:  "new-key",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "route is not registered in scope table",
+			auth:       authConfig,
+			method:     http.MethodGet,
+			path:       "/api/v1/unknown",
+			clientID:   "wallet-client",
+			secretKey:  "new-key",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:         "client with all scopes",
+			auth:         authConfig,
+			method:       http.MethodPost,
+			path:         "/api/v1/money-flow-business-rules/:version/publish",
+			clientID:     "admin-client",
+			secretKey:    "admin-key",
+			username:     "finance.lead",
+			wantStatus:   http.StatusOK,
+			wantClientID: "admin-client",
+		},
+		{
+			name:         "user of trusted client",
+			auth:         authConfig,
+			method:       http.MethodPost,
+			path:         "/api/v1/approvals/:id/approve",
+			clientID:     "backoffice",
+			secretKey:    "backoffice-key",
+			username:     "finance.lead",
+			wantStatus:   http.StatusOK,
+			wantClientID: "backoffice",
+			wantUser:     "finance.lead",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMiddleware(config.Config{SecretKey: "shared-secret", Auth: tt.auth}, nil, nil)
+
+			e := echo.New()
+			req := httptest.NewRequest(tt.method, "/", nil)
+			if tt.clientID != "" {
+				req.Header.Set(models.ClientIdHeader, tt.clientID)
+			}
+			if tt.secretKey != "" {
+				req.Header.Set(models.SecretKeyHeader, tt.secretKey)
+			}
+			if tt.username != "" {
+				req.Header.Set(models.CtxKeyNgmisHeader, tt.username)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath(tt.path)
+
+			var gotClientID, gotUser string
+			err := m.InternalAuth(func(c echo.Context) error {
+				gotClientID = models.GetClientID(c.Request().Context())
+				gotUser = models.GetUser(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			})(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantClientID, gotClientID)
+			assert.Equal(t, tt.wantUser, gotUser)
+		})
+	}
+}
+
+func TestRequiredScope(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{http.MethodGet, "/api/v1/wallet-transactions/async/:id", models.ScopeWalletRead},
+		{http.MethodPost, "/api/v1/wallet-transactions", models.ScopeWalletWrite},
+		{http.MethodPost, "/api/v1/transaction/report", models.ScopeReportsRead},
+		{http.MethodPost, "/api/v1/transaction", models.ScopeTransactionsWrite},
+		{http.MethodGet, "/api/v1/transaction-metrics", models.ScopeReportsRead},
+		{http.MethodPost, "/api/v1/transaction-metrics", models.ScopeAll},
+		{http.MethodPatch, "/api/v1/money-flow-summaries/:summaryID/activation", models.ScopeMoneyFlowApprove},
+		{http.MethodPatch, "/api/v1/money-flow-summaries/:summaryID", models.ScopeMoneyFlowWrite},
+		{http.MethodPost, "/api/v1/money-flow-business-rules/:version/publish", models.ScopeMoneyFlowApprove},
+		{http.MethodDelete, "/api/v1/accounts/:accountNumber", models.ScopeAccountsAdmin},
+		{http.MethodGet, "/api/v1/fin-snapshot/collect", models.ScopeReportsRead},
+		{http.MethodPost, "/api/v2/files/upload", models.ScopeFilesWrite},
+		{http.MethodGet, "/api/v1/approvals/:id", models.ScopeApprovalsRead},
+		{http.MethodPost, "/api/v1/approvals/:id/comments", models.ScopeApprovalsWrite},
+		{http.MethodPost, "/api/v1/approvals/:id/approve", models.ScopeApprovalsApprove},
+		{http.MethodPost, "/api/v1/approvals/:id/reject", models.ScopeApprovalsApprove},
+		{http.MethodGet, "/api/admin/consumers/:name/lag", models.ScopeConsumersRead},
+		{http.MethodPost, "/api/admin/consumers/:name/reset-offsets", models.ScopeConsumersAdmin},
+		{http.MethodGet, "/api/v1/unknown", models.ScopeAll},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, requiredScope(tt.method, tt.path))
+		})
+	}
+}
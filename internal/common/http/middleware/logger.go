@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 	xlog "bitbucket.org/Amartha/go-x/log"
 	"golang.org/x/exp/slices"
 
@@ -126,6 +127,7 @@ func (m *AppMiddleware) Logger() echo.MiddlewareFunc {
 				xlog.String("response", string(resBodyBuff.Bytes())),
 				xlog.String("latency", latency.String()),
 				xlog.String("idempotency_key", req.Header.Get("x-idempotency-key")),
+				xlog.String("client_id", models.GetClientID(c.Request().Context())),
 			}
 
 			message := fmt.Sprintf("%v %v %v %v", res.Status, req.Method, req.URL.String(), latency)
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+)
+
+// routeScope is the scope required to call the routes under prefix,
+// read scope is required by GET requests and write scope by the others
+type routeScope struct {
+	method string
+	prefix string
+	read   string
+	write  string
+}
+
+// routeScopes is matched in order, so the specific rules have to be placed before the generic one.
+// Routes which are not registered here can only be called by clients with ScopeAll
+var routeScopes = []routeScope{
+	// approval of money flow
+	{method: http.MethodPost, prefix: "/api/v1/money-flow-business-rules/:version/publish", write: models.ScopeMoneyFlowApprove},
+	{method: http.MethodPatch, prefix: "/api/v1/money-flow-summaries/:summaryID/activation", write: models.ScopeMoneyFlowApprove},
+
+	// decision of maker-checker approval
+	{method: http.MethodPost, prefix: "/api/v1/approvals/:id/approve", write: models.ScopeApprovalsApprove},
+	{method: http.MethodPost, prefix: "/api/v1/approvals/:id/reject", write: models.ScopeApprovalsApprove},
+
+	// generating report does not change any transaction
+	{method: http.MethodPost, prefix: "/api/v1/transaction/report", write: models.ScopeReportsRead},
+
+	{prefix: "/api/v1/wallet-transactions", read: models.ScopeWalletRead, write: models.ScopeWalletWrite},
+	{prefix: "/api/v1/internal-wallets", read: models.ScopeWalletRead, write: models.ScopeWalletWrite},
+
+	{prefix: "/api/v1/transaction", read: models.ScopeTransactionsRead, write: models.ScopeTransactionsWrite},
+	{prefix: "/api/v1/transactions", read: models.ScopeTransactionsRead, write: models.ScopeTransactionsWrite},
+
+	{prefix: "/api/v1/report", read: models.ScopeReportsRead},
+	{prefix: "/api/v1/reports", read: models.ScopeReportsRead},
+	{prefix: "/api/v1/transaction-metrics", read: models.ScopeReportsRead},
+	{prefix: "/api/v1/tax-reports", read: models.ScopeReportsRead},
+	{prefix: "/api/v1/fin-snapshot", read: models.ScopeReportsRead},
+	{prefix: "/api/v1/exports", read: models.ScopeReportsRead, write: models.ScopeReportsRead},
+
+	{prefix: "/api/v1/accounts", read: models.ScopeAccountsRead, write: models.ScopeAccountsAdmin},
+	{prefix: "/api/v1/account-balances", read: models.ScopeAccountsRead, write: models.ScopeAccountsAdmin},
+
+	{prefix: "/api/v1/money-flow-summaries", read: models.ScopeMoneyFlowRead, write: models.ScopeMoneyFlowWrite},
+	{prefix: "/api/v1/money-flow-business-rules", read: models.ScopeMoneyFlowRead, write: models.ScopeMoneyFlowWrite},
+
+	{prefix: "/api/v1/order-types", read: models.ScopeMasterDataRead, write: models.ScopeMasterDataAdmin},
+	{prefix: "/api/v1/transaction-types", read: models.ScopeMasterDataRead, write: models.ScopeMasterDataAdmin},
+	{prefix: "/api/v1/vat-configs", read: models.ScopeMasterDataRead, write: models.ScopeMasterDataAdmin},
+	{prefix: "/api/v1/entities", read: models.ScopeMasterDataRead, write: models.ScopeMasterDataAdmin},
+	{prefix: "/api/v1/categories", read: models.ScopeMasterDataRead, write: models.ScopeMasterDataAdmin},
+	{prefix: "/api/v1/sub-categories", read: models.ScopeMasterDataRead, write: models.ScopeMasterDataAdmin},
+	{prefix: "/api/v1/tax-rates", read: models.ScopeMasterDataRead, write: models.ScopeMasterDataAdmin},
+
+	{prefix: "/api/v1/recon-exceptions", read: models.ScopeReconRead, write: models.ScopeReconWrite},
+
+	{prefix: "/api/v1/approvals", read: models.ScopeApprovalsRead, write: models.ScopeApprovalsWrite},
+
+	{prefix: "/api/v1/files", write: models.ScopeFilesWrite},
+	{prefix: "/api/v2/files", write: models.ScopeFilesWrite},
+
+	// admin endpoints of the consumer health server
+	{prefix: "/api/admin/consumers", read: models.ScopeConsumersRead, write: models.ScopeConsumersAdmin},
+}
+
+// requiredScope return the scope required to call the route, ScopeAll is returned if the route is not registered
+func requiredScope(method, path string) string {
+	for _, rs := range routeScopes {
+		if rs.method != "" && rs.method != method {
+			continue
+		}
+
+		if path != rs.prefix && !strings.HasPrefix(path, rs.prefix+"/") {
+			continue
+		}
+
+		scope := rs.write
+		if method == http.MethodGet {
+			scope = rs.read
+		}
+
+		if scope == "" {
+			return models.ScopeAll
+		}
+
+		return scope
+	}
+
+	return models.ScopeAll
+}
+
+func hasScope(scopes []string, scope string) bool {
+	return slices.Contains(scopes, models.ScopeAll) || slices.Contains(scopes, scope)
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/tracing"
+
+	"bitbucket.org/Amartha/go-x/log/ctxdata"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracing start the server span of the request, the trace context of the caller is taken from the traceparent header.
+// It must be used after Context so the span has the correlation id
+func (m *AppMiddleware) Tracing() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := otel.GetTextMapPropagator().Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+			route := c.Path()
+			ctx, span := tracing.Tracer().Start(ctx, fmt.Sprintf("%s %s", req.Method, route),
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					semconv.HTTPRoute(route),
+					attribute.String("correlation_id", ctxdata.GetCorrelationId(ctx)),
+				),
+			)
+			defer span.End()
+
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+			if err != nil {
+				span.RecordError(err)
+			}
+
+			// the returned error is written by the error handler of echo after this middleware
+			status := c.Response().Status
+			if he, ok := err.(*echo.HTTPError); ok {
+				status = he.Code
+			} else if err != nil && !c.Response().Committed {
+				status = http.StatusInternalServerError
+			}
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if err != nil || status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(status))
+			}
+
+			return err
+		}
+	}
+}
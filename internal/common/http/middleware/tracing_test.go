@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestAppMiddleware_Tracing(t *testing.T) {
+	// the global provider can not be restored once it is set, the other tests use no-op one
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	tests := []struct {
+		name        string
+		traceParent string
+		handler     echo.HandlerFunc
+		wantCode    int
+		wantStatus  codes.Code
+	}{
+		{
+			name:        "continue trace of the caller",
+			traceParent: "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+			handler: func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "client error is not error of the span",
+			handler: func(c echo.Context) error {
+				return c.NoContent(http.StatusBadRequest)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "returned http error",
+			handler: func(c echo.Context) error {
+				return echo.NewHTTPError(http.StatusServiceUnavailable)
+			},
+			wantCode:   http.StatusServiceUnavailable,
+			wantStatus: codes.Error,
+		},
+		{
+			name: "returned error",
+			handler: func(c echo.Context) error {
+				return errors.New("failed")
+			},
+			wantCode:   http.StatusInternalServerError,
+			wantStatus: codes.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var spanCtx trace.SpanContext
+
+			e := echo.New()
+			m := &AppMiddleware{}
+			e.GET("/api/v1/accounts/:accountNumber", func(c echo.Context) error {
+				spanCtx = trace.SpanContextFromContext(c.Request().Context())
+				return tt.handler(c)
+			}, m.Tracing())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/accounts/123", nil)
+			if tt.traceParent != "" {
+				req.Header.Set("traceparent", tt.traceParent)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			spans := recorder.Ended()
+			require.NotEmpty(t, spans)
+			span := spans[len(spans)-1]
+
+			assert.Equal(t, spanCtx, span.SpanContext())
+			assert.Equal(t, "GET /api/v1/accounts/:accountNumber", span.Name())
+			assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+			assert.Contains(t, span.Attributes(), semconv.HTTPResponseStatusCode(tt.wantCode))
+			assert.Equal(t, tt.wantStatus, span.Status().Code)
+			if tt.traceParent != "" {
+				assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.SpanContext().TraceID().String())
+				assert.Equal(t, "b7ad6b7169203331", span.Parent().SpanID().String())
+			} else {
+				assert.False(t, span.Parent().IsValid())
+			}
+		})
+	}
+}
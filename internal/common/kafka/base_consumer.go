@@ -4,53 +4,108 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	xlog "bitbucket.org/Amartha/go-x/log"
 
+	dlqpublisher "bitbucket.org/Amartha/go-fp-transaction/internal/common/dlq_publisher"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/graceful"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/messaging"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/metrics"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/retry"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
 
 	"github.com/Shopify/sarama"
 	"golang.org/x/sync/errgroup"
 )
 
+const defaultPauseCheckInterval = 10 * time.Second
+
 type BaseConsumer struct {
-	ctx             context.Context
-	clientID        string
-	cfg             config.Config
-	consumerCfg     config.ConsumerConfig
-	cg              sarama.ConsumerGroup
-	handler         sarama.ConsumerGroupHandler
-	metrics         metrics.Metrics
-	consumerMetrics *metrics.ConsumerMetrics
-	logPrefix       string
-	topic           string
-	consumerGroup   string
+	ctx                context.Context
+	clientID           string
+	cfg                config.Config
+	consumerCfg        config.ConsumerConfig
+	cg                 sarama.ConsumerGroup
+	handler            HandlerFunc
+	middlewares        []Middleware
+	dlq                dlqpublisher.Publisher
+	onDeadLetter       DeadLetterFunc
+	claimHandler       *claimHandler
+	metrics            metrics.Metrics
+	consumerMetrics    *metrics.ConsumerMetrics
+	logPrefix          string
+	topics             []string
+	consumerGroup      string
+	pauseWhen          func(ctx context.Context) bool
+	pauseCheckInterval time.Duration
+	newConsumerGroup   func(addrs []string, groupID string, config *sarama.Config) (sarama.ConsumerGroup, error)
+
+	mu             sync.Mutex
+	pausedManually bool
+	pausedByHook   bool
+	cancelSession  context.CancelFunc
+	pendingReset   map[string]map[int32]int64
 }
 
 type BaseConsumerConfig struct {
 	Ctx           context.Context
 	Config        config.Config
 	Metrics       metrics.Metrics
-	Handler       sarama.ConsumerGroupHandler
 	LogPrefix     string
-	Topic         string
+	Topics        []string
 	ConsumerGroup string
+
+	// Handler process the message, see HandlerFunc for how the returned error is handled
+	Handler HandlerFunc
+	// Middlewares run inside the in-process retry, so they are repeated on every attempt, e.g. Idempotency
+	Middlewares []Middleware
+	// DLQ receive the message which is still failed after the retries, failed message is only logged when it is nil
+	DLQ dlqpublisher.Publisher
+	// OnDeadLetter is called after the message is published to DLQ, it is not called when DLQ is nil
+	OnDeadLetter DeadLetterFunc
+
+	// PauseWhen is checked every PauseCheckInterval, the consumer stops fetching while it returns true
+	PauseWhen          func(ctx context.Context) bool
+	PauseCheckInterval time.Duration
+
+	// NewConsumerGroup create the consumer group client, sarama.NewConsumerGroup is used when it is nil.
+	// It is replaced by the in-memory broker of the end-to-end tests
+	NewConsumerGroup func(addrs []string, groupID string, config *sarama.Config) (sarama.ConsumerGroup, error)
 }
 
 func NewBaseConsumer(cfg BaseConsumerConfig) (*BaseConsumer, error) {
+	if cfg.Handler == nil {
+		return nil, errors.New("no handler given to process the message")
+	}
+
+	pauseCheckInterval := cfg.PauseCheckInterval
+	if pauseCheckInterval <= 0 {
+		pauseCheckInterval = defaultPauseCheckInterval
+	}
+
+	newConsumerGroup := cfg.NewConsumerGroup
+	if newConsumerGroup == nil {
+		newConsumerGroup = sarama.NewConsumerGroup
+	}
+
 	return &BaseConsumer{
-		ctx:           cfg.Ctx,
-		cfg:           cfg.Config,
-		consumerCfg:   cfg.Config.MessageBroker.KafkaConsumer,
-		handler:       cfg.Handler,
-		metrics:       cfg.Metrics,
-		logPrefix:     cfg.LogPrefix,
-		topic:         cfg.Topic,
-		consumerGroup: cfg.ConsumerGroup,
+		ctx:                cfg.Ctx,
+		cfg:                cfg.Config,
+		consumerCfg:        cfg.Config.MessageBroker.KafkaConsumer,
+		handler:            cfg.Handler,
+		middlewares:        cfg.Middlewares,
+		dlq:                cfg.DLQ,
+		onDeadLetter:       cfg.OnDeadLetter,
+		metrics:            cfg.Metrics,
+		logPrefix:          cfg.LogPrefix,
+		topics:             cfg.Topics,
+		consumerGroup:      cfg.ConsumerGroup,
+		pauseWhen:          cfg.PauseWhen,
+		pauseCheckInterval: pauseCheckInterval,
+		newConsumerGroup:   newConsumerGroup,
 	}, nil
 }
 
@@ -61,7 +116,7 @@ func (c *BaseConsumer) PreStart() error {
 		return fmt.Errorf("failed to create consumer config: %w", err)
 	}
 
-	if c.topic == "" {
+	if len(c.topics) == 0 || slices.Contains(c.topics, "") {
 		return errors.New("no topics given to be consumed, please set the topic")
 	}
 
@@ -75,8 +130,9 @@ func (c *BaseConsumer) PreStart() error {
 	}
 
 	c.clientID = saramaCfg.ClientID
+	c.claimHandler = c.newClaimHandler()
 
-	client, err := sarama.NewConsumerGroup(c.consumerCfg.Brokers, c.consumerGroup, saramaCfg)
+	client, err := c.newConsumerGroup(c.consumerCfg.Brokers, c.consumerGroup, saramaCfg)
 	if err != nil {
 		return err
 	}
@@ -85,10 +141,41 @@ func (c *BaseConsumer) PreStart() error {
 	return nil
 }
 
+// newClaimHandler build the middleware chain of the handler, the order is
+// correlation id, tracing, DLQ, logging, metrics, retry unless it is disabled, consumer middlewares and panic recovery
+func (c *BaseConsumer) newClaimHandler() *claimHandler {
+	handlerCfg := c.consumerCfg.Handlers[c.consumerGroup]
+
+	retryCfg := handlerCfg.Retry
+	if retryCfg.MaxRetries == 0 {
+		retryCfg = c.cfg.ExponentialBackoff
+	}
+
+	middlewares := []Middleware{CorrelationID(c.clientID), Tracing(c.consumerGroup)}
+	if c.dlq != nil {
+		middlewares = append(middlewares, DeadLetter(c.dlq, c.logPrefix, c.onDeadLetter))
+	}
+	middlewares = append(middlewares, Logging(c.logPrefix), Metrics(c.consumerMetrics))
+	if !handlerCfg.DisableRetry {
+		middlewares = append(middlewares, Retry(retry.NewExponentialBackOff(&retryCfg), c.logPrefix))
+	}
+	middlewares = append(middlewares, c.middlewares...)
+	middlewares = append(middlewares, Recover(c.logPrefix))
+
+	h := newClaimHandler(c.logPrefix, Chain(c.handler, middlewares...), handlerCfg.Workers, handlerCfg.QueueSize)
+	h.onSetup = c.applyPendingReset
+	h.onClaim = c.pauseClaimIfPaused
+	h.onUnacked = c.endSession
+
+	return h
+}
+
 func (c *BaseConsumer) Start() graceful.ProcessStarter {
 	return func() error {
 		err := c.PreStart()
 		if err != nil {
+			// the starters run in background, the error is logged so a consumer failed to start is not silently skipped
+			xlog.Error(c.ctx, c.logPrefix, xlog.Err(fmt.Errorf("failed to start consumer: %w", err)))
 			return err
 		}
 
@@ -100,9 +187,21 @@ func (c *BaseConsumer) Start() graceful.ProcessStarter {
 
 		eg, ctx := errgroup.WithContext(c.ctx)
 
+		if c.pauseWhen != nil {
+			go c.watchPause(ctx)
+		}
+
 		eg.Go(func() error {
 			for {
-				if err := c.cg.Consume(ctx, []string{c.topic}, c.handler); err != nil {
+				// every session has its own context, so ResetOffsets can end the session to rejoin the group
+				sessionCtx, cancel := context.WithCancel(ctx)
+				c.mu.Lock()
+				c.cancelSession = cancel
+				c.mu.Unlock()
+
+				err := c.cg.Consume(sessionCtx, c.topics, c.claimHandler)
+				cancel()
+				if err != nil {
 					xlog.Warn(c.ctx, c.logPrefix, xlog.Err(fmt.Errorf("error start consumer: %v", err)))
 				}
 				if err := c.ctx.Err(); err != nil {
@@ -117,9 +216,148 @@ func (c *BaseConsumer) Start() graceful.ProcessStarter {
 
 func (c *BaseConsumer) Stop() graceful.ProcessStopper {
 	return func(ctx context.Context) error {
+		// consumer failed to start has nothing to close
+		if c.cg == nil {
+			return nil
+		}
+
 		if err := c.cg.Close(); err != nil {
 			return err
 		}
 		return nil
 	}
 }
+
+// ConsumerGroup return the consumer group of the consumer
+func (c *BaseConsumer) ConsumerGroup() string {
+	return c.consumerGroup
+}
+
+// Topics return the topics consumed by the consumer
+func (c *BaseConsumer) Topics() []string {
+	return c.topics
+}
+
+// ResetOffsets move the consumer to offsets, keyed by topic and partition, by ending the current session.
+// The offsets are applied when the group is rejoined, only on partitions claimed by this process,
+// so every instance of the consumer group has to be reset to replay the whole topic
+func (c *BaseConsumer) ResetOffsets(offsets map[string]map[int32]int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cg == nil || c.cancelSession == nil {
+		return errors.New("consumer is not started")
+	}
+
+	c.pendingReset = offsets
+	c.cancelSession()
+
+	return nil
+}
+
+// endSession end the current session to rejoin the group, the claimed partitions are consumed again from the committed offset
+func (c *BaseConsumer) endSession() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.cancelSession != nil {
+		c.cancelSession()
+	}
+}
+
+// applyPendingReset is called on setup of the session, before the claimed partitions are consumed
+func (c *BaseConsumer) applyPendingReset(session sarama.ConsumerGroupSession) {
+	c.mu.Lock()
+	offsets := c.pendingReset
+	c.pendingReset = nil
+	c.mu.Unlock()
+
+	if len(offsets) == 0 {
+		return
+	}
+
+	for topic, partitions := range session.Claims() {
+		for _, partition := range partitions {
+			offset, ok := offsets[topic][partition]
+			if !ok {
+				continue
+			}
+
+			// ResetOffset only moves the offset backward and MarkOffset only moves it forward
+			session.ResetOffset(topic, partition, offset, "")
+			session.MarkOffset(topic, partition, offset, "")
+
+			xlog.Info(c.ctx, c.logPrefix,
+				xlog.String("status", "offset is reset"),
+				xlog.String("topic", topic),
+				xlog.Int32("partition", partition),
+				xlog.Int64("offset", offset),
+			)
+		}
+	}
+}
+
+// Pause stop fetching messages of every claimed partition until Resume is called,
+// messages which are already fetched are still processed
+func (c *BaseConsumer) Pause() {
+	c.setPaused(&c.pausedManually, true)
+}
+
+// Resume continue fetching messages paused by Pause
+func (c *BaseConsumer) Resume() {
+	c.setPaused(&c.pausedManually, false)
+}
+
+// IsPaused return true when the consumer is paused manually or by PauseWhen hook
+func (c *BaseConsumer) IsPaused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.pausedManually || c.pausedByHook
+}
+
+func (c *BaseConsumer) watchPause(ctx context.Context) {
+	ticker := time.NewTicker(c.pauseCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.setPaused(&c.pausedByHook, c.pauseWhen(ctx))
+		}
+	}
+}
+
+func (c *BaseConsumer) setPaused(flag *bool, paused bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wasPaused := c.pausedManually || c.pausedByHook
+	*flag = paused
+	isPaused := c.pausedManually || c.pausedByHook
+
+	if c.cg == nil || wasPaused == isPaused {
+		return
+	}
+
+	if isPaused {
+		c.cg.PauseAll()
+		xlog.Info(c.ctx, c.logPrefix, xlog.String("status", "consumer is paused"))
+		return
+	}
+
+	c.cg.ResumeAll()
+	xlog.Info(c.ctx, c.logPrefix, xlog.String("status", "consumer is resumed"))
+}
+
+// pauseClaimIfPaused pause partition claimed after the consumer is paused, e.g. after rebalance
+func (c *BaseConsumer) pauseClaimIfPaused(topic string, partition int32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pausedManually || c.pausedByHook {
+		c.cg.Pause(map[string][]int32{topic: {partition}})
+	}
+}
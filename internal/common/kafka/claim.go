@@ -0,0 +1,202 @@
+package kafka
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/Shopify/sarama"
+)
+
+const (
+	defaultWorkers   = 1
+	defaultQueueSize = 16
+)
+
+// claimHandler implement sarama.ConsumerGroupHandler on top of HandlerFunc.
+// Messages of a partition are dispatched to key ordered workers, the offset is only committed
+// up to the oldest message which is not finished yet, so a crash never skips unprocessed message
+type claimHandler struct {
+	logPrefix string
+	handle    HandlerFunc
+	workers   int
+	queueSize int
+
+	// onSetup is called when a new session is started, before any partition is consumed
+	onSetup func(session sarama.ConsumerGroupSession)
+	// onClaim is called when a partition is claimed, before its messages are consumed
+	onClaim func(topic string, partition int32)
+	// onUnacked is called when a message is left unacknowledged, it has to end the session
+	// so the partition is consumed again from the message instead of past it
+	onUnacked func()
+}
+
+func newClaimHandler(logPrefix string, handle HandlerFunc, workers, queueSize int) *claimHandler {
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+
+	return &claimHandler{
+		logPrefix: logPrefix,
+		handle:    handle,
+		workers:   workers,
+		queueSize: queueSize,
+	}
+}
+
+// Setup is run at the beginning of a new session, before ConsumeClaim
+func (h *claimHandler) Setup(session sarama.ConsumerGroupSession) error {
+	xlog.Info(session.Context(), h.logPrefix+"[SETUP]",
+		xlog.String("member_id", session.MemberID()),
+		xlog.Int32("generation_id", session.GenerationID()),
+	)
+
+	if h.onSetup != nil {
+		h.onSetup(session)
+	}
+
+	return nil
+}
+
+// Cleanup is run at the end of a session, once all ConsumeClaim goroutines have exited
+func (h *claimHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	xlog.Info(session.Context(), h.logPrefix+"[CLEANUP]", xlog.String("member_id", session.MemberID()))
+	return nil
+}
+
+// ConsumeClaim must start a consumer loop of ConsumerGroupClaim's Messages().
+// It blocks when every worker queue is full, so the consumer stops fetching until the workers catch up
+func (h *claimHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	ctx := session.Context()
+	offsets := newOffsetTracker(session)
+
+	if h.onClaim != nil {
+		h.onClaim(claim.Topic(), claim.Partition())
+	}
+
+	var wg sync.WaitGroup
+	queues := make([]chan *sarama.ConsumerMessage, h.workers)
+	for i := range queues {
+		queues[i] = make(chan *sarama.ConsumerMessage, h.queueSize)
+
+		wg.Add(1)
+		go func(queue <-chan *sarama.ConsumerMessage) {
+			defer wg.Done()
+			for msg := range queue {
+				h.process(ctx, offsets, msg)
+			}
+		}(queues[i])
+	}
+
+	defer func() {
+		for _, queue := range queues {
+			close(queue)
+		}
+		wg.Wait()
+	}()
+
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			offsets.track(msg)
+
+			select {
+			case queues[h.worker(msg)] <- msg:
+			case <-ctx.Done():
+				return nil
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (h *claimHandler) process(ctx context.Context, offsets *offsetTracker, msg *sarama.ConsumerMessage) {
+	// messages left in the queue after the session is ended are redelivered to the next owner of the partition
+	if ctx.Err() != nil {
+		return
+	}
+
+	err := h.handle(ctx, msg)
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	// the offset of the partition stops at the message, the session is ended so it is consumed again once the group is rejoined
+	if isUnacked(err) {
+		xlog.Error(ctx, h.logPrefix+"[UNACKED]", append(LogFields(msg), xlog.Err(err))...)
+		if h.onUnacked != nil {
+			h.onUnacked()
+		}
+		return
+	}
+
+	offsets.done(msg)
+}
+
+// worker return the worker of the message, messages with the same key always go to the same worker
+func (h *claimHandler) worker(msg *sarama.ConsumerMessage) int {
+	if h.workers == 1 {
+		return 0
+	}
+
+	if len(msg.Key) == 0 {
+		return int(msg.Offset % int64(h.workers))
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write(msg.Key)
+	return int(hash.Sum32() % uint32(h.workers))
+}
+
+// offsetTracker mark the message only when every older message of the partition is done
+type offsetTracker struct {
+	session sarama.ConsumerGroupSession
+
+	mu       sync.Mutex
+	pending  []*sarama.ConsumerMessage
+	finished map[int64]bool
+}
+
+func newOffsetTracker(session sarama.ConsumerGroupSession) *offsetTracker {
+	return &offsetTracker{
+		session:  session,
+		finished: map[int64]bool{},
+	}
+}
+
+// track register the message in the order it is consumed
+func (t *offsetTracker) track(msg *sarama.ConsumerMessage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pending = append(t.pending, msg)
+}
+
+func (t *offsetTracker) done(msg *sarama.ConsumerMessage) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.finished[msg.Offset] = true
+
+	var last *sarama.ConsumerMessage
+	for len(t.pending) > 0 && t.finished[t.pending[0].Offset] {
+		last = t.pending[0]
+		delete(t.finished, last.Offset)
+		t.pending = t.pending[1:]
+	}
+
+	if last != nil {
+		t.session.MarkMessage(last, "")
+	}
+}
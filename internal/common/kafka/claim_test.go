@@ -0,0 +1,294 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	kafkaMock "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/kafka/mock"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_offsetTracker(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	session := kafkaMock.NewMockConsumerGroupSession(mockCtrl)
+
+	msgs := []*sarama.ConsumerMessage{{Offset: 10}, {Offset: 11}, {Offset: 12}}
+
+	tracker := newOffsetTracker(session)
+	for _, msg := range msgs {
+		tracker.track(msg)
+	}
+
+	// offset 11 is not marked while offset 10 is still processed
+	tracker.done(msgs[1])
+
+	gomock.InOrder(
+		session.EXPECT().MarkMessage(msgs[1], ""),
+		session.EXPECT().MarkMessage(msgs[2], ""),
+	)
+	tracker.done(msgs[0])
+	tracker.done(msgs[2])
+}
+
+func Test_claimHandler_worker(t *testing.T) {
+	h := newClaimHandler("[TEST]", nil, 4, 0)
+
+	assert.Equal(t, defaultQueueSize, h.queueSize)
+	assert.Equal(t, h.worker(&sarama.ConsumerMessage{Key: []byte("key"), Offset: 1}), h.worker(&sarama.ConsumerMessage{Key: []byte("key"), Offset: 2}))
+	assert.Equal(t, 1, h.worker(&sarama.ConsumerMessage{Offset: 5}))
+	assert.Equal(t, 0, newClaimHandler("[TEST]", nil, 0, 0).worker(&sarama.ConsumerMessage{Key: []byte("key")}))
+}
+
+func Test_claimHandler_ConsumeClaim(t *testing.T) {
+	t.Run("process messages of the same key in order and mark every message", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		session := kafkaMock.NewMockConsumerGroupSession(mockCtrl)
+		claim := kafkaMock.NewMockConsumerGroupClaim(mockCtrl)
+
+		messages := make(chan *sarama.ConsumerMessage, 4)
+		for i, key := range []string{"a", "b", "a", "b"} {
+			messages <- &sarama.ConsumerMessage{Key: []byte(key), Offset: int64(i), Value: []byte(key)}
+		}
+		close(messages)
+
+		var (
+			mu       sync.Mutex
+			order    = map[string][]int64{}
+			marked   []int64
+			claimed  string
+			claimedP int32
+		)
+
+		session.EXPECT().Context().Return(context.Background()).AnyTimes()
+		session.EXPECT().MarkMessage(gomock.Any(), "").Do(func(msg *sarama.ConsumerMessage, _ string) {
+			mu.Lock()
+			defer mu.Unlock()
+			marked = append(marked, msg.Offset)
+		}).AnyTimes()
+		claim.EXPECT().Messages().Return(messages).AnyTimes()
+		claim.EXPECT().Topic().Return("topic")
+		claim.EXPECT().Partition().Return(int32(1))
+
+		h := newClaimHandler("[TEST]", func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			mu.Lock()
+			defer mu.Unlock()
+			order[string(msg.Key)] = append(order[string(msg.Key)], msg.Offset)
+			return nil
+		}, 2, 1)
+		h.onClaim = func(topic string, partition int32) {
+			claimed, claimedP = topic, partition
+		}
+
+		assert.NoError(t, h.ConsumeClaim(session, claim))
+		assert.Equal(t, map[string][]int64{"a": {0, 2}, "b": {1, 3}}, order)
+		assert.Equal(t, int64(3), marked[len(marked)-1])
+		assert.Equal(t, "topic", claimed)
+		assert.Equal(t, int32(1), claimedP)
+	})
+
+	t.Run("unacknowledged message ends the session before the next message", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		session := kafkaMock.NewMockConsumerGroupSession(mockCtrl)
+		claim := kafkaMock.NewMockConsumerGroupClaim(mockCtrl)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		messages := make(chan *sarama.ConsumerMessage, 2)
+		messages <- &sarama.ConsumerMessage{Offset: 1}
+		messages <- &sarama.ConsumerMessage{Offset: 2}
+
+		// no message is marked
+		session.EXPECT().Context().Return(ctx).AnyTimes()
+		claim.EXPECT().Messages().Return(messages).AnyTimes()
+
+		var handled []int64
+		h := newClaimHandler("[TEST]", func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			handled = append(handled, msg.Offset)
+			if msg.Offset == 1 {
+				return unacked(assert.AnError)
+			}
+			return nil
+		}, 1, 1)
+		h.onUnacked = cancel
+
+		assert.NoError(t, h.ConsumeClaim(session, claim))
+		assert.Equal(t, []int64{1}, handled)
+	})
+
+	t.Run("failed message of ended session is not marked", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		session := kafkaMock.NewMockConsumerGroupSession(mockCtrl)
+		claim := kafkaMock.NewMockConsumerGroupClaim(mockCtrl)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		messages := make(chan *sarama.ConsumerMessage, 1)
+		messages <- &sarama.ConsumerMessage{Offset: 1}
+
+		session.EXPECT().Context().Return(ctx).AnyTimes()
+		claim.EXPECT().Messages().Return(messages).AnyTimes()
+
+		h := newClaimHandler("[TEST]", func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			cancel()
+			return ctx.Err()
+		}, 1, 1)
+
+		assert.NoError(t, h.ConsumeClaim(session, claim))
+	})
+}
+
+func Test_BaseConsumer(t *testing.T) {
+	handler := func(ctx context.Context, msg *sarama.ConsumerMessage) error { return nil }
+
+	t.Run("failed new consumer without handler", func(t *testing.T) {
+		_, err := NewBaseConsumer(BaseConsumerConfig{})
+		assert.Error(t, err)
+	})
+
+	t.Run("failed start without topic", func(t *testing.T) {
+		c, err := NewBaseConsumer(BaseConsumerConfig{
+			Ctx:           context.Background(),
+			Config:        config.Config{MessageBroker: config.MessageBroker{KafkaConsumer: config.ConsumerConfig{Brokers: []string{"localhost:9092"}}}},
+			Topics:        []string{""},
+			ConsumerGroup: "group",
+			Handler:       handler,
+		})
+		assert.NoError(t, err)
+		assert.Error(t, c.Start()())
+	})
+
+	t.Run("failed message is not retried when retry is disabled", func(t *testing.T) {
+		var attempts int
+		c, err := NewBaseConsumer(BaseConsumerConfig{
+			Ctx: context.Background(),
+			Config: config.Config{MessageBroker: config.MessageBroker{KafkaConsumer: config.ConsumerConfig{
+				Handlers: map[string]config.ConsumerHandlerConfig{"group": {DisableRetry: true}},
+			}}},
+			ConsumerGroup: "group",
+			Handler: func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+				attempts++
+				return assert.AnError
+			},
+		})
+		assert.NoError(t, err)
+
+		err = c.newClaimHandler().handle(context.Background(), &sarama.ConsumerMessage{})
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("pause and resume", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		cg := kafkaMock.NewMockConsumerGroup(mockCtrl)
+
+		c, err := NewBaseConsumer(BaseConsumerConfig{Ctx: context.Background(), Handler: handler})
+		assert.NoError(t, err)
+		c.cg = cg
+
+		cg.EXPECT().PauseAll()
+		c.Pause()
+		c.Pause()
+		assert.True(t, c.IsPaused())
+
+		cg.EXPECT().Pause(map[string][]int32{"topic": {1}})
+		c.pauseClaimIfPaused("topic", 1)
+
+		cg.EXPECT().ResumeAll()
+		c.Resume()
+		assert.False(t, c.IsPaused())
+
+		c.pauseClaimIfPaused("topic", 1)
+	})
+
+	t.Run("pause when hook return true", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		cg := kafkaMock.NewMockConsumerGroup(mockCtrl)
+
+		var (
+			mu     sync.Mutex
+			paused = true
+		)
+		c, err := NewBaseConsumer(BaseConsumerConfig{
+			Ctx:     context.Background(),
+			Handler: handler,
+			PauseWhen: func(ctx context.Context) bool {
+				mu.Lock()
+				defer mu.Unlock()
+				return paused
+			},
+			PauseCheckInterval: 10 * time.Millisecond,
+		})
+		assert.NoError(t, err)
+		c.cg = cg
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		cg.EXPECT().PauseAll()
+		go c.watchPause(ctx)
+		assert.Eventually(t, c.IsPaused, time.Second, 5*time.Millisecond)
+
+		cg.EXPECT().ResumeAll()
+		mu.Lock()
+		paused = false
+		mu.Unlock()
+		assert.Eventually(t, func() bool { return !c.IsPaused() }, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("failed reset offsets of consumer not started", func(t *testing.T) {
+		c, err := NewBaseConsumer(BaseConsumerConfig{Ctx: context.Background(), Handler: handler})
+		assert.NoError(t, err)
+		assert.Error(t, c.ResetOffsets(map[string]map[int32]int64{"topic": {0: 1}}))
+	})
+
+	t.Run("reset offsets on claimed partitions of the next session", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		cg := kafkaMock.NewMockConsumerGroup(mockCtrl)
+		session := kafkaMock.NewMockConsumerGroupSession(mockCtrl)
+
+		c, err := NewBaseConsumer(BaseConsumerConfig{Ctx: context.Background(), Handler: handler})
+		assert.NoError(t, err)
+		c.cg = cg
+
+		sessionCtx, cancel := context.WithCancel(context.Background())
+		c.cancelSession = cancel
+
+		assert.NoError(t, c.ResetOffsets(map[string]map[int32]int64{"topic": {0: 5, 2: 7}}))
+		assert.Error(t, sessionCtx.Err())
+
+		// partition 1 has no target and partition 2 is claimed by another member
+		session.EXPECT().Claims().Return(map[string][]int32{"topic": {0, 1}})
+		session.EXPECT().ResetOffset("topic", int32(0), int64(5), "")
+		session.EXPECT().MarkOffset("topic", int32(0), int64(5), "")
+		c.applyPendingReset(session)
+
+		// the reset is only applied once
+		c.applyPendingReset(session)
+	})
+
+	t.Run("stop consumer not started", func(t *testing.T) {
+		c, err := NewBaseConsumer(BaseConsumerConfig{Ctx: context.Background(), Handler: handler})
+		assert.NoError(t, err)
+		assert.NoError(t, c.Stop()(context.Background()))
+	})
+
+	t.Run("stop consumer", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		cg := kafkaMock.NewMockConsumerGroup(mockCtrl)
+
+		c, err := NewBaseConsumer(BaseConsumerConfig{Ctx: context.Background(), Handler: handler})
+		assert.NoError(t, err)
+		c.cg = cg
+
+		cg.EXPECT().Close().Return(assert.AnError)
+		assert.Error(t, c.Stop()(context.Background()))
+	})
+}
@@ -0,0 +1,131 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/Shopify/sarama"
+)
+
+// HandlerFunc process a consumed message. Returned error is retried in-process and then sent to DLQ,
+// except error wrapped by Skip which is acknowledged as is and error wrapped by Permanent which is not retried
+type HandlerFunc func(ctx context.Context, msg *sarama.ConsumerMessage) error
+
+// Middleware wrap HandlerFunc with behaviour shared by consumers, e.g. idempotency
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Chain wrap h with the middlewares, the first middleware is the outermost
+func Chain(h HandlerFunc, middlewares ...Middleware) HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+
+	return h
+}
+
+// Decoder decode value of the message to the payload of the handler
+type Decoder[T any] func(value []byte) (T, error)
+
+// JSONDecoder decode value of the message as json
+func JSONDecoder[T any](value []byte) (T, error) {
+	var payload T
+	if err := json.Unmarshal(value, &payload); err != nil {
+		return payload, fmt.Errorf("error unmarshal json: %w", err)
+	}
+
+	return payload, nil
+}
+
+// Typed create HandlerFunc of typed payload, message which can not be decoded is sent to DLQ without retry
+func Typed[T any](decode Decoder[T], fn func(ctx context.Context, msg *sarama.ConsumerMessage, payload T) error) HandlerFunc {
+	return func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		payload, err := decode(msg.Value)
+		if err != nil {
+			return Permanent(err)
+		}
+
+		return fn(ctx, msg, payload)
+	}
+}
+
+type skipError struct {
+	err error
+}
+
+func (e skipError) Error() string { return e.err.Error() }
+
+func (e skipError) Unwrap() error { return e.err }
+
+// Skip mark err as expected, the message is acknowledged without retry and without being sent to DLQ
+func Skip(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return skipError{err: err}
+}
+
+// IsSkip return true when err is wrapped by Skip
+func IsSkip(err error) bool {
+	var e skipError
+	return errors.As(err, &e)
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent mark err as not retryable, the message is sent to DLQ immediately
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return permanentError{err: err}
+}
+
+// IsPermanent return true when err is wrapped by Permanent
+func IsPermanent(err error) bool {
+	var e permanentError
+	return errors.As(err, &e)
+}
+
+type unackedError struct {
+	err error
+}
+
+func (e unackedError) Error() string { return e.err.Error() }
+
+func (e unackedError) Unwrap() error { return e.err }
+
+// unacked mark err as not handled at all, e.g. the message could not be sent to DLQ,
+// the offset of the message is not marked and the session is ended so it is redelivered
+func unacked(err error) error {
+	return unackedError{err: err}
+}
+
+// isUnacked return true when err is wrapped by unacked
+func isUnacked(err error) bool {
+	var e unackedError
+	return errors.As(err, &e)
+}
+
+// LogFields return the common log fields of the message
+func LogFields(msg *sarama.ConsumerMessage) []xlog.Field {
+	return []xlog.Field{
+		xlog.Time("timestamp", msg.Timestamp),
+		xlog.String("topic", msg.Topic),
+		xlog.String("key", string(msg.Key)),
+		xlog.Int32("partition", msg.Partition),
+		xlog.Int64("offset", msg.Offset),
+		xlog.String("message-claimed", string(msg.Value)),
+	}
+}
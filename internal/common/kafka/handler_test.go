@@ -0,0 +1,434 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	dlqMock "bitbucket.org/Amartha/go-fp-transaction/internal/common/dlq_publisher/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/retry"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	repositoryMock "bitbucket.org/Amartha/go-fp-transaction/internal/repositories/mock"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+	"bitbucket.org/Amartha/go-x/log/ctxdata"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/mock/gomock"
+)
+
+func init() {
+	xlog.InitForTest()
+}
+
+type testPayload struct {
+	ID string `json:"id"`
+}
+
+func Test_Chain(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+				calls = append(calls, name)
+				return next(ctx, msg)
+			}
+		}
+	}
+
+	h := Chain(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		calls = append(calls, "handler")
+		return nil
+	}, record("first"), record("second"))
+
+	assert.NoError(t, h(context.Background(), &sarama.ConsumerMessage{}))
+	assert.Equal(t, []string{"first", "second", "handler"}, calls)
+}
+
+func Test_Typed(t *testing.T) {
+	t.Run("success decode payload", func(t *testing.T) {
+		var got testPayload
+		h := Typed(JSONDecoder[testPayload], func(ctx context.Context, msg *sarama.ConsumerMessage, payload testPayload) error {
+			got = payload
+			return nil
+		})
+
+		assert.NoError(t, h(context.Background(), &sarama.ConsumerMessage{Value: []byte(`{"id":"1"}`)}))
+		assert.Equal(t, testPayload{ID: "1"}, got)
+	})
+
+	t.Run("failed decode payload is permanent", func(t *testing.T) {
+		h := Typed(JSONDecoder[testPayload], func(ctx context.Context, msg *sarama.ConsumerMessage, payload testPayload) error {
+			t.Fatal("handler must not be called")
+			return nil
+		})
+
+		err := h(context.Background(), &sarama.ConsumerMessage{Value: []byte(`{`)})
+		assert.Error(t, err)
+		assert.True(t, IsPermanent(err))
+	})
+}
+
+func Test_SkipPermanent(t *testing.T) {
+	assert.NoError(t, Skip(nil))
+	assert.NoError(t, Permanent(nil))
+
+	err := Skip(assert.AnError)
+	assert.True(t, IsSkip(err))
+	assert.False(t, IsPermanent(err))
+	assert.ErrorIs(t, err, assert.AnError)
+
+	err = Permanent(assert.AnError)
+	assert.True(t, IsPermanent(err))
+	assert.False(t, IsSkip(err))
+	assert.ErrorIs(t, err, assert.AnError)
+
+	// the mark is kept when the error is wrapped by the handler
+	assert.True(t, IsSkip(errors.Join(errors.New("wrapped"), Skip(assert.AnError))))
+}
+
+func Test_CorrelationID(t *testing.T) {
+	tests := []struct {
+		name         string
+		headers      []*sarama.RecordHeader
+		want         string
+		wantClientID string
+	}{
+		{
+			name:    "use correlation id of the header",
+			headers: []*sarama.RecordHeader{{Key: []byte(CorrelationIDHeader), Value: []byte("correlation-id")}},
+			want:    "correlation-id",
+		},
+		{
+			name: "use client id of the header",
+			headers: []*sarama.RecordHeader{
+				{Key: []byte(CorrelationIDHeader), Value: []byte("correlation-id")},
+				{Key: []byte(models.ClientIdHeader), Value: []byte("async-client")},
+			},
+			want:         "correlation-id",
+			wantClientID: "async-client",
+		},
+		{
+			name: "generate correlation id",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got, gotClientID string
+			h := CorrelationID("client-id")(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+				got = ctxdata.GetCorrelationId(ctx)
+				gotClientID = models.GetClientID(ctx)
+				return nil
+			})
+
+			assert.NoError(t, h(context.Background(), &sarama.ConsumerMessage{Headers: tt.headers}))
+			assert.Equal(t, tt.wantClientID, gotClientID)
+			if tt.want != "" {
+				assert.Equal(t, tt.want, got)
+			} else {
+				assert.NotEmpty(t, got)
+			}
+		})
+	}
+}
+
+func Test_Tracing(t *testing.T) {
+	// the global provider can not be restored once it is set, the other tests use no-op one
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	traceParent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	msg := &sarama.ConsumerMessage{
+		Topic:   "topic",
+		Headers: []*sarama.RecordHeader{{Key: []byte("traceparent"), Value: []byte(traceParent)}},
+	}
+
+	tests := []struct {
+		name       string
+		handlerErr error
+		wantStatus codes.Code
+	}{
+		{name: "success"},
+		{name: "skipped message is not error", handlerErr: Skip(assert.AnError)},
+		{name: "failed message", handlerErr: assert.AnError, wantStatus: codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var spanCtx trace.SpanContext
+			h := Tracing("group")(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+				spanCtx = trace.SpanContextFromContext(ctx)
+				return tt.handlerErr
+			})
+
+			err := h(context.Background(), msg)
+			assert.Equal(t, tt.handlerErr, err)
+
+			spans := recorder.Ended()
+			span := spans[len(spans)-1]
+			assert.Equal(t, spanCtx, span.SpanContext())
+			assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.SpanContext().TraceID().String())
+			assert.Equal(t, "b7ad6b7169203331", span.Parent().SpanID().String())
+			assert.Equal(t, tt.wantStatus, span.Status().Code)
+		})
+	}
+}
+
+func Test_DeadLetter(t *testing.T) {
+	msg := &sarama.ConsumerMessage{Value: []byte(`{"id":"1"}`), Timestamp: time.Unix(1700000000, 0)}
+
+	tests := []struct {
+		name          string
+		ctx           func() context.Context
+		handleErr     error
+		doMock        func(dlq *dlqMock.MockPublisher)
+		wantPublished bool
+		wantUnacked   bool
+	}{
+		{
+			name: "success message is not published",
+		},
+		{
+			name:      "skipped message is not published",
+			handleErr: Skip(assert.AnError),
+		},
+		{
+			name:      "failed message is published",
+			handleErr: assert.AnError,
+			doMock: func(dlq *dlqMock.MockPublisher) {
+				dlq.EXPECT().Publish(models.FailedMessage{
+					Payload:    msg.Value,
+					Timestamp:  msg.Timestamp,
+					CauseError: assert.AnError,
+					Error:      assert.AnError.Error(),
+				}).Return(nil)
+			},
+			wantPublished: true,
+		},
+		{
+			name:      "failed publish leave the message unacknowledged",
+			handleErr: assert.AnError,
+			doMock: func(dlq *dlqMock.MockPublisher) {
+				dlq.EXPECT().Publish(gomock.Any()).Return(errors.New("publish error"))
+			},
+			wantUnacked: true,
+		},
+		{
+			name: "message of ended session is not published",
+			ctx: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+			handleErr: context.Canceled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			dlq := dlqMock.NewMockPublisher(mockCtrl)
+			if tt.doMock != nil {
+				tt.doMock(dlq)
+			}
+
+			ctx := context.Background()
+			if tt.ctx != nil {
+				ctx = tt.ctx()
+			}
+
+			var published bool
+			onPublished := func(ctx context.Context, m *sarama.ConsumerMessage, cause error) {
+				published = true
+				assert.Equal(t, msg, m)
+				assert.Equal(t, tt.handleErr, cause)
+			}
+
+			h := DeadLetter(dlq, "[TEST]", onPublished)(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+				return tt.handleErr
+			})
+
+			err := h(ctx, msg)
+			assert.ErrorIs(t, err, tt.handleErr)
+			assert.Equal(t, tt.wantUnacked, isUnacked(err))
+			assert.Equal(t, tt.wantPublished, published)
+		})
+	}
+}
+
+func Test_Retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		errs         []error
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "success after retry",
+			errs:         []error{assert.AnError, nil},
+			wantAttempts: 2,
+		},
+		{
+			name:         "failed after max retries",
+			errs:         []error{assert.AnError, assert.AnError},
+			wantAttempts: 2,
+			wantErr:      true,
+		},
+		{
+			name:         "permanent error is not retried",
+			errs:         []error{Permanent(assert.AnError)},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "skipped error is not retried",
+			errs:         []error{Skip(assert.AnError)},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var attempts int
+			retryer := retry.NewExponentialBackOff(&config.ExponentialBackOffConfig{MaxRetries: 1})
+			h := Retry(retryer, "[TEST]")(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			})
+
+			err := h(context.Background(), &sarama.ConsumerMessage{})
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantAttempts, attempts)
+			if tt.wantErr {
+				assert.Equal(t, tt.errs[len(tt.errs)-1], err)
+			}
+		})
+	}
+}
+
+func Test_Idempotency(t *testing.T) {
+	const key = "idempotency-key"
+
+	keyFunc := func(msg *sarama.ConsumerMessage) (string, error) {
+		if len(msg.Key) == 0 {
+			return "", errors.New("key is empty")
+		}
+		return string(msg.Key), nil
+	}
+
+	tests := []struct {
+		name          string
+		msg           *sarama.ConsumerMessage
+		handleErr     error
+		doMock        func(store *repositoryMock.MockCacheRepository)
+		wantCalled    bool
+		wantSkip      bool
+		wantPermanent bool
+		wantErr       bool
+	}{
+		{
+			name: "success process message",
+			msg:  &sarama.ConsumerMessage{Key: []byte(key)},
+			doMock: func(store *repositoryMock.MockCacheRepository) {
+				store.EXPECT().SetIfNotExists(gomock.Any(), key, gomock.Any(), time.Minute).Return(true, nil)
+			},
+			wantCalled: true,
+		},
+		{
+			name: "skip message being processed",
+			msg:  &sarama.ConsumerMessage{Key: []byte(key)},
+			doMock: func(store *repositoryMock.MockCacheRepository) {
+				store.EXPECT().SetIfNotExists(gomock.Any(), key, gomock.Any(), time.Minute).Return(false, nil)
+			},
+			wantSkip: true,
+			wantErr:  true,
+		},
+		{
+			name:      "release key of failed message",
+			msg:       &sarama.ConsumerMessage{Key: []byte(key)},
+			handleErr: assert.AnError,
+			doMock: func(store *repositoryMock.MockCacheRepository) {
+				store.EXPECT().SetIfNotExists(gomock.Any(), key, gomock.Any(), time.Minute).Return(true, nil)
+				store.EXPECT().Del(gomock.Any(), key).Return(errors.New("redis error"))
+			},
+			wantCalled: true,
+			wantErr:    true,
+		},
+		{
+			name: "failed check idempotency",
+			msg:  &sarama.ConsumerMessage{Key: []byte(key)},
+			doMock: func(store *repositoryMock.MockCacheRepository) {
+				store.EXPECT().SetIfNotExists(gomock.Any(), key, gomock.Any(), time.Minute).Return(false, assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name:          "failed get idempotency key",
+			msg:           &sarama.ConsumerMessage{},
+			wantPermanent: true,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			store := repositoryMock.NewMockCacheRepository(mockCtrl)
+			if tt.doMock != nil {
+				tt.doMock(store)
+			}
+
+			var called bool
+			h := Idempotency(store, keyFunc, time.Minute, "[TEST]")(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+				called = true
+				return tt.handleErr
+			})
+
+			err := h(context.Background(), tt.msg)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantCalled, called)
+			assert.Equal(t, tt.wantSkip, IsSkip(err))
+			assert.Equal(t, tt.wantPermanent, IsPermanent(err))
+			if tt.wantSkip {
+				assert.ErrorIs(t, err, common.ErrRequestBeingProcessed)
+			}
+		})
+	}
+}
+
+func Test_Recover(t *testing.T) {
+	h := Recover("[TEST]")(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		var payload *testPayload
+		_ = payload.ID
+		return nil
+	})
+
+	err := h(context.Background(), &sarama.ConsumerMessage{})
+	assert.Error(t, err)
+	assert.True(t, IsPermanent(err))
+}
+
+func Test_Metrics_Nil(t *testing.T) {
+	h := Metrics(nil)(func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+		return assert.AnError
+	})
+
+	assert.Equal(t, assert.AnError, h(context.Background(), &sarama.ConsumerMessage{}))
+}
@@ -0,0 +1,161 @@
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+// PartitionLag is the progress of a consumer group on a partition
+type PartitionLag struct {
+	Topic     string `json:"topic"`
+	Partition int32  `json:"partition"`
+	Committed int64  `json:"committed"`
+	Newest    int64  `json:"newest"`
+	Lag       int64  `json:"lag"`
+}
+
+// OffsetReader read offsets of the topics and consumer groups from the kafka cluster
+type OffsetReader interface {
+	GroupLag(group string, topics []string) ([]PartitionLag, error)
+	OffsetsForTime(topics []string, at time.Time) (map[string]map[int32]int64, error)
+}
+
+type clusterOffsetReader struct {
+	client sarama.Client
+	admin  sarama.ClusterAdmin
+}
+
+func NewOffsetReader(client sarama.Client, admin sarama.ClusterAdmin) OffsetReader {
+	return &clusterOffsetReader{client: client, admin: admin}
+}
+
+func (r *clusterOffsetReader) GroupLag(group string, topics []string) ([]PartitionLag, error) {
+	return GroupLag(r.client, r.admin, group, topics)
+}
+
+func (r *clusterOffsetReader) OffsetsForTime(topics []string, at time.Time) (map[string]map[int32]int64, error) {
+	return OffsetsForTime(r.client, topics, at)
+}
+
+// GroupLag return the lag of the group on every partition of topics.
+// Partition without committed offset is counted from the oldest retained message, as the group starts from it
+func GroupLag(client sarama.Client, admin sarama.ClusterAdmin, group string, topics []string) ([]PartitionLag, error) {
+	topicPartitions, err := partitionsOf(client, topics)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := admin.ListConsumerGroupOffsets(group, topicPartitions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get committed offsets: %w", err)
+	}
+	if res.Err != sarama.ErrNoError {
+		return nil, fmt.Errorf("failed to get committed offsets: %w", res.Err)
+	}
+
+	var lags []PartitionLag
+	for _, topic := range topics {
+		for _, partition := range topicPartitions[topic] {
+			newest, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get newest offset of %s/%d: %w", topic, partition, err)
+			}
+
+			committed := int64(-1)
+			if block := res.GetBlock(topic, partition); block != nil {
+				committed = block.Offset
+			}
+
+			if committed < 0 {
+				committed, err = client.GetOffset(topic, partition, sarama.OffsetOldest)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get oldest offset of %s/%d: %w", topic, partition, err)
+				}
+			}
+
+			lags = append(lags, PartitionLag{
+				Topic:     topic,
+				Partition: partition,
+				Committed: committed,
+				Newest:    newest,
+				Lag:       max(newest-committed, 0),
+			})
+		}
+	}
+
+	return lags, nil
+}
+
+// OffsetsForTime return the offset of the first message produced at or after at on every partition of topics.
+// Partition without such message is given its newest offset, so nothing is replayed on it
+func OffsetsForTime(client sarama.Client, topics []string, at time.Time) (map[string]map[int32]int64, error) {
+	topicPartitions, err := partitionsOf(client, topics)
+	if err != nil {
+		return nil, err
+	}
+
+	offsets := make(map[string]map[int32]int64, len(topicPartitions))
+	for topic, partitions := range topicPartitions {
+		offsets[topic] = make(map[int32]int64, len(partitions))
+		for _, partition := range partitions {
+			offset, err := client.GetOffset(topic, partition, at.UnixMilli())
+			if err != nil {
+				return nil, fmt.Errorf("failed to get offset of %s/%d at %s: %w", topic, partition, at, err)
+			}
+
+			if offset < 0 {
+				offset, err = client.GetOffset(topic, partition, sarama.OffsetNewest)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get newest offset of %s/%d: %w", topic, partition, err)
+				}
+			}
+
+			offsets[topic][partition] = offset
+		}
+	}
+
+	return offsets, nil
+}
+
+// TopicMessages return the number of messages retained on every topic, i.e. the newest minus the oldest offset summed over its partitions
+func TopicMessages(client sarama.Client, topics []string) (map[string]int64, error) {
+	topicPartitions, err := partitionsOf(client, topics)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(map[string]int64, len(topicPartitions))
+	for topic, partitions := range topicPartitions {
+		messages[topic] = 0
+		for _, partition := range partitions {
+			newest, err := client.GetOffset(topic, partition, sarama.OffsetNewest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get newest offset of %s/%d: %w", topic, partition, err)
+			}
+
+			oldest, err := client.GetOffset(topic, partition, sarama.OffsetOldest)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get oldest offset of %s/%d: %w", topic, partition, err)
+			}
+
+			messages[topic] += max(newest-oldest, 0)
+		}
+	}
+
+	return messages, nil
+}
+
+func partitionsOf(client sarama.Client, topics []string) (map[string][]int32, error) {
+	topicPartitions := make(map[string][]int32, len(topics))
+	for _, topic := range topics {
+		partitions, err := client.Partitions(topic)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get partitions of %s: %w", topic, err)
+		}
+		topicPartitions[topic] = partitions
+	}
+
+	return topicPartitions, nil
+}
@@ -0,0 +1,245 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	dlqpublisher "bitbucket.org/Amartha/go-fp-transaction/internal/common/dlq_publisher"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/metrics"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/retry"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/tracing"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+	"bitbucket.org/Amartha/go-x/log/audit"
+	"bitbucket.org/Amartha/go-x/log/ctxdata"
+
+	"github.com/Shopify/sarama"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// CorrelationIDHeader is the message header used as correlation id, a new one is generated when it is empty
+const CorrelationIDHeader = "X-Correlation-Id"
+
+// CorrelationID set correlation id and host of the context, the client which made the request
+// of the message is also set when the publisher put it into the header
+func CorrelationID(clientID string) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			correlationID := header(msg, CorrelationIDHeader)
+			if correlationID == "" {
+				correlationID = uuid.New().String()
+			}
+
+			ctx = ctxdata.Sets(ctx,
+				ctxdata.SetCorrelationId(correlationID),
+				ctxdata.SetHost(clientID),
+			)
+			ctx = models.WithClientID(ctx, header(msg, models.ClientIdHeader))
+
+			return next(ctx, msg)
+		}
+	}
+}
+
+// Tracing start the consumer span of the message, the producer span in the message headers is its parent.
+// Skipped message is not an error of the span
+func Tracing(consumerGroup string) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			ctx, span := tracing.StartConsumerSpan(ctx, consumerGroup, msg)
+			span.SetAttributes(attribute.String("correlation_id", ctxdata.GetCorrelationId(ctx)))
+
+			err := next(ctx, msg)
+
+			spanErr := err
+			if IsSkip(err) {
+				spanErr = nil
+				span.SetAttributes(attribute.Bool("skipped", true))
+			}
+			tracing.End(span, spanErr)
+
+			return err
+		}
+	}
+}
+
+// Logging log the result of the message, successful message is written to audit log
+func Logging(logPrefix string) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			start := time.Now()
+			err := next(ctx, msg)
+
+			logField := append(LogFields(msg), xlog.Duration("response-time", time.Since(start)))
+			switch {
+			case err == nil:
+				xlog.Info(ctx, logPrefix, logField...)
+				audit.Info(ctx, audit.Message{ActivityData: string(msg.Value)})
+			case IsSkip(err):
+				xlog.Info(ctx, logPrefix, append(logField, xlog.Err(err), xlog.String("action", "skipped"))...)
+			default:
+				xlog.Warn(ctx, logPrefix, append(logField, xlog.Err(err))...)
+			}
+
+			return err
+		}
+	}
+}
+
+// DeadLetterFunc is called once the failed message is published to DLQ, e.g. to finish the state of the message
+type DeadLetterFunc func(ctx context.Context, msg *sarama.ConsumerMessage, cause error)
+
+// DeadLetter publish the failed message to DLQ, message is not published when the session is ended
+// because it will be redelivered to the next owner of the partition. onPublished is optional.
+// Message which can not be published is left unacknowledged and the session is ended, so it is not lost
+func DeadLetter(dlq dlqpublisher.Publisher, logPrefix string, onPublished DeadLetterFunc) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			err := next(ctx, msg)
+			if err == nil || IsSkip(err) || ctx.Err() != nil {
+				return err
+			}
+
+			logField := append(LogFields(msg), xlog.Err(err))
+			errPublish := dlq.Publish(models.FailedMessage{
+				Payload:    msg.Value,
+				Timestamp:  msg.Timestamp,
+				CauseError: err,
+				Error:      err.Error(),
+			})
+			if errPublish != nil {
+				xlog.Error(ctx, logPrefix+"[NACK-DLQ-FAILED]", append(logField, xlog.String("dlq_error", errPublish.Error()))...)
+				return unacked(fmt.Errorf("%w, error publish to DLQ: %v", err, errPublish))
+			}
+
+			xlog.Info(ctx, logPrefix+"[NACK-DLQ-SUCCESS]", logField...)
+			if onPublished != nil {
+				onPublished(ctx, msg, err)
+			}
+
+			return err
+		}
+	}
+}
+
+// Metrics record processing time of the message, skipped message is counted as success
+func Metrics(consumerMetrics *metrics.ConsumerMetrics) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		if consumerMetrics == nil {
+			return next
+		}
+
+		return func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			start := time.Now()
+			err := next(ctx, msg)
+
+			processErr := err
+			if IsSkip(err) {
+				processErr = nil
+			}
+			consumerMetrics.GenerateMetrics(start, msg, processErr)
+
+			return err
+		}
+	}
+}
+
+// Retry retry the failed message with backoff before it is sent to DLQ, skipped and permanent error is not retried
+func Retry(retryer retry.Retryer, logPrefix string) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			var (
+				err     error
+				attempt int
+			)
+
+			return retryer.Retry(ctx, func() error {
+				attempt++
+				err = next(ctx, msg)
+				if err == nil {
+					return nil
+				}
+
+				if IsSkip(err) || IsPermanent(err) {
+					return retryer.StopRetryWithErr(err)
+				}
+
+				xlog.Warn(ctx, logPrefix+"[RETRY]", append(LogFields(msg), xlog.Int("attempt", attempt), xlog.Err(err))...)
+				return err
+			}, func() error {
+				return err
+			})
+		}
+	}
+}
+
+// IdempotencyStore is the store of idempotency key, it is satisfied by repositories.CacheRepository
+type IdempotencyStore interface {
+	SetIfNotExists(ctx context.Context, key string, value interface{}, ttl time.Duration) (bool, error)
+	Del(ctx context.Context, keys ...string) error
+}
+
+// KeyFunc return the idempotency key of the message
+type KeyFunc func(msg *sarama.ConsumerMessage) (string, error)
+
+// Idempotency skip the message when its key is already processed or being processed,
+// the key is released when the message is failed so it can be reprocessed from DLQ
+func Idempotency(store IdempotencyStore, keyFunc KeyFunc, ttl time.Duration, logPrefix string) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg *sarama.ConsumerMessage) error {
+			key, err := keyFunc(msg)
+			if err != nil {
+				return Permanent(err)
+			}
+
+			set, err := store.SetIfNotExists(ctx, key, "processing", ttl)
+			if err != nil {
+				return fmt.Errorf("error check idempotency: %w", err)
+			}
+
+			if !set {
+				return Skip(common.ErrRequestBeingProcessed)
+			}
+
+			err = next(ctx, msg)
+			if err != nil && !IsSkip(err) {
+				if errDel := store.Del(context.WithoutCancel(ctx), key); errDel != nil {
+					xlog.Warn(ctx, logPrefix, append(LogFields(msg), xlog.String("idempotency_key", key), xlog.Err(errDel))...)
+				}
+			}
+
+			return err
+		}
+	}
+}
+
+// Recover convert panic of the handler to permanent error, so one bad message does not stop the consumer
+func Recover(logPrefix string) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, msg *sarama.ConsumerMessage) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					err = Permanent(fmt.Errorf("panic: %v", r))
+					xlog.Error(ctx, logPrefix+"[PANIC]", append(LogFields(msg), xlog.Err(err), xlog.String("stack", string(debug.Stack())))...)
+				}
+			}()
+
+			return next(ctx, msg)
+		}
+	}
+}
+
+func header(msg *sarama.ConsumerMessage, key string) string {
+	for _, h := range msg.Headers {
+		if h != nil && string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/common/kafka/lag.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/common/kafka/lag.go -destination=./internal/common/kafka/mock/lag_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	time "time"
+
+	kafka "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOffsetReader is a mock of OffsetReader interface.
+type MockOffsetReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockOffsetReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockOffsetReaderMockRecorder is the mock recorder for MockOffsetReader.
+type MockOffsetReaderMockRecorder struct {
+	mock *MockOffsetReader
+}
+
+// NewMockOffsetReader creates a new mock instance.
+func NewMockOffsetReader(ctrl *gomock.Controller) *MockOffsetReader {
+	mock := &MockOffsetReader{ctrl: ctrl}
+	mock.recorder = &MockOffsetReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOffsetReader) EXPECT() *MockOffsetReaderMockRecorder {
+	return m.recorder
+}
+
+// GroupLag mocks base method.
+func (m *MockOffsetReader) GroupLag(group string, topics []string) ([]kafka.PartitionLag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GroupLag", group, topics)
+	ret0, _ := ret[0].([]kafka.PartitionLag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GroupLag indicates an expected call of GroupLag.
+func (mr *MockOffsetReaderMockRecorder) GroupLag(group, topics any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GroupLag", reflect.TypeOf((*MockOffsetReader)(nil).GroupLag), group, topics)
+}
+
+// OffsetsForTime mocks base method.
+func (m *MockOffsetReader) OffsetsForTime(topics []string, at time.Time) (map[string]map[int32]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OffsetsForTime", topics, at)
+	ret0, _ := ret[0].(map[string]map[int32]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OffsetsForTime indicates an expected call of OffsetsForTime.
+func (mr *MockOffsetReaderMockRecorder) OffsetsForTime(topics, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OffsetsForTime", reflect.TypeOf((*MockOffsetReader)(nil).OffsetsForTime), topics, at)
+}
@@ -0,0 +1,283 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/shopspring/decimal"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+)
+
+// reason of wallet transaction rejected by the balance check
+const (
+	RejectionInsufficientBalance = "insufficient_balance"
+	RejectionNegativeLimit       = "negative_limit"
+)
+
+// statusRejected is the status of rejected wallet transaction, it is never stored as the transaction is rolled back
+const statusRejected = "REJECTED"
+
+const unknownClient = "unknown"
+
+// BusinessPrometheusMetrics is the health of the ledger, counters are recorded by the services
+// and gauges are refreshed by the Collector
+type BusinessPrometheusMetrics struct {
+	walletTransactions      *prometheus.CounterVec
+	walletTransactionAmount *prometheus.HistogramVec
+	rejections              *prometheus.CounterVec
+
+	reservedPending    *replacedGaugeVec
+	reservedOldestAge  *replacedGaugeVec
+	hvtBacklog         prometheus.Gauge
+	dlqMessages        *replacedGaugeVec
+	moneyFlowSummaries *replacedGaugeVec
+	moneyFlowTransfer  *replacedGaugeVec
+
+	collectErrors *prometheus.CounterVec
+	lastCollected *prometheus.GaugeVec
+}
+
+func newBusinessPrometheusMetrics(reg prometheus.Registerer) *BusinessPrometheusMetrics {
+	mtc := &BusinessPrometheusMetrics{
+		walletTransactions: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ledger_wallet_transactions_total",
+				Help: "Number of wallet transactions reaching a status, REJECTED is not stored as it is rolled back",
+			},
+			[]string{"transaction_type", "transaction_flow", "status", "client_id"},
+		),
+		walletTransactionAmount: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Name:    "ledger_wallet_transaction_amount",
+				Help:    "Amount of wallet transactions moved to the balances, reserved amount is observed when it is captured",
+				Buckets: prometheus.ExponentialBuckets(1_000, 10, 9),
+			},
+			[]string{"transaction_type", "transaction_flow"},
+		),
+		rejections: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ledger_wallet_transaction_rejections_total",
+				Help: "Number of wallet transactions rejected by the balance check",
+			},
+			[]string{"transaction_type", "reason"},
+		),
+		reservedPending: newReplacedGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "ledger_reserved_wallet_transactions",
+				Help: "Number of reserved wallet transactions which are not captured or cancelled yet",
+			},
+			"transaction_type",
+		),
+		reservedOldestAge: newReplacedGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "ledger_reserved_wallet_transaction_oldest_age_seconds",
+				Help: "Age of the oldest reserved wallet transaction which is not captured or cancelled yet",
+			},
+			"transaction_type",
+		),
+		hvtBacklog: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name: "ledger_hvt_balance_update_backlog",
+				Help: "Number of HVT balance deltas published but not applied to the balance yet",
+			},
+		),
+		dlqMessages: newReplacedGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "ledger_dlq_messages",
+				Help: "Number of messages retained on the DLQ topic",
+			},
+			"topic",
+		),
+		moneyFlowSummaries: newReplacedGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "ledger_money_flow_summaries",
+				Help: "Number of active money flow summaries by status",
+			},
+			"status",
+		),
+		moneyFlowTransfer: newReplacedGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "ledger_money_flow_summary_transfer_amount",
+				Help: "Total transfer of active money flow summaries by status",
+			},
+			"status",
+		),
+		collectErrors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "ledger_metrics_collect_errors_total",
+				Help: "Number of failed refresh of the ledger health gauges by source",
+			},
+			[]string{"source"},
+		),
+		lastCollected: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name: "ledger_metrics_last_collected_timestamp_seconds",
+				Help: "Time of the last successful refresh of the ledger health gauges by source",
+			},
+			[]string{"source"},
+		),
+	}
+
+	reg.MustRegister(mtc.walletTransactions)
+	reg.MustRegister(mtc.walletTransactionAmount)
+	reg.MustRegister(mtc.rejections)
+	reg.MustRegister(mtc.reservedPending.vec)
+	reg.MustRegister(mtc.reservedOldestAge.vec)
+	reg.MustRegister(mtc.hvtBacklog)
+	reg.MustRegister(mtc.dlqMessages.vec)
+	reg.MustRegister(mtc.moneyFlowSummaries.vec)
+	reg.MustRegister(mtc.moneyFlowTransfer.vec)
+	reg.MustRegister(mtc.collectErrors)
+	reg.MustRegister(mtc.lastCollected)
+
+	return mtc
+}
+
+// RecordWalletTransaction count wallet transaction which reaches its current status
+func (m *BusinessPrometheusMetrics) RecordWalletTransaction(trx models.WalletTransaction, clientID string) {
+	if m == nil {
+		return
+	}
+
+	m.walletTransactions.
+		WithLabelValues(trx.TransactionType, string(trx.TransactionFlow), string(trx.Status), clientLabel(clientID)).
+		Inc()
+}
+
+// RecordAmountMoved observe amount of wallet transaction which is moved to the balances
+func (m *BusinessPrometheusMetrics) RecordAmountMoved(trx models.WalletTransaction, amount decimal.Decimal) {
+	if m == nil || !amount.IsPositive() {
+		return
+	}
+
+	m.walletTransactionAmount.
+		WithLabelValues(trx.TransactionType, string(trx.TransactionFlow)).
+		Observe(amount.InexactFloat64())
+}
+
+// RecordRejection count wallet transaction rejected by the balance check, reason is one of Rejection constants
+func (m *BusinessPrometheusMetrics) RecordRejection(transactionType string, transactionFlow models.TransactionFlow, clientID, reason string) {
+	if m == nil {
+		return
+	}
+
+	m.walletTransactions.WithLabelValues(transactionType, string(transactionFlow), statusRejected, clientLabel(clientID)).Inc()
+	m.rejections.WithLabelValues(transactionType, reason).Inc()
+}
+
+// SetReservedWalletTransactions replace the reserved wallet transaction gauges, the age is counted until now
+func (m *BusinessPrometheusMetrics) SetReservedWalletTransactions(summaries []models.ReservedWalletTransactionSummary, now time.Time) {
+	if m == nil {
+		return
+	}
+
+	counts := make(map[string]float64, len(summaries))
+	ages := make(map[string]float64, len(summaries))
+	for _, summary := range summaries {
+		counts[summary.TransactionType] = float64(summary.TotalCount)
+		ages[summary.TransactionType] = max(now.Sub(summary.OldestCreatedAt).Seconds(), 0)
+	}
+
+	m.reservedPending.replace(counts)
+	m.reservedOldestAge.replace(ages)
+}
+
+// SetHVTBalanceUpdateBacklog set number of HVT balance deltas which are not applied yet
+func (m *BusinessPrometheusMetrics) SetHVTBalanceUpdateBacklog(backlog int64) {
+	if m == nil {
+		return
+	}
+
+	m.hvtBacklog.Set(float64(backlog))
+}
+
+// SetDLQMessages replace the number of retained messages keyed by DLQ topic
+func (m *BusinessPrometheusMetrics) SetDLQMessages(messages map[string]int64) {
+	if m == nil {
+		return
+	}
+
+	values := make(map[string]float64, len(messages))
+	for topic, total := range messages {
+		values[topic] = float64(total)
+	}
+
+	m.dlqMessages.replace(values)
+}
+
+// SetMoneyFlowSummaries replace the money flow summary gauges
+func (m *BusinessPrometheusMetrics) SetMoneyFlowSummaries(summaries []models.MoneyFlowStatusSummary) {
+	if m == nil {
+		return
+	}
+
+	counts := make(map[string]float64, len(summaries))
+	transfers := make(map[string]float64, len(summaries))
+	for _, summary := range summaries {
+		counts[summary.Status] = float64(summary.TotalCount)
+		transfers[summary.Status] = summary.TotalTransfer.InexactFloat64()
+	}
+
+	m.moneyFlowSummaries.replace(counts)
+	m.moneyFlowTransfer.replace(transfers)
+}
+
+// RecordCollect record the result of refreshing the gauges of the source
+func (m *BusinessPrometheusMetrics) RecordCollect(source string, at time.Time, err error) {
+	if m == nil {
+		return
+	}
+
+	if err != nil {
+		m.collectErrors.WithLabelValues(source).Inc()
+		return
+	}
+
+	m.lastCollected.WithLabelValues(source).Set(float64(at.Unix()))
+}
+
+func clientLabel(clientID string) string {
+	if clientID == "" {
+		return unknownClient
+	}
+
+	return clientID
+}
+
+// replacedGaugeVec is a gauge with a single label whose values are replaced as a whole.
+// Label which is no longer reported is deleted after the new values are set,
+// instead of resetting the vector first so a scrape in the middle does not see it empty
+type replacedGaugeVec struct {
+	vec *prometheus.GaugeVec
+
+	mu     sync.Mutex
+	labels map[string]struct{}
+}
+
+func newReplacedGaugeVec(opts prometheus.GaugeOpts, label string) *replacedGaugeVec {
+	return &replacedGaugeVec{
+		vec:    prometheus.NewGaugeVec(opts, []string{label}),
+		labels: map[string]struct{}{},
+	}
+}
+
+func (g *replacedGaugeVec) replace(values map[string]float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	labels := make(map[string]struct{}, len(values))
+	for label, value := range values {
+		g.vec.WithLabelValues(label).Set(value)
+		labels[label] = struct{}{}
+	}
+
+	for label := range g.labels {
+		if _, ok := labels[label]; !ok {
+			g.vec.DeleteLabelValues(label)
+		}
+	}
+
+	g.labels = labels
+}
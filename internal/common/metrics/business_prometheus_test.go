@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+)
+
+func Test_BusinessPrometheusMetrics_nil(t *testing.T) {
+	var m *BusinessPrometheusMetrics
+
+	assert.NotPanics(t, func() {
+		m.RecordWalletTransaction(models.WalletTransaction{}, "")
+		m.RecordAmountMoved(models.WalletTransaction{}, decimal.NewFromInt(1))
+		m.RecordRejection("TUPVA", models.TransactionFlowCashIn, "", RejectionNegativeLimit)
+		m.SetReservedWalletTransactions(nil, time.Now())
+		m.SetHVTBalanceUpdateBacklog(1)
+		m.SetDLQMessages(nil)
+		m.SetMoneyFlowSummaries(nil)
+		m.RecordCollect("dlq_messages", time.Now(), nil)
+	})
+}
+
+func Test_BusinessPrometheusMetrics_RecordWalletTransaction(t *testing.T) {
+	m := newBusinessPrometheusMetrics(prometheus.NewRegistry())
+
+	trx := models.WalletTransaction{
+		TransactionType: "TUPVA",
+		TransactionFlow: models.TransactionFlowCashIn,
+		Status:          models.WalletTransactionStatusSuccess,
+	}
+	m.RecordWalletTransaction(trx, "")
+	m.RecordWalletTransaction(trx, "client-a")
+	m.RecordAmountMoved(trx, decimal.NewFromInt(50_000))
+	m.RecordAmountMoved(trx, decimal.Zero)
+	m.RecordRejection("DSBAB", models.TransactionFlowCashOut, "client-a", RejectionInsufficientBalance)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.walletTransactions.WithLabelValues("TUPVA", "cashin", "SUCCESS", "unknown")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.walletTransactions.WithLabelValues("TUPVA", "cashin", "SUCCESS", "client-a")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.walletTransactions.WithLabelValues("DSBAB", "cashout", "REJECTED", "client-a")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.rejections.WithLabelValues("DSBAB", RejectionInsufficientBalance)))
+
+	// zero amount is not moved
+	err := testutil.CollectAndCompare(m.walletTransactionAmount, strings.NewReader(`
+# HELP ledger_wallet_transaction_amount Amount of wallet transactions moved to the balances, reserved amount is observed when it is captured
+# TYPE ledger_wallet_transaction_amount histogram
+ledger_wallet_transaction_amount_bucket{transaction_flow="cashin",transaction_type="TUPVA",le="1000"} 0
+ledger_wallet_transaction_amount_bucket{transaction_flow="cashin",transaction_type="TUPVA",le="10000"} 0
+ledger_wallet_transaction_amount_bucket{transaction_flow="cashin",transaction_type="TUPVA",le="100000"} 1
+ledger_wallet_transaction_amount_bucket{transaction_flow="cashin",transaction_type="TUPVA",le="1e+06"} 1
+ledger_wallet_transaction_amount_bucket{transaction_flow="cashin",transaction_type="TUPVA",le="1e+07"} 1
+ledger_wallet_transaction_amount_bucket{transaction_flow="cashin",transaction_type="TUPVA",le="1e+08"} 1
+ledger_wallet_transaction_amount_bucket{transaction_flow="cashin",transaction_type="TUPVA",le="1e+09"} 1
+ledger_wallet_transaction_amount_bucket{transaction_flow="cashin",transaction_type="TUPVA",le="1e+10"} 1
+ledger_wallet_transaction_amount_bucket{transaction_flow="cashin",transaction_type="TUPVA",le="1e+11"} 1
+ledger_wallet_transaction_amount_bucket{transaction_flow="cashin",transaction_type="TUPVA",le="+Inf"} 1
+ledger_wallet_transaction_amount_sum{transaction_flow="cashin",transaction_type="TUPVA"} 50000
+ledger_wallet_transaction_amount_count{transaction_flow="cashin",transaction_type="TUPVA"} 1
+`))
+	assert.NoError(t, err)
+}
+
+func Test_BusinessPrometheusMetrics_SetReservedWalletTransactions(t *testing.T) {
+	m := newBusinessPrometheusMetrics(prometheus.NewRegistry())
+	now := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	m.SetReservedWalletTransactions([]models.ReservedWalletTransactionSummary{
+		{TransactionType: "DSBAB", TotalCount: 3, OldestCreatedAt: now.Add(-time.Hour)},
+		{TransactionType: "TUPVA", TotalCount: 1, OldestCreatedAt: now.Add(-time.Minute)},
+	}, now)
+
+	assert.Equal(t, float64(3), testutil.ToFloat64(m.reservedPending.vec.WithLabelValues("DSBAB")))
+	assert.Equal(t, float64(3600), testutil.ToFloat64(m.reservedOldestAge.vec.WithLabelValues("DSBAB")))
+
+	// transaction type without reservation anymore is removed
+	m.SetReservedWalletTransactions([]models.ReservedWalletTransactionSummary{
+		{TransactionType: "TUPVA", TotalCount: 2, OldestCreatedAt: now.Add(-time.Minute)},
+	}, now)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(m.reservedPending.vec))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.reservedOldestAge.vec))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.reservedPending.vec.WithLabelValues("TUPVA")))
+}
+
+func Test_BusinessPrometheusMetrics_gauges(t *testing.T) {
+	m := newBusinessPrometheusMetrics(prometheus.NewRegistry())
+
+	m.SetHVTBalanceUpdateBacklog(12)
+	m.SetDLQMessages(map[string]int64{"dlq": 4, "hvt_dlq": 0})
+	m.SetMoneyFlowSummaries([]models.MoneyFlowStatusSummary{
+		{Status: "PENDING", TotalCount: 2, TotalTransfer: decimal.NewFromInt(1_500_000)},
+	})
+
+	assert.Equal(t, float64(12), testutil.ToFloat64(m.hvtBacklog))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.dlqMessages.vec))
+	assert.Equal(t, float64(4), testutil.ToFloat64(m.dlqMessages.vec.WithLabelValues("dlq")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.moneyFlowSummaries.vec.WithLabelValues("PENDING")))
+	assert.Equal(t, float64(1_500_000), testutil.ToFloat64(m.moneyFlowTransfer.vec.WithLabelValues("PENDING")))
+}
+
+func Test_BusinessPrometheusMetrics_RecordCollect(t *testing.T) {
+	m := newBusinessPrometheusMetrics(prometheus.NewRegistry())
+	at := time.Unix(1_700_000_000, 0)
+
+	m.RecordCollect("dlq_messages", at, nil)
+	m.RecordCollect("dlq_messages", at.Add(time.Minute), assert.AnError)
+
+	require.Equal(t, float64(1), testutil.ToFloat64(m.collectErrors.WithLabelValues("dlq_messages")))
+	assert.Equal(t, float64(at.Unix()), testutil.ToFloat64(m.lastCollected.WithLabelValues("dlq_messages")))
+}
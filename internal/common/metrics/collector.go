@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/graceful"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+)
+
+const (
+	defaultCollectInterval = time.Minute
+	defaultCollectTimeout  = 10 * time.Second
+)
+
+// CollectFunc refresh the gauges of a source
+type CollectFunc func(ctx context.Context) error
+
+// Collector refresh the ledger health gauges periodically.
+// Sources are collected one after another, so the collector holds at most one database connection
+type Collector struct {
+	interval time.Duration
+	timeout  time.Duration
+	business *BusinessPrometheusMetrics
+
+	mu      sync.RWMutex
+	sources []collectSource
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	started atomic.Bool
+	done    chan struct{}
+}
+
+type collectSource struct {
+	name    string
+	collect CollectFunc
+}
+
+func NewCollector(cfg config.BusinessMetricsConfig, business *BusinessPrometheusMetrics) *Collector {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defaultCollectInterval
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultCollectTimeout
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Collector{
+		interval: interval,
+		timeout:  timeout,
+		business: business,
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+}
+
+// Register add source which is refreshed on every interval
+func (c *Collector) Register(name string, collect CollectFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sources = append(c.sources, collectSource{name: name, collect: collect})
+}
+
+// Collect refresh every source once, the gauges of failed source keep their previous values
+func (c *Collector) Collect(ctx context.Context) {
+	c.mu.RLock()
+	sources := slices.Clone(c.sources)
+	c.mu.RUnlock()
+
+	for _, source := range sources {
+		if ctx.Err() != nil {
+			return
+		}
+
+		err := c.collectSource(ctx, source)
+		if err != nil {
+			xlog.Warn(ctx, "[BUSINESS-METRICS] failed to collect", xlog.String("source", source.name), xlog.Err(err))
+		}
+		c.business.RecordCollect(source.name, time.Now(), err)
+	}
+}
+
+func (c *Collector) collectSource(ctx context.Context, source collectSource) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	return source.collect(ctx)
+}
+
+// Start collect immediately and then on every interval until the collector is stopped
+func (c *Collector) Start() graceful.ProcessStarter {
+	return func() error {
+		c.started.Store(true)
+		defer close(c.done)
+
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			c.Collect(c.ctx)
+
+			select {
+			case <-c.ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// Stop cancel the running collection and wait until it returns
+func (c *Collector) Stop() graceful.ProcessStopper {
+	return func(ctx context.Context) error {
+		c.cancel()
+		if !c.started.Load() {
+			return nil
+		}
+
+		select {
+		case <-c.done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+)
+
+func Test_Collector_Collect(t *testing.T) {
+	xlog.InitForTest()
+
+	business := newBusinessPrometheusMetrics(prometheus.NewRegistry())
+	c := NewCollector(config.BusinessMetricsConfig{Timeout: 50 * time.Millisecond}, business)
+
+	var collected []string
+	c.Register("dlq_messages", func(ctx context.Context) error {
+		collected = append(collected, "dlq_messages")
+		return nil
+	})
+	c.Register("money_flow_summaries", func(ctx context.Context) error {
+		collected = append(collected, "money_flow_summaries")
+
+		// source is limited by the timeout
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	c.Register("reserved_wallet_transactions", func(ctx context.Context) error {
+		collected = append(collected, "reserved_wallet_transactions")
+		return nil
+	})
+
+	c.Collect(context.Background())
+
+	assert.Equal(t, []string{"dlq_messages", "money_flow_summaries", "reserved_wallet_transactions"}, collected)
+	assert.Equal(t, float64(1), testutil.ToFloat64(business.collectErrors.WithLabelValues("money_flow_summaries")))
+	assert.Equal(t, 2, testutil.CollectAndCount(business.lastCollected))
+}
+
+func Test_Collector_StartStop(t *testing.T) {
+	xlog.InitForTest()
+
+	c := NewCollector(config.BusinessMetricsConfig{Interval: 10 * time.Millisecond}, nil)
+
+	var count atomic.Int32
+	c.Register("dlq_messages", func(ctx context.Context) error {
+		count.Add(1)
+		return nil
+	})
+
+	go func() { _ = c.Start()() }()
+	require.Eventually(t, func() bool { return count.Load() >= 2 }, time.Second, 5*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, c.Stop()(ctx))
+
+	stopped := count.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stopped, count.Load())
+}
+
+func Test_Collector_StopBeforeStart(t *testing.T) {
+	c := NewCollector(config.BusinessMetricsConfig{}, nil)
+
+	assert.NoError(t, c.Stop()(context.Background()))
+}
@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"errors"
 	"strconv"
 	"time"
 
@@ -42,18 +43,34 @@ func NewConsumerMetrics(namespace, subsystem string, flushInterval time.Duration
 		Buckets: []float64{0, 0.0001, 0.001, 0.010, 0.100, 0.200, 0.500, 1, 2, 5, 10, 100, 1000},
 	}, []string{"topic", "consumer_group"})
 
-	reg.MustRegister(consumeTimeHist, processingTimeHist, getMessageTimeHist)
-
 	return &ConsumerMetrics{
 		namespace:          namespace,
 		subsystem:          subsystem,
 		flushInterval:      flushInterval,
 		registerer:         reg,
 		metrics:            appMetrics,
-		consumeTimeHist:    consumeTimeHist,
-		processingTimeHist: processingTimeHist,
-		getMessageTimeHist: getMessageTimeHist,
+		consumeTimeHist:    registerHistogramVec(reg, consumeTimeHist),
+		processingTimeHist: registerHistogramVec(reg, processingTimeHist),
+		getMessageTimeHist: registerHistogramVec(reg, getMessageTimeHist),
+	}
+}
+
+// registerHistogramVec return the histogram already registered by another consumer of the process,
+// the histograms are labeled by consumer group so they can be shared
+func registerHistogramVec(reg prometheus.Registerer, hist *prometheus.HistogramVec) *prometheus.HistogramVec {
+	err := reg.Register(hist)
+	if err == nil {
+		return hist
 	}
+
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		if existing, ok := registered.ExistingCollector.(*prometheus.HistogramVec); ok {
+			return existing
+		}
+	}
+
+	panic(err)
 }
 
 func (m *ConsumerMetrics) Run() {
@@ -24,6 +24,7 @@ type Metrics interface {
 	GetHTTPClientPrometheus() *HTTPClientPrometheusMetrics
 	GetPublisherPrometheus() *PublisherPrometheusMetrics
 	GetBalancePrometheus() *BalancePrometheusMetrics
+	GetBusinessPrometheus() *BusinessPrometheusMetrics
 }
 
 type metrics struct {
@@ -31,15 +32,22 @@ type metrics struct {
 	httpClientMetrics *HTTPClientPrometheusMetrics
 	publisherMetrics  *PublisherPrometheusMetrics
 	balanceMetrics    *BalancePrometheusMetrics
+	businessMetrics   *BusinessPrometheusMetrics
 }
 
 func New() Metrics {
-	reg := prometheus.DefaultRegisterer
+	return NewWithRegisterer(prometheus.DefaultRegisterer)
+}
+
+// NewWithRegisterer create metrics registered to reg instead of the default registerer,
+// so metrics of several instances in one process, e.g. end-to-end tests, do not collide
+func NewWithRegisterer(reg prometheus.Registerer) Metrics {
 	return &metrics{
 		reg:               reg,
 		httpClientMetrics: newHTTPClientPrometheusMetrics(reg),
 		publisherMetrics:  newPublisherPrometheusMetrics(reg),
 		balanceMetrics:    newBalancePrometheusMetrics(reg),
+		businessMetrics:   newBusinessPrometheusMetrics(reg),
 	}
 }
 
@@ -82,3 +90,7 @@ func (m *metrics) GetPublisherPrometheus() *PublisherPrometheusMetrics {
 func (m *metrics) GetBalancePrometheus() *BalancePrometheusMetrics {
 	return m.balanceMetrics
 }
+
+func (m *metrics) GetBusinessPrometheus() *BusinessPrometheusMetrics {
+	return m.businessMetrics
+}
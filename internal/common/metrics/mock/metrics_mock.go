@@ -60,6 +60,20 @@ func (mr *MockMetricsMockRecorder) GetBalancePrometheus() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalancePrometheus", reflect.TypeOf((*MockMetrics)(nil).GetBalancePrometheus))
 }
 
+// GetBusinessPrometheus mocks base method.
+func (m *MockMetrics) GetBusinessPrometheus() *metrics.BusinessPrometheusMetrics {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBusinessPrometheus")
+	ret0, _ := ret[0].(*metrics.BusinessPrometheusMetrics)
+	return ret0
+}
+
+// GetBusinessPrometheus indicates an expected call of GetBusinessPrometheus.
+func (mr *MockMetricsMockRecorder) GetBusinessPrometheus() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBusinessPrometheus", reflect.TypeOf((*MockMetrics)(nil).GetBusinessPrometheus))
+}
+
 // GetHTTPClientPrometheus mocks base method.
 func (m *MockMetrics) GetHTTPClientPrometheus() *metrics.HTTPClientPrometheusMetrics {
 	m.ctrl.T.Helper()
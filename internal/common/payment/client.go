@@ -0,0 +1,118 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/metrics"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+	"bitbucket.org/Amartha/go-x/log/ctxdata"
+
+	"github.com/go-resty/resty/v2"
+)
+
+var logMessage = "[PAYMENT-CLIENT]"
+
+type Client interface {
+	CreateTransfer(ctx context.Context, req RequestCreateTransfer) (res ResponseCreateTransfer, err error)
+}
+
+type client struct {
+	baseURL    string
+	secretKey  string
+	httpClient *resty.Client
+	metrics    metrics.Metrics
+}
+
+func New(configuration config.HTTPConfiguration, metrics metrics.Metrics) Client {
+	retryWaitTime := time.Duration(configuration.RetryWaitTime) * time.Millisecond
+
+	restyClient := resty.New()
+	restyClient = restyClient.AddRetryCondition(func(r *resty.Response, err error) bool {
+		if r == nil {
+			return false
+		}
+
+		_, shouldRetry := models.RetryableHTTPCodes[r.StatusCode()]
+		return shouldRetry
+	})
+
+	restyClient = restyClient.
+		SetTransport(monitoring.NewMiddlewareRoundTripper(restyClient.GetClient().Transport)).
+		SetRetryCount(configuration.RetryCount).
+		SetRetryWaitTime(retryWaitTime).
+		SetTimeout(configuration.Timeout)
+
+	return client{
+		baseURL:    configuration.BaseURL,
+		secretKey:  configuration.SecretKey,
+		httpClient: restyClient,
+		metrics:    metrics,
+	}
+}
+
+// CreateTransfer request transfer between bank accounts, retry is safe as long as the same idempotency key is used
+func (c client) CreateTransfer(ctx context.Context, req RequestCreateTransfer) (res ResponseCreateTransfer, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	startTime := time.Now()
+	url := fmt.Sprintf("%s/api/v1/transfers", c.baseURL)
+
+	logFields := []xlog.Field{
+		xlog.String("url", url),
+		xlog.String("referenceNumber", req.ReferenceNumber),
+		xlog.String("paymentType", req.PaymentType),
+	}
+
+	xlog.Info(ctx, logMessage, append(logFields, xlog.String("message", "send request to go_payment"))...)
+
+	httpRes, err := c.httpClient.
+		R().
+		SetContext(ctx).
+		SetHeader("Accept", "application/json;  charset=utf-8").
+		SetHeader("Cache-Control", "no-cache").
+		SetHeader("X-Correlation-Id", ctxdata.GetCorrelationId(ctx)).
+		SetHeader("X-Secret-Key", c.secretKey).
+		SetHeader("X-Idempotency-Key", req.IdempotencyKey).
+		SetBody(req).
+		Post(url)
+	if err != nil {
+		return res, fmt.Errorf("failed send request: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			xlog.Warn(ctx, logMessage, append(logFields, xlog.Err(err))...)
+		}
+		if c.metrics != nil {
+			c.metrics.GetHTTPClientPrometheus().Record(time.Since(startTime), SERVICE_NAME, httpRes.Request.Method, url, httpRes.StatusCode())
+		}
+	}()
+
+	logFields = append(logFields,
+		xlog.String("httpStatusCode", httpRes.Status()),
+		xlog.Any("httpResponse", httpRes.Body()))
+
+	if httpRes.StatusCode() != http.StatusOK && httpRes.StatusCode() != http.StatusCreated {
+		return res, fmt.Errorf("invalid response http code: got %d", httpRes.StatusCode())
+	}
+
+	err = json.Unmarshal(httpRes.Body(), &res)
+	if err != nil {
+		return res, fmt.Errorf("error unmarshal response: %w", err)
+	}
+
+	if res.ID == "" {
+		return res, fmt.Errorf("empty transfer id in response")
+	}
+
+	return res, nil
+}
@@ -0,0 +1,44 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// fakeClient is used on local environment and tests where payment API is not reachable.
+// Like the real API, it returns the same transfer for the same idempotency key.
+type fakeClient struct {
+	mu        sync.Mutex
+	transfers map[string]ResponseCreateTransfer
+}
+
+func NewFake() Client {
+	return &fakeClient{
+		transfers: map[string]ResponseCreateTransfer{},
+	}
+}
+
+func (c *fakeClient) CreateTransfer(_ context.Context, req RequestCreateTransfer) (ResponseCreateTransfer, error) {
+	if !req.Amount.IsPositive() {
+		return ResponseCreateTransfer{}, errors.New("amount must be greater than zero")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if res, ok := c.transfers[req.IdempotencyKey]; ok {
+		return res, nil
+	}
+
+	res := ResponseCreateTransfer{
+		Kind:   "transfer",
+		ID:     "FAKE-" + uuid.New().String(),
+		Status: "PENDING",
+	}
+	c.transfers[req.IdempotencyKey] = res
+
+	return res, nil
+}
@@ -0,0 +1,30 @@
+package payment
+
+import "github.com/shopspring/decimal"
+
+const SERVICE_NAME string = "go-payment"
+
+type BankAccount struct {
+	AccountNumber     string `json:"accountNumber"`
+	BankCode          string `json:"bankCode"`
+	BankName          string `json:"bankName"`
+	BankAccountNumber string `json:"bankAccountNumber"`
+	BankAccountName   string `json:"bankAccountName"`
+}
+
+type RequestCreateTransfer struct {
+	// IdempotencyKey makes retried request return the transfer created by the first request
+	IdempotencyKey  string          `json:"-"`
+	ReferenceNumber string          `json:"referenceNumber"`
+	PaymentType     string          `json:"paymentType"`
+	Amount          decimal.Decimal `json:"amount"`
+	Description     string          `json:"description"`
+	Source          BankAccount     `json:"source"`
+	Destination     BankAccount     `json:"destination"`
+}
+
+type ResponseCreateTransfer struct {
+	Kind   string `json:"kind"`
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/tracing"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 
 	xlog "bitbucket.org/Amartha/go-x/log"
 
@@ -65,7 +67,17 @@ func (d publisher) Publish(ctx context.Context, message any, opts ...PublishOpti
 		return err
 	}
 
+	// consumers of the message know which client made the request, see kafka.CorrelationID
+	if clientID := models.GetClientID(ctx); clientID != "" {
+		msg.Headers = append(msg.Headers, sarama.RecordHeader{
+			Key:   []byte(models.ClientIdHeader),
+			Value: []byte(clientID),
+		})
+	}
+
+	_, span := tracing.StartProducerSpan(ctx, msg)
 	_, _, err = d.producer.SendMessage(msg)
+	tracing.End(span, err)
 	if err != nil {
 		xlog.Error(
 			ctx,
@@ -11,6 +11,10 @@ import (
 	queueunicorn "bitbucket.org/Amartha/go-queue-unicorn/client"
 	queueunicornmodel "bitbucket.org/Amartha/go-queue-unicorn/client/model"
 	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"golang.org/x/exp/maps"
 )
 
 type Client interface {
@@ -34,8 +38,8 @@ func New(cfg config.Config) (Client, error) {
 }
 
 func (c *client) SendJobHTTP(ctx context.Context, req RequestJobHTTP) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	var resp *queueunicornmodel.JobResponse
 
@@ -49,7 +53,7 @@ func (c *client) SendJobHTTP(ctx context.Context, req RequestJobHTTP) (err error
 			Host:    req.Payload.Host,
 			Method:  req.Payload.Method,
 			Body:    req.Payload.Body,
-			Headers: req.Payload.Headers,
+			Headers: withTraceHeaders(ctx, req.Payload.Headers),
 			Tag:     c.cfg.App.Name,
 		},
 		Options: queueunicornmodel.Option(req.Options),
@@ -69,3 +73,21 @@ func logGoQueue(ctx context.Context, req, resp any, err error) {
 		xlog.Info(ctx, "[GO-QUEUE]", xlog.String("status", "success"), xlog.Any("request", req), xlog.Any("response", resp))
 	}
 }
+
+// withTraceHeaders add the trace context to the headers of the job,
+// so the request sent by queue unicorn is traced as the child of the caller
+func withTraceHeaders(ctx context.Context, headers map[string]interface{}) map[string]interface{} {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return headers
+	}
+
+	dst := make(map[string]interface{}, len(headers)+len(carrier))
+	for key, value := range carrier {
+		dst[key] = value
+	}
+	maps.Copy(dst, headers)
+
+	return dst
+}
@@ -0,0 +1,104 @@
+package readiness
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/redis/go-redis/v9"
+)
+
+// PingDB check connection of the database pool
+func PingDB(db *sql.DB) Checker {
+	return func(ctx context.Context) (map[string]any, error) {
+		if err := db.PingContext(ctx); err != nil {
+			return nil, err
+		}
+
+		stats := db.Stats()
+		return map[string]any{
+			"openConnections": stats.OpenConnections,
+			"inUse":           stats.InUse,
+		}, nil
+	}
+}
+
+// PingRedis check connection of redis
+func PingRedis(client *redis.Client) Checker {
+	return func(ctx context.Context) (map[string]any, error) {
+		return nil, client.Ping(ctx).Err()
+	}
+}
+
+// KafkaBrokers check the brokers can be reached by refreshing the cluster metadata
+func KafkaBrokers(client sarama.Client) Checker {
+	return func(ctx context.Context) (map[string]any, error) {
+		err := runWithContext(ctx, func() error {
+			return client.RefreshMetadata()
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return map[string]any{"brokers": len(client.Brokers())}, nil
+	}
+}
+
+// MasterDataFreshness check master data is refreshed within maxAge, stale master data may reject valid transaction types
+func MasterDataFreshness(lastRefreshedAt func() time.Time, maxAge time.Duration) Checker {
+	return func(ctx context.Context) (map[string]any, error) {
+		refreshedAt := lastRefreshedAt()
+		if refreshedAt.IsZero() {
+			return nil, errors.New("master data has never been loaded")
+		}
+
+		age := time.Since(refreshedAt)
+		details := map[string]any{"ageSeconds": int64(age.Seconds())}
+		if age > maxAge {
+			return details, fmt.Errorf("master data is not refreshed for %s", age.Truncate(time.Second))
+		}
+
+		return details, nil
+	}
+}
+
+// HTTPEndpoint check the service behind url can be reached, only server error is considered as down
+func HTTPEndpoint(client *http.Client, url string) Checker {
+	return func(ctx context.Context) (map[string]any, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+
+		return nil, nil
+	}
+}
+
+// runWithContext stop waiting fn when ctx is done, for client which does not accept context
+func runWithContext(ctx context.Context, fn func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
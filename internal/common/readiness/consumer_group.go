@@ -0,0 +1,142 @@
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+)
+
+const defaultConsumerMaxIdleTime = 5 * time.Minute
+
+// ConsumerGroupOptions is the threshold of consumer group check
+type ConsumerGroupOptions struct {
+	// MaxLag fail the check when total lag is above it, zero means lag alone does not fail the check
+	MaxLag int64
+	// MaxIdleTime fail the check when there is lag but no offset is committed within it
+	MaxIdleTime time.Duration
+}
+
+type consumerGroupChecker struct {
+	client sarama.Client
+	admin  sarama.ClusterAdmin
+	group  string
+	topics []string
+	opts   ConsumerGroupOptions
+
+	mu              sync.Mutex
+	lastCommitted   int64
+	lastProcessedAt time.Time
+}
+
+// ConsumerGroup check lag of the consumer group and time since it processed the last message.
+// Message is considered processed when its offset is committed, so consumer without traffic is not reported as stuck
+func ConsumerGroup(client sarama.Client, admin sarama.ClusterAdmin, group string, topics []string, opts ConsumerGroupOptions) Checker {
+	if opts.MaxIdleTime <= 0 {
+		opts.MaxIdleTime = defaultConsumerMaxIdleTime
+	}
+
+	c := &consumerGroupChecker{
+		client:          client,
+		admin:           admin,
+		group:           group,
+		topics:          topics,
+		opts:            opts,
+		lastCommitted:   -1,
+		lastProcessedAt: time.Now(),
+	}
+
+	return c.check
+}
+
+func (c *consumerGroupChecker) check(ctx context.Context) (map[string]any, error) {
+	var lag, committed int64
+	err := runWithContext(ctx, func() (err error) {
+		lag, committed, err = c.offsets()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.evaluate(time.Now(), lag, committed)
+}
+
+// offsets return the total lag and the total committed offset of every partition consumed by the group
+func (c *consumerGroupChecker) offsets() (lag, committed int64, err error) {
+	topicPartitions := map[string][]int32{}
+	for _, topic := range c.topics {
+		partitions, err := c.client.Partitions(topic)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to get partitions of %s: %w", topic, err)
+		}
+		topicPartitions[topic] = partitions
+	}
+
+	res, err := c.admin.ListConsumerGroupOffsets(c.group, topicPartitions)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get committed offsets: %w", err)
+	}
+	if res.Err != sarama.ErrNoError {
+		return 0, 0, fmt.Errorf("failed to get committed offsets: %w", res.Err)
+	}
+
+	for topic, partitions := range topicPartitions {
+		for _, partition := range partitions {
+			newest, err := c.client.GetOffset(topic, partition, sarama.OffsetNewest)
+			if err != nil {
+				return 0, 0, fmt.Errorf("failed to get newest offset of %s/%d: %w", topic, partition, err)
+			}
+
+			offset := int64(-1)
+			if block := res.GetBlock(topic, partition); block != nil {
+				offset = block.Offset
+			}
+
+			// nothing is committed yet, the group starts from the oldest retained message
+			if offset < 0 {
+				offset, err = c.client.GetOffset(topic, partition, sarama.OffsetOldest)
+				if err != nil {
+					return 0, 0, fmt.Errorf("failed to get oldest offset of %s/%d: %w", topic, partition, err)
+				}
+			}
+
+			lag += max(newest-offset, 0)
+			committed += offset
+		}
+	}
+
+	return lag, committed, nil
+}
+
+func (c *consumerGroupChecker) evaluate(now time.Time, lag, committed int64) (map[string]any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if committed != c.lastCommitted {
+		// the first check only records the offset, the consumer is counted as active since the process is started
+		if c.lastCommitted >= 0 {
+			c.lastProcessedAt = now
+		}
+		c.lastCommitted = committed
+	}
+
+	idle := now.Sub(c.lastProcessedAt)
+	details := map[string]any{
+		"group":                       c.group,
+		"lag":                         lag,
+		"secondsSinceLastProcessedAt": int64(idle.Seconds()),
+	}
+
+	if c.opts.MaxLag > 0 && lag > c.opts.MaxLag {
+		return details, fmt.Errorf("consumer lag %d is above %d", lag, c.opts.MaxLag)
+	}
+
+	if lag > 0 && idle > c.opts.MaxIdleTime {
+		return details, fmt.Errorf("consumer has lag %d but no message is processed for %s", lag, idle.Truncate(time.Second))
+	}
+
+	return details, nil
+}
@@ -0,0 +1,177 @@
+package readiness
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+)
+
+// name of the components registered by setup
+const (
+	ComponentPostgresWrite = "postgres_write"
+	ComponentPostgresRead  = "postgres_read"
+	ComponentRedis         = "redis"
+	ComponentKafka         = "kafka"
+	ComponentMasterData    = "master_data"
+	ComponentFeatureFlag   = "feature_flag"
+	ComponentConsumerGroup = "consumer_group"
+)
+
+const defaultTimeout = 2 * time.Second
+
+var defaultCriticalComponents = []string{ComponentPostgresWrite, ComponentPostgresRead}
+
+type Status string
+
+const (
+	StatusUp       Status = "UP"
+	StatusDegraded Status = "DEGRADED"
+	StatusDown     Status = "DOWN"
+)
+
+// Checker return error when the component can not be used,
+// details is additional information of the component shown in the report, e.g. consumer lag
+type Checker func(ctx context.Context) (details map[string]any, err error)
+
+// Readiness run the registered checkers to decide whether the process can receive traffic.
+// The process is not ready when a critical component is down, other components only degrade it
+type Readiness struct {
+	timeout  time.Duration
+	critical []string
+
+	mu         sync.RWMutex
+	components []*component
+}
+
+type component struct {
+	name  string
+	check Checker
+
+	mu          sync.Mutex
+	lastError   string
+	lastErrorAt *time.Time
+}
+
+type ComponentReport struct {
+	Name        string         `json:"name" example:"postgres_write"`
+	Status      Status         `json:"status" example:"UP"`
+	Critical    bool           `json:"critical" example:"true"`
+	LatencyMs   int64          `json:"latencyMs" example:"3"`
+	LastError   string         `json:"lastError,omitempty"`
+	LastErrorAt string         `json:"lastErrorAt,omitempty"`
+	Details     map[string]any `json:"details,omitempty"`
+}
+
+type Report struct {
+	Kind       string            `json:"kind" example:"readiness"`
+	Status     Status            `json:"status" example:"UP"`
+	Components []ComponentReport `json:"components"`
+}
+
+// IsReady return false when one of critical components is down
+func (r Report) IsReady() bool {
+	return r.Status != StatusDown
+}
+
+func New(cfg config.ReadinessConfig) *Readiness {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	critical := cfg.CriticalComponents
+	if len(critical) == 0 {
+		critical = defaultCriticalComponents
+	}
+
+	return &Readiness{
+		timeout:  timeout,
+		critical: critical,
+	}
+}
+
+// Register add checker of the component, it is checked on every readiness request
+func (r *Readiness) Register(name string, check Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.components = append(r.components, &component{name: name, check: check})
+}
+
+// Check run every checker concurrently, each checker is limited by the configured timeout
+func (r *Readiness) Check(ctx context.Context) Report {
+	r.mu.RLock()
+	components := slices.Clone(r.components)
+	r.mu.RUnlock()
+
+	reports := make([]ComponentReport, len(components))
+
+	var wg sync.WaitGroup
+	for i, c := range components {
+		wg.Add(1)
+		go func(i int, c *component) {
+			defer wg.Done()
+			reports[i] = r.checkComponent(ctx, c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	report := Report{
+		Kind:       "readiness",
+		Status:     StatusUp,
+		Components: reports,
+	}
+	for _, cr := range reports {
+		if cr.Status != StatusDown {
+			continue
+		}
+
+		if cr.Critical {
+			report.Status = StatusDown
+			break
+		}
+
+		report.Status = StatusDegraded
+	}
+
+	return report
+}
+
+func (r *Readiness) checkComponent(ctx context.Context, c *component) ComponentReport {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	details, err := c.check(ctx)
+	latency := time.Since(start)
+
+	report := ComponentReport{
+		Name:      c.name,
+		Status:    StatusUp,
+		Critical:  slices.Contains(r.critical, c.name),
+		LatencyMs: latency.Milliseconds(),
+		Details:   details,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// last error is kept after the component is recovered, so flapping component can be noticed
+	if err != nil {
+		now := time.Now()
+		c.lastError = err.Error()
+		c.lastErrorAt = &now
+		report.Status = StatusDown
+	}
+
+	report.LastError = c.lastError
+	if c.lastErrorAt != nil {
+		report.LastErrorAt = c.lastErrorAt.In(common.GetLocation()).Format(time.RFC3339)
+	}
+
+	return report
+}
@@ -0,0 +1,193 @@
+package readiness
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func up(ctx context.Context) (map[string]any, error) { return nil, nil }
+
+func down(ctx context.Context) (map[string]any, error) { return nil, errors.New("connection refused") }
+
+func TestReadiness_Check(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        config.ReadinessConfig
+		checkers   map[string]Checker
+		wantStatus Status
+		wantReady  bool
+	}{
+		{
+			name: "all components are up",
+			checkers: map[string]Checker{
+				ComponentPostgresWrite: up,
+				ComponentRedis:         up,
+			},
+			wantStatus: StatusUp,
+			wantReady:  true,
+		},
+		{
+			name: "non critical component is down",
+			checkers: map[string]Checker{
+				ComponentPostgresWrite: up,
+				ComponentRedis:         down,
+			},
+			wantStatus: StatusDegraded,
+			wantReady:  true,
+		},
+		{
+			name: "critical component is down",
+			checkers: map[string]Checker{
+				ComponentPostgresWrite: down,
+				ComponentRedis:         down,
+			},
+			wantStatus: StatusDown,
+			wantReady:  false,
+		},
+		{
+			name: "configured critical component is down",
+			cfg:  config.ReadinessConfig{CriticalComponents: []string{ComponentRedis}},
+			checkers: map[string]Checker{
+				ComponentPostgresWrite: up,
+				ComponentRedis:         down,
+			},
+			wantStatus: StatusDown,
+			wantReady:  false,
+		},
+		{
+			name: "checker is timed out",
+			cfg:  config.ReadinessConfig{Timeout: 10 * time.Millisecond},
+			checkers: map[string]Checker{
+				ComponentPostgresWrite: func(ctx context.Context) (map[string]any, error) {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				},
+			},
+			wantStatus: StatusDown,
+			wantReady:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(tt.cfg)
+			for name, check := range tt.checkers {
+				r.Register(name, check)
+			}
+
+			report := r.Check(context.Background())
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.Equal(t, tt.wantReady, report.IsReady())
+			assert.Len(t, report.Components, len(tt.checkers))
+		})
+	}
+}
+
+func TestReadiness_Check_KeepLastError(t *testing.T) {
+	isDown := true
+	r := New(config.ReadinessConfig{})
+	r.Register(ComponentRedis, func(ctx context.Context) (map[string]any, error) {
+		if isDown {
+			return nil, errors.New("connection refused")
+		}
+		return map[string]any{"ok": true}, nil
+	})
+
+	report := r.Check(context.Background())
+	require.Len(t, report.Components, 1)
+	assert.Equal(t, StatusDown, report.Components[0].Status)
+	assert.Equal(t, "connection refused", report.Components[0].LastError)
+
+	isDown = false
+	report = r.Check(context.Background())
+	require.Len(t, report.Components, 1)
+	assert.Equal(t, StatusUp, report.Components[0].Status)
+	assert.Equal(t, "connection refused", report.Components[0].LastError)
+	assert.NotEmpty(t, report.Components[0].LastErrorAt)
+	assert.Equal(t, map[string]any{"ok": true}, report.Components[0].Details)
+}
+
+func TestMasterDataFreshness(t *testing.T) {
+	tests := []struct {
+		name            string
+		lastRefreshedAt time.Time
+		wantErr         bool
+	}{
+		{
+			name:            "fresh",
+			lastRefreshedAt: time.Now().Add(-time.Minute),
+		},
+		{
+			name:            "stale",
+			lastRefreshedAt: time.Now().Add(-time.Hour),
+			wantErr:         true,
+		},
+		{
+			name:    "never loaded",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := MasterDataFreshness(func() time.Time { return tt.lastRefreshedAt }, 5*time.Minute)
+
+			_, err := check(context.Background())
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestConsumerGroupChecker_evaluate(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newChecker := func(opts ConsumerGroupOptions) *consumerGroupChecker {
+		return &consumerGroupChecker{
+			group:           "group",
+			opts:            opts,
+			lastCommitted:   -1,
+			lastProcessedAt: start,
+		}
+	}
+
+	t.Run("no lag", func(t *testing.T) {
+		c := newChecker(ConsumerGroupOptions{MaxIdleTime: time.Minute})
+
+		details, err := c.evaluate(start.Add(time.Hour), 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), details["lag"])
+	})
+
+	t.Run("lag above max lag", func(t *testing.T) {
+		c := newChecker(ConsumerGroupOptions{MaxLag: 100, MaxIdleTime: time.Minute})
+
+		_, err := c.evaluate(start, 101, 10)
+		require.Error(t, err)
+	})
+
+	t.Run("lag without progress", func(t *testing.T) {
+		c := newChecker(ConsumerGroupOptions{MaxIdleTime: time.Minute})
+
+		_, err := c.evaluate(start.Add(30*time.Second), 5, 10)
+		require.NoError(t, err)
+
+		// offset is not committed since the previous check
+		_, err = c.evaluate(start.Add(2*time.Minute), 5, 10)
+		require.Error(t, err)
+	})
+
+	t.Run("lag with progress", func(t *testing.T) {
+		c := newChecker(ConsumerGroupOptions{MaxIdleTime: time.Minute})
+
+		_, err := c.evaluate(start.Add(30*time.Second), 5, 10)
+		require.NoError(t, err)
+
+		details, err := c.evaluate(start.Add(2*time.Minute), 5, 15)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), details["secondsSinceLastProcessedAt"])
+	})
+}
@@ -0,0 +1,145 @@
+package tracing
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// producerHeaders is the propagation carrier of the headers of the message to be published
+type producerHeaders struct {
+	msg *sarama.ProducerMessage
+}
+
+var _ propagation.TextMapCarrier = producerHeaders{}
+
+func (c producerHeaders) Get(key string) string {
+	for _, h := range c.msg.Headers {
+		if string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+
+	return ""
+}
+
+// Set replace the existing header, so the message does not carry the trace context twice
+func (c producerHeaders) Set(key, value string) {
+	for i, h := range c.msg.Headers {
+		if string(h.Key) == key {
+			c.msg.Headers[i].Value = []byte(value)
+			return
+		}
+	}
+
+	c.msg.Headers = append(c.msg.Headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+}
+
+func (c producerHeaders) Keys() []string {
+	keys := make([]string, 0, len(c.msg.Headers))
+	for _, h := range c.msg.Headers {
+		keys = append(keys, string(h.Key))
+	}
+
+	return keys
+}
+
+// consumerHeaders is the propagation carrier of the headers of the consumed message
+type consumerHeaders struct {
+	msg *sarama.ConsumerMessage
+}
+
+var _ propagation.TextMapCarrier = consumerHeaders{}
+
+func (c consumerHeaders) Get(key string) string {
+	for _, h := range c.msg.Headers {
+		if h != nil && string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+
+	return ""
+}
+
+// Set is not used, the consumed message is read only
+func (c consumerHeaders) Set(key, value string) {}
+
+func (c consumerHeaders) Keys() []string {
+	keys := make([]string, 0, len(c.msg.Headers))
+	for _, h := range c.msg.Headers {
+		if h != nil {
+			keys = append(keys, string(h.Key))
+		}
+	}
+
+	return keys
+}
+
+// InjectKafka write the trace context of ctx to the headers of the message
+func InjectKafka(ctx context.Context, msg *sarama.ProducerMessage) {
+	otel.GetTextMapPropagator().Inject(ctx, producerHeaders{msg})
+}
+
+// ExtractKafka return ctx with the trace context of the message headers as the remote parent
+func ExtractKafka(ctx context.Context, msg *sarama.ConsumerMessage) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, consumerHeaders{msg})
+}
+
+// StartProducerSpan start the span of publishing the message and inject it to the message headers,
+// so the span of the consumer is the child of it
+func StartProducerSpan(ctx context.Context, msg *sarama.ProducerMessage) (context.Context, trace.Span) {
+	ctx, span := Tracer().Start(ctx, "send "+msg.Topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationTypeSend,
+			semconv.MessagingDestinationName(msg.Topic),
+		),
+	)
+
+	if msg.Key != nil {
+		if key, err := msg.Key.Encode(); err == nil {
+			span.SetAttributes(semconv.MessagingKafkaMessageKey(string(key)))
+		}
+	}
+
+	InjectKafka(ctx, msg)
+
+	return ctx, span
+}
+
+// StartConsumerSpan start the span of processing the message, the parent is the producer span in the message headers
+func StartConsumerSpan(ctx context.Context, consumerGroup string, msg *sarama.ConsumerMessage) (context.Context, trace.Span) {
+	ctx = ExtractKafka(ctx, msg)
+
+	return Tracer().Start(ctx, "process "+msg.Topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationTypeProcess,
+			semconv.MessagingDestinationName(msg.Topic),
+			semconv.MessagingConsumerGroupName(consumerGroup),
+			semconv.MessagingDestinationPartitionID(strconv.Itoa(int(msg.Partition))),
+			semconv.MessagingKafkaOffset(int(msg.Offset)),
+			semconv.MessagingKafkaMessageKey(string(msg.Key)),
+		),
+	)
+}
+
+// End record the error of the span and end it
+func End(span trace.Span, err error, attrs ...attribute.KeyValue) {
+	span.SetAttributes(attrs...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_Kafka(t *testing.T) {
+	recorder := newRecorder(t)
+
+	ctx, parent := Tracer().Start(context.Background(), "parent")
+	msg := &sarama.ProducerMessage{
+		Topic: "topic",
+		Key:   sarama.StringEncoder("key"),
+		// stale traceparent is replaced by the producer span
+		Headers: []sarama.RecordHeader{{Key: []byte("traceparent"), Value: []byte("stale")}},
+	}
+
+	_, producerSpan := StartProducerSpan(ctx, msg)
+	End(producerSpan, nil)
+	parent.End()
+
+	require.Len(t, msg.Headers, 1)
+	assert.Equal(t, TraceParent(trace.ContextWithSpan(ctx, producerSpan)), string(msg.Headers[0].Value))
+
+	consumed := &sarama.ConsumerMessage{Topic: msg.Topic, Key: []byte("key")}
+	for _, h := range msg.Headers {
+		consumed.Headers = append(consumed.Headers, &sarama.RecordHeader{Key: h.Key, Value: h.Value})
+	}
+
+	_, consumerSpan := StartConsumerSpan(context.Background(), "group", consumed)
+	End(consumerSpan, assert.AnError)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	producer, consumer := spans[0], spans[2]
+	assert.Equal(t, "send topic", producer.Name())
+	assert.Equal(t, trace.SpanKindProducer, producer.SpanKind())
+	assert.Equal(t, parent.SpanContext().SpanID(), producer.Parent().SpanID())
+
+	assert.Equal(t, "process topic", consumer.Name())
+	assert.Equal(t, trace.SpanKindConsumer, consumer.SpanKind())
+	assert.Equal(t, producer.SpanContext().TraceID(), consumer.SpanContext().TraceID())
+	assert.Equal(t, producer.SpanContext().SpanID(), consumer.Parent().SpanID())
+	assert.True(t, consumer.Parent().IsRemote())
+	assert.Equal(t, codes.Error, consumer.Status().Code)
+}
@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the tracer of every span created by this service
+const InstrumentationName = "bitbucket.org/Amartha/go-fp-transaction"
+
+// ShutdownFunc flush the remaining spans and stop the exporter
+type ShutdownFunc func(ctx context.Context) error
+
+// Init set the global tracer provider and propagator.
+// The propagator is always set so the trace context of the incoming request and message is forwarded
+// even when this service does not export its own spans.
+func Init(ctx context.Context, cfg config.Config, command string) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if !cfg.Tracing.Enabled {
+		return func(ctx context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracehttp.Option{}
+	if cfg.Tracing.Endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpoint(cfg.Tracing.Endpoint))
+	}
+	if cfg.Tracing.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if len(cfg.Tracing.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(cfg.Tracing.Headers))
+	}
+
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(cfg.App.Name),
+		attribute.String("app.command", command),
+		semconv.DeploymentEnvironmentName(cfg.App.Env),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	sampleRatio := cfg.Tracing.SampleRatio
+	if sampleRatio <= 0 {
+		sampleRatio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer return the tracer of the global provider, it is no-op until Init is called with tracing enabled
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// TraceParent return the w3c traceparent of the span in the context, empty when there is no valid span
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+
+	return carrier.Get("traceparent")
+}
@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// newRecorder set the global tracer provider to one which record the ended spans
+func newRecorder(t *testing.T) *tracetest.SpanRecorder {
+	// the global provider can not be restored once it is set, the other tests use no-op one
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return recorder
+}
+
+func Test_Init(t *testing.T) {
+	// the global provider can not be restored once it is set, the other tests use no-op one
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	t.Run("disabled tracing only set the propagator", func(t *testing.T) {
+		shutdown, err := Init(context.Background(), config.Config{}, "api")
+		require.NoError(t, err)
+		assert.NoError(t, shutdown(context.Background()))
+
+		_, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider)
+		assert.False(t, ok)
+		assert.Contains(t, otel.GetTextMapPropagator().Fields(), "traceparent")
+	})
+
+	t.Run("enabled tracing set the provider", func(t *testing.T) {
+		cfg := config.Config{
+			App:     config.App{Name: "go-fp-transaction", Env: "local"},
+			Tracing: config.TracingConfig{Enabled: true, Endpoint: "localhost:4318", Insecure: true},
+		}
+
+		shutdown, err := Init(context.Background(), cfg, "api")
+		require.NoError(t, err)
+
+		_, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider)
+		assert.True(t, ok)
+		assert.NoError(t, shutdown(context.Background()))
+	})
+}
+
+func Test_TraceParent(t *testing.T) {
+	newRecorder(t)
+
+	assert.Empty(t, TraceParent(context.Background()))
+
+	ctx, span := Tracer().Start(context.Background(), "span")
+	defer span.End()
+
+	assert.Regexp(t, `^00-`+span.SpanContext().TraceID().String()+`-`+span.SpanContext().SpanID().String()+`-01$`, TraceParent(ctx))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/Shopify/sarama"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/metrics"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/tracing"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 )
@@ -59,6 +60,11 @@ func (tn kafkaTransactionNotification) Publish(ctx context.Context, payload mode
 		}
 	}()
 
+	// notification of request made by an authenticated client is attributed to the client
+	if payload.ClientID == "" {
+		payload.ClientID = models.GetClientID(ctx)
+	}
+
 	msg, err := tn.prepareMessage(payload)
 	if err != nil {
 		xlog.Error(
@@ -90,9 +96,11 @@ func (tn kafkaTransactionNotification) Publish(ctx context.Context, payload mode
 	// log the successful publishing of the transaction notification
 	logDoubleMetrics(payload)
 
+	_, span := tracing.StartProducerSpan(ctx, msg)
 	errPublishAcuanNotif := make(chan error, 1)
 	go func() {
 		_, _, err := tn.producer.SendMessage(msg)
+		tracing.End(span, err)
 		if err != nil {
 			errPublishAcuanNotif <- err
 			return
@@ -176,22 +184,23 @@ func (tn kafkaTransactionNotification) PublishLogBalance(ctx context.Context, pa
 		payloadKafka[accountNumber] = payloadBytes
 	}
 
-	headers := []sarama.RecordHeader{
-		{
-			Key:   []byte("traceparent"),
-			Value: []byte(ctxdata.GetTraceParent(ctx)),
-		},
-	}
-
 	for accountNumber, payloadBytes := range payloadKafka {
 		msg := &sarama.ProducerMessage{
-			Headers: headers,
-			Topic:   tn.topicBalanceLog,
-			Key:     sarama.StringEncoder(accountNumber),
-			Value:   sarama.ByteEncoder(payloadBytes),
+			// traceparent of the caller is replaced by the producer span when tracing is enabled
+			Headers: []sarama.RecordHeader{
+				{
+					Key:   []byte("traceparent"),
+					Value: []byte(ctxdata.GetTraceParent(ctx)),
+				},
+			},
+			Topic: tn.topicBalanceLog,
+			Key:   sarama.StringEncoder(accountNumber),
+			Value: sarama.ByteEncoder(payloadBytes),
 		}
 
+		_, span := tracing.StartProducerSpan(ctx, msg)
 		_, _, err = tn.producerBalanceLog.SendMessage(msg)
+		tracing.End(span, err)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+	"bitbucket.org/Amartha/go-x/log/ctxdata"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	// SignatureHeader is the hex encoded HMAC-SHA256 of "<timestamp>.<body>" signed with the client secret
+	SignatureHeader = "X-Signature"
+	// TimestampHeader is the unix time when the webhook is signed, receivers should reject stale timestamps
+	TimestampHeader = "X-Timestamp"
+)
+
+var logMessage = "[WEBHOOK-CLIENT]"
+
+type Client interface {
+	// Send post payload as json to url signed with secret, attempts is the number of requests made including retries
+	Send(ctx context.Context, url, secret string, payload any) (attempts int, err error)
+}
+
+type client struct {
+	httpClient *resty.Client
+}
+
+func New(configuration config.HTTPConfiguration) Client {
+	retryWaitTime := time.Duration(configuration.RetryWaitTime) * time.Millisecond
+
+	restyClient := resty.New()
+	restyClient = restyClient.AddRetryCondition(func(r *resty.Response, err error) bool {
+		// network errors and timeouts have no response, they are retried as well
+		if err != nil {
+			return true
+		}
+		if r == nil {
+			return false
+		}
+
+		_, shouldRetry := models.RetryableHTTPCodes[r.StatusCode()]
+		return shouldRetry || r.StatusCode() == http.StatusTooManyRequests
+	})
+
+	restyClient = restyClient.
+		SetTransport(monitoring.NewMiddlewareRoundTripper(restyClient.GetClient().Transport)).
+		SetRetryCount(configuration.RetryCount).
+		SetRetryWaitTime(retryWaitTime).
+		SetTimeout(configuration.Timeout)
+
+	return client{httpClient: restyClient}
+}
+
+func (c client) Send(ctx context.Context, url, secret string, payload any) (attempts int, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("error marshal payload: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	logFields := []xlog.Field{
+		xlog.String("url", url),
+		xlog.String("timestamp", timestamp),
+	}
+
+	httpRes, err := c.httpClient.
+		R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetHeader("X-Correlation-Id", ctxdata.GetCorrelationId(ctx)).
+		SetHeader(TimestampHeader, timestamp).
+		SetHeader(SignatureHeader, Sign(secret, timestamp, body)).
+		SetBody(body).
+		Post(url)
+
+	attempts = 1
+	if httpRes != nil && httpRes.Request != nil && httpRes.Request.Attempt > 0 {
+		attempts = httpRes.Request.Attempt
+	}
+	logFields = append(logFields, xlog.Int("attempts", attempts))
+
+	if err != nil {
+		xlog.Warn(ctx, logMessage, append(logFields, xlog.Err(err))...)
+		return attempts, fmt.Errorf("failed send request: %w", err)
+	}
+
+	logFields = append(logFields, xlog.String("httpStatusCode", httpRes.Status()))
+	if !httpRes.IsSuccess() {
+		err = fmt.Errorf("invalid response http code: got %d", httpRes.StatusCode())
+		xlog.Warn(ctx, logMessage, append(logFields, xlog.Err(err))...)
+		return attempts, err
+	}
+
+	xlog.Info(ctx, logMessage, logFields...)
+	return attempts, nil
+}
+
+// Sign return the signature of the body sent at timestamp, receivers compute the same signature to verify the webhook
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
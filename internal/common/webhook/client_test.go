@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	xlog.InitForTest()
+	os.Exit(m.Run())
+}
+
+func TestClient_Send(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "success",
+			statuses:     []int{http.StatusOK},
+			wantAttempts: 1,
+		},
+		{
+			name:         "success after retry",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusNoContent},
+			wantAttempts: 3,
+		},
+		{
+			name:         "failed without retry on client error",
+			statuses:     []int{http.StatusBadRequest},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+		{
+			name:         "failed after retries are exhausted",
+			statuses:     []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+
+				timestamp := r.Header.Get(TimestampHeader)
+				assert.NotEmpty(t, timestamp)
+				assert.Equal(t, Sign("secret", timestamp, body), r.Header.Get(SignatureHeader))
+				assert.JSONEq(t, `{"id":"1"}`, string(body))
+
+				i := int(calls.Add(1)) - 1
+				w.WriteHeader(tt.statuses[min(i, len(tt.statuses)-1)])
+			}))
+			defer server.Close()
+
+			c := New(config.HTTPConfiguration{RetryCount: 2, RetryWaitTime: 1})
+
+			attempts, err := c.Send(context.Background(), server.URL, "secret", map[string]string{"id": "1"})
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantAttempts, attempts)
+			assert.Equal(t, int32(tt.wantAttempts), calls.Load())
+		})
+	}
+}
+
+func TestClient_Send_RetryNetworkError(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the connection of the first request is closed without response
+		if calls.Add(1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			conn.Close()
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(config.HTTPConfiguration{RetryCount: 2, RetryWaitTime: 1})
+
+	attempts, err := c.Send(context.Background(), server.URL, "secret", map[string]string{"id": "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestSign(t *testing.T) {
+	signature := Sign("secret", "1700000000", []byte(`{"id":"1"}`))
+
+	assert.Len(t, signature, 64)
+	assert.Equal(t, signature, Sign("secret", "1700000000", []byte(`{"id":"1"}`)))
+	assert.NotEqual(t, signature, Sign("another-secret", "1700000000", []byte(`{"id":"1"}`)))
+	assert.NotEqual(t, signature, Sign("secret", "1700000001", []byte(`{"id":"1"}`)))
+}
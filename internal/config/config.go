@@ -22,11 +22,23 @@ type (
 		MasterData                  MasterDataConfig            `json:"master_data"`
 		ExponentialBackoff          ExponentialBackOffConfig    `json:"exponential_backoff"`
 		ReconEngine                 ReconEngineConfig           `json:"recon_engine"`
+		MoneyFlowDisbursement       MoneyFlowDisbursementConfig `json:"money_flow_disbursement"`
+		Tax                         TaxConfig                   `json:"tax"`
+		Export                      ExportConfig                `json:"export"`
+		ScheduledReport             ScheduledReportConfig       `json:"scheduled_report"`
+		TransactionMetric           TransactionMetricConfig     `json:"transaction_metric"`
 		TransactionValidationConfig TransactionValidationConfig `json:"transaction_validation_config"`
 		AccountFeatureConfig        map[string]FeatureConfig    `json:"account_feature_config"`
+		Auth                        AuthConfig                  `json:"auth"`
+		Approval                    ApprovalConfig              `json:"approval"`
+		Readiness                   ReadinessConfig             `json:"readiness"`
+		Tracing                     TracingConfig               `json:"tracing"`
+		BusinessMetrics             BusinessMetricsConfig       `json:"business_metrics"`
 
 		GoQueueUnicorn       HTTPConfiguration     `json:"go_queue_unicorn"`
 		GoAccounting         HTTPConfiguration     `json:"go_accounting"`
+		GoPayment            HTTPConfiguration     `json:"go_payment"`
+		Webhook              HTTPConfiguration     `json:"webhook"`
 		DDDNotification      DDDNotificationConfig `json:"ddd_notification"`
 		FeatureFlagSDKConfig FeatureFlagSDKConfig  `json:"feature_flag_sdk"`
 
@@ -105,6 +117,104 @@ type (
 		TransactionTimeUploadMaxWindowDays int           `json:"transaction_time_upload_max_window_days"`
 		ReversalTimeRangeDays              int           `json:"reversal_time_range_days"`
 		AsyncWalletTransactionForClients   []string      `json:"async_wallet_transaction_for_clients"`
+
+		// AsyncWalletTransactionCallbacks is the callback of async wallet transaction per client id,
+		// clients without callback have to poll the status of their transactions
+		AsyncWalletTransactionCallbacks map[string]WebhookCallbackConfig `json:"async_wallet_transaction_callbacks"`
+
+		// AsyncWalletTransactionCallbackMaxAttempts is the number of requests made to deliver a callback
+		// before it is marked as failed, default is 10
+		AsyncWalletTransactionCallbackMaxAttempts int `json:"async_wallet_transaction_callback_max_attempts"`
+	}
+
+	// AuthConfig is the registry of clients allowed to call the internal api,
+	// when no client is registered every request is authenticated with the shared secret_key
+	AuthConfig struct {
+		Clients map[string]APIClientConfig `json:"clients"`
+	}
+
+	APIClientConfig struct {
+		// Scopes granted to the client e.g. wallet:write, reports:read, "*" grants every scope
+		Scopes []string `json:"scopes"`
+
+		// Keys of the client, more than one key can be valid at the same time to rotate the key without downtime
+		Keys []APIKeyConfig `json:"keys"`
+
+		// TrustUserHeader is set for client which authenticates its users e.g. the back office,
+		// X-Ngmis-Username of other clients is not used as identity
+		TrustUserHeader bool `json:"trust_user_header"`
+	}
+
+	APIKeyConfig struct {
+		ID string `json:"id"`
+
+		// Hash is the hex encoded sha256 of the api key, the plain key is never stored in config
+		Hash string `json:"hash"`
+
+		// ValidFrom and ValidUntil are optional, zero value means unbounded
+		ValidFrom  time.Time `json:"valid_from"`
+		ValidUntil time.Time `json:"valid_until"`
+	}
+
+	ApprovalConfig struct {
+		// RequiredOperations is the list of operations which have to be approved by another user before executed,
+		// e.g. DELETE_ACCOUNT, other operations are executed immediately
+		RequiredOperations []string `json:"required_operations"`
+
+		// ExpiryTime is how long pending approval can be decided, default is 24 hours
+		ExpiryTime time.Duration `json:"expiry_time"`
+	}
+
+	ReadinessConfig struct {
+		// Timeout of each component check, default is 2 seconds
+		Timeout time.Duration `json:"timeout"`
+
+		// CriticalComponents make the pod not ready when one of them is down, the other components only degrade it.
+		// Default is postgres_write and postgres_read
+		CriticalComponents []string `json:"critical_components"`
+
+		// MasterDataMaxAge is how old master data can be since the last successful refresh, default is 5 minutes
+		MasterDataMaxAge time.Duration `json:"master_data_max_age"`
+
+		// ConsumerMaxLag is the maximum lag of consumer group, zero means lag alone does not fail the check
+		ConsumerMaxLag int64 `json:"consumer_max_lag"`
+
+		// ConsumerMaxIdleTime is how long consumer may not commit any offset while it has lag, default is 5 minutes
+		ConsumerMaxIdleTime time.Duration `json:"consumer_max_idle_time"`
+	}
+
+	TracingConfig struct {
+		// Enabled export the spans to OTLP collector, the tracer is no-op when it is disabled
+		Enabled bool `json:"enabled"`
+
+		// Endpoint is host:port of the OTLP http collector, default is taken from OTEL_EXPORTER_OTLP_ENDPOINT env
+		Endpoint string `json:"endpoint"`
+
+		// Insecure export the spans over http instead of https
+		Insecure bool `json:"insecure"`
+
+		// Headers are sent with every export, e.g. the api key of the collector
+		Headers map[string]string `json:"headers"`
+
+		// SampleRatio is the ratio of new traces which are sampled, the trace started by other service follow its sampling decision.
+		// Default is 1
+		SampleRatio float64 `json:"sample_ratio"`
+	}
+
+	BusinessMetricsConfig struct {
+		// Enabled run the collector of the ledger health gauges in the api process
+		Enabled bool `json:"enabled"`
+
+		// Interval between refreshes of the gauges, default is 1 minute
+		Interval time.Duration `json:"interval"`
+
+		// Timeout of refreshing each source, default is 10 seconds
+		Timeout time.Duration `json:"timeout"`
+	}
+
+	WebhookCallbackConfig struct {
+		URL    string `json:"url"`
+		Secret string `json:"secret"`
 	}
 
 	AccountConfig struct {
@@ -194,6 +304,7 @@ type (
 		ConsumerGroupDLQ                      string   `json:"consumer_group_dlq"`
 		ConsumerGroupAccountMutation          string   `json:"consumer_group_account_mutation"`
 		ConsumerGroupTaskQueueRecon           string   `json:"consumer_group_task_queue_recon"`
+		ConsumerGroupTaskQueueExport          string   `json:"consumer_group_task_queue_export"`
 		ConsumerGroupDLQRetrier               string   `json:"consumer_group_dlq_retrier"`
 		ConsumerGroupBalanceHvt               string   `json:"consumer_group_balance_hvt"`
 		ConsumerGroupProcessWalletTransaction string   `json:"consumer_group_process_wallet_transaction"`
@@ -204,6 +315,7 @@ type (
 		TopicAccountMutation                  string   `json:"topic_account_mutation"`
 		TopicAccountMutationDLQ               string   `json:"topic_account_mutation_dlq"`
 		TopicRecon                            string   `json:"topic_recon"`
+		TopicExport                           string   `json:"topic_export"`
 		TopicAccountingJournal                string   `json:"topic_accounting_journal"`
 		TopicTransactionNotification          string   `json:"topic_transaction_notification"`
 		TopicBalanceLogs                      string   `json:"topic_balance_logs"`
@@ -216,6 +328,20 @@ type (
 		Assignor                              string   `json:"assignor"`
 		IsOldest                              bool     `json:"is_oldest"`
 		IsVerbose                             bool     `json:"is_verbose"`
+
+		// Handlers is the message handler option keyed by consumer group
+		Handlers map[string]ConsumerHandlerConfig `json:"handlers"`
+	}
+
+	ConsumerHandlerConfig struct {
+		// Retry is the in-process retry before the message is sent to DLQ, exponential_backoff is used when max_retries is empty
+		Retry ExponentialBackOffConfig `json:"retry"`
+		// DisableRetry send the failed message to DLQ without in-process retry, e.g. the handler is not idempotent
+		DisableRetry bool `json:"disable_retry"`
+		// Workers is the number of workers per partition, messages with the same key are always processed in order by the same worker
+		Workers int `json:"workers"`
+		// QueueSize is the number of messages buffered per worker, the consumer stops fetching when every queue is full
+		QueueSize int `json:"queue_size"`
 	}
 
 	CloudStorageConfig struct {
@@ -230,11 +356,11 @@ type (
 	}
 
 	DDDNotificationConfig struct {
-		BaseUrl       string `json:"base_url"`
-		RetryCount    int    `json:"retry_count"`
-		RetryWaitTime int    `json:"retry_wait_time"`
+		BaseUrl string `json:"base_url"`
 	}
 	MasterDataConfig struct {
+		// Backend is the storage of master data, either "gcs" or "postgres", empty means "gcs"
+		Backend            string `json:"backend"`
 		BucketName         string `json:"bucket_name"`
 		OrderTypeFilePath  string `json:"order_type_file_path"`
 		VatRevenueFilePath string `json:"vat_revenue_file_path"`
@@ -243,6 +369,108 @@ type (
 	ReconEngineConfig struct {
 		// ResultURLExpiryTime is the expiry time of the result URL in minutes
 		ResultURLExpiryTime int `json:"result_url_expiry_time"`
+
+		// MatchingRule is the default rule to match uploaded file with transactions
+		MatchingRule ReconMatchingRuleConfig `json:"matching_rule"`
+
+		// MatchingRuleByTransactionType override MatchingRule for specific transaction type
+		MatchingRuleByTransactionType map[string]ReconMatchingRuleConfig `json:"matching_rule_by_transaction_type"`
+	}
+
+	ReconMatchingRuleConfig struct {
+		AmountToleranceAbsolute float64 `json:"amount_tolerance_absolute"`
+		AmountTolerancePercent  float64 `json:"amount_tolerance_percent"`
+		DateWindowDays          int     `json:"date_window_days"`
+		EnableManyToOne         bool    `json:"enable_many_to_one"`
+		EnableOneToMany         bool    `json:"enable_one_to_many"`
+		MaxGroupSize            int     `json:"max_group_size"`
+
+		// SecondaryKey is the fallback key when identifier is not found, currently only support "refNumber"
+		SecondaryKey string `json:"secondary_key"`
+	}
+
+	TaxConfig struct {
+		// VATTransactionTypes and WHTTransactionTypes are the transaction types of tax legs which are summarized in tax report,
+		// empty means the tax legs created by transformers (DSBRQ and RPYAG for VAT, RPYAC for WHT)
+		VATTransactionTypes []string `json:"vat_transaction_types"`
+		WHTTransactionTypes []string `json:"wht_transaction_types"`
+	}
+
+	ExportConfig struct {
+		// URLExpiryTime is the expiry time of the download URL in minutes, default is 15 minutes
+		URLExpiryTime int `json:"url_expiry_time"`
+
+		// MaxDateRangeDays limit the date range of exported transactions, zero means unlimited
+		MaxDateRangeDays int `json:"max_date_range_days"`
+
+		// ProgressInterval is the number of exported rows between progress updates, default is 10000 rows
+		ProgressInterval int `json:"progress_interval"`
+
+		// StaleAfter is the time in minutes after which a processing job without progress can be taken over by another worker, default is 10 minutes
+		StaleAfter int `json:"stale_after"`
+	}
+
+	ScheduledReportConfig struct {
+		// URLExpiryTime is the expiry time in minutes of the download URL sent by email, default is 7 days
+		URLExpiryTime int `json:"url_expiry_time"`
+
+		// EmailFrom and EmailTemplate are used to deliver reports by DDD notification,
+		// default is noreply@amartha.com with 2024-mis-internal template
+		EmailFrom     string `json:"email_from"`
+		EmailTemplate string `json:"email_template"`
+
+		// Definitions is the scheduled reports keyed by report name
+		Definitions map[string]ReportDefinitionConfig `json:"definitions"`
+	}
+
+	TransactionMetricConfig struct {
+		// MaxDateRangeDays limit the date range of transaction metrics, default is 366 days
+		MaxDateRangeDays int `json:"max_date_range_days"`
+	}
+
+	ReportDefinitionConfig struct {
+		Disabled bool `json:"disabled"`
+
+		// Query is the data of report, either "TRANSACTION", "BALANCE" or "MONEY_FLOW"
+		Query string `json:"query"`
+
+		// Params filter the query, e.g. orderType or transactionTypes of TRANSACTION and paymentType of MONEY_FLOW.
+		// Multiple values are separated by comma.
+		Params map[string]string `json:"params"`
+
+		// Schedule is either "DAILY", "WEEKLY" or "MONTHLY". ScheduleDay is the weekday of WEEKLY (0 is sunday)
+		// and the day of month of MONTHLY, it is moved to the last day when the month is shorter.
+		Schedule    string `json:"schedule"`
+		ScheduleDay int    `json:"schedule_day"`
+
+		// Format is either "CSV", "XLSX" or "PARQUET", default is CSV
+		Format string `json:"format"`
+		Gzip   bool   `json:"gzip"`
+
+		// Path is the cloud storage directory of report files, default is reports/{report name}
+		Path string `json:"path"`
+
+		// Recipients receive the download URL by email when the report is generated
+		Recipients []string `json:"recipients"`
+	}
+
+	MoneyFlowDisbursementConfig struct {
+		// CutOffTimeByPaymentType is the time (HH:mm, Asia/Jakarta) when PENDING summaries of previous days
+		// are sent to payment API, payment type not listed here is not disbursed automatically
+		CutOffTimeByPaymentType map[string]string `json:"cut_off_time_by_payment_type"`
+
+		// BatchSize is the maximum summaries disbursed per payment type in one run
+		BatchSize int `json:"batch_size"`
+
+		// ClaimTimeout is how long DISBURSING summary is kept for the run that claimed it,
+		// after that the run is considered dead and the summary is disbursed again
+		ClaimTimeout time.Duration `json:"claim_timeout"`
+
+		// MaxAttempts is how many times the transfer of a summary is requested before the summary is moved to FAILED
+		MaxAttempts int `json:"max_attempts"`
+
+		// UseFakePaymentClient replaces payment API with in-memory fake, only for local environment
+		UseFakePaymentClient bool `json:"use_fake_payment_client"`
 	}
 
 	HTTPConfiguration struct {
@@ -2,102 +2,37 @@ package account_mutation
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"time"
 
-	dlqpublisher "bitbucket.org/Amartha/go-fp-transaction/internal/common/dlq_publisher"
-	"bitbucket.org/Amartha/go-fp-transaction/internal/common/metrics"
+	kafkacommon "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
 
 	goacuanlib "bitbucket.org/Amartha/go-acuan-lib/model"
 	xlog "bitbucket.org/Amartha/go-x/log"
-	"bitbucket.org/Amartha/go-x/log/audit"
-	"bitbucket.org/Amartha/go-x/log/ctxdata"
 
 	"github.com/Shopify/sarama"
-	"github.com/google/uuid"
 )
 
 type AccountMutationHandler struct {
-	clientId        string
-	as              services.AccountService
-	cfg             config.Config
-	dlq             dlqpublisher.Publisher
-	consumerMetrics *metrics.ConsumerMetrics
+	as  services.AccountService
+	cfg config.Config
 }
 
-func NewAccountMutationHandler(
-	clientId string,
-	as services.AccountService,
-	dlq dlqpublisher.Publisher,
-	cfg config.Config,
-	consumerMetrics *metrics.ConsumerMetrics,
-) sarama.ConsumerGroupHandler {
-	return &AccountMutationHandler{clientId, as, cfg, dlq, consumerMetrics}
+func NewAccountMutationHandler(as services.AccountService, cfg config.Config) *AccountMutationHandler {
+	return &AccountMutationHandler{as: as, cfg: cfg}
 }
 
-// Setup is run at the beginning of a new session, before ConsumeClaim
-func (am AccountMutationHandler) Setup(_ sarama.ConsumerGroupSession) error {
-	return nil
-}
-
-// Cleanup is run at the end of a session, once all ConsumeClaim goroutines have exited
-func (am AccountMutationHandler) Cleanup(_ sarama.ConsumerGroupSession) error {
-	return nil
-}
-
-// ConsumeClaim must start a consumer loop of ConsumerGroupClaim's Messages().
-func (am AccountMutationHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	for {
-		select {
-		case message, ok := <-claim.Messages():
-			if !ok {
-				return nil
-			}
-			ctx := ctxdata.Sets(session.Context(),
-				ctxdata.SetCorrelationId(uuid.New().String()),
-				ctxdata.SetHost(am.clientId),
-			)
-
-			start := time.Now()
-			logField := createLogField(message)
-
-			err := am.handler(ctx, message)
-			if err != nil {
-				logField = append(logField, xlog.Duration("response-time", time.Since(start)), xlog.Err(err))
-				xlog.Warn(ctx, logMessage, logField...)
-
-				am.Nack(ctx, session, message, err)
-				continue
-			}
-
-			logField = append(logField, xlog.Duration("response-time", time.Since(start)))
-			xlog.Info(ctx, logMessage, logField...)
-			audit.Info(ctx, audit.Message{ActivityData: string(message.Value)})
-
-			am.Ack(session, message)
-		case <-session.Context().Done():
-			return nil
-		}
-	}
+// HandlerFunc return the handler of account mutation message
+func (am AccountMutationHandler) HandlerFunc() kafkacommon.HandlerFunc {
+	return kafkacommon.Typed(kafkacommon.JSONDecoder[goacuanlib.Payload[goacuanlib.DataAccount]], am.processMessage)
 }
 
-func (am AccountMutationHandler) processMessage(ctx context.Context, message *sarama.ConsumerMessage) (err error) {
-	var (
-		payload    goacuanlib.Payload[goacuanlib.DataAccount]
-		logMessage = "[PROCESS-MESSAGE]"
-	)
+func (am AccountMutationHandler) processMessage(ctx context.Context, message *sarama.ConsumerMessage, payload goacuanlib.Payload[goacuanlib.DataAccount]) (err error) {
+	const logMessage = "[PROCESS-MESSAGE]"
 
-	logField := createLogField(message)
-
-	if err = json.Unmarshal(message.Value, &payload); err != nil {
-		logField = append(logField, xlog.Err(err))
-		xlog.Warn(ctx, logMessage, logField...)
-		return fmt.Errorf("error unmarshal json: %w", err)
-	}
+	logField := kafkacommon.LogFields(message)
 
 	accountStream := payload.Body.Data.Account
 
@@ -138,7 +73,8 @@ func (am AccountMutationHandler) processMessage(ctx context.Context, message *sa
 			err = fmt.Errorf("account is exist: %d", accountExist.ID)
 			logField = append(logField, xlog.Err(err))
 			xlog.Warn(ctx, logMessage, logField...)
-			return err
+			// the account will still exist on the next attempt
+			return kafkacommon.Permanent(err)
 		}
 
 		_, err = am.as.Create(ctx, models.CreateAccount{
@@ -181,47 +117,3 @@ func (am AccountMutationHandler) processMessage(ctx context.Context, message *sa
 	xlog.Info(ctx, logMessage, logField...)
 	return nil
 }
-
-func (am AccountMutationHandler) handler(ctx context.Context, message *sarama.ConsumerMessage) (err error) {
-	startTime := time.Now() // time when a process consumes a message started
-	err = am.processMessage(ctx, message)
-
-	if am.consumerMetrics != nil {
-		am.consumerMetrics.GenerateMetrics(startTime, message, err)
-	}
-
-	return
-}
-
-func (am AccountMutationHandler) Ack(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage) {
-	session.MarkMessage(message, "")
-}
-
-// Nack is a custom function for handling failed messages during Kafka consumer processing.
-// It publishes the failed message to a DLQ and mark the message as consumed.
-func (am AccountMutationHandler) Nack(ctx context.Context, session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage, causeErr error) {
-	logField := createLogField(message)
-
-	err := am.dlq.Publish(models.FailedMessage{
-		Payload:    message.Value,
-		Timestamp:  message.Timestamp,
-		CauseError: causeErr,
-	})
-	if err != nil {
-		logField = append(logField, xlog.Err(err))
-		xlog.Warn(ctx, logMessage, logField...)
-	}
-
-	session.MarkMessage(message, "")
-}
-
-func createLogField(msg *sarama.ConsumerMessage) []xlog.Field {
-	return []xlog.Field{
-		xlog.Time("timestamp", msg.Timestamp),
-		xlog.String("topic", msg.Topic),
-		xlog.String("key", string(msg.Key)),
-		xlog.Int32("partition", msg.Partition),
-		xlog.Int64("offset", msg.Offset),
-		xlog.String("message-claimed", string(msg.Value)),
-	}
-}
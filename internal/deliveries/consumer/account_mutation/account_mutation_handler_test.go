@@ -3,14 +3,10 @@ package account_mutation
 import (
 	"context"
 	"testing"
-	"time"
 
-	dlqpublisher "bitbucket.org/Amartha/go-fp-transaction/internal/common/dlq_publisher"
-	mock4 "bitbucket.org/Amartha/go-fp-transaction/internal/common/dlq_publisher/mock"
+	kafkacommon "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
-	mock2 "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/kafka/mock"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
-	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/services/mock"
 
 	"github.com/Shopify/sarama"
@@ -21,7 +17,6 @@ import (
 type accountMutationHandlerHelper struct {
 	mockCtrl *gomock.Controller
 	as       *mock.MockAccountService
-	dlq      *mock4.MockPublisher
 
 	payload                  []byte
 	migrationDatabasePayload []byte
@@ -34,7 +29,6 @@ func newAccountMutationHandlerHelper(t *testing.T) accountMutationHandlerHelper
 	mockCtrl := gomock.NewController(t)
 
 	as := mock.NewMockAccountService(mockCtrl)
-	dlq := mock4.NewMockPublisher(mockCtrl)
 
 	payload := []byte(`{
 	    "headers": {
@@ -63,7 +57,6 @@ func newAccountMutationHandlerHelper(t *testing.T) accountMutationHandlerHelper
 	return accountMutationHandlerHelper{
 		mockCtrl: mockCtrl,
 		as:       as,
-		dlq:      dlq,
 		payload:  payload,
 		migrationDatabasePayload: []byte(`{
 			"headers": {
@@ -95,71 +88,9 @@ func TestNewAccountMutationHandler(t *testing.T) {
 	th := newAccountMutationHandlerHelper(t)
 	defer th.mockCtrl.Finish()
 
-	cfg := &config.Config{}
+	cfg := config.Config{}
 
-	type args struct {
-		cfg *config.Config
-		as  services.AccountService
-		dlq dlqpublisher.Publisher
-	}
-	tests := []struct {
-		name string
-		args args
-		want *AccountMutationHandler
-	}{
-		{
-			name: "success init NewAccountMutationHandler",
-			args: args{
-				cfg: cfg,
-				as:  th.as,
-				dlq: th.dlq,
-			},
-			want: &AccountMutationHandler{
-				as:  th.as,
-				dlq: th.dlq,
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			assert.Equalf(t, tt.want, NewAccountMutationHandler("", tt.args.as, tt.args.dlq, *tt.args.cfg, nil), "NewTransactionHandler(%v)", tt.args.as)
-		})
-	}
-}
-
-func TestAccountMutationHandler_Cleanup(t *testing.T) {
-	th := newAccountMutationHandlerHelper(t)
-	defer th.mockCtrl.Finish()
-
-	type fields struct {
-		as services.AccountService
-	}
-	type args struct {
-		session sarama.ConsumerGroupSession
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
-	}{
-		{
-			name: "Success Cleanup",
-			fields: fields{
-				as: th.as,
-			},
-			wantErr: false,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			th := AccountMutationHandler{
-				as: tt.fields.as,
-			}
-			err := th.Cleanup(tt.args.session)
-			assert.Equal(t, tt.wantErr, err != nil)
-		})
-	}
+	assert.Equal(t, &AccountMutationHandler{as: th.as, cfg: cfg}, NewAccountMutationHandler(th.as, cfg))
 }
 
 func TestAccountMutationHandler_processMessage(t *testing.T) {
@@ -172,6 +103,7 @@ func TestAccountMutationHandler_processMessage(t *testing.T) {
 		message                                *sarama.ConsumerMessage
 		doMock                                 func()
 		wantErr                                bool
+		wantPermanent                          bool
 	}{
 		{
 			name:    "upsert - happy path",
@@ -201,9 +133,10 @@ func TestAccountMutationHandler_processMessage(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:    "error marshall message",
-			message: &sarama.ConsumerMessage{Value: []byte("{__INVALID_JSON_HERE")},
-			wantErr: true,
+			name:          "error marshall message",
+			message:       &sarama.ConsumerMessage{Value: []byte("{__INVALID_JSON_HERE")},
+			wantErr:       true,
+			wantPermanent: true,
 		},
 		{
 			name:    "upsert with migration_database - err service",
@@ -228,7 +161,8 @@ func TestAccountMutationHandler_processMessage(t *testing.T) {
 			doMock: func() {
 				th.as.EXPECT().GetOneByAccountNumber(gomock.AssignableToTypeOf(context.Background()), gomock.Any()).Return(models.GetAccountOut{}, nil)
 			},
-			wantErr: true,
+			wantErr:       true,
+			wantPermanent: true,
 		},
 		{
 			name:                                   "insert - error insert",
@@ -255,137 +189,9 @@ func TestAccountMutationHandler_processMessage(t *testing.T) {
 					},
 				},
 			}
-			err := h.processMessage(context.Background(), tt.message)
+			err := h.HandlerFunc()(context.Background(), tt.message)
 			assert.Equal(t, tt.wantErr, err != nil, err)
-		})
-	}
-}
-
-func TestAccountMutationHandler_Setup(t *testing.T) {
-	th := newAccountMutationHandlerHelper(t)
-	defer th.mockCtrl.Finish()
-
-	type fields struct {
-		as services.AccountService
-	}
-	type args struct {
-		session sarama.ConsumerGroupSession
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
-	}{
-		{
-			name: "Success Setup",
-			fields: fields{
-				as: th.as,
-			},
-			wantErr: false,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			th := AccountMutationHandler{
-				as: tt.fields.as,
-			}
-			err := th.Setup(tt.args.session)
-			assert.Equal(t, tt.wantErr, err != nil)
-		})
-	}
-}
-
-func TestAccountMutationHandler_ConsumeClaim(t *testing.T) {
-	th := newAccountMutationHandlerHelper(t)
-	defer th.mockCtrl.Finish()
-
-	type fields struct {
-		as        *mock.MockAccountService
-		dlq       *mock4.MockPublisher
-		ctx       context.Context
-		ctxCancel context.CancelFunc
-		msg       chan *sarama.ConsumerMessage
-	}
-
-	type args struct {
-		session *mock2.MockConsumerGroupSession
-		claim   *mock2.MockConsumerGroupClaim
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		doMock  func(a args, f fields)
-		wantErr bool
-	}{
-		{
-			name: "success consume message",
-			fields: fields{
-				as:  mock.NewMockAccountService(th.mockCtrl),
-				dlq: mock4.NewMockPublisher(th.mockCtrl),
-				msg: make(chan *sarama.ConsumerMessage, 1),
-			},
-			args: args{
-				session: mock2.NewMockConsumerGroupSession(th.mockCtrl),
-				claim:   mock2.NewMockConsumerGroupClaim(th.mockCtrl),
-			},
-			doMock: func(a args, f fields) {
-				f.msg <- &sarama.ConsumerMessage{
-					Value: th.payload,
-				}
-
-				a.claim.EXPECT().Messages().Return(f.msg).AnyTimes()
-				a.session.EXPECT().Context().Return(f.ctx).AnyTimes()
-				a.session.EXPECT().MarkMessage(gomock.Any(), gomock.Any()).AnyTimes()
-				f.as.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
-			},
-			wantErr: false,
-		},
-		{
-			name: "failed consume message",
-			fields: fields{
-				as:  mock.NewMockAccountService(th.mockCtrl),
-				dlq: mock4.NewMockPublisher(th.mockCtrl),
-				msg: make(chan *sarama.ConsumerMessage, 1),
-			},
-			args: args{
-				session: mock2.NewMockConsumerGroupSession(th.mockCtrl),
-				claim:   mock2.NewMockConsumerGroupClaim(th.mockCtrl),
-			},
-			doMock: func(a args, f fields) {
-				f.msg <- &sarama.ConsumerMessage{
-					Value: th.payload,
-				}
-
-				f.as.EXPECT().Upsert(gomock.Any(), gomock.Any()).Return(assert.AnError).AnyTimes()
-
-				a.claim.EXPECT().Messages().Return(f.msg).AnyTimes()
-				a.session.EXPECT().Context().Return(f.ctx).AnyTimes()
-
-				f.dlq.EXPECT().Publish(gomock.Any()).Return(nil).AnyTimes()
-
-				a.session.EXPECT().MarkMessage(gomock.Any(), gomock.Any()).AnyTimes()
-			},
-			wantErr: false,
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tt.fields.ctx, tt.fields.ctxCancel = context.WithTimeout(context.Background(), 1*time.Second)
-			defer tt.fields.ctxCancel()
-
-			if tt.doMock != nil {
-				tt.doMock(tt.args, tt.fields)
-			}
-
-			th := AccountMutationHandler{
-				as:  tt.fields.as,
-				dlq: tt.fields.dlq,
-			}
-
-			err := th.ConsumeClaim(tt.args.session, tt.args.claim)
-			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantPermanent, kafkacommon.IsPermanent(err))
 		})
 	}
 }
@@ -1,10 +1,10 @@
 package consumer
 
 import (
-	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/money_flow_calc"
-	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/transaction_stream"
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	"bitbucket.org/Amartha/go-fp-transaction/cmd/setup"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/graceful"
@@ -13,87 +13,164 @@ import (
 	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/account_mutation"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/dlq_notification"
 	hvtbalanceupdate "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/hvt_balance_update"
+	kafkaconsumer "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/kafka"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/money_flow_calc"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/process_wallet_transaction"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/transaction_stream"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
 
 	dlqpublisher "bitbucket.org/Amartha/go-fp-transaction/internal/common/dlq_publisher"
 	dlqretrier "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/dlq_retrier"
+	queueexport "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/task_queue_export"
 	queuerecon "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/task_queue_recon"
+
+	"github.com/Shopify/sarama"
 )
 
-func NewKafkaConsumer(
+// AllNames select every consumer in ParseNames
+const AllNames = "all"
+
+// Names is every consumer which can be run by the consumer command
+var Names = []string{
+	"dlq_notification",
+	"dlq_retrier",
+	"account_mutation",
+	"recon_task_queue",
+	"export_task_queue",
+	"hvt_balance_update",
+	"process_wallet_transaction",
+	"money_flow_calc",
+	"transaction_stream",
+}
+
+// Process is a consumer run by the consumer command, it can be controlled by the admin endpoints
+type Process interface {
+	graceful.ProcessStartStopper
+	kafkaconsumer.Consumer
+}
+
+// ParseNames parse comma separated consumer names or AllNames, duplicated name is only returned once
+func ParseNames(value string) ([]string, error) {
+	if strings.TrimSpace(value) == AllNames {
+		return slices.Clone(Names), nil
+	}
+
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || slices.Contains(names, name) {
+			continue
+		}
+
+		if !slices.Contains(Names, name) {
+			return nil, fmt.Errorf("consumer type name for %s not found", name)
+		}
+
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no consumer name given, available consumers: %s", strings.Join(Names, ", "))
+	}
+
+	return names, nil
+}
+
+// NewKafkaConsumers create the consumers of names, they share the setup and a single DLQ producer.
+// The returned stoppers close the shared resources, so they must be called after the consumers are stopped
+func NewKafkaConsumers(
+	ctx context.Context,
+	names []string,
+	conf config.Config,
+	svc *services.Services,
+	cacheRepo repositories.CacheRepository,
+	contract *setup.Setup,
+) (consumers map[string]Process, stoppers []graceful.ProcessStopper, err error) {
+	var producer sarama.SyncProducer
+	newDLQ := func(topic string) (dlqpublisher.Publisher, error) {
+		if producer == nil {
+			newProducer, err := publisher.NewKafkaSyncProducer(conf.MessageBroker.KafkaConsumer.Brokers)
+			if err != nil {
+				return nil, fmt.Errorf("failed setup kafka dlq publisher : %w", err)
+			}
+
+			producer = newProducer
+			stoppers = append(stoppers, func(ctx context.Context) error { return producer.Close() })
+		}
+
+		return dlqpublisher.New(producer, topic, contract.Metrics), nil
+	}
+
+	consumers = make(map[string]Process, len(names))
+	for _, name := range names {
+		consumer, errConsumer := newKafkaConsumer(ctx, name, conf, svc, cacheRepo, contract, newDLQ)
+		if errConsumer != nil {
+			return nil, stoppers, fmt.Errorf("failed setup consumer %s: %w", name, errConsumer)
+		}
+
+		consumers[name] = consumer
+	}
+
+	return consumers, stoppers, nil
+}
+
+func newKafkaConsumer(
 	ctx context.Context,
 	consumerName string,
 	conf config.Config,
 	svc *services.Services,
 	cacheRepo repositories.CacheRepository,
 	contract *setup.Setup,
-) (consumerProcess graceful.ProcessStartStopper, stoppers []graceful.ProcessStopper, err error) {
+	newDLQ func(topic string) (dlqpublisher.Publisher, error),
+) (Process, error) {
+	consumerCfg := conf.MessageBroker.KafkaConsumer
+
 	switch consumerName {
 	case "dlq_notification":
-		consumerProcess, err = dlq_notification.New(ctx, conf, svc.DLQProcessor, contract.Metrics)
+		return dlq_notification.New(ctx, conf, svc.DLQProcessor, contract.Metrics)
 	case "dlq_retrier":
-		consumerProcess, err = dlqretrier.New(ctx, conf, svc.DLQProcessor, contract.Metrics)
+		return dlqretrier.New(ctx, conf, svc.DLQProcessor, contract.Metrics)
 	case "account_mutation":
-		producer, errProducer := publisher.NewKafkaSyncProducer(conf.MessageBroker.KafkaConsumer.Brokers)
-		if errProducer != nil {
-			err = fmt.Errorf("failed setup kafka dlq publisher : %w", errProducer)
-			return
+		accountDlq, err := newDLQ(consumerCfg.TopicAccountMutationDLQ)
+		if err != nil {
+			return nil, err
 		}
 
-		stoppers = append(stoppers, func(ctx context.Context) error { return producer.Close() })
-
-		accountDlq := dlqpublisher.New(producer, conf.MessageBroker.KafkaConsumer.TopicAccountMutationDLQ, contract.Metrics) // TODO: init publisher on setup.go
-		consumerProcess, err = account_mutation.New(ctx, conf, svc.Account, accountDlq, contract.Metrics)
+		return account_mutation.New(ctx, conf, svc.Account, accountDlq, contract.Metrics)
 	case "recon_task_queue":
-		consumerProcess, err = queuerecon.New(ctx, conf, services.NewReconBalanceService(svc), contract.Metrics)
+		return queuerecon.New(ctx, conf, services.NewReconBalanceService(svc), contract.Metrics)
+	case "export_task_queue":
+		return queueexport.New(ctx, conf, svc.Export, contract.Metrics)
 	case "hvt_balance_update":
-		producer, errProducer := publisher.NewKafkaSyncProducer(conf.MessageBroker.KafkaConsumer.Brokers)
-		if errProducer != nil {
-			err = fmt.Errorf("failed setup kafka dlq publisher : %w", errProducer)
-			return
+		hvtBalanceDlq, err := newDLQ(consumerCfg.TopicBalanceHvtDLQ)
+		if err != nil {
+			return nil, err
 		}
 
-		stoppers = append(stoppers, func(ctx context.Context) error { return producer.Close() })
-
-		hvtBalanceDlq := dlqpublisher.New(producer, conf.MessageBroker.KafkaConsumer.TopicBalanceHvtDLQ, contract.Metrics)
-
-		consumerProcess, err = hvtbalanceupdate.New(ctx, conf, svc.Balance, contract.Metrics, cacheRepo, hvtBalanceDlq)
+		return hvtbalanceupdate.New(ctx, conf, svc.Balance, contract.Metrics, cacheRepo, hvtBalanceDlq)
 	case "process_wallet_transaction":
-		producer, errProducer := publisher.NewKafkaSyncProducer(conf.MessageBroker.KafkaConsumer.Brokers)
-		if errProducer != nil {
-			err = fmt.Errorf("failed setup kafka dlq publisher : %w", errProducer)
-			return
+		processWalletDlq, err := newDLQ(consumerCfg.TopicProcessWalletTransactionDLQ)
+		if err != nil {
+			return nil, err
 		}
-		processWalletDlq := dlqpublisher.New(producer, conf.MessageBroker.KafkaConsumer.TopicProcessWalletTransactionDLQ, contract.Metrics)
 
-		consumerProcess, err = process_wallet_transaction.New(ctx, conf, contract.Metrics, cacheRepo, svc.WalletTrx, processWalletDlq)
+		return process_wallet_transaction.New(ctx, conf, contract.Metrics, cacheRepo, svc.WalletTrx, processWalletDlq)
 	case "money_flow_calc":
-		producer, errProducer := publisher.NewKafkaSyncProducer(conf.MessageBroker.KafkaConsumer.Brokers)
-		if errProducer != nil {
-			err = fmt.Errorf("failed setup kafka dlq publisher : %w", errProducer)
-			return
+		moneyFlowCalcDlq, err := newDLQ(consumerCfg.TopicMoneyFlowCalcDLQ)
+		if err != nil {
+			return nil, err
 		}
 
-		stoppers = append(stoppers, func(ctx context.Context) error { return producer.Close() })
-
-		moneyFlowCalcDlq := dlqpublisher.New(producer, conf.MessageBroker.KafkaConsumer.TopicMoneyFlowCalcDLQ, contract.Metrics)
-		consumerProcess, err = money_flow_calc.New(ctx, conf, svc.MoneyFlowCalc, moneyFlowCalcDlq, contract.Metrics)
+		return money_flow_calc.New(ctx, conf, svc.MoneyFlowCalc, moneyFlowCalcDlq, contract.Metrics)
 	case "transaction_stream":
-		producer, errProducer := publisher.NewKafkaSyncProducer(conf.MessageBroker.KafkaConsumer.Brokers)
-		if errProducer != nil {
-			err = fmt.Errorf("failed setup kafka dlq publisher : %w", errProducer)
-			return
+		moneyFlowCalcDlq, err := newDLQ(consumerCfg.TopicMoneyFlowCalcDLQ)
+		if err != nil {
+			return nil, err
 		}
 
-		stoppers = append(stoppers, func(ctx context.Context) error { return producer.Close() })
-
-		moneyFlowCalcDlq := dlqpublisher.New(producer, conf.MessageBroker.KafkaConsumer.TopicMoneyFlowCalcDLQ, contract.Metrics)
-		consumerProcess, err = transaction_stream.New(ctx, conf, svc.MoneyFlowCalc, moneyFlowCalcDlq, contract.Metrics)
+		return transaction_stream.New(ctx, conf, svc.MoneyFlowCalc, moneyFlowCalcDlq, contract.Metrics)
 	default:
-		err = fmt.Errorf("consumer type name for %s not found", consumerName)
+		return nil, fmt.Errorf("consumer type name for %s not found", consumerName)
 	}
-
-	return
 }
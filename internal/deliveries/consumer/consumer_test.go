@@ -0,0 +1,49 @@
+package consumer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNames(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "single consumer",
+			value: "account_mutation",
+			want:  []string{"account_mutation"},
+		},
+		{
+			name:  "list of consumers without duplication",
+			value: "account_mutation, hvt_balance_update,account_mutation",
+			want:  []string{"account_mutation", "hvt_balance_update"},
+		},
+		{
+			name:  "all consumers",
+			value: "all",
+			want:  Names,
+		},
+		{
+			name:    "unknown consumer",
+			value:   "account_mutation,unknown",
+			wantErr: true,
+		},
+		{
+			name:    "empty name",
+			value:   " , ",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNames(tt.value)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,15 +2,9 @@ package hvtbalanceupdate
 
 import (
 	"context"
-	"encoding/json"
 	"testing"
-	"time"
 
-	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
-	dlqpublisher "bitbucket.org/Amartha/go-fp-transaction/internal/common/dlq_publisher"
-	publisherMock "bitbucket.org/Amartha/go-fp-transaction/internal/common/dlq_publisher/mock"
-	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
-	kafkaMock "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/kafka/mock"
+	kafkacommon "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
 	repositoryMock "bitbucket.org/Amartha/go-fp-transaction/internal/repositories/mock"
@@ -27,7 +21,6 @@ type hvtBalanceUpdateHandlerHelper struct {
 	bs       *mock.MockBalanceService
 
 	cacheRepo *repositoryMock.MockCacheRepository
-	dlq       *publisherMock.MockPublisher
 	payload   []byte
 }
 
@@ -39,8 +32,9 @@ func newHvtBalanceUpdateHandlerHelper(t *testing.T) hvtBalanceUpdateHandlerHelpe
 
 	bs := mock.NewMockBalanceService(mockCtrl)
 	cacheRepo := repositoryMock.NewMockCacheRepository(mockCtrl)
-	dlq := publisherMock.NewMockPublisher(mockCtrl)
 	payload := []byte(`{
+	"walletTransactionId": "b7f6c2a1-5d2e-4c1b-9a55-0f3f7a0d2c11",
+	"refNumber": "REF-001",
 	"accountNumber": "211001000331186",
 	"updateAmount": {
 		"value": 9999.999,
@@ -53,7 +47,6 @@ func newHvtBalanceUpdateHandlerHelper(t *testing.T) hvtBalanceUpdateHandlerHelpe
 		bs:        bs,
 		cacheRepo: cacheRepo,
 		payload:   payload,
-		dlq:       dlq,
 	}
 }
 
@@ -61,15 +54,9 @@ func TestNewHvtBalanceUpdateHandler(t *testing.T) {
 	hh := newHvtBalanceUpdateHandlerHelper(t)
 	defer hh.mockCtrl.Finish()
 
-	cfg := &config.Config{
-		FeatureFlag: config.FeatureFlag{},
-	}
-
 	type args struct {
-		cfg       *config.Config
 		bs        services.BalanceService
 		cacheRepo repositories.CacheRepository
-		dlq       dlqpublisher.Publisher
 	}
 
 	tests := []struct {
@@ -81,116 +68,52 @@ func TestNewHvtBalanceUpdateHandler(t *testing.T) {
 			name: "success init HvtBalanceHandler",
 			args: args{
 				bs:        hh.bs,
-				cfg:       cfg,
 				cacheRepo: hh.cacheRepo,
-				dlq:       hh.dlq,
 			},
 			want: &HvtBalanceHandler{
-				clientId:        "",
-				bs:              hh.bs,
-				cacheRepo:       hh.cacheRepo,
-				dlq:             hh.dlq,
-				featureFlag:     cfg.FeatureFlag,
-				consumerMetrics: nil,
-			},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			assert.Equalf(t,
-				tt.want,
-				NewHvtBalanceHandler(
-					"",
-					tt.args.bs,
-					tt.args.cacheRepo,
-					tt.args.dlq,
-					tt.args.cfg.FeatureFlag,
-					nil),
-				"NewHvtBalanceHandler(%v)", tt.args.bs)
-		})
-	}
-}
-
-func TestHvtBalanceUpdateHandler_Cleanup(t *testing.T) {
-	hh := newHvtBalanceUpdateHandlerHelper(t)
-	defer hh.mockCtrl.Finish()
-
-	type fields struct {
-		bs  services.BalanceService
-		dlq dlqpublisher.Publisher
-	}
-	type args struct {
-		session sarama.ConsumerGroupSession
-	}
-
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
-	}{
-		{
-			name: "success cleanup",
-			fields: fields{
-				bs:  hh.bs,
-				dlq: hh.dlq,
+				bs:        hh.bs,
+				cacheRepo: hh.cacheRepo,
 			},
-			wantErr: false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			hh := HvtBalanceHandler{
-				bs: tt.fields.bs,
-			}
-			err := hh.Cleanup(tt.args.session)
-			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equalf(t, tt.want, NewHvtBalanceHandler(tt.args.bs, tt.args.cacheRepo), "NewHvtBalanceHandler(%v)", tt.args.bs)
 		})
 	}
-
 }
 
 func TestHvtBalanceUpdateHandler_processMessage(t *testing.T) {
 	hh := newHvtBalanceUpdateHandlerHelper(t)
 	defer hh.mockCtrl.Finish()
 
-	type fields struct {
-		bs services.BalanceService
-	}
 	type args struct {
 		message *sarama.ConsumerMessage
 	}
 
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		doMock  func()
-		wantErr bool
+		name          string
+		args          args
+		doMock        func()
+		wantErr       bool
+		wantPermanent bool
 	}{
 		{
 			name: "happy path",
-			fields: fields{
-				bs: hh.bs,
-			},
 			args: args{
 				message: &sarama.ConsumerMessage{Value: hh.payload},
 			},
 			doMock: func() {
 				hh.bs.EXPECT().AdjustAccountBalance(
 					gomock.AssignableToTypeOf(context.Background()),
-					gomock.Any(),
+					"211001000331186",
 					gomock.Any()).Return(nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "failed - err service",
-			fields: fields{
-				bs: hh.bs,
-			},
+			name: "failed - err service is not retried",
 			args: args{
 				message: &sarama.ConsumerMessage{Value: hh.payload},
 			},
@@ -200,102 +123,16 @@ func TestHvtBalanceUpdateHandler_processMessage(t *testing.T) {
 					gomock.Any(),
 					gomock.Any()).Return(assert.AnError)
 			},
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if tt.doMock != nil {
-				tt.doMock()
-			}
-
-			var payload models.UpdateBalanceHVTPayload
-			err := json.Unmarshal(tt.args.message.Value, &payload)
-			assert.NoError(t, err)
-
-			h := HvtBalanceHandler{
-				bs: tt.fields.bs,
-			}
-			err = h.processMessage(context.Background(), tt.args.message, &payload)
-			assert.Equal(t, tt.wantErr, err != nil, err)
-		})
-	}
-}
-
-func TestHvtBalanceUpdateHandler_Setup(t *testing.T) {
-	hh := newHvtBalanceUpdateHandlerHelper(t)
-	defer hh.mockCtrl.Finish()
-
-	type fields struct {
-		bs services.BalanceService
-	}
-	type args struct {
-		session sarama.ConsumerGroupSession
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
-	}{
-		{
-			name: "Success Setup",
-			fields: fields{
-				bs: hh.bs,
-			},
-			wantErr: false,
+			wantErr:       true,
+			wantPermanent: true,
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			th := HvtBalanceHandler{
-				bs: tt.fields.bs,
-			}
-			err := th.Setup(tt.args.session)
-			assert.Equal(t, tt.wantErr, err != nil)
-		})
-	}
-}
-
-func TestHvtBalanceUpdateHandler_Ack(t *testing.T) {
-	hh := newHvtBalanceUpdateHandlerHelper(t)
-	defer hh.mockCtrl.Finish()
-
-	mockSess := kafkaMock.NewMockConsumerGroupSession(hh.mockCtrl)
-
-	type fields struct {
-		bs services.BalanceService
-	}
-	type args struct {
-		ctx     context.Context
-		session sarama.ConsumerGroupSession
-		payload *ackPayload
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		doMock func()
-	}{
 		{
-			name: "success Ack message",
-			fields: fields{
-				bs: hh.bs,
-			},
+			name: "failed - err Unmarshal",
 			args: args{
-				ctx:     context.TODO(),
-				session: mockSess,
-				payload: &ackPayload{
-					message: &sarama.ConsumerMessage{
-						Value: []byte(`payload_here`),
-						Topic: "test",
-					},
-				},
-			},
-			doMock: func() {
-				mockSess.EXPECT().MarkMessage(gomock.Any(), "")
+				message: &sarama.ConsumerMessage{Value: []byte("{__INVALID_JSON_HERE")},
 			},
+			wantErr:       true,
+			wantPermanent: true,
 		},
 	}
 
@@ -305,352 +142,87 @@ func TestHvtBalanceUpdateHandler_Ack(t *testing.T) {
 				tt.doMock()
 			}
 
-			th := HvtBalanceHandler{
-				clientId:        "",
-				bs:              tt.fields.bs,
-				consumerMetrics: nil,
+			h := HvtBalanceHandler{
+				bs: hh.bs,
 			}
-			th.Ack(tt.args.session, tt.args.payload)
+			err := h.HandlerFunc()(context.Background(), tt.args.message)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantPermanent, kafkacommon.IsPermanent(err))
 		})
 	}
 }
 
-func TestHvtBalanceUpdateHandler_Nack(t *testing.T) {
+func TestHvtBalanceUpdateHandler_Idempotency(t *testing.T) {
 	hh := newHvtBalanceUpdateHandlerHelper(t)
 	defer hh.mockCtrl.Finish()
 
-	mockSess := kafkaMock.NewMockConsumerGroupSession(hh.mockCtrl)
-
-	type fields struct {
-		bs          services.BalanceService
-		dlq         dlqpublisher.Publisher
-		cacheRepo   repositories.CacheRepository
-		featureFlag config.FeatureFlag
-	}
-	type args struct {
-		ctx        context.Context
-		session    sarama.ConsumerGroupSession
-		payload    *ackPayload
-		consumeErr error
-	}
+	const key = "acuan:hvt:b7f6c2a1-5d2e-4c1b-9a55-0f3f7a0d2c11:REF-001:211001000331186"
 
 	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		doMock func(a args)
+		name     string
+		message  *sarama.ConsumerMessage
+		doMock   func()
+		wantErr  bool
+		wantSkip bool
 	}{
 		{
-			name: "success Nack message - dlq enabled",
-			fields: fields{
-				bs:        hh.bs,
-				dlq:       hh.dlq,
-				cacheRepo: hh.cacheRepo,
-				featureFlag: config.FeatureFlag{
-					EnablePublishHvtBalanceDLQ: true,
-				},
-			},
-			args: args{
-				session: mockSess,
-				payload: &ackPayload{
-					message: &sarama.ConsumerMessage{
-						Value: []byte(`payload_here`),
-						Topic: "test",
-					},
-				},
-				consumeErr: assert.AnError,
-			},
-			doMock: func(a args) {
-				hh.cacheRepo.EXPECT().Del(gomock.Any(), gomock.Any()).Return(nil)
-				hh.dlq.EXPECT().Publish(gomock.Any()).Return(nil)
-				mockSess.EXPECT().MarkMessage(gomock.Any(), "")
-			},
-		},
-		{
-			name: "success Nack message - dlq disabled",
-			fields: fields{
-				bs:        hh.bs,
-				dlq:       hh.dlq,
-				cacheRepo: hh.cacheRepo,
-				featureFlag: config.FeatureFlag{
-					EnablePublishHvtBalanceDLQ: false,
-				},
-			},
-			args: args{
-				session: mockSess,
-				payload: &ackPayload{
-					message: &sarama.ConsumerMessage{
-						Value: []byte(`payload_here`),
-						Topic: "test",
-					},
-				},
-				consumeErr: assert.AnError,
-			},
-			doMock: func(a args) {
-				hh.cacheRepo.EXPECT().Del(gomock.Any(), gomock.Any()).Return(nil)
-				mockSess.EXPECT().MarkMessage(gomock.Any(), "")
-			},
-		},
-		{
-			name: "Success Nack message - no send to DLQ (idempotency key existed)",
-			fields: fields{
-				bs:        hh.bs,
-				dlq:       hh.dlq,
-				cacheRepo: hh.cacheRepo,
-				featureFlag: config.FeatureFlag{
-					EnablePublishHvtBalanceDLQ: true,
-				},
-			},
-			args: args{
-				session: mockSess,
-				payload: &ackPayload{
-					message: &sarama.ConsumerMessage{
-						Value: []byte(`payload_here`),
-						Topic: "test",
-					},
-				},
-				consumeErr: common.ErrRequestBeingProcessed,
-			},
-			doMock: func(a args) {
-				mockSess.EXPECT().MarkMessage(gomock.Any(), "")
-			},
-		},
-		{
-			name: "Success Nack message but error while deleting idempotency key",
-			fields: fields{
-				bs:        hh.bs,
-				dlq:       hh.dlq,
-				cacheRepo: hh.cacheRepo,
-				featureFlag: config.FeatureFlag{
-					EnablePublishHvtBalanceDLQ: true,
-				},
-			},
-			args: args{
-				session: mockSess,
-				payload: &ackPayload{
-					message: &sarama.ConsumerMessage{
-						Value: []byte(`payload_here`),
-						Topic: "test",
-					},
-				},
-				consumeErr: assert.AnError,
-			},
-			doMock: func(a args) {
-				hh.cacheRepo.EXPECT().Del(gomock.Any(), gomock.Any()).Return(assert.AnError)
-				hh.dlq.EXPECT().Publish(gomock.Any()).Return(nil)
-				mockSess.EXPECT().MarkMessage(gomock.Any(), "")
+			name:    "success consume message",
+			message: &sarama.ConsumerMessage{Value: hh.payload},
+			doMock: func() {
+				hh.cacheRepo.EXPECT().SetIfNotExists(gomock.Any(), key, gomock.Any(), models.TTLIdempotency).Return(true, nil)
+				hh.bs.EXPECT().AdjustAccountBalance(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
 		},
 		{
-			name: "Success Nack message but publish dlq failed",
-			fields: fields{
-				bs:        hh.bs,
-				dlq:       hh.dlq,
-				cacheRepo: hh.cacheRepo,
-				featureFlag: config.FeatureFlag{
-					EnablePublishHvtBalanceDLQ: true,
-				},
-			},
-			args: args{
-				session: mockSess,
-				payload: &ackPayload{
-					message: &sarama.ConsumerMessage{
-						Value: []byte(`payload_here`),
-						Topic: "test",
-					},
-				},
-				consumeErr: assert.AnError,
-			},
-			doMock: func(a args) {
-				hh.cacheRepo.EXPECT().Del(gomock.Any(), gomock.Any()).Return(nil)
-				hh.dlq.EXPECT().Publish(gomock.Any()).Return(assert.AnError)
-				mockSess.EXPECT().MarkMessage(gomock.Any(), "")
-			},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if tt.doMock != nil {
-				tt.doMock(tt.args)
-			}
-
-			th := HvtBalanceHandler{
-				bs:          tt.fields.bs,
-				cacheRepo:   tt.fields.cacheRepo,
-				dlq:         tt.fields.dlq,
-				featureFlag: tt.fields.featureFlag,
-			}
-			th.Nack(tt.args.ctx, tt.args.session, tt.args.payload, tt.args.consumeErr)
-		})
-	}
-}
-
-func TestHvtBalanceUpdateHandler_ConsumeClaim(t *testing.T) {
-	hh := newHvtBalanceUpdateHandlerHelper(t)
-	defer hh.mockCtrl.Finish()
-
-	type fields struct {
-		bs          *mock.MockBalanceService
-		ctx         context.Context
-		ctxCancel   context.CancelFunc
-		msg         chan *sarama.ConsumerMessage
-		dlq         *publisherMock.MockPublisher
-		cacheRepo   *repositoryMock.MockCacheRepository
-		featureFlag config.FeatureFlag
-	}
-
-	type args struct {
-		session *kafkaMock.MockConsumerGroupSession
-		claim   *kafkaMock.MockConsumerGroupClaim
-	}
-	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		doMock  func(a args, f fields)
-		wantErr bool
-	}{
-		{
-			name: "success consume message",
-			fields: fields{
-				bs:        hh.bs,
-				msg:       make(chan *sarama.ConsumerMessage, 1),
-				dlq:       hh.dlq,
-				cacheRepo: hh.cacheRepo,
-				featureFlag: config.FeatureFlag{
-					EnablePublishHvtBalanceDLQ: true,
-				},
-			},
-			args: args{
-				session: kafkaMock.NewMockConsumerGroupSession(hh.mockCtrl),
-				claim:   kafkaMock.NewMockConsumerGroupClaim(hh.mockCtrl),
-			},
-			doMock: func(a args, f fields) {
-				f.msg <- &sarama.ConsumerMessage{
-					Value: hh.payload,
-				}
-
-				a.claim.EXPECT().Messages().Return(f.msg).AnyTimes()
-				a.session.EXPECT().Context().Return(f.ctx).AnyTimes()
-				f.cacheRepo.EXPECT().SetIfNotExists(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any())
-				f.bs.EXPECT().AdjustAccountBalance(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
-				a.session.EXPECT().MarkMessage(gomock.Any(), gomock.Any()).AnyTimes()
+			name: "success consume partial capture with its own key",
+			message: &sarama.ConsumerMessage{Value: []byte(`{
+				"walletTransactionId": "b7f6c2a1-5d2e-4c1b-9a55-0f3f7a0d2c11",
+				"refNumber": "REF-001",
+				"accountNumber": "211001000331186",
+				"updateAmount": {"value": 4000, "currency": "IDR"},
+				"capturedAmount": "8000"
+			}`)},
+			doMock: func() {
+				hh.cacheRepo.EXPECT().SetIfNotExists(gomock.Any(), key+":8000", gomock.Any(), models.TTLIdempotency).Return(true, nil)
+				hh.bs.EXPECT().AdjustAccountBalance(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 			},
-			wantErr: false,
 		},
 		{
-			name: "failed - err Unmarshal",
-			fields: fields{
-				bs:        hh.bs,
-				msg:       make(chan *sarama.ConsumerMessage, 1),
-				dlq:       hh.dlq,
-				cacheRepo: hh.cacheRepo,
-				featureFlag: config.FeatureFlag{
-					EnablePublishHvtBalanceDLQ: true,
-				},
-			},
-			args: args{
-				session: kafkaMock.NewMockConsumerGroupSession(hh.mockCtrl),
-				claim:   kafkaMock.NewMockConsumerGroupClaim(hh.mockCtrl),
-			},
-			doMock: func(a args, f fields) {
-				f.msg <- &sarama.ConsumerMessage{
-					Value: []byte("{__INVALID_JSON_HERE"),
-				}
-
-				a.claim.EXPECT().Messages().Return(f.msg).AnyTimes()
-				a.session.EXPECT().Context().Return(f.ctx).AnyTimes()
-
-				f.cacheRepo.EXPECT().Del(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
-				f.dlq.EXPECT().Publish(gomock.Any()).Return(nil).AnyTimes()
-				a.session.EXPECT().MarkMessage(gomock.Any(), gomock.Any()).AnyTimes()
+			name:    "failed consume message and release idempotency key",
+			message: &sarama.ConsumerMessage{Value: hh.payload},
+			doMock: func() {
+				hh.cacheRepo.EXPECT().SetIfNotExists(gomock.Any(), key, gomock.Any(), models.TTLIdempotency).Return(true, nil)
+				hh.bs.EXPECT().AdjustAccountBalance(gomock.Any(), gomock.Any(), gomock.Any()).Return(assert.AnError)
+				hh.cacheRepo.EXPECT().Del(gomock.Any(), key).Return(nil)
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 		{
-			name: "failed consume message and send to DLQ",
-			fields: fields{
-				bs:        hh.bs,
-				msg:       make(chan *sarama.ConsumerMessage, 1),
-				dlq:       hh.dlq,
-				cacheRepo: hh.cacheRepo,
-				featureFlag: config.FeatureFlag{
-					EnablePublishHvtBalanceDLQ: true,
-				},
-			},
-			args: args{
-				session: kafkaMock.NewMockConsumerGroupSession(hh.mockCtrl),
-				claim:   kafkaMock.NewMockConsumerGroupClaim(hh.mockCtrl),
-			},
-			doMock: func(a args, f fields) {
-				f.msg <- &sarama.ConsumerMessage{
-					Value: hh.payload,
-				}
-
-				str := ""
-
-				a.claim.EXPECT().Messages().Return(f.msg).AnyTimes()
-				a.session.EXPECT().Context().Return(f.ctx).AnyTimes()
-
-				f.cacheRepo.EXPECT().SetIfNotExists(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
-				f.bs.EXPECT().AdjustAccountBalance(gomock.Any(), gomock.AssignableToTypeOf(str), gomock.Any()).Return(assert.AnError).AnyTimes()
-
-				f.cacheRepo.EXPECT().Del(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
-				f.dlq.EXPECT().Publish(gomock.Any()).Return(nil).AnyTimes()
-				a.session.EXPECT().MarkMessage(gomock.Any(), gomock.Any()).AnyTimes()
+			name:    "Idempotency key already exist",
+			message: &sarama.ConsumerMessage{Value: hh.payload},
+			doMock: func() {
+				hh.cacheRepo.EXPECT().SetIfNotExists(gomock.Any(), key, gomock.Any(), models.TTLIdempotency).Return(false, nil)
 			},
-			wantErr: false,
+			wantErr:  true,
+			wantSkip: true,
 		},
 		{
-			name: "Idempotency key already exist",
-			fields: fields{
-				bs:        hh.bs,
-				msg:       make(chan *sarama.ConsumerMessage, 1),
-				dlq:       hh.dlq,
-				cacheRepo: hh.cacheRepo,
-				featureFlag: config.FeatureFlag{
-					EnablePublishHvtBalanceDLQ: true,
-				},
-			},
-			args: args{
-				session: kafkaMock.NewMockConsumerGroupSession(hh.mockCtrl),
-				claim:   kafkaMock.NewMockConsumerGroupClaim(hh.mockCtrl),
-			},
-			doMock: func(a args, f fields) {
-				f.msg <- &sarama.ConsumerMessage{
-					Value: hh.payload,
-				}
-
-				a.claim.EXPECT().Messages().Return(f.msg).AnyTimes()
-				a.session.EXPECT().Context().Return(f.ctx).AnyTimes()
-
-				f.cacheRepo.EXPECT().SetIfNotExists(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
-				a.session.EXPECT().MarkMessage(gomock.Any(), gomock.Any()).AnyTimes()
-			},
-			wantErr: false,
+			name:    "failed - err Unmarshal",
+			message: &sarama.ConsumerMessage{Value: []byte("{__INVALID_JSON_HERE")},
+			wantErr: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.fields.ctx, tt.fields.ctxCancel = context.WithTimeout(context.Background(), 1*time.Second)
-			defer tt.fields.ctxCancel()
-
 			if tt.doMock != nil {
-				tt.doMock(tt.args, tt.fields)
-			}
-
-			hh := HvtBalanceHandler{
-				clientId:  "",
-				bs:        hh.bs,
-				cacheRepo: hh.cacheRepo,
-				dlq:       hh.dlq,
+				tt.doMock()
 			}
 
-			err := hh.ConsumeClaim(tt.args.session, tt.args.claim)
-			assert.Equal(t, tt.wantErr, err != nil)
+			h := NewHvtBalanceHandler(hh.bs, hh.cacheRepo)
+			err := kafkacommon.Chain(h.HandlerFunc(), h.Idempotency())(context.Background(), tt.message)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.wantSkip, kafkacommon.IsSkip(err))
 		})
 	}
-
 }
@@ -0,0 +1,252 @@
+package kafkaconsumer
+
+import (
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"slices"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/http"
+	kafkacommon "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Consumer is a consumer of the process which is controlled by the admin endpoints
+type Consumer interface {
+	ConsumerGroup() string
+	Topics() []string
+	Pause()
+	Resume()
+	IsPaused() bool
+	ResetOffsets(offsets map[string]map[int32]int64) error
+}
+
+type Admin struct {
+	consumers map[string]Consumer
+	offsets   kafkacommon.OffsetReader
+}
+
+func NewAdmin(consumers map[string]Consumer, offsets kafkacommon.OffsetReader) *Admin {
+	return &Admin{
+		consumers: consumers,
+		offsets:   offsets,
+	}
+}
+
+func (a *Admin) Route(g *echo.Group) {
+	g.GET("", a.getList)
+	g.GET("/:name/lag", a.getLag)
+	g.POST("/:name/pause", a.pause)
+	g.POST("/:name/resume", a.resume)
+	g.POST("/:name/reset-offsets", a.resetOffsets)
+}
+
+type (
+	DoGetConsumerResponse struct {
+		Name          string   `json:"name" example:"account_mutation"`
+		ConsumerGroup string   `json:"consumerGroup" example:"go_fp_transaction_account_mutation"`
+		Topics        []string `json:"topics"`
+		Paused        bool     `json:"paused"`
+	}
+
+	DoGetConsumerLagResponse struct {
+		Name          string                     `json:"name" example:"account_mutation"`
+		ConsumerGroup string                     `json:"consumerGroup" example:"go_fp_transaction_account_mutation"`
+		TotalLag      int64                      `json:"totalLag"`
+		Partitions    []kafkacommon.PartitionLag `json:"partitions"`
+	}
+
+	DoResetConsumerOffsetsRequest struct {
+		Timestamp time.Time `json:"timestamp" example:"2024-01-02T15:04:05+07:00"`
+	}
+
+	DoResetConsumerOffsetsResponse struct {
+		Name          string                     `json:"name" example:"account_mutation"`
+		ConsumerGroup string                     `json:"consumerGroup" example:"go_fp_transaction_account_mutation"`
+		Timestamp     time.Time                  `json:"timestamp"`
+		Offsets       map[string]map[int32]int64 `json:"offsets"`
+	}
+)
+
+// getList godoc
+// @Summary Get list consumer
+// @Description Get consumers run by the process with their consumer group and pause state
+// @Tags Consumer Admin
+// @Produce json
+// @Success 200 {object} http.RestTotalRowResponseModel
+// @Failure 401 {object} http.RestErrorResponseModel
+// @Router /admin/consumers [get]
+func (a *Admin) getList(c echo.Context) error {
+	names := make([]string, 0, len(a.consumers))
+	for name := range a.consumers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	res := make([]DoGetConsumerResponse, 0, len(names))
+	for _, name := range names {
+		res = append(res, toConsumerResponse(name, a.consumers[name]))
+	}
+
+	return http.RestSuccessResponseListWithTotalRows(c, res, len(res))
+}
+
+// getLag godoc
+// @Summary Get consumer lag
+// @Description Get committed offset, newest offset and lag of every partition consumed by the consumer group
+// @Tags Consumer Admin
+// @Produce json
+// @Param name path string true "consumer name"
+// @Success 200 {object} DoGetConsumerLagResponse
+// @Failure 401 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /admin/consumers/{name}/lag [get]
+func (a *Admin) getLag(c echo.Context) error {
+	name, consumer, err := a.consumer(c)
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusNotFound, err)
+	}
+
+	partitions, err := a.offsets.GroupLag(consumer.ConsumerGroup(), consumer.Topics())
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusInternalServerError, err)
+	}
+
+	res := DoGetConsumerLagResponse{
+		Name:          name,
+		ConsumerGroup: consumer.ConsumerGroup(),
+		Partitions:    partitions,
+	}
+	for _, partition := range partitions {
+		res.TotalLag += partition.Lag
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, res)
+}
+
+// pause godoc
+// @Summary Pause consumer
+// @Description Stop fetching messages of the consumer until it is resumed, messages already fetched are still processed
+// @Tags Consumer Admin
+// @Produce json
+// @Param name path string true "consumer name"
+// @Success 200 {object} DoGetConsumerResponse
+// @Failure 401 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Router /admin/consumers/{name}/pause [post]
+func (a *Admin) pause(c echo.Context) error {
+	name, consumer, err := a.consumer(c)
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusNotFound, err)
+	}
+
+	consumer.Pause()
+	xlog.Info(c.Request().Context(), "[CONSUMER-ADMIN]", xlog.String("consumer", name), xlog.String("action", "pause"))
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, toConsumerResponse(name, consumer))
+}
+
+// resume godoc
+// @Summary Resume consumer
+// @Description Continue fetching messages of the consumer paused by the admin endpoint
+// @Tags Consumer Admin
+// @Produce json
+// @Param name path string true "consumer name"
+// @Success 200 {object} DoGetConsumerResponse
+// @Failure 401 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Router /admin/consumers/{name}/resume [post]
+func (a *Admin) resume(c echo.Context) error {
+	name, consumer, err := a.consumer(c)
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusNotFound, err)
+	}
+
+	consumer.Resume()
+	xlog.Info(c.Request().Context(), "[CONSUMER-ADMIN]", xlog.String("consumer", name), xlog.String("action", "resume"))
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, toConsumerResponse(name, consumer))
+}
+
+// resetOffsets godoc
+// @Summary Reset consumer offsets
+// @Description Move the consumer to the first message produced at or after the timestamp to replay the messages.
+// @Description The consumer rejoins the group and the offsets are applied on the partitions claimed by this process,
+// @Description so every instance of the consumer has to be reset to replay the whole topic
+// @Tags Consumer Admin
+// @Accept json
+// @Produce json
+// @Param name path string true "consumer name"
+// @Param body body DoResetConsumerOffsetsRequest true "body"
+// @Success 202 {object} DoResetConsumerOffsetsResponse
+// @Failure 400 {object} http.RestErrorResponseModel
+// @Failure 401 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Failure 409 {object} http.RestErrorResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /admin/consumers/{name}/reset-offsets [post]
+func (a *Admin) resetOffsets(c echo.Context) error {
+	name, consumer, err := a.consumer(c)
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusNotFound, err)
+	}
+
+	req := new(DoResetConsumerOffsetsRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if req.Timestamp.IsZero() {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, errors.New("timestamp is required"))
+	}
+
+	if req.Timestamp.After(time.Now()) {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, errors.New("timestamp must not be in the future"))
+	}
+
+	offsets, err := a.offsets.OffsetsForTime(consumer.Topics(), req.Timestamp)
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusInternalServerError, err)
+	}
+
+	if err := consumer.ResetOffsets(offsets); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusConflict, err)
+	}
+
+	xlog.Info(c.Request().Context(), "[CONSUMER-ADMIN]",
+		xlog.String("consumer", name),
+		xlog.String("action", "reset offsets"),
+		xlog.Time("timestamp", req.Timestamp),
+	)
+
+	return http.RestSuccessResponse(c, nethttp.StatusAccepted, DoResetConsumerOffsetsResponse{
+		Name:          name,
+		ConsumerGroup: consumer.ConsumerGroup(),
+		Timestamp:     req.Timestamp,
+		Offsets:       offsets,
+	})
+}
+
+func (a *Admin) consumer(c echo.Context) (string, Consumer, error) {
+	name := c.Param("name")
+	consumer, ok := a.consumers[name]
+	if !ok {
+		return name, nil, fmt.Errorf("consumer %s is not run by this process", name)
+	}
+
+	return name, consumer, nil
+}
+
+func toConsumerResponse(name string, consumer Consumer) DoGetConsumerResponse {
+	return DoGetConsumerResponse{
+		Name:          name,
+		ConsumerGroup: consumer.ConsumerGroup(),
+		Topics:        consumer.Topics(),
+		Paused:        consumer.IsPaused(),
+	}
+}
@@ -0,0 +1,240 @@
+package kafkaconsumer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	kafkacommon "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
+	kafkaMock "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/kafka/mock"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testAdminHelper struct {
+	router      *echo.Echo
+	mockCtrl    *gomock.Controller
+	mockConsume *mock.MockConsumer
+	mockOffsets *kafkaMock.MockOffsetReader
+}
+
+func Test_Admin_getList(t *testing.T) {
+	testHelper := adminTestHelper(t)
+
+	testHelper.mockConsume.EXPECT().ConsumerGroup().Return("group")
+	testHelper.mockConsume.EXPECT().Topics().Return([]string{"topic"})
+	testHelper.mockConsume.EXPECT().IsPaused().Return(true)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/consumers", nil)
+	rec := httptest.NewRecorder()
+	testHelper.router.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"name":"account_mutation"`)
+	assert.Contains(t, rec.Body.String(), `"paused":true`)
+}
+
+func Test_Admin_getLag(t *testing.T) {
+	testHelper := adminTestHelper(t)
+
+	tests := []struct {
+		name      string
+		urlCalled string
+		doMock    func()
+		wantCode  int
+		wantLag   int64
+	}{
+		{
+			name:      "success",
+			urlCalled: "/api/admin/consumers/account_mutation/lag",
+			doMock: func() {
+				testHelper.mockConsume.EXPECT().ConsumerGroup().Return("group").Times(2)
+				testHelper.mockConsume.EXPECT().Topics().Return([]string{"topic"})
+				testHelper.mockOffsets.EXPECT().GroupLag("group", []string{"topic"}).Return([]kafkacommon.PartitionLag{
+					{Topic: "topic", Partition: 0, Committed: 5, Newest: 10, Lag: 5},
+					{Topic: "topic", Partition: 1, Committed: 7, Newest: 10, Lag: 3},
+				}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantLag:  8,
+		},
+		{
+			name:      "consumer not found",
+			urlCalled: "/api/admin/consumers/unknown/lag",
+			wantCode:  http.StatusNotFound,
+		},
+		{
+			name:      "failed get lag",
+			urlCalled: "/api/admin/consumers/account_mutation/lag",
+			doMock: func() {
+				testHelper.mockConsume.EXPECT().ConsumerGroup().Return("group")
+				testHelper.mockConsume.EXPECT().Topics().Return([]string{"topic"})
+				testHelper.mockOffsets.EXPECT().GroupLag("group", []string{"topic"}).Return(nil, assert.AnError)
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tc.urlCalled, nil)
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.wantCode, rec.Code)
+			if tc.wantCode == http.StatusOK {
+				var res DoGetConsumerLagResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+				assert.Equal(t, tc.wantLag, res.TotalLag)
+				assert.Len(t, res.Partitions, 2)
+			}
+		})
+	}
+}
+
+func Test_Admin_pauseResume(t *testing.T) {
+	testHelper := adminTestHelper(t)
+
+	gomock.InOrder(
+		testHelper.mockConsume.EXPECT().Pause(),
+		testHelper.mockConsume.EXPECT().Resume(),
+	)
+	testHelper.mockConsume.EXPECT().ConsumerGroup().Return("group").AnyTimes()
+	testHelper.mockConsume.EXPECT().Topics().Return([]string{"topic"}).AnyTimes()
+	testHelper.mockConsume.EXPECT().IsPaused().Return(false).AnyTimes()
+
+	for _, action := range []string{"pause", "resume"} {
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/consumers/account_mutation/"+action, nil)
+		rec := httptest.NewRecorder()
+		testHelper.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code, action)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/consumers/unknown/pause", nil)
+	rec := httptest.NewRecorder()
+	testHelper.router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func Test_Admin_resetOffsets(t *testing.T) {
+	testHelper := adminTestHelper(t)
+
+	timestamp := time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+	offsets := map[string]map[int32]int64{"topic": {0: 3, 1: 4}}
+
+	tests := []struct {
+		name      string
+		urlCalled string
+		body      string
+		doMock    func()
+		wantCode  int
+	}{
+		{
+			name:      "success",
+			urlCalled: "/api/admin/consumers/account_mutation/reset-offsets",
+			body:      `{"timestamp":"2024-01-02T15:04:05Z"}`,
+			doMock: func() {
+				testHelper.mockConsume.EXPECT().Topics().Return([]string{"topic"})
+				testHelper.mockConsume.EXPECT().ConsumerGroup().Return("group")
+				testHelper.mockOffsets.EXPECT().OffsetsForTime([]string{"topic"}, timestamp).Return(offsets, nil)
+				testHelper.mockConsume.EXPECT().ResetOffsets(offsets).Return(nil)
+			},
+			wantCode: http.StatusAccepted,
+		},
+		{
+			name:      "consumer not found",
+			urlCalled: "/api/admin/consumers/unknown/reset-offsets",
+			body:      `{"timestamp":"2024-01-02T15:04:05Z"}`,
+			wantCode:  http.StatusNotFound,
+		},
+		{
+			name:      "timestamp is required",
+			urlCalled: "/api/admin/consumers/account_mutation/reset-offsets",
+			body:      `{}`,
+			wantCode:  http.StatusBadRequest,
+		},
+		{
+			name:      "timestamp in the future",
+			urlCalled: "/api/admin/consumers/account_mutation/reset-offsets",
+			body:      `{"timestamp":"` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`,
+			wantCode:  http.StatusBadRequest,
+		},
+		{
+			name:      "failed get offsets",
+			urlCalled: "/api/admin/consumers/account_mutation/reset-offsets",
+			body:      `{"timestamp":"2024-01-02T15:04:05Z"}`,
+			doMock: func() {
+				testHelper.mockConsume.EXPECT().Topics().Return([]string{"topic"})
+				testHelper.mockOffsets.EXPECT().OffsetsForTime([]string{"topic"}, timestamp).Return(nil, assert.AnError)
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name:      "consumer is not started",
+			urlCalled: "/api/admin/consumers/account_mutation/reset-offsets",
+			body:      `{"timestamp":"2024-01-02T15:04:05Z"}`,
+			doMock: func() {
+				testHelper.mockConsume.EXPECT().Topics().Return([]string{"topic"})
+				testHelper.mockOffsets.EXPECT().OffsetsForTime([]string{"topic"}, timestamp).Return(offsets, nil)
+				testHelper.mockConsume.EXPECT().ResetOffsets(offsets).Return(assert.AnError)
+			},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			req := httptest.NewRequest(http.MethodPost, tc.urlCalled, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.wantCode, rec.Code)
+		})
+	}
+}
+
+func adminTestHelper(t *testing.T) testAdminHelper {
+	t.Helper()
+
+	mockCtrl := gomock.NewController(t)
+
+	mockConsume := mock.NewMockConsumer(mockCtrl)
+	mockOffsets := kafkaMock.NewMockOffsetReader(mockCtrl)
+
+	app := echo.New()
+	app.Pre(echomiddleware.RemoveTrailingSlash())
+	NewAdmin(map[string]Consumer{"account_mutation": mockConsume}, mockOffsets).Route(app.Group("/api/admin/consumers"))
+
+	return testAdminHelper{
+		router:      app,
+		mockCtrl:    mockCtrl,
+		mockConsume: mockConsume,
+		mockOffsets: mockOffsets,
+	}
+}
+
+func TestMain(m *testing.M) {
+	xlog.InitForTest()
+	os.Exit(m.Run())
+}
@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/deliveries/consumer/kafka/admin.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/deliveries/consumer/kafka/admin.go -destination=./internal/deliveries/consumer/kafka/mock/admin_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockConsumer is a mock of Consumer interface.
+type MockConsumer struct {
+	ctrl     *gomock.Controller
+	recorder *MockConsumerMockRecorder
+	isgomock struct{}
+}
+
+// MockConsumerMockRecorder is the mock recorder for MockConsumer.
+type MockConsumerMockRecorder struct {
+	mock *MockConsumer
+}
+
+// NewMockConsumer creates a new mock instance.
+func NewMockConsumer(ctrl *gomock.Controller) *MockConsumer {
+	mock := &MockConsumer{ctrl: ctrl}
+	mock.recorder = &MockConsumerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConsumer) EXPECT() *MockConsumerMockRecorder {
+	return m.recorder
+}
+
+// ConsumerGroup mocks base method.
+func (m *MockConsumer) ConsumerGroup() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumerGroup")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ConsumerGroup indicates an expected call of ConsumerGroup.
+func (mr *MockConsumerMockRecorder) ConsumerGroup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumerGroup", reflect.TypeOf((*MockConsumer)(nil).ConsumerGroup))
+}
+
+// IsPaused mocks base method.
+func (m *MockConsumer) IsPaused() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPaused")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsPaused indicates an expected call of IsPaused.
+func (mr *MockConsumerMockRecorder) IsPaused() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPaused", reflect.TypeOf((*MockConsumer)(nil).IsPaused))
+}
+
+// Pause mocks base method.
+func (m *MockConsumer) Pause() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Pause")
+}
+
+// Pause indicates an expected call of Pause.
+func (mr *MockConsumerMockRecorder) Pause() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pause", reflect.TypeOf((*MockConsumer)(nil).Pause))
+}
+
+// ResetOffsets mocks base method.
+func (m *MockConsumer) ResetOffsets(offsets map[string]map[int32]int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetOffsets", offsets)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetOffsets indicates an expected call of ResetOffsets.
+func (mr *MockConsumerMockRecorder) ResetOffsets(offsets any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetOffsets", reflect.TypeOf((*MockConsumer)(nil).ResetOffsets), offsets)
+}
+
+// Resume mocks base method.
+func (m *MockConsumer) Resume() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Resume")
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockConsumerMockRecorder) Resume() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockConsumer)(nil).Resume))
+}
+
+// Topics mocks base method.
+func (m *MockConsumer) Topics() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Topics")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Topics indicates an expected call of Topics.
+func (mr *MockConsumerMockRecorder) Topics() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Topics", reflect.TypeOf((*MockConsumer)(nil).Topics))
+}
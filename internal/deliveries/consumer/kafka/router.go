@@ -11,6 +11,7 @@ import (
 	echomiddleware "github.com/labstack/echo/v4/middleware"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/graceful"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/http/middleware"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/metrics"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/health"
@@ -41,6 +42,7 @@ func NewHTTPServer(
 	conf config.Config,
 	metrics metrics.Metrics,
 	check *health.HealthCheck,
+	admin *Admin,
 ) *svc {
 	app := echo.New()
 	svc := &svc{e: app, addr: fmt.Sprintf(":%d", conf.MessageBroker.HTTPPort), gracefulTimeout: conf.App.GracefulTimeout}
@@ -67,5 +69,11 @@ func NewHTTPServer(
 	// health check
 	check.Route(apiGroup.Group("/health"))
 
+	// admin of the consumers, the scope of the client is checked on every route
+	m := middleware.NewMiddleware(conf, nil, nil)
+	adminGroup := apiGroup.Group("/admin/consumers")
+	adminGroup.Use(m.InternalAuth)
+	admin.Route(adminGroup)
+
 	return svc
 }
@@ -4,46 +4,27 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"time"
 
 	goacuanlib "bitbucket.org/Amartha/go-acuan-lib/model"
-	dlqpublisher "bitbucket.org/Amartha/go-fp-transaction/internal/common/dlq_publisher"
 	kafkacommon "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
 	xlog "bitbucket.org/Amartha/go-x/log"
 
-	"bitbucket.org/Amartha/go-fp-transaction/internal/common/metrics"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
-	"bitbucket.org/Amartha/go-x/log/audit"
-	"bitbucket.org/Amartha/go-x/log/ctxdata"
 
 	"github.com/Shopify/sarama"
-	"github.com/google/uuid"
 )
 
 type MoneyFlowCalcHandler struct {
-	kafkacommon.BaseHandler
 	mfs           services.MoneyFlowService
 	cfg           config.Config
 	messageParser *MessageParser
 	errorHandler  *ErrorHandler
 }
 
-func NewMoneyFlowCalcHandler(
-	clientId string,
-	mfs services.MoneyFlowService,
-	dlq dlqpublisher.Publisher,
-	cfg config.Config,
-	consumerMetrics *metrics.ConsumerMetrics,
-) sarama.ConsumerGroupHandler {
+func NewMoneyFlowCalcHandler(mfs services.MoneyFlowService, cfg config.Config) *MoneyFlowCalcHandler {
 	return &MoneyFlowCalcHandler{
-		BaseHandler: kafkacommon.BaseHandler{
-			ClientID:        clientId,
-			ConsumerMetrics: consumerMetrics,
-			DLQ:             dlq,
-			LogPrefix:       logMessage,
-		},
 		mfs:           mfs,
 		cfg:           cfg,
 		messageParser: NewMessageParser(),
@@ -51,96 +32,23 @@ func NewMoneyFlowCalcHandler(
 	}
 }
 
-func (mfc MoneyFlowCalcHandler) Setup(_ sarama.ConsumerGroupSession) error {
-	return nil
-}
-
-func (mfc MoneyFlowCalcHandler) Cleanup(_ sarama.ConsumerGroupSession) error {
-	return nil
+// HandlerFunc return the handler of transaction notification, message which can not be parsed is sent to DLQ
+func (mfc MoneyFlowCalcHandler) HandlerFunc() kafkacommon.HandlerFunc {
+	return kafkacommon.Typed(mfc.messageParser.Parse, mfc.processMessage)
 }
 
-func (mfc MoneyFlowCalcHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	for {
-		select {
-		case message, ok := <-claim.Messages():
-			if !ok {
-				return nil
-			}
-			mfc.handleMessage(session, message)
-		case <-session.Context().Done():
-			return nil
-		}
-	}
-}
-
-// handleMessage processes a single Kafka message
-func (mfc MoneyFlowCalcHandler) handleMessage(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage) {
-	ctx := mfc.createContext(session, message)
-	start := time.Now()
-	logField := mfc.CreateLogField(message)
-
-	err, shouldSkip := mfc.processMessage(ctx, message)
-
-	mfc.handleProcessingResult(ctx, session, message, err, shouldSkip, logField, start)
-}
-
-// createContext creates context with correlation ID and host
-func (mfc MoneyFlowCalcHandler) createContext(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage) context.Context {
-	return ctxdata.Sets(session.Context(),
-		ctxdata.SetCorrelationId(uuid.New().String()),
-		ctxdata.SetHost(mfc.ClientID),
-	)
-}
-
-// handleProcessingResult handles the result of message processing
-func (mfc MoneyFlowCalcHandler) handleProcessingResult(
-	ctx context.Context,
-	session sarama.ConsumerGroupSession,
-	message *sarama.ConsumerMessage,
-	err error,
-	shouldSkip bool,
-	logField []xlog.Field,
-	start time.Time,
-) {
-	logField = append(logField, xlog.Duration("response-time", time.Since(start)))
-
+// processMessage processes a transaction notification, ineligible transaction is skipped without DLQ
+func (mfc MoneyFlowCalcHandler) processMessage(ctx context.Context, _ *sarama.ConsumerMessage, notification goacuanlib.Payload[goacuanlib.DataOrder]) error {
+	err := mfc.mfs.ProcessTransactionNotification(ctx, notification)
 	if err != nil {
-		logField = append(logField, xlog.Err(err))
+		processErr, shouldSkip := mfc.errorHandler.HandleProcessingError(ctx, err)
 		if shouldSkip {
-			xlog.Info(ctx, logMessage, append(logField, xlog.String("action", "skipped"))...)
-			mfc.Ack(session, message)
-		} else {
-			xlog.Warn(ctx, logMessage, append(logField, xlog.String("action", "sent_to_dlq"))...)
-			mfc.Nack(ctx, session, message, err)
+			return kafkacommon.Skip(processErr)
 		}
-		return
-	}
-
-	xlog.Info(ctx, logMessage, logField...)
-	audit.Info(ctx, audit.Message{ActivityData: string(message.Value)})
-	mfc.Ack(session, message)
-}
-
-// processMessage processes a Kafka message and returns (error, shouldSkip)
-func (mfc MoneyFlowCalcHandler) processMessage(ctx context.Context, message *sarama.ConsumerMessage) (error, bool) {
-	startTime := time.Now()
-	defer func() {
-		mfc.RecordMetrics(startTime, message, nil)
-	}()
-
-	// Parse message
-	notification, err := mfc.messageParser.Parse(ctx, message.Value)
-	if err != nil {
-		return err, false // Parsing errors go to DLQ
-	}
-
-	// Process notification
-	err = mfc.mfs.ProcessTransactionNotification(ctx, notification)
-	if err != nil {
-		return mfc.errorHandler.HandleProcessingError(ctx, err)
+		return processErr
 	}
 
-	return nil, false
+	return nil
 }
 
 // MessageParser handles message parsing logic
@@ -151,7 +59,7 @@ func NewMessageParser() *MessageParser {
 }
 
 // Parse parses Kafka message into notification
-func (mp *MessageParser) Parse(ctx context.Context, data []byte) (goacuanlib.Payload[goacuanlib.DataOrder], error) {
+func (mp *MessageParser) Parse(data []byte) (goacuanlib.Payload[goacuanlib.DataOrder], error) {
 	var rawNotif models.TransactionNotificationRaw
 	if err := json.Unmarshal(data, &rawNotif); err != nil {
 		return goacuanlib.Payload[goacuanlib.DataOrder]{}, fmt.Errorf("error unmarshal json to raw: %w", err)
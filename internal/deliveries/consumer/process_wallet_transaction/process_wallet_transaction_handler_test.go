@@ -0,0 +1,168 @@
+package process_wallet_transaction
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	kafkacommon "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	repositoryMock "bitbucket.org/Amartha/go-fp-transaction/internal/repositories/mock"
+	serviceMock "bitbucket.org/Amartha/go-fp-transaction/internal/services/mock"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestProcessWalletTransactionHandler_processMessage(t *testing.T) {
+	payload := []byte(`{"accountNumber":"222","refNumber":"333","transactionType":"TUPVA","ClientId":"async-client","AsyncId":"0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e"}`)
+	redisKey := "go_fp_transaction_wallet_transaction_idempotency-key:lock"
+
+	newMessage := func(value []byte) *sarama.ConsumerMessage {
+		return &sarama.ConsumerMessage{
+			Value: value,
+			Headers: []*sarama.RecordHeader{
+				{Key: []byte(models.IdempotencyKeyHeader), Value: []byte("idempotency-key")},
+			},
+		}
+	}
+
+	type mocks struct {
+		cacheRepo *repositoryMock.MockCacheRepository
+		wts       *serviceMock.MockWalletTrxService
+	}
+
+	tests := []struct {
+		name          string
+		message       *sarama.ConsumerMessage
+		doMock        func(m mocks)
+		wantErr       bool
+		wantSkip      bool
+		wantPermanent bool
+	}{
+		{
+			name:    "success process enqueued transaction",
+			message: newMessage(payload),
+			doMock: func(m mocks) {
+				m.cacheRepo.EXPECT().SetIfNotExists(gomock.Any(), redisKey, "processing", idempotencyTTL).Return(true, nil)
+				m.wts.EXPECT().ProcessAsyncTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, in models.CreateWalletTransactionRequest) (*models.WalletTransaction, error) {
+						assert.Equal(t, "async-client", in.ClientId)
+						assert.Equal(t, "0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e", in.AsyncId)
+						return &models.WalletTransaction{ID: "41d03147-c017-4176-8a1a-0b7ec735cc29"}, nil
+					})
+			},
+		},
+		{
+			name:    "skip message which has been processed",
+			message: newMessage(payload),
+			doMock: func(m mocks) {
+				m.cacheRepo.EXPECT().SetIfNotExists(gomock.Any(), redisKey, "processing", idempotencyTTL).Return(false, nil)
+			},
+			wantErr:  true,
+			wantSkip: true,
+		},
+		{
+			name:    "failed process transaction release idempotency",
+			message: newMessage(payload),
+			doMock: func(m mocks) {
+				m.cacheRepo.EXPECT().SetIfNotExists(gomock.Any(), redisKey, "processing", idempotencyTTL).Return(true, nil)
+				m.wts.EXPECT().ProcessAsyncTransaction(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+				m.cacheRepo.EXPECT().Del(gomock.Any(), redisKey).Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name:    "failed rejected transaction is not retried",
+			message: newMessage(payload),
+			doMock: func(m mocks) {
+				m.cacheRepo.EXPECT().SetIfNotExists(gomock.Any(), redisKey, "processing", idempotencyTTL).Return(true, nil)
+				m.wts.EXPECT().ProcessAsyncTransaction(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("unable to update balance: %w", common.ErrInsufficientAvailableBalance))
+				m.cacheRepo.EXPECT().Del(gomock.Any(), redisKey).Return(nil)
+			},
+			wantErr:       true,
+			wantPermanent: true,
+		},
+		{
+			name:          "failed idempotency key is empty",
+			message:       &sarama.ConsumerMessage{Value: payload},
+			wantErr:       true,
+			wantPermanent: true,
+		},
+		{
+			name:    "failed unmarshal payload release idempotency",
+			message: newMessage([]byte(`{`)),
+			doMock: func(m mocks) {
+				m.cacheRepo.EXPECT().SetIfNotExists(gomock.Any(), redisKey, "processing", idempotencyTTL).Return(true, nil)
+				m.cacheRepo.EXPECT().Del(gomock.Any(), redisKey).Return(nil)
+			},
+			wantErr:       true,
+			wantPermanent: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			m := mocks{
+				cacheRepo: repositoryMock.NewMockCacheRepository(mockCtrl),
+				wts:       serviceMock.NewMockWalletTrxService(mockCtrl),
+			}
+			if tt.doMock != nil {
+				tt.doMock(m)
+			}
+
+			handler := NewHandler(m.cacheRepo, m.wts)
+
+			err := kafkacommon.Chain(handler.HandlerFunc(), handler.Idempotency())(context.Background(), tt.message)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantSkip, kafkacommon.IsSkip(err))
+			assert.Equal(t, tt.wantPermanent, kafkacommon.IsPermanent(err))
+		})
+	}
+}
+
+func TestProcessWalletTransactionHandler_DeadLetter(t *testing.T) {
+	tests := []struct {
+		name    string
+		message *sarama.ConsumerMessage
+		doMock  func(wts *serviceMock.MockWalletTrxService)
+	}{
+		{
+			name:    "enqueued transaction is failed",
+			message: &sarama.ConsumerMessage{Value: []byte(`{"ClientId":"async-client","AsyncId":"0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e"}`)},
+			doMock: func(wts *serviceMock.MockWalletTrxService) {
+				wts.EXPECT().FailAsyncTransaction(gomock.Any(), gomock.Any(), assert.AnError).
+					DoAndReturn(func(ctx context.Context, in models.CreateWalletTransactionRequest, cause error) error {
+						assert.Equal(t, "0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e", in.AsyncId)
+						return nil
+					})
+			},
+		},
+		{
+			name:    "failed to fail enqueued transaction is only logged",
+			message: &sarama.ConsumerMessage{Value: []byte(`{"AsyncId":"0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e"}`)},
+			doMock: func(wts *serviceMock.MockWalletTrxService) {
+				wts.EXPECT().FailAsyncTransaction(gomock.Any(), gomock.Any(), assert.AnError).Return(assert.AnError)
+			},
+		},
+		{
+			name:    "message which can not be decoded is ignored",
+			message: &sarama.ConsumerMessage{Value: []byte(`{`)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			wts := serviceMock.NewMockWalletTrxService(mockCtrl)
+			if tt.doMock != nil {
+				tt.doMock(wts)
+			}
+
+			NewHandler(repositoryMock.NewMockCacheRepository(mockCtrl), wts).DeadLetter()(context.Background(), tt.message, assert.AnError)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package queueexport
+
+import (
+	"context"
+
+	kafkacommon "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/metrics"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+)
+
+const logMessage = "[KAFKA-CONSUMER] [TASK-QUEUE-EXPORT] "
+
+// Consumer represents a Sarama consumer group consumer
+type Consumer struct {
+	*kafkacommon.BaseConsumer
+}
+
+func New(ctx context.Context, cfg config.Config, es services.ExportService, metrics metrics.Metrics) (*Consumer, error) {
+	handler := NewTaskQueueExportHandler(es)
+
+	baseConsumer, err := kafkacommon.NewBaseConsumer(kafkacommon.BaseConsumerConfig{
+		Ctx:           ctx,
+		Config:        cfg,
+		Metrics:       metrics,
+		LogPrefix:     logMessage,
+		Topics:        []string{cfg.MessageBroker.KafkaConsumer.TopicExport},
+		ConsumerGroup: cfg.MessageBroker.KafkaConsumer.ConsumerGroupTaskQueueExport,
+		Handler:       handler.HandlerFunc(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	xlog.Info(ctx, logMessage, xlog.String("status", "success init kafka consumer"))
+
+	return &Consumer{BaseConsumer: baseConsumer}, nil
+}
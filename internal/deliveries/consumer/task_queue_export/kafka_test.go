@@ -0,0 +1,125 @@
+package queueexport
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/kafka/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+
+	mock3 "bitbucket.org/Amartha/go-fp-transaction/internal/common/messaging/mock"
+	mock2 "bitbucket.org/Amartha/go-fp-transaction/internal/services/mock"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMain(m *testing.M) {
+	xlog.InitForTest()
+	os.Exit(m.Run())
+}
+
+type kafkaTestHelper struct {
+	mockCtrl      *gomock.Controller
+	group         string
+	topic         string
+	broker        *sarama.MockBroker
+	defaultConfig config.Config
+
+	es services.ExportService
+
+	cg *mock.MockConsumerGroup
+}
+
+func (th kafkaTestHelper) close() {
+	th.broker.Close()
+	th.mockCtrl.Finish()
+}
+
+func newKafkaTestHelper(t *testing.T) kafkaTestHelper {
+	t.Helper()
+	t.Parallel()
+
+	var (
+		group = "go-fp-transaction"
+		topic = "test"
+	)
+
+	mockCtrl := gomock.NewController(t)
+
+	broker := mock3.NewMockBroker(t, group, topic)
+	cg := mock.NewMockConsumerGroup(mockCtrl)
+	es := mock2.NewMockExportService(mockCtrl)
+
+	return kafkaTestHelper{
+		mockCtrl: mockCtrl,
+		group:    group,
+		topic:    topic,
+		broker:   broker,
+		defaultConfig: config.Config{
+			App: config.App{
+				Env:  "test",
+				Name: "go-fp-transaction",
+			},
+			MessageBroker: config.MessageBroker{
+				KafkaConsumer: config.ConsumerConfig{
+					Brokers:       []string{broker.Addr()},
+					Topic:         topic,
+					ConsumerGroup: group,
+				},
+			},
+		},
+		es: es,
+		cg: cg,
+	}
+}
+
+func TestNew(t *testing.T) {
+
+	th := newKafkaTestHelper(t)
+	defer th.close()
+
+	type args struct {
+		ctx context.Context
+		cfg config.Config
+		es  services.ExportService
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "success new client",
+			args: args{
+				cfg: config.Config{
+					App: config.App{
+						Env:  "test",
+						Name: "go-fp-transaction",
+					},
+					MessageBroker: config.MessageBroker{
+						KafkaConsumer: config.ConsumerConfig{
+							Brokers:       []string{th.broker.Addr()},
+							Topic:         th.topic,
+							ConsumerGroup: th.group,
+						},
+					},
+				},
+				es: th.es,
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.args.ctx, tt.args.cfg, tt.args.es, nil)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
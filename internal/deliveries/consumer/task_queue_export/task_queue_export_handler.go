@@ -0,0 +1,57 @@
+package queueexport
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	kafkacommon "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/Shopify/sarama"
+)
+
+type TaskQueueExportHandler struct {
+	es services.ExportService
+}
+
+func NewTaskQueueExportHandler(es services.ExportService) *TaskQueueExportHandler {
+	return &TaskQueueExportHandler{
+		es: es,
+	}
+}
+
+// HandlerFunc return the handler of export task queue message
+func (eh TaskQueueExportHandler) HandlerFunc() kafkacommon.HandlerFunc {
+	return kafkacommon.Typed(kafkacommon.JSONDecoder[models.ExportPublisher], eh.processMessage)
+}
+
+func (eh TaskQueueExportHandler) processMessage(ctx context.Context, message *sarama.ConsumerMessage, payload models.ExportPublisher) error {
+	const logMessage = "[PROCESS-MESSAGE]"
+
+	logField := kafkacommon.LogFields(message)
+
+	if payload.Task != models.ExportTaskName {
+		return kafkacommon.Skip(fmt.Errorf("unsupported task: %s", payload.Task))
+	}
+
+	id, err := strconv.ParseUint(payload.ID, 10, 64)
+	if err != nil {
+		logField = append(logField, xlog.Err(err))
+		xlog.Warn(ctx, logMessage, logField...)
+		return kafkacommon.Permanent(fmt.Errorf("unable to parse id payload to uint64: %w", err))
+	}
+
+	err = eh.es.ProcessExportTaskQueue(ctx, id)
+	if err != nil {
+		logField = append(logField, xlog.Err(err))
+		xlog.Warn(ctx, logMessage, logField...)
+		return fmt.Errorf("error process export job: %w", err)
+	}
+
+	xlog.Info(ctx, logMessage, logField...)
+	return nil
+}
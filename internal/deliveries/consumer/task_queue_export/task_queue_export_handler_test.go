@@ -0,0 +1,152 @@
+package queueexport
+
+import (
+	"context"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services/mock"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type taskQueueExportHandlerHelper struct {
+	mockCtrl *gomock.Controller
+	es       *mock.MockExportService
+
+	payload []byte
+}
+
+func newTaskQueueExportHandlerHelper(t *testing.T) taskQueueExportHandlerHelper {
+	t.Helper()
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+
+	es := mock.NewMockExportService(mockCtrl)
+
+	payload := []byte(`{"id":"1","task":"EXPORT_TRANSACTION"}`)
+
+	return taskQueueExportHandlerHelper{
+		mockCtrl: mockCtrl,
+		es:       es,
+		payload:  payload,
+	}
+}
+
+func TestNewTaskQueueExportHandler(t *testing.T) {
+	th := newTaskQueueExportHandlerHelper(t)
+	defer th.mockCtrl.Finish()
+
+	cfg := &config.Config{}
+
+	type args struct {
+		cfg *config.Config
+		es  services.ExportService
+	}
+	tests := []struct {
+		name string
+		args args
+		want *TaskQueueExportHandler
+	}{
+		{
+			name: "success init TaskQueueExportHandler",
+			args: args{
+				cfg: cfg,
+				es:  th.es,
+			},
+			want: &TaskQueueExportHandler{
+				es: th.es,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equalf(t, tt.want, NewTaskQueueExportHandler(tt.args.es), "NewTransactionHandler(%v)", tt.args.es)
+		})
+	}
+}
+
+func TestTaskQueueExportHandler_processMessage(t *testing.T) {
+	th := newTaskQueueExportHandlerHelper(t)
+	defer th.mockCtrl.Finish()
+
+	type fields struct {
+		es *mock.MockExportService
+	}
+
+	type args struct {
+		ctx     context.Context
+		message *sarama.ConsumerMessage
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		doMock  func(a args)
+		wantErr bool
+	}{
+		{
+			name: "success handle message",
+			fields: fields{
+				es: th.es,
+			},
+			args: args{
+				ctx: context.Background(),
+				message: &sarama.ConsumerMessage{
+					Value: th.payload,
+				},
+			},
+			doMock: func(a args) {
+				th.es.EXPECT().ProcessExportTaskQueue(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error marshall message",
+			fields: fields{
+				es: th.es,
+			},
+			args: args{
+				ctx: context.Background(),
+				message: &sarama.ConsumerMessage{
+					Value: []byte("{__INVALID_JSON_HERE"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error process export task",
+			fields: fields{
+				es: th.es,
+			},
+			args: args{
+				ctx: context.Background(),
+				message: &sarama.ConsumerMessage{
+					Value: th.payload,
+				},
+			},
+			doMock: func(a args) {
+				th.es.EXPECT().ProcessExportTaskQueue(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			h := TaskQueueExportHandler{
+				es: tt.fields.es,
+			}
+			err := h.HandlerFunc()(tt.args.ctx, tt.args.message)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"os"
 	"testing"
-	"time"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/kafka/mock"
@@ -124,132 +123,3 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
-
-func TestConsumer_Start(t *testing.T) {
-	th := newKafkaTestHelper(t)
-	defer th.close()
-
-	type fields struct {
-		ctx         context.Context
-		ctxCancel   context.CancelFunc
-		cfg         config.Config
-		consumerCfg config.ConsumerConfig
-		cg          *mock.MockConsumerGroup
-		rs          services.ReconService
-	}
-
-	tests := []struct {
-		name   string
-		fields fields
-		doMock func(f fields)
-	}{
-		{
-			name: "success start",
-			fields: fields{
-				cfg:         th.defaultConfig,
-				consumerCfg: th.defaultConfig.MessageBroker.KafkaConsumer,
-				cg:          mock.NewMockConsumerGroup(th.mockCtrl),
-				rs:          th.rs,
-			},
-			doMock: func(f fields) {
-				chanErr := make(chan error)
-				f.cg.EXPECT().Errors().Return(chanErr).AnyTimes()
-				f.cg.EXPECT().Consume(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
-			},
-		},
-		{
-			name: "failed preStart() error config",
-			fields: fields{
-				cfg:         th.defaultConfig,
-				consumerCfg: config.ConsumerConfig{},
-				cg:          mock.NewMockConsumerGroup(th.mockCtrl),
-				rs:          th.rs,
-			},
-			doMock: func(f fields) {
-			},
-		},
-		{
-			name: "error consume message",
-			fields: fields{
-				cfg:         th.defaultConfig,
-				consumerCfg: th.defaultConfig.MessageBroker.KafkaConsumer,
-				cg:          mock.NewMockConsumerGroup(th.mockCtrl),
-				rs:          th.rs,
-			},
-			doMock: func(f fields) {
-				chanErr := make(chan error, 1)
-				chanErr <- assert.AnError
-				f.cg.EXPECT().Errors().Return(chanErr).AnyTimes()
-				f.cg.EXPECT().Consume(gomock.Any(), gomock.Any(), gomock.Any()).Return(assert.AnError).AnyTimes()
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tt.fields.ctx, tt.fields.ctxCancel = context.WithTimeout(context.Background(), 1*time.Second)
-			defer tt.fields.ctxCancel()
-
-			if tt.doMock != nil {
-				tt.doMock(tt.fields)
-			}
-
-			consumer := &Consumer{
-				ctx:      tt.fields.ctx,
-				clientID: th.group,
-				cfg:      tt.fields.cfg,
-				cg:       tt.fields.cg,
-				rs:       tt.fields.rs,
-			}
-
-			consumer.Start()
-		})
-	}
-}
-
-func TestConsumer_Stop(t *testing.T) {
-	th := newKafkaTestHelper(t)
-	defer th.close()
-
-	type fields struct {
-		ctx       context.Context
-		ctxCancel context.CancelFunc
-		cg        *mock.MockConsumerGroup
-	}
-
-	tests := []struct {
-		name   string
-		fields fields
-		doMock func(f fields)
-	}{
-		{
-			name: "success stop consumer",
-			fields: fields{
-				cg: mock.NewMockConsumerGroup(th.mockCtrl),
-			},
-		},
-		{
-			name: "error stop consumer",
-			fields: fields{
-				cg: mock.NewMockConsumerGroup(th.mockCtrl),
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			tt.fields.ctx, tt.fields.ctxCancel = context.WithTimeout(context.Background(), 1*time.Second)
-			defer tt.fields.ctxCancel()
-
-			if tt.doMock != nil {
-				tt.doMock(tt.fields)
-			}
-
-			consumer := &Consumer{
-				ctx:      tt.fields.ctx,
-				clientID: th.group,
-				cg:       tt.fields.cg,
-			}
-
-			consumer.Stop()
-		})
-	}
-}
@@ -6,22 +6,26 @@ import (
 	"sync/atomic"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/http"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/readiness"
 	"github.com/labstack/echo/v4"
 )
 
 type HealthCheck struct {
 	isShutdown atomic.Bool
+	readiness  *readiness.Readiness
 }
 
-func NewHealthCheck() *HealthCheck {
+func NewHealthCheck(r *readiness.Readiness) *HealthCheck {
 	return &HealthCheck{
 		isShutdown: atomic.Bool{},
+		readiness:  r,
 	}
 }
 
 func (d *HealthCheck) Route(g *echo.Group) {
 	g.GET("", d.healthCheck)
 	g.GET("/liveness", d.liveness)
+	g.GET("/readiness", d.readinessCheck)
 }
 
 type (
@@ -63,6 +67,26 @@ func (h *HealthCheck) liveness(c echo.Context) error {
 	})
 }
 
+// @Summary Readiness Check
+// @Description Checking dependencies of the server, e.g. database, redis, kafka and consumer lag. Server is not ready when one of critical components is down
+// @Tags Health
+// @Produce json
+// @Success 200 {object} readiness.Report "Server is ready, some non critical components may be down"
+// @Failure 503 {object} readiness.Report "Server is shutting down or one of critical components is down"
+// @Router /health/readiness [get]
+func (h *HealthCheck) readinessCheck(c echo.Context) error {
+	if h.isShutdown.Load() {
+		return http.RestErrorResponse(c, nethttp.StatusServiceUnavailable, errors.New("server is shutting down"))
+	}
+
+	report := h.readiness.Check(c.Request().Context())
+	if !report.IsReady() {
+		return http.RestSuccessResponse(c, nethttp.StatusServiceUnavailable, report)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, report)
+}
+
 func (h *HealthCheck) Shutdown() {
 	h.isShutdown.Store(true)
 }
@@ -1,6 +1,8 @@
 package health
 
 import (
+	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -8,6 +10,9 @@ import (
 	"strings"
 	"testing"
 
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/readiness"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+
 	xlog "bitbucket.org/Amartha/go-x/log"
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/require"
@@ -28,7 +33,7 @@ func toolTestHealthCheckHelper(t *testing.T) testHealthCheckHelper {
 
 	app := echo.New()
 	apiGroup := app.Group("/api")
-	NewHealthCheck().Route(apiGroup.Group("/health"))
+	NewHealthCheck(readiness.New(config.ReadinessConfig{})).Route(apiGroup.Group("/health"))
 
 	return testHealthCheckHelper{
 		mockCtrl: mockCtrl,
@@ -90,3 +95,76 @@ func Test_Handler_healthCheck(t *testing.T) {
 		})
 	}
 }
+
+func Test_Handler_readiness(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		checkers   map[string]readiness.Checker
+		isShutdown bool
+		wantStatus string
+		wantCode   int
+	}{
+		{
+			name: "success",
+			checkers: map[string]readiness.Checker{
+				readiness.ComponentPostgresWrite: func(ctx context.Context) (map[string]any, error) { return nil, nil },
+			},
+			wantStatus: `"status":"UP"`,
+			wantCode:   http.StatusOK,
+		},
+		{
+			name: "success degraded",
+			checkers: map[string]readiness.Checker{
+				readiness.ComponentPostgresWrite: func(ctx context.Context) (map[string]any, error) { return nil, nil },
+				readiness.ComponentRedis:         func(ctx context.Context) (map[string]any, error) { return nil, errors.New("connection refused") },
+			},
+			wantStatus: `"status":"DEGRADED"`,
+			wantCode:   http.StatusOK,
+		},
+		{
+			name: "critical component is down",
+			checkers: map[string]readiness.Checker{
+				readiness.ComponentPostgresWrite: func(ctx context.Context) (map[string]any, error) { return nil, errors.New("connection refused") },
+			},
+			wantStatus: `"status":"DOWN"`,
+			wantCode:   http.StatusServiceUnavailable,
+		},
+		{
+			name:       "server is shutting down",
+			isShutdown: true,
+			wantCode:   http.StatusServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			r := readiness.New(config.ReadinessConfig{})
+			for name, check := range tt.checkers {
+				r.Register(name, check)
+			}
+
+			h := NewHealthCheck(r)
+			if tt.isShutdown {
+				h.Shutdown()
+			}
+
+			app := echo.New()
+			h.Route(app.Group("/api/health"))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/health/readiness", nil)
+			rec := httptest.NewRecorder()
+			app.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wantCode, resp.StatusCode)
+			require.Contains(t, string(body), tt.wantStatus)
+		})
+	}
+}
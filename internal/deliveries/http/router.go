@@ -18,15 +18,22 @@ import (
 
 	v1account "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/account"
 	v1accountBalance "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/account_balances"
+	v1approval "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/approval"
 	v1category "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/category"
 	v1entity "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/entity"
+	v1exports "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/exports"
 	v1Files "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/files"
 	v1finSnapshot "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/fin_snapshot"
 	v1internalWallet "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/internal_wallet"
 	v1masterData "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/masterdata"
+	v1moneyFlowBusinessRules "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/money_flow_business_rules"
 	v1moneyflow "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/money_flow_summaries"
+	v1reconException "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/recon_exception"
+	v1reports "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/reports"
 	v1subcategory "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/sub_category"
+	v1tax "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/tax"
 	v1transaction "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/transaction"
+	v1transactionMetrics "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/transaction_metrics"
 	v1walletTrx "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v1/wallet_transaction"
 	v2Files "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/v2/files"
 
@@ -57,6 +64,11 @@ func (s *svc) Start() graceful.ProcessStarter {
 	}
 }
 
+// Handler return the router of the server, it is served without Start by httptest in the end-to-end tests
+func (s *svc) Handler() nethttp.Handler {
+	return s.e
+}
+
 func (s *svc) Stop() graceful.ProcessStopper {
 	return func(ctx context.Context) error {
 		err := s.e.Shutdown(ctx)
@@ -100,11 +112,18 @@ func NewHTTPServer(
 	fileService services.FileService,
 	masterDataService services.MasterDataService,
 	reconService services.ReconService,
+	reconExceptionService services.ReconExceptionService,
 	dlqProcessorService services.DLQProcessorService,
 	walletAccountService services.WalletAccountService,
 	walletTrxService services.WalletTrxService,
 	metrics metrics.Metrics,
 	moneyFlowService services.MoneyFlowService,
+	moneyFlowBusinessRuleService services.MoneyFlowBusinessRuleService,
+	taxService services.TaxService,
+	exportService services.ExportService,
+	ledgerReportService services.LedgerReportService,
+	transactionMetricService services.TransactionMetricService,
+	approvalService services.ApprovalService,
 	healthCheck *health.HealthCheck,
 ) *svc {
 	app := echo.New()
@@ -121,6 +140,7 @@ func NewHTTPServer(
 	app.Use(echomiddleware.Recover())
 	app.Use(echomiddleware.RequestID())
 	app.Use(m.Context())
+	app.Use(m.Tracing())
 	app.Use(m.Logger())
 
 	if nr != nil {
@@ -160,29 +180,36 @@ func NewHTTPServer(
 
 	// v1Group
 	v1Group := apiGroup.Group("/v1")
-	v1finSnapshot.New(v1Group, transactionService)
 
 	// v1Group middleware
 	v1Group.Use(m.InternalAuth)
 	// v1Group register api
-	v1transaction.New(v1Group, transactionService, m)
-	v1account.New(v1Group, accountService, walletAccountService, balanceService, m)
+	v1transaction.New(v1Group, transactionService, approvalService, m)
+	v1account.New(v1Group, accountService, walletAccountService, balanceService, approvalService, m)
 	v1accountBalance.New(v1Group, balanceService)
 	v1entity.New(v1Group, entityService)
 	v1category.New(v1Group, categoryService)
 	v1subcategory.New(v1Group, subCategoryService)
 	v1Files.New(v1Group, fileService)
-	v1masterData.New(v1Group, masterDataService)
+	v1masterData.New(v1Group, masterDataService, approvalService)
 	v1walletTrx.New(conf, v1Group, walletTrxService, accountService, m)
 	v1internalWallet.New(v1Group, walletTrxService)
-	v1moneyflow.New(v1Group, moneyFlowService)
+	v1moneyflow.New(v1Group, moneyFlowService, approvalService)
+	v1moneyFlowBusinessRules.New(v1Group, moneyFlowBusinessRuleService)
+	v1reconException.New(v1Group, reconExceptionService)
+	v1tax.New(v1Group, taxService)
+	v1exports.New(v1Group, exportService)
+	v1reports.New(v1Group, ledgerReportService)
+	v1transactionMetrics.New(v1Group, transactionMetricService)
+	v1finSnapshot.New(v1Group, transactionService)
+	v1approval.New(v1Group, approvalService)
 
 	// v2Group
 	v2Group := apiGroup.Group("/v2")
 	// v2Group middleware
 	v2Group.Use(m.InternalAuth)
 	// v2Group register api
-	v2Files.New(v2Group, fileService)
+	v2Files.New(v2Group, fileService, approvalService)
 
 	// prepare an endpoint for 'Not Found'.
 	app.Any("*", func(c echo.Context) error {
@@ -2,6 +2,7 @@ package account
 
 import (
 	"errors"
+	"fmt"
 	nethttp "net/http"
 	"strings"
 
@@ -19,6 +20,7 @@ type accountHandler struct {
 	accountService       services.AccountService
 	balanceService       services.BalanceService
 	walletAccountService services.WalletAccountService
+	approvalService      services.ApprovalService
 }
 
 // New account handler will initialize the account/ resources endpoint
@@ -26,11 +28,13 @@ func New(app *echo.Group,
 	accountSrv services.AccountService,
 	walletAccSrv services.WalletAccountService,
 	balanceSrv services.BalanceService,
+	approvalSrv services.ApprovalService,
 	m middleware.AppMiddleware) {
 	ah := accountHandler{
 		accountService:       accountSrv,
 		balanceService:       balanceSrv,
 		walletAccountService: walletAccSrv,
+		approvalService:      approvalSrv,
 	}
 	account := app.Group("/accounts")
 	account.GET("/balances", ah.getTotalBalance)
@@ -335,6 +339,7 @@ func (ah accountHandler) updateAccountBySubCategory(c echo.Context) error {
 // @Param	X-Secret-Key header string true "X-Secret-Key"
 // @Param   params query models.DoGetAccountRequest true "Get all account query parameters"
 // @Success 204 "Empty response"
+// @Success 202 {object} models.DoGetApprovalResponse "Delete is pending approval"
 // @Failure 400 {object} http.RestErrorResponseModel "Bad request error. This can happen if there is an error while get account"
 // @Failure 404 {object} http.RestErrorResponseModel "Data not found. This can happen if data not found while get account"
 // @Failure 500 {object} http.RestErrorResponseModel "Internal server error. This can happen if there is an error while get account"
@@ -346,6 +351,12 @@ func (ah accountHandler) deleteAccount(c echo.Context) error {
 		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
 	}
 
+	if ah.approvalService.IsRequired(models.ApprovalOperationDeleteAccount) {
+		return http.SubmitApprovalResponse(c, ah.approvalService, models.ApprovalOperationDeleteAccount,
+			models.ApprovalDeleteAccountPayload{AccountNumber: req.AccountNumber},
+			fmt.Sprintf("delete account %s", req.AccountNumber))
+	}
+
 	err := ah.accountService.Delete(c.Request().Context(), req.AccountNumber)
 	if err != nil {
 		if errors.Is(err, common.ErrNoRowsAffected) {
@@ -747,11 +747,44 @@ func Test_Handler_deleteAccount(t *testing.T) {
 				wantCode: 500,
 			},
 			doMock: func(args args, mockData mockData) {
+				testHelper.mockApprovalService.EXPECT().
+					IsRequired(models.ApprovalOperationDeleteAccount).
+					Return(false)
 				testHelper.mockAccountService.EXPECT().
 					Delete(args.ctx, "123456").
 					Return(assert.AnError)
 			},
 		},
+		{
+			name:      "success - submitted for approval",
+			urlCalled: "/api/v1/accounts/123456",
+			args: args{
+				ctx: context.Background(),
+			},
+			mockData: mockData{
+				wantRes:  `{"kind":"approval","id":"1","operation":"DELETE_ACCOUNT","payload":{"accountNumber":"123456"},"description":"delete account 123456","state":"PENDING","maker":"","makerClientId":"","checker":"","checkerClientId":"","executionError":"","expiresAt":"2026-01-02 07:00:00","decidedAt":"","createdAt":"","updatedAt":""}`,
+				wantCode: 202,
+			},
+			doMock: func(args args, mockData mockData) {
+				testHelper.mockApprovalService.EXPECT().
+					IsRequired(models.ApprovalOperationDeleteAccount).
+					Return(true)
+				testHelper.mockApprovalService.EXPECT().
+					Submit(args.ctx, models.SubmitApprovalRequest{
+						Operation:   models.ApprovalOperationDeleteAccount,
+						Payload:     models.ApprovalDeleteAccountPayload{AccountNumber: "123456"},
+						Description: "delete account 123456",
+					}).
+					Return(&models.Approval{
+						ID:          1,
+						Operation:   models.ApprovalOperationDeleteAccount,
+						Payload:     []byte(`{"accountNumber":"123456"}`),
+						Description: "delete account 123456",
+						State:       models.ApprovalStatePending,
+						ExpiresAt:   time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+					}, nil)
+			},
+		},
 		{
 			name:      "success",
 			urlCalled: "/api/v1/accounts/123456",
@@ -763,6 +796,9 @@ func Test_Handler_deleteAccount(t *testing.T) {
 				wantCode: 204,
 			},
 			doMock: func(args args, mockData mockData) {
+				testHelper.mockApprovalService.EXPECT().
+					IsRequired(models.ApprovalOperationDeleteAccount).
+					Return(false)
 				testHelper.mockAccountService.EXPECT().
 					Delete(args.ctx, "123456").
 					Return(nil)
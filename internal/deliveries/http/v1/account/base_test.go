@@ -22,6 +22,7 @@ type testAccountHelper struct {
 	mockAccountService       *mock.MockAccountService
 	mockBalanceService       *mock.MockBalanceService
 	mockWalletAccountService *mock.MockWalletAccountService
+	mockApprovalService      *mock.MockApprovalService
 }
 
 func accountTestHelper(t *testing.T) testAccountHelper {
@@ -33,6 +34,7 @@ func accountTestHelper(t *testing.T) testAccountHelper {
 	mockAccountSvc := mock.NewMockAccountService(mockCtrl)
 	mockBalanceSvc := mock.NewMockBalanceService(mockCtrl)
 	mockWalletAccountSvc := mock.NewMockWalletAccountService(mockCtrl)
+	mockApprovalSvc := mock.NewMockApprovalService(mockCtrl)
 	mockCacheRepo := mockRepo.NewMockCacheRepository(mockCtrl)
 	mockDlqProcessorService := mock.NewMockDLQProcessorService(mockCtrl)
 
@@ -41,7 +43,7 @@ func accountTestHelper(t *testing.T) testAccountHelper {
 	v1Group := app.Group("/api/v1")
 	m := middleware.NewMiddleware(config.Config{}, mockCacheRepo, mockDlqProcessorService)
 
-	New(v1Group, mockAccountSvc, mockWalletAccountSvc, mockBalanceSvc, m)
+	New(v1Group, mockAccountSvc, mockWalletAccountSvc, mockBalanceSvc, mockApprovalSvc, m)
 
 	return testAccountHelper{
 		router:                   app,
@@ -49,6 +51,7 @@ func accountTestHelper(t *testing.T) testAccountHelper {
 		mockAccountService:       mockAccountSvc,
 		mockBalanceService:       mockBalanceSvc,
 		mockWalletAccountService: mockWalletAccountSvc,
+		mockApprovalService:      mockApprovalSvc,
 	}
 }
 
@@ -0,0 +1,204 @@
+package approval
+
+import (
+	"errors"
+	nethttp "net/http"
+	"strconv"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/http"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/validation"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type approvalHandler struct {
+	approvalSvc services.ApprovalService
+}
+
+// New approval handler will initialize the approvals/ resources endpoint
+func New(app *echo.Group, approvalSvc services.ApprovalService) {
+	handler := approvalHandler{
+		approvalSvc: approvalSvc,
+	}
+	api := app.Group("/approvals")
+	api.GET("", handler.getList)
+	api.GET("/:id", handler.getByID)
+	api.POST("/:id/approve", handler.approve)
+	api.POST("/:id/reject", handler.reject)
+	api.POST("/:id/comments", handler.comment)
+}
+
+// getList API get list approval
+// @Summary Get list approval
+// @Description Get list of sensitive operations submitted for approval
+// @Tags Approval
+// @Accept  json
+// @Produce  json
+// @Param params query models.DoGetListApprovalRequest true "Get approval query parameters"
+// @Success 200 {object} http.RestPaginationResponseModel[[]models.DoGetApprovalResponse]
+// @Failure 400 {object} http.RestErrorResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /v1/approvals [get]
+func (h *approvalHandler) getList(c echo.Context) error {
+	req := new(models.DoGetListApprovalRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	opts, err := req.ToFilterOpts()
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	approvals, total, err := h.approvalSvc.List(c.Request().Context(), *opts)
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusInternalServerError, err)
+	}
+
+	return http.RestSuccessResponseCursorPagination[models.DoGetApprovalResponse](c, approvals, opts.Limit, total)
+}
+
+// getByID API get detail approval
+// @Summary Get detail approval
+// @Description Get detail approval with its activities as audit trail
+// @Tags Approval
+// @Accept  json
+// @Produce  json
+// @Param id path string true "approval id"
+// @Success 200 {object} models.DoGetApprovalResponse
+// @Failure 400 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /v1/approvals/{id} [get]
+func (h *approvalHandler) getByID(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	approval, err := h.approvalSvc.GetByID(c.Request().Context(), id)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, approval.ToModelResponse())
+}
+
+// approve API approve pending approval
+// @Summary Approve pending approval
+// @Description Approve pending approval and execute the operation, the approval must be decided by another user than the maker
+// @Tags Approval
+// @Accept  json
+// @Produce  json
+// @Param id path string true "approval id"
+// @Param X-Ngmis-Username header string false "user who approve, the client id is used if empty"
+// @Param body body models.DecideApprovalRequest true "body"
+// @Success 200 {object} models.DoGetApprovalResponse
+// @Failure 400 {object} http.RestErrorResponseModel
+// @Failure 403 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Failure 409 {object} http.RestErrorResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /v1/approvals/{id}/approve [post]
+func (h *approvalHandler) approve(c echo.Context) error {
+	req := new(models.DecideApprovalRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	req.Actor, req.ClientID = http.ApprovalActor(c)
+
+	approval, err := h.approvalSvc.Approve(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, approval.ToModelResponse())
+}
+
+// reject API reject pending approval
+// @Summary Reject pending approval
+// @Description Reject pending approval, the operation will not be executed
+// @Tags Approval
+// @Accept  json
+// @Produce  json
+// @Param id path string true "approval id"
+// @Param X-Ngmis-Username header string false "user who reject, the client id is used if empty"
+// @Param body body models.DecideApprovalRequest true "body"
+// @Success 200 {object} models.DoGetApprovalResponse
+// @Failure 400 {object} http.RestErrorResponseModel
+// @Failure 403 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Failure 409 {object} http.RestErrorResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /v1/approvals/{id}/reject [post]
+func (h *approvalHandler) reject(c echo.Context) error {
+	req := new(models.DecideApprovalRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	req.Actor, req.ClientID = http.ApprovalActor(c)
+
+	approval, err := h.approvalSvc.Reject(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, approval.ToModelResponse())
+}
+
+// comment API comment approval
+// @Summary Comment approval
+// @Description Add comment to approval
+// @Tags Approval
+// @Accept  json
+// @Produce  json
+// @Param id path string true "approval id"
+// @Param X-Ngmis-Username header string false "user who comment, the client id is used if empty"
+// @Param body body models.CommentApprovalRequest true "body"
+// @Success 201 {object} models.DoGetApprovalActivityResponse
+// @Failure 400 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Failure 422 {object} http.RestErrorValidationResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /v1/approvals/{id}/comments [post]
+func (h *approvalHandler) comment(c echo.Context) error {
+	req := new(models.CommentApprovalRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	req.Actor, req.ClientID = http.ApprovalActor(c)
+
+	activity, err := h.approvalSvc.Comment(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusCreated, activity.ToModelResponse())
+}
+
+func getHttpErrorStatusCode(err error) int {
+	if errors.Is(err, common.ErrDataNotFound) {
+		return nethttp.StatusNotFound
+	}
+
+	if errors.Is(err, common.ErrApprovalSameActor) {
+		return nethttp.StatusForbidden
+	}
+
+	if errors.Is(err, common.ErrApprovalNotPending) ||
+		errors.Is(err, common.ErrApprovalExpired) {
+		return nethttp.StatusConflict
+	}
+
+	return nethttp.StatusInternalServerError
+}
@@ -0,0 +1,251 @@
+package approval
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services/mock"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_getByID(t *testing.T) {
+	testHelper := approvalTestHelper(t)
+
+	tests := []struct {
+		name      string
+		urlCalled string
+		doMock    func()
+		wantCode  int
+	}{
+		{
+			name:      "success",
+			urlCalled: "/api/v1/approvals/1",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetByID(gomock.Any(), uint64(1)).
+					Return(&models.Approval{ID: 1, State: models.ApprovalStatePending}, nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:      "invalid id",
+			urlCalled: "/api/v1/approvals/abc",
+			wantCode:  http.StatusBadRequest,
+		},
+		{
+			name:      "not found",
+			urlCalled: "/api/v1/approvals/1",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetByID(gomock.Any(), uint64(1)).
+					Return(nil, common.ErrDataNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tc.urlCalled, nil)
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			require.Equal(t, tc.wantCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_Handler_approve(t *testing.T) {
+	testHelper := approvalTestHelper(t)
+
+	tests := []struct {
+		name     string
+		username string
+		header   string
+		doMock   func()
+		wantCode int
+	}{
+		{
+			name:     "success",
+			username: "finance.lead",
+			doMock: func() {
+				testHelper.mockService.EXPECT().Approve(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, req models.DecideApprovalRequest) (*models.Approval, error) {
+						assert.Equal(t, uint64(1), req.ID)
+						assert.Equal(t, "finance.lead", req.Actor)
+						assert.Equal(t, "client-1", req.ClientID)
+						return &models.Approval{ID: 1, State: models.ApprovalStateApproved}, nil
+					})
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "unverified user is approved as client",
+			doMock: func() {
+				testHelper.mockService.EXPECT().Approve(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, req models.DecideApprovalRequest) (*models.Approval, error) {
+						assert.Equal(t, "client-1", req.Actor)
+						return &models.Approval{ID: 1, State: models.ApprovalStateExecuted}, nil
+					})
+			},
+			header:   "finance.lead",
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "success approved by client",
+			doMock: func() {
+				testHelper.mockService.EXPECT().Approve(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, req models.DecideApprovalRequest) (*models.Approval, error) {
+						assert.Equal(t, "client-1", req.Actor)
+						return &models.Approval{ID: 1, State: models.ApprovalStateApproved}, nil
+					})
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "approved by maker",
+			username: "finance.ops",
+			doMock: func() {
+				testHelper.mockService.EXPECT().Approve(gomock.Any(), gomock.Any()).
+					Return(nil, common.ErrApprovalSameActor)
+			},
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "approval already decided",
+			username: "finance.lead",
+			doMock: func() {
+				testHelper.mockService.EXPECT().Approve(gomock.Any(), gomock.Any()).
+					Return(nil, common.ErrApprovalNotPending)
+			},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			var b bytes.Buffer
+			require.NoError(t, json.NewEncoder(&b).Encode(map[string]any{"comment": "ok"}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/approvals/1/approve", &b)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(models.ClientIdHeader, "client-1")
+			// the user is verified by InternalAuth when the client is trusted to assert it
+			req = req.WithContext(models.WithUser(req.Context(), tc.username))
+			if tc.header != "" {
+				req.Header.Set(models.CtxKeyNgmisHeader, tc.header)
+			}
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			_, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_Handler_comment(t *testing.T) {
+	testHelper := approvalTestHelper(t)
+
+	tests := []struct {
+		name     string
+		body     map[string]any
+		doMock   func()
+		wantCode int
+	}{
+		{
+			name: "success",
+			body: map[string]any{"comment": "please check the amount"},
+			doMock: func() {
+				testHelper.mockService.EXPECT().Comment(gomock.Any(), gomock.Any()).
+					Return(&models.ApprovalActivity{ApprovalID: 1, Action: models.ApprovalActionComment}, nil)
+			},
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:     "error validating request",
+			body:     map[string]any{},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			var b bytes.Buffer
+			require.NoError(t, json.NewEncoder(&b).Encode(tc.body))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/approvals/1/comments", &b)
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			require.Equal(t, tc.wantCode, resp.StatusCode)
+		})
+	}
+}
+
+type testApprovalHelper struct {
+	router      *echo.Echo
+	mockCtrl    *gomock.Controller
+	mockService *mock.MockApprovalService
+}
+
+func approvalTestHelper(t *testing.T) testApprovalHelper {
+	t.Helper()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := mock.NewMockApprovalService(mockCtrl)
+
+	app := echo.New()
+	app.Pre(echomiddleware.RemoveTrailingSlash())
+	v1Group := app.Group("/api/v1")
+	New(v1Group, mockSvc)
+
+	return testApprovalHelper{
+		router:      app,
+		mockCtrl:    mockCtrl,
+		mockService: mockSvc,
+	}
+}
+
+func TestMain(m *testing.M) {
+	xlog.InitForTest()
+	os.Exit(m.Run())
+}
@@ -0,0 +1,101 @@
+package exports
+
+import (
+	"errors"
+	nethttp "net/http"
+	"strconv"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/http"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/validation"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type exportHandler struct {
+	exportSvc services.ExportService
+}
+
+// New export handler will initialize the exports/ resources endpoint
+func New(app *echo.Group, exportSvc services.ExportService) {
+	handler := exportHandler{
+		exportSvc: exportSvc,
+	}
+
+	api := app.Group("/exports")
+	api.POST("", handler.create)
+	api.GET("/:id", handler.getByID)
+}
+
+// @Summary 	Create transaction export
+// @Description Queue export of transactions into CSV, XLSX or Parquet file. The file is generated in background, poll the export to get the download url.
+// @Tags 		Export
+// @Accept		json
+// @Produce		json
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Param   	body body models.DoCreateExportRequest true "Create export request body"
+// @Success 	202 {object} models.DoGetExportResponse "Response indicates that the export has been queued"
+// @Failure 	400 {object} http.RestErrorResponseModel "Bad request error. This can happen if the filter is invalid or the date range is too long"
+// @Failure 	422 {object} http.RestErrorValidationResponseModel "Unprocessable entity"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/exports [post]
+func (h *exportHandler) create(c echo.Context) error {
+	req := new(models.DoCreateExportRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	job, err := h.exportSvc.CreateExport(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusAccepted, job.ToModelResponse(""))
+}
+
+// @Summary 	Get transaction export
+// @Description Get status and progress of transaction export, downloadUrl is a signed url available when the export has succeeded
+// @Tags 		Export
+// @Accept		json
+// @Produce		json
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Param 		id path string true "export id"
+// @Success 	200 {object} models.DoGetExportResponse "Response indicates that the request succeeded"
+// @Failure 	400 {object} http.RestErrorResponseModel "Bad request error"
+// @Failure 	404 {object} http.RestErrorResponseModel "Export not found"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/exports/{id} [get]
+func (h *exportHandler) getByID(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	res, err := h.exportSvc.GetExport(c.Request().Context(), id)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, res)
+}
+
+func getHttpErrorStatusCode(err error) int {
+	if errors.Is(err, common.ErrDataNotFound) {
+		return nethttp.StatusNotFound
+	}
+
+	var errDetail models.ErrorDetail
+	if errors.As(err, &errDetail) ||
+		errors.Is(err, common.ErrInvalidDateRange) ||
+		errors.Is(err, common.ErrExportDateRangeExceeded) {
+		return nethttp.StatusBadRequest
+	}
+
+	return nethttp.StatusInternalServerError
+}
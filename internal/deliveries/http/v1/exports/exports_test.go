@@ -0,0 +1,181 @@
+package exports
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services/mock"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_create(t *testing.T) {
+	testHelper := exportTestHelper(t)
+
+	filter := map[string]any{"startDate": "2025-01-01", "endDate": "2025-01-31"}
+
+	tests := []struct {
+		name     string
+		body     map[string]any
+		doMock   func()
+		wantCode int
+	}{
+		{
+			name: "success",
+			body: map[string]any{"format": "CSV", "gzip": true, "filter": filter, "actor": "finance.ops"},
+			doMock: func() {
+				testHelper.mockService.EXPECT().CreateExport(gomock.Any(), gomock.Any()).
+					Return(&models.ExportJob{ID: 1, Format: "CSV", Status: models.ExportStatusPending}, nil)
+			},
+			wantCode: http.StatusAccepted,
+		},
+		{
+			name:     "error validating request",
+			body:     map[string]any{"format": "JSON", "filter": filter, "actor": "finance.ops"},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "date range exceeded",
+			body: map[string]any{"format": "XLSX", "filter": filter, "actor": "finance.ops"},
+			doMock: func() {
+				testHelper.mockService.EXPECT().CreateExport(gomock.Any(), gomock.Any()).
+					Return(nil, fmt.Errorf("%w: maximum is 7 days", common.ErrExportDateRangeExceeded))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "invalid filter",
+			body: map[string]any{"format": "PARQUET", "filter": filter, "actor": "finance.ops"},
+			doMock: func() {
+				testHelper.mockService.EXPECT().CreateExport(gomock.Any(), gomock.Any()).
+					Return(nil, models.GetErrMap(models.ErrKeyInvalidFormatAmount))
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "error create export",
+			body: map[string]any{"format": "CSV", "filter": filter, "actor": "finance.ops"},
+			doMock: func() {
+				testHelper.mockService.EXPECT().CreateExport(gomock.Any(), gomock.Any()).
+					Return(nil, common.ErrUnableToCreate)
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			var b bytes.Buffer
+			require.NoError(t, json.NewEncoder(&b).Encode(tc.body))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/exports", &b)
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			require.Equal(t, tc.wantCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_Handler_getByID(t *testing.T) {
+	testHelper := exportTestHelper(t)
+
+	tests := []struct {
+		name      string
+		urlCalled string
+		doMock    func()
+		wantCode  int
+	}{
+		{
+			name:      "success",
+			urlCalled: "/api/v1/exports/1",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetExport(gomock.Any(), uint64(1)).
+					Return(&models.DoGetExportResponse{Kind: models.ExportKind, ID: 1}, nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:      "invalid id",
+			urlCalled: "/api/v1/exports/abc",
+			wantCode:  http.StatusBadRequest,
+		},
+		{
+			name:      "not found",
+			urlCalled: "/api/v1/exports/2",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetExport(gomock.Any(), uint64(2)).
+					Return(nil, common.ErrDataNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tc.urlCalled, nil)
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			require.Equal(t, tc.wantCode, resp.StatusCode)
+		})
+	}
+}
+
+type testExportHelper struct {
+	router      *echo.Echo
+	mockCtrl    *gomock.Controller
+	mockService *mock.MockExportService
+}
+
+func exportTestHelper(t *testing.T) testExportHelper {
+	t.Helper()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := mock.NewMockExportService(mockCtrl)
+
+	app := echo.New()
+	app.Pre(echomiddleware.RemoveTrailingSlash())
+	v1Group := app.Group("/api/v1")
+	New(v1Group, mockSvc)
+
+	return testExportHelper{
+		router:      app,
+		mockCtrl:    mockCtrl,
+		mockService: mockSvc,
+	}
+}
+
+func TestMain(m *testing.M) {
+	xlog.InitForTest()
+	os.Exit(m.Run())
+}
@@ -1,7 +1,9 @@
 package masterdata
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	nethttp "net/http"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
@@ -15,12 +17,14 @@ import (
 
 type masterDataHandler struct {
 	masterDataSvc services.MasterDataService
+	approvalSvc   services.ApprovalService
 }
 
 // New transaction handler will initialize the order-types/ and transaction-types/ resources endpoint
-func New(app *echo.Group, masterDataSvc services.MasterDataService) {
+func New(app *echo.Group, masterDataSvc services.MasterDataService, approvalSvc services.ApprovalService) {
 	handler := masterDataHandler{
 		masterDataSvc: masterDataSvc,
+		approvalSvc:   approvalSvc,
 	}
 
 	apiOrderTypes := app.Group("/order-types")
@@ -28,9 +32,13 @@ func New(app *echo.Group, masterDataSvc services.MasterDataService) {
 	apiOrderTypes.PATCH("", handler.updateOrderType)
 	apiOrderTypes.GET("", handler.getAllOrderType)
 	apiOrderTypes.GET("/:orderTypeCode", handler.getOrderType)
+	apiOrderTypes.POST("/:orderTypeCode/transaction-types", handler.createTransactionType)
+	apiOrderTypes.PATCH("/:orderTypeCode/transaction-types/:transactionTypeCode", handler.updateTransactionType)
+	apiOrderTypes.DELETE("/:orderTypeCode/transaction-types/:transactionTypeCode", handler.deactivateTransactionType)
 
 	apiTransactionTypes := app.Group("/transaction-types")
 	apiTransactionTypes.GET("", handler.getAllTransactionType)
+	apiTransactionTypes.GET("/consistency-report", handler.getConsistencyReport)
 	apiTransactionTypes.GET("/:transactionTypeCode", handler.getTransactionType)
 
 	apiVATConfigs := app.Group("/vat-configs")
@@ -103,6 +111,7 @@ func (h *masterDataHandler) getAllTransactionType(c echo.Context) error {
 // @Tags OrderType
 // @Accept  json
 // @Produce  json
+// @Param X-Ngmis-Username header string false "user who change the data, recorded in audit"
 // @Param body body models.OrderType true "body"
 // @Success 201 {object} http.RestTotalRowResponseModel
 // @Failure 400 {object} http.RestErrorResponseModel
@@ -121,7 +130,7 @@ func (h *masterDataHandler) createOrderType(c echo.Context) error {
 		return http.RestErrorValidationResponse(c, err)
 	}
 
-	err := h.masterDataSvc.CreateOrderType(c.Request().Context(), req)
+	err := h.masterDataSvc.CreateOrderType(masterDataActorContext(c), req)
 	if err != nil {
 		if errors.Is(err, common.ErrDataExist) {
 			return http.RestErrorResponse(c, nethttp.StatusConflict, err)
@@ -138,6 +147,7 @@ func (h *masterDataHandler) createOrderType(c echo.Context) error {
 // @Tags OrderType
 // @Accept  json
 // @Produce  json
+// @Param X-Ngmis-Username header string false "user who change the data, recorded in audit"
 // @Param body body models.OrderType true "body"
 // @Success 200 {object} http.RestTotalRowResponseModel
 // @Failure 400 {object} http.RestErrorResponseModel
@@ -156,11 +166,14 @@ func (h *masterDataHandler) updateOrderType(c echo.Context) error {
 		return http.RestErrorValidationResponse(c, err)
 	}
 
-	err := h.masterDataSvc.UpdateOrderType(c.Request().Context(), req)
+	err := h.masterDataSvc.UpdateOrderType(masterDataActorContext(c), req)
 	if err != nil {
 		if errors.Is(err, common.ErrDataNotFound) {
 			return http.RestErrorResponse(c, nethttp.StatusNotFound, err)
 		}
+		if errors.Is(err, common.ErrMasterDataVersionConflict) {
+			return http.RestErrorResponse(c, nethttp.StatusConflict, err)
+		}
 		return http.RestErrorResponse(c, nethttp.StatusInternalServerError, err)
 	}
 
@@ -223,6 +236,126 @@ func (h *masterDataHandler) getTransactionType(c echo.Context) error {
 	return http.RestSuccessResponse(c, nethttp.StatusOK, transactionType.ToResponse())
 }
 
+// createTransactionType API create transaction type of an order type
+// @Summary Create transaction type of an order type
+// @Description Create transaction type of an order type, transaction type code must be unique across order types
+// @Tags TransactionType
+// @Accept  json
+// @Produce  json
+// @Param X-Ngmis-Username header string false "user who change the data, recorded in audit"
+// @Param 	orderTypeCode path string true "order type code"
+// @Param body body models.CreateTransactionTypeRequest true "body"
+// @Success 201 {object} models.OrderTypeOut
+// @Failure 400 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Failure 409 {object} http.RestErrorResponseModel
+// @Failure 422 {object} http.RestErrorValidationResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /v1/order-types/{orderTypeCode}/transaction-types [post]
+func (h *masterDataHandler) createTransactionType(c echo.Context) error {
+	var req models.CreateTransactionTypeRequest
+
+	if err := c.Bind(&req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(&req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	orderType, err := h.masterDataSvc.CreateTransactionType(masterDataActorContext(c), req)
+	if err != nil {
+		return transactionTypeErrorResponse(c, err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusCreated, orderType.ToResponse())
+}
+
+// updateTransactionType API update transaction type of an order type
+// @Summary Update transaction type of an order type
+// @Description Update name, status or effective time of transaction type, empty field is not changed
+// @Tags TransactionType
+// @Accept  json
+// @Produce  json
+// @Param X-Ngmis-Username header string false "user who change the data, recorded in audit"
+// @Param 	orderTypeCode path string true "order type code"
+// @Param 	transactionTypeCode path string true "transaction type code"
+// @Param body body models.UpdateTransactionTypeRequest true "body"
+// @Success 200 {object} models.OrderTypeOut
+// @Failure 400 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Failure 409 {object} http.RestErrorResponseModel
+// @Failure 422 {object} http.RestErrorValidationResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /v1/order-types/{orderTypeCode}/transaction-types/{transactionTypeCode} [patch]
+func (h *masterDataHandler) updateTransactionType(c echo.Context) error {
+	var req models.UpdateTransactionTypeRequest
+
+	if err := c.Bind(&req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(&req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	orderType, err := h.masterDataSvc.UpdateTransactionType(masterDataActorContext(c), req)
+	if err != nil {
+		return transactionTypeErrorResponse(c, err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, orderType.ToResponse())
+}
+
+// deactivateTransactionType API deactivate transaction type of an order type
+// @Summary Deactivate transaction type of an order type
+// @Description Transaction type is set to INACTIVE instead of removed, since existing transactions still refer to it
+// @Tags TransactionType
+// @Accept  json
+// @Produce  json
+// @Param X-Ngmis-Username header string false "user who change the data, recorded in audit"
+// @Param 	orderTypeCode path string true "order type code"
+// @Param 	transactionTypeCode path string true "transaction type code"
+// @Param 	version query int false "current version of the order type"
+// @Success 200 {object} models.OrderTypeOut
+// @Failure 400 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Failure 409 {object} http.RestErrorResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /v1/order-types/{orderTypeCode}/transaction-types/{transactionTypeCode} [delete]
+func (h *masterDataHandler) deactivateTransactionType(c echo.Context) error {
+	var req models.DeactivateTransactionTypeRequest
+
+	if err := c.Bind(&req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	orderType, err := h.masterDataSvc.DeactivateTransactionType(masterDataActorContext(c), req)
+	if err != nil {
+		return transactionTypeErrorResponse(c, err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, orderType.ToResponse())
+}
+
+// getConsistencyReport API get master data consistency report
+// @Summary Get master data consistency report
+// @Description Cross check transaction types in master data against transformers, accepted transaction type config and money flow mapping
+// @Tags TransactionType
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} models.MasterDataConsistencyReport
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /v1/transaction-types/consistency-report [get]
+func (h *masterDataHandler) getConsistencyReport(c echo.Context) error {
+	report, err := h.masterDataSvc.GetConsistencyReport(c.Request().Context())
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusInternalServerError, err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, report)
+}
+
 // getAllVatConfig API get all data vat config
 // @Summary Get all data vat config
 // @Description Get all data vat config
@@ -252,8 +385,10 @@ func (h *masterDataHandler) getAllVatConfig(c echo.Context) error {
 // @Tags VATConfig
 // @Accept  json
 // @Produce  json
+// @Param X-Ngmis-Username header string false "user who change the data, recorded in audit"
 // @Param payload body []models.ConfigVatRevenue true "body"
 // @Success 200 {object} http.RestTotalRowResponseModel
+// @Success 202 {object} models.DoGetApprovalResponse "Change is pending approval"
 // @Failure 400 {object} http.RestErrorResponseModel
 // @Failure 422 {object} http.RestErrorValidationResponseModel
 // @Failure 409 {object} http.RestErrorResponseModel
@@ -266,7 +401,12 @@ func (h *masterDataHandler) upsertVatConfig(c echo.Context) error {
 		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
 	}
 
-	err := h.masterDataSvc.UpsertVATConfig(c.Request().Context(), req)
+	if h.approvalSvc.IsRequired(models.ApprovalOperationUpsertVATConfig) {
+		return http.SubmitApprovalResponse(c, h.approvalSvc, models.ApprovalOperationUpsertVATConfig, req,
+			fmt.Sprintf("upsert %d vat configs", len(req)))
+	}
+
+	err := h.masterDataSvc.UpsertVATConfig(masterDataActorContext(c), req)
 	if err != nil {
 		return http.RestErrorResponse(c, nethttp.StatusInternalServerError, err)
 	}
@@ -278,3 +418,19 @@ func (h *masterDataHandler) upsertVatConfig(c echo.Context) error {
 
 	return http.RestSuccessResponseListWithTotalRows(c, data, len(data))
 }
+
+// masterDataActorContext return request context with the user who change master data, used for audit
+func masterDataActorContext(c echo.Context) context.Context {
+	return models.WithMasterDataActor(c.Request().Context(), c.Request().Header.Get(models.CtxKeyNgmisHeader))
+}
+
+func transactionTypeErrorResponse(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, common.ErrDataNotFound):
+		return http.RestErrorResponse(c, nethttp.StatusNotFound, err)
+	case errors.Is(err, common.ErrDataExist), errors.Is(err, common.ErrMasterDataVersionConflict):
+		return http.RestErrorResponse(c, nethttp.StatusConflict, err)
+	default:
+		return http.RestErrorResponse(c, nethttp.StatusInternalServerError, err)
+	}
+}
@@ -60,7 +60,7 @@ func Test_Handler_createOrderType(t *testing.T) {
 				},
 			},
 			mockData: mockData{
-				wantRes:  `{"kind":"orderType","orderTypeCode":"001","orderTypeName":"TOPUP LENDER P2P","transactionTypes":[{"kind":"transactionType","transactionTypeCode":"001001","transactionTypeName":"TOPUP Mandiri VA"}]}`,
+				wantRes:  `{"kind":"orderType","orderTypeCode":"001","orderTypeName":"TOPUP LENDER P2P","transactionTypes":[{"kind":"transactionType","transactionTypeCode":"001001","transactionTypeName":"TOPUP Mandiri VA","status":"ACTIVE"}]}`,
 				wantCode: 201,
 			},
 			doMock: func(args args, mockData mockData) {
@@ -153,7 +153,7 @@ func Test_Handler_updateOrderType(t *testing.T) {
 				},
 			},
 			mockData: mockData{
-				wantRes:  `{"kind":"orderType","orderTypeCode":"001","orderTypeName":"TOPUP LENDER P2P","transactionTypes":[{"kind":"transactionType","transactionTypeCode":"001001","transactionTypeName":"TOPUP Mandiri VA"}]}`,
+				wantRes:  `{"kind":"orderType","orderTypeCode":"001","orderTypeName":"TOPUP LENDER P2P","transactionTypes":[{"kind":"transactionType","transactionTypeCode":"001001","transactionTypeName":"TOPUP Mandiri VA","status":"ACTIVE"}]}`,
 				wantCode: 201,
 			},
 			doMock: func(args args, mockData mockData) {
@@ -183,6 +183,24 @@ func Test_Handler_updateOrderType(t *testing.T) {
 				testHelper.mockService.EXPECT().UpdateOrderType(args.ctx, args.req).Return(common.ErrDataNotFound)
 			},
 		},
+		{
+			name: "error version conflict",
+			args: args{
+				ctx: context.Background(),
+				req: models.OrderType{
+					OrderTypeCode: "001",
+					OrderTypeName: "TOPUP LENDER P2P",
+					Version:       2,
+				},
+			},
+			mockData: mockData{
+				wantRes:  `{"status":"error","code":409,"message":"master data has been changed by another request"}`,
+				wantCode: 409,
+			},
+			doMock: func(args args, mockData mockData) {
+				testHelper.mockService.EXPECT().UpdateOrderType(args.ctx, args.req).Return(common.ErrMasterDataVersionConflict)
+			},
+		},
 		{
 			name: "test error",
 			args: args{
@@ -251,7 +269,7 @@ func Test_Handler_getAllOrderType(t *testing.T) {
 		{
 			name: "success get all order types",
 			expectation: Expectation{
-				wantRes:  `{"kind":"collection","contents":[{"kind":"orderType","orderTypeCode":"1002","orderTypeName":"Cashout Lender P2P","transactionTypes":[{"kind":"transactionType","transactionTypeCode":"1002001","transactionTypeName":"Request Cashout"},{"kind":"transactionType","transactionTypeCode":"1002002","transactionTypeName":"Reject Request Cashout"}]}],"total_rows":1}`,
+				wantRes:  `{"kind":"collection","contents":[{"kind":"orderType","orderTypeCode":"1002","orderTypeName":"Cashout Lender P2P","transactionTypes":[{"kind":"transactionType","transactionTypeCode":"1002001","transactionTypeName":"Request Cashout","status":"ACTIVE"},{"kind":"transactionType","transactionTypeCode":"1002002","transactionTypeName":"Reject Request Cashout","status":"ACTIVE"}]}],"total_rows":1}`,
 				wantCode: 200,
 			},
 			doMock: func() {
@@ -328,7 +346,7 @@ func Test_Handler_getAllTransactionType(t *testing.T) {
 		{
 			name: "success get all transaction types",
 			expectation: Expectation{
-				wantRes:  `{"kind":"collection","contents":[{"kind":"transactionType","transactionTypeCode":"1002001","transactionTypeName":"Request Cashout"},{"kind":"transactionType","transactionTypeCode":"1002002","transactionTypeName":"Reject Request Cashout"}],"total_rows":2}`,
+				wantRes:  `{"kind":"collection","contents":[{"kind":"transactionType","transactionTypeCode":"1002001","transactionTypeName":"Request Cashout","status":"ACTIVE"},{"kind":"transactionType","transactionTypeCode":"1002002","transactionTypeName":"Reject Request Cashout","status":"ACTIVE"}],"total_rows":2}`,
 				wantCode: 200,
 			},
 			doMock: func() {
@@ -400,7 +418,7 @@ func Test_Handler_getOrderType(t *testing.T) {
 		{
 			name: "success",
 			expectation: Expectation{
-				wantRes:  `{"kind":"orderType","orderTypeCode":"1002","orderTypeName":"Cashout Lender P2P","transactionTypes":[{"kind":"transactionType","transactionTypeCode":"1002001","transactionTypeName":"Request Cashout"},{"kind":"transactionType","transactionTypeCode":"1002002","transactionTypeName":"Reject Request Cashout"}]}`,
+				wantRes:  `{"kind":"orderType","orderTypeCode":"1002","orderTypeName":"Cashout Lender P2P","transactionTypes":[{"kind":"transactionType","transactionTypeCode":"1002001","transactionTypeName":"Request Cashout","status":"ACTIVE"},{"kind":"transactionType","transactionTypeCode":"1002002","transactionTypeName":"Reject Request Cashout","status":"ACTIVE"}]}`,
 				wantCode: 200,
 			},
 			doMock: func() {
@@ -490,7 +508,7 @@ func Test_Handler_getTransactionType(t *testing.T) {
 		{
 			name: "success",
 			expectation: Expectation{
-				wantRes:  `{"kind":"transactionType","transactionTypeCode":"1002001","transactionTypeName":"Request Cashout"}`,
+				wantRes:  `{"kind":"transactionType","transactionTypeCode":"1002001","transactionTypeName":"Request Cashout","status":"ACTIVE"}`,
 				wantCode: 200,
 			},
 			doMock: func() {
@@ -655,11 +673,48 @@ func Test_Handler_upsertVatConfig(t *testing.T) {
 				wantCode: 200,
 			},
 			doMock: func(args args, mockData mockData) {
+				testHelper.mockApproval.EXPECT().
+					IsRequired(models.ApprovalOperationUpsertVATConfig).
+					Return(false)
 				testHelper.mockService.EXPECT().
 					UpsertVATConfig(args.ctx, args.req).
 					Return(nil)
 			},
 		},
+		{
+			name: "success - submitted for approval",
+			args: args{
+				ctx: context.Background(),
+				req: []models.ConfigVatRevenue{
+					{
+						Percentage: decimal.NewFromFloat(0.11),
+					},
+				},
+			},
+			mockData: mockData{
+				wantRes:  `{"kind":"approval","id":"1","operation":"UPSERT_VAT_CONFIG","payload":[{"percentage":"0.11"}],"description":"upsert 1 vat configs","state":"PENDING","maker":"","makerClientId":"","checker":"","checkerClientId":"","executionError":"","expiresAt":"2026-01-02 07:00:00","decidedAt":"","createdAt":"","updatedAt":""}`,
+				wantCode: 202,
+			},
+			doMock: func(args args, mockData mockData) {
+				testHelper.mockApproval.EXPECT().
+					IsRequired(models.ApprovalOperationUpsertVATConfig).
+					Return(true)
+				testHelper.mockApproval.EXPECT().
+					Submit(args.ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, req models.SubmitApprovalRequest) (*models.Approval, error) {
+						require.Equal(t, models.ApprovalOperationUpsertVATConfig, req.Operation)
+						require.Equal(t, "upsert 1 vat configs", req.Description)
+						return &models.Approval{
+							ID:          1,
+							Operation:   req.Operation,
+							Payload:     []byte(`[{"percentage":"0.11"}]`),
+							Description: req.Description,
+							State:       models.ApprovalStatePending,
+							ExpiresAt:   time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+						}, nil
+					})
+			},
+		},
 		{
 			name: "test error",
 			args: args{
@@ -675,6 +730,9 @@ func Test_Handler_upsertVatConfig(t *testing.T) {
 				wantCode: 500,
 			},
 			doMock: func(args args, mockData mockData) {
+				testHelper.mockApproval.EXPECT().
+					IsRequired(models.ApprovalOperationUpsertVATConfig).
+					Return(false)
 				testHelper.mockService.EXPECT().
 					UpsertVATConfig(args.ctx, args.req).
 					Return(assert.AnError)
@@ -710,10 +768,264 @@ func Test_Handler_upsertVatConfig(t *testing.T) {
 	}
 }
 
+func Test_Handler_createTransactionType(t *testing.T) {
+	testHelper := masterDataTestHelper(t)
+	orderTypeCode := "1002"
+
+	orderType := models.OrderType{
+		OrderTypeCode: orderTypeCode,
+		OrderTypeName: "Cashout Lender P2P",
+		Version:       4,
+		TransactionTypes: []models.TransactionType{
+			{TransactionTypeCode: "1002001", TransactionTypeName: "Request Cashout"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		wantRes  string
+		wantCode int
+		doMock   func()
+	}{
+		{
+			name:     "success",
+			body:     `{"transactionTypeCode":"1002001","transactionTypeName":"Request Cashout","version":3}`,
+			wantRes:  `{"kind":"orderType","orderTypeCode":"1002","orderTypeName":"Cashout Lender P2P","transactionTypes":[{"kind":"transactionType","transactionTypeCode":"1002001","transactionTypeName":"Request Cashout","status":"ACTIVE"}],"version":4}`,
+			wantCode: 201,
+			doMock: func() {
+				testHelper.mockService.EXPECT().
+					CreateTransactionType(gomock.Any(), models.CreateTransactionTypeRequest{
+						OrderTypeCode:       orderTypeCode,
+						TransactionTypeCode: "1002001",
+						TransactionTypeName: "Request Cashout",
+						Version:             3,
+					}).
+					Return(&orderType, nil)
+			},
+		},
+		{
+			name:     "failed - invalid status",
+			body:     `{"transactionTypeCode":"1002001","transactionTypeName":"Request Cashout","status":"DELETED"}`,
+			wantCode: 422,
+		},
+		{
+			name:     "failed - already exist",
+			body:     `{"transactionTypeCode":"1002001","transactionTypeName":"Request Cashout"}`,
+			wantRes:  `{"status":"error","code":409,"message":"data exist"}`,
+			wantCode: 409,
+			doMock: func() {
+				testHelper.mockService.EXPECT().
+					CreateTransactionType(gomock.Any(), gomock.Any()).
+					Return(nil, common.ErrDataExist)
+			},
+		},
+		{
+			name:     "failed - order type not found",
+			body:     `{"transactionTypeCode":"1002001","transactionTypeName":"Request Cashout"}`,
+			wantRes:  `{"status":"error","code":404,"message":"data not found"}`,
+			wantCode: 404,
+			doMock: func() {
+				testHelper.mockService.EXPECT().
+					CreateTransactionType(gomock.Any(), gomock.Any()).
+					Return(nil, common.ErrDataNotFound)
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/order-types/%s/transaction-types", orderTypeCode), strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.wantCode, resp.StatusCode)
+			if tc.wantRes != "" {
+				require.Equal(t, tc.wantRes, strings.TrimSuffix(string(body), "\n"))
+			}
+		})
+	}
+}
+
+func Test_Handler_updateTransactionType(t *testing.T) {
+	testHelper := masterDataTestHelper(t)
+	effectiveFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	orderType := models.OrderType{
+		OrderTypeCode: "1002",
+		OrderTypeName: "Cashout Lender P2P",
+		Version:       4,
+		TransactionTypes: []models.TransactionType{
+			{TransactionTypeCode: "1002001", TransactionTypeName: "Request Cashout", EffectiveFrom: &effectiveFrom},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		wantRes  string
+		wantCode int
+		doMock   func()
+	}{
+		{
+			name:     "success",
+			body:     `{"effectiveFrom":"2025-01-01T00:00:00Z","version":3}`,
+			wantRes:  `{"kind":"orderType","orderTypeCode":"1002","orderTypeName":"Cashout Lender P2P","transactionTypes":[{"kind":"transactionType","transactionTypeCode":"1002001","transactionTypeName":"Request Cashout","status":"ACTIVE","effectiveFrom":"2025-01-01T00:00:00Z"}],"version":4}`,
+			wantCode: 200,
+			doMock: func() {
+				testHelper.mockService.EXPECT().
+					UpdateTransactionType(gomock.Any(), models.UpdateTransactionTypeRequest{
+						OrderTypeCode:       "1002",
+						TransactionTypeCode: "1002001",
+						EffectiveFrom:       &effectiveFrom,
+						Version:             3,
+					}).
+					Return(&orderType, nil)
+			},
+		},
+		{
+			name:     "failed - version conflict",
+			body:     `{"transactionTypeName":"Request Cashout","version":2}`,
+			wantRes:  `{"status":"error","code":409,"message":"master data has been changed by another request"}`,
+			wantCode: 409,
+			doMock: func() {
+				testHelper.mockService.EXPECT().
+					UpdateTransactionType(gomock.Any(), gomock.Any()).
+					Return(nil, common.ErrMasterDataVersionConflict)
+			},
+		},
+		{
+			name:     "failed - err service",
+			body:     `{"transactionTypeName":"Request Cashout"}`,
+			wantRes:  `{"status":"error","code":500,"message":"assert.AnError general error for testing"}`,
+			wantCode: 500,
+			doMock: func() {
+				testHelper.mockService.EXPECT().
+					UpdateTransactionType(gomock.Any(), gomock.Any()).
+					Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/order-types/1002/transaction-types/1002001", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.wantCode, resp.StatusCode)
+			require.Equal(t, tc.wantRes, strings.TrimSuffix(string(body), "\n"))
+		})
+	}
+}
+
+func Test_Handler_deactivateTransactionType(t *testing.T) {
+	testHelper := masterDataTestHelper(t)
+
+	t.Run("success", func(t *testing.T) {
+		orderType := models.OrderType{
+			OrderTypeCode: "1002",
+			OrderTypeName: "Cashout Lender P2P",
+			Version:       4,
+			TransactionTypes: []models.TransactionType{
+				{TransactionTypeCode: "1002001", TransactionTypeName: "Request Cashout", Status: models.TransactionTypeStatusInactive},
+			},
+		}
+
+		testHelper.mockService.EXPECT().
+			DeactivateTransactionType(gomock.Any(), models.DeactivateTransactionTypeRequest{
+				OrderTypeCode:       "1002",
+				TransactionTypeCode: "1002001",
+				Version:             3,
+			}).
+			Return(&orderType, nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/order-types/1002/transaction-types/1002001?version=3", nil)
+		rec := httptest.NewRecorder()
+		testHelper.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t,
+			`{"kind":"orderType","orderTypeCode":"1002","orderTypeName":"Cashout Lender P2P","transactionTypes":[{"kind":"transactionType","transactionTypeCode":"1002001","transactionTypeName":"Request Cashout","status":"INACTIVE"}],"version":4}`,
+			strings.TrimSuffix(rec.Body.String(), "\n"))
+	})
+
+	t.Run("failed - transaction type not found", func(t *testing.T) {
+		testHelper.mockService.EXPECT().
+			DeactivateTransactionType(gomock.Any(), gomock.Any()).
+			Return(nil, common.ErrDataNotFound)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/order-types/1002/transaction-types/XXX", nil)
+		rec := httptest.NewRecorder()
+		testHelper.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func Test_Handler_getConsistencyReport(t *testing.T) {
+	testHelper := masterDataTestHelper(t)
+
+	t.Run("success", func(t *testing.T) {
+		report := models.BuildMasterDataConsistencyReport(models.MasterDataConsistencySources{
+			AcceptedTransactionTypes: []string{"TUPVA"},
+			MoneyFlowRulesSource:     models.MoneyFlowRulesSourceFeatureFlag,
+		}, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		testHelper.mockService.EXPECT().
+			GetConsistencyReport(gomock.AssignableToTypeOf(context.Background())).
+			Return(&report, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/transaction-types/consistency-report", nil)
+		rec := httptest.NewRecorder()
+		testHelper.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t,
+			`{"kind":"masterDataConsistencyReport","checkedAt":"2025-01-01T00:00:00Z","totalTransactionTypes":0,"totalTransformers":0,"totalAcceptedTransactionTypes":1,"totalMoneyFlowMappings":0,"moneyFlowRulesSource":"FEATURE_FLAG","issues":[{"transactionTypeCode":"TUPVA","issue":"ACCEPTED_WITHOUT_TRANSFORMER"}]}`,
+			strings.TrimSuffix(rec.Body.String(), "\n"))
+	})
+
+	t.Run("failed - err service", func(t *testing.T) {
+		testHelper.mockService.EXPECT().
+			GetConsistencyReport(gomock.AssignableToTypeOf(context.Background())).
+			Return(nil, assert.AnError)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/transaction-types/consistency-report", nil)
+		rec := httptest.NewRecorder()
+		testHelper.router.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
 type testMasterDataHelper struct {
-	router      *echo.Echo
-	mockCtrl    *gomock.Controller
-	mockService *mock.MockMasterDataService
+	router       *echo.Echo
+	mockCtrl     *gomock.Controller
+	mockService  *mock.MockMasterDataService
+	mockApproval *mock.MockApprovalService
 }
 
 func masterDataTestHelper(t *testing.T) testMasterDataHelper {
@@ -723,17 +1035,19 @@ func masterDataTestHelper(t *testing.T) testMasterDataHelper {
 	defer mockCtrl.Finish()
 
 	mockSvc := mock.NewMockMasterDataService(mockCtrl)
+	mockApprovalSvc := mock.NewMockApprovalService(mockCtrl)
 
 	app := echo.New()
 
 	v1Group := app.Group("/api/v1")
 	app.Pre(echomiddleware.RemoveTrailingSlash())
-	New(v1Group, mockSvc)
+	New(v1Group, mockSvc, mockApprovalSvc)
 
 	return testMasterDataHelper{
-		router:      app,
-		mockCtrl:    mockCtrl,
-		mockService: mockSvc,
+		router:       app,
+		mockCtrl:     mockCtrl,
+		mockService:  mockSvc,
+		mockApproval: mockApprovalSvc,
 	}
 }
 
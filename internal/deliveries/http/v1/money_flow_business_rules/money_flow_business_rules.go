@@ -0,0 +1,255 @@
+package money_flow_business_rules
+
+import (
+	"errors"
+	nethttp "net/http"
+	"strconv"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/http"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/validation"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type moneyFlowBusinessRulesHandler struct {
+	businessRuleSvc services.MoneyFlowBusinessRuleService
+}
+
+// New money flow business rules handler will initialize the money-flow-business-rules/ resources endpoint
+func New(app *echo.Group, businessRuleSvc services.MoneyFlowBusinessRuleService) {
+	handler := moneyFlowBusinessRulesHandler{
+		businessRuleSvc: businessRuleSvc,
+	}
+	api := app.Group("/money-flow-business-rules")
+	api.GET("", handler.getList)
+	api.POST("", handler.create)
+	api.GET("/published", handler.getPublished)
+	api.GET("/:version", handler.getByVersion)
+	api.PUT("/:version", handler.update)
+	api.DELETE("/:version", handler.delete)
+	api.POST("/:version/publish", handler.publish)
+}
+
+// @Summary 	Get list money flow business rules
+// @Description Get all versions of money flow business rules, newest first
+// @Tags 		MoneyFlowBusinessRule
+// @Accept		json
+// @Produce		json
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Param   	params query models.DoGetListMoneyFlowBusinessRuleRequest false "Get business rules query parameters"
+// @Success 	200 {object} http.RestTotalRowResponseModel{contents=[]models.DoGetMoneyFlowBusinessRuleResponse} "Response indicates that the request succeeded"
+// @Failure 	422 {object} http.RestErrorValidationResponseModel "Unprocessable entity. This can happen if status filter is invalid"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/money-flow-business-rules [get]
+func (h *moneyFlowBusinessRulesHandler) getList(c echo.Context) error {
+	req := new(models.DoGetListMoneyFlowBusinessRuleRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	rules, err := h.businessRuleSvc.GetList(c.Request().Context(), models.MoneyFlowBusinessRuleFilterOptions{
+		Status: models.MoneyFlowBusinessRuleStatus(req.Status),
+	})
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusInternalServerError, err)
+	}
+
+	data := make([]models.DoGetMoneyFlowBusinessRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		data = append(data, rule.ToModelResponse())
+	}
+
+	return http.RestSuccessResponseListWithTotalRows(c, data, len(data))
+}
+
+// @Summary 	Get published money flow business rules
+// @Description Get the business rules version currently used by money flow calculation
+// @Tags 		MoneyFlowBusinessRule
+// @Accept		json
+// @Produce		json
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Success 	200 {object} models.DoGetMoneyFlowBusinessRuleResponse "Response indicates that the request succeeded"
+// @Failure 	404 {object} http.RestErrorResponseModel "Data not found. This can happen if no version is published yet"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/money-flow-business-rules/published [get]
+func (h *moneyFlowBusinessRulesHandler) getPublished(c echo.Context) error {
+	rule, err := h.businessRuleSvc.GetPublished(c.Request().Context())
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, rule.ToModelResponse())
+}
+
+// @Summary 	Get money flow business rules by version
+// @Description Get money flow business rules by version
+// @Tags 		MoneyFlowBusinessRule
+// @Accept		json
+// @Produce		json
+// @Param 		version path int true "business rules version"
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Success 	200 {object} models.DoGetMoneyFlowBusinessRuleResponse "Response indicates that the request succeeded"
+// @Failure 	400 {object} http.RestErrorResponseModel "Bad request error. This can happen if version is not a number"
+// @Failure 	404 {object} http.RestErrorResponseModel "Data not found"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/money-flow-business-rules/{version} [get]
+func (h *moneyFlowBusinessRulesHandler) getByVersion(c echo.Context) error {
+	version, err := strconv.ParseInt(c.Param("version"), 10, 64)
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	rule, err := h.businessRuleSvc.GetByVersion(c.Request().Context(), version)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, rule.ToModelResponse())
+}
+
+// @Summary 	Create money flow business rules
+// @Description Create new version of money flow business rules as draft
+// @Tags 		MoneyFlowBusinessRule
+// @Accept		json
+// @Produce		json
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Param   	body body models.CreateMoneyFlowBusinessRuleRequest true "Create business rules request body"
+// @Success 	201 {object} models.DoGetMoneyFlowBusinessRuleResponse "Response indicates that the draft has been created"
+// @Failure 	400 {object} http.RestErrorResponseModel "Bad request error"
+// @Failure 	422 {object} http.RestErrorValidationResponseModel "Unprocessable entity. This can happen if actor is missing"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/money-flow-business-rules [post]
+func (h *moneyFlowBusinessRulesHandler) create(c echo.Context) error {
+	req := new(models.CreateMoneyFlowBusinessRuleRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	rule, err := h.businessRuleSvc.Create(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusCreated, rule.ToModelResponse())
+}
+
+// @Summary 	Update money flow business rules
+// @Description Update draft money flow business rules, published or archived version can not be changed
+// @Tags 		MoneyFlowBusinessRule
+// @Accept		json
+// @Produce		json
+// @Param 		version path int true "business rules version"
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Param   	body body models.UpdateMoneyFlowBusinessRuleRequest true "Update business rules request body"
+// @Success 	200 {object} models.DoGetMoneyFlowBusinessRuleResponse "Response indicates that the draft has been updated"
+// @Failure 	400 {object} http.RestErrorResponseModel "Bad request error"
+// @Failure 	404 {object} http.RestErrorResponseModel "Data not found"
+// @Failure 	409 {object} http.RestErrorResponseModel "Conflict. This can happen if version is not a draft"
+// @Failure 	422 {object} http.RestErrorValidationResponseModel "Unprocessable entity. This can happen if actor is missing"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/money-flow-business-rules/{version} [put]
+func (h *moneyFlowBusinessRulesHandler) update(c echo.Context) error {
+	req := new(models.UpdateMoneyFlowBusinessRuleRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	rule, err := h.businessRuleSvc.Update(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, rule.ToModelResponse())
+}
+
+// @Summary 	Delete money flow business rules
+// @Description Delete draft money flow business rules
+// @Tags 		MoneyFlowBusinessRule
+// @Accept		json
+// @Produce		json
+// @Param 		version path int true "business rules version"
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Success 	204 "Response indicates that the draft has been deleted"
+// @Failure 	400 {object} http.RestErrorResponseModel "Bad request error"
+// @Failure 	404 {object} http.RestErrorResponseModel "Data not found"
+// @Failure 	409 {object} http.RestErrorResponseModel "Conflict. This can happen if version is not a draft"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/money-flow-business-rules/{version} [delete]
+func (h *moneyFlowBusinessRulesHandler) delete(c echo.Context) error {
+	version, err := strconv.ParseInt(c.Param("version"), 10, 64)
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	err = h.businessRuleSvc.Delete(c.Request().Context(), version)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusNoContent, nil)
+}
+
+// @Summary 	Publish money flow business rules
+// @Description Validate draft money flow business rules and make it the active version. Previously published version is archived.
+// @Tags 		MoneyFlowBusinessRule
+// @Accept		json
+// @Produce		json
+// @Param 		version path int true "business rules version"
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Param   	body body models.PublishMoneyFlowBusinessRuleRequest true "Publish business rules request body"
+// @Success 	200 {object} models.DoGetMoneyFlowBusinessRuleResponse "Response indicates that the version has been published"
+// @Failure 	400 {object} http.RestErrorResponseModel "Bad request error. This can happen if business rules are incomplete or transaction mapping is not unique"
+// @Failure 	404 {object} http.RestErrorResponseModel "Data not found"
+// @Failure 	409 {object} http.RestErrorResponseModel "Conflict. This can happen if version is not a draft"
+// @Failure 	422 {object} http.RestErrorValidationResponseModel "Unprocessable entity. This can happen if actor is missing"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/money-flow-business-rules/{version}/publish [post]
+func (h *moneyFlowBusinessRulesHandler) publish(c echo.Context) error {
+	req := new(models.PublishMoneyFlowBusinessRuleRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	rule, err := h.businessRuleSvc.Publish(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, rule.ToModelResponse())
+}
+
+func getHttpErrorStatusCode(err error) int {
+	if errors.Is(err, common.ErrDataNotFound) {
+		return nethttp.StatusNotFound
+	}
+
+	if errors.Is(err, common.ErrInvalidMoneyFlowBusinessRules) {
+		return nethttp.StatusBadRequest
+	}
+
+	if errors.Is(err, common.ErrMoneyFlowBusinessRuleNotDraft) ||
+		errors.Is(err, common.ErrNoRowsAffected) {
+		return nethttp.StatusConflict
+	}
+
+	return nethttp.StatusInternalServerError
+}
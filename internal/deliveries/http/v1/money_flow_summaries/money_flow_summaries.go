@@ -22,17 +22,20 @@ import (
 
 type moneyFlowSummariesHandler struct {
 	moneyFlowService services.MoneyFlowService
+	approvalService  services.ApprovalService
 }
 
 // New money flow summary handler will initialize the money-flow-summaries/ resources endpoint
-func New(app *echo.Group, moneyFlowSvc services.MoneyFlowService) {
+func New(app *echo.Group, moneyFlowSvc services.MoneyFlowService, approvalSvc services.ApprovalService) {
 	handler := moneyFlowSummariesHandler{
 		moneyFlowService: moneyFlowSvc,
+		approvalService:  approvalSvc,
 	}
 	api := app.Group("/money-flow-summaries")
 	api.GET("", handler.getSummariesList)
 	api.GET("/:summaryID", handler.getSummaryDetailBySummaryID)
 	api.GET("/:summaryID/transactions", handler.getDetailedTransactionsBySummaryID)
+	api.GET("/:summaryID/history", handler.getSummaryHistory)
 	api.PATCH("/:summaryID", handler.updateSummary)
 	api.GET("/:summaryID/transactions/download", handler.downloadDetailedTransactionsBySummaryID)
 	api.PATCH("/:summaryID/activation", handler.updateActivationStatus)
@@ -145,6 +148,33 @@ func (h *moneyFlowSummariesHandler) getDetailedTransactionsBySummaryID(c echo.Co
 	return http.RestSuccessResponseCursorPagination[models.DetailedTransactionResponse](c, transactions, opts.Limit, total)
 }
 
+// @Summary 	Get money flow summary history
+// @Description Get status transitions of money flow summary ordered by event time, including transitions from API, PAPA stream and system
+// @Tags 		MoneyFlowSummary
+// @Accept		json
+// @Produce		json
+// @Param 		summaryID path string true "summary identifier"
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Success 	200 {object} http.RestTotalRowResponseModel{contents=[]models.MoneyFlowSummaryEventResponse} "Response indicates that the request succeeded"
+// @Failure 	404 {object} http.RestErrorResponseModel "Data not found. This can happen if summary ID not found"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/money-flow-summaries/{summaryID}/history [get]
+func (h *moneyFlowSummariesHandler) getSummaryHistory(c echo.Context) error {
+	summaryID := c.Param("summaryID")
+
+	events, err := h.moneyFlowService.GetSummaryHistory(c.Request().Context(), summaryID)
+	if err != nil {
+		return http.HandleRepositoryError(c, err)
+	}
+
+	data := make([]models.MoneyFlowSummaryEventResponse, 0, len(events))
+	for _, event := range events {
+		data = append(data, event.ToModelResponse())
+	}
+
+	return http.RestSuccessResponseListWithTotalRows(c, data, len(data))
+}
+
 // @Summary 	Update money flow summary
 // @Description Update money flow summary by summary ID. At least one field must be provided for update. Status transitions are validated: PENDING→IN_PROGRESS, IN_PROGRESS→SUCCESS/FAILED/REJECTED. When status changes to IN_PROGRESS, requestedDate is auto-filled and papaTransactionId is required.
 // @Tags 		MoneyFlowSummary
@@ -154,6 +184,7 @@ func (h *moneyFlowSummariesHandler) getDetailedTransactionsBySummaryID(c echo.Co
 // @Param		X-Secret-Key header string true "X-Secret-Key"
 // @Param   	body body models.UpdateMoneyFlowSummaryRequest true "Update summary request body"
 // @Success 	200 {object} models.UpdateMoneyFlowSummaryResponse "Response indicates that the summary has been updated successfully"
+// @Success 	202 {object} models.DoGetApprovalResponse "Status change is pending approval"
 // @Failure 	400 {object} http.RestErrorResponseModel "Bad request error. This can happen if validation fails, no fields provided, or invalid status transition"
 // @Failure 	404 {object} http.RestErrorResponseModel "Data not found. This can happen if summary ID not found"
 // @Failure 	422 {object} http.RestErrorResponseModel "Unprocessable entity. This can happen if data format is invalid"
@@ -173,6 +204,13 @@ func (h *moneyFlowSummariesHandler) updateSummary(c echo.Context) error {
 		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
 	}
 
+	// only status change has to be approved, other fields are updated immediately
+	if req.MoneyFlowStatus != nil && h.approvalService.IsRequired(models.ApprovalOperationUpdateMoneyFlowSummary) {
+		return http.SubmitApprovalResponse(c, h.approvalService, models.ApprovalOperationUpdateMoneyFlowSummary,
+			models.ApprovalUpdateMoneyFlowSummaryPayload{SummaryID: summaryID, Request: *req},
+			fmt.Sprintf("change status of money flow summary %s to %s", summaryID, *req.MoneyFlowStatus))
+	}
+
 	// Call service to update summary (validation logic is in service layer)
 	err := h.moneyFlowService.UpdateSummary(c.Request().Context(), summaryID, *req)
 	if err != nil {
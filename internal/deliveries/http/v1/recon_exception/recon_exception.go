@@ -0,0 +1,201 @@
+package reconexception
+
+import (
+	"errors"
+	nethttp "net/http"
+	"strconv"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/http"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/validation"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type reconExceptionHandler struct {
+	reconExceptionSvc services.ReconExceptionService
+}
+
+// New recon exception handler will initialize the recon-exceptions/ resources endpoint
+func New(app *echo.Group, reconExceptionSvc services.ReconExceptionService) {
+	handler := reconExceptionHandler{
+		reconExceptionSvc: reconExceptionSvc,
+	}
+	api := app.Group("/recon-exceptions")
+	api.GET("", handler.getList)
+	api.GET("/:id", handler.getByID)
+	api.POST("/:id/assign", handler.assign)
+	api.POST("/:id/comments", handler.comment)
+	api.POST("/:id/resolve", handler.resolve)
+}
+
+// getList API get list recon exception
+// @Summary Get list recon exception
+// @Description Get list of unmatched recon records that need follow up
+// @Tags ReconException
+// @Accept  json
+// @Produce  json
+// @Param params query models.DoGetListReconExceptionRequest true "Get recon exception query parameters"
+// @Success 200 {object} http.RestPaginationResponseModel[[]models.DoGetReconExceptionResponse]
+// @Failure 400 {object} http.RestErrorResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /v1/recon-exceptions [get]
+func (h *reconExceptionHandler) getList(c echo.Context) error {
+	req := new(models.DoGetListReconExceptionRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	opts, err := req.ToFilterOpts()
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	exceptions, total, err := h.reconExceptionSvc.List(c.Request().Context(), *opts)
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusInternalServerError, err)
+	}
+
+	return http.RestSuccessResponseCursorPagination[models.DoGetReconExceptionResponse](c, exceptions, opts.Limit, total)
+}
+
+// getByID API get detail recon exception
+// @Summary Get detail recon exception
+// @Description Get detail recon exception with its activities
+// @Tags ReconException
+// @Accept  json
+// @Produce  json
+// @Param id path string true "recon exception id"
+// @Success 200 {object} models.DoGetReconExceptionResponse
+// @Failure 400 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /v1/recon-exceptions/{id} [get]
+func (h *reconExceptionHandler) getByID(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	exception, err := h.reconExceptionSvc.GetByID(c.Request().Context(), id)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, exception.ToModelResponse())
+}
+
+// assign API assign recon exception
+// @Summary Assign recon exception
+// @Description Assign recon exception to a person, open exception will be moved to investigating
+// @Tags ReconException
+// @Accept  json
+// @Produce  json
+// @Param id path string true "recon exception id"
+// @Param body body models.AssignReconExceptionRequest true "body"
+// @Success 200 {object} models.DoGetReconExceptionResponse
+// @Failure 400 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Failure 409 {object} http.RestErrorResponseModel
+// @Failure 422 {object} http.RestErrorValidationResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /v1/recon-exceptions/{id}/assign [post]
+func (h *reconExceptionHandler) assign(c echo.Context) error {
+	req := new(models.AssignReconExceptionRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	exception, err := h.reconExceptionSvc.Assign(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, exception.ToModelResponse())
+}
+
+// comment API comment recon exception
+// @Summary Comment recon exception
+// @Description Add comment to recon exception
+// @Tags ReconException
+// @Accept  json
+// @Produce  json
+// @Param id path string true "recon exception id"
+// @Param body body models.CommentReconExceptionRequest true "body"
+// @Success 201 {object} models.DoGetReconExceptionActivityResponse
+// @Failure 400 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Failure 422 {object} http.RestErrorValidationResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /v1/recon-exceptions/{id}/comments [post]
+func (h *reconExceptionHandler) comment(c echo.Context) error {
+	req := new(models.CommentReconExceptionRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	activity, err := h.reconExceptionSvc.Comment(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusCreated, activity.ToModelResponse())
+}
+
+// resolve API resolve recon exception
+// @Summary Resolve recon exception
+// @Description Resolve or write off recon exception, optionally create adjustment wallet transaction
+// @Tags ReconException
+// @Accept  json
+// @Produce  json
+// @Param id path string true "recon exception id"
+// @Param body body models.ResolveReconExceptionRequest true "body"
+// @Success 200 {object} models.DoGetReconExceptionResponse
+// @Failure 400 {object} http.RestErrorResponseModel
+// @Failure 404 {object} http.RestErrorResponseModel
+// @Failure 409 {object} http.RestErrorResponseModel
+// @Failure 422 {object} http.RestErrorValidationResponseModel
+// @Failure 500 {object} http.RestErrorResponseModel
+// @Router /v1/recon-exceptions/{id}/resolve [post]
+func (h *reconExceptionHandler) resolve(c echo.Context) error {
+	req := new(models.ResolveReconExceptionRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	req.ClientId = c.Request().Header.Get(models.ClientIdHeader)
+
+	exception, err := h.reconExceptionSvc.Resolve(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, exception.ToModelResponse())
+}
+
+func getHttpErrorStatusCode(err error) int {
+	if errors.Is(err, common.ErrDataNotFound) {
+		return nethttp.StatusNotFound
+	}
+
+	if errors.Is(err, common.ErrReconExceptionClosed) ||
+		errors.Is(err, common.ErrReconExceptionInvalidState) {
+		return nethttp.StatusConflict
+	}
+
+	return nethttp.StatusInternalServerError
+}
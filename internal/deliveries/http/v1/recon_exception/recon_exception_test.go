@@ -0,0 +1,170 @@
+package reconexception
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services/mock"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_getByID(t *testing.T) {
+	testHelper := reconExceptionTestHelper(t)
+
+	tests := []struct {
+		name      string
+		urlCalled string
+		doMock    func()
+		wantCode  int
+	}{
+		{
+			name:      "success",
+			urlCalled: "/api/v1/recon-exceptions/1",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetByID(gomock.Any(), uint64(1)).
+					Return(&models.ReconException{ID: 1, State: models.ReconExceptionStateOpen}, nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:      "invalid id",
+			urlCalled: "/api/v1/recon-exceptions/abc",
+			wantCode:  http.StatusBadRequest,
+		},
+		{
+			name:      "not found",
+			urlCalled: "/api/v1/recon-exceptions/1",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetByID(gomock.Any(), uint64(1)).
+					Return(nil, common.ErrDataNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tc.urlCalled, nil)
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			require.Equal(t, tc.wantCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_Handler_resolve(t *testing.T) {
+	testHelper := reconExceptionTestHelper(t)
+
+	tests := []struct {
+		name     string
+		body     map[string]any
+		doMock   func()
+		wantCode int
+	}{
+		{
+			name: "success",
+			body: map[string]any{"state": "RESOLVED", "note": "matched manually", "actor": "finance.ops"},
+			doMock: func() {
+				testHelper.mockService.EXPECT().Resolve(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ any, req models.ResolveReconExceptionRequest) (*models.ReconException, error) {
+						assert.Equal(t, uint64(1), req.ID)
+						assert.Equal(t, "client-1", req.ClientId)
+						return &models.ReconException{ID: 1, State: models.ReconExceptionStateResolved}, nil
+					})
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "error validating request",
+			body:     map[string]any{"state": "OPEN", "actor": "finance.ops"},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "exception already closed",
+			body: map[string]any{"state": "WRITTEN_OFF", "note": "immaterial", "actor": "finance.ops"},
+			doMock: func() {
+				testHelper.mockService.EXPECT().Resolve(gomock.Any(), gomock.Any()).
+					Return(nil, common.ErrReconExceptionClosed)
+			},
+			wantCode: http.StatusConflict,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			var b bytes.Buffer
+			require.NoError(t, json.NewEncoder(&b).Encode(tc.body))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/recon-exceptions/1/resolve", &b)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set(models.ClientIdHeader, "client-1")
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			_, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantCode, resp.StatusCode)
+		})
+	}
+}
+
+type testReconExceptionHelper struct {
+	router      *echo.Echo
+	mockCtrl    *gomock.Controller
+	mockService *mock.MockReconExceptionService
+}
+
+func reconExceptionTestHelper(t *testing.T) testReconExceptionHelper {
+	t.Helper()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := mock.NewMockReconExceptionService(mockCtrl)
+
+	app := echo.New()
+	app.Pre(echomiddleware.RemoveTrailingSlash())
+	v1Group := app.Group("/api/v1")
+	New(v1Group, mockSvc)
+
+	return testReconExceptionHelper{
+		router:      app,
+		mockCtrl:    mockCtrl,
+		mockService: mockSvc,
+	}
+}
+
+func TestMain(m *testing.M) {
+	xlog.InitForTest()
+	os.Exit(m.Run())
+}
@@ -0,0 +1,144 @@
+package reports
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/exportfile"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/http"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/validation"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type reportsHandler struct {
+	ledgerReportSvc services.LedgerReportService
+}
+
+// New reports handler will initialize the reports/ resources endpoint
+func New(app *echo.Group, ledgerReportSvc services.LedgerReportService) {
+	handler := reportsHandler{
+		ledgerReportSvc: ledgerReportSvc,
+	}
+
+	api := app.Group("/reports")
+	api.GET("/trial-balance", handler.getTrialBalance)
+	api.GET("/general-ledger", handler.getGeneralLedger)
+}
+
+// @Summary 	Get trial balance
+// @Description Get opening balance, debit, credit and closing balance per account of the entity grouped by category and sub category.
+// @Description The period is from until date, from is default to date. Use format=csv to download the report as CSV.
+// @Tags 		Reports
+// @Accept		json
+// @Produce		json
+// @Produce		text/csv
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Param   	params query models.DoGetTrialBalanceRequest true "Trial balance query parameters"
+// @Success 	200 {object} models.DoGetTrialBalanceResponse "Response indicates that the request succeeded"
+// @Failure 	400 {object} http.RestErrorResponseModel "Bad request error. This can happen if date is before from"
+// @Failure 	422 {object} http.RestErrorValidationResponseModel "Unprocessable entity"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/reports/trial-balance [get]
+func (h *reportsHandler) getTrialBalance(c echo.Context) error {
+	req := new(models.DoGetTrialBalanceRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	report, err := h.ledgerReportSvc.GetTrialBalance(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	if req.Format == models.LedgerReportFormatCSV {
+		fileName := fmt.Sprintf("trial-balance-%s_%s.csv", report.StartDate, report.EndDate)
+		if report.Entity != "" {
+			fileName = fmt.Sprintf("trial-balance-%s-%s_%s.csv", report.Entity, report.StartDate, report.EndDate)
+		}
+		return csvResponse(c, fileName, models.TrialBalanceCSVHeader, report.ToCSVRows())
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, report)
+}
+
+// @Summary 	Get general ledger
+// @Description Get opening balance, postings with running balance and closing balance of the account within the period.
+// @Description Use format=csv to download the report as CSV.
+// @Tags 		Reports
+// @Accept		json
+// @Produce		json
+// @Produce		text/csv
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Param   	params query models.DoGetGeneralLedgerRequest true "General ledger query parameters"
+// @Success 	200 {object} models.DoGetGeneralLedgerResponse "Response indicates that the request succeeded"
+// @Failure 	400 {object} http.RestErrorResponseModel "Bad request error. This can happen if to is before from"
+// @Failure 	404 {object} http.RestErrorResponseModel "Account not found"
+// @Failure 	422 {object} http.RestErrorValidationResponseModel "Unprocessable entity"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/reports/general-ledger [get]
+func (h *reportsHandler) getGeneralLedger(c echo.Context) error {
+	req := new(models.DoGetGeneralLedgerRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	report, err := h.ledgerReportSvc.GetGeneralLedger(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	if req.Format == models.LedgerReportFormatCSV {
+		fileName := fmt.Sprintf("general-ledger-%s-%s_%s.csv", report.AccountNumber, report.StartDate, report.EndDate)
+		return csvResponse(c, fileName, models.GeneralLedgerCSVHeader, report.ToCSVRows())
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, report)
+}
+
+// csvResponse write the whole file before sending, so client gets JSON error instead of a partial file
+func csvResponse(c echo.Context, fileName string, header []string, rows [][]string) error {
+	var buf bytes.Buffer
+	fw, err := exportfile.New(exportfile.FormatCSV, &buf, header)
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusInternalServerError, err)
+	}
+
+	for _, row := range rows {
+		if err = fw.Write(row); err != nil {
+			return http.RestErrorResponse(c, nethttp.StatusInternalServerError, err)
+		}
+	}
+
+	if err = fw.Close(); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusInternalServerError, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	return c.Blob(nethttp.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+func getHttpErrorStatusCode(err error) int {
+	if errors.Is(err, common.ErrDataNotFound) {
+		return nethttp.StatusNotFound
+	}
+
+	if errors.Is(err, common.ErrInvalidDateRange) {
+		return nethttp.StatusBadRequest
+	}
+
+	return nethttp.StatusInternalServerError
+}
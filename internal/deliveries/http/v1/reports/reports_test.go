@@ -0,0 +1,222 @@
+package reports
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services/mock"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_getTrialBalance(t *testing.T) {
+	testHelper := reportsTestHelper(t)
+
+	report := &models.DoGetTrialBalanceResponse{
+		Kind:       models.TrialBalanceKind,
+		Entity:     "001",
+		StartDate:  "2025-01-31",
+		EndDate:    "2025-01-31",
+		IsBalanced: true,
+		Total: models.TrialBalanceTotal{
+			Debit:  decimal.NewFromInt(500),
+			Credit: decimal.NewFromInt(500),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		urlCalled string
+		doMock    func()
+		wantCode  int
+		wantType  string
+		wantBody  string
+	}{
+		{
+			name:      "success",
+			urlCalled: "/api/v1/reports/trial-balance?entity=001&date=2025-01-31",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetTrialBalance(gomock.Any(), models.DoGetTrialBalanceRequest{Entity: "001", Date: "2025-01-31"}).
+					Return(report, nil)
+			},
+			wantCode: http.StatusOK,
+			wantType: echo.MIMEApplicationJSON,
+		},
+		{
+			name:      "success csv",
+			urlCalled: "/api/v1/reports/trial-balance?entity=001&date=2025-01-31&format=csv",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetTrialBalance(gomock.Any(), gomock.Any()).Return(report, nil)
+			},
+			wantCode: http.StatusOK,
+			wantType: "text/csv; charset=utf-8",
+			wantBody: "Category Code,Category Name,Sub Category Code,Sub Category Name,Account Number,Account Name,Opening Balance,Debit,Credit,Closing Balance\n" +
+				",,,,,TOTAL,0,500,500,0\n",
+		},
+		{
+			name:      "error validating request",
+			urlCalled: "/api/v1/reports/trial-balance?entity=001&format=pdf",
+			wantCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "invalid date range",
+			urlCalled: "/api/v1/reports/trial-balance?date=2025-01-01&from=2025-01-31",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetTrialBalance(gomock.Any(), gomock.Any()).Return(nil, common.ErrInvalidDateRange)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:      "error service",
+			urlCalled: "/api/v1/reports/trial-balance?date=2025-01-31",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetTrialBalance(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tc.urlCalled, nil)
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			require.Equal(t, tc.wantCode, resp.StatusCode)
+			if tc.wantType != "" {
+				require.Contains(t, resp.Header.Get(echo.HeaderContentType), tc.wantType)
+			}
+			if tc.wantBody != "" {
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				require.Equal(t, tc.wantBody, string(body))
+			}
+		})
+	}
+}
+
+func Test_Handler_getGeneralLedger(t *testing.T) {
+	testHelper := reportsTestHelper(t)
+
+	report := &models.DoGetGeneralLedgerResponse{
+		Kind:          models.GeneralLedgerKind,
+		AccountNumber: "21100100000001",
+		StartDate:     "2025-01-01",
+		EndDate:       "2025-01-31",
+		Entries:       []models.GeneralLedgerEntry{},
+	}
+
+	tests := []struct {
+		name      string
+		urlCalled string
+		doMock    func()
+		wantCode  int
+		wantType  string
+	}{
+		{
+			name:      "success",
+			urlCalled: "/api/v1/reports/general-ledger?account=21100100000001&from=2025-01-01&to=2025-01-31",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetGeneralLedger(gomock.Any(), models.DoGetGeneralLedgerRequest{
+					Account: "21100100000001",
+					From:    "2025-01-01",
+					To:      "2025-01-31",
+				}).Return(report, nil)
+			},
+			wantCode: http.StatusOK,
+			wantType: echo.MIMEApplicationJSON,
+		},
+		{
+			name:      "success csv",
+			urlCalled: "/api/v1/reports/general-ledger?account=21100100000001&from=2025-01-01&to=2025-01-31&format=csv",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetGeneralLedger(gomock.Any(), gomock.Any()).Return(report, nil)
+			},
+			wantCode: http.StatusOK,
+			wantType: "text/csv; charset=utf-8",
+		},
+		{
+			name:      "error validating request",
+			urlCalled: "/api/v1/reports/general-ledger?from=2025-01-01&to=2025-01-31",
+			wantCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "account not found",
+			urlCalled: "/api/v1/reports/general-ledger?account=1&from=2025-01-01&to=2025-01-31",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetGeneralLedger(gomock.Any(), gomock.Any()).Return(nil, common.ErrDataNotFound)
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tc.urlCalled, nil)
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			require.Equal(t, tc.wantCode, resp.StatusCode)
+			if tc.wantType != "" {
+				require.Contains(t, resp.Header.Get(echo.HeaderContentType), tc.wantType)
+			}
+		})
+	}
+}
+
+type testReportsHelper struct {
+	router      *echo.Echo
+	mockCtrl    *gomock.Controller
+	mockService *mock.MockLedgerReportService
+}
+
+func reportsTestHelper(t *testing.T) testReportsHelper {
+	t.Helper()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := mock.NewMockLedgerReportService(mockCtrl)
+
+	app := echo.New()
+	app.Pre(echomiddleware.RemoveTrailingSlash())
+	v1Group := app.Group("/api/v1")
+	New(v1Group, mockSvc)
+
+	return testReportsHelper{
+		router:      app,
+		mockCtrl:    mockCtrl,
+		mockService: mockSvc,
+	}
+}
+
+func TestMain(m *testing.M) {
+	xlog.InitForTest()
+	os.Exit(m.Run())
+}
@@ -0,0 +1,123 @@
+package tax
+
+import (
+	"errors"
+	nethttp "net/http"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/http"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/validation"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type taxHandler struct {
+	taxSvc services.TaxService
+}
+
+// New tax handler will initialize the tax-rates/ and tax-reports/ resources endpoint
+func New(app *echo.Group, taxSvc services.TaxService) {
+	handler := taxHandler{
+		taxSvc: taxSvc,
+	}
+
+	apiTaxRates := app.Group("/tax-rates")
+	apiTaxRates.GET("", handler.getList)
+	apiTaxRates.POST("", handler.create)
+
+	apiTaxReports := app.Group("/tax-reports")
+	apiTaxReports.GET("", handler.getReport)
+}
+
+// @Summary 	Get list tax rates
+// @Description Get all tax rates of tax engine including the expired ones
+// @Tags 		Tax
+// @Accept		json
+// @Produce		json
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Success 	200 {object} http.RestTotalRowResponseModel{contents=[]models.DoGetTaxRateResponse} "Response indicates that the request succeeded"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/tax-rates [get]
+func (h *taxHandler) getList(c echo.Context) error {
+	rates, err := h.taxSvc.ListTaxRates(c.Request().Context())
+	if err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusInternalServerError, err)
+	}
+
+	data := make([]models.DoGetTaxRateResponse, 0, len(rates))
+	for _, rate := range rates {
+		data = append(data, rate.ToModelResponse())
+	}
+
+	return http.RestSuccessResponseListWithTotalRows(c, data, len(data))
+}
+
+// @Summary 	Create tax rate
+// @Description Create tax rate of tax engine. Rate is never updated, create a new rate with later effectiveFrom to change it.
+// @Tags 		Tax
+// @Accept		json
+// @Produce		json
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Param   	body body models.DoCreateTaxRateRequest true "Create tax rate request body"
+// @Success 	201 {object} models.DoGetTaxRateResponse "Response indicates that the rate has been created"
+// @Failure 	400 {object} http.RestErrorResponseModel "Bad request error. This can happen if rate is not between 0 and 1 or effectiveTo is before effectiveFrom"
+// @Failure 	422 {object} http.RestErrorValidationResponseModel "Unprocessable entity"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/tax-rates [post]
+func (h *taxHandler) create(c echo.Context) error {
+	req := new(models.DoCreateTaxRateRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	rate, err := h.taxSvc.CreateTaxRate(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusCreated, rate.ToModelResponse())
+}
+
+// @Summary 	Get tax report
+// @Description Summarize tax collected per entity and month from tax leg transactions, used for monthly tax filing
+// @Tags 		Tax
+// @Accept		json
+// @Produce		json
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Param   	params query models.DoGetTaxReportRequest true "Tax report query parameters"
+// @Success 	200 {object} models.DoGetTaxReportResponse "Response indicates that the request succeeded"
+// @Failure 	400 {object} http.RestErrorResponseModel "Bad request error. This can happen if endDate is before startDate"
+// @Failure 	422 {object} http.RestErrorValidationResponseModel "Unprocessable entity"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/tax-reports [get]
+func (h *taxHandler) getReport(c echo.Context) error {
+	req := new(models.DoGetTaxReportRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	report, err := h.taxSvc.GetTaxReport(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, report)
+}
+
+func getHttpErrorStatusCode(err error) int {
+	if errors.Is(err, common.ErrInvalidTaxRate) || errors.Is(err, common.ErrInvalidDateRange) {
+		return nethttp.StatusBadRequest
+	}
+
+	return nethttp.StatusInternalServerError
+}
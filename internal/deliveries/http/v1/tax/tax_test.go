@@ -0,0 +1,160 @@
+package tax
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services/mock"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_create(t *testing.T) {
+	testHelper := taxTestHelper(t)
+
+	tests := []struct {
+		name     string
+		body     map[string]any
+		doMock   func()
+		wantCode int
+	}{
+		{
+			name: "success",
+			body: map[string]any{"taxType": "VAT", "rate": "0.12", "effectiveFrom": "2025-01-01T00:00:00Z", "actor": "finance.tax"},
+			doMock: func() {
+				testHelper.mockService.EXPECT().CreateTaxRate(gomock.Any(), gomock.Any()).
+					Return(&models.TaxRate{ID: 1, TaxType: models.TaxTypeVAT}, nil)
+			},
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:     "error validating request",
+			body:     map[string]any{"taxType": "GST", "rate": "0.12", "effectiveFrom": "2025-01-01T00:00:00Z", "actor": "finance.tax"},
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "invalid rate",
+			body: map[string]any{"taxType": "WHT", "rate": "2", "effectiveFrom": "2025-01-01T00:00:00Z", "actor": "finance.tax"},
+			doMock: func() {
+				testHelper.mockService.EXPECT().CreateTaxRate(gomock.Any(), gomock.Any()).
+					Return(nil, common.ErrInvalidTaxRate)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			var b bytes.Buffer
+			require.NoError(t, json.NewEncoder(&b).Encode(tc.body))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/tax-rates", &b)
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			require.Equal(t, tc.wantCode, resp.StatusCode)
+		})
+	}
+}
+
+func Test_Handler_getReport(t *testing.T) {
+	testHelper := taxTestHelper(t)
+
+	tests := []struct {
+		name      string
+		urlCalled string
+		doMock    func()
+		wantCode  int
+	}{
+		{
+			name:      "success",
+			urlCalled: "/api/v1/tax-reports?startDate=2025-01-01&endDate=2025-01-31",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetTaxReport(gomock.Any(), models.DoGetTaxReportRequest{StartDate: "2025-01-01", EndDate: "2025-01-31"}).
+					Return(&models.DoGetTaxReportResponse{Kind: models.TaxReportKind}, nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:      "error validating request",
+			urlCalled: "/api/v1/tax-reports?startDate=2025-01-01",
+			wantCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "invalid date range",
+			urlCalled: "/api/v1/tax-reports?startDate=2025-02-01&endDate=2025-01-31",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetTaxReport(gomock.Any(), gomock.Any()).
+					Return(nil, common.ErrInvalidDateRange)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tc.urlCalled, nil)
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			require.Equal(t, tc.wantCode, resp.StatusCode)
+		})
+	}
+}
+
+type testTaxHelper struct {
+	router      *echo.Echo
+	mockCtrl    *gomock.Controller
+	mockService *mock.MockTaxService
+}
+
+func taxTestHelper(t *testing.T) testTaxHelper {
+	t.Helper()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := mock.NewMockTaxService(mockCtrl)
+
+	app := echo.New()
+	app.Pre(echomiddleware.RemoveTrailingSlash())
+	v1Group := app.Group("/api/v1")
+	New(v1Group, mockSvc)
+
+	return testTaxHelper{
+		router:      app,
+		mockCtrl:    mockCtrl,
+		mockService: mockSvc,
+	}
+}
+
+func TestMain(m *testing.M) {
+	xlog.InitForTest()
+	os.Exit(m.Run())
+}
@@ -23,6 +23,7 @@ type testTransactionHelper struct {
 	mockTrxService          *mock.MockTransactionService
 	mockCacheRepository     *mockRepo.MockCacheRepository
 	mockDLQProcessorService *mock.MockDLQProcessorService
+	mockApprovalService     *mock.MockApprovalService
 }
 
 func TestMain(m *testing.M) {
@@ -39,13 +40,14 @@ func transactionTestHelper(t *testing.T) testTransactionHelper {
 	mockTrxService := mock.NewMockTransactionService(mockCtrl)
 	mockCacheRepo := mockRepo.NewMockCacheRepository(mockCtrl)
 	mockDlqProcessorService := mock.NewMockDLQProcessorService(mockCtrl)
+	mockApprovalSvc := mock.NewMockApprovalService(mockCtrl)
 
 	app := echo.New()
 
 	v1Group := app.Group("/api/v1")
 	app.Pre(echomiddleware.RemoveTrailingSlash())
 	m := middleware.NewMiddleware(config.Config{}, mockCacheRepo, mockDlqProcessorService)
-	New(v1Group, mockTrxService, m)
+	New(v1Group, mockTrxService, mockApprovalSvc, m)
 
 	return testTransactionHelper{
 		router:                  app,
@@ -53,5 +55,6 @@ func transactionTestHelper(t *testing.T) testTransactionHelper {
 		mockTrxService:          mockTrxService,
 		mockCacheRepository:     mockCacheRepo,
 		mockDLQProcessorService: mockDlqProcessorService,
+		mockApprovalService:     mockApprovalSvc,
 	}
 }
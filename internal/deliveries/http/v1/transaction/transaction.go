@@ -15,11 +15,12 @@ import (
 
 type transactionHandler struct {
 	transactionSrv services.TransactionService
+	approvalSrv    services.ApprovalService
 }
 
 // New transaction handler will initialize the transaction/ resources endpoint
-func New(app *echo.Group, transactionSrv services.TransactionService, m middleware.AppMiddleware) {
-	handler := transactionHandler{transactionSrv}
+func New(app *echo.Group, transactionSrv services.TransactionService, approvalSrv services.ApprovalService, m middleware.AppMiddleware) {
+	handler := transactionHandler{transactionSrv, approvalSrv}
 	transaction := app.Group("/transaction")
 	transaction.GET("", handler.getAllTransaction)
 	transaction.GET("/status-count", handler.getTransactionStatusCount)
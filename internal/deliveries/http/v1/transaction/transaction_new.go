@@ -2,6 +2,7 @@ package transaction
 
 import (
 	"errors"
+	"fmt"
 	nethttp "net/http"
 	"net/url"
 	"strings"
@@ -23,6 +24,7 @@ import (
 // @Param	X-Secret-Key header string true "X-Secret-Key"
 // @Param 	payload body models.DoPublishTransactionRequest true "A JSON object containing publish transaction payload"
 // @Success 201 {object} models.DoPublishTransactionResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Success 202 {object} models.DoGetApprovalResponse "Publish is pending approval"
 // @Failure 400 {object} http.RestErrorResponseModel "Bad request error. This can happen if there is an error while publish transaction"
 // @Failure 422 {object} http.RestErrorValidationResponseModel{errors=[]validation.ErrorValidateResponse} "Validation error. This can happen if there is an error validation while publish transaction"
 // @Failure 500 {object} http.RestErrorResponseModel "Internal server error. This can happen if there is an error while publish transaction"
@@ -38,6 +40,11 @@ func (th *transactionHandler) publishTransaction(c echo.Context) error {
 		return http.RestErrorValidationResponse(c, err)
 	}
 
+	if th.approvalSrv.IsRequired(models.ApprovalOperationPublishTransaction) {
+		return http.SubmitApprovalResponse(c, th.approvalSrv, models.ApprovalOperationPublishTransaction, req,
+			fmt.Sprintf("publish %s %s from %s to %s", req.TransactionType, req.Amount, req.FromAccount, req.ToAccount))
+	}
+
 	res, err := th.transactionSrv.PublishTransaction(c.Request().Context(), *req)
 	if err != nil {
 		return http.RestErrorResponse(c, nethttp.StatusInternalServerError, err)
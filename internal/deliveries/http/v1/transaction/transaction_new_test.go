@@ -10,6 +10,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
@@ -68,6 +69,7 @@ func Test_Handler_publishTransaction(t *testing.T) {
 				req: mockPublishTransactionReq,
 			},
 			doMock: func(args args, mockData mockData) {
+				testHelper.mockApprovalService.EXPECT().IsRequired(models.ApprovalOperationPublishTransaction).Return(false)
 				testHelper.mockTrxService.EXPECT().PublishTransaction(args.ctx, args.req).Return(mockPublishTransactionRes, nil)
 			},
 			mockData: mockData{
@@ -75,6 +77,33 @@ func Test_Handler_publishTransaction(t *testing.T) {
 				wantCode: 201,
 			},
 		},
+		{
+			name:      "success submitted for approval",
+			urlCalled: "/api/v1/transaction/publish",
+			args: args{
+				ctx: context.Background(),
+				req: mockPublishTransactionReq,
+			},
+			doMock: func(args args, mockData mockData) {
+				testHelper.mockApprovalService.EXPECT().IsRequired(models.ApprovalOperationPublishTransaction).Return(true)
+				testHelper.mockApprovalService.EXPECT().Submit(args.ctx, models.SubmitApprovalRequest{
+					Operation:   models.ApprovalOperationPublishTransaction,
+					Payload:     &args.req,
+					Description: "publish DISBNORMBPEBSA 420000.69 from 666 to 777",
+				}).Return(&models.Approval{
+					ID:          1,
+					Operation:   models.ApprovalOperationPublishTransaction,
+					Payload:     []byte(`{"refNumber":"12345abcd"}`),
+					Description: "publish DISBNORMBPEBSA 420000.69 from 666 to 777",
+					State:       models.ApprovalStatePending,
+					ExpiresAt:   time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+				}, nil)
+			},
+			mockData: mockData{
+				wantRes:  `{"kind":"approval","id":"1","operation":"PUBLISH_TRANSACTION","payload":{"refNumber":"12345abcd"},"description":"publish DISBNORMBPEBSA 420000.69 from 666 to 777","state":"PENDING","maker":"","makerClientId":"","checker":"","checkerClientId":"","executionError":"","expiresAt":"2026-01-02 07:00:00","decidedAt":"","createdAt":"","updatedAt":""}`,
+				wantCode: 202,
+			},
+		},
 		{
 			name:      "error validating required",
 			urlCalled: "/api/v1/transaction/publish",
@@ -95,6 +124,7 @@ func Test_Handler_publishTransaction(t *testing.T) {
 				req: mockPublishTransactionReq,
 			},
 			doMock: func(args args, mockData mockData) {
+				testHelper.mockApprovalService.EXPECT().IsRequired(models.ApprovalOperationPublishTransaction).Return(false)
 				testHelper.mockTrxService.EXPECT().PublishTransaction(args.ctx, args.req).Return(mockPublishTransactionRes, common.ErrInternalServerError)
 			},
 			mockData: mockData{
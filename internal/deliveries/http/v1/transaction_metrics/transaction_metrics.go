@@ -0,0 +1,71 @@
+package transaction_metrics
+
+import (
+	"errors"
+	nethttp "net/http"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/http"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/validation"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+
+	"github.com/labstack/echo/v4"
+)
+
+type transactionMetricsHandler struct {
+	transactionMetricSvc services.TransactionMetricService
+}
+
+// New transaction metrics handler will initialize the transaction-metrics/ resources endpoint
+func New(app *echo.Group, transactionMetricSvc services.TransactionMetricService) {
+	handler := transactionMetricsHandler{
+		transactionMetricSvc: transactionMetricSvc,
+	}
+
+	api := app.Group("/transaction-metrics")
+	api.GET("", handler.getTransactionMetrics)
+}
+
+// @Summary 	Get transaction metrics
+// @Description Get sum, count and average amount of transactions per day, week or month between start and end date.
+// @Description Metrics can be grouped by orderType, transactionType, entity and productType by repeating groupBy parameter.
+// @Description Closed days are served from daily aggregates, today is aggregated from transactions.
+// @Tags 		Transaction Metrics
+// @Accept		json
+// @Produce		json
+// @Param		X-Secret-Key header string true "X-Secret-Key"
+// @Param   	params query models.DoGetTransactionMetricsRequest true "Transaction metrics query parameters"
+// @Success 	200 {object} models.DoGetTransactionMetricsResponse "Response indicates that the request succeeded"
+// @Failure 	400 {object} http.RestErrorResponseModel "Bad request error. This can happen if end date is before start date or the date range is too long"
+// @Failure 	422 {object} http.RestErrorValidationResponseModel "Unprocessable entity"
+// @Failure 	500 {object} http.RestErrorResponseModel "Internal server error"
+// @Router /v1/transaction-metrics [get]
+func (h *transactionMetricsHandler) getTransactionMetrics(c echo.Context) error {
+	req := new(models.DoGetTransactionMetricsRequest)
+	if err := c.Bind(req); err != nil {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+	}
+
+	if err := validation.ValidateStruct(req); err != nil {
+		return http.RestErrorValidationResponse(c, err)
+	}
+
+	resp, err := h.transactionMetricSvc.GetTransactionMetrics(c.Request().Context(), *req)
+	if err != nil {
+		return http.RestErrorResponse(c, getHttpErrorStatusCode(err), err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, resp)
+}
+
+func getHttpErrorStatusCode(err error) int {
+	var errDetail models.ErrorDetail
+	if errors.As(err, &errDetail) ||
+		errors.Is(err, common.ErrInvalidDateRange) ||
+		errors.Is(err, common.ErrMetricDateRangeExceeded) {
+		return nethttp.StatusBadRequest
+	}
+
+	return nethttp.StatusInternalServerError
+}
@@ -0,0 +1,117 @@
+package transaction_metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services/mock"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_Handler_getTransactionMetrics(t *testing.T) {
+	testHelper := transactionMetricsTestHelper(t)
+
+	tests := []struct {
+		name      string
+		urlCalled string
+		doMock    func()
+		wantCode  int
+	}{
+		{
+			name:      "success",
+			urlCalled: "/api/v1/transaction-metrics?startDate=2025-01-01&endDate=2025-01-31&interval=week&groupBy=entity&groupBy=transactionType&transactionTypes=RPYAD",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetTransactionMetrics(gomock.Any(), models.DoGetTransactionMetricsRequest{
+					StartDate:        "2025-01-01",
+					EndDate:          "2025-01-31",
+					Interval:         models.MetricIntervalWeek,
+					GroupBy:          []string{models.MetricGroupByEntity, models.MetricGroupByTransactionType},
+					TransactionTypes: []string{"RPYAD"},
+				}).Return(models.DoGetTransactionMetricsResponse{Kind: models.TransactionMetricsKind}, nil)
+			},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:      "error validating request",
+			urlCalled: "/api/v1/transaction-metrics?startDate=2025-01-01&endDate=2025-01-31&groupBy=account",
+			wantCode:  http.StatusUnprocessableEntity,
+		},
+		{
+			name:      "error date range is too long",
+			urlCalled: "/api/v1/transaction-metrics?startDate=2020-01-01&endDate=2025-01-31",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetTransactionMetrics(gomock.Any(), gomock.Any()).
+					Return(models.DoGetTransactionMetricsResponse{}, common.ErrMetricDateRangeExceeded)
+			},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:      "error service",
+			urlCalled: "/api/v1/transaction-metrics?startDate=2025-01-01&endDate=2025-01-31",
+			doMock: func() {
+				testHelper.mockService.EXPECT().GetTransactionMetrics(gomock.Any(), gomock.Any()).
+					Return(models.DoGetTransactionMetricsResponse{}, assert.AnError)
+			},
+			wantCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.doMock != nil {
+				tc.doMock()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, tc.urlCalled, nil)
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			require.Equal(t, tc.wantCode, resp.StatusCode)
+		})
+	}
+}
+
+type testTransactionMetricsHelper struct {
+	router      *echo.Echo
+	mockCtrl    *gomock.Controller
+	mockService *mock.MockTransactionMetricService
+}
+
+func transactionMetricsTestHelper(t *testing.T) testTransactionMetricsHelper {
+	t.Helper()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := mock.NewMockTransactionMetricService(mockCtrl)
+
+	app := echo.New()
+	app.Pre(echomiddleware.RemoveTrailingSlash())
+	v1Group := app.Group("/api/v1")
+	New(v1Group, mockSvc)
+
+	return testTransactionMetricsHelper{
+		router:      app,
+		mockCtrl:    mockCtrl,
+		mockService: mockSvc,
+	}
+}
+
+func TestMain(m *testing.M) {
+	xlog.InitForTest()
+	os.Exit(m.Run())
+}
@@ -50,6 +50,9 @@ func New(
 		Timeout: durationTimeout,
 	}))
 	transaction.POST("", handler.createWalletTransaction)
+	transaction.GET("", handler.getWalletTransactionDetail)
+	transaction.GET("/async/:id", handler.getAsyncWalletTransaction)
+	transaction.GET("/:transactionId", handler.getWalletTransactionDetail)
 	transaction.PATCH("/:transactionId", handler.updateStatusWalletTransaction)
 }
 
@@ -143,11 +146,86 @@ func (h *walletTrxHandler) updateStatusWalletTransaction(c echo.Context) error {
 	walletTransaction, err := h.walletTrxService.ProcessReservedTransaction(c.Request().Context(), req)
 	if err != nil {
 		var code = nethttp.StatusInternalServerError
-		if errors.Is(err, common.ErrTransactionNotReserved) {
+		if errors.Is(err, common.ErrTransactionNotReserved) ||
+			errors.Is(err, common.ErrReservedTransactionAlreadyCaptured) {
 			code = nethttp.StatusConflict
+		} else if errors.Is(err, common.ErrInvalidAmount) ||
+			errors.Is(err, common.ErrCaptureAmountExceedsRemaining) ||
+			errors.Is(err, common.ErrUnsupportedReservedTransactionFlow) {
+			code = nethttp.StatusUnprocessableEntity
 		}
 		return http.RestErrorResponse(c, code, err)
 	}
 
 	return http.RestSuccessResponse(c, nethttp.StatusOK, req.ToResponse(*walletTransaction))
 }
+
+// getWalletTransactionDetail API to get detail wallet transaction
+// @Summary Get detail wallet transaction
+// @Description Get wallet transaction by id or refNumber with its child transactions and balances of the accounts before and after the transaction
+// @Tags WalletTransaction
+// @Accept  json
+// @Produce  json
+// @Param	transactionId path string false "wallet transaction id"
+// @Param	refNumber query string false "reference number, used when transactionId is empty"
+// @Param	transactionType query string false "transaction type, required when the refNumber is used by more than one wallet transaction"
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Success 200 {object} models.WalletTransactionDetailResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 400 {object} http.RestErrorResponseModel "Bad request error. This can happen if neither transactionId nor refNumber is filled"
+// @Failure 404 {object} http.RestErrorResponseModel "Data not found. This can happen if the wallet transaction is not found"
+// @Failure 409 {object} http.RestErrorResponseModel "Conflict. This can happen if the refNumber matches more than one wallet transaction"
+// @Failure 500 {object} http.RestErrorResponseModel "Internal server error. This can happen if there is an error while get wallet transaction"
+// @Router /wallet-transactions/{transactionId} [get]
+// @Router /wallet-transactions [get]
+func (h *walletTrxHandler) getWalletTransactionDetail(c echo.Context) error {
+	req := models.DoGetWalletTransactionDetailRequest{
+		TransactionId:   c.Param("transactionId"),
+		RefNumber:       c.QueryParam("refNumber"),
+		TransactionType: c.QueryParam("transactionType"),
+	}
+
+	if req.TransactionId == "" && req.RefNumber == "" {
+		return http.RestErrorResponse(c, nethttp.StatusBadRequest, errors.New("transactionId or refNumber is required"))
+	}
+
+	detail, err := h.walletTrxService.GetDetail(c.Request().Context(), req)
+	if err != nil {
+		var code = nethttp.StatusInternalServerError
+		if errors.Is(err, common.ErrDataNotFound) {
+			code = nethttp.StatusNotFound
+		} else if errors.Is(err, common.ErrAmbiguousRefNumber) {
+			code = nethttp.StatusConflict
+		}
+		return http.RestErrorResponse(c, code, err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, detail.ToModelResponse())
+}
+
+// getAsyncWalletTransaction API to get status of asynchronous wallet transaction
+// @Summary Get status of asynchronous wallet transaction
+// @Description Get status of wallet transaction submitted by asynchronous client, only the submitting client can get the status
+// @Tags WalletTransaction
+// @Accept  json
+// @Produce  json
+// @Param	id path string true "async id returned on submission"
+// @Param	X-Secret-Key header string true "X-Secret-Key"
+// @Param	X-Client-Id header string true "X-Client-Id"
+// @Success 200 {object} models.DoGetAsyncWalletTransactionResponse "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Failure 404 {object} http.RestErrorResponseModel "Data not found. This can happen if the transaction is not found or submitted by another client"
+// @Failure 500 {object} http.RestErrorResponseModel "Internal server error. This can happen if there is an error while get the transaction"
+// @Router /wallet-transactions/async/{id} [get]
+func (h *walletTrxHandler) getAsyncWalletTransaction(c echo.Context) error {
+	clientId := getClientId(c.Request().Header)
+
+	intake, err := h.walletTrxService.GetAsyncTransaction(c.Request().Context(), c.Param("id"), clientId)
+	if err != nil {
+		var code = nethttp.StatusInternalServerError
+		if errors.Is(err, common.ErrDataNotFound) {
+			code = nethttp.StatusNotFound
+		}
+		return http.RestErrorResponse(c, code, err)
+	}
+
+	return http.RestSuccessResponse(c, nethttp.StatusOK, intake.ToModelResponse())
+}
@@ -2,6 +2,7 @@ package wallettrx
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/http/middleware"
@@ -50,6 +52,32 @@ func Test_Handler_createWalletTransaction(t *testing.T) {
 					Return(&models.WalletTransaction{ID: "ID1", Status: "PENDING"}, nil)
 			},
 		},
+		{
+			name:     "happy path - enqueued",
+			wantRes:  `{"kind":"walletTransaction","id":"","asyncId":"0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e","status":"PENDING","accountNumber":"111","refNumber":"222","transactionType":"333","transactionFlow":"transfer","transactionTime":"2024-04-16T16:32:34+07:00","netAmount":{"value":10,"currency":""},"amounts":null,"destinationAccountNumber":"","description":"","metadata":null}`,
+			wantCode: 201,
+			doMock: func(request models.CreateWalletTransactionRequest) {
+				testHelper.mockWalletService.EXPECT().
+					CreateTransaction(
+						gomock.Any(),
+						gomock.AssignableToTypeOf(request),
+					).
+					Return(&models.WalletTransaction{AsyncId: "0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e", Status: "PENDING"}, nil)
+			},
+		},
+		{
+			name:     "failed - unprocessable transaction",
+			wantRes:  `{"status":"error","code":422,"message":"insufficient balance"}`,
+			wantCode: 422,
+			doMock: func(request models.CreateWalletTransactionRequest) {
+				testHelper.mockWalletService.EXPECT().
+					CreateTransaction(
+						gomock.Any(),
+						gomock.AssignableToTypeOf(request),
+					).
+					Return(nil, common.ErrInsufficientAvailableBalance)
+			},
+		},
 		{
 			name:     "failed - validation error",
 			wantRes:  `{"status":"error","code":400,"message":"validation"}`,
@@ -132,7 +160,7 @@ func Test_Handler_updateStatusWalletTransaction(t *testing.T) {
 			request: models.UpdateStatusWalletTransactionRequest{
 				Action: "commit",
 			},
-			wantRes:  `{"kind":"walletTransaction","transactionId":"TransactionId","status":"SUCCESS"}`,
+			wantRes:  `{"kind":"walletTransaction","transactionId":"TransactionId","status":"SUCCESS","reservedAmount":{"value":0,"currency":"IDR"},"capturedAmount":{"value":0,"currency":"IDR"},"remainingAmount":{"value":0,"currency":"IDR"}}`,
 			wantCode: 200,
 			doMock: func(request models.UpdateStatusWalletTransactionRequest) {
 				testHelper.mockWalletService.EXPECT().
@@ -145,7 +173,7 @@ func Test_Handler_updateStatusWalletTransaction(t *testing.T) {
 			request: models.UpdateStatusWalletTransactionRequest{
 				Action: "cancel",
 			},
-			wantRes:  `{"kind":"walletTransaction","transactionId":"TransactionId","status":"CANCEL"}`,
+			wantRes:  `{"kind":"walletTransaction","transactionId":"TransactionId","status":"CANCEL","reservedAmount":{"value":0,"currency":"IDR"},"capturedAmount":{"value":0,"currency":"IDR"},"remainingAmount":{"value":0,"currency":"IDR"}}`,
 			wantCode: 200,
 			doMock: func(request models.UpdateStatusWalletTransactionRequest) {
 				testHelper.mockWalletService.EXPECT().
@@ -153,12 +181,48 @@ func Test_Handler_updateStatusWalletTransaction(t *testing.T) {
 					Return(&models.WalletTransaction{ID: request.TransactionId, Status: "CANCEL"}, nil)
 			},
 		},
+		{
+			name: "success partial capture",
+			request: models.UpdateStatusWalletTransactionRequest{
+				Action: "commit",
+				Amount: &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(40)), Currency: "IDR"},
+			},
+			wantRes:  `{"kind":"walletTransaction","transactionId":"TransactionId","status":"PENDING","reservedAmount":{"value":100,"currency":"IDR"},"capturedAmount":{"value":40,"currency":"IDR"},"remainingAmount":{"value":60,"currency":"IDR"}}`,
+			wantCode: 200,
+			doMock: func(request models.UpdateStatusWalletTransactionRequest) {
+				testHelper.mockWalletService.EXPECT().
+					ProcessReservedTransaction(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req models.UpdateStatusWalletTransactionRequest) (*models.WalletTransaction, error) {
+						assert.True(t, req.Amount.ValueDecimal.Equal(decimal.NewFromInt(40)))
+						return &models.WalletTransaction{
+							ID:             req.TransactionId,
+							Status:         "PENDING",
+							NetAmount:      models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(100))},
+							CapturedAmount: decimal.NewFromInt(40),
+						}, nil
+					})
+			},
+		},
+		{
+			name: "failed - capture more than remaining amount",
+			request: models.UpdateStatusWalletTransactionRequest{
+				Action: "commit",
+				Amount: &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(200)), Currency: "IDR"},
+			},
+			wantRes:  `{"status":"error","code":422,"message":"capture amount is greater than remaining reserved amount"}`,
+			wantCode: 422,
+			doMock: func(request models.UpdateStatusWalletTransactionRequest) {
+				testHelper.mockWalletService.EXPECT().
+					ProcessReservedTransaction(gomock.Any(), gomock.Any()).
+					Return(nil, common.ErrCaptureAmountExceedsRemaining)
+			},
+		},
 		{
 			name: "failed - validation error",
 			request: models.UpdateStatusWalletTransactionRequest{
 				Action: "non_existent_action",
 			},
-			wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"INVALID_VALUES","field":"action","message":"action must be commit or cancel"}]}`,
+			wantRes:  `{"status":"error","message":"validation failed","errors":[{"code":"INVALID_VALUES","field":"action","message":"action must be commit, cancel or increase"}]}`,
 			wantCode: 422,
 		},
 		{
@@ -218,6 +282,232 @@ func Test_Handler_updateStatusWalletTransaction(t *testing.T) {
 	}
 }
 
+func Test_Handler_getWalletTransactionDetail(t *testing.T) {
+	testHelper := walletTrxTestHelper(t)
+	trxTime := time.Date(2024, 4, 16, 9, 32, 34, 0, time.UTC)
+
+	detail := &models.WalletTransactionDetail{
+		WalletTransaction: models.WalletTransaction{
+			ID:              "ID1",
+			Status:          models.WalletTransactionStatusSuccess,
+			AccountNumber:   "111",
+			RefNumber:       "REF1",
+			TransactionType: "TUPVA",
+			TransactionFlow: models.TransactionFlowCashIn,
+			TransactionTime: trxTime,
+			NetAmount:       models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(10)), Currency: "IDR"},
+			CreatedAt:       trxTime,
+		},
+		Transactions: []models.Transaction{
+			{
+				ID:                  1,
+				TransactionID:       "TRX1",
+				TransactionDate:     trxTime,
+				TransactionTime:     trxTime,
+				FromAccount:         "222",
+				ToAccount:           "111",
+				Amount:              decimal.NewNullDecimal(decimal.NewFromInt(10)),
+				Status:              "1",
+				Method:              "TUPVA",
+				TypeTransaction:     "TUPVA",
+				RefNumber:           "REF1",
+				WalletTransactionID: "ID1",
+			},
+		},
+		Balances: []models.WalletTransactionBalance{
+			{
+				WalletTransactionID: "ID1",
+				AccountNumber:       "111",
+				Before:              models.NewBalance(decimal.Zero, decimal.Zero),
+				After:               models.NewBalance(decimal.NewFromInt(10), decimal.Zero),
+				CreatedAt:           trxTime,
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		wantRes  string
+		wantCode int
+		doMock   func()
+	}{
+		{
+			name:     "success by id",
+			path:     "/api/v1/wallet-transactions/ID1",
+			wantRes:  `{"kind":"walletTransaction","id":"ID1","status":"SUCCESS","accountNumber":"111","refNumber":"REF1","transactionType":"TUPVA","transactionFlow":"cashin","transactionTime":"2024-04-16 16:32:34","netAmount":{"value":10,"currency":"IDR"},"amounts":null,"destinationAccountNumber":"","description":"","metadata":null,"capturedAmount":{"value":0,"currency":"IDR"},"createdAt":"2024-04-16T16:32:34+07:00","transactions":[{"kind":"transaction","id":1,"transactionId":"TRX1","transactionType":"TUPVA","transactionDate":"2024-04-16","transactionTime":"2024-04-16T16:32:34+07:00","fromAccount":"222","toAccount":"111","amount":{"value":10,"currency":"IDR"},"status":"SUCCESS","method":"TUPVA","description":"","refNumber":"REF1","legs":[{"accountNumber":"222","entry":"DEBIT","amount":{"value":10,"currency":"IDR"}},{"accountNumber":"111","entry":"CREDIT","amount":{"value":10,"currency":"IDR"}}]}],"balances":[{"kind":"walletTransactionBalance","accountNumber":"111","currency":"IDR","before":{"actualBalance":"0","pendingBalance":"0","availableBalance":"0"},"after":{"actualBalance":"10","pendingBalance":"0","availableBalance":"10"},"createdAt":"2024-04-16T16:32:34+07:00"}]}`,
+			wantCode: 200,
+			doMock: func() {
+				testHelper.mockWalletService.EXPECT().
+					GetDetail(gomock.Any(), models.DoGetWalletTransactionDetailRequest{TransactionId: "ID1"}).
+					Return(detail, nil)
+			},
+		},
+		{
+			name:     "success by refNumber",
+			path:     "/api/v1/wallet-transactions?refNumber=REF1",
+			wantRes:  `{"kind":"walletTransaction","id":"ID1","status":"SUCCESS","accountNumber":"111","refNumber":"REF1","transactionType":"TUPVA","transactionFlow":"cashin","transactionTime":"2024-04-16 16:32:34","netAmount":{"value":10,"currency":"IDR"},"amounts":null,"destinationAccountNumber":"","description":"","metadata":null,"capturedAmount":{"value":0,"currency":"IDR"},"createdAt":"2024-04-16T16:32:34+07:00","transactions":[{"kind":"transaction","id":1,"transactionId":"TRX1","transactionType":"TUPVA","transactionDate":"2024-04-16","transactionTime":"2024-04-16T16:32:34+07:00","fromAccount":"222","toAccount":"111","amount":{"value":10,"currency":"IDR"},"status":"SUCCESS","method":"TUPVA","description":"","refNumber":"REF1","legs":[{"accountNumber":"222","entry":"DEBIT","amount":{"value":10,"currency":"IDR"}},{"accountNumber":"111","entry":"CREDIT","amount":{"value":10,"currency":"IDR"}}]}],"balances":[{"kind":"walletTransactionBalance","accountNumber":"111","currency":"IDR","before":{"actualBalance":"0","pendingBalance":"0","availableBalance":"0"},"after":{"actualBalance":"10","pendingBalance":"0","availableBalance":"10"},"createdAt":"2024-04-16T16:32:34+07:00"}]}`,
+			wantCode: 200,
+			doMock: func() {
+				testHelper.mockWalletService.EXPECT().
+					GetDetail(gomock.Any(), models.DoGetWalletTransactionDetailRequest{RefNumber: "REF1"}).
+					Return(detail, nil)
+			},
+		},
+		{
+			name:     "failed - ambiguous refNumber",
+			path:     "/api/v1/wallet-transactions?refNumber=REF2",
+			wantRes:  `{"status":"error","code":409,"message":"refNumber is used by more than one wallet transaction, transactionType is required"}`,
+			wantCode: 409,
+			doMock: func() {
+				testHelper.mockWalletService.EXPECT().
+					GetDetail(gomock.Any(), models.DoGetWalletTransactionDetailRequest{RefNumber: "REF2"}).
+					Return(nil, common.ErrAmbiguousRefNumber)
+			},
+		},
+		{
+			name:     "success by refNumber and transactionType",
+			path:     "/api/v1/wallet-transactions?refNumber=REF1&transactionType=TUPVA",
+			wantRes:  `{"kind":"walletTransaction","id":"ID1","status":"SUCCESS","accountNumber":"111","refNumber":"REF1","transactionType":"TUPVA","transactionFlow":"cashin","transactionTime":"2024-04-16 16:32:34","netAmount":{"value":10,"currency":"IDR"},"amounts":null,"destinationAccountNumber":"","description":"","metadata":null,"capturedAmount":{"value":0,"currency":"IDR"},"createdAt":"2024-04-16T16:32:34+07:00","transactions":[{"kind":"transaction","id":1,"transactionId":"TRX1","transactionType":"TUPVA","transactionDate":"2024-04-16","transactionTime":"2024-04-16T16:32:34+07:00","fromAccount":"222","toAccount":"111","amount":{"value":10,"currency":"IDR"},"status":"SUCCESS","method":"TUPVA","description":"","refNumber":"REF1","legs":[{"accountNumber":"222","entry":"DEBIT","amount":{"value":10,"currency":"IDR"}},{"accountNumber":"111","entry":"CREDIT","amount":{"value":10,"currency":"IDR"}}]}],"balances":[{"kind":"walletTransactionBalance","accountNumber":"111","currency":"IDR","before":{"actualBalance":"0","pendingBalance":"0","availableBalance":"0"},"after":{"actualBalance":"10","pendingBalance":"0","availableBalance":"10"},"createdAt":"2024-04-16T16:32:34+07:00"}]}`,
+			wantCode: 200,
+			doMock: func() {
+				testHelper.mockWalletService.EXPECT().
+					GetDetail(gomock.Any(), models.DoGetWalletTransactionDetailRequest{RefNumber: "REF1", TransactionType: "TUPVA"}).
+					Return(detail, nil)
+			},
+		},
+		{
+			name:     "failed - missing id and refNumber",
+			path:     "/api/v1/wallet-transactions",
+			wantRes:  `{"status":"error","code":400,"message":"transactionId or refNumber is required"}`,
+			wantCode: 400,
+		},
+		{
+			name:     "failed - not found",
+			path:     "/api/v1/wallet-transactions/ID2",
+			wantRes:  `{"status":"error","code":404,"message":"data not found"}`,
+			wantCode: 404,
+			doMock: func() {
+				testHelper.mockWalletService.EXPECT().
+					GetDetail(gomock.Any(), models.DoGetWalletTransactionDetailRequest{TransactionId: "ID2"}).
+					Return(nil, common.ErrDataNotFound)
+			},
+		},
+		{
+			name:     "failed - service error",
+			path:     "/api/v1/wallet-transactions/ID3",
+			wantRes:  `{"status":"error","code":500,"message":"assert.AnError general error for testing"}`,
+			wantCode: 500,
+			doMock: func() {
+				testHelper.mockWalletService.EXPECT().
+					GetDetail(gomock.Any(), models.DoGetWalletTransactionDetailRequest{TransactionId: "ID3"}).
+					Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wantCode, resp.StatusCode)
+			require.Equal(t, tt.wantRes, strings.TrimSuffix(string(body), "\n"))
+		})
+	}
+}
+
+func Test_Handler_getAsyncWalletTransaction(t *testing.T) {
+	testHelper := walletTrxTestHelper(t)
+	createdAt := time.Date(2024, 4, 16, 9, 32, 34, 0, time.UTC)
+	processedAt := createdAt.Add(time.Second)
+	asyncId := "0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e"
+
+	tests := []struct {
+		name     string
+		wantRes  string
+		wantCode int
+		doMock   func()
+	}{
+		{
+			name:     "success",
+			wantRes:  `{"kind":"asyncWalletTransaction","id":"0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e","status":"FAILED","refNumber":"REF1","transactionType":"TUPVA","accountNumber":"111","errorCode":"UNPROCESSABLE_TRANSACTION","errorMessage":"insufficient available balance","createdAt":"2024-04-16 16:32:34","processedAt":"2024-04-16 16:32:35"}`,
+			wantCode: 200,
+			doMock: func() {
+				testHelper.mockWalletService.EXPECT().
+					GetAsyncTransaction(gomock.Any(), asyncId, "async-client").
+					Return(&models.AsyncWalletTransaction{
+						ID:              asyncId,
+						ClientId:        "async-client",
+						RefNumber:       "REF1",
+						TransactionType: "TUPVA",
+						AccountNumber:   "111",
+						Status:          models.AsyncWalletTransactionStatusFailed,
+						ErrorCode:       models.WalletTransactionErrorCodeUnprocessable,
+						ErrorMessage:    "insufficient available balance",
+						CreatedAt:       createdAt,
+						ProcessedAt:     &processedAt,
+					}, nil)
+			},
+		},
+		{
+			name:     "failed - not found",
+			wantRes:  `{"status":"error","code":404,"message":"data not found"}`,
+			wantCode: 404,
+			doMock: func() {
+				testHelper.mockWalletService.EXPECT().
+					GetAsyncTransaction(gomock.Any(), asyncId, "async-client").
+					Return(nil, common.ErrDataNotFound)
+			},
+		},
+		{
+			name:     "failed - service error",
+			wantRes:  `{"status":"error","code":500,"message":"assert.AnError general error for testing"}`,
+			wantCode: 500,
+			doMock: func() {
+				testHelper.mockWalletService.EXPECT().
+					GetAsyncTransaction(gomock.Any(), asyncId, "async-client").
+					Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			req := httptest.NewRequest("GET", "/api/v1/wallet-transactions/async/"+asyncId, nil)
+			req.Header.Set(models.ClientIdHeader, "async-client")
+
+			rec := httptest.NewRecorder()
+			testHelper.router.ServeHTTP(rec, req)
+
+			resp := rec.Result()
+			defer resp.Body.Close()
+
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.wantCode, resp.StatusCode)
+			require.Equal(t, tt.wantRes, strings.TrimSuffix(string(body), "\n"))
+		})
+	}
+}
+
 type testWalletTrxHelper struct {
 	router              *echo.Echo
 	mockCtrl            *gomock.Controller
@@ -3,6 +3,9 @@ package files
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
 	nethttp "net/http"
 	"strings"
 
@@ -15,13 +18,15 @@ import (
 )
 
 type filesHandler struct {
-	fileSvc services.FileService
+	fileSvc     services.FileService
+	approvalSvc services.ApprovalService
 }
 
 // New will initialize the files/ resources endpoint
-func New(app *echo.Group, fileSvc services.FileService) {
+func New(app *echo.Group, fileSvc services.FileService, approvalSvc services.ApprovalService) {
 	handler := filesHandler{
-		fileSvc: fileSvc,
+		fileSvc:     fileSvc,
+		approvalSvc: approvalSvc,
 	}
 	files := app.Group("/files")
 	files.POST("/upload", handler.uploadFile)
@@ -34,6 +39,7 @@ func New(app *echo.Group, fileSvc services.FileService) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} models.FileOut "Response indicates that the request succeeded and the resources has been fetched and transmitted in the message body"
+// @Success 202 {object} models.DoGetApprovalResponse "Upload is pending approval"
 // @Failure 400 {object} http.RestErrorResponseModel "Bad request error. This can happen if there is an error while create account"
 // @Failure 500 {object} http.RestErrorResponseModel "Internal server error. This can happen if there is an error while create account"
 // @Router /v2/files/upload [post]
@@ -58,6 +64,22 @@ func (h *filesHandler) uploadFile(c echo.Context) error {
 
 	clientID := c.Request().Header.Get(models.ClientIdHeader)
 
+	if h.approvalSvc.IsRequired(models.ApprovalOperationUploadWalletTransaction) {
+		content, err := readFile(file)
+		if err != nil {
+			return http.RestErrorResponse(c, nethttp.StatusBadRequest, err)
+		}
+
+		return http.SubmitApprovalResponse(c, h.approvalSvc, models.ApprovalOperationUploadWalletTransaction,
+			models.ApprovalUploadWalletTransactionPayload{
+				FileName: file.Filename,
+				Content:  content,
+				ReportTo: username,
+				ClientID: clientID,
+			},
+			fmt.Sprintf("upload wallet transaction file %s", file.Filename))
+	}
+
 	go func() {
 		errUpload := h.fileSvc.UploadWalletTransaction(ctx, file, username, clientID)
 		if errUpload != nil {
@@ -67,3 +89,13 @@ func (h *filesHandler) uploadFile(c echo.Context) error {
 
 	return http.RestSuccessResponse(c, nethttp.StatusOK, models.NewFileOut(file.Filename, "processing"))
 }
+
+func readFile(file *multipart.FileHeader) ([]byte, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
@@ -2,6 +2,7 @@ package files
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"mime/multipart"
 	"net/http"
@@ -9,7 +10,9 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/services/mock"
 
 	xlog "bitbucket.org/Amartha/go-x/log"
@@ -54,10 +57,48 @@ func Test_Handler_uploadFile(t *testing.T) {
 				// Close the multipart writer to finalize the request body
 				writer.Close()
 
+				testHelper.mockApproval.EXPECT().IsRequired(models.ApprovalOperationUploadWalletTransaction).Return(false)
 				testHelper.mockService.EXPECT().UploadWalletTransaction(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(nil)
 			},
 		},
+		{
+			name: "success - submitted for approval",
+			expectation: Expectation{
+				wantRes:  `{"kind":"approval","id":"1","operation":"UPLOAD_WALLET_TRANSACTION","payload":{"fileName":"test.csv","reportTo":"test@gmail.com","clientId":""},"description":"upload wallet transaction file test.csv","state":"PENDING","maker":"test@gmail.com","makerClientId":"","checker":"","checkerClientId":"","executionError":"","expiresAt":"2026-01-02 07:00:00","decidedAt":"","createdAt":"","updatedAt":""}`,
+				wantCode: 202,
+			},
+			writer: multipart.NewWriter(&requestBody),
+			doMock: func(writer *multipart.Writer) {
+				fileWriter, _ := writer.CreateFormFile("files", "test.csv")
+				fileWriter.Write([]byte("csv content here"))
+
+				writer.Close()
+
+				payload := models.ApprovalUploadWalletTransactionPayload{
+					FileName: "test.csv",
+					Content:  []byte("csv content here"),
+					ReportTo: "test@gmail.com",
+				}
+				rawPayload, _ := json.Marshal(payload)
+
+				testHelper.mockApproval.EXPECT().IsRequired(models.ApprovalOperationUploadWalletTransaction).Return(true)
+				testHelper.mockApproval.EXPECT().Submit(gomock.Any(), models.SubmitApprovalRequest{
+					Operation:   models.ApprovalOperationUploadWalletTransaction,
+					Payload:     payload,
+					Description: "upload wallet transaction file test.csv",
+					Maker:       "test@gmail.com",
+				}).Return(&models.Approval{
+					ID:          1,
+					Operation:   models.ApprovalOperationUploadWalletTransaction,
+					Payload:     rawPayload,
+					Description: "upload wallet transaction file test.csv",
+					State:       models.ApprovalStatePending,
+					Maker:       "test@gmail.com",
+					ExpiresAt:   time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+				}, nil)
+			},
+		},
 		{
 			name: "failed - missing file",
 			expectation: Expectation{
@@ -94,6 +135,7 @@ func Test_Handler_uploadFile(t *testing.T) {
 			req := httptest.NewRequest(http.MethodPost, "/api/v2/files/upload", &requestBody)
 			req.Header.Set("Content-Type", tc.writer.FormDataContentType())
 			req.Header.Set("X-Ngmis-Username", "test@gmail.com")
+			req = req.WithContext(models.WithUser(req.Context(), "test@gmail.com"))
 
 			rec := httptest.NewRecorder()
 			testHelper.router.ServeHTTP(rec, req)
@@ -110,9 +152,10 @@ func Test_Handler_uploadFile(t *testing.T) {
 }
 
 type filesHandlerTestHelper struct {
-	router      *echo.Echo
-	mockCtrl    *gomock.Controller
-	mockService *mock.MockFileService
+	router       *echo.Echo
+	mockCtrl     *gomock.Controller
+	mockService  *mock.MockFileService
+	mockApproval *mock.MockApprovalService
 }
 
 func filesTestHelper(t *testing.T) filesHandlerTestHelper {
@@ -122,16 +165,18 @@ func filesTestHelper(t *testing.T) filesHandlerTestHelper {
 	defer mockCtrl.Finish()
 
 	mockSvc := mock.NewMockFileService(mockCtrl)
+	mockApprovalSvc := mock.NewMockApprovalService(mockCtrl)
 
 	app := echo.New()
 
 	v2Group := app.Group("/api/v2")
 	app.Pre(echomiddleware.RemoveTrailingSlash())
-	New(v2Group, mockSvc)
+	New(v2Group, mockSvc, mockApprovalSvc)
 
 	return filesHandlerTestHelper{
-		router:      app,
-		mockCtrl:    mockCtrl,
-		mockService: mockSvc,
+		router:       app,
+		mockCtrl:     mockCtrl,
+		mockService:  mockSvc,
+		mockApproval: mockApprovalSvc,
 	}
 }
@@ -11,8 +11,11 @@ import (
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/flag"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/log"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	v1approval "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/job/v1/approval"
 	v1file "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/job/v1/file"
+	v1moneyflow "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/job/v1/money_flow"
 	v1report "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/job/v1/report"
+	v1wallettransaction "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/job/v1/wallet_transaction"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
 
 	"github.com/google/uuid"
@@ -27,15 +30,32 @@ type Job struct {
 func New(cfg config.Config, srv *services.Services) *Job {
 	v1group := "v1"
 
-	jobRoutes := map[string]map[string]func(ctx context.Context, date time.Time, flag flag.Job) error{
-		v1group: v1report.Routes(srv.Transaction, services.NewReconBalanceService(srv)),
-		v1group: v1file.Routes(srv.File),
+	jobRoutes := JobRoutes{
+		v1group: mergeRoutes(
+			v1report.Routes(srv.Transaction, services.NewReconBalanceService(srv), srv.ScheduledReport, srv.TransactionMetric),
+			v1file.Routes(srv.File),
+			v1moneyflow.Routes(srv.MoneyFlowCalc),
+			v1approval.Routes(srv.Approval),
+			v1wallettransaction.Routes(srv.WalletTrx),
+		),
 		// add other version routes
 	}
 
 	return &Job{jobRoutes}
 }
 
+// mergeRoutes combines routes of the same version, a version key can only appear once in JobRoutes
+func mergeRoutes(routes ...map[string]func(ctx context.Context, date time.Time, flag flag.Job) error) map[string]func(ctx context.Context, date time.Time, flag flag.Job) error {
+	merged := map[string]func(ctx context.Context, date time.Time, flag flag.Job) error{}
+	for _, r := range routes {
+		for name, fn := range r {
+			merged[name] = fn
+		}
+	}
+
+	return merged
+}
+
 func (j *Job) Start(ctx context.Context, flag flag.Job) {
 	if fn, ok := j.Routes[flag.Version][flag.JobName]; ok {
 		var (
@@ -0,0 +1,46 @@
+package v1approval
+
+import (
+	"context"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/flag"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+	xlog "bitbucket.org/Amartha/go-x/log"
+)
+
+type approvalHandler struct {
+	approvalSrv services.ApprovalService
+}
+
+func Routes(as services.ApprovalService) map[string]func(ctx context.Context, date time.Time, flag flag.Job) error {
+	handler := approvalHandler{approvalSrv: as}
+	return map[string]func(ctx context.Context, date time.Time, flag flag.Job) error{
+		"ExpireApprovals":  handler.ExpireApprovals,
+		"ExecuteApprovals": handler.ExecuteApprovals,
+	}
+}
+
+// ExpireApprovals mark pending approvals which are not decided before their expiry time as expired
+func (ah *approvalHandler) ExpireApprovals(ctx context.Context, date time.Time, flag flag.Job) error {
+	expired, err := ah.approvalSrv.ExpirePending(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	xlog.Info(ctx, "ExpireApprovals", xlog.Int("expired", expired))
+
+	return nil
+}
+
+// ExecuteApprovals execute approved approvals whose operation is not executed by the approve request
+func (ah *approvalHandler) ExecuteApprovals(ctx context.Context, date time.Time, flag flag.Job) error {
+	executed, err := ah.approvalSrv.ExecuteApproved(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	xlog.Info(ctx, "ExecuteApprovals", xlog.Int("executed", executed))
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package v1moneyflow
+
+import (
+	"context"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/flag"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+	xlog "bitbucket.org/Amartha/go-x/log"
+)
+
+type moneyFlowHandler struct {
+	moneyFlowSrv services.MoneyFlowService
+}
+
+func Routes(mfs services.MoneyFlowService) map[string]func(ctx context.Context, date time.Time, flag flag.Job) error {
+	handler := moneyFlowHandler{moneyFlowSrv: mfs}
+	return map[string]func(ctx context.Context, date time.Time, flag flag.Job) error{
+		"DisburseMoneyFlowSummaries": handler.DisburseMoneyFlowSummaries,
+	}
+}
+
+// DisburseMoneyFlowSummaries is scheduled frequently, cut-off time of each payment type decides when summaries are disbursed
+func (mh *moneyFlowHandler) DisburseMoneyFlowSummaries(ctx context.Context, date time.Time, flag flag.Job) error {
+	result, err := mh.moneyFlowSrv.DisburseDueSummaries(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	xlog.Info(ctx, "DisburseMoneyFlowSummaries",
+		xlog.Int("disbursed", result.Disbursed),
+		xlog.Int("skipped", result.Skipped))
+
+	return nil
+}
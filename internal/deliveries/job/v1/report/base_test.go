@@ -15,6 +15,8 @@ type testReportHelper struct {
 	mockCtrl               *gomock.Controller
 	mockTransactionService *mock.MockTransactionService
 	mockReconService       *mock.MockReconService
+	mockScheduledReport    *mock.MockScheduledReportService
+	mockTransactionMetric  *mock.MockTransactionMetricService
 }
 
 func reportTestHelper(t *testing.T) testReportHelper {
@@ -25,13 +27,17 @@ func reportTestHelper(t *testing.T) testReportHelper {
 
 	mockTransactionService := mock.NewMockTransactionService(mockCtrl)
 	mockReconService := mock.NewMockReconService(mockCtrl)
+	mockScheduledReport := mock.NewMockScheduledReportService(mockCtrl)
+	mockTransactionMetric := mock.NewMockTransactionMetricService(mockCtrl)
 
-	Routes(mockTransactionService, mockReconService)
+	Routes(mockTransactionService, mockReconService, mockScheduledReport, mockTransactionMetric)
 
 	return testReportHelper{
 		mockCtrl:               mockCtrl,
 		mockTransactionService: mockTransactionService,
 		mockReconService:       mockReconService,
+		mockScheduledReport:    mockScheduledReport,
+		mockTransactionMetric:  mockTransactionMetric,
 	}
 }
 
@@ -2,28 +2,37 @@ package report
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
 	xlog "bitbucket.org/Amartha/go-x/log"
 
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/flag"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
 )
 
 type reportHandler struct {
-	transactionSrv services.TransactionService
-	reconSrv       services.ReconService
+	transactionSrv     services.TransactionService
+	reconSrv           services.ReconService
+	scheduledReportSrv services.ScheduledReportService
+	metricSrv          services.TransactionMetricService
 }
 
-func Routes(ts services.TransactionService, rs services.ReconService) map[string]func(ctx context.Context, date time.Time, flag flag.Job) error {
+func Routes(ts services.TransactionService, rs services.ReconService, srs services.ScheduledReportService, tms services.TransactionMetricService) map[string]func(ctx context.Context, date time.Time, flag flag.Job) error {
 	handler := reportHandler{
-		transactionSrv: ts,
-		reconSrv:       rs,
+		transactionSrv:     ts,
+		reconSrv:           rs,
+		scheduledReportSrv: srs,
+		metricSrv:          tms,
 	}
 	return map[string]func(ctx context.Context, date time.Time, flag flag.Job) error{
-		"GenerateTransactionReport": handler.GenerateTransactionReport,
-		"DoBalanceReconDaily":       handler.DoBalanceReconDaily,
+		"GenerateTransactionReport":        handler.GenerateTransactionReport,
+		"DoBalanceReconDaily":              handler.DoBalanceReconDaily,
+		"RunScheduledReports":              handler.RunScheduledReports,
+		"MaterializeTransactionAggregates": handler.MaterializeTransactionAggregates,
 		// add more job here
 	}
 }
@@ -48,3 +57,86 @@ func (rh *reportHandler) DoBalanceReconDaily(ctx context.Context, date time.Time
 
 	return nil
 }
+
+// RunScheduledReports generate the scheduled reports which are due on date, default is today.
+// Reports are backfilled when start and end date are given.
+func (rh *reportHandler) RunScheduledReports(ctx context.Context, date time.Time, flag flag.Job) error {
+	req := models.RunScheduledReportsRequest{
+		StartDate:  date,
+		EndDate:    date,
+		ReportName: flag.ReportName,
+	}
+
+	startDate, endDate, backfill, err := parseBackfillDates(flag)
+	if err != nil {
+		return err
+	}
+
+	if backfill {
+		req.StartDate, req.EndDate = startDate, endDate
+	} else if date.IsZero() {
+		today, err := common.NowZeroTime()
+		if err != nil {
+			return err
+		}
+
+		req.StartDate, req.EndDate = today, today
+	}
+
+	result, err := rh.scheduledReportSrv.RunScheduledReports(ctx, req)
+	xlog.Info(ctx, "RunScheduledReports",
+		xlog.String("start_date", req.StartDate.Format(common.DateFormatYYYYMMDD)),
+		xlog.String("end_date", req.EndDate.Format(common.DateFormatYYYYMMDD)),
+		xlog.Int("succeeded", result.Succeeded),
+		xlog.Int("failed", result.Failed),
+		xlog.Int("skipped", result.Skipped))
+
+	return err
+}
+
+// MaterializeTransactionAggregates aggregate transactions of the day before date, default is yesterday.
+// Days are backfilled when start and end date are given.
+func (rh *reportHandler) MaterializeTransactionAggregates(ctx context.Context, date time.Time, flag flag.Job) error {
+	startDate, endDate, backfill, err := parseBackfillDates(flag)
+	if err != nil {
+		return err
+	}
+
+	if !backfill {
+		if date.IsZero() {
+			date, err = common.NowZeroTime()
+			if err != nil {
+				return err
+			}
+		}
+
+		startDate = date.AddDate(0, 0, -1)
+		endDate = startDate
+	}
+
+	err = rh.metricSrv.MaterializeDailyAggregates(ctx, startDate, endDate)
+	xlog.Info(ctx, "MaterializeTransactionAggregates",
+		xlog.String("start_date", startDate.Format(common.DateFormatYYYYMMDD)),
+		xlog.String("end_date", endDate.Format(common.DateFormatYYYYMMDD)))
+
+	return err
+}
+
+// parseBackfillDates parse start and end date flags, backfill is false when none of them is given
+func parseBackfillDates(flag flag.Job) (startDate, endDate time.Time, backfill bool, err error) {
+	if flag.StartDate == "" && flag.EndDate == "" {
+		return startDate, endDate, false, nil
+	}
+
+	startDate, err = common.ParseStringToDatetime(common.DateFormatYYYYMMDD, flag.StartDate)
+	if err != nil {
+		return startDate, endDate, true, fmt.Errorf("invalid start date: %w", err)
+	}
+
+	endDate, err = common.ParseStringToDatetime(common.DateFormatYYYYMMDD, flag.EndDate)
+	if err != nil {
+		return startDate, endDate, true, fmt.Errorf("invalid end date: %w", err)
+	}
+
+	return startDate, endDate, true, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/flag"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -125,3 +126,171 @@ func Test_reportHandler_DoBalanceReconDaily(t *testing.T) {
 		})
 	}
 }
+
+func Test_reportHandler_RunScheduledReports(t *testing.T) {
+	testHelper := reportTestHelper(t)
+
+	date := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx  context.Context
+		date time.Time
+		flag flag.Job
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr bool
+	}{
+		{
+			name: "success run reports of date",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+				flag: flag.Job{ReportName: "balance-daily"},
+			},
+			doMock: func(args args) {
+				testHelper.mockScheduledReport.EXPECT().RunScheduledReports(gomock.AssignableToTypeOf(args.ctx), models.RunScheduledReportsRequest{
+					StartDate:  date,
+					EndDate:    date,
+					ReportName: "balance-daily",
+				}).Return(models.RunScheduledReportsResult{Succeeded: 1}, nil)
+			},
+		},
+		{
+			name: "success backfill reports",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+				flag: flag.Job{StartDate: "2025-01-01", EndDate: "2025-01-31"},
+			},
+			doMock: func(args args) {
+				testHelper.mockScheduledReport.EXPECT().RunScheduledReports(gomock.AssignableToTypeOf(args.ctx), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, req models.RunScheduledReportsRequest) (models.RunScheduledReportsResult, error) {
+						assert.Equal(t, "2025-01-01", req.StartDate.Format(common.DateFormatYYYYMMDD))
+						assert.Equal(t, "2025-01-31", req.EndDate.Format(common.DateFormatYYYYMMDD))
+						return models.RunScheduledReportsResult{Succeeded: 2, Skipped: 29}, nil
+					})
+			},
+		},
+		{
+			name: "error invalid backfill date",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+				flag: flag.Job{StartDate: "2025-01-01"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error RunScheduledReports",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+			},
+			doMock: func(args args) {
+				testHelper.mockScheduledReport.EXPECT().RunScheduledReports(gomock.AssignableToTypeOf(args.ctx), gomock.Any()).
+					Return(models.RunScheduledReportsResult{Failed: 1}, assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+			rh := &reportHandler{
+				scheduledReportSrv: testHelper.mockScheduledReport,
+			}
+			err := rh.RunScheduledReports(tt.args.ctx, tt.args.date, tt.args.flag)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func Test_reportHandler_MaterializeTransactionAggregates(t *testing.T) {
+	testHelper := reportTestHelper(t)
+
+	date := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx  context.Context
+		date time.Time
+		flag flag.Job
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr bool
+	}{
+		{
+			name: "success materialize previous day",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+			},
+			doMock: func(args args) {
+				yesterday := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+				testHelper.mockTransactionMetric.EXPECT().
+					MaterializeDailyAggregates(gomock.AssignableToTypeOf(args.ctx), yesterday, yesterday).
+					Return(nil)
+			},
+		},
+		{
+			name: "success backfill days",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+				flag: flag.Job{StartDate: "2025-01-01", EndDate: "2025-01-31"},
+			},
+			doMock: func(args args) {
+				testHelper.mockTransactionMetric.EXPECT().
+					MaterializeDailyAggregates(gomock.AssignableToTypeOf(args.ctx), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, startDate, endDate time.Time) error {
+						assert.Equal(t, "2025-01-01", startDate.Format(common.DateFormatYYYYMMDD))
+						assert.Equal(t, "2025-01-31", endDate.Format(common.DateFormatYYYYMMDD))
+						return nil
+					})
+			},
+		},
+		{
+			name: "error invalid backfill date",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+				flag: flag.Job{EndDate: "2025-01-31"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error MaterializeDailyAggregates",
+			args: args{
+				ctx:  context.TODO(),
+				date: date,
+			},
+			doMock: func(args args) {
+				testHelper.mockTransactionMetric.EXPECT().
+					MaterializeDailyAggregates(gomock.AssignableToTypeOf(args.ctx), gomock.Any(), gomock.Any()).
+					Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+			rh := &reportHandler{
+				metricSrv: testHelper.mockTransactionMetric,
+			}
+			err := rh.MaterializeTransactionAggregates(tt.args.ctx, tt.args.date, tt.args.flag)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
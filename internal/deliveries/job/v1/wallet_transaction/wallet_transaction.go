@@ -0,0 +1,34 @@
+package v1wallettransaction
+
+import (
+	"context"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/flag"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+	xlog "bitbucket.org/Amartha/go-x/log"
+)
+
+type walletTransactionHandler struct {
+	walletTrxSrv services.WalletTrxService
+}
+
+func Routes(wts services.WalletTrxService) map[string]func(ctx context.Context, date time.Time, flag flag.Job) error {
+	handler := walletTransactionHandler{walletTrxSrv: wts}
+	return map[string]func(ctx context.Context, date time.Time, flag flag.Job) error{
+		"SendAsyncWalletTransactionCallbacks": handler.SendAsyncWalletTransactionCallbacks,
+	}
+}
+
+// SendAsyncWalletTransactionCallbacks send the pending callbacks of finished async wallet transactions,
+// failed callback is retried by the next run until its max attempts is reached
+func (wh *walletTransactionHandler) SendAsyncWalletTransactionCallbacks(ctx context.Context, date time.Time, flag flag.Job) error {
+	sent, err := wh.walletTrxSrv.SendAsyncCallbacks(ctx)
+	if err != nil {
+		return err
+	}
+
+	xlog.Info(ctx, "SendAsyncWalletTransactionCallbacks", xlog.Int("sent", sent))
+
+	return nil
+}
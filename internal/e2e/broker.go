@@ -0,0 +1,603 @@
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	kafkacommon "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
+
+	"github.com/Shopify/sarama"
+)
+
+const defaultPartitions = 3
+
+// Broker is an in-memory kafka cluster for the end-to-end tests.
+// It gives sarama.SyncProducer to the publishers and sarama.ConsumerGroup to kafka.BaseConsumer,
+// topics are created with the same number of partitions when they are first used.
+// Every partition of the consumed topics is claimed by every member of a group,
+// so a consumer group must be run by a single consumer
+type Broker struct {
+	partitions int32
+
+	mu        sync.Mutex
+	topics    map[string][][]*sarama.ConsumerMessage
+	committed map[string]map[string]map[int32]int64
+	// changed is closed and replaced whenever a message is produced or a partition is paused or resumed,
+	// so waiting claims can check it again
+	changed    chan struct{}
+	roundRobin uint32
+}
+
+var _ kafkacommon.OffsetReader = (*Broker)(nil)
+
+// NewBroker create a broker which creates topics with partitions, default partitions is used when it is not positive
+func NewBroker(partitions int32) *Broker {
+	if partitions <= 0 {
+		partitions = defaultPartitions
+	}
+
+	return &Broker{
+		partitions: partitions,
+		topics:     map[string][][]*sarama.ConsumerMessage{},
+		committed:  map[string]map[string]map[int32]int64{},
+		changed:    make(chan struct{}),
+	}
+}
+
+// SyncProducer return a producer to the broker, message with the same key is always produced to the same partition
+func (b *Broker) SyncProducer() sarama.SyncProducer {
+	return &syncProducer{broker: b}
+}
+
+// NewConsumerGroup has the signature of sarama.NewConsumerGroup, addrs and config are ignored
+// except the initial offset which is used by group without committed offset
+func (b *Broker) NewConsumerGroup(_ []string, groupID string, config *sarama.Config) (sarama.ConsumerGroup, error) {
+	initial := sarama.OffsetNewest
+	if config != nil {
+		initial = config.Consumer.Offsets.Initial
+	}
+
+	return &consumerGroup{
+		broker:  b,
+		groupID: groupID,
+		initial: initial,
+		errors:  make(chan error),
+		paused:  map[string]map[int32]bool{},
+	}, nil
+}
+
+// Produce append a message to the topic, it is what SyncProducer does for every message
+func (b *Broker) Produce(msg *sarama.ProducerMessage) (partition int32, offset int64, err error) {
+	if msg.Topic == "" {
+		return 0, 0, errors.New("no topic given to produce the message")
+	}
+
+	key, err := encode(msg.Key)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to encode key: %w", err)
+	}
+
+	value, err := encode(msg.Value)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to encode value: %w", err)
+	}
+
+	headers := make([]*sarama.RecordHeader, 0, len(msg.Headers))
+	for i := range msg.Headers {
+		headers = append(headers, &msg.Headers[i])
+	}
+
+	timestamp := msg.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	partitions := b.topic(msg.Topic)
+	partition = b.partitionOf(key)
+	offset = int64(len(partitions[partition]))
+
+	partitions[partition] = append(partitions[partition], &sarama.ConsumerMessage{
+		Headers:   headers,
+		Timestamp: timestamp,
+		Key:       key,
+		Value:     value,
+		Topic:     msg.Topic,
+		Partition: partition,
+		Offset:    offset,
+	})
+	msg.Partition = partition
+	msg.Offset = offset
+	b.notify()
+
+	return partition, offset, nil
+}
+
+// Messages return every message of the topic ordered by partition and offset
+func (b *Broker) Messages(topic string) []*sarama.ConsumerMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var messages []*sarama.ConsumerMessage
+	for _, partition := range b.topics[topic] {
+		messages = append(messages, partition...)
+	}
+
+	return messages
+}
+
+// GroupLag return the lag of the group on every partition of topics,
+// partition without committed offset is counted from the first message
+func (b *Broker) GroupLag(group string, topics []string) ([]kafkacommon.PartitionLag, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var lags []kafkacommon.PartitionLag
+	for _, topic := range topics {
+		for partition, messages := range b.topic(topic) {
+			committed := b.committed[group][topic][int32(partition)]
+			newest := int64(len(messages))
+			lags = append(lags, kafkacommon.PartitionLag{
+				Topic:     topic,
+				Partition: int32(partition),
+				Committed: committed,
+				Newest:    newest,
+				Lag:       max(newest-committed, 0),
+			})
+		}
+	}
+
+	return lags, nil
+}
+
+// OffsetsForTime return the offset of the first message produced at or after at on every partition of topics,
+// partition without such message is given its newest offset
+func (b *Broker) OffsetsForTime(topics []string, at time.Time) (map[string]map[int32]int64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	offsets := make(map[string]map[int32]int64, len(topics))
+	for _, topic := range topics {
+		offsets[topic] = map[int32]int64{}
+		for partition, messages := range b.topic(topic) {
+			offset := int64(len(messages))
+			for _, message := range messages {
+				if !message.Timestamp.Before(at) {
+					offset = message.Offset
+					break
+				}
+			}
+
+			offsets[topic][int32(partition)] = offset
+		}
+	}
+
+	return offsets, nil
+}
+
+// topic return the partitions of the topic, it is created when it does not exist yet. b.mu must be held
+func (b *Broker) topic(name string) [][]*sarama.ConsumerMessage {
+	partitions, ok := b.topics[name]
+	if !ok {
+		partitions = make([][]*sarama.ConsumerMessage, b.partitions)
+		b.topics[name] = partitions
+	}
+
+	return partitions
+}
+
+// partitionOf return the partition of the key, message without key is spread over the partitions. b.mu must be held
+func (b *Broker) partitionOf(key []byte) int32 {
+	if key == nil {
+		b.roundRobin++
+		return int32(b.roundRobin % uint32(b.partitions))
+	}
+
+	hasher := fnv.New32a()
+	_, _ = hasher.Write(key)
+
+	return int32(hasher.Sum32() % uint32(b.partitions))
+}
+
+// committedOffset return the next offset of the group to consume on the partition, initial is used when nothing is committed.
+// b.mu must be held
+func (b *Broker) committedOffset(group, topic string, partition int32, initial int64) int64 {
+	if offset, ok := b.committed[group][topic][partition]; ok {
+		return offset
+	}
+
+	if initial == sarama.OffsetOldest {
+		return 0
+	}
+
+	return int64(len(b.topic(topic)[partition]))
+}
+
+// commit store the next offset of the group to consume on the partition. b.mu must be held
+func (b *Broker) commit(group, topic string, partition int32, offset int64) {
+	if b.committed[group] == nil {
+		b.committed[group] = map[string]map[int32]int64{}
+	}
+
+	if b.committed[group][topic] == nil {
+		b.committed[group][topic] = map[int32]int64{}
+	}
+
+	b.committed[group][topic][partition] = offset
+}
+
+// notify wake up every claim waiting for a change. b.mu must be held
+func (b *Broker) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+func encode(encoder sarama.Encoder) ([]byte, error) {
+	if encoder == nil {
+		return nil, nil
+	}
+
+	return encoder.Encode()
+}
+
+type syncProducer struct {
+	broker *Broker
+}
+
+func (p *syncProducer) SendMessage(msg *sarama.ProducerMessage) (partition int32, offset int64, err error) {
+	return p.broker.Produce(msg)
+}
+
+func (p *syncProducer) SendMessages(msgs []*sarama.ProducerMessage) error {
+	for _, msg := range msgs {
+		if _, _, err := p.broker.Produce(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *syncProducer) Close() error {
+	return nil
+}
+
+func (p *syncProducer) TxnStatus() sarama.ProducerTxnStatusFlag {
+	return sarama.ProducerTxnFlagReady
+}
+
+func (p *syncProducer) IsTransactional() bool {
+	return false
+}
+
+func (p *syncProducer) BeginTxn() error {
+	return sarama.ErrNonTransactedProducer
+}
+
+func (p *syncProducer) CommitTxn() error {
+	return sarama.ErrNonTransactedProducer
+}
+
+func (p *syncProducer) AbortTxn() error {
+	return sarama.ErrNonTransactedProducer
+}
+
+func (p *syncProducer) AddOffsetsToTxn(map[string][]*sarama.PartitionOffsetMetadata, string) error {
+	return sarama.ErrNonTransactedProducer
+}
+
+func (p *syncProducer) AddMessageToTxn(*sarama.ConsumerMessage, string, *string) error {
+	return sarama.ErrNonTransactedProducer
+}
+
+type consumerGroup struct {
+	broker  *Broker
+	groupID string
+	initial int64
+	errors  chan error
+
+	// paused and pausedAll are guarded by broker.mu, so claims see them together with the messages
+	paused    map[string]map[int32]bool
+	pausedAll bool
+
+	mu         sync.Mutex
+	closed     bool
+	generation int32
+	cancel     context.CancelFunc
+	sessions   sync.WaitGroup
+}
+
+// Consume run a session claiming every partition of topics until ctx is done or the group is closed
+func (g *consumerGroup) Consume(ctx context.Context, topics []string, handler sarama.ConsumerGroupHandler) error {
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		return sarama.ErrClosedConsumerGroup
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	g.generation++
+	g.cancel = cancel
+	g.sessions.Add(1)
+	defer g.sessions.Done()
+
+	sess := &session{
+		ctx:        ctx,
+		group:      g,
+		generation: g.generation,
+		claims:     map[string][]int32{},
+		offsets:    map[string]map[int32]int64{},
+	}
+	g.mu.Unlock()
+
+	g.broker.mu.Lock()
+	for _, topic := range topics {
+		sess.offsets[topic] = map[int32]int64{}
+		for partition := range g.broker.topic(topic) {
+			sess.claims[topic] = append(sess.claims[topic], int32(partition))
+			sess.offsets[topic][int32(partition)] = g.broker.committedOffset(g.groupID, topic, int32(partition), g.initial)
+		}
+	}
+	g.broker.mu.Unlock()
+
+	if err := handler.Setup(sess); err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(topics)*int(g.broker.partitions))
+	for topic, partitions := range sess.claims {
+		for _, partition := range partitions {
+			c := &claim{
+				topic:     topic,
+				partition: partition,
+				offset:    sess.offset(topic, partition),
+				messages:  make(chan *sarama.ConsumerMessage),
+				broker:    g.broker,
+			}
+
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				c.feed(ctx, g)
+			}()
+			go func() {
+				defer wg.Done()
+				if err := handler.ConsumeClaim(sess, c); err != nil {
+					errs <- err
+				}
+				// the handler may return before its messages channel is drained, e.g. on error
+				cancel()
+				for range c.messages {
+				}
+			}()
+		}
+	}
+
+	<-ctx.Done()
+	wg.Wait()
+	close(errs)
+
+	err := handler.Cleanup(sess)
+	for errClaim := range errs {
+		err = errors.Join(err, errClaim)
+	}
+
+	return err
+}
+
+func (g *consumerGroup) Errors() <-chan error {
+	return g.errors
+}
+
+// Close end the running session and wait for it, then Consume always returns sarama.ErrClosedConsumerGroup
+func (g *consumerGroup) Close() error {
+	g.mu.Lock()
+	if g.closed {
+		g.mu.Unlock()
+		return nil
+	}
+
+	g.closed = true
+	if g.cancel != nil {
+		g.cancel()
+	}
+	g.mu.Unlock()
+
+	g.sessions.Wait()
+	close(g.errors)
+
+	return nil
+}
+
+func (g *consumerGroup) Pause(partitions map[string][]int32) {
+	g.setPaused(partitions, true)
+}
+
+func (g *consumerGroup) Resume(partitions map[string][]int32) {
+	g.setPaused(partitions, false)
+}
+
+func (g *consumerGroup) PauseAll() {
+	g.broker.mu.Lock()
+	defer g.broker.mu.Unlock()
+
+	g.pausedAll = true
+	g.broker.notify()
+}
+
+func (g *consumerGroup) ResumeAll() {
+	g.broker.mu.Lock()
+	defer g.broker.mu.Unlock()
+
+	g.pausedAll = false
+	g.paused = map[string]map[int32]bool{}
+	g.broker.notify()
+}
+
+func (g *consumerGroup) setPaused(partitions map[string][]int32, paused bool) {
+	g.broker.mu.Lock()
+	defer g.broker.mu.Unlock()
+
+	for topic, ps := range partitions {
+		if g.paused[topic] == nil {
+			g.paused[topic] = map[int32]bool{}
+		}
+
+		for _, partition := range ps {
+			g.paused[topic][partition] = paused
+		}
+	}
+	g.broker.notify()
+}
+
+type session struct {
+	ctx        context.Context
+	group      *consumerGroup
+	generation int32
+	claims     map[string][]int32
+
+	mu      sync.Mutex
+	offsets map[string]map[int32]int64
+}
+
+func (s *session) Claims() map[string][]int32 {
+	return s.claims
+}
+
+func (s *session) MemberID() string {
+	return s.group.groupID + "-member"
+}
+
+func (s *session) GenerationID() int32 {
+	return s.generation
+}
+
+// MarkOffset only moves the offset forward and commits it right away
+func (s *session) MarkOffset(topic string, partition int32, offset int64, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if offset <= s.offsets[topic][partition] {
+		return
+	}
+
+	s.setOffset(topic, partition, offset)
+}
+
+func (s *session) Commit() {}
+
+// ResetOffset only moves the offset backward and commits it right away,
+// it is applied to the claim when it is called before the partitions are consumed, i.e. in Setup
+func (s *session) ResetOffset(topic string, partition int32, offset int64, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if offset >= s.offsets[topic][partition] {
+		return
+	}
+
+	s.setOffset(topic, partition, offset)
+}
+
+func (s *session) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	s.MarkOffset(msg.Topic, msg.Partition, msg.Offset+1, metadata)
+}
+
+func (s *session) Context() context.Context {
+	return s.ctx
+}
+
+func (s *session) offset(topic string, partition int32) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.offsets[topic][partition]
+}
+
+// setOffset s.mu must be held
+func (s *session) setOffset(topic string, partition int32, offset int64) {
+	if s.offsets[topic] == nil {
+		s.offsets[topic] = map[int32]int64{}
+	}
+	s.offsets[topic][partition] = offset
+
+	s.group.broker.mu.Lock()
+	s.group.broker.commit(s.group.groupID, topic, partition, offset)
+	s.group.broker.mu.Unlock()
+}
+
+type claim struct {
+	topic     string
+	partition int32
+	offset    int64
+	messages  chan *sarama.ConsumerMessage
+	broker    *Broker
+}
+
+func (c *claim) Topic() string {
+	return c.topic
+}
+
+func (c *claim) Partition() int32 {
+	return c.partition
+}
+
+func (c *claim) InitialOffset() int64 {
+	return c.offset
+}
+
+func (c *claim) HighWaterMarkOffset() int64 {
+	c.broker.mu.Lock()
+	defer c.broker.mu.Unlock()
+
+	return int64(len(c.broker.topic(c.topic)[c.partition]))
+}
+
+func (c *claim) Messages() <-chan *sarama.ConsumerMessage {
+	return c.messages
+}
+
+// feed send the messages of the partition from the claim offset until ctx is done,
+// it waits while the partition is paused or there is no new message
+func (c *claim) feed(ctx context.Context, g *consumerGroup) {
+	defer close(c.messages)
+
+	next := c.offset
+	for {
+		c.broker.mu.Lock()
+		messages := c.broker.topic(c.topic)[c.partition]
+		paused := g.pausedAll || g.paused[c.topic][c.partition]
+		changed := c.broker.changed
+
+		var message *sarama.ConsumerMessage
+		if !paused && next < int64(len(messages)) {
+			message = messages[next]
+		}
+		c.broker.mu.Unlock()
+
+		if message == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+				continue
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case c.messages <- message:
+			next++
+		}
+	}
+}
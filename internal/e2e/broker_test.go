@@ -0,0 +1,178 @@
+package e2e
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	kafkacommon "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	xlog.InitForTest()
+}
+
+type recorder struct {
+	mu     sync.Mutex
+	values []string
+}
+
+func (r *recorder) handle(_ context.Context, msg *sarama.ConsumerMessage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.values = append(r.values, string(msg.Value))
+	return nil
+}
+
+func (r *recorder) get() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.values...)
+}
+
+func startConsumer(t *testing.T, broker *Broker, topic string, handler kafkacommon.HandlerFunc) *kafkacommon.BaseConsumer {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	consumer, err := kafkacommon.NewBaseConsumer(kafkacommon.BaseConsumerConfig{
+		Ctx: ctx,
+		Config: config.Config{
+			MessageBroker: config.MessageBroker{
+				KafkaConsumer: config.ConsumerConfig{Brokers: []string{"in-memory"}, IsOldest: true},
+			},
+			ExponentialBackoff: config.ExponentialBackOffConfig{MaxRetries: 1, MaxBackoffTime: time.Millisecond},
+		},
+		Topics:           []string{topic},
+		ConsumerGroup:    "test-group",
+		Handler:          handler,
+		NewConsumerGroup: broker.NewConsumerGroup,
+	})
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = consumer.Start()()
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		_ = consumer.Stop()(context.Background())
+		<-done
+	})
+
+	return consumer
+}
+
+func waitLag(t *testing.T, broker *Broker, topic string) {
+	t.Helper()
+
+	assert.Eventually(t, func() bool {
+		lags, err := broker.GroupLag("test-group", []string{topic})
+		if err != nil {
+			return false
+		}
+
+		for _, lag := range lags {
+			if lag.Lag > 0 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func produce(t *testing.T, broker *Broker, topic string, values ...string) {
+	t.Helper()
+
+	for _, value := range values {
+		_, _, err := broker.SyncProducer().SendMessage(&sarama.ProducerMessage{
+			Topic: topic,
+			Key:   sarama.StringEncoder("key"),
+			Value: sarama.StringEncoder(value),
+		})
+		require.NoError(t, err)
+	}
+}
+
+func Test_Broker_Produce(t *testing.T) {
+	broker := NewBroker(2)
+
+	first, offset, err := broker.Produce(&sarama.ProducerMessage{Topic: "topic", Key: sarama.StringEncoder("a"), Value: sarama.StringEncoder("1")})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), offset)
+
+	partition, offset, err := broker.Produce(&sarama.ProducerMessage{Topic: "topic", Key: sarama.StringEncoder("a"), Value: sarama.StringEncoder("2")})
+	require.NoError(t, err)
+	assert.Equal(t, first, partition, "same key is produced to the same partition")
+	assert.Equal(t, int64(1), offset)
+
+	messages := broker.Messages("topic")
+	require.Len(t, messages, 2)
+	assert.Equal(t, "1", string(messages[0].Value))
+	assert.Equal(t, "2", string(messages[1].Value))
+
+	lags, err := broker.GroupLag("group", []string{"topic"})
+	require.NoError(t, err)
+	assert.Len(t, lags, 2)
+}
+
+func Test_Broker_Consume(t *testing.T) {
+	broker := NewBroker(0)
+	produce(t, broker, "topic", "1", "2")
+
+	rec := &recorder{}
+	startConsumer(t, broker, "topic", rec.handle)
+	waitLag(t, broker, "topic")
+
+	produce(t, broker, "topic", "3")
+	waitLag(t, broker, "topic")
+
+	assert.Equal(t, []string{"1", "2", "3"}, rec.get())
+}
+
+func Test_Broker_Pause(t *testing.T) {
+	broker := NewBroker(0)
+	rec := &recorder{}
+	consumer := startConsumer(t, broker, "topic", rec.handle)
+
+	produce(t, broker, "topic", "1")
+	waitLag(t, broker, "topic")
+
+	consumer.Pause()
+	produce(t, broker, "topic", "2")
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, []string{"1"}, rec.get(), "paused consumer does not fetch")
+
+	consumer.Resume()
+	waitLag(t, broker, "topic")
+	assert.Equal(t, []string{"1", "2"}, rec.get())
+}
+
+func Test_Broker_ResetOffsets(t *testing.T) {
+	broker := NewBroker(1)
+	rec := &recorder{}
+	consumer := startConsumer(t, broker, "topic", rec.handle)
+
+	produce(t, broker, "topic", "1", "2")
+	waitLag(t, broker, "topic")
+
+	offsets, err := broker.OffsetsForTime([]string{"topic"}, time.Time{})
+	require.NoError(t, err)
+	require.NoError(t, consumer.ResetOffsets(offsets))
+
+	assert.Eventually(t, func() bool {
+		return len(rec.get()) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"1", "2", "1", "2"}, rec.get())
+}
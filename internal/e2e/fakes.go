@@ -0,0 +1,277 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"sync"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/acuanclient"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/ddd_notification"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/flag"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/queueunicorn"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/webhook"
+	kafkaRecon "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/kafka_recon"
+
+	goacuanlib "bitbucket.org/Amartha/go-acuan-lib/model"
+	sdkflag "bitbucket.org/Amartha/go-feature-flag-sdk"
+
+	"github.com/Unleash/unleash-client-go/v3/api"
+)
+
+var errNotAvailable = errors.New("not available in the end-to-end tests")
+
+// Acuan record the transactions published to acuan
+type Acuan struct {
+	mu       sync.Mutex
+	requests []acuanclient.PublishTransactionRequest
+}
+
+var _ acuanclient.AcuanClient = (*Acuan)(nil)
+
+func (a *Acuan) PublishTransaction(_ context.Context, req acuanclient.PublishTransactionRequest) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.requests = append(a.requests, req)
+	return nil
+}
+
+// Requests return the transactions published to acuan
+func (a *Acuan) Requests() []acuanclient.PublishTransactionRequest {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]acuanclient.PublishTransactionRequest(nil), a.requests...)
+}
+
+// Accounting has no account mapping, transaction types which look up go-accounting fail with it
+type Accounting struct{}
+
+var _ accounting.Client = Accounting{}
+
+func (Accounting) GetInvestedAccountNumber(context.Context, string) (string, error) {
+	return "", fmt.Errorf("go-accounting is %w", errNotAvailable)
+}
+
+func (Accounting) GetReceivableAccountNumber(context.Context, string) (string, error) {
+	return "", fmt.Errorf("go-accounting is %w", errNotAvailable)
+}
+
+func (Accounting) GetLoanAdvancePayment(context.Context, string) (string, error) {
+	return "", fmt.Errorf("go-accounting is %w", errNotAvailable)
+}
+
+func (Accounting) GetLoanPartnerAccounts(context.Context, string, string) (accounting.ResponseGetListAccountNumber, error) {
+	return accounting.ResponseGetListAccountNumber{}, fmt.Errorf("go-accounting is %w", errNotAvailable)
+}
+
+// Flag is a feature flag client with fixed toggles and variants,
+// unknown variant is returned disabled like the flag service does for unknown key
+type Flag struct {
+	mu       sync.RWMutex
+	enabled  map[string]bool
+	variants map[string]*api.Variant
+}
+
+var _ flag.Client = (*Flag)(nil)
+
+func NewFlag() *Flag {
+	return &Flag{
+		enabled:  map[string]bool{},
+		variants: map[string]*api.Variant{},
+	}
+}
+
+// Enable set the toggle of name
+func (f *Flag) Enable(name string, enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.enabled[name] = enabled
+}
+
+// SetVariant enable the variant of name with value encoded as json payload
+func (f *Flag) SetVariant(name string, value any) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to marshal variant %s: %w", name, err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.variants[name] = &api.Variant{
+		Name:    name,
+		Enabled: true,
+		Payload: api.Payload{Type: "json", Value: string(payload)},
+	}
+
+	return nil
+}
+
+func (f *Flag) Close() error {
+	return nil
+}
+
+func (f *Flag) GetVariant(name string) *api.Variant {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if variant, ok := f.variants[name]; ok {
+		return variant
+	}
+
+	return &api.Variant{Name: "disabled", Enabled: false}
+}
+
+func (f *Flag) IsEnabled(name string, _ ...sdkflag.FeatureOption) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.enabled[name]
+}
+
+func (f *Flag) WaitForReady() {}
+
+// Notification record the emails sent through ddd notification
+type Notification struct {
+	mu     sync.Mutex
+	emails []ddd_notification.RequestEmail
+}
+
+var _ ddd_notification.DDDNotification = (*Notification)(nil)
+
+func (n *Notification) SendEmail(_ context.Context, req ddd_notification.RequestEmail) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.emails = append(n.emails, req)
+	return nil
+}
+
+// Emails return the emails sent
+func (n *Notification) Emails() []ddd_notification.RequestEmail {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]ddd_notification.RequestEmail(nil), n.emails...)
+}
+
+// QueueJob is a job sent to queue unicorn with the response of running it
+type QueueJob struct {
+	Request    queueunicorn.RequestJobHTTP
+	StatusCode int
+	Response   []byte
+	Err        error
+}
+
+// QueueUnicorn run the http job right away instead of scheduling it, so the job result is known when SendJobHTTP returns.
+// Like queue unicorn, failed job is not returned as error of SendJobHTTP, it is only recorded in Jobs
+type QueueUnicorn struct {
+	client *nethttp.Client
+
+	mu   sync.Mutex
+	jobs []QueueJob
+}
+
+var _ queueunicorn.Client = (*QueueUnicorn)(nil)
+
+func NewQueueUnicorn(client *nethttp.Client) *QueueUnicorn {
+	if client == nil {
+		client = &nethttp.Client{Timeout: 30 * time.Second}
+	}
+
+	return &QueueUnicorn{client: client}
+}
+
+func (q *QueueUnicorn) SendJobHTTP(ctx context.Context, req queueunicorn.RequestJobHTTP) error {
+	job := QueueJob{Request: req}
+	job.StatusCode, job.Response, job.Err = q.run(ctx, req.Payload)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.jobs = append(q.jobs, job)
+	return nil
+}
+
+// Jobs return the jobs sent with their result
+func (q *QueueUnicorn) Jobs() []QueueJob {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return append([]QueueJob(nil), q.jobs...)
+}
+
+func (q *QueueUnicorn) run(ctx context.Context, payload queueunicorn.PayloadJob) (int, []byte, error) {
+	body, err := json.Marshal(payload.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to marshal job body: %w", err)
+	}
+
+	req, err := nethttp.NewRequestWithContext(ctx, payload.Method, payload.Host, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to create job request: %w", err)
+	}
+
+	for key, value := range payload.Headers {
+		req.Header.Set(key, fmt.Sprint(value))
+	}
+
+	res, err := q.client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to run job: %w", err)
+	}
+	defer res.Body.Close()
+
+	response, err := io.ReadAll(res.Body)
+	if err != nil {
+		return res.StatusCode, nil, fmt.Errorf("failed to read job response: %w", err)
+	}
+
+	return res.StatusCode, response, nil
+}
+
+// WebhookCall is a payload sent by the webhook client
+type WebhookCall struct {
+	URL     string
+	Payload any
+}
+
+// Webhook record the payloads instead of sending them
+type Webhook struct {
+	mu    sync.Mutex
+	calls []WebhookCall
+}
+
+var _ webhook.Client = (*Webhook)(nil)
+
+func (w *Webhook) Send(_ context.Context, url, _ string, payload any) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.calls = append(w.calls, WebhookCall{URL: url, Payload: payload})
+	return 1, nil
+}
+
+// Calls return the payloads sent
+func (w *Webhook) Calls() []WebhookCall {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]WebhookCall(nil), w.calls...)
+}
+
+// ReconConsumer has no acuan transaction to reconcile
+type ReconConsumer struct{}
+
+var _ kafkaRecon.Consumer = ReconConsumer{}
+
+func (ReconConsumer) Consume(time.Time, int64, func(transactions []goacuanlib.Transaction)) {}
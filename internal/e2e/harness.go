@@ -0,0 +1,411 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	dlqpublisher "bitbucket.org/Amartha/go-fp-transaction/internal/common/dlq_publisher"
+	kafkacommon "bitbucket.org/Amartha/go-fp-transaction/internal/common/kafka"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/readiness"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/account_mutation"
+	dlqretrier "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/dlq_retrier"
+	hvtbalanceupdate "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/hvt_balance_update"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/process_wallet_transaction"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/http/health"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/fsouza/fake-gcs-server/fakestorage"
+	"github.com/redis/go-redis/v9"
+)
+
+// names of the consumers the harness can run, they are the names used by the consumer command
+const (
+	ConsumerAccountMutation          = "account_mutation"
+	ConsumerDLQRetrier               = "dlq_retrier"
+	ConsumerHVTBalanceUpdate         = "hvt_balance_update"
+	ConsumerProcessWalletTransaction = "process_wallet_transaction"
+)
+
+// accounts and codes of the default config
+const (
+	SecretKey             = "e2e-secret-key"
+	SystemAccountNumber   = "10000000000001"
+	HVTSubCategoryCode    = "10099"
+	defaultWaitTimeout    = 10 * time.Second
+	defaultWaitInterval   = 20 * time.Millisecond
+	defaultHandlerTimeout = 16 * time.Second
+)
+
+var (
+	postgresOnce sync.Once
+	postgres     *Postgres
+	errPostgres  error
+
+	harnessSeq atomic.Int64
+)
+
+// Main run the tests of the package and stop the postgres started by the harness, it is called by TestMain
+func Main(m *testing.M) int {
+	code := m.Run()
+
+	if postgres != nil {
+		if err := postgres.Stop(); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to stop postgres: %v\n", err)
+		}
+	}
+
+	return code
+}
+
+// Options select what the harness runs
+type Options struct {
+	// Consumers is the consumers run next to the API, see the Consumer constants for the supported names
+	Consumers []string
+	// Config modify the default config before the harness is built
+	Config func(cfg *config.Config)
+}
+
+// Harness run the API and the chosen consumers in process against a fresh postgres database,
+// the in-memory kafka Broker, fake GCS, miniredis and fakes of the other external services
+type Harness struct {
+	*Stack
+
+	DB    *sql.DB
+	Redis *miniredis.Miniredis
+	GCS   *fakestorage.Server
+	API   *httptest.Server
+
+	consumers map[string]*kafkacommon.BaseConsumer
+}
+
+// New build and start the harness, everything is stopped on cleanup of t
+func New(t testing.TB, opts Options) *Harness {
+	t.Helper()
+
+	postgresOnce.Do(func() {
+		postgres, errPostgres = StartPostgres()
+	})
+	if errPostgres != nil {
+		t.Fatalf("failed to start postgres: %v", errPostgres)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	h := &Harness{
+		consumers: map[string]*kafkacommon.BaseConsumer{},
+	}
+
+	// the API is listening before the services are built, so queue unicorn jobs can call it back
+	var handler nethttp.Handler = nethttp.NotFoundHandler()
+	h.API = httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(h.API.Close)
+
+	h.Redis = miniredis.RunT(t)
+
+	gcs, err := fakestorage.NewServerWithOptions(fakestorage.Options{NoListener: true})
+	if err != nil {
+		t.Fatalf("failed to start fake gcs: %v", err)
+	}
+	t.Cleanup(gcs.Stop)
+	h.GCS = gcs
+
+	dbConf, err := postgres.CreateDatabase()
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := postgres.DropDatabase(dbConf.DbName); err != nil {
+			t.Logf("failed to drop database: %v", err)
+		}
+	})
+
+	cfg := DefaultConfig(dbConf, h.Redis.Host(), h.Redis.Port(), h.API.URL+"/api")
+	if opts.Config != nil {
+		opts.Config(&cfg)
+	}
+
+	h.DB, err = sql.Open("nrpgx", DSN(dbConf))
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	t.Cleanup(func() { _ = h.DB.Close() })
+
+	cache := redis.NewClient(&redis.Options{Addr: h.Redis.Addr()})
+	t.Cleanup(func() { _ = cache.Close() })
+
+	h.Stack, err = NewStack(cfg, h.DB, cache, gcs, h.API.Client())
+	if err != nil {
+		t.Fatalf("failed to build services: %v", err)
+	}
+
+	srv := h.Services
+	handler = http.NewHTTPServer(ctx, cfg, nil,
+		h.Cache,
+		srv.Transaction,
+		srv.Account,
+		srv.Balance,
+		srv.Entity,
+		srv.Category,
+		srv.SubCategory,
+		srv.File,
+		srv.MasterData,
+		services.NewReconBalanceService(srv),
+		srv.ReconException,
+		srv.DLQProcessor,
+		srv.WalletAccount,
+		srv.WalletTrx,
+		h.Metrics,
+		srv.MoneyFlowCalc,
+		srv.MoneyFlowBusinessRule,
+		srv.Tax,
+		srv.Export,
+		srv.LedgerReport,
+		srv.TransactionMetric,
+		srv.Approval,
+		health.NewHealthCheck(readiness.New(cfg.Readiness)),
+	).Handler()
+
+	for _, name := range opts.Consumers {
+		consumer, err := h.newConsumer(ctx, name)
+		if err != nil {
+			t.Fatalf("failed to create consumer %s: %v", name, err)
+		}
+
+		h.start(t, ctx, cancel, consumer)
+		h.consumers[name] = consumer
+	}
+
+	return h
+}
+
+// DefaultConfig return the config of the harness, topics and consumer groups are prefixed with e2e
+func DefaultConfig(dbConf config.Database, redisHost, redisPort, apiURL string) config.Config {
+	name := fmt.Sprintf("go_fp_transaction_e2e_%d", harnessSeq.Add(1))
+
+	return config.Config{
+		App: config.App{
+			Env:             "local",
+			Name:            name,
+			HTTPTimeout:     defaultHandlerTimeout,
+			GracefulTimeout: time.Second,
+		},
+		Postgres:            config.Postgres{Write: dbConf, Read: dbConf},
+		Redis:               config.Redis{Host: redisHost, Port: redisPort},
+		SecretKey:           SecretKey,
+		HostGoFPTransaction: apiURL,
+		FeatureFlag: config.FeatureFlag{
+			EnablePublishTransactionNotification: true,
+			EnablePublishHvtBalanceDLQ:           true,
+		},
+		AccountConfig: config.AccountConfig{
+			SystemAccountNumber:                 SystemAccountNumber,
+			HVTSubCategoryCodes:                 []string{HVTSubCategoryCode},
+			ExcludedBalanceUpdateAccountNumbers: []string{SystemAccountNumber},
+		},
+		AccountFeatureConfig: map[string]config.FeatureConfig{
+			models.DefaultPresetWalletFeature: {},
+		},
+		TransactionValidationConfig: config.TransactionValidationConfig{
+			AcceptedTransactionType: []string{"TUPVA", "COTWC"},
+		},
+		TransactionConfig: config.TransactionConfig{
+			HandlerTimeoutWalletTransaction: defaultHandlerTimeout,
+		},
+		MessageBroker: config.MessageBroker{
+			KafkaConsumer: config.ConsumerConfig{
+				Brokers:                               []string{"in-memory"},
+				IsOldest:                              true,
+				ConsumerGroupAccountMutation:          "e2e_account_mutation",
+				ConsumerGroupDLQRetrier:               "e2e_dlq_retrier",
+				ConsumerGroupBalanceHvt:               "e2e_balance_hvt",
+				ConsumerGroupProcessWalletTransaction: "e2e_process_wallet_transaction",
+				TopicDLQ:                              "e2e.transaction.dlq",
+				TopicAccountMutation:                  "e2e.account.mutation",
+				TopicAccountMutationDLQ:               "e2e.account.mutation.dlq",
+				TopicRecon:                            "e2e.recon",
+				TopicExport:                           "e2e.export",
+				TopicTransactionNotification:          "e2e.transaction.notification",
+				TopicBalanceLogs:                      "e2e.balance.logs",
+				TopicBalanceHVT:                       "e2e.balance.hvt",
+				TopicBalanceHvtDLQ:                    "e2e.balance.hvt.dlq",
+				TopicProcessWalletTransaction:         "e2e.process.wallet.transaction",
+				TopicProcessWalletTransactionDLQ:      "e2e.process.wallet.transaction.dlq",
+			},
+		},
+		ExponentialBackoff: config.ExponentialBackOffConfig{
+			MaxRetries:        1,
+			MaxBackoffTime:    time.Second,
+			BackoffMultiplier: 1,
+		},
+		CloudStorageConfig: config.CloudStorageConfig{
+			BaseURL:    "https://storage.googleapis.com",
+			BucketName: "e2e",
+		},
+		MasterData: config.MasterDataConfig{
+			Backend: repositories.MasterDataBackendPostgres,
+		},
+	}
+}
+
+// newConsumer wire the consumer like the constructor of its package, with the consumer group of the broker
+func (h *Harness) newConsumer(ctx context.Context, name string) (*kafkacommon.BaseConsumer, error) {
+	cfg := h.Config
+	mtc := h.Metrics
+	consumerCfg := cfg.MessageBroker.KafkaConsumer
+	producer := h.Broker.SyncProducer()
+
+	baseCfg := kafkacommon.BaseConsumerConfig{
+		Ctx:              ctx,
+		Config:           cfg,
+		Metrics:          mtc,
+		LogPrefix:        fmt.Sprintf("[E2E-CONSUMER] [%s] ", name),
+		NewConsumerGroup: h.Broker.NewConsumerGroup,
+	}
+
+	switch name {
+	case ConsumerAccountMutation:
+		handler := account_mutation.NewAccountMutationHandler(h.Services.Account, cfg)
+		baseCfg.Topics = []string{consumerCfg.TopicAccountMutation}
+		baseCfg.ConsumerGroup = consumerCfg.ConsumerGroupAccountMutation
+		baseCfg.Handler = handler.HandlerFunc()
+		baseCfg.DLQ = dlqpublisher.New(producer, consumerCfg.TopicAccountMutationDLQ, mtc)
+	case ConsumerDLQRetrier:
+		handler := dlqretrier.NewRetrierHandler(h.Services.DLQProcessor, consumerCfg)
+		baseCfg.Topics = []string{consumerCfg.TopicDLQ, consumerCfg.TopicAccountMutationDLQ}
+		baseCfg.ConsumerGroup = consumerCfg.ConsumerGroupDLQRetrier
+		baseCfg.Handler = handler.HandlerFunc()
+	case ConsumerHVTBalanceUpdate:
+		handler := hvtbalanceupdate.NewHvtBalanceHandler(h.Services.Balance, h.Cache)
+		baseCfg.Topics = []string{consumerCfg.TopicBalanceHVT}
+		baseCfg.ConsumerGroup = consumerCfg.ConsumerGroupBalanceHvt
+		baseCfg.Handler = handler.HandlerFunc()
+		baseCfg.Middlewares = []kafkacommon.Middleware{handler.Idempotency()}
+		baseCfg.DLQ = dlqpublisher.New(producer, consumerCfg.TopicBalanceHvtDLQ, mtc)
+	case ConsumerProcessWalletTransaction:
+		handler := process_wallet_transaction.NewHandler(h.Cache, h.Services.WalletTrx)
+		baseCfg.Topics = []string{consumerCfg.TopicProcessWalletTransaction}
+		baseCfg.ConsumerGroup = consumerCfg.ConsumerGroupProcessWalletTransaction
+		baseCfg.Handler = handler.HandlerFunc()
+		baseCfg.Middlewares = []kafkacommon.Middleware{handler.Idempotency()}
+		baseCfg.DLQ = dlqpublisher.New(producer, consumerCfg.TopicProcessWalletTransactionDLQ, mtc)
+	default:
+		return nil, fmt.Errorf("consumer %s is not supported by the harness", name)
+	}
+
+	return kafkacommon.NewBaseConsumer(baseCfg)
+}
+
+// start run the consumer until cleanup of t, ctx is canceled before the consumer is stopped so it does not rejoin
+func (h *Harness) start(t testing.TB, ctx context.Context, cancel context.CancelFunc, consumer *kafkacommon.BaseConsumer) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = consumer.Start()()
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		if err := consumer.Stop()(context.Background()); err != nil {
+			t.Logf("failed to stop consumer %s: %v", consumer.ConsumerGroup(), err)
+		}
+		<-done
+	})
+}
+
+// Consumer return the consumer started by the harness, it is nil when the consumer is not chosen in Options
+func (h *Harness) Consumer(name string) *kafkacommon.BaseConsumer {
+	return h.consumers[name]
+}
+
+// WaitConsumed wait until the consumer has committed every message of its topics
+func (h *Harness) WaitConsumed(t testing.TB, name string) {
+	t.Helper()
+
+	consumer, ok := h.consumers[name]
+	if !ok {
+		t.Fatalf("consumer %s is not run by the harness", name)
+	}
+
+	h.Eventually(t, func() bool {
+		lags, err := h.Broker.GroupLag(consumer.ConsumerGroup(), consumer.Topics())
+		if err != nil {
+			return false
+		}
+
+		return !slices.ContainsFunc(lags, func(lag kafkacommon.PartitionLag) bool { return lag.Lag > 0 })
+	}, "consumer %s did not consume every message", name)
+}
+
+// Eventually wait until condition is true, the test fails when it is still false after the default timeout
+func (h *Harness) Eventually(t testing.TB, condition func() bool, format string, args ...any) {
+	t.Helper()
+
+	deadline := time.Now().Add(defaultWaitTimeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf(format, args...)
+		}
+		time.Sleep(defaultWaitInterval)
+	}
+}
+
+// Do send a request authenticated with the secret key to the API, body is encoded and the response is decoded to out as json.
+// It returns the status code of the response
+func (h *Harness) Do(t testing.TB, method, path string, body, out any) int {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("failed to marshal request body: %v", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := nethttp.NewRequest(method, h.API.URL+path, reader)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(models.SecretKeyHeader, h.Config.SecretKey)
+
+	res, err := h.API.Client().Do(req)
+	if err != nil {
+		t.Fatalf("failed to send request %s %s: %v", method, path, err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatalf("failed to read response: %v", err)
+	}
+
+	if out != nil && len(resBody) > 0 {
+		if err := json.Unmarshal(resBody, out); err != nil {
+			t.Fatalf("failed to unmarshal response %s: %v", resBody, err)
+		}
+	}
+
+	return res.StatusCode
+}
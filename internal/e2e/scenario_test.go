@@ -0,0 +1,176 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"encoding/json"
+	"fmt"
+	nethttp "net/http"
+	"os"
+	"testing"
+	"time"
+
+	dlqpublisher "bitbucket.org/Amartha/go-fp-transaction/internal/common/dlq_publisher"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	goacuanlib "bitbucket.org/Amartha/go-acuan-lib/model"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(Main(m))
+}
+
+func createAccount(t *testing.T, h *Harness, accountNumber, subCategoryCode string) {
+	t.Helper()
+
+	status := h.Do(t, nethttp.MethodPost, "/api/v1/accounts", models.DoCreateAccountRequest{
+		AccountNumber:   accountNumber,
+		Name:            "E2E " + accountNumber,
+		OwnerID:         "e2e" + accountNumber[len(accountNumber)-4:],
+		CategoryCode:    "211",
+		SubCategoryCode: subCategoryCode,
+		EntityCode:      "001",
+		Currency:        models.IDRCurrency,
+		Status:          "active",
+	}, nil)
+	require.Equal(t, nethttp.StatusCreated, status, "create account %s", accountNumber)
+}
+
+func createWalletTransaction(t *testing.T, h *Harness, req models.CreateWalletTransactionRequest) models.WalletTransactionResponse {
+	t.Helper()
+
+	var res models.WalletTransactionResponse
+	status := h.Do(t, nethttp.MethodPost, "/api/v1/wallet-transactions", req, &res)
+	require.Equal(t, nethttp.StatusCreated, status, "create wallet transaction %s", req.RefNumber)
+
+	return res
+}
+
+func getAccount(t *testing.T, h *Harness, accountNumber string) models.GetAccountResponse {
+	t.Helper()
+
+	var res models.GetAccountResponse
+	status := h.Do(t, nethttp.MethodGet, "/api/v1/accounts/"+accountNumber, nil, &res)
+	require.Equal(t, nethttp.StatusOK, status, "get account %s", accountNumber)
+
+	return res
+}
+
+func walletTransaction(t *testing.T, accountNumber, refNumber, transactionType string, flow models.TransactionFlow, amount string, reserved bool) models.CreateWalletTransactionRequest {
+	t.Helper()
+
+	value, err := models.NewDecimal(amount)
+	require.NoError(t, err)
+	netAmount := models.Amount{ValueDecimal: value, Currency: models.IDRCurrency}
+
+	return models.CreateWalletTransactionRequest{
+		IsReserved:      reserved,
+		AccountNumber:   accountNumber,
+		RefNumber:       refNumber,
+		TransactionType: transactionType,
+		TransactionFlow: flow,
+		TransactionTime: time.Now().Format(time.RFC3339),
+		NetAmount:       netAmount,
+		Amounts:         []models.AmountDetail{{Type: transactionType, Amount: &netAmount}},
+	}
+}
+
+func Test_CashIn(t *testing.T) {
+	h := New(t, Options{})
+	createAccount(t, h, SystemAccountNumber, "10000")
+	createAccount(t, h, "21100100000001", "10000")
+
+	created := createWalletTransaction(t, h, walletTransaction(t, "21100100000001", "e2e-cashin-1", "TUPVA", models.TransactionFlowCashIn, "10000", false))
+	assert.Equal(t, models.WalletTransactionStatusSuccess, created.Status)
+
+	account := getAccount(t, h, "21100100000001")
+	assert.Equal(t, "10000", account.ActualBalance)
+	assert.Equal(t, "10000", account.AvailableBalance)
+
+	assert.Len(t, h.Broker.Messages(h.Config.MessageBroker.KafkaConsumer.TopicTransactionNotification), 1,
+		"success wallet transaction is notified")
+}
+
+func Test_ReserveCommit(t *testing.T) {
+	h := New(t, Options{})
+	createAccount(t, h, SystemAccountNumber, "10000")
+	createAccount(t, h, "21100100000002", "10000")
+	createWalletTransaction(t, h, walletTransaction(t, "21100100000002", "e2e-topup-2", "TUPVA", models.TransactionFlowCashIn, "10000", false))
+
+	reserved := createWalletTransaction(t, h, walletTransaction(t, "21100100000002", "e2e-reserve-2", "COTWC", models.TransactionFlowCashOut, "4000", true))
+	assert.Equal(t, models.WalletTransactionStatusPending, reserved.Status)
+
+	account := getAccount(t, h, "21100100000002")
+	assert.Equal(t, "10000", account.ActualBalance, "reserved amount is not deducted yet")
+	assert.Equal(t, "6000", account.AvailableBalance)
+	assert.Equal(t, "4000", account.PendingBalance)
+
+	status := h.Do(t, nethttp.MethodPatch, "/api/v1/wallet-transactions/"+reserved.ID, models.UpdateStatusWalletTransactionRequest{
+		Action: "commit",
+	}, nil)
+	require.Equal(t, nethttp.StatusOK, status)
+
+	account = getAccount(t, h, "21100100000002")
+	assert.Equal(t, "6000", account.ActualBalance)
+	assert.Equal(t, "6000", account.AvailableBalance)
+	assert.Equal(t, "0", account.PendingBalance)
+}
+
+func Test_HVTBalanceUpdate(t *testing.T) {
+	h := New(t, Options{Consumers: []string{ConsumerHVTBalanceUpdate}})
+	createAccount(t, h, SystemAccountNumber, "10000")
+	createAccount(t, h, "21100100000003", HVTSubCategoryCode)
+
+	createWalletTransaction(t, h, walletTransaction(t, "21100100000003", "e2e-hvt-3", "TUPVA", models.TransactionFlowCashIn, "25000", false))
+	require.Len(t, h.Broker.Messages(h.Config.MessageBroker.KafkaConsumer.TopicBalanceHVT), 1,
+		"balance of hvt account is updated by the consumer")
+
+	h.WaitConsumed(t, ConsumerHVTBalanceUpdate)
+
+	account := getAccount(t, h, "21100100000003")
+	assert.Equal(t, "25000", account.ActualBalance)
+	assert.Equal(t, "25000", account.AvailableBalance)
+	assert.Empty(t, h.Broker.Messages(h.Config.MessageBroker.KafkaConsumer.TopicBalanceHvtDLQ))
+}
+
+func Test_DLQReplay(t *testing.T) {
+	h := New(t, Options{Consumers: []string{ConsumerDLQRetrier}})
+
+	payload, err := json.Marshal(goacuanlib.Payload[goacuanlib.DataAccount]{
+		Body: goacuanlib.Body[goacuanlib.DataAccount]{
+			Data: goacuanlib.DataAccount{
+				Account: goacuanlib.Account{
+					AccountNumber:   "21100100000004",
+					Name:            "E2E DLQ",
+					OwnerId:         "e2e0004",
+					CategoryCode:    "211",
+					SubCategoryCode: "10000",
+					EntityCode:      "001",
+					Currency:        models.IDRCurrency,
+					Status:          "active",
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	dlq := dlqpublisher.New(h.Broker.SyncProducer(), h.Config.MessageBroker.KafkaConsumer.TopicAccountMutationDLQ, nil)
+	require.NoError(t, dlq.Publish(models.FailedMessage{
+		Payload:   payload,
+		Timestamp: time.Now(),
+		Error:     fmt.Sprintf("failed to create account: %v", errNotAvailable),
+	}))
+
+	h.WaitConsumed(t, ConsumerDLQRetrier)
+
+	jobs := h.Queue.Jobs()
+	require.Len(t, jobs, 1, "failed account mutation is retried through queue unicorn")
+	require.NoError(t, jobs[0].Err)
+	assert.Equal(t, nethttp.StatusCreated, jobs[0].StatusCode, "response: %s", jobs[0].Response)
+
+	account := getAccount(t, h, "21100100000004")
+	assert.Equal(t, "E2E DLQ", account.AccountName)
+}
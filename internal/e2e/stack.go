@@ -0,0 +1,94 @@
+package e2e
+
+import (
+	"database/sql"
+	"fmt"
+	nethttp "net/http"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/idgenerator"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/metrics"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/payment"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/publisher"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/transaction_notification"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
+
+	"github.com/fsouza/fake-gcs-server/fakestorage"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/api/option"
+)
+
+// Stack is the services wired with the in-memory Broker and the fakes of the external services,
+// it is shared by the Harness and the benchmark command
+type Stack struct {
+	Config   config.Config
+	Broker   *Broker
+	Services *services.Services
+	Cache    repositories.CacheRepository
+	Metrics  metrics.Metrics
+
+	Flag         *Flag
+	Acuan        *Acuan
+	Queue        *QueueUnicorn
+	Notification *Notification
+	Webhook      *Webhook
+}
+
+// NewStack build the services on db, cache and gcs, the bucket of cfg is created on gcs.
+// Jobs sent to queue unicorn are run with queueClient
+func NewStack(cfg config.Config, db *sql.DB, cache *redis.Client, gcs *fakestorage.Server, queueClient *nethttp.Client) (*Stack, error) {
+	s := &Stack{
+		Config:       cfg,
+		Broker:       NewBroker(0),
+		Cache:        repositories.NewCacheRepository(cache),
+		Metrics:      metrics.NewWithRegisterer(prometheus.NewRegistry()),
+		Flag:         NewFlag(),
+		Acuan:        &Acuan{},
+		Queue:        NewQueueUnicorn(queueClient),
+		Notification: &Notification{},
+		Webhook:      &Webhook{},
+	}
+
+	gcs.CreateBucketWithOpts(fakestorage.CreateBucketOpts{Name: cfg.CloudStorageConfig.BucketName})
+
+	cloudStorageRepo, err := repositories.NewCloudStorageRepository(&cfg,
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(gcs.HTTPClient()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cloud storage repository: %w", err)
+	}
+
+	sqlRepo := repositories.NewSQLRepository(db, db, cfg, s.Flag, Accounting{})
+	masterDataRepo := repositories.NewSQLMasterDataRepository(sqlRepo, nil)
+
+	producer := s.Broker.SyncProducer()
+	consumerCfg := cfg.MessageBroker.KafkaConsumer
+
+	s.Services = services.New(
+		cfg,
+		sqlRepo,
+		s.Cache,
+		cloudStorageRepo,
+		ReconConsumer{},
+		s.Acuan,
+		idgenerator.New(),
+		repositories.NewFileRepository(),
+		masterDataRepo,
+		s.Notification,
+		s.Queue,
+		publisher.NewPublisher(producer, consumerCfg.TopicRecon),
+		publisher.NewPublisher(producer, consumerCfg.TopicBalanceHVT),
+		transaction_notification.NewTransactionNotificationPublisher(cfg, producer, producer, s.Metrics),
+		publisher.NewPublisher(producer, consumerCfg.TopicProcessWalletTransaction),
+		publisher.NewPublisher(producer, consumerCfg.TopicExport),
+		Accounting{},
+		payment.NewFake(),
+		s.Webhook,
+		s.Flag,
+		s.Metrics,
+	)
+
+	return s, nil
+}
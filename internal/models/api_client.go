@@ -0,0 +1,73 @@
+package models
+
+import "context"
+
+const (
+	SecretKeyHeader = "X-Secret-Key"
+
+	// ScopeAll grants every scope, it is also required by routes which are not registered in the scope table
+	ScopeAll = "*"
+
+	ScopeWalletRead        = "wallet:read"
+	ScopeWalletWrite       = "wallet:write"
+	ScopeTransactionsRead  = "transactions:read"
+	ScopeTransactionsWrite = "transactions:write"
+	ScopeAccountsRead      = "accounts:read"
+	ScopeAccountsAdmin     = "accounts:admin"
+	ScopeReportsRead       = "reports:read"
+	ScopeMoneyFlowRead     = "moneyflow:read"
+	ScopeMoneyFlowWrite    = "moneyflow:write"
+	ScopeMoneyFlowApprove  = "moneyflow:approve"
+	ScopeMasterDataRead    = "masterdata:read"
+	ScopeMasterDataAdmin   = "masterdata:admin"
+	ScopeReconRead         = "recon:read"
+	ScopeReconWrite        = "recon:write"
+	ScopeFilesWrite        = "files:write"
+	ScopeApprovalsRead     = "approvals:read"
+	ScopeApprovalsWrite    = "approvals:write"
+	ScopeApprovalsApprove  = "approvals:approve"
+	ScopeConsumersRead     = "consumers:read"
+	ScopeConsumersAdmin    = "consumers:admin"
+)
+
+type (
+	clientIDKey struct{}
+	userKey     struct{}
+)
+
+// WithClientID set the authenticated client of the request, it is used for logging and notifications
+func WithClientID(ctx context.Context, clientID string) context.Context {
+	if clientID == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, clientIDKey{}, clientID)
+}
+
+// GetClientID get the authenticated client of the request, empty string is returned if not set
+func GetClientID(ctx context.Context) string {
+	if clientID, ok := ctx.Value(clientIDKey{}).(string); ok {
+		return clientID
+	}
+
+	return ""
+}
+
+// WithUser set the user of the request, it is only set when the authenticated client is trusted
+// to assert its users, so the user can be used as identity e.g. in four-eyes approval
+func WithUser(ctx context.Context, user string) context.Context {
+	if user == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// GetUser get the verified user of the request, empty string is returned if not set
+func GetUser(ctx context.Context) string {
+	if user, ok := ctx.Value(userKey{}).(string); ok {
+		return user
+	}
+
+	return ""
+}
@@ -0,0 +1,303 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	kindApproval         = "approval"
+	kindApprovalActivity = "approvalActivity"
+)
+
+// ApprovalOperation is the sensitive operation which can only be executed after approved by another user
+type ApprovalOperation string
+
+const (
+	ApprovalOperationUploadWalletTransaction ApprovalOperation = "UPLOAD_WALLET_TRANSACTION"
+	ApprovalOperationPublishTransaction      ApprovalOperation = "PUBLISH_TRANSACTION"
+	ApprovalOperationDeleteAccount           ApprovalOperation = "DELETE_ACCOUNT"
+	ApprovalOperationUpdateMoneyFlowSummary  ApprovalOperation = "UPDATE_MONEY_FLOW_SUMMARY"
+	ApprovalOperationUpsertVATConfig         ApprovalOperation = "UPSERT_VAT_CONFIG"
+)
+
+// ApprovalState is the lifecycle state of approval, only pending approval can be decided.
+// Approved approval is claimed as executing before the operation is run, then it is executed or failed
+type ApprovalState string
+
+const (
+	ApprovalStatePending  ApprovalState = "PENDING"
+	ApprovalStateApproved ApprovalState = "APPROVED"
+	ApprovalStateRejected ApprovalState = "REJECTED"
+	ApprovalStateExpired  ApprovalState = "EXPIRED"
+
+	// ApprovalStateExecuting is approved and the operation is being executed, approval which stays executing
+	// is not retried because the operation may have been done, it has to be checked manually
+	ApprovalStateExecuting ApprovalState = "EXECUTING"
+	ApprovalStateExecuted  ApprovalState = "EXECUTED"
+
+	// ApprovalStateFailed is approved but the operation is failed when executed
+	ApprovalStateFailed ApprovalState = "FAILED"
+)
+
+// ApprovalAction is the action recorded in approval activity as audit trail
+type ApprovalAction string
+
+const (
+	ApprovalActionSubmit  ApprovalAction = "SUBMIT"
+	ApprovalActionApprove ApprovalAction = "APPROVE"
+	ApprovalActionReject  ApprovalAction = "REJECT"
+	ApprovalActionComment ApprovalAction = "COMMENT"
+	ApprovalActionExpire  ApprovalAction = "EXPIRE"
+	ApprovalActionExecute ApprovalAction = "EXECUTE"
+)
+
+// ApprovalSystemActor is recorded in the activity when the action is not made by a user e.g. expiry
+const ApprovalSystemActor = "system"
+
+// Approval is a pending change of sensitive operation, the payload is the input of the operation
+// and it is executed only when another user approve it
+type Approval struct {
+	ID              uint64
+	Operation       ApprovalOperation
+	Payload         json.RawMessage
+	Description     string
+	State           ApprovalState
+	Maker           string
+	MakerClientID   string
+	Checker         string
+	CheckerClientID string
+	ExecutionError  string
+	ExpiresAt       time.Time
+	DecidedAt       *time.Time
+	CreatedAt       *time.Time
+	UpdatedAt       *time.Time
+
+	Activities []ApprovalActivity
+}
+
+// IsExpired return true if pending approval can not be decided anymore
+func (a Approval) IsExpired(now time.Time) bool {
+	return a.State == ApprovalStatePending && !now.Before(a.ExpiresAt)
+}
+
+func (a Approval) GetCursor() string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(a.ID)))
+}
+
+func (a Approval) ToModelResponse() DoGetApprovalResponse {
+	res := DoGetApprovalResponse{
+		Kind:            kindApproval,
+		ID:              fmt.Sprint(a.ID),
+		Operation:       string(a.Operation),
+		Payload:         a.Payload,
+		Description:     a.Description,
+		State:           string(a.State),
+		Maker:           a.Maker,
+		MakerClientID:   a.MakerClientID,
+		Checker:         a.Checker,
+		CheckerClientID: a.CheckerClientID,
+		ExecutionError:  a.ExecutionError,
+		ExpiresAt:       formatReconExceptionTime(&a.ExpiresAt),
+		DecidedAt:       formatReconExceptionTime(a.DecidedAt),
+		CreatedAt:       formatReconExceptionTime(a.CreatedAt),
+		UpdatedAt:       formatReconExceptionTime(a.UpdatedAt),
+	}
+
+	// file content is not returned in the response, it can be large
+	if a.Operation == ApprovalOperationUploadWalletTransaction {
+		var payload ApprovalUploadWalletTransactionPayload
+		if err := json.Unmarshal(a.Payload, &payload); err == nil {
+			payload.Content = nil
+			res.Payload, _ = json.Marshal(payload)
+		}
+	}
+
+	for _, activity := range a.Activities {
+		res.Activities = append(res.Activities, activity.ToModelResponse())
+	}
+
+	return res
+}
+
+type ApprovalActivity struct {
+	ID         uint64
+	ApprovalID uint64
+	Action     ApprovalAction
+	Actor      string
+	ClientID   string
+	FromState  ApprovalState
+	ToState    ApprovalState
+	Comment    string
+	CreatedAt  *time.Time
+}
+
+func (a ApprovalActivity) ToModelResponse() DoGetApprovalActivityResponse {
+	return DoGetApprovalActivityResponse{
+		Kind:      kindApprovalActivity,
+		Action:    string(a.Action),
+		Actor:     a.Actor,
+		ClientID:  a.ClientID,
+		FromState: string(a.FromState),
+		ToState:   string(a.ToState),
+		Comment:   a.Comment,
+		CreatedAt: formatReconExceptionTime(a.CreatedAt),
+	}
+}
+
+// ApprovalUploadWalletTransactionPayload is the uploaded csv file, it is kept until the upload is approved
+type ApprovalUploadWalletTransactionPayload struct {
+	FileName string `json:"fileName"`
+	Content  []byte `json:"content,omitempty"`
+	ReportTo string `json:"reportTo"`
+	ClientID string `json:"clientId"`
+}
+
+type ApprovalDeleteAccountPayload struct {
+	AccountNumber string `json:"accountNumber"`
+}
+
+type ApprovalUpdateMoneyFlowSummaryPayload struct {
+	SummaryID string                        `json:"summaryId"`
+	Request   UpdateMoneyFlowSummaryRequest `json:"request"`
+}
+
+// SubmitApprovalRequest is built by the handler of protected operation instead of executing the operation
+type SubmitApprovalRequest struct {
+	Operation   ApprovalOperation
+	Payload     any
+	Description string
+	Maker       string
+	ClientID    string
+}
+
+type ApprovalFilterOptions struct {
+	Operation ApprovalOperation
+	State     ApprovalState
+	Maker     string
+
+	// Pagination filter
+	Limit          int
+	AscendingOrder bool
+	AfterID        uint64
+	BeforeID       uint64
+}
+
+type DoGetListApprovalRequest struct {
+	Operation  string `query:"operation" example:"DELETE_ACCOUNT"`
+	State      string `query:"state" example:"PENDING"`
+	Maker      string `query:"maker" example:"finance.ops"`
+	Limit      int    `query:"limit" example:"10"`
+	NextCursor string `query:"nextCursor" example:"abc"`
+	PrevCursor string `query:"prevCursor" example:"cba"`
+}
+
+func (req DoGetListApprovalRequest) ToFilterOpts() (*ApprovalFilterOptions, error) {
+	opts := &ApprovalFilterOptions{
+		Operation: ApprovalOperation(req.Operation),
+		State:     ApprovalState(req.State),
+		Maker:     req.Maker,
+		Limit:     req.Limit,
+	}
+
+	if req.Limit < 0 {
+		return nil, GetErrMap(ErrKeyLimitMustBeGreaterThanZero)
+	}
+
+	if req.Limit == 0 {
+		// default limit
+		opts.Limit = 10
+	}
+
+	// use over-fetch limit for check next page exists or not
+	opts.Limit += 1
+
+	// forward pagination
+	if req.NextCursor != "" {
+		afterID, err := decodeApprovalCursor(req.NextCursor)
+		if err != nil {
+			return nil, err
+		}
+		opts.AfterID = afterID
+	}
+
+	// backward pagination
+	if req.NextCursor == "" && req.PrevCursor != "" {
+		beforeID, err := decodeApprovalCursor(req.PrevCursor)
+		if err != nil {
+			return nil, err
+		}
+		opts.BeforeID = beforeID
+
+		// reverse order
+		opts.AscendingOrder = true
+	}
+
+	return opts, nil
+}
+
+func decodeApprovalCursor(cursor string) (uint64, error) {
+	decodedBytes, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse offset string: %w", err)
+	}
+
+	id, err := strconv.ParseUint(string(decodedBytes), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse offset id: %w", err)
+	}
+
+	return id, nil
+}
+
+// DecideApprovalRequest is used to approve or reject approval, actor and client are taken from request header
+type DecideApprovalRequest struct {
+	ID      uint64 `param:"id" json:"-"`
+	Comment string `json:"comment" example:"checked with finance lead"`
+
+	// internal use
+	Actor    string `json:"-"`
+	ClientID string `json:"-"`
+}
+
+type CommentApprovalRequest struct {
+	ID      uint64 `param:"id" json:"-"`
+	Comment string `json:"comment" validate:"required" example:"please attach the supporting document"`
+
+	// internal use
+	Actor    string `json:"-"`
+	ClientID string `json:"-"`
+}
+
+type DoGetApprovalResponse struct {
+	Kind            string                          `json:"kind" example:"approval"`
+	ID              string                          `json:"id" example:"1"`
+	Operation       string                          `json:"operation" example:"DELETE_ACCOUNT"`
+	Payload         json.RawMessage                 `json:"payload" swaggertype:"object"`
+	Description     string                          `json:"description" example:"delete account 21100100000001"`
+	State           string                          `json:"state" example:"PENDING"`
+	Maker           string                          `json:"maker" example:"finance.ops"`
+	MakerClientID   string                          `json:"makerClientId" example:"ngmis"`
+	Checker         string                          `json:"checker" example:"finance.lead"`
+	CheckerClientID string                          `json:"checkerClientId" example:"ngmis"`
+	ExecutionError  string                          `json:"executionError" example:""`
+	ExpiresAt       string                          `json:"expiresAt" example:"2006-01-02 15:04:05"`
+	DecidedAt       string                          `json:"decidedAt" example:"2006-01-02 15:04:05"`
+	CreatedAt       string                          `json:"createdAt" example:"2006-01-02 15:04:05"`
+	UpdatedAt       string                          `json:"updatedAt" example:"2006-01-02 15:04:05"`
+	Activities      []DoGetApprovalActivityResponse `json:"activities,omitempty"`
+}
+
+type DoGetApprovalActivityResponse struct {
+	Kind      string `json:"kind" example:"approvalActivity"`
+	Action    string `json:"action" example:"APPROVE"`
+	Actor     string `json:"actor" example:"finance.lead"`
+	ClientID  string `json:"clientId" example:"ngmis"`
+	FromState string `json:"fromState" example:"PENDING"`
+	ToState   string `json:"toState" example:"APPROVED"`
+	Comment   string `json:"comment" example:"checked with finance lead"`
+	CreatedAt string `json:"createdAt" example:"2006-01-02 15:04:05"`
+}
@@ -33,6 +33,9 @@ type UpdateBalanceHVTPayload struct {
 	RefNumber           string `json:"refNumber"`
 	AccountNumber       string `json:"accountNumber"`
 	UpdateAmount        Amount `json:"updateAmount"`
+	// CapturedAmount is the captured amount of the reservation after the capture which made the update,
+	// it tells the partial captures of one reservation apart. It is empty for other transactions
+	CapturedAmount string `json:"capturedAmount,omitempty"`
 }
 
 func CreateUpdateBalanceHVTPayload(acuanTransaction Transaction) (*UpdateBalanceHVTPayload, error) {
@@ -120,6 +123,39 @@ func (b *Balance) Commit(amount decimal.Decimal, _ ...CalculateBalanceOption) er
 	return nil
 }
 
+// Capture finalizes part of a reservation and releases another part of it in one step.
+// captureAmount reduces both the actual balance and pending balance, releaseAmount only reduces the pending balance.
+// One of them can be zero, e.g. a non-final capture releases nothing
+func (b *Balance) Capture(captureAmount, releaseAmount decimal.Decimal, _ ...CalculateBalanceOption) error {
+	if captureAmount.IsNegative() || releaseAmount.IsNegative() {
+		return common.ErrInvalidAmount
+	}
+
+	total := captureAmount.Add(releaseAmount)
+	if total.IsZero() {
+		return common.ErrInvalidAmount
+	}
+
+	if !b.ignoreBalanceSufficiency && b.Pending().LessThan(total) {
+		return common.ErrInsufficientPendingBalance
+	}
+
+	b.actualBalance = b.actualBalance.Sub(captureAmount)
+	b.pendingBalance = b.pendingBalance.Sub(total)
+
+	return nil
+}
+
+// IncreaseReservation tops up an existing reservation.
+// The same sufficiency rules as Reserve are applied to the additional amount
+func (b *Balance) IncreaseReservation(amount decimal.Decimal, opt ...CalculateBalanceOption) error {
+	if !b.ignoreBalanceSufficiency && b.Pending().LessThanOrEqual(decimal.Zero) {
+		return common.ErrTransactionNotReserved
+	}
+
+	return b.Reserve(amount, opt...)
+}
+
 // AddFunds increases the actual balance
 func (b *Balance) AddFunds(amount decimal.Decimal, _ ...CalculateBalanceOption) error {
 	if amount.LessThanOrEqual(decimal.Zero) {
@@ -2,8 +2,11 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+
 	"github.com/google/go-cmp/cmp"
 	"github.com/shopspring/decimal"
 )
@@ -634,6 +637,180 @@ func TestBalance_Commit(t *testing.T) {
 	}
 }
 
+func TestBalance_Capture(t *testing.T) {
+	type fields struct {
+		actualBalance    decimal.Decimal
+		pendingBalance   decimal.Decimal
+		ignoreValidation bool
+	}
+	type args struct {
+		captureAmount decimal.Decimal
+		releaseAmount decimal.Decimal
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    Balance
+		wantErr bool
+	}{
+		{
+			name: "success capture part of reservation",
+			fields: fields{
+				actualBalance:  decimal.NewFromFloat(500),
+				pendingBalance: decimal.NewFromFloat(100),
+			},
+			args: args{
+				captureAmount: decimal.NewFromFloat(40),
+				releaseAmount: decimal.Zero,
+			},
+			want:    NewBalance(decimal.NewFromFloat(460), decimal.NewFromFloat(60)),
+			wantErr: false,
+		},
+		{
+			name: "success capture and release remaining reservation",
+			fields: fields{
+				actualBalance:  decimal.NewFromFloat(500),
+				pendingBalance: decimal.NewFromFloat(100),
+			},
+			args: args{
+				captureAmount: decimal.NewFromFloat(40),
+				releaseAmount: decimal.NewFromFloat(60),
+			},
+			want:    NewBalance(decimal.NewFromFloat(460), decimal.NewFromFloat(0)),
+			wantErr: false,
+		},
+		{
+			name: "success release only",
+			fields: fields{
+				actualBalance:  decimal.NewFromFloat(500),
+				pendingBalance: decimal.NewFromFloat(100),
+			},
+			args: args{
+				captureAmount: decimal.Zero,
+				releaseAmount: decimal.NewFromFloat(100),
+			},
+			want:    NewBalance(decimal.NewFromFloat(500), decimal.NewFromFloat(0)),
+			wantErr: false,
+		},
+		{
+			name: "error capture - both amounts are zero",
+			fields: fields{
+				actualBalance:  decimal.NewFromFloat(500),
+				pendingBalance: decimal.NewFromFloat(100),
+			},
+			args: args{
+				captureAmount: decimal.Zero,
+				releaseAmount: decimal.Zero,
+			},
+			want:    NewBalance(decimal.NewFromFloat(500), decimal.NewFromFloat(100)),
+			wantErr: true,
+		},
+		{
+			name: "error capture - negative amount",
+			fields: fields{
+				actualBalance:  decimal.NewFromFloat(500),
+				pendingBalance: decimal.NewFromFloat(100),
+			},
+			args: args{
+				captureAmount: decimal.NewFromFloat(-10),
+				releaseAmount: decimal.NewFromFloat(20),
+			},
+			want:    NewBalance(decimal.NewFromFloat(500), decimal.NewFromFloat(100)),
+			wantErr: true,
+		},
+		{
+			name: "error capture - more than pending balance",
+			fields: fields{
+				actualBalance:  decimal.NewFromFloat(500),
+				pendingBalance: decimal.NewFromFloat(100),
+			},
+			args: args{
+				captureAmount: decimal.NewFromFloat(80),
+				releaseAmount: decimal.NewFromFloat(30),
+			},
+			want:    NewBalance(decimal.NewFromFloat(500), decimal.NewFromFloat(100)),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Balance{
+				actualBalance:            tt.fields.actualBalance,
+				pendingBalance:           tt.fields.pendingBalance,
+				ignoreBalanceSufficiency: tt.fields.ignoreValidation,
+			}
+			if err := b.Capture(tt.args.captureAmount, tt.args.releaseAmount); (err != nil) != tt.wantErr {
+				t.Errorf("Capture() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !cmp.Equal(&tt.want, b, balanceComparer()) {
+				t.Errorf("Result and Expected differ: (-got +want)\n%s", cmp.Diff(&tt.want, b, balanceComparer()))
+			}
+		})
+	}
+}
+
+func TestBalance_IncreaseReservation(t *testing.T) {
+	type fields struct {
+		actualBalance  decimal.Decimal
+		pendingBalance decimal.Decimal
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		amount  decimal.Decimal
+		want    Balance
+		wantErr error
+	}{
+		{
+			name: "success increase existing reservation",
+			fields: fields{
+				actualBalance:  decimal.NewFromFloat(500),
+				pendingBalance: decimal.NewFromFloat(100),
+			},
+			amount: decimal.NewFromFloat(50),
+			want:   NewBalance(decimal.NewFromFloat(500), decimal.NewFromFloat(150)),
+		},
+		{
+			name: "error increase - no existing reservation",
+			fields: fields{
+				actualBalance:  decimal.NewFromFloat(500),
+				pendingBalance: decimal.Zero,
+			},
+			amount:  decimal.NewFromFloat(50),
+			want:    NewBalance(decimal.NewFromFloat(500), decimal.Zero),
+			wantErr: common.ErrTransactionNotReserved,
+		},
+		{
+			name: "error increase - insufficient available balance",
+			fields: fields{
+				actualBalance:  decimal.NewFromFloat(120),
+				pendingBalance: decimal.NewFromFloat(100),
+			},
+			amount:  decimal.NewFromFloat(50),
+			want:    NewBalance(decimal.NewFromFloat(120), decimal.NewFromFloat(100)),
+			wantErr: common.ErrInsufficientAvailableBalance,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Balance{
+				actualBalance:  tt.fields.actualBalance,
+				pendingBalance: tt.fields.pendingBalance,
+			}
+			err := b.IncreaseReservation(tt.amount)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("IncreaseReservation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !cmp.Equal(&tt.want, b, balanceComparer()) {
+				t.Errorf("Result and Expected differ: (-got +want)\n%s", cmp.Diff(&tt.want, b, balanceComparer()))
+			}
+		})
+	}
+}
+
 func TestNewBalance(t *testing.T) {
 	type args struct {
 		actualBalance  decimal.Decimal
@@ -103,6 +103,17 @@ const (
 	ErrKeyFailedFromExternalClient                        = "failedFromExternalClient"
 	ErrKeyUpdateStatusWalletTransactionRequestActionOneof = "UpdateStatusWalletTransactionRequest.action_oneof"
 	ErrKeySummaryIdnotFound                               = "summaryIDNotFound"
+	ErrKeyActorRequired                                   = "actor_required"
+	ErrKeyAssigneeRequired                                = "assignee_required"
+	ErrKeyCommentRequired                                 = "comment_required"
+	ErrKeyNoteRequired                                    = "note_required"
+	ErrKeyStateRequired                                   = "state_required"
+	ErrKeyStateOneof                                      = "state_oneof"
+	ErrKeyInvalidMetadataFilter                           = "invalidMetadataFilter"
+	ErrKeyTooManyMetadataFilters                          = "tooManyMetadataFilters"
+	ErrKeyInvalidAmountFilter                             = "invalidAmountFilter"
+	ErrKeyMinAmountIsGreaterThanMaxAmount                 = "minAmountIsGreaterThanMaxAmount"
+	ErrKeyInvalidStatusFilter                             = "invalidStatusFilter"
 )
 
 const (
@@ -153,8 +164,14 @@ var (
 	errStatusMustBeActiveOrInactive                       = errors.New("status must be active or inactive")
 	errInvalidFormatFile                                  = errors.New("invalid format file")
 	errFailedFromExternalClient                           = errors.New("failed from external client")
-	errActionMustBeCommitOrCancel                         = errors.New("action must be commit or cancel")
+	errActionMustBeCommitCancelOrIncrease                 = errors.New("action must be commit, cancel or increase")
 	errSummaryIdNotFound                                  = errors.New("summary id not found")
+	errStateMustBeResolvedOrWrittenOff                    = errors.New("state must be RESOLVED or WRITTEN_OFF")
+	errMetadataFilterMustBePathoperatorvalue              = errors.New("metadata filter must be path:operator:value")
+	errMetadataFilterCanNotBeMoreThan5                    = errors.New("metadata filter can not be more than 5")
+	errMinAmountAndMaxAmountMustBeANonNegativeNumber      = errors.New("minAmount and maxAmount must be a non negative number")
+	errMaxAmountMustBeGreaterThanOrEqualToMinAmount       = errors.New("maxAmount must be greater than or equal to minAmount")
+	errStatusMustBePendingSuccessOrCancel                 = errors.New("status must be PENDING, SUCCESS or CANCEL")
 )
 
 var MapErrors = MapErrs{
@@ -544,10 +561,54 @@ var MapErrors = MapErrs{
 	},
 	ErrKeyUpdateStatusWalletTransactionRequestActionOneof: ErrorDetail{
 		Code:         errCodeInvalidValues,
-		ErrorMessage: errActionMustBeCommitOrCancel,
+		ErrorMessage: errActionMustBeCommitCancelOrIncrease,
 	},
 	ErrKeySummaryIdnotFound: ErrorDetail{
 		Code:         errCodeDataNotFound,
 		ErrorMessage: errSummaryIdNotFound,
 	},
+	ErrKeyActorRequired: ErrorDetail{
+		Code:         errCodeMissingField,
+		ErrorMessage: errFieldIsMissing,
+	},
+	ErrKeyAssigneeRequired: ErrorDetail{
+		Code:         errCodeMissingField,
+		ErrorMessage: errFieldIsMissing,
+	},
+	ErrKeyCommentRequired: ErrorDetail{
+		Code:         errCodeMissingField,
+		ErrorMessage: errFieldIsMissing,
+	},
+	ErrKeyNoteRequired: ErrorDetail{
+		Code:         errCodeMissingField,
+		ErrorMessage: errFieldIsMissing,
+	},
+	ErrKeyStateRequired: ErrorDetail{
+		Code:         errCodeMissingField,
+		ErrorMessage: errFieldIsMissing,
+	},
+	ErrKeyStateOneof: ErrorDetail{
+		Code:         errCodeInvalidValues,
+		ErrorMessage: errStateMustBeResolvedOrWrittenOff,
+	},
+	ErrKeyInvalidMetadataFilter: ErrorDetail{
+		Code:         errCodeInvalidValues,
+		ErrorMessage: errMetadataFilterMustBePathoperatorvalue,
+	},
+	ErrKeyTooManyMetadataFilters: ErrorDetail{
+		Code:         errCodeInvalidValues,
+		ErrorMessage: errMetadataFilterCanNotBeMoreThan5,
+	},
+	ErrKeyInvalidAmountFilter: ErrorDetail{
+		Code:         errCodeInvalidValues,
+		ErrorMessage: errMinAmountAndMaxAmountMustBeANonNegativeNumber,
+	},
+	ErrKeyMinAmountIsGreaterThanMaxAmount: ErrorDetail{
+		Code:         errCodeInvalidValues,
+		ErrorMessage: errMaxAmountMustBeGreaterThanOrEqualToMinAmount,
+	},
+	ErrKeyInvalidStatusFilter: ErrorDetail{
+		Code:         errCodeInvalidValues,
+		ErrorMessage: errStatusMustBePendingSuccessOrCancel,
+	},
 }
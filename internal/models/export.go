@@ -0,0 +1,219 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/exportfile"
+)
+
+const (
+	ExportKind = "export"
+
+	// ExportTaskName is the name of the task that will be used in the export queue
+	ExportTaskName = "EXPORT_TRANSACTION"
+
+	ExportStatusPending    = "PENDING"
+	ExportStatusProcessing = "PROCESSING"
+	ExportStatusSuccess    = "SUCCESS"
+	ExportStatusFailed     = "FAILED"
+
+	// ExportPath is the cloud storage directory of exported files
+	ExportPath = "exports"
+)
+
+// ExportTransactionFilter is the filter of exported transactions, it is stored with the job
+// so the worker export the same transactions as requested.
+type ExportTransactionFilter struct {
+	StartDate        string   `json:"startDate" validate:"required,date" example:"2025-01-01"`
+	EndDate          string   `json:"endDate" validate:"required,date" example:"2025-01-31"`
+	Search           string   `json:"search,omitempty" example:"value of accountNumber refNumber transactionId"`
+	SearchBy         string   `json:"searchBy,omitempty" example:"refNumber"`
+	OrderType        string   `json:"orderType,omitempty" example:"TOPUP"`
+	TransactionTypes []string `json:"transactionTypes,omitempty" example:"TUPVA"`
+	ProductTypeName  string   `json:"productTypeName,omitempty" example:"Poket"`
+	AccountNumber    string   `json:"accountNumber,omitempty" example:"21100100000001"`
+	Statuses         []string `json:"statuses,omitempty" example:"SUCCESS"`
+	RefNumbers       []string `json:"refNumbers,omitempty" example:"55aa66bb-e6e0-4065-9f4a-64182e97e9d9"`
+	MinAmount        string   `json:"minAmount,omitempty" example:"10000"`
+	MaxAmount        string   `json:"maxAmount,omitempty" example:"50000"`
+	Metadata         []string `json:"metadata,omitempty" example:"loanAccountNumber:eq:LA-0001"`
+}
+
+// ToFilterOptions convert the filter into transaction filter, maxDays is the maximum date range, zero means unlimited
+func (f ExportTransactionFilter) ToFilterOptions(maxDays int) (*TransactionFilterOptions, error) {
+	opts := &TransactionFilterOptions{
+		Search:           f.Search,
+		SearchBy:         f.SearchBy,
+		OrderType:        f.OrderType,
+		TransactionTypes: f.TransactionTypes,
+		ProductTypeName:  f.ProductTypeName,
+	}
+
+	req := DoGetListTransactionRequest{
+		AccountNumber: f.AccountNumber,
+		Statuses:      f.Statuses,
+		RefNumbers:    f.RefNumbers,
+		MinAmount:     f.MinAmount,
+		MaxAmount:     f.MaxAmount,
+		Metadata:      f.Metadata,
+	}
+	if err := req.applyAdvancedFilter(opts); err != nil {
+		return nil, err
+	}
+
+	startDate, err := common.ParseStringToDatetime(common.DateFormatYYYYMMDD, f.StartDate)
+	if err != nil {
+		return nil, GetErrMap(ErrKeyInvalidFormatDate, fmt.Sprintf("date %s format must be YYYY-MM-DD", f.StartDate))
+	}
+
+	endDate, err := common.ParseStringToDatetime(common.DateFormatYYYYMMDD, f.EndDate)
+	if err != nil {
+		return nil, GetErrMap(ErrKeyInvalidFormatDate, fmt.Sprintf("date %s format must be YYYY-MM-DD", f.EndDate))
+	}
+
+	if endDate.Before(startDate) {
+		return nil, common.ErrInvalidDateRange
+	}
+
+	if maxDays > 0 && common.GetTotalDiffDayBetweenTwoDate(startDate, endDate) > float64(maxDays) {
+		return nil, fmt.Errorf("%w: maximum is %d days", common.ErrExportDateRangeExceeded, maxDays)
+	}
+
+	opts.StartDate = &startDate
+	opts.EndDate = &endDate
+
+	return opts, nil
+}
+
+func (f ExportTransactionFilter) Value() (driver.Value, error) {
+	return json.Marshal(f)
+}
+
+func (f *ExportTransactionFilter) Scan(src interface{}) error {
+	var raw []byte
+	switch src := src.(type) {
+	case string:
+		raw = []byte(src)
+	case []byte:
+		raw = src
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("type %T not supported by Scan", src)
+	}
+
+	return json.Unmarshal(raw, f)
+}
+
+type DoCreateExportRequest struct {
+	Format string                  `json:"format" validate:"required,oneof=CSV XLSX PARQUET" example:"CSV"`
+	Gzip   bool                    `json:"gzip" example:"true"`
+	Filter ExportTransactionFilter `json:"filter" validate:"required"`
+	Actor  string                  `json:"actor" validate:"required" example:"finance.ops"`
+}
+
+func (req DoCreateExportRequest) ToExportJob() ExportJob {
+	return ExportJob{
+		Format:      req.Format,
+		Gzip:        req.Gzip,
+		Filter:      req.Filter,
+		Status:      ExportStatusPending,
+		RequestedBy: req.Actor,
+	}
+}
+
+type ExportJob struct {
+	ID            uint64
+	Format        string
+	Gzip          bool
+	Filter        ExportTransactionFilter
+	Status        string
+	TotalRows     int64
+	ProcessedRows int64
+	FilePath      string
+	ErrorMessage  string
+	RequestedBy   string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	CompletedAt   *time.Time
+}
+
+// CloudStoragePayload return location of the exported file
+func (e ExportJob) CloudStoragePayload() *CloudStoragePayload {
+	filename := fmt.Sprintf("transaction-%d-%s_%s.%s", e.ID, e.Filter.StartDate, e.Filter.EndDate, exportfile.Format(e.Format).Extension())
+	if e.Gzip {
+		filename += ".gz"
+	}
+
+	return &CloudStoragePayload{
+		Filename: filename,
+		Path:     fmt.Sprintf("%s/%04d/%02d", ExportPath, e.CreatedAt.Year(), e.CreatedAt.Month()),
+	}
+}
+
+// Progress return percentage of processed rows, total rows is counted when the job start processing
+func (e ExportJob) Progress() float64 {
+	if e.Status == ExportStatusSuccess {
+		return 100
+	}
+
+	if e.TotalRows == 0 {
+		return 0
+	}
+
+	progress := float64(e.ProcessedRows) * 100 / float64(e.TotalRows)
+
+	// total rows is counted before streaming, new transactions may be written in between
+	return min(progress, 99)
+}
+
+// ToModelResponse convert job into response, downloadURL is only set when the job has succeeded
+func (e ExportJob) ToModelResponse(downloadURL string) DoGetExportResponse {
+	res := DoGetExportResponse{
+		Kind:          ExportKind,
+		ID:            e.ID,
+		Format:        e.Format,
+		Gzip:          e.Gzip,
+		Filter:        e.Filter,
+		Status:        e.Status,
+		TotalRows:     e.TotalRows,
+		ProcessedRows: e.ProcessedRows,
+		Progress:      e.Progress(),
+		DownloadURL:   downloadURL,
+		ErrorMessage:  e.ErrorMessage,
+		RequestedBy:   e.RequestedBy,
+		CreatedAt:     common.FormatDatetimeToStringInLocalTime(e.CreatedAt, common.DateFormatYYYYMMDDWithTime),
+	}
+
+	if e.CompletedAt != nil {
+		res.CompletedAt = common.FormatDatetimeToStringInLocalTime(*e.CompletedAt, common.DateFormatYYYYMMDDWithTime)
+	}
+
+	return res
+}
+
+type DoGetExportResponse struct {
+	Kind          string                  `json:"kind" example:"export"`
+	ID            uint64                  `json:"id" example:"1"`
+	Format        string                  `json:"format" example:"CSV"`
+	Gzip          bool                    `json:"gzip" example:"true"`
+	Filter        ExportTransactionFilter `json:"filter"`
+	Status        string                  `json:"status" example:"PROCESSING"`
+	TotalRows     int64                   `json:"totalRows" example:"1200000"`
+	ProcessedRows int64                   `json:"processedRows" example:"300000"`
+	Progress      float64                 `json:"progress" example:"25"`
+	DownloadURL   string                  `json:"downloadUrl,omitempty" example:"https://storage.googleapis.com/bucket/exports/2025/1/1/transaction.csv.gz"`
+	ErrorMessage  string                  `json:"errorMessage,omitempty" example:""`
+	RequestedBy   string                  `json:"requestedBy" example:"finance.ops"`
+	CreatedAt     string                  `json:"createdAt" example:"2025-01-01 08:00:00"`
+	CompletedAt   string                  `json:"completedAt,omitempty" example:"2025-01-01 08:10:00"`
+}
+
+type ExportPublisher struct {
+	ID   string `json:"id"`
+	Task string `json:"task"`
+}
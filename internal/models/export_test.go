@@ -0,0 +1,96 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportTransactionFilter_ToFilterOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  ExportTransactionFilter
+		maxDays int
+		wantErr error
+	}{
+		{
+			name:   "success unlimited range",
+			filter: ExportTransactionFilter{StartDate: "2024-01-01", EndDate: "2025-01-31", MinAmount: "1000"},
+		},
+		{
+			name:    "success within max range",
+			filter:  ExportTransactionFilter{StartDate: "2025-01-01", EndDate: "2025-01-31"},
+			maxDays: 31,
+		},
+		{
+			name:    "range exceeded",
+			filter:  ExportTransactionFilter{StartDate: "2025-01-01", EndDate: "2025-03-01"},
+			maxDays: 31,
+			wantErr: common.ErrExportDateRangeExceeded,
+		},
+		{
+			name:    "end date before start date",
+			filter:  ExportTransactionFilter{StartDate: "2025-02-01", EndDate: "2025-01-31"},
+			wantErr: common.ErrInvalidDateRange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := tt.filter.ToFilterOptions(tt.maxDays)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.filter.StartDate, opts.StartDate.Format(common.DateFormatYYYYMMDD))
+			assert.Equal(t, tt.filter.EndDate, opts.EndDate.Format(common.DateFormatYYYYMMDD))
+		})
+	}
+
+	_, err := ExportTransactionFilter{StartDate: "2025-01-01", EndDate: "2025-01-31", MinAmount: "abc"}.ToFilterOptions(0)
+	var errDetail ErrorDetail
+	assert.True(t, errors.As(err, &errDetail), err)
+}
+
+func TestExportTransactionFilter_Scan(t *testing.T) {
+	filter := ExportTransactionFilter{StartDate: "2025-01-01", EndDate: "2025-01-31", Statuses: []string{"SUCCESS"}}
+	raw, err := filter.Value()
+	require.NoError(t, err)
+
+	var got ExportTransactionFilter
+	require.NoError(t, got.Scan(raw))
+	assert.Equal(t, filter, got)
+
+	assert.Error(t, got.Scan(1))
+}
+
+func TestExportJob(t *testing.T) {
+	job := ExportJob{
+		ID:            7,
+		Format:        "PARQUET",
+		Gzip:          true,
+		Filter:        ExportTransactionFilter{StartDate: "2025-01-01", EndDate: "2025-01-31"},
+		Status:        ExportStatusProcessing,
+		TotalRows:     200,
+		ProcessedRows: 50,
+		CreatedAt:     time.Date(2025, 2, 1, 8, 0, 0, 0, time.UTC),
+	}
+
+	assert.Equal(t, "exports/2025/02/transaction-7-2025-01-01_2025-01-31.parquet.gz", job.CloudStoragePayload().GetFilePath())
+	assert.Equal(t, float64(25), job.Progress())
+
+	// new transactions may be written after counted
+	job.ProcessedRows = 250
+	assert.Equal(t, float64(99), job.Progress())
+
+	job.Status = ExportStatusSuccess
+	res := job.ToModelResponse("https://storage.test/export")
+	assert.Equal(t, float64(100), res.Progress)
+	assert.Equal(t, "https://storage.test/export", res.DownloadURL)
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+
+	"github.com/shopspring/decimal"
 )
 
 type ListWalletTrxByAccountNumberRequest struct {
@@ -20,6 +22,8 @@ type ListWalletTrxByAccountNumberRequest struct {
 	SortDirection   string
 	NextCursor      string `query:"nextCursor" example:"abc"`
 	PrevCursor      string `query:"prevCursor" example:"cba"`
+
+	WalletTrxAdvancedFilterRequest
 }
 
 type ListWalletTrxRequest struct {
@@ -34,6 +38,42 @@ type ListWalletTrxRequest struct {
 	SortDirection    string
 	NextCursor       string `query:"nextCursor" example:"abc"`
 	PrevCursor       string `query:"prevCursor" example:"cba"`
+
+	WalletTrxAdvancedFilterRequest
+}
+
+// WalletTrxAdvancedFilterRequest is structured filter shared by wallet transaction listing
+type WalletTrxAdvancedFilterRequest struct {
+	Statuses   string   `query:"statuses" example:"PENDING,SUCCESS"`
+	RefNumbers string   `query:"refNumbers" example:"REF-0001,REF-0002"`
+	MinAmount  string   `query:"minAmount" example:"10000"`
+	MaxAmount  string   `query:"maxAmount" example:"50000"`
+	Metadata   []string `query:"metadata" example:"loanAccountNumber:eq:LA-0001"`
+}
+
+func (req WalletTrxAdvancedFilterRequest) applyTo(opts *WalletTrxFilterOptions) (err error) {
+	opts.RefNumbers = splitCommaSeparated(req.RefNumbers)
+
+	for _, status := range splitCommaSeparated(req.Statuses) {
+		switch WalletTransactionStatus(status) {
+		case WalletTransactionStatusPending, WalletTransactionStatusSuccess, WalletTransactionStatusCancel:
+			opts.Statuses = append(opts.Statuses, status)
+		default:
+			return GetErrMap(ErrKeyInvalidStatusFilter, status)
+		}
+	}
+
+	opts.MinAmount, opts.MaxAmount, err = ParseAmountRange(req.MinAmount, req.MaxAmount)
+	if err != nil {
+		return err
+	}
+
+	opts.MetadataFilters, err = ParseMetadataFilters(req.Metadata)
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 type WalletTrxCursor struct {
@@ -81,6 +121,10 @@ func (req ListWalletTrxByAccountNumberRequest) ToFilterOpts() (*WalletTrxFilterO
 		TransactionType: req.TransactionType,
 	}
 
+	if err := req.WalletTrxAdvancedFilterRequest.applyTo(opts); err != nil {
+		return nil, err
+	}
+
 	if req.StartDate == "" && req.EndDate != "" || req.StartDate != "" && req.EndDate == "" {
 		return nil, GetErrMap(ErrKeyStartDateAndEndDateRequiredIfOneIsFilled)
 	}
@@ -166,6 +210,10 @@ func (req ListWalletTrxRequest) ToFilterOpts() (*WalletTrxFilterOptions, error)
 		opts.TransactionTypes = strings.Split(transactionTypes, ",")
 	}
 
+	if err := req.WalletTrxAdvancedFilterRequest.applyTo(opts); err != nil {
+		return nil, err
+	}
+
 	if req.StartDate == "" && req.EndDate != "" || req.StartDate != "" && req.EndDate == "" {
 		return nil, GetErrMap(ErrKeyStartDateAndEndDateRequiredIfOneIsFilled)
 	}
@@ -246,6 +294,11 @@ type WalletTrxFilterOptions struct {
 	AccountNumbers   []string
 	TransactionTypes []string
 	RefNumber        string
+	Statuses         []string
+	RefNumbers       []string
+	MinAmount        *decimal.Decimal
+	MaxAmount        *decimal.Decimal
+	MetadataFilters  []MetadataFilter
 
 	Cursor *WalletTrxCursor
 }
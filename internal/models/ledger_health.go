@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ReservedWalletTransactionSummary is the reserved wallet transactions of a transaction type
+// which have not been captured or cancelled yet
+type ReservedWalletTransactionSummary struct {
+	TransactionType string
+	TotalCount      int64
+	OldestCreatedAt time.Time
+}
+
+// MoneyFlowStatusSummary is the active money flow summaries of a status
+type MoneyFlowStatusSummary struct {
+	Status        string
+	TotalCount    int64
+	TotalTransfer decimal.Decimal
+}
@@ -0,0 +1,381 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	TrialBalanceKind  = "trialBalance"
+	GeneralLedgerKind = "generalLedger"
+
+	LedgerReportFormatCSV = "csv"
+)
+
+var (
+	TrialBalanceCSVHeader = []string{
+		"Category Code",
+		"Category Name",
+		"Sub Category Code",
+		"Sub Category Name",
+		"Account Number",
+		"Account Name",
+		"Opening Balance",
+		"Debit",
+		"Credit",
+		"Closing Balance",
+	}
+
+	GeneralLedgerCSVHeader = []string{
+		"Transaction Date",
+		"Transaction ID",
+		"Ref Number",
+		"Transaction Type",
+		"Counterpart Account",
+		"Description",
+		"Debit",
+		"Credit",
+		"Balance",
+	}
+)
+
+// DoGetTrialBalanceRequest is the trial balance of an entity, the period is from until date and both dates are inclusive.
+// From is default to date, so the trial balance is the movement of the date.
+type DoGetTrialBalanceRequest struct {
+	Entity string `query:"entity" example:"001"`
+	Date   string `query:"date" validate:"required,date" example:"2025-01-31"`
+	From   string `query:"from" validate:"omitempty,date" example:"2025-01-01"`
+	Format string `query:"format" validate:"omitempty,oneof=json csv" example:"csv"`
+}
+
+// TrialBalanceFilterOptions is the filter of trial balance, empty entity means all entities
+type TrialBalanceFilterOptions struct {
+	Entity    string
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+func (r DoGetTrialBalanceRequest) ToFilterOptions() (TrialBalanceFilterOptions, error) {
+	endDate, err := time.Parse(time.DateOnly, r.Date)
+	if err != nil {
+		return TrialBalanceFilterOptions{}, err
+	}
+
+	startDate := endDate
+	if r.From != "" {
+		startDate, err = time.Parse(time.DateOnly, r.From)
+		if err != nil {
+			return TrialBalanceFilterOptions{}, err
+		}
+	}
+
+	if endDate.Before(startDate) {
+		return TrialBalanceFilterOptions{}, common.ErrInvalidDateRange
+	}
+
+	return TrialBalanceFilterOptions{
+		Entity:    r.Entity,
+		StartDate: startDate,
+		EndDate:   endDate,
+	}, nil
+}
+
+// TrialBalanceAccount is the balance movement of an account within the period.
+// Every transaction is posted as debit of fromAccount and credit of toAccount,
+// so the balance is the opening balance plus credit minus debit.
+type TrialBalanceAccount struct {
+	AccountNumber   string          `json:"accountNumber" example:"21100100000001"`
+	AccountName     string          `json:"accountName" example:"John"`
+	CategoryCode    string          `json:"-"`
+	CategoryName    string          `json:"-"`
+	SubCategoryCode string          `json:"-"`
+	SubCategoryName string          `json:"-"`
+	EntityCode      string          `json:"entityCode" example:"001"`
+	OpeningBalance  decimal.Decimal `json:"openingBalance" example:"100000"`
+	Debit           decimal.Decimal `json:"debit" example:"25000"`
+	Credit          decimal.Decimal `json:"credit" example:"50000"`
+	ClosingBalance  decimal.Decimal `json:"closingBalance" example:"125000"`
+}
+
+type TrialBalanceTotal struct {
+	OpeningBalance decimal.Decimal `json:"openingBalance" example:"100000"`
+	Debit          decimal.Decimal `json:"debit" example:"25000"`
+	Credit         decimal.Decimal `json:"credit" example:"50000"`
+	ClosingBalance decimal.Decimal `json:"closingBalance" example:"125000"`
+}
+
+func (t *TrialBalanceTotal) add(account TrialBalanceAccount) {
+	t.OpeningBalance = t.OpeningBalance.Add(account.OpeningBalance)
+	t.Debit = t.Debit.Add(account.Debit)
+	t.Credit = t.Credit.Add(account.Credit)
+	t.ClosingBalance = t.ClosingBalance.Add(account.ClosingBalance)
+}
+
+type TrialBalanceSubCategory struct {
+	Code     string                `json:"code" example:"21100"`
+	Name     string                `json:"name" example:"Lender Wallet"`
+	Total    TrialBalanceTotal     `json:"total"`
+	Accounts []TrialBalanceAccount `json:"accounts"`
+}
+
+type TrialBalanceCategory struct {
+	Code          string                    `json:"code" example:"211"`
+	Name          string                    `json:"name" example:"Wallet"`
+	Total         TrialBalanceTotal         `json:"total"`
+	SubCategories []TrialBalanceSubCategory `json:"subCategories"`
+}
+
+type DoGetTrialBalanceResponse struct {
+	Kind       string                 `json:"kind" example:"trialBalance"`
+	Entity     string                 `json:"entity" example:"001"`
+	StartDate  string                 `json:"startDate" example:"2025-01-01"`
+	EndDate    string                 `json:"endDate" example:"2025-01-31"`
+	IsBalanced bool                   `json:"isBalanced" example:"true"`
+	Total      TrialBalanceTotal      `json:"total"`
+	Categories []TrialBalanceCategory `json:"categories"`
+}
+
+// NewTrialBalance group the accounts by category and sub category.
+// The trial balance is balanced when total debit equals total credit, it is not when the entity has transactions
+// with accounts of other entities.
+func NewTrialBalance(opts TrialBalanceFilterOptions, accounts []TrialBalanceAccount) DoGetTrialBalanceResponse {
+	sort.SliceStable(accounts, func(i, j int) bool {
+		if accounts[i].CategoryCode != accounts[j].CategoryCode {
+			return accounts[i].CategoryCode < accounts[j].CategoryCode
+		}
+		if accounts[i].SubCategoryCode != accounts[j].SubCategoryCode {
+			return accounts[i].SubCategoryCode < accounts[j].SubCategoryCode
+		}
+		return accounts[i].AccountNumber < accounts[j].AccountNumber
+	})
+
+	result := DoGetTrialBalanceResponse{
+		Kind:       TrialBalanceKind,
+		Entity:     opts.Entity,
+		StartDate:  opts.StartDate.Format(time.DateOnly),
+		EndDate:    opts.EndDate.Format(time.DateOnly),
+		Categories: []TrialBalanceCategory{},
+	}
+
+	for _, account := range accounts {
+		account.ClosingBalance = account.OpeningBalance.Add(account.Credit).Sub(account.Debit)
+
+		n := len(result.Categories)
+		if n == 0 || result.Categories[n-1].Code != account.CategoryCode {
+			result.Categories = append(result.Categories, TrialBalanceCategory{
+				Code:          account.CategoryCode,
+				Name:          account.CategoryName,
+				SubCategories: []TrialBalanceSubCategory{},
+			})
+			n++
+		}
+		category := &result.Categories[n-1]
+
+		m := len(category.SubCategories)
+		if m == 0 || category.SubCategories[m-1].Code != account.SubCategoryCode {
+			category.SubCategories = append(category.SubCategories, TrialBalanceSubCategory{
+				Code: account.SubCategoryCode,
+				Name: account.SubCategoryName,
+			})
+			m++
+		}
+		subCategory := &category.SubCategories[m-1]
+
+		subCategory.Accounts = append(subCategory.Accounts, account)
+		subCategory.Total.add(account)
+		category.Total.add(account)
+		result.Total.add(account)
+	}
+
+	result.IsBalanced = result.Total.Debit.Equal(result.Total.Credit)
+
+	return result
+}
+
+// ToCSVRows return a row per account followed by the total row
+func (r DoGetTrialBalanceResponse) ToCSVRows() [][]string {
+	var rows [][]string
+	for _, category := range r.Categories {
+		for _, subCategory := range category.SubCategories {
+			for _, account := range subCategory.Accounts {
+				rows = append(rows, []string{
+					category.Code,
+					category.Name,
+					subCategory.Code,
+					subCategory.Name,
+					account.AccountNumber,
+					account.AccountName,
+					account.OpeningBalance.String(),
+					account.Debit.String(),
+					account.Credit.String(),
+					account.ClosingBalance.String(),
+				})
+			}
+		}
+	}
+
+	return append(rows, []string{
+		"", "", "", "", "", "TOTAL",
+		r.Total.OpeningBalance.String(),
+		r.Total.Debit.String(),
+		r.Total.Credit.String(),
+		r.Total.ClosingBalance.String(),
+	})
+}
+
+// DoGetGeneralLedgerRequest is the general ledger of an account, both dates are inclusive
+type DoGetGeneralLedgerRequest struct {
+	Account string `query:"account" validate:"required" example:"21100100000001"`
+	From    string `query:"from" validate:"required,date" example:"2025-01-01"`
+	To      string `query:"to" validate:"required,date" example:"2025-01-31"`
+	Format  string `query:"format" validate:"omitempty,oneof=json csv" example:"csv"`
+}
+
+type GeneralLedgerFilterOptions struct {
+	AccountNumber string
+	StartDate     time.Time
+	EndDate       time.Time
+}
+
+func (r DoGetGeneralLedgerRequest) ToFilterOptions() (GeneralLedgerFilterOptions, error) {
+	startDate, err := time.Parse(time.DateOnly, r.From)
+	if err != nil {
+		return GeneralLedgerFilterOptions{}, err
+	}
+
+	endDate, err := time.Parse(time.DateOnly, r.To)
+	if err != nil {
+		return GeneralLedgerFilterOptions{}, err
+	}
+
+	if endDate.Before(startDate) {
+		return GeneralLedgerFilterOptions{}, common.ErrInvalidDateRange
+	}
+
+	return GeneralLedgerFilterOptions{
+		AccountNumber: r.Account,
+		StartDate:     startDate,
+		EndDate:       endDate,
+	}, nil
+}
+
+// LedgerPosting is a successful transaction from or to the account of general ledger
+type LedgerPosting struct {
+	ID              uint64
+	TransactionID   string
+	RefNumber       string
+	TransactionType string
+	TransactionDate time.Time
+	FromAccount     string
+	ToAccount       string
+	Amount          decimal.Decimal
+	Description     string
+}
+
+type GeneralLedgerEntry struct {
+	ID                 uint64          `json:"id" example:"1"`
+	TransactionID      string          `json:"transactionId" example:"TRX-1"`
+	RefNumber          string          `json:"refNumber" example:"REF-1"`
+	TransactionType    string          `json:"transactionType" example:"TUPVA"`
+	TransactionDate    string          `json:"transactionDate" example:"2025-01-02"`
+	CounterpartAccount string          `json:"counterpartAccount" example:"21100100000002"`
+	Description        string          `json:"description" example:"topup"`
+	Debit              decimal.Decimal `json:"debit" example:"0"`
+	Credit             decimal.Decimal `json:"credit" example:"50000"`
+	Balance            decimal.Decimal `json:"balance" example:"150000"`
+}
+
+type DoGetGeneralLedgerResponse struct {
+	Kind            string               `json:"kind" example:"generalLedger"`
+	AccountNumber   string               `json:"accountNumber" example:"21100100000001"`
+	AccountName     string               `json:"accountName" example:"John"`
+	CategoryCode    string               `json:"categoryCode" example:"211"`
+	SubCategoryCode string               `json:"subCategoryCode" example:"21100"`
+	EntityCode      string               `json:"entityCode" example:"001"`
+	StartDate       string               `json:"startDate" example:"2025-01-01"`
+	EndDate         string               `json:"endDate" example:"2025-01-31"`
+	OpeningBalance  decimal.Decimal      `json:"openingBalance" example:"100000"`
+	TotalDebit      decimal.Decimal      `json:"totalDebit" example:"0"`
+	TotalCredit     decimal.Decimal      `json:"totalCredit" example:"50000"`
+	ClosingBalance  decimal.Decimal      `json:"closingBalance" example:"150000"`
+	Entries         []GeneralLedgerEntry `json:"entries"`
+}
+
+// NewGeneralLedger list the postings of the account with running balance, the postings must be sorted by date
+func NewGeneralLedger(opts GeneralLedgerFilterOptions, account GetAccountOut, openingBalance decimal.Decimal, postings []LedgerPosting) DoGetGeneralLedgerResponse {
+	result := DoGetGeneralLedgerResponse{
+		Kind:            GeneralLedgerKind,
+		AccountNumber:   account.AccountNumber,
+		AccountName:     account.AccountName,
+		CategoryCode:    account.Category,
+		SubCategoryCode: account.SubCategory,
+		EntityCode:      account.Entity,
+		StartDate:       opts.StartDate.Format(time.DateOnly),
+		EndDate:         opts.EndDate.Format(time.DateOnly),
+		OpeningBalance:  openingBalance,
+		Entries:         make([]GeneralLedgerEntry, 0, len(postings)),
+	}
+
+	balance := openingBalance
+	for _, posting := range postings {
+		entry := GeneralLedgerEntry{
+			ID:              posting.ID,
+			TransactionID:   posting.TransactionID,
+			RefNumber:       posting.RefNumber,
+			TransactionType: posting.TransactionType,
+			TransactionDate: posting.TransactionDate.Format(time.DateOnly),
+			Description:     posting.Description,
+		}
+
+		// transfer to the account itself is posted as both debit and credit
+		if posting.FromAccount == account.AccountNumber {
+			entry.Debit = posting.Amount
+			entry.CounterpartAccount = posting.ToAccount
+		}
+		if posting.ToAccount == account.AccountNumber {
+			entry.Credit = posting.Amount
+			entry.CounterpartAccount = posting.FromAccount
+		}
+
+		balance = balance.Add(entry.Credit).Sub(entry.Debit)
+		entry.Balance = balance
+
+		result.TotalDebit = result.TotalDebit.Add(entry.Debit)
+		result.TotalCredit = result.TotalCredit.Add(entry.Credit)
+		result.Entries = append(result.Entries, entry)
+	}
+	result.ClosingBalance = balance
+
+	return result
+}
+
+// ToCSVRows return the opening balance row, a row per entry and the closing balance row
+func (r DoGetGeneralLedgerResponse) ToCSVRows() [][]string {
+	rows := [][]string{
+		{r.StartDate, "", "", "", "", "OPENING BALANCE", "", "", r.OpeningBalance.String()},
+	}
+	for _, entry := range r.Entries {
+		rows = append(rows, []string{
+			entry.TransactionDate,
+			entry.TransactionID,
+			entry.RefNumber,
+			entry.TransactionType,
+			entry.CounterpartAccount,
+			entry.Description,
+			entry.Debit.String(),
+			entry.Credit.String(),
+			entry.Balance.String(),
+		})
+	}
+
+	return append(rows, []string{
+		r.EndDate, "", "", "", "", "CLOSING BALANCE",
+		r.TotalDebit.String(),
+		r.TotalCredit.String(),
+		r.ClosingBalance.String(),
+	})
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoGetTrialBalanceRequest_ToFilterOptions(t *testing.T) {
+	opts, err := DoGetTrialBalanceRequest{Entity: "001", Date: "2025-01-31"}.ToFilterOptions()
+	require.NoError(t, err)
+	assert.Equal(t, opts.EndDate, opts.StartDate)
+
+	opts, err = DoGetTrialBalanceRequest{Date: "2025-01-31", From: "2025-01-01"}.ToFilterOptions()
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-01", opts.StartDate.Format(time.DateOnly))
+
+	_, err = DoGetTrialBalanceRequest{Date: "2025-01-01", From: "2025-01-31"}.ToFilterOptions()
+	assert.True(t, errors.Is(err, common.ErrInvalidDateRange), err)
+
+	_, err = DoGetGeneralLedgerRequest{Account: "1", From: "2025-01-31", To: "2025-01-01"}.ToFilterOptions()
+	assert.True(t, errors.Is(err, common.ErrInvalidDateRange), err)
+}
+
+func TestNewTrialBalance(t *testing.T) {
+	opts := TrialBalanceFilterOptions{
+		Entity:    "001",
+		StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	// bank transfer 700 to lender wallets and lender 1 transfer 200 to lender 2
+	accounts := []TrialBalanceAccount{
+		{
+			AccountNumber: "21100100000002", CategoryCode: "211", CategoryName: "Wallet", SubCategoryCode: "21100", SubCategoryName: "Lender",
+			Credit: decimal.NewFromInt(400),
+		},
+		{
+			AccountNumber: "21100100000001", CategoryCode: "211", CategoryName: "Wallet", SubCategoryCode: "21100", SubCategoryName: "Lender",
+			OpeningBalance: decimal.NewFromInt(1000), Debit: decimal.NewFromInt(200), Credit: decimal.NewFromInt(500),
+		},
+		{
+			AccountNumber: "14100100000001", CategoryCode: "141", CategoryName: "Bank", SubCategoryCode: "14100", SubCategoryName: "Operational",
+			OpeningBalance: decimal.NewFromInt(-1000), Debit: decimal.NewFromInt(700),
+		},
+	}
+
+	report := NewTrialBalance(opts, accounts)
+
+	assert.Equal(t, TrialBalanceKind, report.Kind)
+	assert.Equal(t, "2025-01-01", report.StartDate)
+	assert.Equal(t, "2025-01-31", report.EndDate)
+	assert.True(t, report.IsBalanced)
+	assert.True(t, report.Total.Debit.Equal(report.Total.Credit), "total debit %s must equal total credit %s", report.Total.Debit, report.Total.Credit)
+	assert.True(t, decimal.NewFromInt(900).Equal(report.Total.Debit))
+	assert.True(t, report.Total.OpeningBalance.IsZero())
+	assert.True(t, report.Total.ClosingBalance.IsZero())
+
+	require.Len(t, report.Categories, 2)
+	assert.Equal(t, "141", report.Categories[0].Code)
+	assert.True(t, decimal.NewFromInt(-1700).Equal(report.Categories[0].Total.ClosingBalance))
+
+	wallet := report.Categories[1]
+	assert.Equal(t, "Wallet", wallet.Name)
+	require.Len(t, wallet.SubCategories, 1)
+	require.Len(t, wallet.SubCategories[0].Accounts, 2)
+	assert.Equal(t, "21100100000001", wallet.SubCategories[0].Accounts[0].AccountNumber)
+	assert.True(t, decimal.NewFromInt(1300).Equal(wallet.SubCategories[0].Accounts[0].ClosingBalance))
+	assert.True(t, decimal.NewFromInt(1700).Equal(wallet.Total.ClosingBalance))
+
+	rows := report.ToCSVRows()
+	require.Len(t, rows, 4)
+	assert.Equal(t, []string{"141", "Bank", "14100", "Operational", "14100100000001", "", "-1000", "700", "0", "-1700"}, rows[0])
+	assert.Equal(t, []string{"", "", "", "", "", "TOTAL", "0", "900", "900", "0"}, rows[3])
+}
+
+func TestNewTrialBalance_InterEntity(t *testing.T) {
+	// transfer from the bank of other entity is only credited to the entity
+	accounts := []TrialBalanceAccount{
+		{AccountNumber: "21100100000001", CategoryCode: "211", SubCategoryCode: "21100", Credit: decimal.NewFromInt(500)},
+	}
+
+	report := NewTrialBalance(TrialBalanceFilterOptions{Entity: "001"}, accounts)
+	assert.False(t, report.IsBalanced)
+}
+
+func TestNewGeneralLedger(t *testing.T) {
+	opts := GeneralLedgerFilterOptions{
+		AccountNumber: "21100100000001",
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:       time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+	account := GetAccountOut{AccountNumber: "21100100000001", AccountName: "John", Category: "211", SubCategory: "21100", Entity: "001"}
+	postings := []LedgerPosting{
+		{
+			ID: 1, TransactionID: "TRX-1", TransactionType: "TUPVA", TransactionDate: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+			FromAccount: "14100100000001", ToAccount: "21100100000001", Amount: decimal.NewFromInt(500),
+		},
+		{
+			ID: 2, TransactionID: "TRX-2", TransactionType: "INVAA", TransactionDate: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+			FromAccount: "21100100000001", ToAccount: "21100100000002", Amount: decimal.NewFromInt(200),
+		},
+		{
+			ID: 3, TransactionID: "TRX-3", TransactionType: "ADJ", TransactionDate: time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC),
+			FromAccount: "21100100000001", ToAccount: "21100100000001", Amount: decimal.NewFromInt(50),
+		},
+	}
+
+	report := NewGeneralLedger(opts, account, decimal.NewFromInt(1000), postings)
+
+	assert.Equal(t, GeneralLedgerKind, report.Kind)
+	assert.Equal(t, "001", report.EntityCode)
+	require.Len(t, report.Entries, 3)
+
+	assert.Equal(t, "14100100000001", report.Entries[0].CounterpartAccount)
+	assert.True(t, decimal.NewFromInt(500).Equal(report.Entries[0].Credit))
+	assert.True(t, decimal.NewFromInt(1500).Equal(report.Entries[0].Balance))
+
+	assert.Equal(t, "21100100000002", report.Entries[1].CounterpartAccount)
+	assert.True(t, decimal.NewFromInt(200).Equal(report.Entries[1].Debit))
+	assert.True(t, decimal.NewFromInt(1300).Equal(report.Entries[1].Balance))
+
+	// transfer to itself is both debit and credit, so the balance does not change
+	assert.True(t, report.Entries[2].Debit.Equal(report.Entries[2].Credit))
+	assert.True(t, decimal.NewFromInt(1300).Equal(report.Entries[2].Balance))
+
+	assert.True(t, decimal.NewFromInt(250).Equal(report.TotalDebit))
+	assert.True(t, decimal.NewFromInt(550).Equal(report.TotalCredit))
+	assert.True(t, report.OpeningBalance.Add(report.TotalCredit).Sub(report.TotalDebit).Equal(report.ClosingBalance))
+
+	rows := report.ToCSVRows()
+	require.Len(t, rows, 5)
+	assert.Equal(t, []string{"2025-01-01", "", "", "", "", "OPENING BALANCE", "", "", "1000"}, rows[0])
+	assert.Equal(t, []string{"2025-01-31", "", "", "", "", "CLOSING BALANCE", "250", "550", "1300"}, rows[4])
+}
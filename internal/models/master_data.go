@@ -11,6 +11,10 @@ type OrderType struct {
 	OrderTypeCode    string            `json:"orderTypeCode"`
 	OrderTypeName    string            `json:"orderTypeName"`
 	TransactionTypes []TransactionType `json:"transactionTypes"`
+
+	// Version is used for optimistic locking when master data is stored in postgres,
+	// zero means the caller does not care about concurrent update
+	Version int64 `json:"version,omitempty"`
 }
 
 func MakeOrderTypesMap(orderTypes []OrderType) (mapOrderType, mapTransactionType map[string]string) {
@@ -33,6 +37,7 @@ type OrderTypeOut struct {
 	OrderTypeCode    string               `json:"orderTypeCode"`
 	OrderTypeName    string               `json:"orderTypeName"`
 	TransactionTypes []TransactionTypeOut `json:"transactionTypes"`
+	Version          int64                `json:"version,omitempty"`
 }
 
 func (m OrderType) ToResponse() OrderTypeOut {
@@ -46,18 +51,48 @@ func (m OrderType) ToResponse() OrderTypeOut {
 		OrderTypeCode:    m.OrderTypeCode,
 		OrderTypeName:    m.OrderTypeName,
 		TransactionTypes: tts,
+		Version:          m.Version,
 	}
 }
 
+const (
+	TransactionTypeStatusActive   = "ACTIVE"
+	TransactionTypeStatusInactive = "INACTIVE"
+)
+
 type TransactionType struct {
 	TransactionTypeCode string `json:"transactionTypeCode"`
 	TransactionTypeName string `json:"transactionTypeName"`
+
+	// Status empty is treated as active, master data created before status exist does not have it
+	Status string `json:"status,omitempty"`
+	// EffectiveFrom is the time when the transaction type start to be accepted, nil means always
+	EffectiveFrom *time.Time `json:"effectiveFrom,omitempty"`
+}
+
+func (t TransactionType) GetStatus() string {
+	if t.Status == "" {
+		return TransactionTypeStatusActive
+	}
+
+	return t.Status
+}
+
+// IsActiveAt check whether the transaction type is accepted at the given time
+func (t TransactionType) IsActiveAt(at time.Time) bool {
+	if t.GetStatus() != TransactionTypeStatusActive {
+		return false
+	}
+
+	return t.EffectiveFrom == nil || !t.EffectiveFrom.After(at)
 }
 
 type TransactionTypeOut struct {
-	Kind                string `json:"kind"`
-	TransactionTypeCode string `json:"transactionTypeCode"`
-	TransactionTypeName string `json:"transactionTypeName"`
+	Kind                string     `json:"kind"`
+	TransactionTypeCode string     `json:"transactionTypeCode"`
+	TransactionTypeName string     `json:"transactionTypeName"`
+	Status              string     `json:"status" example:"ACTIVE"`
+	EffectiveFrom       *time.Time `json:"effectiveFrom,omitempty"`
 }
 
 func (t TransactionType) ToResponse() TransactionTypeOut {
@@ -65,9 +100,63 @@ func (t TransactionType) ToResponse() TransactionTypeOut {
 		Kind:                "transactionType",
 		TransactionTypeCode: t.TransactionTypeCode,
 		TransactionTypeName: t.TransactionTypeName,
+		Status:              t.GetStatus(),
+		EffectiveFrom:       t.EffectiveFrom,
+	}
+}
+
+// CreateTransactionTypeRequest add transaction type into an order type,
+// Version is the current version of the order type and only checked when master data is stored in postgres
+type CreateTransactionTypeRequest struct {
+	OrderTypeCode       string     `param:"orderTypeCode" json:"-"`
+	TransactionTypeCode string     `json:"transactionTypeCode" validate:"required" example:"TUPVA"`
+	TransactionTypeName string     `json:"transactionTypeName" validate:"required" example:"Top up Lender via VA"`
+	Status              string     `json:"status" validate:"omitempty,oneof=ACTIVE INACTIVE" example:"ACTIVE"`
+	EffectiveFrom       *time.Time `json:"effectiveFrom" example:"2025-01-01T00:00:00+07:00"`
+	Version             int64      `json:"version" example:"3"`
+}
+
+func (r CreateTransactionTypeRequest) ToTransactionType() TransactionType {
+	return TransactionType{
+		TransactionTypeCode: r.TransactionTypeCode,
+		TransactionTypeName: r.TransactionTypeName,
+		Status:              TransactionType{Status: r.Status}.GetStatus(),
+		EffectiveFrom:       r.EffectiveFrom,
 	}
 }
 
+// UpdateTransactionTypeRequest change transaction type of an order type, empty field is not changed
+type UpdateTransactionTypeRequest struct {
+	OrderTypeCode       string     `param:"orderTypeCode" json:"-"`
+	TransactionTypeCode string     `param:"transactionTypeCode" json:"-"`
+	TransactionTypeName string     `json:"transactionTypeName" example:"Top up Lender via VA"`
+	Status              string     `json:"status" validate:"omitempty,oneof=ACTIVE INACTIVE" example:"INACTIVE"`
+	EffectiveFrom       *time.Time `json:"effectiveFrom" example:"2025-01-01T00:00:00+07:00"`
+	Version             int64      `json:"version" example:"3"`
+}
+
+func (r UpdateTransactionTypeRequest) ApplyTo(t *TransactionType) {
+	if r.TransactionTypeName != "" {
+		t.TransactionTypeName = r.TransactionTypeName
+	}
+
+	if r.Status != "" {
+		t.Status = r.Status
+	}
+
+	if r.EffectiveFrom != nil {
+		t.EffectiveFrom = r.EffectiveFrom
+	}
+}
+
+// DeactivateTransactionTypeRequest set transaction type to inactive,
+// transaction type is never removed since existing transactions still refer to it
+type DeactivateTransactionTypeRequest struct {
+	OrderTypeCode       string `param:"orderTypeCode"`
+	TransactionTypeCode string `param:"transactionTypeCode"`
+	Version             int64  `query:"version" example:"3"`
+}
+
 type FilterMasterData struct {
 	Name string `query:"name" example:"Top Up Lender P2P"`
 	Code string `query:"code" example:"100"`
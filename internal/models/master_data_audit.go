@@ -0,0 +1,49 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const (
+	MasterDataEntityOrderType  = "ORDER_TYPE"
+	MasterDataEntityVATRevenue = "VAT_REVENUE"
+
+	MasterDataAuditActionCreate = "CREATE"
+	MasterDataAuditActionUpdate = "UPDATE"
+
+	// MasterDataDefaultActor is recorded in the audit when the change is not made by a known user
+	MasterDataDefaultActor = "system"
+)
+
+// MasterDataAudit is a history of master data change stored in postgres backend,
+// Before and After are the json snapshot of the entity
+type MasterDataAudit struct {
+	Entity     string
+	EntityCode string
+	Action     string
+	Version    int64
+	Actor      string
+	Before     json.RawMessage
+	After      json.RawMessage
+}
+
+type masterDataActorKey struct{}
+
+// WithMasterDataActor set the user who is changing master data, it will be recorded in the audit
+func WithMasterDataActor(ctx context.Context, actor string) context.Context {
+	if actor == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, masterDataActorKey{}, actor)
+}
+
+// GetMasterDataActor get the user who is changing master data, MasterDataDefaultActor is returned if not set
+func GetMasterDataActor(ctx context.Context) string {
+	if actor, ok := ctx.Value(masterDataActorKey{}).(string); ok {
+		return actor
+	}
+
+	return MasterDataDefaultActor
+}
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+type MasterDataConsistencyIssueType string
+
+const (
+	// ConsistencyIssueAcceptedWithoutTransformer transaction type is accepted but wallet transaction can not be transformed
+	ConsistencyIssueAcceptedWithoutTransformer MasterDataConsistencyIssueType = "ACCEPTED_WITHOUT_TRANSFORMER"
+	// ConsistencyIssueTransformerUndefined transformer is registered for transaction type which is not defined
+	ConsistencyIssueTransformerUndefined MasterDataConsistencyIssueType = "TRANSFORMER_UNDEFINED"
+	// ConsistencyIssueMoneyFlowUndefined money flow maps transaction type which is not defined
+	ConsistencyIssueMoneyFlowUndefined MasterDataConsistencyIssueType = "MONEY_FLOW_UNDEFINED"
+	// ConsistencyIssueInactiveButAccepted transaction type is inactive in master data but still accepted by config
+	ConsistencyIssueInactiveButAccepted MasterDataConsistencyIssueType = "INACTIVE_BUT_ACCEPTED"
+
+	MoneyFlowRulesSourcePublished   = "PUBLISHED"
+	MoneyFlowRulesSourceFeatureFlag = "FEATURE_FLAG"
+	MoneyFlowRulesSourceUnavailable = "UNAVAILABLE"
+)
+
+// MasterDataConsistencySources is every place where transaction type is referenced
+type MasterDataConsistencySources struct {
+	OrderTypes                []OrderType
+	TransformerTypes          []string
+	AcceptedTransactionTypes  []string
+	MoneyFlowTransactionTypes []string
+	MoneyFlowRulesSource      string
+	MoneyFlowRulesVersion     int64
+}
+
+type MasterDataConsistencyIssue struct {
+	TransactionTypeCode string                         `json:"transactionTypeCode" example:"TUPVA"`
+	Issue               MasterDataConsistencyIssueType `json:"issue" example:"ACCEPTED_WITHOUT_TRANSFORMER"`
+}
+
+type MasterDataConsistencyReport struct {
+	Kind                          string                       `json:"kind" example:"masterDataConsistencyReport"`
+	CheckedAt                     time.Time                    `json:"checkedAt"`
+	TotalTransactionTypes         int                          `json:"totalTransactionTypes"`
+	TotalTransformers             int                          `json:"totalTransformers"`
+	TotalAcceptedTransactionTypes int                          `json:"totalAcceptedTransactionTypes"`
+	TotalMoneyFlowMappings        int                          `json:"totalMoneyFlowMappings"`
+	MoneyFlowRulesSource          string                       `json:"moneyFlowRulesSource" example:"PUBLISHED"`
+	MoneyFlowRulesVersion         int64                        `json:"moneyFlowRulesVersion,omitempty"`
+	Issues                        []MasterDataConsistencyIssue `json:"issues"`
+}
+
+// BuildMasterDataConsistencyReport cross check master data against transformers, accepted config and money flow mapping.
+// A transaction type is defined when it exists in master data (any status) or in accepted config,
+// and it is accepted when it is active in master data at the given time or in accepted config.
+func BuildMasterDataConsistencyReport(src MasterDataConsistencySources, now time.Time) MasterDataConsistencyReport {
+	defined := map[string]bool{}
+	accepted := map[string]bool{}
+	inactive := map[string]bool{}
+	totalTransactionTypes := 0
+
+	for _, orderType := range src.OrderTypes {
+		for _, transactionType := range orderType.TransactionTypes {
+			totalTransactionTypes++
+			defined[transactionType.TransactionTypeCode] = true
+			if transactionType.IsActiveAt(now) {
+				accepted[transactionType.TransactionTypeCode] = true
+			} else if transactionType.GetStatus() == TransactionTypeStatusInactive {
+				inactive[transactionType.TransactionTypeCode] = true
+			}
+		}
+	}
+
+	for _, code := range src.AcceptedTransactionTypes {
+		defined[code] = true
+		accepted[code] = true
+	}
+
+	hasTransformer := map[string]bool{}
+	for _, code := range src.TransformerTypes {
+		hasTransformer[code] = true
+	}
+
+	var issues []MasterDataConsistencyIssue
+	addIssue := func(code string, issue MasterDataConsistencyIssueType) {
+		issues = append(issues, MasterDataConsistencyIssue{TransactionTypeCode: code, Issue: issue})
+	}
+
+	for code := range accepted {
+		if !hasTransformer[code] {
+			addIssue(code, ConsistencyIssueAcceptedWithoutTransformer)
+		}
+	}
+
+	for code := range hasTransformer {
+		if !defined[code] {
+			addIssue(code, ConsistencyIssueTransformerUndefined)
+		}
+	}
+
+	for _, code := range src.MoneyFlowTransactionTypes {
+		if !defined[code] {
+			addIssue(code, ConsistencyIssueMoneyFlowUndefined)
+		}
+	}
+
+	for _, code := range src.AcceptedTransactionTypes {
+		if inactive[code] {
+			addIssue(code, ConsistencyIssueInactiveButAccepted)
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		if issues[i].Issue != issues[j].Issue {
+			return issues[i].Issue < issues[j].Issue
+		}
+		return issues[i].TransactionTypeCode < issues[j].TransactionTypeCode
+	})
+
+	if issues == nil {
+		issues = []MasterDataConsistencyIssue{}
+	}
+
+	return MasterDataConsistencyReport{
+		Kind:                          "masterDataConsistencyReport",
+		CheckedAt:                     now,
+		TotalTransactionTypes:         totalTransactionTypes,
+		TotalTransformers:             len(src.TransformerTypes),
+		TotalAcceptedTransactionTypes: len(src.AcceptedTransactionTypes),
+		TotalMoneyFlowMappings:        len(src.MoneyFlowTransactionTypes),
+		MoneyFlowRulesSource:          src.MoneyFlowRulesSource,
+		MoneyFlowRulesVersion:         src.MoneyFlowRulesVersion,
+		Issues:                        issues,
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionType_IsActiveAt(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+
+	assert.True(t, TransactionType{}.IsActiveAt(now))
+	assert.True(t, TransactionType{Status: TransactionTypeStatusActive, EffectiveFrom: &now}.IsActiveAt(now))
+	assert.False(t, TransactionType{EffectiveFrom: &later}.IsActiveAt(now))
+	assert.False(t, TransactionType{Status: TransactionTypeStatusInactive}.IsActiveAt(now))
+}
+
+func TestBuildMasterDataConsistencyReport(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+
+	report := BuildMasterDataConsistencyReport(MasterDataConsistencySources{
+		OrderTypes: []OrderType{
+			{
+				OrderTypeCode: "1001",
+				TransactionTypes: []TransactionType{
+					{TransactionTypeCode: "TUPVA"},
+					{TransactionTypeCode: "NOTRF"},
+					{TransactionTypeCode: "SCHED", EffectiveFrom: &later},
+					{TransactionTypeCode: "OLD", Status: TransactionTypeStatusInactive},
+				},
+			},
+		},
+		TransformerTypes:          []string{"TUPVA", "SCHED", "GHOST"},
+		AcceptedTransactionTypes:  []string{"OLD", "CONF"},
+		MoneyFlowTransactionTypes: []string{"TUPVA", "UNKWN"},
+		MoneyFlowRulesSource:      MoneyFlowRulesSourcePublished,
+		MoneyFlowRulesVersion:     7,
+	}, now)
+
+	assert.Equal(t, 4, report.TotalTransactionTypes)
+	assert.Equal(t, 3, report.TotalTransformers)
+	assert.Equal(t, 2, report.TotalAcceptedTransactionTypes)
+	assert.Equal(t, 2, report.TotalMoneyFlowMappings)
+	assert.Equal(t, int64(7), report.MoneyFlowRulesVersion)
+	assert.Equal(t, []MasterDataConsistencyIssue{
+		{TransactionTypeCode: "CONF", Issue: ConsistencyIssueAcceptedWithoutTransformer},
+		{TransactionTypeCode: "NOTRF", Issue: ConsistencyIssueAcceptedWithoutTransformer},
+		{TransactionTypeCode: "OLD", Issue: ConsistencyIssueAcceptedWithoutTransformer},
+		{TransactionTypeCode: "OLD", Issue: ConsistencyIssueInactiveButAccepted},
+		{TransactionTypeCode: "UNKWN", Issue: ConsistencyIssueMoneyFlowUndefined},
+		{TransactionTypeCode: "GHOST", Issue: ConsistencyIssueTransformerUndefined},
+	}, report.Issues)
+
+	empty := BuildMasterDataConsistencyReport(MasterDataConsistencySources{}, now)
+	assert.NotNil(t, empty.Issues)
+	assert.Empty(t, empty.Issues)
+}
@@ -0,0 +1,200 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const MoneyFlowBusinessRuleKind = "moneyFlowBusinessRule"
+
+// MoneyFlowBusinessRuleStatus is the lifecycle of a business rules version.
+// Only DRAFT version can be edited, publishing a version archives the previously published one.
+type MoneyFlowBusinessRuleStatus string
+
+const (
+	MoneyFlowBusinessRuleStatusDraft     MoneyFlowBusinessRuleStatus = "DRAFT"
+	MoneyFlowBusinessRuleStatusPublished MoneyFlowBusinessRuleStatus = "PUBLISHED"
+	MoneyFlowBusinessRuleStatusArchived  MoneyFlowBusinessRuleStatus = "ARCHIVED"
+)
+
+// MoneyFlowBusinessRule represents one version of money_flow_business_rules table
+type MoneyFlowBusinessRule struct {
+	Version     int64
+	Status      MoneyFlowBusinessRuleStatus
+	Rules       BusinessRulesConfigs
+	Description string
+	CreatedBy   string
+	UpdatedBy   string
+	PublishedBy string
+	PublishedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ActiveRules return the rules stamped with its version
+func (m MoneyFlowBusinessRule) ActiveRules() *BusinessRulesConfigs {
+	rules := m.Rules
+	rules.Version = m.Version
+	return &rules
+}
+
+func (m MoneyFlowBusinessRule) ToModelResponse() DoGetMoneyFlowBusinessRuleResponse {
+	return DoGetMoneyFlowBusinessRuleResponse{
+		Kind:        MoneyFlowBusinessRuleKind,
+		Version:     m.Version,
+		Status:      string(m.Status),
+		Rules:       m.Rules,
+		Description: m.Description,
+		CreatedBy:   m.CreatedBy,
+		UpdatedBy:   m.UpdatedBy,
+		PublishedBy: m.PublishedBy,
+		PublishedAt: m.PublishedAt,
+		CreatedAt:   &m.CreatedAt,
+		UpdatedAt:   &m.UpdatedAt,
+	}
+}
+
+func (c *BusinessRulesConfigs) Scan(src interface{}) error {
+	var raw []byte
+	switch src := src.(type) {
+	case string:
+		raw = []byte(src)
+	case []byte:
+		raw = src
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("type %T not supported by Scan", src)
+	}
+
+	return json.Unmarshal(raw, c)
+}
+
+func (c BusinessRulesConfigs) Value() (value driver.Value, err error) {
+	return json.Marshal(c)
+}
+
+// Validate check the rules before it can be published:
+// every payment config must have complete bank info and
+// every transaction type must be mapped to exactly one existing payment type.
+func (c BusinessRulesConfigs) Validate() error {
+	var errs []error
+
+	if len(c.PaymentConfigs) == 0 {
+		return errors.New("payment_configs is empty")
+	}
+
+	paymentTypes := make([]string, 0, len(c.PaymentConfigs))
+	for paymentType := range c.PaymentConfigs {
+		paymentTypes = append(paymentTypes, paymentType)
+	}
+	sort.Strings(paymentTypes)
+
+	owner := make(map[string]string, len(paymentTypes))
+	for _, paymentType := range paymentTypes {
+		cfg := c.PaymentConfigs[paymentType]
+		prefix := fmt.Sprintf("payment_configs.%s", paymentType)
+
+		if cfg.TransactionType == "" {
+			errs = append(errs, fmt.Errorf("%s.transaction_type is required", prefix))
+		} else if other, exists := owner[cfg.TransactionType]; exists {
+			errs = append(errs, fmt.Errorf("%s.transaction_type %s is already used by %s", prefix, cfg.TransactionType, other))
+		} else {
+			owner[cfg.TransactionType] = paymentType
+		}
+
+		if cfg.RequestToPAPA.Description == "" {
+			errs = append(errs, fmt.Errorf("%s.request_to_papa.description is required", prefix))
+		}
+
+		errs = append(errs, cfg.Source.validate(prefix+".source")...)
+		errs = append(errs, cfg.Destination.validate(prefix+".destination")...)
+	}
+
+	transactionTypes := make([]string, 0, len(c.TransactionToPaymentMap))
+	for transactionType := range c.TransactionToPaymentMap {
+		transactionTypes = append(transactionTypes, transactionType)
+	}
+	sort.Strings(transactionTypes)
+
+	for _, transactionType := range transactionTypes {
+		paymentType := c.TransactionToPaymentMap[transactionType]
+		if _, exists := c.PaymentConfigs[paymentType]; !exists {
+			errs = append(errs, fmt.Errorf("transaction_to_payment_map.%s refers to unknown payment type %s", transactionType, paymentType))
+			continue
+		}
+
+		if other, exists := owner[transactionType]; exists && other != paymentType {
+			errs = append(errs, fmt.Errorf("transaction_to_payment_map.%s must map to %s", transactionType, other))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (b BankInfo) validate(prefix string) []error {
+	var errs []error
+
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"account_number", b.AccountNumber},
+		{"bank_code", b.BankCode},
+		{"bank_name", b.BankName},
+		{"bank_account_number", b.BankAccountNumber},
+		{"bank_account_name", b.BankAccountName},
+	}
+	for _, field := range fields {
+		if field.value == "" {
+			errs = append(errs, fmt.Errorf("%s.%s is required", prefix, field.name))
+		}
+	}
+
+	return errs
+}
+
+// MoneyFlowBusinessRuleFilterOptions represents filter options for database query
+type MoneyFlowBusinessRuleFilterOptions struct {
+	Status MoneyFlowBusinessRuleStatus
+}
+
+type DoGetListMoneyFlowBusinessRuleRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=DRAFT PUBLISHED ARCHIVED" example:"PUBLISHED"`
+}
+
+type CreateMoneyFlowBusinessRuleRequest struct {
+	Rules       BusinessRulesConfigs `json:"rules"`
+	Description string               `json:"description" example:"add MF_EARN_DIVEST bank info"`
+	Actor       string               `json:"actor" validate:"required" example:"finance.ops"`
+}
+
+type UpdateMoneyFlowBusinessRuleRequest struct {
+	Version     int64                `param:"version" json:"-"`
+	Rules       BusinessRulesConfigs `json:"rules"`
+	Description string               `json:"description" example:"fix destination bank name"`
+	Actor       string               `json:"actor" validate:"required" example:"finance.ops"`
+}
+
+type PublishMoneyFlowBusinessRuleRequest struct {
+	Version int64  `param:"version" json:"-"`
+	Actor   string `json:"actor" validate:"required" example:"finance.lead"`
+}
+
+type DoGetMoneyFlowBusinessRuleResponse struct {
+	Kind        string               `json:"kind" example:"moneyFlowBusinessRule"`
+	Version     int64                `json:"version" example:"3"`
+	Status      string               `json:"status" example:"PUBLISHED"`
+	Rules       BusinessRulesConfigs `json:"rules"`
+	Description string               `json:"description" example:"add MF_EARN_DIVEST bank info"`
+	CreatedBy   string               `json:"createdBy" example:"finance.ops"`
+	UpdatedBy   string               `json:"updatedBy" example:"finance.ops"`
+	PublishedBy string               `json:"publishedBy" example:"finance.lead"`
+	PublishedAt *time.Time           `json:"publishedAt"`
+	CreatedAt   *time.Time           `json:"createdAt"`
+	UpdatedAt   *time.Time           `json:"updatedAt"`
+}
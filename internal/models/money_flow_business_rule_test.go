@@ -0,0 +1,112 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestBusinessRulesConfigs() BusinessRulesConfigs {
+	bank := BankInfo{
+		AccountNumber:     "21100100000001",
+		BankCode:          "014",
+		BankName:          "BCA",
+		BankAccountNumber: "1234567890",
+		BankAccountName:   "PT Amartha",
+	}
+
+	return BusinessRulesConfigs{
+		PaymentConfigs: map[string]BusinessRuleConfig{
+			"MF_EARN_DIVEST": {
+				TransactionType: "DVEST",
+				RequestToPAPA:   RequestToPAPA{Description: "earn divest"},
+				Source:          bank,
+				Destination:     bank,
+			},
+			"MF_EARN_INVEST": {
+				TransactionType: "INVST",
+				RequestToPAPA:   RequestToPAPA{Description: "earn invest"},
+				Source:          bank,
+				Destination:     bank,
+			},
+		},
+		TransactionToPaymentMap: map[string]string{
+			"DVEST": "MF_EARN_DIVEST",
+			"INVST": "MF_EARN_INVEST",
+		},
+	}
+}
+
+func TestBusinessRulesConfigs_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *BusinessRulesConfigs)
+		wantErr []string
+	}{
+		{
+			name:   "valid rules",
+			modify: func(c *BusinessRulesConfigs) {},
+		},
+		{
+			name: "empty payment configs",
+			modify: func(c *BusinessRulesConfigs) {
+				c.PaymentConfigs = nil
+			},
+			wantErr: []string{"payment_configs is empty"},
+		},
+		{
+			name: "incomplete bank info",
+			modify: func(c *BusinessRulesConfigs) {
+				cfg := c.PaymentConfigs["MF_EARN_DIVEST"]
+				cfg.Source.BankCode = ""
+				cfg.Destination.BankAccountName = ""
+				c.PaymentConfigs["MF_EARN_DIVEST"] = cfg
+			},
+			wantErr: []string{
+				"payment_configs.MF_EARN_DIVEST.source.bank_code is required",
+				"payment_configs.MF_EARN_DIVEST.destination.bank_account_name is required",
+			},
+		},
+		{
+			name: "transaction type used by two payment types",
+			modify: func(c *BusinessRulesConfigs) {
+				cfg := c.PaymentConfigs["MF_EARN_INVEST"]
+				cfg.TransactionType = "DVEST"
+				c.PaymentConfigs["MF_EARN_INVEST"] = cfg
+				delete(c.TransactionToPaymentMap, "INVST")
+			},
+			wantErr: []string{"payment_configs.MF_EARN_INVEST.transaction_type DVEST is already used by MF_EARN_DIVEST"},
+		},
+		{
+			name: "mapping to other payment type",
+			modify: func(c *BusinessRulesConfigs) {
+				c.TransactionToPaymentMap["DVEST"] = "MF_EARN_INVEST"
+			},
+			wantErr: []string{"transaction_to_payment_map.DVEST must map to MF_EARN_DIVEST"},
+		},
+		{
+			name: "mapping to unknown payment type",
+			modify: func(c *BusinessRulesConfigs) {
+				c.TransactionToPaymentMap["RPYMT"] = "MF_UNKNOWN"
+			},
+			wantErr: []string{"transaction_to_payment_map.RPYMT refers to unknown payment type MF_UNKNOWN"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestBusinessRulesConfigs()
+			tt.modify(&c)
+
+			err := c.Validate()
+			if len(tt.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.Error(t, err)
+			for _, want := range tt.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
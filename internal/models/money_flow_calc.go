@@ -38,6 +38,7 @@ type MoneyFlowSummary struct {
 	UpdatedAt                        time.Time       `db:"updated_at"`
 	RelatedFailedOrRejectedSummaryID *string         `db:"related_failed_or_rejected_summary_id"`
 	IsActive                         bool            `db:"is_active"`
+	BusinessRuleVersion              int64           `db:"business_rule_version"`
 }
 
 // DetailedMoneyFlowSummary represents the detailed_money_flow_summaries table
@@ -71,6 +72,7 @@ type CreateMoneyFlowSummary struct {
 	DestinationBankAccountName       string
 	DestinationBankName              string
 	RelatedFailedOrRejectedSummaryID *string
+	BusinessRuleVersion              int64
 	CreatedAt                        time.Time
 }
 
@@ -108,6 +110,9 @@ type MoneyFlowSummaryUpdate struct {
 type BusinessRulesConfigs struct {
 	PaymentConfigs          map[string]BusinessRuleConfig `json:"payment_configs"`
 	TransactionToPaymentMap map[string]string             `json:"transaction_to_payment_map"`
+
+	// Version of published business rules, 0 means rules are loaded from feature flag
+	Version int64 `json:"-"`
 }
 
 type BusinessRuleConfig struct {
@@ -115,6 +120,9 @@ type BusinessRuleConfig struct {
 	RequestToPAPA   RequestToPAPA `json:"request_to_papa"`
 	Source          BankInfo      `json:"source"`
 	Destination     BankInfo      `json:"destination"`
+
+	// Version of business rules which the config belongs to
+	Version int64 `json:"-"`
 }
 
 type RequestToPAPA struct {
@@ -242,6 +250,7 @@ type MoneyFlowSummaryBySummaryIDOut struct {
 	DestinationBankAccountNumber string          `json:"destinationBankAccountNumber"`
 	DestinationBankAccountName   string          `json:"destinationBankAccountName"`
 	DestinationBankName          string          `json:"destinationBankName"`
+	BusinessRuleVersion          int64           `json:"businessRuleVersion"`
 }
 
 type MoneyFlowSummaryDetailBySummaryIDOut struct {
@@ -264,6 +273,7 @@ type MoneyFlowSummaryDetailBySummaryIDOut struct {
 	DestinationBankName              string          `json:"destinationBankName"`
 	RelatedFailedOrRejectedSummaryID *string         `json:"relatedFailedOrRejectedSummaryId"`
 	RelatedTotalTransfer             decimal.Decimal `json:"relatedTotalTransfer"`
+	BusinessRuleVersion              int64           `json:"businessRuleVersion"`
 }
 
 func (m MoneyFlowSummaryDetailBySummaryIDOut) ToModelResponse() MoneyFlowSummaryBySummaryIDOut {
@@ -291,6 +301,7 @@ func (m MoneyFlowSummaryDetailBySummaryIDOut) ToModelResponse() MoneyFlowSummary
 		DestinationBankAccountNumber: m.DestinationBankAccountNumber,
 		DestinationBankAccountName:   m.DestinationBankAccountName,
 		DestinationBankName:          m.DestinationBankName,
+		BusinessRuleVersion:          m.BusinessRuleVersion,
 	}
 }
 
@@ -447,6 +458,8 @@ type UpdateMoneyFlowSummaryRequest struct {
 	MoneyFlowStatus   *string `json:"moneyFlowStatus,omitempty" example:"COMPLETED"`
 	//RequestedDate     *string `json:"requestedDate,omitempty" example:"2025-10-21T10:00:00Z"`
 	ActualDate *string `json:"actualDate,omitempty" example:"2025-10-21T15:00:00Z"`
+	// Actor is recorded in summary history when status changes
+	Actor string `json:"actor,omitempty" example:"finance.ops"`
 }
 
 // DoUpdateSummaryRequest represents the path parameter for update
@@ -485,32 +498,32 @@ type DownloadDetailedTransactionsRequest struct {
 	Writer                           io.Writer
 }
 
-// ValidateStatusTransition validates if status transition is allowed
-func (req UpdateMoneyFlowSummaryRequest) ValidateStatusTransition(currentStatus string) error {
-	if req.MoneyFlowStatus == nil {
-		return nil
-	}
-
-	newStatus := *req.MoneyFlowStatus
-
-	// Define allowed transitions
-	allowedTransitions := map[string][]string{
-		constants.MoneyFlowStatusPending: {
-			constants.MoneyFlowStatusInProgress,
-		},
-		constants.MoneyFlowStatusInProgress: {
-			constants.MoneyFlowStatusSuccess,
-			constants.MoneyFlowStatusFailed,
-			constants.MoneyFlowStatusRejected,
-		},
-		constants.MoneyFlowStatusSuccess: {}, // Cannot transition from SUCCESS
-		constants.MoneyFlowStatusFailed: {
-			constants.MoneyFlowStatusInProgress, // allow transition to IN_PROGRESS
-		},
-		constants.MoneyFlowStatusRejected: {}, // Cannot transition from REJECTED
-	}
-
-	allowedStatuses, exists := allowedTransitions[currentStatus]
+// moneyFlowStatusTransitions is the state machine of money flow summary, DISBURSING is only used by automatic disbursement
+var moneyFlowStatusTransitions = map[string][]string{
+	constants.MoneyFlowStatusPending: {
+		constants.MoneyFlowStatusInProgress,
+		constants.MoneyFlowStatusDisbursing,
+	},
+	constants.MoneyFlowStatusDisbursing: {
+		constants.MoneyFlowStatusInProgress,
+		constants.MoneyFlowStatusPending, // transfer request is failed, sent again by next run
+		constants.MoneyFlowStatusFailed,  // transfer request is failed too many times
+	},
+	constants.MoneyFlowStatusInProgress: {
+		constants.MoneyFlowStatusSuccess,
+		constants.MoneyFlowStatusFailed,
+		constants.MoneyFlowStatusRejected,
+	},
+	constants.MoneyFlowStatusSuccess: {}, // Cannot transition from SUCCESS
+	constants.MoneyFlowStatusFailed: {
+		constants.MoneyFlowStatusInProgress, // allow transition to IN_PROGRESS
+	},
+	constants.MoneyFlowStatusRejected: {}, // Cannot transition from REJECTED
+}
+
+// ValidateMoneyFlowStatusTransition validates if summary can be moved from currentStatus to newStatus
+func ValidateMoneyFlowStatusTransition(currentStatus, newStatus string) error {
+	allowedStatuses, exists := moneyFlowStatusTransitions[currentStatus]
 	if !exists {
 		return fmt.Errorf("invalid current status: %s", currentStatus)
 	}
@@ -530,6 +543,15 @@ func (req UpdateMoneyFlowSummaryRequest) ValidateStatusTransition(currentStatus
 	return fmt.Errorf("status transition from %s to %s is not allowed", currentStatus, newStatus)
 }
 
+// ValidateStatusTransition validates if status transition is allowed
+func (req UpdateMoneyFlowSummaryRequest) ValidateStatusTransition(currentStatus string) error {
+	if req.MoneyFlowStatus == nil {
+		return nil
+	}
+
+	return ValidateMoneyFlowStatusTransition(currentStatus, *req.MoneyFlowStatus)
+}
+
 // ValidateInProgressRequirements validates requirements when status is IN_PROGRESS
 func (req UpdateMoneyFlowSummaryRequest) ValidateInProgressRequirements() error {
 	if req.MoneyFlowStatus == nil {
@@ -636,3 +658,26 @@ type UpdateActivationStatusResponse struct {
 	IsActive  bool   `json:"isActive" example:"false"`
 	Message   string `json:"message" example:"Money flow summary status updated successfully"`
 }
+
+// MoneyFlowSummaryForDisbursement represents PENDING summary that is due to be sent to payment API
+type MoneyFlowSummaryForDisbursement struct {
+	ID                            string
+	ReferenceNumber               string
+	TransactionType               string
+	PaymentType                   string
+	TransactionSourceCreationDate time.Time
+	TotalTransfer                 decimal.Decimal
+	RelatedTotalTransfer          decimal.Decimal
+}
+
+// TransferAmount includes amount of related failed or rejected summary, same as total shown in summary detail
+func (m MoneyFlowSummaryForDisbursement) TransferAmount() decimal.Decimal {
+	return m.TotalTransfer.Add(m.RelatedTotalTransfer)
+}
+
+// MoneyFlowDisbursementResult is the outcome of one automatic disbursement run
+type MoneyFlowDisbursementResult struct {
+	Disbursed int
+	Skipped   int
+	Failed    int
+}
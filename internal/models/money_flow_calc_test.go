@@ -0,0 +1,68 @@
+package models
+
+import (
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/constants"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMoneyFlowStatusTransition(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{
+			name: "pending is claimed for disbursement",
+			from: constants.MoneyFlowStatusPending,
+			to:   constants.MoneyFlowStatusDisbursing,
+		},
+		{
+			name: "disbursing summary is reclaimed",
+			from: constants.MoneyFlowStatusDisbursing,
+			to:   constants.MoneyFlowStatusDisbursing,
+		},
+		{
+			name: "disbursing summary is transferred",
+			from: constants.MoneyFlowStatusDisbursing,
+			to:   constants.MoneyFlowStatusInProgress,
+		},
+		{
+			name: "disbursing summary is released",
+			from: constants.MoneyFlowStatusDisbursing,
+			to:   constants.MoneyFlowStatusPending,
+		},
+		{
+			name: "disbursing summary is failed",
+			from: constants.MoneyFlowStatusDisbursing,
+			to:   constants.MoneyFlowStatusFailed,
+		},
+		{
+			name:    "disbursing summary can not be successful before transfer",
+			from:    constants.MoneyFlowStatusDisbursing,
+			to:      constants.MoneyFlowStatusSuccess,
+			wantErr: true,
+		},
+		{
+			name:    "successful summary can not be disbursed",
+			from:    constants.MoneyFlowStatusSuccess,
+			to:      constants.MoneyFlowStatusDisbursing,
+			wantErr: true,
+		},
+		{
+			name:    "unknown status",
+			from:    "UNKNOWN",
+			to:      constants.MoneyFlowStatusPending,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMoneyFlowStatusTransition(tt.from, tt.to)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/constants"
+)
+
+// CreateMoneyFlowSummaryEvent represents a status transition to be stored in money_flow_summary_events
+type CreateMoneyFlowSummaryEvent struct {
+	SummaryID         string
+	FromStatus        string
+	ToStatus          string
+	Source            string
+	Actor             string
+	PapaTransactionID string
+	PapaEventPayload  json.RawMessage
+	EventTime         time.Time
+}
+
+// MoneyFlowSummaryEvent represents the money_flow_summary_events table
+type MoneyFlowSummaryEvent struct {
+	ID                int64           `db:"id"`
+	SummaryID         string          `db:"summary_id"`
+	FromStatus        string          `db:"from_status"`
+	ToStatus          string          `db:"to_status"`
+	Source            string          `db:"source"`
+	Actor             string          `db:"actor"`
+	PapaTransactionID string          `db:"papa_transaction_id"`
+	PapaEventPayload  json.RawMessage `db:"papa_event_payload"`
+	EventTime         time.Time       `db:"event_time"`
+	CreatedAt         time.Time       `db:"created_at"`
+}
+
+func (e MoneyFlowSummaryEvent) ToModelResponse() MoneyFlowSummaryEventResponse {
+	return MoneyFlowSummaryEventResponse{
+		Kind:              constants.MoneyFlowKind,
+		ID:                e.ID,
+		SummaryID:         e.SummaryID,
+		FromStatus:        e.FromStatus,
+		ToStatus:          e.ToStatus,
+		Source:            e.Source,
+		Actor:             e.Actor,
+		PapaTransactionID: e.PapaTransactionID,
+		PapaEventPayload:  e.PapaEventPayload,
+		EventTime:         e.EventTime,
+		CreatedAt:         e.CreatedAt,
+	}
+}
+
+// PapaTransactionEvent carries PAPA stream event metadata needed to audit the transition
+type PapaTransactionEvent struct {
+	Payload   json.RawMessage
+	Timestamp time.Time
+}
+
+// MoneyFlowSummaryEventResponse represents one entry of money flow summary history
+type MoneyFlowSummaryEventResponse struct {
+	Kind              string          `json:"kind" example:"moneyFlowCalc"`
+	ID                int64           `json:"id" example:"1"`
+	SummaryID         string          `json:"summaryId" example:"bbc15647-0e2e-4f3a-9b2b-a4a918d3f34b"`
+	FromStatus        string          `json:"fromStatus" example:"IN_PROGRESS"`
+	ToStatus          string          `json:"toStatus" example:"SUCCESSFUL"`
+	Source            string          `json:"source" example:"PAPA_STREAM"`
+	Actor             string          `json:"actor" example:"finance.ops"`
+	PapaTransactionID string          `json:"papaTransactionId" example:"PAPA-123456"`
+	PapaEventPayload  json.RawMessage `json:"papaEventPayload,omitempty" swaggertype:"object"`
+	EventTime         time.Time       `json:"eventTime" example:"2025-10-21T15:00:00Z"`
+	CreatedAt         time.Time       `json:"createdAt" example:"2025-10-21T15:00:01Z"`
+}
+
+// papaEventTime is the time written by PAPA in the event payload
+type papaEventTime struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewPapaTransactionEvent take the event time from the payload itself, so the order of events does not depend on
+// when they are produced to kafka. Time of the last change is used and creation time is only used when it is not set
+func NewPapaTransactionEvent(payload json.RawMessage) (PapaTransactionEvent, error) {
+	var eventTime papaEventTime
+	if err := json.Unmarshal(payload, &eventTime); err != nil {
+		return PapaTransactionEvent{}, err
+	}
+
+	timestamp := eventTime.UpdatedAt
+	if timestamp.IsZero() {
+		timestamp = eventTime.CreatedAt
+	}
+	if timestamp.IsZero() {
+		return PapaTransactionEvent{}, errors.New("papa event has no timestamp")
+	}
+
+	return PapaTransactionEvent{
+		Payload:   payload,
+		Timestamp: timestamp,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPapaTransactionEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:    "use time of last change",
+			payload: `{"id":"PAPA-123","created_at":"2025-10-21T15:00:00Z","updated_at":"2025-10-21T15:05:00Z"}`,
+			want:    time.Date(2025, 10, 21, 15, 5, 0, 0, time.UTC),
+		},
+		{
+			name:    "fallback to creation time",
+			payload: `{"id":"PAPA-123","created_at":"2025-10-21T15:00:00Z"}`,
+			want:    time.Date(2025, 10, 21, 15, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "failed without timestamp",
+			payload: `{"id":"PAPA-123"}`,
+			wantErr: true,
+		},
+		{
+			name:    "failed invalid payload",
+			payload: `{"id":`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewPapaTransactionEvent(json.RawMessage(tt.payload))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(got.Timestamp))
+			assert.JSONEq(t, tt.payload, string(got.Payload))
+		})
+	}
+}
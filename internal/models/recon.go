@@ -62,6 +62,7 @@ type ReconToolHistory struct {
 	ResultFilePath   string
 	UploadedFilePath string
 	Status           string
+	Summary          ReconSummary
 	CreatedAt        *time.Time
 	UpdatedAt        *time.Time
 }
@@ -81,6 +82,7 @@ func (rth ReconToolHistory) ToModelResponse() DoGetReconToolHistoryResponse {
 		ResultFilePath:   rth.ResultFilePath,
 		UploadedFilePath: rth.UploadedFilePath,
 		Status:           rth.Status,
+		Summary:          rth.Summary,
 		ReconDate:        rth.CreatedAt.In(common.GetLocation()).Format(common.DateFormatYYYYMMDDWithTime),
 		CreatedAt:        rth.CreatedAt.In(common.GetLocation()).Format(common.DateFormatYYYYMMDDWithTime),
 		UpdatedAt:        rth.UpdatedAt.In(common.GetLocation()).Format(common.DateFormatYYYYMMDDWithTime),
@@ -124,17 +126,18 @@ type DoGetListReconToolHistoryRequest struct {
 }
 
 type DoGetReconToolHistoryResponse struct {
-	Kind             string `json:"kind" example:"reconTool"`
-	ID               string `json:"id" example:"1"`
-	OrderType        string `json:"orderType" example:"TOPUP"`
-	TransactionType  string `json:"transactionType" example:"TOPUP"`
-	TransactionDate  string `json:"transactionDate" example:"2023-10-25 08:08:26"`
-	ResultFilePath   string `json:"resultFilePath" example:"/tmp/result.csv"`
-	UploadedFilePath string `json:"uploadedFilePath" example:"/tmp/uploaded.csv"`
-	Status           string `json:"status" example:"active"`
-	ReconDate        string `json:"reconDate" example:"2023-10-25 08:08:26"`
-	CreatedAt        string `json:"createdAt" example:"2006-01-02 15:04:05"`
-	UpdatedAt        string `json:"updatedAt" example:"2006-01-02 15:04:05"`
+	Kind             string       `json:"kind" example:"reconTool"`
+	ID               string       `json:"id" example:"1"`
+	OrderType        string       `json:"orderType" example:"TOPUP"`
+	TransactionType  string       `json:"transactionType" example:"TOPUP"`
+	TransactionDate  string       `json:"transactionDate" example:"2023-10-25 08:08:26"`
+	ResultFilePath   string       `json:"resultFilePath" example:"/tmp/result.csv"`
+	UploadedFilePath string       `json:"uploadedFilePath" example:"/tmp/uploaded.csv"`
+	Status           string       `json:"status" example:"active"`
+	Summary          ReconSummary `json:"summary,omitempty"`
+	ReconDate        string       `json:"reconDate" example:"2023-10-25 08:08:26"`
+	CreatedAt        string       `json:"createdAt" example:"2006-01-02 15:04:05"`
+	UpdatedAt        string       `json:"updatedAt" example:"2006-01-02 15:04:05"`
 }
 
 func (req DoGetListReconToolHistoryRequest) ToFilterOpts() (*ReconToolHistoryFilterOptions, error) {
@@ -230,6 +233,8 @@ var CSVHeaderReconRecord = []string{
 	"reconDate",
 	"match",
 	"status",
+	"matchRule",
+	"matchConfidence",
 }
 
 const (
@@ -237,6 +242,7 @@ const (
 	StatusReconRecordNotExistsDBExistsCSV
 	StatusReconRecordExistsDBNotExistsCSV
 	StatusReconRecordMatch
+	StatusReconRecordMatchWithTolerance
 )
 
 func (s StatusReconRecord) Title() string {
@@ -249,6 +255,8 @@ func (s StatusReconRecord) Title() string {
 		return "Exists in DB, Not Exists in CSV"
 	case StatusReconRecordMatch:
 		return "Match"
+	case StatusReconRecordMatchWithTolerance:
+		return "Match With Tolerance"
 	default:
 		return "Unknown"
 	}
@@ -262,6 +270,10 @@ type ReconRecord struct {
 	LenderID     string
 	Match        bool
 
+	// MatchRule and MatchConfidence explain how the record is matched
+	MatchRule       ReconMatchRule
+	MatchConfidence int
+
 	// PaymentDate is the date of the transaction in DD-MMM-YYYY format
 	PaymentDate string
 
@@ -269,6 +281,11 @@ type ReconRecord struct {
 }
 
 func (rr ReconRecord) ToCSVRow(rth ReconToolHistory) []string {
+	matchConfidence := ""
+	if rr.Match {
+		matchConfidence = fmt.Sprint(rr.MatchConfidence)
+	}
+
 	return []string{
 		rr.Identifier,
 		rr.Amount.String(),
@@ -281,6 +298,20 @@ func (rr ReconRecord) ToCSVRow(rth ReconToolHistory) []string {
 		rth.CreatedAt.In(common.GetLocation()).Format(common.DateFormatYYYYMMDDWithTime),
 		fmt.Sprint(rr.Match),
 		rr.Status.Title(),
+		string(rr.MatchRule),
+		matchConfidence,
+	}
+}
+
+// SetMatch mark record as matched using result of matching rule
+func (rr *ReconRecord) SetMatch(result ReconMatchResult, refNumber string) {
+	rr.Match = true
+	rr.RefNumber = refNumber
+	rr.MatchRule = result.Rule
+	rr.MatchConfidence = result.Confidence()
+	rr.Status = StatusReconRecordMatch
+	if result.Rule != ReconMatchRuleExact || result.BySecondaryKey {
+		rr.Status = StatusReconRecordMatchWithTolerance
 	}
 }
 
@@ -297,5 +328,7 @@ func (rr ReconRecord) ToCSVRowWithErr(rth ReconToolHistory, err error) []string
 		rth.CreatedAt.In(common.GetLocation()).Format(common.DateFormatYYYYMMDDWithTime),
 		"-",
 		err.Error(),
+		"-",
+		"-",
 	}
 }
@@ -0,0 +1,362 @@
+package models
+
+import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	kindReconException         = "reconException"
+	kindReconExceptionActivity = "reconExceptionActivity"
+
+	// ReconExceptionAdjustmentRefPrefix is the prefix of ref number for adjustment transaction,
+	// ref number is derived from exception id so resolving the same exception is idempotent
+	ReconExceptionAdjustmentRefPrefix = "RECONEXC"
+)
+
+// ReconExceptionState is the lifecycle state of recon exception
+type ReconExceptionState string
+
+const (
+	ReconExceptionStateOpen          ReconExceptionState = "OPEN"
+	ReconExceptionStateInvestigating ReconExceptionState = "INVESTIGATING"
+	ReconExceptionStateResolved      ReconExceptionState = "RESOLVED"
+	ReconExceptionStateWrittenOff    ReconExceptionState = "WRITTEN_OFF"
+)
+
+var reconExceptionTransitions = map[ReconExceptionState][]ReconExceptionState{
+	ReconExceptionStateOpen: {
+		ReconExceptionStateInvestigating,
+		ReconExceptionStateResolved,
+		ReconExceptionStateWrittenOff,
+	},
+	ReconExceptionStateInvestigating: {
+		ReconExceptionStateResolved,
+		ReconExceptionStateWrittenOff,
+	},
+}
+
+// IsClosed return true if no more action can be done to exception
+func (s ReconExceptionState) IsClosed() bool {
+	return s == ReconExceptionStateResolved || s == ReconExceptionStateWrittenOff
+}
+
+// CanTransitionTo check whether exception can move from current state to next state
+func (s ReconExceptionState) CanTransitionTo(next ReconExceptionState) bool {
+	return slices.Contains(reconExceptionTransitions[s], next)
+}
+
+// ReconExceptionAction is the action recorded in exception activity as audit trail
+type ReconExceptionAction string
+
+const (
+	ReconExceptionActionAssign   ReconExceptionAction = "ASSIGN"
+	ReconExceptionActionComment  ReconExceptionAction = "COMMENT"
+	ReconExceptionActionResolve  ReconExceptionAction = "RESOLVE"
+	ReconExceptionActionWriteOff ReconExceptionAction = "WRITE_OFF"
+)
+
+// IsReconExceptionStatus return true if recon record status need to be followed up as exception
+func IsReconExceptionStatus(status StatusReconRecord) bool {
+	return status == StatusReconRecordNotExistsDBExistsCSV ||
+		status == StatusReconRecordExistsDBNotExistsCSV
+}
+
+type ReconException struct {
+	ID              uint64
+	ReconHistoryID  uint64
+	Identifier      string
+	RefNumber       string
+	Amount          decimal.Decimal
+	PaymentDate     string
+	OrderType       string
+	TransactionType string
+	ReconStatus     string
+	State           ReconExceptionState
+	Assignee        string
+	ResolutionNote  string
+
+	// AdjustmentTransactionID is the wallet transaction created when exception is resolved with adjustment
+	AdjustmentTransactionID string
+
+	ResolvedBy string
+	ResolvedAt *time.Time
+	CreatedAt  *time.Time
+	UpdatedAt  *time.Time
+
+	Activities []ReconExceptionActivity
+}
+
+// NewReconException create open exception from unmatched recon record
+func NewReconException(rth ReconToolHistory, rr ReconRecord) ReconException {
+	return ReconException{
+		ReconHistoryID:  uint64(rth.ID),
+		Identifier:      rr.Identifier,
+		RefNumber:       rr.RefNumber,
+		Amount:          rr.Amount,
+		PaymentDate:     rr.PaymentDate,
+		OrderType:       rth.OrderType,
+		TransactionType: rth.TransactionType,
+		ReconStatus:     rr.Status.Title(),
+		State:           ReconExceptionStateOpen,
+	}
+}
+
+// AdjustmentRefNumber return ref number of adjustment transaction for the exception
+func (e ReconException) AdjustmentRefNumber() string {
+	return fmt.Sprintf("%s%d", ReconExceptionAdjustmentRefPrefix, e.ID)
+}
+
+// MatchKey identify the unmatched record of exception, it is used to keep exception across recon reprocess
+func (e ReconException) MatchKey() string {
+	return strings.Join([]string{e.Identifier, e.RefNumber, e.Amount.String(), e.PaymentDate, e.ReconStatus}, "|")
+}
+
+func (e ReconException) GetCursor() string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(e.ID)))
+}
+
+func (e ReconException) ToModelResponse() DoGetReconExceptionResponse {
+	res := DoGetReconExceptionResponse{
+		Kind:                    kindReconException,
+		ID:                      fmt.Sprint(e.ID),
+		ReconHistoryID:          fmt.Sprint(e.ReconHistoryID),
+		Identifier:              e.Identifier,
+		RefNumber:               e.RefNumber,
+		Amount:                  e.Amount.String(),
+		PaymentDate:             e.PaymentDate,
+		OrderType:               e.OrderType,
+		TransactionType:         e.TransactionType,
+		ReconStatus:             e.ReconStatus,
+		State:                   string(e.State),
+		Assignee:                e.Assignee,
+		ResolutionNote:          e.ResolutionNote,
+		AdjustmentTransactionID: e.AdjustmentTransactionID,
+		ResolvedBy:              e.ResolvedBy,
+		CreatedAt:               formatReconExceptionTime(e.CreatedAt),
+		UpdatedAt:               formatReconExceptionTime(e.UpdatedAt),
+		ResolvedAt:              formatReconExceptionTime(e.ResolvedAt),
+	}
+
+	for _, activity := range e.Activities {
+		res.Activities = append(res.Activities, activity.ToModelResponse())
+	}
+
+	return res
+}
+
+type ReconExceptionActivity struct {
+	ID               uint64
+	ReconExceptionID uint64
+	Action           ReconExceptionAction
+	Actor            string
+	FromState        ReconExceptionState
+	ToState          ReconExceptionState
+	Comment          string
+	CreatedAt        *time.Time
+}
+
+func (a ReconExceptionActivity) ToModelResponse() DoGetReconExceptionActivityResponse {
+	return DoGetReconExceptionActivityResponse{
+		Kind:      kindReconExceptionActivity,
+		Action:    string(a.Action),
+		Actor:     a.Actor,
+		FromState: string(a.FromState),
+		ToState:   string(a.ToState),
+		Comment:   a.Comment,
+		CreatedAt: formatReconExceptionTime(a.CreatedAt),
+	}
+}
+
+func formatReconExceptionTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.In(common.GetLocation()).Format(common.DateFormatYYYYMMDDWithTime)
+}
+
+type ReconExceptionFilterOptions struct {
+	ReconHistoryID uint64
+	State          ReconExceptionState
+	Assignee       string
+
+	// Pagination filter
+	Limit          int
+	AscendingOrder bool
+	AfterID        uint64
+	BeforeID       uint64
+}
+
+type DoGetListReconExceptionRequest struct {
+	ReconHistoryID string `query:"reconHistoryId" example:"1"`
+	State          string `query:"state" example:"OPEN"`
+	Assignee       string `query:"assignee" example:"finance.ops"`
+	Limit          int    `query:"limit" example:"10"`
+	NextCursor     string `query:"nextCursor" example:"abc"`
+	PrevCursor     string `query:"prevCursor" example:"cba"`
+}
+
+func (req DoGetListReconExceptionRequest) ToFilterOpts() (*ReconExceptionFilterOptions, error) {
+	opts := &ReconExceptionFilterOptions{
+		State:    ReconExceptionState(req.State),
+		Assignee: req.Assignee,
+		Limit:    req.Limit,
+	}
+
+	if req.Limit < 0 {
+		return nil, GetErrMap(ErrKeyLimitMustBeGreaterThanZero)
+	}
+
+	if req.ReconHistoryID != "" {
+		id, err := strconv.ParseUint(req.ReconHistoryID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reconHistoryId: %w", err)
+		}
+		opts.ReconHistoryID = id
+	}
+
+	if req.Limit == 0 {
+		// default limit
+		opts.Limit = 10
+	}
+
+	// use over-fetch limit for check next page exists or not
+	opts.Limit += 1
+
+	// forward pagination
+	if req.NextCursor != "" {
+		afterID, err := decodeReconExceptionCursor(req.NextCursor)
+		if err != nil {
+			return nil, err
+		}
+		opts.AfterID = afterID
+	}
+
+	// backward pagination
+	if req.NextCursor == "" && req.PrevCursor != "" {
+		beforeID, err := decodeReconExceptionCursor(req.PrevCursor)
+		if err != nil {
+			return nil, err
+		}
+		opts.BeforeID = beforeID
+
+		// reverse order
+		opts.AscendingOrder = true
+	}
+
+	return opts, nil
+}
+
+func decodeReconExceptionCursor(cursor string) (uint64, error) {
+	decodedBytes, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse offset string: %w", err)
+	}
+
+	id, err := strconv.ParseUint(string(decodedBytes), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse offset id: %w", err)
+	}
+
+	return id, nil
+}
+
+type AssignReconExceptionRequest struct {
+	ID       uint64 `param:"id" json:"-"`
+	Assignee string `json:"assignee" validate:"required" example:"finance.ops"`
+	Actor    string `json:"actor" validate:"required" example:"finance.lead"`
+}
+
+type CommentReconExceptionRequest struct {
+	ID      uint64 `param:"id" json:"-"`
+	Comment string `json:"comment" validate:"required" example:"bank statement requested"`
+	Actor   string `json:"actor" validate:"required" example:"finance.ops"`
+}
+
+type ResolveReconExceptionRequest struct {
+	ID         uint64                           `param:"id" json:"-"`
+	State      string                           `json:"state" validate:"required,oneof=RESOLVED WRITTEN_OFF" example:"RESOLVED"`
+	Note       string                           `json:"note" validate:"required" example:"settled by adjustment"`
+	Actor      string                           `json:"actor" validate:"required" example:"finance.ops"`
+	Adjustment *ReconExceptionAdjustmentRequest `json:"adjustment"`
+
+	// internal use
+	ClientId string `json:"-"`
+}
+
+// ReconExceptionAdjustmentRequest is the wallet transaction created to close the break
+type ReconExceptionAdjustmentRequest struct {
+	AccountNumber            string          `json:"accountNumber" validate:"required"`
+	TransactionType          string          `json:"transactionType" validate:"required"`
+	TransactionFlow          TransactionFlow `json:"transactionFlow" validate:"required,oneof=cashin cashout transfer refund"`
+	NetAmount                Amount          `json:"netAmount" validate:"required"`
+	DestinationAccountNumber string          `json:"destinationAccountNumber"`
+	Description              string          `json:"description"`
+}
+
+// ToCreateWalletTransactionRequest build wallet transaction request linked to the exception
+func (req ReconExceptionAdjustmentRequest) ToCreateWalletTransactionRequest(e ReconException, clientId string, now time.Time) CreateWalletTransactionRequest {
+	description := req.Description
+	if description == "" {
+		description = fmt.Sprintf("recon exception %d adjustment", e.ID)
+	}
+
+	return CreateWalletTransactionRequest{
+		AccountNumber:            req.AccountNumber,
+		RefNumber:                e.AdjustmentRefNumber(),
+		TransactionType:          req.TransactionType,
+		TransactionFlow:          req.TransactionFlow,
+		TransactionTime:          now.Format(common.DateFormatYYYYMMDDWithTimeAndOffset),
+		NetAmount:                req.NetAmount,
+		DestinationAccountNumber: req.DestinationAccountNumber,
+		Description:              description,
+		Metadata: WalletMetadata{
+			"reconExceptionId": fmt.Sprint(e.ID),
+			"reconHistoryId":   fmt.Sprint(e.ReconHistoryID),
+			"identifier":       e.Identifier,
+		},
+		ClientId:       clientId,
+		IdempotencyKey: e.AdjustmentRefNumber(),
+	}
+}
+
+type DoGetReconExceptionResponse struct {
+	Kind                    string                                `json:"kind" example:"reconException"`
+	ID                      string                                `json:"id" example:"1"`
+	ReconHistoryID          string                                `json:"reconHistoryId" example:"1"`
+	Identifier              string                                `json:"identifier" example:"86861101189513"`
+	RefNumber               string                                `json:"refNumber" example:"123456"`
+	Amount                  string                                `json:"amount" example:"100000"`
+	PaymentDate             string                                `json:"paymentDate" example:"01-Jan-2023"`
+	OrderType               string                                `json:"orderType" example:"TOPUP"`
+	TransactionType         string                                `json:"transactionType" example:"TOPUP"`
+	ReconStatus             string                                `json:"reconStatus" example:"Not Exists in DB, Exists in CSV"`
+	State                   string                                `json:"state" example:"OPEN"`
+	Assignee                string                                `json:"assignee" example:"finance.ops"`
+	ResolutionNote          string                                `json:"resolutionNote" example:"settled by adjustment"`
+	AdjustmentTransactionID string                                `json:"adjustmentTransactionId" example:"c9b1b4c4-1c2a-4f4e-9a0e-3c2b1a0e9f7d"`
+	ResolvedBy              string                                `json:"resolvedBy" example:"finance.ops"`
+	ResolvedAt              string                                `json:"resolvedAt" example:"2006-01-02 15:04:05"`
+	CreatedAt               string                                `json:"createdAt" example:"2006-01-02 15:04:05"`
+	UpdatedAt               string                                `json:"updatedAt" example:"2006-01-02 15:04:05"`
+	Activities              []DoGetReconExceptionActivityResponse `json:"activities,omitempty"`
+}
+
+type DoGetReconExceptionActivityResponse struct {
+	Kind      string `json:"kind" example:"reconExceptionActivity"`
+	Action    string `json:"action" example:"COMMENT"`
+	Actor     string `json:"actor" example:"finance.ops"`
+	FromState string `json:"fromState" example:"OPEN"`
+	ToState   string `json:"toState" example:"INVESTIGATING"`
+	Comment   string `json:"comment" example:"bank statement requested"`
+	CreatedAt string `json:"createdAt" example:"2006-01-02 15:04:05"`
+}
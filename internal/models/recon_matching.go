@@ -0,0 +1,352 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+
+	"github.com/shopspring/decimal"
+)
+
+// ReconMatchRule is the rule that produced a match between csv row and transaction
+type ReconMatchRule string
+
+const (
+	ReconMatchRuleExact                  ReconMatchRule = "EXACT"
+	ReconMatchRuleAmountTolerance        ReconMatchRule = "AMOUNT_TOLERANCE"
+	ReconMatchRuleDateWindow             ReconMatchRule = "DATE_WINDOW"
+	ReconMatchRuleAmountToleranceAndDate ReconMatchRule = "AMOUNT_TOLERANCE_DATE_WINDOW"
+	ReconMatchRuleManyToOne              ReconMatchRule = "MANY_TO_ONE"
+	ReconMatchRuleOneToMany              ReconMatchRule = "ONE_TO_MANY"
+)
+
+const (
+	// ReconSecondaryKeyRefNumber match csv identifier with transaction refNumber
+	ReconSecondaryKeyRefNumber = "refNumber"
+
+	// ReconGroupRefNumberSeparator is used to join refNumber of grouped transactions
+	ReconGroupRefNumberSeparator = "|"
+
+	defaultReconMatchingMaxGroupSize = 5
+	maxReconMatchingGroupCandidates  = 20
+
+	// reconSecondaryKeyConfidencePenalty is subtracted from confidence when match found by secondary key
+	reconSecondaryKeyConfidencePenalty = 20
+)
+
+var reconMatchConfidence = map[ReconMatchRule]int{
+	ReconMatchRuleExact:                  100,
+	ReconMatchRuleDateWindow:             90,
+	ReconMatchRuleAmountTolerance:        85,
+	ReconMatchRuleAmountToleranceAndDate: 75,
+	ReconMatchRuleManyToOne:              70,
+	ReconMatchRuleOneToMany:              70,
+}
+
+// Confidence return match confidence in percent, zero means not matched
+func (r ReconMatchRule) Confidence() int {
+	return reconMatchConfidence[r]
+}
+
+// ReconMatchingRule is the rule used by recon engine to match csv row with transactions
+type ReconMatchingRule struct {
+	// AmountToleranceAbsolute is the maximum absolute amount difference, e.g. bank fee
+	AmountToleranceAbsolute decimal.Decimal
+
+	// AmountTolerancePercent is the maximum amount difference in percent of csv amount
+	AmountTolerancePercent decimal.Decimal
+
+	// DateWindowDays is the maximum distance in days between csv payment date and transaction date
+	DateWindowDays int
+
+	// EnableManyToOne allow one csv row to settle several transactions by sum
+	EnableManyToOne bool
+
+	// EnableOneToMany allow several csv rows to settle one transaction by sum
+	EnableOneToMany bool
+
+	// MaxGroupSize is the maximum number of records that can be grouped
+	MaxGroupSize int
+
+	// SecondaryKey is the fallback key when csv identifier not found, only "refNumber" supported
+	SecondaryKey string
+}
+
+// ReconMatchResult is the result of matching a record with candidates
+type ReconMatchResult struct {
+	// Indexes is the index of matched candidates
+	Indexes []int
+	Rule    ReconMatchRule
+
+	// BySecondaryKey is true when candidates found using secondary key
+	BySecondaryKey bool
+}
+
+// Matched return true if there is matched candidates
+func (r ReconMatchResult) Matched() bool {
+	return len(r.Indexes) > 0
+}
+
+// Confidence return the match confidence of the result
+func (r ReconMatchResult) Confidence() int {
+	confidence := r.Rule.Confidence()
+	if r.BySecondaryKey {
+		confidence -= reconSecondaryKeyConfidencePenalty
+	}
+
+	return max(confidence, 0)
+}
+
+// UseSecondaryKey return true if secondary key lookup is enabled
+func (r ReconMatchingRule) UseSecondaryKey() bool {
+	return r.SecondaryKey == ReconSecondaryKeyRefNumber
+}
+
+// UseDateWindow return true if recon need to load transaction outside recon date
+func (r ReconMatchingRule) UseDateWindow() bool {
+	return r.DateWindowDays > 0
+}
+
+// DateRange return the range of transaction date that will be loaded for recon date
+func (r ReconMatchingRule) DateRange(date time.Time) (start, end time.Time) {
+	return date.AddDate(0, 0, -r.DateWindowDays), date.AddDate(0, 0, r.DateWindowDays)
+}
+
+// AmountTolerance return the maximum accepted difference for expected amount,
+// the bigger one between absolute and percent tolerance is used
+func (r ReconMatchingRule) AmountTolerance(expected decimal.Decimal) decimal.Decimal {
+	tolerance := r.AmountToleranceAbsolute
+	if r.AmountTolerancePercent.IsPositive() {
+		percentTolerance := expected.Abs().Mul(r.AmountTolerancePercent).Div(decimal.NewFromInt(100))
+		tolerance = decimal.Max(tolerance, percentTolerance)
+	}
+
+	return tolerance
+}
+
+// AmountWithinTolerance check whether actual amount is still acceptable for expected amount
+func (r ReconMatchingRule) AmountWithinTolerance(expected, actual decimal.Decimal) bool {
+	return expected.Sub(actual).Abs().LessThanOrEqual(r.AmountTolerance(expected))
+}
+
+// DateDistance return absolute distance in days between two payment date with DD-MMM-YYYY format
+func (r ReconMatchingRule) DateDistance(a, b string) (int, bool) {
+	if a == b {
+		return 0, true
+	}
+
+	dateA, err := time.Parse(common.DateFormatDDMMMYYYY, a)
+	if err != nil {
+		return 0, false
+	}
+
+	dateB, err := time.Parse(common.DateFormatDDMMMYYYY, b)
+	if err != nil {
+		return 0, false
+	}
+
+	days := int(dateA.Sub(dateB).Hours() / 24)
+	if days < 0 {
+		days = -days
+	}
+
+	return days, true
+}
+
+// DateWithinWindow check whether two payment date is still in configured date window
+func (r ReconMatchingRule) DateWithinWindow(a, b string) bool {
+	days, ok := r.DateDistance(a, b)
+	return ok && days <= r.DateWindowDays
+}
+
+// FindMatch find the best single candidate for record.
+// Exact amount on the same date always win, otherwise the closest amount then closest date is used.
+func (r ReconMatchingRule) FindMatch(record ReconRecord, candidates []ReconRecord) ReconMatchResult {
+	bestIdx := -1
+	var bestAmountDiff decimal.Decimal
+	var bestDateDiff int
+
+	for i, candidate := range candidates {
+		if !r.DateWithinWindow(record.PaymentDate, candidate.PaymentDate) {
+			continue
+		}
+		if !r.AmountWithinTolerance(record.Amount, candidate.Amount) {
+			continue
+		}
+
+		amountDiff := record.Amount.Sub(candidate.Amount).Abs()
+		dateDiff, _ := r.DateDistance(record.PaymentDate, candidate.PaymentDate)
+
+		isBetter := bestIdx == -1 ||
+			amountDiff.LessThan(bestAmountDiff) ||
+			(amountDiff.Equal(bestAmountDiff) && dateDiff < bestDateDiff)
+		if isBetter {
+			bestIdx = i
+			bestAmountDiff = amountDiff
+			bestDateDiff = dateDiff
+		}
+	}
+
+	if bestIdx == -1 {
+		return ReconMatchResult{}
+	}
+
+	return ReconMatchResult{
+		Indexes: []int{bestIdx},
+		Rule:    singleMatchRule(bestAmountDiff.IsZero(), bestDateDiff == 0),
+	}
+}
+
+func singleMatchRule(exactAmount, sameDate bool) ReconMatchRule {
+	switch {
+	case exactAmount && sameDate:
+		return ReconMatchRuleExact
+	case exactAmount:
+		return ReconMatchRuleDateWindow
+	case sameDate:
+		return ReconMatchRuleAmountTolerance
+	default:
+		return ReconMatchRuleAmountToleranceAndDate
+	}
+}
+
+// FindGroup find combination of candidates whose sum settle the record amount.
+// Only candidates inside date window are considered and the combination is limited by MaxGroupSize.
+func (r ReconMatchingRule) FindGroup(record ReconRecord, candidates []ReconRecord, rule ReconMatchRule) ReconMatchResult {
+	maxGroupSize := r.MaxGroupSize
+	if maxGroupSize <= 0 {
+		maxGroupSize = defaultReconMatchingMaxGroupSize
+	}
+
+	var eligible []int
+	for i, candidate := range candidates {
+		if !r.DateWithinWindow(record.PaymentDate, candidate.PaymentDate) {
+			continue
+		}
+		if !candidate.Amount.IsPositive() {
+			continue
+		}
+		eligible = append(eligible, i)
+		if len(eligible) == maxReconMatchingGroupCandidates {
+			break
+		}
+	}
+
+	if len(eligible) < 2 {
+		return ReconMatchResult{}
+	}
+
+	// biggest amount first, so the search can prune earlier
+	sort.SliceStable(eligible, func(i, j int) bool {
+		return candidates[eligible[i]].Amount.GreaterThan(candidates[eligible[j]].Amount)
+	})
+
+	upperBound := record.Amount.Add(r.AmountTolerance(record.Amount))
+
+	var found []int
+	var search func(start int, sum decimal.Decimal, picked []int) bool
+	search = func(start int, sum decimal.Decimal, picked []int) bool {
+		if len(picked) >= 2 && r.AmountWithinTolerance(record.Amount, sum) {
+			found = append([]int{}, picked...)
+			return true
+		}
+		if len(picked) == maxGroupSize {
+			return false
+		}
+
+		for i := start; i < len(eligible); i++ {
+			next := sum.Add(candidates[eligible[i]].Amount)
+			if next.GreaterThan(upperBound) {
+				continue
+			}
+			if search(i+1, next, append(picked, eligible[i])) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if !search(0, decimal.Zero, nil) {
+		return ReconMatchResult{}
+	}
+
+	sort.Ints(found)
+
+	return ReconMatchResult{
+		Indexes: found,
+		Rule:    rule,
+	}
+}
+
+// RemoveReconRecords return records without given indexes
+func RemoveReconRecords(records []ReconRecord, indexes []int) []ReconRecord {
+	removed := make(map[int]bool, len(indexes))
+	for _, idx := range indexes {
+		removed[idx] = true
+	}
+
+	result := make([]ReconRecord, 0, len(records))
+	for i, record := range records {
+		if !removed[i] {
+			result = append(result, record)
+		}
+	}
+
+	return result
+}
+
+// JoinReconRefNumbers join ref number of records which is settled by a single record
+func JoinReconRefNumbers(records []ReconRecord, indexes []int) string {
+	refNumbers := make([]string, 0, len(indexes))
+	for _, idx := range indexes {
+		refNumbers = append(refNumbers, records[idx].RefNumber)
+	}
+
+	return strings.Join(refNumbers, ReconGroupRefNumberSeparator)
+}
+
+// ReconSummaryErrorKey is the summary key for records that failed to be processed
+const ReconSummaryErrorKey = "Error"
+
+// ReconSummary is the number of recon records per status
+type ReconSummary map[string]int
+
+// Add increase the counter for given status
+func (s ReconSummary) Add(status StatusReconRecord) {
+	s[status.Title()]++
+}
+
+// AddError increase the counter of failed records
+func (s ReconSummary) AddError() {
+	s[ReconSummaryErrorKey]++
+}
+
+func (s *ReconSummary) Scan(src interface{}) error {
+	var raw []byte
+	switch src := src.(type) {
+	case string:
+		raw = []byte(src)
+	case []byte:
+		raw = src
+	case nil:
+		return nil
+	default:
+		return fmt.Errorf("type %T not supported by Scan", src)
+	}
+
+	return json.Unmarshal(raw, s)
+}
+
+func (s ReconSummary) Value() (value driver.Value, err error) {
+	if s == nil {
+		return []byte("{}"), nil
+	}
+
+	return json.Marshal(s)
+}
@@ -0,0 +1,244 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestReconRecord(refNumber string, amount int64, paymentDate string) ReconRecord {
+	return ReconRecord{
+		Identifier:  "VA-001",
+		RefNumber:   refNumber,
+		Amount:      decimal.NewFromInt(amount),
+		PaymentDate: paymentDate,
+	}
+}
+
+func TestReconMatchingRule_AmountWithinTolerance(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     ReconMatchingRule
+		expected int64
+		actual   int64
+		want     bool
+	}{
+		{
+			name:     "exact amount without tolerance",
+			expected: 100000,
+			actual:   100000,
+			want:     true,
+		},
+		{
+			name:     "different amount without tolerance",
+			expected: 100000,
+			actual:   99000,
+			want:     false,
+		},
+		{
+			name:     "within absolute tolerance",
+			rule:     ReconMatchingRule{AmountToleranceAbsolute: decimal.NewFromInt(2500)},
+			expected: 100000,
+			actual:   97500,
+			want:     true,
+		},
+		{
+			name:     "within percent tolerance",
+			rule:     ReconMatchingRule{AmountTolerancePercent: decimal.NewFromInt(1)},
+			expected: 100000,
+			actual:   99000,
+			want:     true,
+		},
+		{
+			name: "use bigger tolerance between absolute and percent",
+			rule: ReconMatchingRule{
+				AmountToleranceAbsolute: decimal.NewFromInt(500),
+				AmountTolerancePercent:  decimal.NewFromInt(2),
+			},
+			expected: 100000,
+			actual:   98000,
+			want:     true,
+		},
+		{
+			name:     "outside tolerance",
+			rule:     ReconMatchingRule{AmountToleranceAbsolute: decimal.NewFromInt(500)},
+			expected: 100000,
+			actual:   99000,
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rule.AmountWithinTolerance(decimal.NewFromInt(tt.expected), decimal.NewFromInt(tt.actual))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReconMatchingRule_FindMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       ReconMatchingRule
+		record     ReconRecord
+		candidates []ReconRecord
+		wantIdx    []int
+		wantRule   ReconMatchRule
+	}{
+		{
+			name:   "exact match is preferred",
+			rule:   ReconMatchingRule{AmountToleranceAbsolute: decimal.NewFromInt(5000), DateWindowDays: 1},
+			record: newTestReconRecord("", 100000, "01-Jan-2023"),
+			candidates: []ReconRecord{
+				newTestReconRecord("ref-1", 97500, "01-Jan-2023"),
+				newTestReconRecord("ref-2", 100000, "02-Jan-2023"),
+				newTestReconRecord("ref-3", 100000, "01-Jan-2023"),
+			},
+			wantIdx:  []int{2},
+			wantRule: ReconMatchRuleExact,
+		},
+		{
+			name:   "match using amount tolerance for bank fee",
+			rule:   ReconMatchingRule{AmountToleranceAbsolute: decimal.NewFromInt(2500)},
+			record: newTestReconRecord("", 97500, "01-Jan-2023"),
+			candidates: []ReconRecord{
+				newTestReconRecord("ref-1", 100000, "01-Jan-2023"),
+			},
+			wantIdx:  []int{0},
+			wantRule: ReconMatchRuleAmountTolerance,
+		},
+		{
+			name:   "match using date window for T+1 posting",
+			rule:   ReconMatchingRule{DateWindowDays: 1},
+			record: newTestReconRecord("", 100000, "02-Jan-2023"),
+			candidates: []ReconRecord{
+				newTestReconRecord("ref-1", 100000, "01-Jan-2023"),
+			},
+			wantIdx:  []int{0},
+			wantRule: ReconMatchRuleDateWindow,
+		},
+		{
+			name:   "match using amount tolerance and date window",
+			rule:   ReconMatchingRule{AmountToleranceAbsolute: decimal.NewFromInt(2500), DateWindowDays: 1},
+			record: newTestReconRecord("", 97500, "02-Jan-2023"),
+			candidates: []ReconRecord{
+				newTestReconRecord("ref-1", 100000, "01-Jan-2023"),
+			},
+			wantIdx:  []int{0},
+			wantRule: ReconMatchRuleAmountToleranceAndDate,
+		},
+		{
+			name:   "outside date window",
+			rule:   ReconMatchingRule{DateWindowDays: 1},
+			record: newTestReconRecord("", 100000, "03-Jan-2023"),
+			candidates: []ReconRecord{
+				newTestReconRecord("ref-1", 100000, "01-Jan-2023"),
+			},
+		},
+		{
+			name:   "no exact match without tolerance",
+			record: newTestReconRecord("", 100000, "01-Jan-2023"),
+			candidates: []ReconRecord{
+				newTestReconRecord("ref-1", 100001, "01-Jan-2023"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rule.FindMatch(tt.record, tt.candidates)
+			assert.Equal(t, tt.wantIdx, got.Indexes)
+			assert.Equal(t, tt.wantRule, got.Rule)
+		})
+	}
+}
+
+func TestReconMatchingRule_FindGroup(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       ReconMatchingRule
+		record     ReconRecord
+		candidates []ReconRecord
+		wantIdx    []int
+	}{
+		{
+			name:   "one bank credit settle several transactions",
+			rule:   ReconMatchingRule{EnableManyToOne: true},
+			record: newTestReconRecord("", 300000, "01-Jan-2023"),
+			candidates: []ReconRecord{
+				newTestReconRecord("ref-1", 100000, "01-Jan-2023"),
+				newTestReconRecord("ref-2", 50000, "01-Jan-2023"),
+				newTestReconRecord("ref-3", 200000, "01-Jan-2023"),
+			},
+			wantIdx: []int{0, 2},
+		},
+		{
+			name:   "group sum within tolerance",
+			rule:   ReconMatchingRule{EnableManyToOne: true, AmountToleranceAbsolute: decimal.NewFromInt(1000)},
+			record: newTestReconRecord("", 149000, "01-Jan-2023"),
+			candidates: []ReconRecord{
+				newTestReconRecord("ref-1", 100000, "01-Jan-2023"),
+				newTestReconRecord("ref-2", 50000, "01-Jan-2023"),
+			},
+			wantIdx: []int{0, 1},
+		},
+		{
+			name:   "group is limited by max group size",
+			rule:   ReconMatchingRule{EnableManyToOne: true, MaxGroupSize: 2},
+			record: newTestReconRecord("", 300000, "01-Jan-2023"),
+			candidates: []ReconRecord{
+				newTestReconRecord("ref-1", 100000, "01-Jan-2023"),
+				newTestReconRecord("ref-2", 100000, "01-Jan-2023"),
+				newTestReconRecord("ref-3", 100000, "01-Jan-2023"),
+			},
+		},
+		{
+			name:   "candidates outside date window are ignored",
+			rule:   ReconMatchingRule{EnableManyToOne: true},
+			record: newTestReconRecord("", 200000, "01-Jan-2023"),
+			candidates: []ReconRecord{
+				newTestReconRecord("ref-1", 100000, "01-Jan-2023"),
+				newTestReconRecord("ref-2", 100000, "02-Jan-2023"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.rule.FindGroup(tt.record, tt.candidates, ReconMatchRuleManyToOne)
+			assert.Equal(t, tt.wantIdx, got.Indexes)
+			if tt.wantIdx != nil {
+				assert.Equal(t, ReconMatchRuleManyToOne, got.Rule)
+			}
+		})
+	}
+}
+
+func TestJoinReconRefNumbers(t *testing.T) {
+	records := []ReconRecord{
+		newTestReconRecord("ref-1", 100000, "01-Jan-2023"),
+		newTestReconRecord("ref-2", 50000, "01-Jan-2023"),
+		newTestReconRecord("ref-3", 200000, "01-Jan-2023"),
+	}
+
+	assert.Equal(t, "ref-1|ref-3", JoinReconRefNumbers(records, []int{0, 2}))
+	assert.Equal(t, []ReconRecord{records[1]}, RemoveReconRecords(records, []int{0, 2}))
+}
+
+func TestReconMatchResult_Confidence(t *testing.T) {
+	assert.Equal(t, 100, ReconMatchResult{Indexes: []int{0}, Rule: ReconMatchRuleExact}.Confidence())
+	assert.Equal(t, 80, ReconMatchResult{Indexes: []int{0}, Rule: ReconMatchRuleExact, BySecondaryKey: true}.Confidence())
+	assert.Equal(t, 0, ReconMatchResult{}.Confidence())
+}
+
+func TestReconSummary_Add(t *testing.T) {
+	summary := ReconSummary{}
+	summary.Add(StatusReconRecordMatch)
+	summary.Add(StatusReconRecordMatch)
+	summary.Add(StatusReconRecordExistsDBNotExistsCSV)
+	summary.AddError()
+
+	assert.Equal(t, ReconSummary{
+		"Match":                           2,
+		"Exists in DB, Not Exists in CSV": 1,
+		ReconSummaryErrorKey:              1,
+	}, summary)
+}
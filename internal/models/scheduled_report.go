@@ -0,0 +1,194 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/exportfile"
+)
+
+const (
+	ReportQueryTransaction = "TRANSACTION"
+	ReportQueryBalance     = "BALANCE"
+	ReportQueryMoneyFlow   = "MONEY_FLOW"
+
+	ReportScheduleDaily   = "DAILY"
+	ReportScheduleWeekly  = "WEEKLY"
+	ReportScheduleMonthly = "MONTHLY"
+
+	ReportRunStatusRunning = "RUNNING"
+	ReportRunStatusSuccess = "SUCCESS"
+	ReportRunStatusFailed  = "FAILED"
+
+	// ReportPath is the default cloud storage directory of report files
+	ReportPath = "reports"
+)
+
+// ReportDefinition is a named report generated by RunScheduledReports job, it is defined in config
+// so a recurring report is added without code changes.
+type ReportDefinition struct {
+	Name        string
+	Query       string
+	Params      map[string]string
+	Schedule    string
+	ScheduleDay int
+	Format      string
+	Gzip        bool
+	Path        string
+	Recipients  []string
+}
+
+func (d ReportDefinition) Validate() error {
+	if !slices.Contains([]string{ReportQueryTransaction, ReportQueryBalance, ReportQueryMoneyFlow}, d.Query) {
+		return fmt.Errorf("%w: %s has unknown query %q", common.ErrInvalidReportDefinition, d.Name, d.Query)
+	}
+
+	switch d.Schedule {
+	case ReportScheduleDaily:
+	case ReportScheduleWeekly:
+		if d.ScheduleDay < 0 || d.ScheduleDay > 6 {
+			return fmt.Errorf("%w: %s schedule day of weekly report must be between 0 and 6", common.ErrInvalidReportDefinition, d.Name)
+		}
+	case ReportScheduleMonthly:
+		if d.ScheduleDay < 1 || d.ScheduleDay > 31 {
+			return fmt.Errorf("%w: %s schedule day of monthly report must be between 1 and 31", common.ErrInvalidReportDefinition, d.Name)
+		}
+	default:
+		return fmt.Errorf("%w: %s has unknown schedule %q", common.ErrInvalidReportDefinition, d.Name, d.Schedule)
+	}
+
+	if !exportfile.Format(d.Format).Valid() {
+		return fmt.Errorf("%w: %s has unknown format %q", common.ErrInvalidReportDefinition, d.Name, d.Format)
+	}
+
+	return nil
+}
+
+// IsDue return true when the report is generated on date
+func (d ReportDefinition) IsDue(date time.Time) bool {
+	switch d.Schedule {
+	case ReportScheduleDaily:
+		return true
+	case ReportScheduleWeekly:
+		return int(date.Weekday()) == d.ScheduleDay
+	case ReportScheduleMonthly:
+		lastDay := time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, date.Location()).Day()
+		return date.Day() == min(d.ScheduleDay, lastDay)
+	default:
+		return false
+	}
+}
+
+// Period return the inclusive date range reported on date, the report always covers complete days before date:
+// the previous day for DAILY, the previous 7 days for WEEKLY and the previous month for MONTHLY.
+func (d ReportDefinition) Period(date time.Time) (start, end time.Time) {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	end = date.AddDate(0, 0, -1)
+
+	switch d.Schedule {
+	case ReportScheduleWeekly:
+		start = date.AddDate(0, 0, -7)
+	case ReportScheduleMonthly:
+		start = time.Date(date.Year(), date.Month()-1, 1, 0, 0, 0, 0, date.Location())
+		end = start.AddDate(0, 1, -1)
+	default:
+		start = end
+	}
+
+	return start, end
+}
+
+// Param return the query parameter, empty when it is not defined
+func (d ReportDefinition) Param(key string) string {
+	return strings.TrimSpace(d.Params[key])
+}
+
+// ParamList return the comma separated query parameter
+func (d ReportDefinition) ParamList(key string) []string {
+	value := d.Param(key)
+	if value == "" {
+		return nil
+	}
+
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// TransactionFilter return the filter of TRANSACTION report within the period
+func (d ReportDefinition) TransactionFilter(start, end time.Time) ExportTransactionFilter {
+	return ExportTransactionFilter{
+		StartDate:        start.Format(common.DateFormatYYYYMMDD),
+		EndDate:          end.Format(common.DateFormatYYYYMMDD),
+		Search:           d.Param("search"),
+		SearchBy:         d.Param("searchBy"),
+		OrderType:        d.Param("orderType"),
+		TransactionTypes: d.ParamList("transactionTypes"),
+		ProductTypeName:  d.Param("productTypeName"),
+		AccountNumber:    d.Param("accountNumber"),
+		Statuses:         d.ParamList("statuses"),
+		RefNumbers:       d.ParamList("refNumbers"),
+		MinAmount:        d.Param("minAmount"),
+		MaxAmount:        d.Param("maxAmount"),
+		Metadata:         d.ParamList("metadata"),
+	}
+}
+
+// CloudStoragePayload return location of the report file of the period
+func (d ReportDefinition) CloudStoragePayload(start, end time.Time) *CloudStoragePayload {
+	dir := d.Path
+	if dir == "" {
+		dir = fmt.Sprintf("%s/%s", ReportPath, d.Name)
+	}
+
+	filename := fmt.Sprintf("%s-%s_%s.%s", d.Name,
+		start.Format(common.DateFormatYYYYMMDD),
+		end.Format(common.DateFormatYYYYMMDD),
+		exportfile.Format(d.Format).Extension())
+	if d.Gzip {
+		filename += ".gz"
+	}
+
+	return &CloudStoragePayload{
+		Filename: filename,
+		Path:     fmt.Sprintf("%s/%04d/%02d", strings.TrimSuffix(dir, "/"), end.Year(), end.Month()),
+	}
+}
+
+// ReportRun is the history of a report generated for a period, a period is only generated once unless it failed
+type ReportRun struct {
+	ID           uint64
+	ReportName   string
+	PeriodStart  time.Time
+	PeriodEnd    time.Time
+	Status       string
+	RowCount     int64
+	FilePath     string
+	ErrorMessage string
+	StartedAt    time.Time
+	FinishedAt   *time.Time
+}
+
+type RunScheduledReportsRequest struct {
+	// StartDate and EndDate is the inclusive range of running dates, the same date is used for a regular run
+	// and a range is used to backfill the reports which were not generated
+	StartDate time.Time
+	EndDate   time.Time
+
+	// ReportName limit the run to a report, empty means all reports
+	ReportName string
+}
+
+type RunScheduledReportsResult struct {
+	Succeeded int
+	Failed    int
+	Skipped   int
+}
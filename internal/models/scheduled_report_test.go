@@ -0,0 +1,182 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func reportDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestReportDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition ReportDefinition
+		wantErr    bool
+	}{
+		{
+			name:       "success daily",
+			definition: ReportDefinition{Name: "daily", Query: ReportQueryBalance, Schedule: ReportScheduleDaily, Format: "CSV"},
+		},
+		{
+			name:       "success weekly on sunday",
+			definition: ReportDefinition{Name: "weekly", Query: ReportQueryTransaction, Schedule: ReportScheduleWeekly, Format: "XLSX"},
+		},
+		{
+			name:       "success monthly",
+			definition: ReportDefinition{Name: "monthly", Query: ReportQueryMoneyFlow, Schedule: ReportScheduleMonthly, ScheduleDay: 31, Format: "PARQUET"},
+		},
+		{
+			name:       "unknown query",
+			definition: ReportDefinition{Name: "daily", Query: "LOAN", Schedule: ReportScheduleDaily, Format: "CSV"},
+			wantErr:    true,
+		},
+		{
+			name:       "unknown schedule",
+			definition: ReportDefinition{Name: "daily", Query: ReportQueryBalance, Schedule: "HOURLY", Format: "CSV"},
+			wantErr:    true,
+		},
+		{
+			name:       "invalid weekly schedule day",
+			definition: ReportDefinition{Name: "weekly", Query: ReportQueryBalance, Schedule: ReportScheduleWeekly, ScheduleDay: 7, Format: "CSV"},
+			wantErr:    true,
+		},
+		{
+			name:       "invalid monthly schedule day",
+			definition: ReportDefinition{Name: "monthly", Query: ReportQueryBalance, Schedule: ReportScheduleMonthly, Format: "CSV"},
+			wantErr:    true,
+		},
+		{
+			name:       "unknown format",
+			definition: ReportDefinition{Name: "daily", Query: ReportQueryBalance, Schedule: ReportScheduleDaily, Format: "PDF"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.definition.Validate()
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, common.ErrInvalidReportDefinition), err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReportDefinition_IsDueAndPeriod(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition ReportDefinition
+		date       time.Time
+		wantDue    bool
+		wantStart  time.Time
+		wantEnd    time.Time
+	}{
+		{
+			name:       "daily report previous day",
+			definition: ReportDefinition{Schedule: ReportScheduleDaily},
+			date:       time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC),
+			wantDue:    true,
+			wantStart:  reportDate(2025, 2, 28),
+			wantEnd:    reportDate(2025, 2, 28),
+		},
+		{
+			name:       "weekly report previous 7 days",
+			definition: ReportDefinition{Schedule: ReportScheduleWeekly, ScheduleDay: int(time.Monday)},
+			date:       reportDate(2025, 3, 3),
+			wantDue:    true,
+			wantStart:  reportDate(2025, 2, 24),
+			wantEnd:    reportDate(2025, 3, 2),
+		},
+		{
+			name:       "weekly report not due",
+			definition: ReportDefinition{Schedule: ReportScheduleWeekly, ScheduleDay: int(time.Monday)},
+			date:       reportDate(2025, 3, 4),
+			wantStart:  reportDate(2025, 2, 25),
+			wantEnd:    reportDate(2025, 3, 3),
+		},
+		{
+			name:       "monthly report previous month",
+			definition: ReportDefinition{Schedule: ReportScheduleMonthly, ScheduleDay: 1},
+			date:       reportDate(2025, 3, 1),
+			wantDue:    true,
+			wantStart:  reportDate(2025, 2, 1),
+			wantEnd:    reportDate(2025, 2, 28),
+		},
+		{
+			name:       "monthly report due on last day of shorter month",
+			definition: ReportDefinition{Schedule: ReportScheduleMonthly, ScheduleDay: 31},
+			date:       reportDate(2025, 2, 28),
+			wantDue:    true,
+			wantStart:  reportDate(2025, 1, 1),
+			wantEnd:    reportDate(2025, 1, 31),
+		},
+		{
+			name:       "monthly report not due",
+			definition: ReportDefinition{Schedule: ReportScheduleMonthly, ScheduleDay: 31},
+			date:       reportDate(2025, 3, 30),
+			wantStart:  reportDate(2025, 2, 1),
+			wantEnd:    reportDate(2025, 2, 28),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantDue, tt.definition.IsDue(tt.date))
+
+			start, end := tt.definition.Period(tt.date)
+			assert.Equal(t, tt.wantStart, start)
+			assert.Equal(t, tt.wantEnd, end)
+		})
+	}
+}
+
+func TestReportDefinition_TransactionFilter(t *testing.T) {
+	definition := ReportDefinition{
+		Params: map[string]string{
+			"orderType":        "TOPUP",
+			"transactionTypes": "TUPVA, TUPVM,",
+			"minAmount":        " 1000 ",
+		},
+	}
+
+	got := definition.TransactionFilter(reportDate(2025, 1, 1), reportDate(2025, 1, 31))
+	assert.Equal(t, ExportTransactionFilter{
+		StartDate:        "2025-01-01",
+		EndDate:          "2025-01-31",
+		OrderType:        "TOPUP",
+		TransactionTypes: []string{"TUPVA", "TUPVM"},
+		MinAmount:        "1000",
+	}, got)
+}
+
+func TestReportDefinition_CloudStoragePayload(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition ReportDefinition
+		want       *CloudStoragePayload
+	}{
+		{
+			name:       "default path",
+			definition: ReportDefinition{Name: "balance-daily", Format: "CSV"},
+			want:       &CloudStoragePayload{Filename: "balance-daily-2025-01-01_2025-01-31.csv", Path: "reports/balance-daily/2025/01"},
+		},
+		{
+			name:       "custom path with gzip",
+			definition: ReportDefinition{Name: "trx-monthly", Format: "XLSX", Gzip: true, Path: "finance/monthly/"},
+			want:       &CloudStoragePayload{Filename: "trx-monthly-2025-01-01_2025-01-31.xlsx.gz", Path: "finance/monthly/2025/01"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.definition.CloudStoragePayload(reportDate(2025, 1, 1), reportDate(2025, 1, 31)))
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type MetadataFilterOperator string
+
+const (
+	MetadataFilterOperatorEqual    MetadataFilterOperator = "eq"
+	MetadataFilterOperatorContains MetadataFilterOperator = "contains"
+
+	// MaxMetadataFilters limit the number of metadata filter in one request,
+	// every filter is an additional condition on JSONB column
+	MaxMetadataFilters = 5
+)
+
+var metadataFilterPathKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// MetadataFilter is filter on the JSONB metadata column.
+// The query format is "<path>:<operator>:<value>", path is dot separated keys of the metadata, for example:
+//   - loanAccountNumber:eq:LA-0001 match metadata {"loanAccountNumber": "LA-0001"}
+//   - partner.name:contains:amar match metadata {"partner": {"name": "Amartha"}}
+type MetadataFilter struct {
+	Path     []string
+	Operator MetadataFilterOperator
+	Value    string
+}
+
+// ToContainmentJSON return json object of the filter to be used with jsonb containment operator (@>)
+func (f MetadataFilter) ToContainmentJSON() string {
+	var v any = f.Value
+	for i := len(f.Path) - 1; i >= 0; i-- {
+		v = map[string]any{f.Path[i]: v}
+	}
+
+	// marshal nested map of string will never fail
+	b, _ := json.Marshal(v)
+
+	return string(b)
+}
+
+// ParseMetadataFilters parse list of "<path>:<operator>:<value>" from query param
+func ParseMetadataFilters(raws []string) ([]MetadataFilter, error) {
+	if len(raws) > MaxMetadataFilters {
+		return nil, GetErrMap(ErrKeyTooManyMetadataFilters)
+	}
+
+	var filters []MetadataFilter
+	for _, raw := range raws {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(raw, ":", 3)
+		if len(parts) != 3 || parts[2] == "" {
+			return nil, GetErrMap(ErrKeyInvalidMetadataFilter, raw)
+		}
+
+		operator := MetadataFilterOperator(parts[1])
+		if operator != MetadataFilterOperatorEqual && operator != MetadataFilterOperatorContains {
+			return nil, GetErrMap(ErrKeyInvalidMetadataFilter, raw)
+		}
+
+		path := strings.Split(strings.TrimPrefix(parts[0], "metadata."), ".")
+		for _, key := range path {
+			if !metadataFilterPathKeyRegex.MatchString(key) {
+				return nil, GetErrMap(ErrKeyInvalidMetadataFilter, raw)
+			}
+		}
+
+		filters = append(filters, MetadataFilter{
+			Path:     path,
+			Operator: operator,
+			Value:    parts[2],
+		})
+	}
+
+	return filters, nil
+}
+
+// ParseAmountRange parse minAmount and maxAmount from query param, empty value means no limit
+func ParseAmountRange(rawMin, rawMax string) (minAmount, maxAmount *decimal.Decimal, err error) {
+	parse := func(raw string) (*decimal.Decimal, error) {
+		if raw == "" {
+			return nil, nil
+		}
+
+		amount, errParse := decimal.NewFromString(raw)
+		if errParse != nil || amount.IsNegative() {
+			return nil, GetErrMap(ErrKeyInvalidAmountFilter, fmt.Sprintf("value %s", raw))
+		}
+
+		return &amount, nil
+	}
+
+	if minAmount, err = parse(rawMin); err != nil {
+		return nil, nil, err
+	}
+
+	if maxAmount, err = parse(rawMax); err != nil {
+		return nil, nil, err
+	}
+
+	if minAmount != nil && maxAmount != nil && minAmount.GreaterThan(*maxAmount) {
+		return nil, nil, GetErrMap(ErrKeyMinAmountIsGreaterThanMaxAmount)
+	}
+
+	return minAmount, maxAmount, nil
+}
+
+// splitCommaSeparated split query param with comma separated value, empty item is ignored
+func splitCommaSeparated(raw string) []string {
+	var res []string
+	for _, v := range strings.Split(raw, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetadataFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		raws    []string
+		want    []MetadataFilter
+		wantErr bool
+	}{
+		{
+			name: "equal and contains",
+			raws: []string{"loanAccountNumber:eq:LA-1", "metadata.partner.name:contains:amar:tha"},
+			want: []MetadataFilter{
+				{Path: []string{"loanAccountNumber"}, Operator: MetadataFilterOperatorEqual, Value: "LA-1"},
+				{Path: []string{"partner", "name"}, Operator: MetadataFilterOperatorContains, Value: "amar:tha"},
+			},
+		},
+		{
+			name: "empty value is ignored",
+			raws: []string{""},
+		},
+		{
+			name:    "unknown operator",
+			raws:    []string{"loanAccountNumber:gt:1"},
+			wantErr: true,
+		},
+		{
+			name:    "missing value",
+			raws:    []string{"loanAccountNumber:eq:"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid path",
+			raws:    []string{"loan'AccountNumber:eq:1"},
+			wantErr: true,
+		},
+		{
+			name:    "too many filters",
+			raws:    []string{"a:eq:1", "b:eq:1", "c:eq:1", "d:eq:1", "e:eq:1", "f:eq:1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMetadataFilters(tt.raws)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMetadataFilter_ToContainmentJSON(t *testing.T) {
+	f := MetadataFilter{Path: []string{"partner", "name"}, Operator: MetadataFilterOperatorEqual, Value: `Amartha "Mikro"`}
+	assert.Equal(t, `{"partner":{"name":"Amartha \"Mikro\""}}`, f.ToContainmentJSON())
+}
+
+func TestParseAmountRange(t *testing.T) {
+	minAmount, maxAmount, err := ParseAmountRange("1000", "")
+	require.NoError(t, err)
+	assert.True(t, minAmount.Equal(decimal.NewFromInt(1000)))
+	assert.Nil(t, maxAmount)
+
+	_, _, err = ParseAmountRange("-1", "")
+	assert.Error(t, err)
+
+	_, _, err = ParseAmountRange("abc", "")
+	assert.Error(t, err)
+
+	_, _, err = ParseAmountRange("5000", "1000")
+	assert.Error(t, err)
+}
+
+func TestDoGetListTransactionRequest_ToFilterOpts_AdvancedFilter(t *testing.T) {
+	opts, err := DoGetListTransactionRequest{
+		AccountNumber: "189513",
+		Statuses:      []string{"PENDING", "1"},
+		RefNumbers:    []string{"REF-1"},
+		MinAmount:     "1000",
+		Metadata:      []string{"loanAccountNumber:eq:LA-1"},
+	}.ToFilterOpts()
+	require.NoError(t, err)
+
+	assert.Equal(t, "189513", opts.AccountNumber)
+	assert.Equal(t, []string{"0", "1"}, opts.Statuses)
+	assert.Equal(t, []string{"REF-1"}, opts.RefNumbers)
+	assert.Len(t, opts.MetadataFilters, 1)
+
+	_, err = DoGetListTransactionRequest{Statuses: []string{"DONE"}}.ToFilterOpts()
+	assert.Error(t, err)
+}
+
+func TestListWalletTrxRequest_ToFilterOpts_AdvancedFilter(t *testing.T) {
+	opts, err := ListWalletTrxRequest{
+		WalletTrxAdvancedFilterRequest: WalletTrxAdvancedFilterRequest{
+			Statuses:   "PENDING, SUCCESS",
+			RefNumbers: "REF-1,REF-2",
+			MaxAmount:  "5000",
+		},
+	}.ToFilterOpts()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"PENDING", "SUCCESS"}, opts.Statuses)
+	assert.Equal(t, []string{"REF-1", "REF-2"}, opts.RefNumbers)
+	assert.True(t, opts.MaxAmount.Equal(decimal.NewFromInt(5000)))
+
+	_, err = ListWalletTrxRequest{
+		WalletTrxAdvancedFilterRequest: WalletTrxAdvancedFilterRequest{Statuses: "DONE"},
+	}.ToFilterOpts()
+	assert.Error(t, err)
+}
@@ -0,0 +1,312 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	TaxRateKind   = "taxRate"
+	TaxReportKind = "taxReport"
+)
+
+type TaxType string
+
+const (
+	// TaxTypeVAT is PPN, it is included in the gross amount
+	TaxTypeVAT TaxType = "VAT"
+	// TaxTypeWHT is PPh 23/26, it is withheld from the amount after VAT
+	TaxTypeWHT TaxType = "WHT"
+)
+
+// TaxRate represents a row of tax_rates table.
+// Empty Entity, LoanKind or TransactionType match any value, so a rate can be defined as broad or as specific as needed.
+type TaxRate struct {
+	ID              int64
+	TaxType         TaxType
+	Entity          string
+	LoanKind        string
+	TransactionType string
+	Rate            decimal.Decimal
+	EffectiveFrom   time.Time
+	EffectiveTo     *time.Time
+	Description     string
+	CreatedBy       string
+	CreatedAt       time.Time
+}
+
+// IsEffectiveAt check whether the rate is applied at the given time, EffectiveTo is exclusive
+func (r TaxRate) IsEffectiveAt(at time.Time) bool {
+	if at.Before(r.EffectiveFrom) {
+		return false
+	}
+
+	return r.EffectiveTo == nil || at.Before(*r.EffectiveTo)
+}
+
+func (r TaxRate) matches(lookup TaxLookup) bool {
+	return matchTaxDimension(r.Entity, lookup.Entity) &&
+		matchTaxDimension(r.LoanKind, lookup.LoanKind) &&
+		matchTaxDimension(r.TransactionType, lookup.TransactionType)
+}
+
+// specificity is the number of dimensions defined by the rate, the most specific rate wins
+func (r TaxRate) specificity() int {
+	count := 0
+	for _, v := range []string{r.Entity, r.LoanKind, r.TransactionType} {
+		if v != "" {
+			count++
+		}
+	}
+
+	return count
+}
+
+func matchTaxDimension(rate, value string) bool {
+	return rate == "" || strings.EqualFold(rate, value)
+}
+
+func (r TaxRate) ToModelResponse() DoGetTaxRateResponse {
+	return DoGetTaxRateResponse{
+		Kind:            TaxRateKind,
+		ID:              r.ID,
+		TaxType:         string(r.TaxType),
+		Entity:          r.Entity,
+		LoanKind:        r.LoanKind,
+		TransactionType: r.TransactionType,
+		Rate:            r.Rate,
+		EffectiveFrom:   r.EffectiveFrom,
+		EffectiveTo:     r.EffectiveTo,
+		Description:     r.Description,
+		CreatedBy:       r.CreatedBy,
+		CreatedAt:       r.CreatedAt,
+	}
+}
+
+// TaxLookup is the attributes of a transaction which decide its tax rate
+type TaxLookup struct {
+	Entity          string
+	LoanKind        string
+	TransactionType string
+	At              time.Time
+}
+
+// TaxRates is the rate table used by tax engine
+type TaxRates []TaxRate
+
+// Find return the most specific effective rate of the tax type for the lookup,
+// when more than one rate is equally specific the latest EffectiveFrom is used.
+func (t TaxRates) Find(taxType TaxType, lookup TaxLookup) (TaxRate, bool) {
+	var (
+		found TaxRate
+		ok    bool
+	)
+
+	for _, rate := range t {
+		if rate.TaxType != taxType || !rate.IsEffectiveAt(lookup.At) || !rate.matches(lookup) {
+			continue
+		}
+
+		if !ok ||
+			rate.specificity() > found.specificity() ||
+			(rate.specificity() == found.specificity() && rate.EffectiveFrom.After(found.EffectiveFrom)) {
+			found, ok = rate, true
+		}
+	}
+
+	return found, ok
+}
+
+// TaxSplit is gross amount which is split into its tax legs, Net + VAT + WHT is always equal to Gross
+type TaxSplit struct {
+	Gross   decimal.Decimal
+	Net     decimal.Decimal
+	VAT     decimal.Decimal
+	WHT     decimal.Decimal
+	VATRate decimal.Decimal
+	WHTRate decimal.Decimal
+}
+
+// SplitGross split amount which already include VAT, WHT is calculated from the amount after VAT.
+// Tax amounts are rounded to whole rupiah and the rounding difference stays in Net.
+func SplitGross(gross, vatRate, whtRate decimal.Decimal) TaxSplit {
+	one := decimal.NewFromInt(1)
+
+	vat := gross.Mul(vatRate.Div(one.Add(vatRate))).Round(0)
+	wht := gross.Sub(vat).Mul(whtRate).Round(0)
+
+	return TaxSplit{
+		Gross:   gross,
+		Net:     gross.Sub(vat).Sub(wht),
+		VAT:     vat,
+		WHT:     wht,
+		VATRate: vatRate,
+		WHTRate: whtRate,
+	}
+}
+
+type DoCreateTaxRateRequest struct {
+	TaxType         string          `json:"taxType" validate:"required,oneof=VAT WHT" example:"VAT"`
+	Entity          string          `json:"entity" example:"AMF"`
+	LoanKind        string          `json:"loanKind" example:"MODAL_LOAN"`
+	TransactionType string          `json:"transactionType" example:"RPYAF"`
+	Rate            decimal.Decimal `json:"rate" example:"0.12"`
+	EffectiveFrom   time.Time       `json:"effectiveFrom" validate:"required" example:"2025-01-01T00:00:00+07:00"`
+	EffectiveTo     *time.Time      `json:"effectiveTo" example:"2026-01-01T00:00:00+07:00"`
+	Description     string          `json:"description" example:"PPN 12% for modal loan"`
+	Actor           string          `json:"actor" validate:"required" example:"finance.tax"`
+}
+
+// Validate check rule which can not be expressed by validate tag
+func (r DoCreateTaxRateRequest) Validate() error {
+	if r.Rate.IsNegative() || r.Rate.GreaterThanOrEqual(decimal.NewFromInt(1)) {
+		return fmt.Errorf("%w: rate must be between 0 and 1", common.ErrInvalidTaxRate)
+	}
+
+	if r.EffectiveTo != nil && !r.EffectiveTo.After(r.EffectiveFrom) {
+		return fmt.Errorf("%w: effectiveTo must be after effectiveFrom", common.ErrInvalidTaxRate)
+	}
+
+	return nil
+}
+
+func (r DoCreateTaxRateRequest) ToTaxRate() TaxRate {
+	return TaxRate{
+		TaxType:         TaxType(r.TaxType),
+		Entity:          r.Entity,
+		LoanKind:        r.LoanKind,
+		TransactionType: r.TransactionType,
+		Rate:            r.Rate,
+		EffectiveFrom:   r.EffectiveFrom,
+		EffectiveTo:     r.EffectiveTo,
+		Description:     r.Description,
+		CreatedBy:       r.Actor,
+	}
+}
+
+type DoGetTaxRateResponse struct {
+	Kind            string          `json:"kind" example:"taxRate"`
+	ID              int64           `json:"id" example:"1"`
+	TaxType         string          `json:"taxType" example:"VAT"`
+	Entity          string          `json:"entity" example:"AMF"`
+	LoanKind        string          `json:"loanKind" example:"MODAL_LOAN"`
+	TransactionType string          `json:"transactionType" example:"RPYAF"`
+	Rate            decimal.Decimal `json:"rate" example:"0.12"`
+	EffectiveFrom   time.Time       `json:"effectiveFrom"`
+	EffectiveTo     *time.Time      `json:"effectiveTo"`
+	Description     string          `json:"description" example:"PPN 12% for modal loan"`
+	CreatedBy       string          `json:"createdBy" example:"finance.tax"`
+	CreatedAt       time.Time       `json:"createdAt"`
+}
+
+// DoGetTaxReportRequest is the period of tax report, both dates are inclusive
+type DoGetTaxReportRequest struct {
+	StartDate string `query:"startDate" validate:"required,date" example:"2025-01-01"`
+	EndDate   string `query:"endDate" validate:"required,date" example:"2025-01-31"`
+	Entity    string `query:"entity" example:"AMF"`
+}
+
+// TaxReportFilterOptions is the filter of tax collected report.
+// TransactionTypes is the tax leg transaction types with the tax they carry.
+type TaxReportFilterOptions struct {
+	StartDate        time.Time
+	EndDate          time.Time
+	Entity           string
+	TransactionTypes map[string]TaxType
+}
+
+func (r DoGetTaxReportRequest) ToFilterOptions(transactionTypes map[string]TaxType) (TaxReportFilterOptions, error) {
+	startDate, err := time.Parse(time.DateOnly, r.StartDate)
+	if err != nil {
+		return TaxReportFilterOptions{}, err
+	}
+
+	endDate, err := time.Parse(time.DateOnly, r.EndDate)
+	if err != nil {
+		return TaxReportFilterOptions{}, err
+	}
+
+	if endDate.Before(startDate) {
+		return TaxReportFilterOptions{}, common.ErrInvalidDateRange
+	}
+
+	return TaxReportFilterOptions{
+		StartDate:        startDate,
+		EndDate:          endDate,
+		Entity:           r.Entity,
+		TransactionTypes: transactionTypes,
+	}, nil
+}
+
+// TaxCollected is the sum of one tax leg transaction type per entity and month
+type TaxCollected struct {
+	Entity            string          `json:"entity" example:"AMF"`
+	Period            string          `json:"period" example:"2025-01"`
+	TaxType           TaxType         `json:"taxType" example:"VAT"`
+	TransactionType   string          `json:"transactionType" example:"RPYAG"`
+	TotalAmount       decimal.Decimal `json:"totalAmount" example:"1100000"`
+	TotalTransactions int64           `json:"totalTransactions" example:"25"`
+}
+
+type TaxReportTotal struct {
+	Entity      string          `json:"entity" example:"AMF"`
+	Period      string          `json:"period" example:"2025-01"`
+	TaxType     TaxType         `json:"taxType" example:"VAT"`
+	TotalAmount decimal.Decimal `json:"totalAmount" example:"1100000"`
+}
+
+type DoGetTaxReportResponse struct {
+	Kind      string           `json:"kind" example:"taxReport"`
+	StartDate string           `json:"startDate" example:"2025-01-01"`
+	EndDate   string           `json:"endDate" example:"2025-01-31"`
+	Totals    []TaxReportTotal `json:"totals"`
+	Details   []TaxCollected   `json:"details"`
+}
+
+// NewTaxReport summarize tax collected per entity, period and tax type, it is what is reported in monthly tax filing
+func NewTaxReport(opts TaxReportFilterOptions, details []TaxCollected) DoGetTaxReportResponse {
+	type key struct {
+		entity  string
+		period  string
+		taxType TaxType
+	}
+
+	sums := map[key]decimal.Decimal{}
+	for _, d := range details {
+		k := key{entity: d.Entity, period: d.Period, taxType: d.TaxType}
+		sums[k] = sums[k].Add(d.TotalAmount)
+	}
+
+	totals := make([]TaxReportTotal, 0, len(sums))
+	for k, amount := range sums {
+		totals = append(totals, TaxReportTotal{Entity: k.entity, Period: k.period, TaxType: k.taxType, TotalAmount: amount})
+	}
+
+	sort.Slice(totals, func(i, j int) bool {
+		if totals[i].Entity != totals[j].Entity {
+			return totals[i].Entity < totals[j].Entity
+		}
+		if totals[i].Period != totals[j].Period {
+			return totals[i].Period < totals[j].Period
+		}
+		return totals[i].TaxType < totals[j].TaxType
+	})
+
+	if details == nil {
+		details = []TaxCollected{}
+	}
+
+	return DoGetTaxReportResponse{
+		Kind:      TaxReportKind,
+		StartDate: opts.StartDate.Format(time.DateOnly),
+		EndDate:   opts.EndDate.Format(time.DateOnly),
+		Totals:    totals,
+		Details:   details,
+	}
+}
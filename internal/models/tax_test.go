@@ -0,0 +1,125 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaxRates_Find(t *testing.T) {
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	jul := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	rates := TaxRates{
+		{ID: 1, TaxType: TaxTypeVAT, Rate: decimal.NewFromFloat(0.11), EffectiveFrom: jan},
+		{ID: 2, TaxType: TaxTypeVAT, Rate: decimal.NewFromFloat(0.12), EffectiveFrom: jul},
+		{ID: 3, TaxType: TaxTypeVAT, Entity: "AWF", Rate: decimal.Zero, EffectiveFrom: jan},
+		{ID: 4, TaxType: TaxTypeVAT, Entity: "AMF", LoanKind: "MODAL_LOAN", Rate: decimal.NewFromFloat(0.1), EffectiveFrom: jan, EffectiveTo: &jul},
+		{ID: 5, TaxType: TaxTypeWHT, Rate: decimal.NewFromFloat(0.02), EffectiveFrom: jan},
+	}
+
+	tests := []struct {
+		name    string
+		taxType TaxType
+		lookup  TaxLookup
+		wantID  int64
+		wantOK  bool
+	}{
+		{
+			name:    "generic rate",
+			taxType: TaxTypeVAT,
+			lookup:  TaxLookup{Entity: "AMF", LoanKind: "NORMAL", At: jan.AddDate(0, 1, 0)},
+			wantID:  1,
+			wantOK:  true,
+		},
+		{
+			name:    "latest effective generic rate",
+			taxType: TaxTypeVAT,
+			lookup:  TaxLookup{Entity: "AMF", LoanKind: "NORMAL", At: jul.AddDate(0, 1, 0)},
+			wantID:  2,
+			wantOK:  true,
+		},
+		{
+			name:    "entity rate is more specific, entity is case insensitive",
+			taxType: TaxTypeVAT,
+			lookup:  TaxLookup{Entity: "awf", At: jul.AddDate(0, 1, 0)},
+			wantID:  3,
+			wantOK:  true,
+		},
+		{
+			name:    "entity and loan kind rate until effective to",
+			taxType: TaxTypeVAT,
+			lookup:  TaxLookup{Entity: "AMF", LoanKind: "MODAL_LOAN", At: jan.AddDate(0, 1, 0)},
+			wantID:  4,
+			wantOK:  true,
+		},
+		{
+			name:    "effective to is exclusive",
+			taxType: TaxTypeVAT,
+			lookup:  TaxLookup{Entity: "AMF", LoanKind: "MODAL_LOAN", At: jul},
+			wantID:  2,
+			wantOK:  true,
+		},
+		{
+			name:    "not effective yet",
+			taxType: TaxTypeWHT,
+			lookup:  TaxLookup{At: jan.Add(-time.Second)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := rates.Find(tt.taxType, tt.lookup)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantID, got.ID)
+		})
+	}
+}
+
+func TestSplitGross(t *testing.T) {
+	split := SplitGross(decimal.NewFromInt(111_000), decimal.NewFromFloat(0.11), decimal.NewFromFloat(0.02))
+
+	assert.True(t, decimal.NewFromInt(11_000).Equal(split.VAT))
+	assert.True(t, decimal.NewFromInt(2_000).Equal(split.WHT))
+	assert.True(t, decimal.NewFromInt(98_000).Equal(split.Net))
+	assert.True(t, split.Gross.Equal(split.Net.Add(split.VAT).Add(split.WHT)))
+
+	noTax := SplitGross(decimal.NewFromInt(100), decimal.Zero, decimal.Zero)
+	assert.True(t, decimal.NewFromInt(100).Equal(noTax.Net))
+}
+
+func TestDoCreateTaxRateRequest_Validate(t *testing.T) {
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, DoCreateTaxRateRequest{Rate: decimal.NewFromFloat(0.12), EffectiveFrom: jan}.Validate())
+	assert.ErrorIs(t, DoCreateTaxRateRequest{Rate: decimal.NewFromInt(12), EffectiveFrom: jan}.Validate(), common.ErrInvalidTaxRate)
+	assert.ErrorIs(t, DoCreateTaxRateRequest{Rate: decimal.NewFromFloat(0.12), EffectiveFrom: jan, EffectiveTo: &jan}.Validate(), common.ErrInvalidTaxRate)
+}
+
+func TestNewTaxReport(t *testing.T) {
+	opts, err := DoGetTaxReportRequest{StartDate: "2025-01-01", EndDate: "2025-02-28"}.ToFilterOptions(nil)
+	require.NoError(t, err)
+
+	report := NewTaxReport(opts, []TaxCollected{
+		{Entity: "AMF", Period: "2025-01", TaxType: TaxTypeVAT, TransactionType: "DSBRQ", TotalAmount: decimal.NewFromInt(500)},
+		{Entity: "AMF", Period: "2025-01", TaxType: TaxTypeVAT, TransactionType: "RPYAG", TotalAmount: decimal.NewFromInt(1000)},
+		{Entity: "AMF", Period: "2025-01", TaxType: TaxTypeWHT, TransactionType: "RPYAC", TotalAmount: decimal.NewFromInt(200)},
+		{Entity: "AFA", Period: "2025-02", TaxType: TaxTypeVAT, TransactionType: "RPYAG", TotalAmount: decimal.NewFromInt(300)},
+	})
+
+	assert.Equal(t, "2025-01-01", report.StartDate)
+	assert.Equal(t, "2025-02-28", report.EndDate)
+	assert.Len(t, report.Details, 4)
+	require.Len(t, report.Totals, 3)
+	assert.Equal(t, "AFA", report.Totals[0].Entity)
+	assert.Equal(t, TaxTypeVAT, report.Totals[1].TaxType)
+	assert.True(t, decimal.NewFromInt(1500).Equal(report.Totals[1].TotalAmount))
+	assert.Equal(t, TaxTypeWHT, report.Totals[2].TaxType)
+
+	_, err = DoGetTaxReportRequest{StartDate: "2025-02-01", EndDate: "2025-01-01"}.ToFilterOptions(nil)
+	assert.ErrorIs(t, err, common.ErrInvalidDateRange)
+}
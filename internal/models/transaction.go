@@ -17,15 +17,16 @@ import (
 )
 
 const (
-	TransactionIDPrefix             = "TRX"
-	TransactionIDManualPrefix       = "TRX-MANUAL"
-	WalletTransactionIDManualPrefix = "MANUAL"
-	TransactionRequestCommitStatus  = "commit"
-	TransactionRequestCancelStatus  = "cancel"
-	MaxRowTransactionFile           = 500000
-	TransactionStatusSuccessNum     = "1"
-	TransactionStatusCancelNum      = "2"
-	TransactionStatusPendingNum     = "0"
+	TransactionIDPrefix              = "TRX"
+	TransactionIDManualPrefix        = "TRX-MANUAL"
+	WalletTransactionIDManualPrefix  = "MANUAL"
+	TransactionRequestCommitStatus   = "commit"
+	TransactionRequestCancelStatus   = "cancel"
+	TransactionRequestIncreaseStatus = "increase"
+	MaxRowTransactionFile            = 500000
+	TransactionStatusSuccessNum      = "1"
+	TransactionStatusCancelNum       = "2"
+	TransactionStatusPendingNum      = "0"
 )
 
 type TransactionStoreProcessType int
@@ -64,6 +65,7 @@ type Transaction struct {
 	OrderType                  string              `json:"orderType"`
 	TransactionTime            time.Time           `json:"transactionTime"`
 	Currency                   string              `json:"currency"`
+	WalletTransactionID        string              `json:"walletTransactionId,omitempty"`
 }
 
 func (e *Transaction) ToAcuanLibTransaction() (*model.Transaction, error) {
@@ -285,6 +287,9 @@ type TransactionReq struct {
 	OrderType       string              `json:"orderType"`
 	TransactionTime time.Time           `json:"transactionTime"`
 	Currency        string              `json:"currency"`
+
+	// WalletTransactionID is id of the parent wallet transaction, empty when it is not transformed from wallet transaction
+	WalletTransactionID string `json:"walletTransactionId,omitempty"`
 }
 
 func (req *TransactionReq) ToRequest() (en Transaction, err error) {
@@ -324,6 +329,8 @@ func (req *TransactionReq) ToRequest() (en Transaction, err error) {
 		OrderType:       req.OrderType,
 		TransactionTime: req.TransactionTime,
 		Currency:        req.Currency,
+
+		WalletTransactionID: req.WalletTransactionID,
 	}
 
 	return
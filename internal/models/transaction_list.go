@@ -92,6 +92,61 @@ type DoGetListTransactionRequest struct {
 	ProductTypeName  string   `query:"productTypeName" example:"Poket"`
 	NextCursor       string   `query:"nextCursor" example:"abc"`
 	PrevCursor       string   `query:"prevCursor" example:"cba"`
+	AccountNumber    string   `query:"accountNumber" example:"21100100000001"`
+	Statuses         []string `query:"statuses" example:"SUCCESS"`
+	RefNumbers       []string `query:"refNumbers" example:"55aa66bb-e6e0-4065-9f4a-64182e97e9d9"`
+	MinAmount        string   `query:"minAmount" example:"10000"`
+	MaxAmount        string   `query:"maxAmount" example:"50000"`
+	Metadata         []string `query:"metadata" example:"loanAccountNumber:eq:LA-0001"`
+}
+
+// applyAdvancedFilter fill the structured filter of the request into opts
+func (req DoGetListTransactionRequest) applyAdvancedFilter(opts *TransactionFilterOptions) (err error) {
+	opts.AccountNumber = req.AccountNumber
+	opts.RefNumbers = req.RefNumbers
+
+	opts.Statuses, err = parseTransactionStatuses(req.Statuses)
+	if err != nil {
+		return err
+	}
+
+	opts.MinAmount, opts.MaxAmount, err = ParseAmountRange(req.MinAmount, req.MaxAmount)
+	if err != nil {
+		return err
+	}
+
+	opts.MetadataFilters, err = ParseMetadataFilters(req.Metadata)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// parseTransactionStatuses accept status title (PENDING, SUCCESS, CANCEL) or its stored value (0, 1, 2)
+func parseTransactionStatuses(statuses []string) ([]string, error) {
+	var res []string
+	for _, status := range statuses {
+		if _, ok := MapTransactionStatus[TransactionStatus(status)]; ok {
+			res = append(res, status)
+			continue
+		}
+
+		found := false
+		for code, title := range MapTransactionStatus {
+			if strings.EqualFold(title, status) {
+				res = append(res, code.String())
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, GetErrMap(ErrKeyInvalidStatusFilter, status)
+		}
+	}
+
+	return res, nil
 }
 
 type DoGetStatusCountTransactionRequest struct {
@@ -152,6 +207,14 @@ type TransactionFilterOptions struct {
 	// Filter only AMF transaction
 	OnlyAMF bool
 
+	// AccountNumber is filtering transaction from or to the account
+	AccountNumber   string
+	Statuses        []string
+	RefNumbers      []string
+	MinAmount       *decimal.Decimal
+	MaxAmount       *decimal.Decimal
+	MetadataFilters []MetadataFilter
+
 	Cursor *TransactionCursor
 }
 
@@ -218,6 +281,10 @@ func (req DoGetListTransactionRequest) ToFilterOpts() (*TransactionFilterOptions
 		return nil, GetErrMap(ErrKeyLimitMustBeGreaterThanZero)
 	}
 
+	if err := req.applyAdvancedFilter(opts); err != nil {
+		return nil, err
+	}
+
 	if req.StartDate == "" && req.EndDate != "" || req.StartDate != "" && req.EndDate == "" {
 		return nil, GetErrMap(ErrKeyStartDateAndEndDateRequiredIfOneIsFilled)
 	}
@@ -287,6 +354,10 @@ func (req DoGetListTransactionRequest) ToDownloadFilterOpts() (*TransactionFilte
 		Limit:            0,
 	}
 
+	if err := req.applyAdvancedFilter(opts); err != nil {
+		return nil, err
+	}
+
 	if req.StartDate == "" && req.EndDate != "" || req.StartDate != "" && req.EndDate == "" {
 		return nil, GetErrMap(ErrKeyStartDateAndEndDateRequiredIfOneIsFilled)
 	} else if req.StartDate == "" && req.EndDate == "" {
@@ -0,0 +1,274 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	TransactionMetricsKind = "transactionMetrics"
+
+	MetricIntervalDay   = "day"
+	MetricIntervalWeek  = "week"
+	MetricIntervalMonth = "month"
+
+	MetricGroupByOrderType       = "orderType"
+	MetricGroupByTransactionType = "transactionType"
+	MetricGroupByEntity          = "entity"
+	MetricGroupByProductType     = "productType"
+)
+
+// RepaymentTransactionTypes is the transaction types of repayment report and fin snapshot
+var RepaymentTransactionTypes = []string{"RPYAE", "RPYAD", "RPYAF", "RPYAB", "RPYAG", "RPYAC"}
+
+// DoGetTransactionMetricsRequest aggregate transactions between start and end date, both dates are inclusive.
+// Metrics are always grouped by interval and optionally by the groupBy dimensions.
+type DoGetTransactionMetricsRequest struct {
+	StartDate        string   `query:"startDate" validate:"required,date" example:"2025-01-01"`
+	EndDate          string   `query:"endDate" validate:"required,date" example:"2025-01-31"`
+	Interval         string   `query:"interval" validate:"omitempty,oneof=day week month" example:"day"`
+	GroupBy          []string `query:"groupBy" validate:"dive,oneof=orderType transactionType entity productType" example:"transactionType"`
+	OrderTypes       []string `query:"orderTypes" example:"REPAYMENT"`
+	TransactionTypes []string `query:"transactionTypes" example:"RPYAD"`
+	Entity           string   `query:"entity" example:"AMF"`
+	ProductTypeName  string   `query:"productTypeName" example:"Poket"`
+	Statuses         []string `query:"statuses" example:"SUCCESS"`
+}
+
+// TransactionMetricFilterOptions is the filter of transaction metrics, empty filter means all values
+// except statuses, only successful transactions are aggregated when no status is given
+type TransactionMetricFilterOptions struct {
+	StartDate        time.Time
+	EndDate          time.Time
+	Interval         string
+	GroupBy          []string
+	OrderTypes       []string
+	TransactionTypes []string
+	Entity           string
+	ProductTypeName  string
+	Statuses         []string
+}
+
+// ToFilterOptions convert the request into filter options, maxDays is the maximum date range, zero means unlimited
+func (r DoGetTransactionMetricsRequest) ToFilterOptions(maxDays int) (TransactionMetricFilterOptions, error) {
+	startDate, err := time.Parse(time.DateOnly, r.StartDate)
+	if err != nil {
+		return TransactionMetricFilterOptions{}, err
+	}
+
+	endDate, err := time.Parse(time.DateOnly, r.EndDate)
+	if err != nil {
+		return TransactionMetricFilterOptions{}, err
+	}
+
+	if endDate.Before(startDate) {
+		return TransactionMetricFilterOptions{}, common.ErrInvalidDateRange
+	}
+
+	if maxDays > 0 && int(endDate.Sub(startDate).Hours()/24) >= maxDays {
+		return TransactionMetricFilterOptions{}, common.ErrMetricDateRangeExceeded
+	}
+
+	statuses, err := parseTransactionStatuses(r.Statuses)
+	if err != nil {
+		return TransactionMetricFilterOptions{}, err
+	}
+
+	interval := r.Interval
+	if interval == "" {
+		interval = MetricIntervalDay
+	}
+
+	return TransactionMetricFilterOptions{
+		StartDate:        startDate,
+		EndDate:          endDate,
+		Interval:         interval,
+		GroupBy:          r.GroupBy,
+		OrderTypes:       r.OrderTypes,
+		TransactionTypes: r.TransactionTypes,
+		Entity:           r.Entity,
+		ProductTypeName:  r.ProductTypeName,
+		Statuses:         statuses,
+	}, nil
+}
+
+// TransactionDailyAggregate is the total of transactions per day and dimension,
+// it is materialized for closed days so metrics do not scan the transaction table.
+type TransactionDailyAggregate struct {
+	TransactionDate time.Time
+	OrderType       string
+	TransactionType string
+	Entity          string
+	ProductTypeName string
+	Status          string
+	TotalAmount     decimal.Decimal
+	TotalCount      int64
+}
+
+type TransactionMetric struct {
+	Period          string          `json:"period" example:"2025-01-01"`
+	OrderType       string          `json:"orderType,omitempty" example:"REPAYMENT"`
+	TransactionType string          `json:"transactionType,omitempty" example:"RPYAD"`
+	Entity          string          `json:"entity,omitempty" example:"AMF"`
+	ProductTypeName string          `json:"productTypeName,omitempty" example:"Poket"`
+	Sum             decimal.Decimal `json:"sum" example:"1500000"`
+	Count           int64           `json:"count" example:"3"`
+	Avg             decimal.Decimal `json:"avg" example:"500000"`
+}
+
+type DoGetTransactionMetricsResponse struct {
+	Kind      string              `json:"kind"`
+	StartDate string              `json:"startDate"`
+	EndDate   string              `json:"endDate"`
+	Interval  string              `json:"interval"`
+	GroupBy   []string            `json:"groupBy"`
+	Metrics   []TransactionMetric `json:"metrics"`
+}
+
+// NewTransactionMetrics roll up daily aggregates into the interval and group by dimensions of opts.
+// Period is the first date of the interval, weeks start on monday.
+func NewTransactionMetrics(opts TransactionMetricFilterOptions, aggregates []TransactionDailyAggregate) DoGetTransactionMetricsResponse {
+	groupBy := make(map[string]bool, len(opts.GroupBy))
+	for _, dimension := range opts.GroupBy {
+		groupBy[dimension] = true
+	}
+
+	indexes := map[TransactionMetric]int{}
+	metrics := []TransactionMetric{}
+	for _, aggregate := range aggregates {
+		key := TransactionMetric{Period: metricPeriod(opts.Interval, aggregate.TransactionDate)}
+		if groupBy[MetricGroupByOrderType] {
+			key.OrderType = aggregate.OrderType
+		}
+		if groupBy[MetricGroupByTransactionType] {
+			key.TransactionType = aggregate.TransactionType
+		}
+		if groupBy[MetricGroupByEntity] {
+			key.Entity = aggregate.Entity
+		}
+		if groupBy[MetricGroupByProductType] {
+			key.ProductTypeName = aggregate.ProductTypeName
+		}
+
+		i, ok := indexes[key]
+		if !ok {
+			i = len(metrics)
+			indexes[key] = i
+			metrics = append(metrics, key)
+		}
+		metrics[i].Sum = metrics[i].Sum.Add(aggregate.TotalAmount)
+		metrics[i].Count += aggregate.TotalCount
+	}
+
+	for i := range metrics {
+		if metrics[i].Count > 0 {
+			metrics[i].Avg = metrics[i].Sum.DivRound(decimal.NewFromInt(metrics[i].Count), 2)
+		}
+	}
+
+	sort.Slice(metrics, func(i, j int) bool {
+		a, b := metrics[i], metrics[j]
+		for _, cmp := range [][2]string{
+			{a.Period, b.Period},
+			{a.OrderType, b.OrderType},
+			{a.TransactionType, b.TransactionType},
+			{a.Entity, b.Entity},
+			{a.ProductTypeName, b.ProductTypeName},
+		} {
+			if c := strings.Compare(cmp[0], cmp[1]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	groupByResp := opts.GroupBy
+	if groupByResp == nil {
+		groupByResp = []string{}
+	}
+
+	return DoGetTransactionMetricsResponse{
+		Kind:      TransactionMetricsKind,
+		StartDate: opts.StartDate.Format(time.DateOnly),
+		EndDate:   opts.EndDate.Format(time.DateOnly),
+		Interval:  opts.Interval,
+		GroupBy:   groupByResp,
+		Metrics:   metrics,
+	}
+}
+
+func metricPeriod(interval string, date time.Time) string {
+	switch interval {
+	case MetricIntervalWeek:
+		offset := (int(date.Weekday()) + 6) % 7
+		date = date.AddDate(0, 0, -offset)
+	case MetricIntervalMonth:
+		date = time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	}
+
+	return date.Format(time.DateOnly)
+}
+
+// NewReportRepayments pivot the daily aggregates of repayment transaction types, days without repayment are skipped
+func NewReportRepayments(aggregates []TransactionDailyAggregate) ReportRepayments {
+	indexes := map[time.Time]int{}
+	var out ReportRepayments
+	for _, aggregate := range aggregates {
+		date := aggregate.TransactionDate
+		i, ok := indexes[date]
+		if !ok {
+			i = len(out)
+			indexes[date] = i
+			out = append(out, ReportRepayment{TransactionDate: date})
+		}
+
+		rr := &out[i]
+		switch aggregate.TransactionType {
+		case "RPYAE":
+			rr.Outstanding = rr.Outstanding.Add(aggregate.TotalAmount)
+		case "RPYAD":
+			rr.Principal = rr.Principal.Add(aggregate.TotalAmount)
+		case "RPYAF":
+			rr.Amartha = rr.Amartha.Add(aggregate.TotalAmount)
+		case "RPYAB":
+			rr.Lender = rr.Lender.Add(aggregate.TotalAmount)
+		case "RPYAG":
+			rr.PPN = rr.PPN.Add(aggregate.TotalAmount)
+		case "RPYAC":
+			rr.PPh = rr.PPh.Add(aggregate.TotalAmount)
+		}
+	}
+
+	for i := range out {
+		out[i].Total = out[i].Principal.Add(out[i].Amartha).Add(out[i].Lender).Add(out[i].PPN).Add(out[i].PPh)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].TransactionDate.Before(out[j].TransactionDate)
+	})
+
+	return out
+}
+
+// NewCollectRepayment sum repayment of the date, the amounts are zero when there is no repayment
+func NewCollectRepayment(date time.Time, aggregates []TransactionDailyAggregate) *CollectRepayment {
+	var rr ReportRepayment
+	if repayments := NewReportRepayments(aggregates); len(repayments) > 0 {
+		rr = repayments[0]
+	}
+
+	return &CollectRepayment{
+		TransactionDate: date,
+		Outstanding:     decimal.NewNullDecimal(rr.Outstanding),
+		Principal:       decimal.NewNullDecimal(rr.Principal),
+		Amartha:         decimal.NewNullDecimal(rr.Amartha),
+		Lender:          decimal.NewNullDecimal(rr.Lender),
+		PPN:             decimal.NewNullDecimal(rr.PPN),
+		PPh:             decimal.NewNullDecimal(rr.PPh),
+	}
+}
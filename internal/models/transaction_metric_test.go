@@ -0,0 +1,121 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoGetTransactionMetricsRequest_ToFilterOptions(t *testing.T) {
+	opts, err := DoGetTransactionMetricsRequest{
+		StartDate: "2025-01-01",
+		EndDate:   "2025-01-31",
+		Statuses:  []string{"SUCCESS"},
+	}.ToFilterOptions(31)
+	require.NoError(t, err)
+	assert.Equal(t, MetricIntervalDay, opts.Interval)
+	assert.Equal(t, []string{TransactionStatusSuccessNum}, opts.Statuses)
+
+	_, err = DoGetTransactionMetricsRequest{StartDate: "2025-01-31", EndDate: "2025-01-01"}.ToFilterOptions(0)
+	assert.True(t, errors.Is(err, common.ErrInvalidDateRange), err)
+
+	_, err = DoGetTransactionMetricsRequest{StartDate: "2025-01-01", EndDate: "2025-02-01"}.ToFilterOptions(31)
+	assert.True(t, errors.Is(err, common.ErrMetricDateRangeExceeded), err)
+
+	_, err = DoGetTransactionMetricsRequest{StartDate: "2025-01-01", EndDate: "2025-01-01", Statuses: []string{"UNKNOWN"}}.ToFilterOptions(0)
+	assert.Error(t, err)
+}
+
+func TestNewTransactionMetrics(t *testing.T) {
+	// 2025-01-05 is sunday and 2025-01-06 is monday
+	aggregates := []TransactionDailyAggregate{
+		{TransactionDate: reportDate(2025, 1, 6), OrderType: "REPAYMENT", TransactionType: "RPYAD", Entity: "AMF", TotalAmount: decimal.NewFromInt(300), TotalCount: 1},
+		{TransactionDate: reportDate(2025, 1, 5), OrderType: "REPAYMENT", TransactionType: "RPYAD", Entity: "AMF", TotalAmount: decimal.NewFromInt(100), TotalCount: 1},
+		{TransactionDate: reportDate(2025, 1, 4), OrderType: "REPAYMENT", TransactionType: "RPYAF", Entity: "AFA", Status: "1", TotalAmount: decimal.NewFromInt(200), TotalCount: 2},
+		{TransactionDate: reportDate(2025, 1, 4), OrderType: "REPAYMENT", TransactionType: "RPYAF", Entity: "AFA", Status: "2", TotalAmount: decimal.NewFromInt(10), TotalCount: 1},
+	}
+
+	tests := []struct {
+		name string
+		opts TransactionMetricFilterOptions
+		want []TransactionMetric
+	}{
+		{
+			name: "daily without group by",
+			opts: TransactionMetricFilterOptions{Interval: MetricIntervalDay},
+			want: []TransactionMetric{
+				{Period: "2025-01-04", Sum: decimal.NewFromInt(210), Count: 3, Avg: decimal.NewFromInt(70)},
+				{Period: "2025-01-05", Sum: decimal.NewFromInt(100), Count: 1, Avg: decimal.NewFromInt(100)},
+				{Period: "2025-01-06", Sum: decimal.NewFromInt(300), Count: 1, Avg: decimal.NewFromInt(300)},
+			},
+		},
+		{
+			name: "weekly by entity",
+			opts: TransactionMetricFilterOptions{Interval: MetricIntervalWeek, GroupBy: []string{MetricGroupByEntity}},
+			want: []TransactionMetric{
+				{Period: "2024-12-30", Entity: "AFA", Sum: decimal.NewFromInt(210), Count: 3, Avg: decimal.NewFromInt(70)},
+				{Period: "2024-12-30", Entity: "AMF", Sum: decimal.NewFromInt(100), Count: 1, Avg: decimal.NewFromInt(100)},
+				{Period: "2025-01-06", Entity: "AMF", Sum: decimal.NewFromInt(300), Count: 1, Avg: decimal.NewFromInt(300)},
+			},
+		},
+		{
+			name: "monthly by order type and transaction type",
+			opts: TransactionMetricFilterOptions{
+				Interval: MetricIntervalMonth,
+				GroupBy:  []string{MetricGroupByTransactionType, MetricGroupByOrderType},
+			},
+			want: []TransactionMetric{
+				{Period: "2025-01-01", OrderType: "REPAYMENT", TransactionType: "RPYAD", Sum: decimal.NewFromInt(400), Count: 2, Avg: decimal.NewFromInt(200)},
+				{Period: "2025-01-01", OrderType: "REPAYMENT", TransactionType: "RPYAF", Sum: decimal.NewFromInt(210), Count: 3, Avg: decimal.NewFromInt(70)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewTransactionMetrics(tt.opts, aggregates)
+			assert.Equal(t, TransactionMetricsKind, got.Kind)
+			require.Len(t, got.Metrics, len(tt.want))
+			for i, want := range tt.want {
+				metric := got.Metrics[i]
+				assert.Equal(t, want.Period, metric.Period)
+				assert.Equal(t, want.OrderType, metric.OrderType)
+				assert.Equal(t, want.TransactionType, metric.TransactionType)
+				assert.Equal(t, want.Entity, metric.Entity)
+				assert.Equal(t, want.Count, metric.Count)
+				assert.True(t, want.Sum.Equal(metric.Sum), "sum %s of %s", metric.Sum, metric.Period)
+				assert.True(t, want.Avg.Equal(metric.Avg), "avg %s of %s", metric.Avg, metric.Period)
+			}
+		})
+	}
+}
+
+func TestNewReportRepayments(t *testing.T) {
+	aggregates := []TransactionDailyAggregate{
+		{TransactionDate: reportDate(2025, 1, 2), TransactionType: "RPYAE", TotalAmount: decimal.NewFromInt(5000)},
+		{TransactionDate: reportDate(2025, 1, 2), TransactionType: "RPYAD", TotalAmount: decimal.NewFromInt(4000)},
+		{TransactionDate: reportDate(2025, 1, 2), TransactionType: "RPYAF", TotalAmount: decimal.NewFromInt(500)},
+		{TransactionDate: reportDate(2025, 1, 2), TransactionType: "RPYAB", TotalAmount: decimal.NewFromInt(400)},
+		{TransactionDate: reportDate(2025, 1, 2), TransactionType: "RPYAG", TotalAmount: decimal.NewFromInt(55)},
+		{TransactionDate: reportDate(2025, 1, 2), TransactionType: "RPYAC", TotalAmount: decimal.NewFromInt(8)},
+		{TransactionDate: reportDate(2025, 1, 1), TransactionType: "RPYAD", TotalAmount: decimal.NewFromInt(100)},
+	}
+
+	got := NewReportRepayments(aggregates)
+	require.Len(t, got, 2)
+	assert.Equal(t, reportDate(2025, 1, 1), got[0].TransactionDate)
+	assert.True(t, decimal.NewFromInt(100).Equal(got[0].Total))
+
+	// outstanding is not part of total
+	assert.True(t, decimal.NewFromInt(5000).Equal(got[1].Outstanding))
+	assert.True(t, decimal.NewFromInt(4963).Equal(got[1].Total))
+
+	collected := NewCollectRepayment(reportDate(2025, 1, 3), nil)
+	assert.Equal(t, reportDate(2025, 1, 3), collected.TransactionDate)
+	assert.True(t, collected.Principal.Valid)
+	assert.True(t, collected.Principal.Decimal.IsZero())
+}
@@ -325,3 +325,70 @@ func (trx WalletTransactionSet) CalculateTransferCancel(ctx context.Context, acc
 
 	return accountBalance, nil
 }
+
+// CalculateCapture will capture trx.Amount and release releaseAmount from fromAccount pendingBalance,
+// the captured amount is added to toAccount. It is used for cashout, transfer and refund since those flows
+// only reserve the fromAccount
+func (trx WalletTransactionSet) CalculateCapture(ctx context.Context, releaseAmount decimal.Decimal, accountBalance map[string]Balance) (map[string]Balance, error) {
+	sourceBalance, ok := accountBalance[trx.FromAccount]
+	if !ok {
+		return accountBalance, fmt.Errorf("source account not found: %s", trx.FromAccount)
+	}
+
+	err := sourceBalance.Capture(trx.Amount, releaseAmount, WithTransactionType(trx.TransactionType))
+	if err != nil {
+		xlog.Error(ctx, "failed to capture source account",
+			xlog.String("accountNumber", trx.FromAccount),
+			xlog.Any("balance", sourceBalance),
+		)
+
+		return accountBalance, err
+	}
+
+	accountBalance[trx.FromAccount] = sourceBalance
+
+	if trx.Amount.IsZero() {
+		return accountBalance, nil
+	}
+
+	destinationBalance, ok := accountBalance[trx.ToAccount]
+	if !ok {
+		return accountBalance, fmt.Errorf("destination account not found: %s", trx.ToAccount)
+	}
+
+	err = destinationBalance.AddFunds(trx.Amount, WithTransactionType(trx.TransactionType))
+	if err != nil {
+		xlog.Error(ctx, "failed to add funds to destination account",
+			xlog.String("accountNumber", trx.ToAccount),
+			xlog.Any("balance", destinationBalance),
+		)
+
+		return accountBalance, err
+	}
+
+	accountBalance[trx.ToAccount] = destinationBalance
+
+	return accountBalance, nil
+}
+
+// CalculateIncreaseReservation will increase fromAccount pendingBalance of an existing reservation
+func (trx WalletTransactionSet) CalculateIncreaseReservation(ctx context.Context, accountBalance map[string]Balance) (map[string]Balance, error) {
+	sourceBalance, ok := accountBalance[trx.FromAccount]
+	if !ok {
+		return accountBalance, fmt.Errorf("source account not found: %s", trx.FromAccount)
+	}
+
+	err := sourceBalance.IncreaseReservation(trx.Amount, WithTransactionType(trx.TransactionType))
+	if err != nil {
+		xlog.Error(ctx, "failed to increase reservation of source account",
+			xlog.String("accountNumber", trx.FromAccount),
+			xlog.Any("balance", sourceBalance),
+		)
+
+		return accountBalance, err
+	}
+
+	accountBalance[trx.FromAccount] = sourceBalance
+
+	return accountBalance, nil
+}
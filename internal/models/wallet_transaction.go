@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
 )
@@ -71,12 +72,14 @@ type CreateWalletTransactionRequest struct {
 	// internal use
 	ClientId       string
 	IdempotencyKey string
+	AsyncId        string
 }
 
 func (e CreateWalletTransactionRequest) ToResponse(walletTrx WalletTransaction) WalletTransactionResponse {
 	return WalletTransactionResponse{
 		Kind:                     "walletTransaction",
 		ID:                       walletTrx.ID,
+		AsyncId:                  walletTrx.AsyncId,
 		Status:                   walletTrx.Status,
 		AccountNumber:            e.AccountNumber,
 		RefNumber:                e.RefNumber,
@@ -118,6 +121,7 @@ func (e CreateWalletTransactionRequest) ToNewWalletTransaction() NewWalletTransa
 type WalletTransactionResponse struct {
 	Kind                     string                  `json:"kind"`
 	ID                       string                  `json:"id"`
+	AsyncId                  string                  `json:"asyncId,omitempty"`
 	Status                   WalletTransactionStatus `json:"status"`
 	AccountNumber            string                  `json:"accountNumber"`
 	RefNumber                string                  `json:"refNumber"`
@@ -146,6 +150,17 @@ type WalletTransaction struct {
 	Description              string
 	Metadata                 WalletMetadata
 	CreatedAt                time.Time
+
+	// CapturedAmount is part of reserved NetAmount which has been captured
+	CapturedAmount decimal.Decimal
+
+	// AsyncId is the intake id of an asynchronous submission, it is only set when the transaction is enqueued
+	AsyncId string
+}
+
+// RemainingAmount is part of reserved NetAmount which has not been captured yet
+func (e WalletTransaction) RemainingAmount() decimal.Decimal {
+	return e.NetAmount.ValueDecimal.Decimal.Sub(e.CapturedAmount)
 }
 
 func (e WalletTransaction) ToResponse() WalletTransactionResponse {
@@ -243,11 +258,17 @@ func (nw NewWalletTransaction) ToWalletTransaction() WalletTransaction {
 }
 
 // UpdateStatusWalletTransactionRequest is DTO object from handler
+//
+// Amount is optional for commit, when it is filled only that amount is captured and the transaction stays
+// reserved until the whole amount is captured or IsFinalCapture is true, in which case the remaining amount is released.
+// Amount is required for increase, it is added to the reserved amount
 type UpdateStatusWalletTransactionRequest struct {
 	TransactionId      string         `json:"-" validate:"required"`
-	Action             string         `json:"action" example:"commit" validate:"required,oneof=commit cancel"`
+	Action             string         `json:"action" example:"commit" validate:"required,oneof=commit cancel increase"`
 	RawTransactionTime string         `json:"transactionTime" validate:"iso8601datetime"`
 	Metadata           WalletMetadata `json:"metadata"`
+	Amount             *Amount        `json:"amount"`
+	IsFinalCapture     bool           `json:"isFinalCapture"`
 
 	TransactionTime time.Time `json:"-"`
 
@@ -273,13 +294,28 @@ func (e UpdateStatusWalletTransactionRequest) ToResponse(walletTrx WalletTransac
 		Kind:          "walletTransaction",
 		TransactionId: walletTrx.ID,
 		Status:        string(walletTrx.Status),
+		ReservedAmount: Amount{
+			ValueDecimal: walletTrx.NetAmount.ValueDecimal,
+			Currency:     IDRCurrency,
+		},
+		CapturedAmount: Amount{
+			ValueDecimal: NewDecimalFromExternal(walletTrx.CapturedAmount),
+			Currency:     IDRCurrency,
+		},
+		RemainingAmount: Amount{
+			ValueDecimal: NewDecimalFromExternal(walletTrx.RemainingAmount()),
+			Currency:     IDRCurrency,
+		},
 	}
 }
 
 type UpdateStatusWalletTransactionResponse struct {
-	Kind          string `json:"kind" example:"walletTransaction"`
-	TransactionId string `json:"transactionId" example:"41d03147-c017-4176-8a1a-0b7ec735cc29"`
-	Status        string `json:"status" example:"SUCCESS"`
+	Kind            string `json:"kind" example:"walletTransaction"`
+	TransactionId   string `json:"transactionId" example:"41d03147-c017-4176-8a1a-0b7ec735cc29"`
+	Status          string `json:"status" example:"SUCCESS"`
+	ReservedAmount  Amount `json:"reservedAmount"`
+	CapturedAmount  Amount `json:"capturedAmount"`
+	RemainingAmount Amount `json:"remainingAmount"`
 }
 
 // WalletTransactionUpdate only used when repository call update
@@ -287,4 +323,7 @@ type WalletTransactionUpdate struct {
 	Status          *WalletTransactionStatus
 	TransactionTime *time.Time
 	Metadata        *WalletMetadata
+	NetAmount       *Amount
+	Amounts         *Amounts
+	CapturedAmount  *decimal.Decimal
 }
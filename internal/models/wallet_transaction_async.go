@@ -0,0 +1,160 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+)
+
+const (
+	AsyncWalletTransactionKind = "asyncWalletTransaction"
+
+	AsyncWalletTransactionStatusQueued     = "QUEUED"
+	AsyncWalletTransactionStatusProcessing = "PROCESSING"
+	AsyncWalletTransactionStatusSucceeded  = "SUCCEEDED"
+	AsyncWalletTransactionStatusFailed     = "FAILED"
+
+	// AsyncCallbackStatusPending is the callback of finished transaction which is not delivered yet,
+	// it is sent by the SendAsyncWalletTransactionCallbacks job
+	AsyncCallbackStatusPending = "PENDING"
+	AsyncCallbackStatusSent    = "SENT"
+	AsyncCallbackStatusFailed  = "FAILED"
+
+	// WalletTransactionErrorCodeInvalidRequest is the error code of requests rejected with http 400
+	WalletTransactionErrorCodeInvalidRequest = "INVALID_REQUEST"
+	// WalletTransactionErrorCodeUnprocessable is the error code of requests rejected with http 422
+	WalletTransactionErrorCodeUnprocessable = "UNPROCESSABLE_TRANSACTION"
+	// WalletTransactionErrorCodeInternal is the error code of unexpected errors
+	WalletTransactionErrorCodeInternal = "INTERNAL_ERROR"
+)
+
+// AsyncWalletTransaction representing wallet_transaction_async_requests table,
+// it tracks a wallet transaction submitted asynchronously from intake until processed by the consumer
+type AsyncWalletTransaction struct {
+	ID                  string
+	ClientId            string
+	IdempotencyKey      string
+	RefNumber           string
+	TransactionType     string
+	AccountNumber       string
+	Status              string
+	WalletTransactionId string
+	ErrorCode           string
+	ErrorMessage        string
+	CallbackStatus      string
+	CallbackAttempts    int
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	ProcessedAt         *time.Time
+}
+
+// NewAsyncWalletTransaction create the intake of the request, the id must be assigned by caller
+func NewAsyncWalletTransaction(in CreateWalletTransactionRequest) AsyncWalletTransaction {
+	return AsyncWalletTransaction{
+		ID:              in.AsyncId,
+		ClientId:        in.ClientId,
+		IdempotencyKey:  in.IdempotencyKey,
+		RefNumber:       in.RefNumber,
+		TransactionType: in.TransactionType,
+		AccountNumber:   in.AccountNumber,
+		Status:          AsyncWalletTransactionStatusQueued,
+	}
+}
+
+func (e AsyncWalletTransaction) ToModelResponse() DoGetAsyncWalletTransactionResponse {
+	res := DoGetAsyncWalletTransactionResponse{
+		Kind:                AsyncWalletTransactionKind,
+		ID:                  e.ID,
+		Status:              e.Status,
+		RefNumber:           e.RefNumber,
+		TransactionType:     e.TransactionType,
+		AccountNumber:       e.AccountNumber,
+		WalletTransactionId: e.WalletTransactionId,
+		ErrorCode:           e.ErrorCode,
+		ErrorMessage:        e.ErrorMessage,
+		CreatedAt:           common.FormatDatetimeToStringInLocalTime(e.CreatedAt, common.DateFormatYYYYMMDDWithTime),
+	}
+
+	if e.ProcessedAt != nil {
+		res.ProcessedAt = common.FormatDatetimeToStringInLocalTime(*e.ProcessedAt, common.DateFormatYYYYMMDDWithTime)
+	}
+
+	return res
+}
+
+// DoGetAsyncWalletTransactionResponse is the status of an asynchronous wallet transaction,
+// it is also the body of the callback sent to the client when processing is finished
+type DoGetAsyncWalletTransactionResponse struct {
+	Kind                string `json:"kind" example:"asyncWalletTransaction"`
+	ID                  string `json:"id" example:"0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e"`
+	Status              string `json:"status" example:"SUCCEEDED"`
+	RefNumber           string `json:"refNumber" example:"55aa66bb-e6e0-4065-9f4a-64182e97e9d9"`
+	TransactionType     string `json:"transactionType" example:"TUPVA"`
+	AccountNumber       string `json:"accountNumber" example:"21100100000001"`
+	WalletTransactionId string `json:"walletTransactionId,omitempty" example:"41d03147-c017-4176-8a1a-0b7ec735cc29"`
+	ErrorCode           string `json:"errorCode,omitempty" example:"UNPROCESSABLE_TRANSACTION"`
+	ErrorMessage        string `json:"errorMessage,omitempty" example:""`
+	CreatedAt           string `json:"createdAt" example:"2025-01-01 08:00:00"`
+	ProcessedAt         string `json:"processedAt,omitempty" example:"2025-01-01 08:00:01"`
+}
+
+// IsFinished return true when the transaction is processed, its status will not change anymore
+func (e AsyncWalletTransaction) IsFinished() bool {
+	return e.Status == AsyncWalletTransactionStatusSucceeded || e.Status == AsyncWalletTransactionStatusFailed
+}
+
+// IsWalletTransactionRejected return true when the request is rejected by validation or business rule,
+// retrying the request will not change the outcome
+func IsWalletTransactionRejected(err error) bool {
+	return WalletTransactionErrorCode(err) != WalletTransactionErrorCodeInternal
+}
+
+// WalletTransactionErrorCode classify the error of creating wallet transaction,
+// the code is returned to asynchronous clients in place of the http status code
+func WalletTransactionErrorCode(err error) string {
+	if strings.Contains(err.Error(), "validation") ||
+		errors.Is(err, common.ErrInvalidAmount) ||
+		errors.Is(err, common.ErrMissingDescription) ||
+		errors.Is(err, common.ErrMissingDestinationAccountNumber) ||
+		errors.Is(err, common.ErrMissingCustomerNumberFromMetadata) ||
+		errors.Is(err, common.ErrMissingDisbursementDateFromMetadata) ||
+		errors.Is(err, common.ErrMissingVirtualAccountPointFromMetadata) ||
+		errors.Is(err, common.ErrMissingAgreementNumberFromMetadata) ||
+		errors.Is(err, common.ErrMissingRepaymentDateFromMetadata) ||
+		errors.Is(err, common.ErrMissingEntityFromMetadata) ||
+		errors.Is(err, common.ErrMissingPartnerPPOBFromMetadata) ||
+		errors.Is(err, common.ErrMissingLoanAccountNumberFromMetadata) ||
+		errors.Is(err, common.ErrMissingOldLoanAccountNumberFromMetadata) ||
+		errors.Is(err, common.ErrMissingNewLoanAccountNumberFromMetadata) ||
+		errors.Is(err, common.ErrMissingProductTypeFromMetadata) ||
+		errors.Is(err, common.ErrMissingLoanTypeFromMetadata) ||
+		errors.Is(err, common.ErrMissingLoanIdsFromMetadata) ||
+		errors.Is(err, common.ErrInvalidLoanIdsTypeMetadata) ||
+		errors.Is(err, common.ErrMissingDebitFromMetadata) ||
+		errors.Is(err, common.ErrMissingCreditFromMetadata) ||
+		errors.Is(err, common.ErrUnsupportedDescription) ||
+		errors.Is(err, common.ErrAccountNotExists) ||
+		errors.Is(err, common.ErrMissingWalletTransactionIdFromMetadata) {
+		return WalletTransactionErrorCodeInvalidRequest
+	}
+
+	if errors.Is(err, common.ErrUnsupportedReservedTransactionFlow) ||
+		errors.Is(err, common.ErrNegativeBalanceReached) ||
+		errors.Is(err, common.ErrInsufficientAvailableBalance) ||
+		errors.Is(err, common.ErrInsufficientPendingBalance) ||
+		errors.Is(err, common.ErrConfigAccountNumberNotFound) ||
+		errors.Is(err, common.ErrUnableGetTransformer) ||
+		errors.Is(err, common.ErrAccountNumberNotFoundInAccounting) ||
+		errors.Is(err, common.ErrInvestedAccountNumberNotFound) ||
+		errors.Is(err, common.ErrReceivableAccountNumberNotFound) ||
+		errors.Is(err, common.ErrrefNumberNotFound) ||
+		errors.Is(err, common.ErrUnsupportedTransactionFlow) ||
+		errors.Is(err, common.ErrInvalidRefundData) ||
+		errors.Is(err, common.ErrRefundAmountHigherThanOriginalAmount) {
+		return WalletTransactionErrorCodeUnprocessable
+	}
+
+	return WalletTransactionErrorCodeInternal
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalletTransactionErrorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "validation error",
+			err:  fmt.Errorf("validation error: %w", common.ErrInvalidTransactionType),
+			want: WalletTransactionErrorCodeInvalidRequest,
+		},
+		{
+			name: "invalid amount",
+			err:  common.ErrInvalidAmount,
+			want: WalletTransactionErrorCodeInvalidRequest,
+		},
+		{
+			name: "insufficient balance",
+			err:  fmt.Errorf("unable to update balance: %w", common.ErrInsufficientAvailableBalance),
+			want: WalletTransactionErrorCodeUnprocessable,
+		},
+		{
+			name: "unexpected error",
+			err:  errors.New("connection refused"),
+			want: WalletTransactionErrorCodeInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, WalletTransactionErrorCode(tt.err))
+			assert.Equal(t, tt.want != WalletTransactionErrorCodeInternal, IsWalletTransactionRejected(tt.err))
+		})
+	}
+}
+
+func TestNewAsyncWalletTransaction(t *testing.T) {
+	got := NewAsyncWalletTransaction(CreateWalletTransactionRequest{
+		AccountNumber:   "111",
+		RefNumber:       "REF1",
+		TransactionType: "TUPVA",
+		ClientId:        "async-client",
+		IdempotencyKey:  "idempotency-key",
+		AsyncId:         "0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e",
+	})
+
+	assert.Equal(t, AsyncWalletTransaction{
+		ID:              "0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e",
+		ClientId:        "async-client",
+		IdempotencyKey:  "idempotency-key",
+		RefNumber:       "REF1",
+		TransactionType: "TUPVA",
+		AccountNumber:   "111",
+		Status:          AsyncWalletTransactionStatusQueued,
+	}, got)
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+)
+
+// WalletTransactionBalance is the balance of an account before and after it is changed by a wallet transaction
+type WalletTransactionBalance struct {
+	WalletTransactionID string
+	AccountNumber       string
+	Before              Balance
+	After               Balance
+	CreatedAt           time.Time
+}
+
+// NewWalletTransactionBalances returns snapshot of every account changed from current to updated balances,
+// accounts which are not stored in database are skipped because their balance is not known
+func NewWalletTransactionBalances(walletTransactionID string, current, updated map[string]Balance) []WalletTransactionBalance {
+	var res []WalletTransactionBalance
+	for accountNumber, after := range updated {
+		before, ok := current[accountNumber]
+		if !ok || after.IsSkipBalanceUpdateOnDB() {
+			continue
+		}
+
+		if before.Actual().Equal(after.Actual()) && before.Pending().Equal(after.Pending()) {
+			continue
+		}
+
+		res = append(res, WalletTransactionBalance{
+			WalletTransactionID: walletTransactionID,
+			AccountNumber:       accountNumber,
+			Before:              before,
+			After:               after,
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].AccountNumber < res[j].AccountNumber
+	})
+
+	return res
+}
+
+func (e WalletTransactionBalance) ToModelResponse() WalletTransactionBalanceResponse {
+	return WalletTransactionBalanceResponse{
+		Kind:          "walletTransactionBalance",
+		AccountNumber: e.AccountNumber,
+		Currency:      IDRCurrency,
+		Before:        newBalanceSnapshotResponse(e.Before),
+		After:         newBalanceSnapshotResponse(e.After),
+		CreatedAt:     e.CreatedAt.In(common.GetLocation()).Format(common.DateFormatYYYYMMDDWithTimeAndOffset),
+	}
+}
+
+type WalletTransactionBalanceResponse struct {
+	Kind          string                  `json:"kind" example:"walletTransactionBalance"`
+	AccountNumber string                  `json:"accountNumber" example:"21100100000001"`
+	Currency      string                  `json:"currency" example:"IDR"`
+	Before        BalanceSnapshotResponse `json:"before"`
+	After         BalanceSnapshotResponse `json:"after"`
+	CreatedAt     string                  `json:"createdAt" example:"2024-01-22T15:51:43+0700"`
+}
+
+type BalanceSnapshotResponse struct {
+	ActualBalance    string `json:"actualBalance" example:"10000"`
+	PendingBalance   string `json:"pendingBalance" example:"10000"`
+	AvailableBalance string `json:"availableBalance" example:"10000"`
+}
+
+func newBalanceSnapshotResponse(b Balance) BalanceSnapshotResponse {
+	return BalanceSnapshotResponse{
+		ActualBalance:    b.Actual().String(),
+		PendingBalance:   b.Pending().String(),
+		AvailableBalance: b.Available().String(),
+	}
+}
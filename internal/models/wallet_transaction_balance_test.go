@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWalletTransactionBalances(t *testing.T) {
+	current := map[string]Balance{
+		"222": NewBalance(decimal.NewFromInt(100), decimal.Zero),
+		"111": NewBalance(decimal.NewFromInt(50), decimal.Zero),
+		"333": NewBalance(decimal.NewFromInt(10), decimal.Zero),
+		"444": NewBalance(decimal.Zero, decimal.Zero, WithSkipBalanceUpdateOnDB()),
+	}
+	updated := map[string]Balance{
+		"222": NewBalance(decimal.NewFromInt(90), decimal.Zero),
+		"111": NewBalance(decimal.NewFromInt(60), decimal.Zero),
+		"333": NewBalance(decimal.NewFromInt(10), decimal.Zero),
+		"444": NewBalance(decimal.NewFromInt(10), decimal.Zero, WithSkipBalanceUpdateOnDB()),
+	}
+
+	got := NewWalletTransactionBalances("trx-1", current, updated)
+
+	// unchanged account and account which is not stored in database have no snapshot
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, "111", got[0].AccountNumber)
+		assert.Equal(t, "trx-1", got[0].WalletTransactionID)
+		assert.True(t, decimal.NewFromInt(50).Equal(got[0].Before.Actual()))
+		assert.True(t, decimal.NewFromInt(60).Equal(got[0].After.Actual()))
+
+		assert.Equal(t, "222", got[1].AccountNumber)
+		assert.True(t, decimal.NewFromInt(100).Equal(got[1].Before.Actual()))
+		assert.True(t, decimal.NewFromInt(90).Equal(got[1].After.Actual()))
+	}
+}
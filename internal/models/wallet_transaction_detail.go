@@ -0,0 +1,114 @@
+package models
+
+import (
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+)
+
+const (
+	LedgerEntryDebit  = "DEBIT"
+	LedgerEntryCredit = "CREDIT"
+)
+
+// DoGetWalletTransactionDetailRequest is DTO object from handler, either TransactionId or RefNumber must be filled,
+// TransactionType is needed when the RefNumber is used by more than one wallet transaction
+type DoGetWalletTransactionDetailRequest struct {
+	TransactionId   string `param:"transactionId"`
+	RefNumber       string `query:"refNumber" example:"REF-0001"`
+	TransactionType string `query:"transactionType" example:"TUPVA"`
+}
+
+// WalletTransactionDetail is wallet transaction with every child transaction produced by the transformer
+// and the balances of accounts before and after they are changed by the wallet transaction
+type WalletTransactionDetail struct {
+	WalletTransaction
+	Transactions []Transaction
+	Balances     []WalletTransactionBalance
+}
+
+func (e WalletTransactionDetail) ToModelResponse() WalletTransactionDetailResponse {
+	res := WalletTransactionDetailResponse{
+		WalletTransactionResponse: e.WalletTransaction.ToResponse(),
+		CapturedAmount: Amount{
+			ValueDecimal: NewDecimalFromExternal(e.CapturedAmount),
+			Currency:     IDRCurrency,
+		},
+		CreatedAt:    e.CreatedAt.In(common.GetLocation()).Format(common.DateFormatYYYYMMDDWithTimeAndOffset),
+		Transactions: make([]WalletTransactionChildResponse, 0, len(e.Transactions)),
+		Balances:     make([]WalletTransactionBalanceResponse, 0, len(e.Balances)),
+	}
+
+	for _, trx := range e.Transactions {
+		res.Transactions = append(res.Transactions, newWalletTransactionChildResponse(trx))
+	}
+
+	for _, balance := range e.Balances {
+		res.Balances = append(res.Balances, balance.ToModelResponse())
+	}
+
+	return res
+}
+
+type WalletTransactionDetailResponse struct {
+	WalletTransactionResponse
+	CapturedAmount Amount                             `json:"capturedAmount"`
+	CreatedAt      string                             `json:"createdAt" example:"2024-01-22T15:51:43+0700"`
+	Transactions   []WalletTransactionChildResponse   `json:"transactions"`
+	Balances       []WalletTransactionBalanceResponse `json:"balances"`
+}
+
+// WalletTransactionChildResponse is a child transaction of wallet transaction,
+// every child transaction is posted as debit of fromAccount and credit of toAccount
+type WalletTransactionChildResponse struct {
+	Kind            string                `json:"kind" example:"transaction"`
+	ID              uint64                `json:"id"`
+	TransactionId   string                `json:"transactionId"`
+	TransactionType string                `json:"transactionType"`
+	TransactionDate string                `json:"transactionDate"`
+	TransactionTime string                `json:"transactionTime"`
+	FromAccount     string                `json:"fromAccount"`
+	ToAccount       string                `json:"toAccount"`
+	Amount          Amount                `json:"amount"`
+	Status          string                `json:"status"`
+	Method          string                `json:"method"`
+	Description     string                `json:"description"`
+	RefNumber       string                `json:"refNumber"`
+	Legs            []LedgerEntryResponse `json:"legs"`
+}
+
+type LedgerEntryResponse struct {
+	AccountNumber string `json:"accountNumber"`
+	Entry         string `json:"entry" example:"DEBIT"`
+	Amount        Amount `json:"amount"`
+}
+
+func newWalletTransactionChildResponse(trx Transaction) WalletTransactionChildResponse {
+	currency := trx.Currency
+	if currency == "" {
+		currency = IDRCurrency
+	}
+
+	amount := Amount{
+		ValueDecimal: NewDecimalFromExternal(trx.Amount.Decimal),
+		Currency:     currency,
+	}
+
+	return WalletTransactionChildResponse{
+		Kind:            "transaction",
+		ID:              trx.ID,
+		TransactionId:   trx.TransactionID,
+		TransactionType: trx.TypeTransaction,
+		TransactionDate: common.FormatDatetimeToString(trx.TransactionDate, common.DateFormatYYYYMMDD),
+		TransactionTime: trx.TransactionTime.In(common.GetLocation()).Format(common.DateFormatYYYYMMDDWithTimeAndOffset),
+		FromAccount:     trx.FromAccount,
+		ToAccount:       trx.ToAccount,
+		Amount:          amount,
+		Status:          trx.GetStatusString(),
+		Method:          trx.Method,
+		Description:     trx.Description,
+		RefNumber:       trx.RefNumber,
+		Legs: []LedgerEntryResponse{
+			{AccountNumber: trx.FromAccount, Entry: LedgerEntryDebit, Amount: amount},
+			{AccountNumber: trx.ToAccount, Entry: LedgerEntryCredit, Amount: amount},
+		},
+	}
+}
@@ -3,6 +3,8 @@ package monitoring
 import (
 	"time"
 
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/tracing"
+
 	xlog "bitbucket.org/Amartha/go-x/log"
 )
 
@@ -63,5 +65,9 @@ func (m *Monitor) Finish(opts ...FinishOption) {
 		m.segment.End()
 	}
 
+	if m.span != nil {
+		tracing.End(m.span, fOpts.err)
+	}
+
 	return
 }
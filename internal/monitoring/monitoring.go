@@ -8,6 +8,12 @@ import (
 	"time"
 
 	"github.com/newrelic/go-agent/v3/newrelic"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/tracing"
 )
 
 const (
@@ -28,6 +34,7 @@ type Monitor struct {
 
 	// add observability here
 	segment *newrelic.Segment
+	span    trace.Span
 }
 
 type initOptions struct {
@@ -49,7 +56,9 @@ func WithSegmentName(segmentName string) InitOption {
 	}
 }
 
-func New(ctx context.Context, opts ...InitOption) *Monitor {
+// Start start the monitor of the caller, the returned ctx has the span of the monitor
+// so the spans started from it, e.g. in the repository layer, are its children
+func Start(ctx context.Context, opts ...InitOption) (context.Context, *Monitor) {
 	fOpts := &initOptions{}
 	for _, opt := range opts {
 		opt(fOpts)
@@ -97,22 +106,34 @@ func New(ctx context.Context, opts ...InitOption) *Monitor {
 		segment.AddAttribute("layer", fOpts.layer)
 	}
 
-	return &Monitor{
+	attrs := []attribute.KeyValue{attribute.String("layer", fOpts.layer)}
+	if fOpts.layer == LayerRepository {
+		attrs = append(attrs, semconv.DBSystemNamePostgreSQL)
+	}
+	spanCtx, span := tracing.Tracer().Start(ctx, fOpts.segmentName, trace.WithAttributes(attrs...))
+	// ctx is returned as is when there is no trace, e.g. tracing is disabled and the caller is not traced
+	if span.SpanContext().IsValid() {
+		ctx = spanCtx
+	}
+
+	return ctx, &Monitor{
 		ctx:   ctx,
 		layer: fOpts.layer,
 		start: time.Now(),
 
 		segmentName: fOpts.segmentName,
 		segment:     segment,
+		span:        span,
 	}
 }
 
 func NewMiddlewareRoundTripper(next http.RoundTripper) http.RoundTripper {
-	// nr txn already exists on request.Context(), so no need to pass context
+	// nr txn and otel span already exist on request.Context(), so no need to pass context.
+	// otelhttp inject the traceparent header, the client span is the parent of the span of the server
 
 	if next == nil {
 		next = http.DefaultTransport
 	}
 
-	return newrelic.NewRoundTripper(next)
+	return otelhttp.NewTransport(newrelic.NewRoundTripper(next))
 }
@@ -0,0 +1,57 @@
+package monitoring
+
+import (
+	"context"
+	"testing"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func Test_Start(t *testing.T) {
+	xlog.InitForTest()
+
+	// the global provider can not be restored once it is set, the other tests use no-op one
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx, service := Start(context.Background(), WithLayer(LayerService), WithSegmentName("service.Create"))
+	_, repository := Start(ctx, WithLayer(LayerRepository), WithSegmentName("repository.Insert"))
+	repository.Finish(WithFinishCheckError(assert.AnError))
+	service.Finish()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "repository.Insert", spans[0].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("layer", LayerRepository))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.system.name", "postgresql"))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+
+	assert.Equal(t, "service.Create", spans[1].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
+
+func Test_Start_segmentName(t *testing.T) {
+	xlog.InitForTest()
+
+	// ctx is not changed when tracing is disabled
+	ctx := context.Background()
+	got, m := Start(ctx)
+	m.Finish()
+
+	assert.Equal(t, ctx, got)
+	assert.Equal(t, "monitoring.Test_Start_segmentName", m.segmentName)
+	assert.Equal(t, LayerUnknown, m.layer)
+}
@@ -26,8 +26,8 @@ type accountConfigRepository sqlRepo
 var _ AccountConfigRepository = (*accountConfigRepository)(nil)
 
 func (a *accountConfigRepository) GetWht2326(ctx context.Context, loanAccountNumber string, loanType string) (an string, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	account, err := a.r.GetAccountRepository().GetCachedAccount(
 		ctx,
@@ -53,8 +53,8 @@ func (a *accountConfigRepository) GetWht2326(ctx context.Context, loanAccountNum
 }
 
 func (a *accountConfigRepository) GetVatOut(ctx context.Context, loanAccountNumber string, loanType string) (an string, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	account, err := a.r.GetAccountRepository().GetCachedAccount(
 		ctx,
@@ -80,8 +80,8 @@ func (a *accountConfigRepository) GetVatOut(ctx context.Context, loanAccountNumb
 }
 
 func (a *accountConfigRepository) GetRevenue(ctx context.Context, loanAccountNumber string, loanType string) (an string, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	account, err := a.r.GetAccountRepository().GetCachedAccount(
 		ctx,
@@ -3,9 +3,9 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
-	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/safeaccess"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
@@ -26,6 +26,8 @@ type MasterDataRepository interface {
 	GetListOrderTypeCode(ctx context.Context) ([]string, error)
 	GetListTransactionTypeCode(ctx context.Context) ([]string, error)
 	RefreshDataPeriodically(ctx context.Context, interval time.Duration)
+	// LastRefreshedAt return the time of the last successful refresh, zero if data has never been loaded
+	LastRefreshedAt() time.Time
 
 	// GetConfigVATRevenue is get list PPN Amartha revenue
 	GetConfigVATRevenue(ctx context.Context) ([]models.ConfigVatRevenue, error)
@@ -40,6 +42,8 @@ type gcsMasterDataRepository struct {
 
 	orderTypeCodes       []string
 	transactionTypeCodes []string
+
+	refreshedAt atomic.Int64
 }
 
 func NewGCSMasterDataRepository(cfg *config.Config, opts ...option.ClientOption) (MasterDataRepository, error) {
@@ -72,20 +76,7 @@ func NewGCSMasterDataRepository(cfg *config.Config, opts ...option.ClientOption)
 }
 
 func (g *gcsMasterDataRepository) updateTransactionCodes(data []models.OrderType) {
-	var orderTypeCodes []string
-	for _, datum := range data {
-		orderTypeCodes = append(orderTypeCodes, datum.OrderTypeCode)
-	}
-
-	var transactionTypeCodes []string
-	for _, datum := range data {
-		for _, transactionType := range datum.TransactionTypes {
-			transactionTypeCodes = append(transactionTypeCodes, transactionType.TransactionTypeCode)
-		}
-	}
-
-	g.orderTypeCodes = orderTypeCodes
-	g.transactionTypeCodes = transactionTypeCodes
+	g.orderTypeCodes, g.transactionTypeCodes = collectMasterDataCodes(data, time.Now())
 }
 
 func (g *gcsMasterDataRepository) repopulate(ctx context.Context) error {
@@ -100,10 +91,20 @@ func (g *gcsMasterDataRepository) repopulate(ctx context.Context) error {
 	}
 
 	g.updateTransactionCodes(g.orderTypes.Value().Load())
+	g.refreshedAt.Store(time.Now().UnixNano())
 
 	return nil
 }
 
+func (g *gcsMasterDataRepository) LastRefreshedAt() time.Time {
+	refreshedAt := g.refreshedAt.Load()
+	if refreshedAt == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, refreshedAt)
+}
+
 func (g *gcsMasterDataRepository) RefreshDataPeriodically(ctx context.Context, interval time.Duration) {
 	err := g.repopulate(ctx)
 	if err != nil {
@@ -135,38 +136,16 @@ func (g *gcsMasterDataRepository) GetListTransactionTypeCode(ctx context.Context
 }
 
 func (g *gcsMasterDataRepository) GetListTransactionType(ctx context.Context, filter models.FilterMasterData) ([]models.TransactionType, error) {
-	var result []models.TransactionType
-	for _, v := range g.orderTypes.Value().Load() {
-		for _, transactionType := range v.TransactionTypes {
-			isMatchCode := filter.Code != "" && transactionType.TransactionTypeCode == filter.Code
-			isMatchName := filter.Name != "" && transactionType.TransactionTypeName == filter.Name
-
-			if (filter.Code == "" && filter.Name == "") || isMatchCode || isMatchName {
-				result = append(result, transactionType)
-			}
-		}
-	}
-
-	return result, nil
+	return filterTransactionTypes(g.orderTypes.Value().Load(), filter), nil
 }
 
 func (g *gcsMasterDataRepository) GetListOrderType(ctx context.Context, filter models.FilterMasterData) ([]models.OrderType, error) {
-	var result []models.OrderType
-	for _, orderType := range g.orderTypes.Value().Load() {
-		isMatchCode := filter.Code != "" && orderType.OrderTypeCode == filter.Code
-		isMatchName := filter.Name != "" && orderType.OrderTypeName == filter.Name
-
-		if (filter.Code == "" && filter.Name == "") || isMatchCode || isMatchName {
-			result = append(result, orderType)
-		}
-	}
-
-	return result, nil
+	return filterOrderTypes(g.orderTypes.Value().Load(), filter), nil
 }
 
 func (g *gcsMasterDataRepository) UpsertOrderType(ctx context.Context, orderType models.OrderType) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	orderTypes := g.orderTypes.Value().Load()
 
@@ -197,19 +176,11 @@ func (g *gcsMasterDataRepository) UpsertOrderType(ctx context.Context, orderType
 func (g *gcsMasterDataRepository) GetOrderType(ctx context.Context, orderTypeCode string) (*models.OrderType, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
-	var result *models.OrderType
-	for _, orderType := range g.orderTypes.Value().Load() {
-		if orderType.OrderTypeCode == orderTypeCode {
-			result = &orderType
-			break
-		}
-	}
-
-	if result == nil {
-		err = common.ErrDataNotFound
+	result, err := findOrderType(g.orderTypes.Value().Load(), orderTypeCode)
+	if err != nil {
 		return nil, err
 	}
 
@@ -219,21 +190,11 @@ func (g *gcsMasterDataRepository) GetOrderType(ctx context.Context, orderTypeCod
 func (g *gcsMasterDataRepository) GetTransactionType(ctx context.Context, transactionTypeCode string) (*models.TransactionType, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
-	var result *models.TransactionType
-	for _, orderType := range g.orderTypes.Value().Load() {
-		for _, transactionType := range orderType.TransactionTypes {
-			if transactionType.TransactionTypeCode == transactionTypeCode {
-				result = &transactionType
-				break
-			}
-		}
-	}
-
-	if result == nil {
-		err = common.ErrDataNotFound
+	result, err := findTransactionType(g.orderTypes.Value().Load(), transactionTypeCode)
+	if err != nil {
 		return nil, err
 	}
 
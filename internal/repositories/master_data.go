@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+)
+
+// backend of MasterDataRepository which is selected by config master_data.backend
+const (
+	MasterDataBackendGCS      = "gcs"
+	MasterDataBackendPostgres = "postgres"
+)
+
+// the helpers below are shared by every MasterDataRepository implementation,
+// so filtering behaves the same regardless of the backend
+
+// collectMasterDataCodes return codes which are accepted at the given time, inactive transaction type is excluded.
+// Codes are cached until the next refresh, so transaction type become accepted at most one refresh interval after its effectiveFrom.
+func collectMasterDataCodes(data []models.OrderType, now time.Time) (orderTypeCodes, transactionTypeCodes []string) {
+	for _, datum := range data {
+		orderTypeCodes = append(orderTypeCodes, datum.OrderTypeCode)
+	}
+
+	for _, datum := range data {
+		for _, transactionType := range datum.TransactionTypes {
+			if transactionType.IsActiveAt(now) {
+				transactionTypeCodes = append(transactionTypeCodes, transactionType.TransactionTypeCode)
+			}
+		}
+	}
+
+	return orderTypeCodes, transactionTypeCodes
+}
+
+func filterOrderTypes(data []models.OrderType, filter models.FilterMasterData) []models.OrderType {
+	var result []models.OrderType
+	for _, orderType := range data {
+		isMatchCode := filter.Code != "" && orderType.OrderTypeCode == filter.Code
+		isMatchName := filter.Name != "" && orderType.OrderTypeName == filter.Name
+
+		if (filter.Code == "" && filter.Name == "") || isMatchCode || isMatchName {
+			result = append(result, orderType)
+		}
+	}
+
+	return result
+}
+
+func filterTransactionTypes(data []models.OrderType, filter models.FilterMasterData) []models.TransactionType {
+	var result []models.TransactionType
+	for _, v := range data {
+		for _, transactionType := range v.TransactionTypes {
+			isMatchCode := filter.Code != "" && transactionType.TransactionTypeCode == filter.Code
+			isMatchName := filter.Name != "" && transactionType.TransactionTypeName == filter.Name
+
+			if (filter.Code == "" && filter.Name == "") || isMatchCode || isMatchName {
+				result = append(result, transactionType)
+			}
+		}
+	}
+
+	return result
+}
+
+func findOrderType(data []models.OrderType, orderTypeCode string) (*models.OrderType, error) {
+	for _, orderType := range data {
+		if orderType.OrderTypeCode == orderTypeCode {
+			return &orderType, nil
+		}
+	}
+
+	return nil, common.ErrDataNotFound
+}
+
+func findTransactionType(data []models.OrderType, transactionTypeCode string) (*models.TransactionType, error) {
+	for _, orderType := range data {
+		for _, transactionType := range orderType.TransactionTypes {
+			if transactionType.TransactionTypeCode == transactionTypeCode {
+				return &transactionType, nil
+			}
+		}
+	}
+
+	return nil, common.ErrDataNotFound
+}
+
+// CopyMasterData copy every order type and vat revenue config from src to dst.
+// It is used to migrate master data between backends, existing data in dst is replaced so it is safe to re-run.
+func CopyMasterData(ctx context.Context, src, dst MasterDataRepository) (err error) {
+	orderTypes, err := src.GetListOrderType(ctx, models.FilterMasterData{})
+	if err != nil {
+		return fmt.Errorf("failed to get source order types: %w", err)
+	}
+
+	if len(orderTypes) == 0 {
+		return errors.New("source master data is empty")
+	}
+
+	for _, orderType := range orderTypes {
+		// version of other backend is meaningless in dst
+		orderType.Version = 0
+
+		if err = dst.UpsertOrderType(ctx, orderType); err != nil {
+			return fmt.Errorf("failed to copy order type %s: %w", orderType.OrderTypeCode, err)
+		}
+	}
+
+	vatRevenue, err := src.GetConfigVATRevenue(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get source vat revenue: %w", err)
+	}
+
+	if err = dst.UpsertConfigVATRevenue(ctx, vatRevenue); err != nil {
+		return fmt.Errorf("failed to copy vat revenue: %w", err)
+	}
+
+	return nil
+}
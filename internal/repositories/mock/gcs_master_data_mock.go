@@ -147,6 +147,20 @@ func (mr *MockMasterDataRepositoryMockRecorder) GetTransactionType(ctx, transact
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionType", reflect.TypeOf((*MockMasterDataRepository)(nil).GetTransactionType), ctx, transactionTypeCode)
 }
 
+// LastRefreshedAt mocks base method.
+func (m *MockMasterDataRepository) LastRefreshedAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastRefreshedAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastRefreshedAt indicates an expected call of LastRefreshedAt.
+func (mr *MockMasterDataRepositoryMockRecorder) LastRefreshedAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastRefreshedAt", reflect.TypeOf((*MockMasterDataRepository)(nil).LastRefreshedAt))
+}
+
 // RefreshDataPeriodically mocks base method.
 func (m *MockMasterDataRepository) RefreshDataPeriodically(ctx context.Context, interval time.Duration) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,202 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/sql_approval.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repositories/sql_approval.go -destination=./internal/repositories/mock/sql_approval_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApprovalRepository is a mock of ApprovalRepository interface.
+type MockApprovalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockApprovalRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockApprovalRepositoryMockRecorder is the mock recorder for MockApprovalRepository.
+type MockApprovalRepositoryMockRecorder struct {
+	mock *MockApprovalRepository
+}
+
+// NewMockApprovalRepository creates a new mock instance.
+func NewMockApprovalRepository(ctrl *gomock.Controller) *MockApprovalRepository {
+	mock := &MockApprovalRepository{ctrl: ctrl}
+	mock.recorder = &MockApprovalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApprovalRepository) EXPECT() *MockApprovalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountAll mocks base method.
+func (m *MockApprovalRepository) CountAll(ctx context.Context, opts models.ApprovalFilterOptions) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx, opts)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockApprovalRepositoryMockRecorder) CountAll(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockApprovalRepository)(nil).CountAll), ctx, opts)
+}
+
+// Create mocks base method.
+func (m *MockApprovalRepository) Create(ctx context.Context, in *models.Approval) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockApprovalRepositoryMockRecorder) Create(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockApprovalRepository)(nil).Create), ctx, in)
+}
+
+// CreateActivity mocks base method.
+func (m *MockApprovalRepository) CreateActivity(ctx context.Context, in *models.ApprovalActivity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateActivity", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateActivity indicates an expected call of CreateActivity.
+func (mr *MockApprovalRepositoryMockRecorder) CreateActivity(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActivity", reflect.TypeOf((*MockApprovalRepository)(nil).CreateActivity), ctx, in)
+}
+
+// ExpirePending mocks base method.
+func (m *MockApprovalRepository) ExpirePending(ctx context.Context, now time.Time) ([]uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePending", ctx, now)
+	ret0, _ := ret[0].([]uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePending indicates an expected call of ExpirePending.
+func (mr *MockApprovalRepositoryMockRecorder) ExpirePending(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePending", reflect.TypeOf((*MockApprovalRepository)(nil).ExpirePending), ctx, now)
+}
+
+// GetByID mocks base method.
+func (m *MockApprovalRepository) GetByID(ctx context.Context, id uint64) (*models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApprovalRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApprovalRepository)(nil).GetByID), ctx, id)
+}
+
+// GetList mocks base method.
+func (m *MockApprovalRepository) GetList(ctx context.Context, opts models.ApprovalFilterOptions) ([]models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, opts)
+	ret0, _ := ret[0].([]models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockApprovalRepositoryMockRecorder) GetList(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockApprovalRepository)(nil).GetList), ctx, opts)
+}
+
+// ListActivities mocks base method.
+func (m *MockApprovalRepository) ListActivities(ctx context.Context, approvalID uint64) ([]models.ApprovalActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActivities", ctx, approvalID)
+	ret0, _ := ret[0].([]models.ApprovalActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActivities indicates an expected call of ListActivities.
+func (mr *MockApprovalRepositoryMockRecorder) ListActivities(ctx, approvalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActivities", reflect.TypeOf((*MockApprovalRepository)(nil).ListActivities), ctx, approvalID)
+}
+
+// UpdateState mocks base method.
+func (m *MockApprovalRepository) UpdateState(ctx context.Context, in *models.Approval, fromState models.ApprovalState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateState", ctx, in, fromState)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateState indicates an expected call of UpdateState.
+func (mr *MockApprovalRepositoryMockRecorder) UpdateState(ctx, in, fromState any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateState", reflect.TypeOf((*MockApprovalRepository)(nil).UpdateState), ctx, in, fromState)
+}
+
+// MockapprovalScanner is a mock of approvalScanner interface.
+type MockapprovalScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockapprovalScannerMockRecorder
+	isgomock struct{}
+}
+
+// MockapprovalScannerMockRecorder is the mock recorder for MockapprovalScanner.
+type MockapprovalScannerMockRecorder struct {
+	mock *MockapprovalScanner
+}
+
+// NewMockapprovalScanner creates a new mock instance.
+func NewMockapprovalScanner(ctrl *gomock.Controller) *MockapprovalScanner {
+	mock := &MockapprovalScanner{ctrl: ctrl}
+	mock.recorder = &MockapprovalScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockapprovalScanner) EXPECT() *MockapprovalScannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockapprovalScanner) Scan(dest ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range dest {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockapprovalScannerMockRecorder) Scan(dest ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockapprovalScanner)(nil).Scan), dest...)
+}
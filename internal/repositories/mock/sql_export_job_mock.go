@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/sql_export_job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repositories/sql_export_job.go -destination=./internal/repositories/mock/sql_export_job_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExportJobRepository is a mock of ExportJobRepository interface.
+type MockExportJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportJobRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockExportJobRepositoryMockRecorder is the mock recorder for MockExportJobRepository.
+type MockExportJobRepositoryMockRecorder struct {
+	mock *MockExportJobRepository
+}
+
+// NewMockExportJobRepository creates a new mock instance.
+func NewMockExportJobRepository(ctrl *gomock.Controller) *MockExportJobRepository {
+	mock := &MockExportJobRepository{ctrl: ctrl}
+	mock.recorder = &MockExportJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportJobRepository) EXPECT() *MockExportJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockExportJobRepository) Claim(ctx context.Context, id uint64, staleAfter time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, id, staleAfter)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockExportJobRepositoryMockRecorder) Claim(ctx, id, staleAfter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockExportJobRepository)(nil).Claim), ctx, id, staleAfter)
+}
+
+// Create mocks base method.
+func (m *MockExportJobRepository) Create(ctx context.Context, in *models.ExportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockExportJobRepositoryMockRecorder) Create(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockExportJobRepository)(nil).Create), ctx, in)
+}
+
+// Finish mocks base method.
+func (m *MockExportJobRepository) Finish(ctx context.Context, in *models.ExportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockExportJobRepositoryMockRecorder) Finish(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockExportJobRepository)(nil).Finish), ctx, in)
+}
+
+// GetByID mocks base method.
+func (m *MockExportJobRepository) GetByID(ctx context.Context, id uint64) (*models.ExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockExportJobRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockExportJobRepository)(nil).GetByID), ctx, id)
+}
+
+// UpdateProgress mocks base method.
+func (m *MockExportJobRepository) UpdateProgress(ctx context.Context, id uint64, totalRows, processedRows int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProgress", ctx, id, totalRows, processedRows)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProgress indicates an expected call of UpdateProgress.
+func (mr *MockExportJobRepositoryMockRecorder) UpdateProgress(ctx, id, totalRows, processedRows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProgress", reflect.TypeOf((*MockExportJobRepository)(nil).UpdateProgress), ctx, id, totalRows, processedRows)
+}
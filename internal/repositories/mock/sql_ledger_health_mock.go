@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/sql_ledger_health.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repositories/sql_ledger_health.go -destination=./internal/repositories/mock/sql_ledger_health_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLedgerHealthRepository is a mock of LedgerHealthRepository interface.
+type MockLedgerHealthRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerHealthRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLedgerHealthRepositoryMockRecorder is the mock recorder for MockLedgerHealthRepository.
+type MockLedgerHealthRepositoryMockRecorder struct {
+	mock *MockLedgerHealthRepository
+}
+
+// NewMockLedgerHealthRepository creates a new mock instance.
+func NewMockLedgerHealthRepository(ctrl *gomock.Controller) *MockLedgerHealthRepository {
+	mock := &MockLedgerHealthRepository{ctrl: ctrl}
+	mock.recorder = &MockLedgerHealthRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerHealthRepository) EXPECT() *MockLedgerHealthRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetMoneyFlowSummaries mocks base method.
+func (m *MockLedgerHealthRepository) GetMoneyFlowSummaries(ctx context.Context) ([]models.MoneyFlowStatusSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMoneyFlowSummaries", ctx)
+	ret0, _ := ret[0].([]models.MoneyFlowStatusSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMoneyFlowSummaries indicates an expected call of GetMoneyFlowSummaries.
+func (mr *MockLedgerHealthRepositoryMockRecorder) GetMoneyFlowSummaries(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoneyFlowSummaries", reflect.TypeOf((*MockLedgerHealthRepository)(nil).GetMoneyFlowSummaries), ctx)
+}
+
+// GetReservedWalletTransactions mocks base method.
+func (m *MockLedgerHealthRepository) GetReservedWalletTransactions(ctx context.Context) ([]models.ReservedWalletTransactionSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservedWalletTransactions", ctx)
+	ret0, _ := ret[0].([]models.ReservedWalletTransactionSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservedWalletTransactions indicates an expected call of GetReservedWalletTransactions.
+func (mr *MockLedgerHealthRepositoryMockRecorder) GetReservedWalletTransactions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservedWalletTransactions", reflect.TypeOf((*MockLedgerHealthRepository)(nil).GetReservedWalletTransactions), ctx)
+}
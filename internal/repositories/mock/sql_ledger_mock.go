@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/sql_ledger.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repositories/sql_ledger.go -destination=./internal/repositories/mock/sql_ledger_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	decimal "github.com/shopspring/decimal"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLedgerRepository is a mock of LedgerRepository interface.
+type MockLedgerRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockLedgerRepositoryMockRecorder is the mock recorder for MockLedgerRepository.
+type MockLedgerRepositoryMockRecorder struct {
+	mock *MockLedgerRepository
+}
+
+// NewMockLedgerRepository creates a new mock instance.
+func NewMockLedgerRepository(ctrl *gomock.Controller) *MockLedgerRepository {
+	mock := &MockLedgerRepository{ctrl: ctrl}
+	mock.recorder = &MockLedgerRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerRepository) EXPECT() *MockLedgerRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetOpeningBalance mocks base method.
+func (m *MockLedgerRepository) GetOpeningBalance(ctx context.Context, accountNumber string, date time.Time) (decimal.Decimal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpeningBalance", ctx, accountNumber, date)
+	ret0, _ := ret[0].(decimal.Decimal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpeningBalance indicates an expected call of GetOpeningBalance.
+func (mr *MockLedgerRepositoryMockRecorder) GetOpeningBalance(ctx, accountNumber, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpeningBalance", reflect.TypeOf((*MockLedgerRepository)(nil).GetOpeningBalance), ctx, accountNumber, date)
+}
+
+// GetPostings mocks base method.
+func (m *MockLedgerRepository) GetPostings(ctx context.Context, opts models.GeneralLedgerFilterOptions) ([]models.LedgerPosting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPostings", ctx, opts)
+	ret0, _ := ret[0].([]models.LedgerPosting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPostings indicates an expected call of GetPostings.
+func (mr *MockLedgerRepositoryMockRecorder) GetPostings(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPostings", reflect.TypeOf((*MockLedgerRepository)(nil).GetPostings), ctx, opts)
+}
+
+// GetTrialBalance mocks base method.
+func (m *MockLedgerRepository) GetTrialBalance(ctx context.Context, opts models.TrialBalanceFilterOptions) ([]models.TrialBalanceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrialBalance", ctx, opts)
+	ret0, _ := ret[0].([]models.TrialBalanceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrialBalance indicates an expected call of GetTrialBalance.
+func (mr *MockLedgerRepositoryMockRecorder) GetTrialBalance(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalance", reflect.TypeOf((*MockLedgerRepository)(nil).GetTrialBalance), ctx, opts)
+}
@@ -125,6 +125,34 @@ func (mr *MockSQLRepositoryMockRecorder) GetAccountRepository() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetAccountRepository))
 }
 
+// GetApprovalRepository mocks base method.
+func (m *MockSQLRepository) GetApprovalRepository() repositories.ApprovalRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApprovalRepository")
+	ret0, _ := ret[0].(repositories.ApprovalRepository)
+	return ret0
+}
+
+// GetApprovalRepository indicates an expected call of GetApprovalRepository.
+func (mr *MockSQLRepositoryMockRecorder) GetApprovalRepository() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApprovalRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetApprovalRepository))
+}
+
+// GetAsyncWalletTransactionRepository mocks base method.
+func (m *MockSQLRepository) GetAsyncWalletTransactionRepository() repositories.AsyncWalletTransactionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAsyncWalletTransactionRepository")
+	ret0, _ := ret[0].(repositories.AsyncWalletTransactionRepository)
+	return ret0
+}
+
+// GetAsyncWalletTransactionRepository indicates an expected call of GetAsyncWalletTransactionRepository.
+func (mr *MockSQLRepositoryMockRecorder) GetAsyncWalletTransactionRepository() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAsyncWalletTransactionRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetAsyncWalletTransactionRepository))
+}
+
 // GetBalanceRepository mocks base method.
 func (m *MockSQLRepository) GetBalanceRepository() repositories.BalanceRepository {
 	m.ctrl.T.Helper()
@@ -167,6 +195,20 @@ func (mr *MockSQLRepositoryMockRecorder) GetEntityRepository() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntityRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetEntityRepository))
 }
 
+// GetExportJobRepository mocks base method.
+func (m *MockSQLRepository) GetExportJobRepository() repositories.ExportJobRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExportJobRepository")
+	ret0, _ := ret[0].(repositories.ExportJobRepository)
+	return ret0
+}
+
+// GetExportJobRepository indicates an expected call of GetExportJobRepository.
+func (mr *MockSQLRepositoryMockRecorder) GetExportJobRepository() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExportJobRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetExportJobRepository))
+}
+
 // GetFeatureRepository mocks base method.
 func (m *MockSQLRepository) GetFeatureRepository() repositories.FeatureRepository {
 	m.ctrl.T.Helper()
@@ -181,6 +223,48 @@ func (mr *MockSQLRepositoryMockRecorder) GetFeatureRepository() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeatureRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetFeatureRepository))
 }
 
+// GetLedgerHealthRepository mocks base method.
+func (m *MockSQLRepository) GetLedgerHealthRepository() repositories.LedgerHealthRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLedgerHealthRepository")
+	ret0, _ := ret[0].(repositories.LedgerHealthRepository)
+	return ret0
+}
+
+// GetLedgerHealthRepository indicates an expected call of GetLedgerHealthRepository.
+func (mr *MockSQLRepositoryMockRecorder) GetLedgerHealthRepository() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedgerHealthRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetLedgerHealthRepository))
+}
+
+// GetLedgerRepository mocks base method.
+func (m *MockSQLRepository) GetLedgerRepository() repositories.LedgerRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLedgerRepository")
+	ret0, _ := ret[0].(repositories.LedgerRepository)
+	return ret0
+}
+
+// GetLedgerRepository indicates an expected call of GetLedgerRepository.
+func (mr *MockSQLRepositoryMockRecorder) GetLedgerRepository() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLedgerRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetLedgerRepository))
+}
+
+// GetMoneyFlowBusinessRuleRepository mocks base method.
+func (m *MockSQLRepository) GetMoneyFlowBusinessRuleRepository() repositories.MoneyFlowBusinessRuleRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMoneyFlowBusinessRuleRepository")
+	ret0, _ := ret[0].(repositories.MoneyFlowBusinessRuleRepository)
+	return ret0
+}
+
+// GetMoneyFlowBusinessRuleRepository indicates an expected call of GetMoneyFlowBusinessRuleRepository.
+func (mr *MockSQLRepositoryMockRecorder) GetMoneyFlowBusinessRuleRepository() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoneyFlowBusinessRuleRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetMoneyFlowBusinessRuleRepository))
+}
+
 // GetMoneyFlowCalcRepository mocks base method.
 func (m *MockSQLRepository) GetMoneyFlowCalcRepository() repositories.MoneyFlowRepository {
 	m.ctrl.T.Helper()
@@ -195,6 +279,20 @@ func (mr *MockSQLRepositoryMockRecorder) GetMoneyFlowCalcRepository() *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMoneyFlowCalcRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetMoneyFlowCalcRepository))
 }
 
+// GetReconExceptionRepository mocks base method.
+func (m *MockSQLRepository) GetReconExceptionRepository() repositories.ReconExceptionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReconExceptionRepository")
+	ret0, _ := ret[0].(repositories.ReconExceptionRepository)
+	return ret0
+}
+
+// GetReconExceptionRepository indicates an expected call of GetReconExceptionRepository.
+func (mr *MockSQLRepositoryMockRecorder) GetReconExceptionRepository() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReconExceptionRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetReconExceptionRepository))
+}
+
 // GetReconToolHistoryRepository mocks base method.
 func (m *MockSQLRepository) GetReconToolHistoryRepository() repositories.ReconToolHistoryRepository {
 	m.ctrl.T.Helper()
@@ -209,6 +307,20 @@ func (mr *MockSQLRepositoryMockRecorder) GetReconToolHistoryRepository() *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReconToolHistoryRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetReconToolHistoryRepository))
 }
 
+// GetReportRunRepository mocks base method.
+func (m *MockSQLRepository) GetReportRunRepository() repositories.ReportRunRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportRunRepository")
+	ret0, _ := ret[0].(repositories.ReportRunRepository)
+	return ret0
+}
+
+// GetReportRunRepository indicates an expected call of GetReportRunRepository.
+func (mr *MockSQLRepositoryMockRecorder) GetReportRunRepository() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportRunRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetReportRunRepository))
+}
+
 // GetSubCategoryRepository mocks base method.
 func (m *MockSQLRepository) GetSubCategoryRepository() repositories.SubCategoryRepository {
 	m.ctrl.T.Helper()
@@ -223,6 +335,34 @@ func (mr *MockSQLRepositoryMockRecorder) GetSubCategoryRepository() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubCategoryRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetSubCategoryRepository))
 }
 
+// GetTaxRateRepository mocks base method.
+func (m *MockSQLRepository) GetTaxRateRepository() repositories.TaxRateRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRateRepository")
+	ret0, _ := ret[0].(repositories.TaxRateRepository)
+	return ret0
+}
+
+// GetTaxRateRepository indicates an expected call of GetTaxRateRepository.
+func (mr *MockSQLRepositoryMockRecorder) GetTaxRateRepository() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRateRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetTaxRateRepository))
+}
+
+// GetTransactionMetricRepository mocks base method.
+func (m *MockSQLRepository) GetTransactionMetricRepository() repositories.TransactionMetricRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionMetricRepository")
+	ret0, _ := ret[0].(repositories.TransactionMetricRepository)
+	return ret0
+}
+
+// GetTransactionMetricRepository indicates an expected call of GetTransactionMetricRepository.
+func (mr *MockSQLRepositoryMockRecorder) GetTransactionMetricRepository() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionMetricRepository", reflect.TypeOf((*MockSQLRepository)(nil).GetTransactionMetricRepository))
+}
+
 // GetTransactionRepository mocks base method.
 func (m *MockSQLRepository) GetTransactionRepository() repositories.TransactionRepository {
 	m.ctrl.T.Helper()
@@ -0,0 +1,199 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/sql_money_flow_business_rule.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repositories/sql_money_flow_business_rule.go -destination=./internal/repositories/mock/sql_money_flow_business_rule_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMoneyFlowBusinessRuleRepository is a mock of MoneyFlowBusinessRuleRepository interface.
+type MockMoneyFlowBusinessRuleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMoneyFlowBusinessRuleRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMoneyFlowBusinessRuleRepositoryMockRecorder is the mock recorder for MockMoneyFlowBusinessRuleRepository.
+type MockMoneyFlowBusinessRuleRepositoryMockRecorder struct {
+	mock *MockMoneyFlowBusinessRuleRepository
+}
+
+// NewMockMoneyFlowBusinessRuleRepository creates a new mock instance.
+func NewMockMoneyFlowBusinessRuleRepository(ctrl *gomock.Controller) *MockMoneyFlowBusinessRuleRepository {
+	mock := &MockMoneyFlowBusinessRuleRepository{ctrl: ctrl}
+	mock.recorder = &MockMoneyFlowBusinessRuleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMoneyFlowBusinessRuleRepository) EXPECT() *MockMoneyFlowBusinessRuleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ArchivePublished mocks base method.
+func (m *MockMoneyFlowBusinessRuleRepository) ArchivePublished(ctx context.Context, actor string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchivePublished", ctx, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ArchivePublished indicates an expected call of ArchivePublished.
+func (mr *MockMoneyFlowBusinessRuleRepositoryMockRecorder) ArchivePublished(ctx, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchivePublished", reflect.TypeOf((*MockMoneyFlowBusinessRuleRepository)(nil).ArchivePublished), ctx, actor)
+}
+
+// Create mocks base method.
+func (m *MockMoneyFlowBusinessRuleRepository) Create(ctx context.Context, in *models.MoneyFlowBusinessRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMoneyFlowBusinessRuleRepositoryMockRecorder) Create(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMoneyFlowBusinessRuleRepository)(nil).Create), ctx, in)
+}
+
+// DeleteDraft mocks base method.
+func (m *MockMoneyFlowBusinessRuleRepository) DeleteDraft(ctx context.Context, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDraft", ctx, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDraft indicates an expected call of DeleteDraft.
+func (mr *MockMoneyFlowBusinessRuleRepositoryMockRecorder) DeleteDraft(ctx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDraft", reflect.TypeOf((*MockMoneyFlowBusinessRuleRepository)(nil).DeleteDraft), ctx, version)
+}
+
+// GetByVersion mocks base method.
+func (m *MockMoneyFlowBusinessRuleRepository) GetByVersion(ctx context.Context, version int64) (*models.MoneyFlowBusinessRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByVersion", ctx, version)
+	ret0, _ := ret[0].(*models.MoneyFlowBusinessRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByVersion indicates an expected call of GetByVersion.
+func (mr *MockMoneyFlowBusinessRuleRepositoryMockRecorder) GetByVersion(ctx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByVersion", reflect.TypeOf((*MockMoneyFlowBusinessRuleRepository)(nil).GetByVersion), ctx, version)
+}
+
+// GetList mocks base method.
+func (m *MockMoneyFlowBusinessRuleRepository) GetList(ctx context.Context, opts models.MoneyFlowBusinessRuleFilterOptions) ([]models.MoneyFlowBusinessRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, opts)
+	ret0, _ := ret[0].([]models.MoneyFlowBusinessRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockMoneyFlowBusinessRuleRepositoryMockRecorder) GetList(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockMoneyFlowBusinessRuleRepository)(nil).GetList), ctx, opts)
+}
+
+// GetPublished mocks base method.
+func (m *MockMoneyFlowBusinessRuleRepository) GetPublished(ctx context.Context) (*models.MoneyFlowBusinessRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublished", ctx)
+	ret0, _ := ret[0].(*models.MoneyFlowBusinessRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublished indicates an expected call of GetPublished.
+func (mr *MockMoneyFlowBusinessRuleRepositoryMockRecorder) GetPublished(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublished", reflect.TypeOf((*MockMoneyFlowBusinessRuleRepository)(nil).GetPublished), ctx)
+}
+
+// Publish mocks base method.
+func (m *MockMoneyFlowBusinessRuleRepository) Publish(ctx context.Context, version int64, actor string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, version, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockMoneyFlowBusinessRuleRepositoryMockRecorder) Publish(ctx, version, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockMoneyFlowBusinessRuleRepository)(nil).Publish), ctx, version, actor)
+}
+
+// UpdateDraft mocks base method.
+func (m *MockMoneyFlowBusinessRuleRepository) UpdateDraft(ctx context.Context, in *models.MoneyFlowBusinessRule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDraft", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDraft indicates an expected call of UpdateDraft.
+func (mr *MockMoneyFlowBusinessRuleRepositoryMockRecorder) UpdateDraft(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDraft", reflect.TypeOf((*MockMoneyFlowBusinessRuleRepository)(nil).UpdateDraft), ctx, in)
+}
+
+// MockmoneyFlowBusinessRuleScanner is a mock of moneyFlowBusinessRuleScanner interface.
+type MockmoneyFlowBusinessRuleScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockmoneyFlowBusinessRuleScannerMockRecorder
+	isgomock struct{}
+}
+
+// MockmoneyFlowBusinessRuleScannerMockRecorder is the mock recorder for MockmoneyFlowBusinessRuleScanner.
+type MockmoneyFlowBusinessRuleScannerMockRecorder struct {
+	mock *MockmoneyFlowBusinessRuleScanner
+}
+
+// NewMockmoneyFlowBusinessRuleScanner creates a new mock instance.
+func NewMockmoneyFlowBusinessRuleScanner(ctrl *gomock.Controller) *MockmoneyFlowBusinessRuleScanner {
+	mock := &MockmoneyFlowBusinessRuleScanner{ctrl: ctrl}
+	mock.recorder = &MockmoneyFlowBusinessRuleScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockmoneyFlowBusinessRuleScanner) EXPECT() *MockmoneyFlowBusinessRuleScannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockmoneyFlowBusinessRuleScanner) Scan(dest ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range dest {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockmoneyFlowBusinessRuleScannerMockRecorder) Scan(dest ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockmoneyFlowBusinessRuleScanner)(nil).Scan), dest...)
+}
@@ -42,6 +42,22 @@ func (m *MockMoneyFlowRepository) EXPECT() *MockMoneyFlowRepositoryMockRecorder
 	return m.recorder
 }
 
+// ClaimSummaryForDisbursement mocks base method.
+func (m *MockMoneyFlowRepository) ClaimSummaryForDisbursement(ctx context.Context, summaryID string, claimedBefore time.Time) (string, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimSummaryForDisbursement", ctx, summaryID, claimedBefore)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ClaimSummaryForDisbursement indicates an expected call of ClaimSummaryForDisbursement.
+func (mr *MockMoneyFlowRepositoryMockRecorder) ClaimSummaryForDisbursement(ctx, summaryID, claimedBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimSummaryForDisbursement", reflect.TypeOf((*MockMoneyFlowRepository)(nil).ClaimSummaryForDisbursement), ctx, summaryID, claimedBefore)
+}
+
 // CountDetailedTransactions mocks base method.
 func (m *MockMoneyFlowRepository) CountDetailedTransactions(ctx context.Context, opts models.DetailedTransactionFilterOptions) (int, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +117,20 @@ func (mr *MockMoneyFlowRepositoryMockRecorder) CreateSummary(ctx, in any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSummary", reflect.TypeOf((*MockMoneyFlowRepository)(nil).CreateSummary), ctx, in)
 }
 
+// CreateSummaryEvent mocks base method.
+func (m *MockMoneyFlowRepository) CreateSummaryEvent(ctx context.Context, in models.CreateMoneyFlowSummaryEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSummaryEvent", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSummaryEvent indicates an expected call of CreateSummaryEvent.
+func (mr *MockMoneyFlowRepositoryMockRecorder) CreateSummaryEvent(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSummaryEvent", reflect.TypeOf((*MockMoneyFlowRepository)(nil).CreateSummaryEvent), ctx, in)
+}
+
 // EstimateCountDetailedTransactions mocks base method.
 func (m *MockMoneyFlowRepository) EstimateCountDetailedTransactions(ctx context.Context, opts models.DetailedTransactionFilterOptions) (int, error) {
 	m.ctrl.T.Helper()
@@ -207,6 +237,36 @@ func (mr *MockMoneyFlowRepositoryMockRecorder) GetLastFailedOrRejectedTransactio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastFailedOrRejectedTransaction", reflect.TypeOf((*MockMoneyFlowRepository)(nil).GetLastFailedOrRejectedTransaction), ctx, transactionType, paymentType)
 }
 
+// GetLastPapaEventTime mocks base method.
+func (m *MockMoneyFlowRepository) GetLastPapaEventTime(ctx context.Context, summaryID string) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastPapaEventTime", ctx, summaryID)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastPapaEventTime indicates an expected call of GetLastPapaEventTime.
+func (mr *MockMoneyFlowRepositoryMockRecorder) GetLastPapaEventTime(ctx, summaryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastPapaEventTime", reflect.TypeOf((*MockMoneyFlowRepository)(nil).GetLastPapaEventTime), ctx, summaryID)
+}
+
+// GetPendingSummariesForDisbursement mocks base method.
+func (m *MockMoneyFlowRepository) GetPendingSummariesForDisbursement(ctx context.Context, paymentType string, beforeDate, claimedBefore time.Time, limit int) ([]models.MoneyFlowSummaryForDisbursement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingSummariesForDisbursement", ctx, paymentType, beforeDate, claimedBefore, limit)
+	ret0, _ := ret[0].([]models.MoneyFlowSummaryForDisbursement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingSummariesForDisbursement indicates an expected call of GetPendingSummariesForDisbursement.
+func (mr *MockMoneyFlowRepositoryMockRecorder) GetPendingSummariesForDisbursement(ctx, paymentType, beforeDate, claimedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingSummariesForDisbursement", reflect.TypeOf((*MockMoneyFlowRepository)(nil).GetPendingSummariesForDisbursement), ctx, paymentType, beforeDate, claimedBefore, limit)
+}
+
 // GetSummariesList mocks base method.
 func (m *MockMoneyFlowRepository) GetSummariesList(ctx context.Context, opts models.MoneyFlowSummaryFilterOptions) ([]models.MoneyFlowSummaryOut, error) {
 	m.ctrl.T.Helper()
@@ -252,6 +312,21 @@ func (mr *MockMoneyFlowRepositoryMockRecorder) GetSummaryDetailBySummaryIDAllSta
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummaryDetailBySummaryIDAllStatus", reflect.TypeOf((*MockMoneyFlowRepository)(nil).GetSummaryDetailBySummaryIDAllStatus), ctx, summaryID)
 }
 
+// GetSummaryEvents mocks base method.
+func (m *MockMoneyFlowRepository) GetSummaryEvents(ctx context.Context, summaryID string) ([]models.MoneyFlowSummaryEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSummaryEvents", ctx, summaryID)
+	ret0, _ := ret[0].([]models.MoneyFlowSummaryEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSummaryEvents indicates an expected call of GetSummaryEvents.
+func (mr *MockMoneyFlowRepositoryMockRecorder) GetSummaryEvents(ctx, summaryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummaryEvents", reflect.TypeOf((*MockMoneyFlowRepository)(nil).GetSummaryEvents), ctx, summaryID)
+}
+
 // GetSummaryIDByPapaTransactionID mocks base method.
 func (m *MockMoneyFlowRepository) GetSummaryIDByPapaTransactionID(ctx context.Context, papaTransactionID string) (string, error) {
 	m.ctrl.T.Helper()
@@ -267,6 +342,21 @@ func (mr *MockMoneyFlowRepositoryMockRecorder) GetSummaryIDByPapaTransactionID(c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummaryIDByPapaTransactionID", reflect.TypeOf((*MockMoneyFlowRepository)(nil).GetSummaryIDByPapaTransactionID), ctx, papaTransactionID)
 }
 
+// GetSummaryStatusForUpdate mocks base method.
+func (m *MockMoneyFlowRepository) GetSummaryStatusForUpdate(ctx context.Context, summaryID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSummaryStatusForUpdate", ctx, summaryID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSummaryStatusForUpdate indicates an expected call of GetSummaryStatusForUpdate.
+func (mr *MockMoneyFlowRepositoryMockRecorder) GetSummaryStatusForUpdate(ctx, summaryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummaryStatusForUpdate", reflect.TypeOf((*MockMoneyFlowRepository)(nil).GetSummaryStatusForUpdate), ctx, summaryID)
+}
+
 // GetTransactionProcessed mocks base method.
 func (m *MockMoneyFlowRepository) GetTransactionProcessed(ctx context.Context, breakdownTransactionsFrom string, transactionSourceDate time.Time) (*models.MoneyFlowTransactionProcessed, error) {
 	m.ctrl.T.Helper()
@@ -342,6 +432,21 @@ func (mr *MockMoneyFlowRepositoryMockRecorder) HasPendingTransactionBefore(ctx,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPendingTransactionBefore", reflect.TypeOf((*MockMoneyFlowRepository)(nil).HasPendingTransactionBefore), ctx, transactionType, paymentType, transactionDate)
 }
 
+// IsSummaryDisbursing mocks base method.
+func (m *MockMoneyFlowRepository) IsSummaryDisbursing(ctx context.Context, referenceNumber string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSummaryDisbursing", ctx, referenceNumber)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSummaryDisbursing indicates an expected call of IsSummaryDisbursing.
+func (mr *MockMoneyFlowRepositoryMockRecorder) IsSummaryDisbursing(ctx, referenceNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSummaryDisbursing", reflect.TypeOf((*MockMoneyFlowRepository)(nil).IsSummaryDisbursing), ctx, referenceNumber)
+}
+
 // UpdateActivationStatus mocks base method.
 func (m *MockMoneyFlowRepository) UpdateActivationStatus(ctx context.Context, summaryID string, isActive bool) error {
 	m.ctrl.T.Helper()
@@ -369,3 +474,19 @@ func (mr *MockMoneyFlowRepositoryMockRecorder) UpdateSummary(ctx, summaryID, upd
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSummary", reflect.TypeOf((*MockMoneyFlowRepository)(nil).UpdateSummary), ctx, summaryID, update)
 }
+
+// UpsertSummary mocks base method.
+func (m *MockMoneyFlowRepository) UpsertSummary(ctx context.Context, in models.CreateMoneyFlowSummary) (string, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertSummary", ctx, in)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpsertSummary indicates an expected call of UpsertSummary.
+func (mr *MockMoneyFlowRepositoryMockRecorder) UpsertSummary(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertSummary", reflect.TypeOf((*MockMoneyFlowRepository)(nil).UpsertSummary), ctx, in)
+}
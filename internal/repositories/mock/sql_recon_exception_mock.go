@@ -0,0 +1,230 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/sql_recon_exception.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repositories/sql_recon_exception.go -destination=./internal/repositories/mock/sql_recon_exception_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReconExceptionRepository is a mock of ReconExceptionRepository interface.
+type MockReconExceptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconExceptionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReconExceptionRepositoryMockRecorder is the mock recorder for MockReconExceptionRepository.
+type MockReconExceptionRepositoryMockRecorder struct {
+	mock *MockReconExceptionRepository
+}
+
+// NewMockReconExceptionRepository creates a new mock instance.
+func NewMockReconExceptionRepository(ctrl *gomock.Controller) *MockReconExceptionRepository {
+	mock := &MockReconExceptionRepository{ctrl: ctrl}
+	mock.recorder = &MockReconExceptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconExceptionRepository) EXPECT() *MockReconExceptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BulkCreate mocks base method.
+func (m *MockReconExceptionRepository) BulkCreate(ctx context.Context, in []models.ReconException) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreate", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkCreate indicates an expected call of BulkCreate.
+func (mr *MockReconExceptionRepositoryMockRecorder) BulkCreate(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreate", reflect.TypeOf((*MockReconExceptionRepository)(nil).BulkCreate), ctx, in)
+}
+
+// CountAll mocks base method.
+func (m *MockReconExceptionRepository) CountAll(ctx context.Context, opts models.ReconExceptionFilterOptions) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountAll", ctx, opts)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountAll indicates an expected call of CountAll.
+func (mr *MockReconExceptionRepositoryMockRecorder) CountAll(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountAll", reflect.TypeOf((*MockReconExceptionRepository)(nil).CountAll), ctx, opts)
+}
+
+// CreateActivity mocks base method.
+func (m *MockReconExceptionRepository) CreateActivity(ctx context.Context, in *models.ReconExceptionActivity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateActivity", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateActivity indicates an expected call of CreateActivity.
+func (mr *MockReconExceptionRepositoryMockRecorder) CreateActivity(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActivity", reflect.TypeOf((*MockReconExceptionRepository)(nil).CreateActivity), ctx, in)
+}
+
+// DeleteUntouchedByReconHistoryID mocks base method.
+func (m *MockReconExceptionRepository) DeleteUntouchedByReconHistoryID(ctx context.Context, reconHistoryID uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUntouchedByReconHistoryID", ctx, reconHistoryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUntouchedByReconHistoryID indicates an expected call of DeleteUntouchedByReconHistoryID.
+func (mr *MockReconExceptionRepositoryMockRecorder) DeleteUntouchedByReconHistoryID(ctx, reconHistoryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUntouchedByReconHistoryID", reflect.TypeOf((*MockReconExceptionRepository)(nil).DeleteUntouchedByReconHistoryID), ctx, reconHistoryID)
+}
+
+// GetByID mocks base method.
+func (m *MockReconExceptionRepository) GetByID(ctx context.Context, id uint64) (*models.ReconException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ReconException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockReconExceptionRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockReconExceptionRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockReconExceptionRepository) GetByIDForUpdate(ctx context.Context, id uint64) (*models.ReconException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*models.ReconException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockReconExceptionRepositoryMockRecorder) GetByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockReconExceptionRepository)(nil).GetByIDForUpdate), ctx, id)
+}
+
+// GetByReconHistoryID mocks base method.
+func (m *MockReconExceptionRepository) GetByReconHistoryID(ctx context.Context, reconHistoryID uint64) ([]models.ReconException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByReconHistoryID", ctx, reconHistoryID)
+	ret0, _ := ret[0].([]models.ReconException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByReconHistoryID indicates an expected call of GetByReconHistoryID.
+func (mr *MockReconExceptionRepositoryMockRecorder) GetByReconHistoryID(ctx, reconHistoryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByReconHistoryID", reflect.TypeOf((*MockReconExceptionRepository)(nil).GetByReconHistoryID), ctx, reconHistoryID)
+}
+
+// GetList mocks base method.
+func (m *MockReconExceptionRepository) GetList(ctx context.Context, opts models.ReconExceptionFilterOptions) ([]models.ReconException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, opts)
+	ret0, _ := ret[0].([]models.ReconException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockReconExceptionRepositoryMockRecorder) GetList(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockReconExceptionRepository)(nil).GetList), ctx, opts)
+}
+
+// ListActivities mocks base method.
+func (m *MockReconExceptionRepository) ListActivities(ctx context.Context, reconExceptionID uint64) ([]models.ReconExceptionActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActivities", ctx, reconExceptionID)
+	ret0, _ := ret[0].([]models.ReconExceptionActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActivities indicates an expected call of ListActivities.
+func (mr *MockReconExceptionRepositoryMockRecorder) ListActivities(ctx, reconExceptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActivities", reflect.TypeOf((*MockReconExceptionRepository)(nil).ListActivities), ctx, reconExceptionID)
+}
+
+// Update mocks base method.
+func (m *MockReconExceptionRepository) Update(ctx context.Context, in *models.ReconException) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockReconExceptionRepositoryMockRecorder) Update(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockReconExceptionRepository)(nil).Update), ctx, in)
+}
+
+// MockreconExceptionScanner is a mock of reconExceptionScanner interface.
+type MockreconExceptionScanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockreconExceptionScannerMockRecorder
+	isgomock struct{}
+}
+
+// MockreconExceptionScannerMockRecorder is the mock recorder for MockreconExceptionScanner.
+type MockreconExceptionScannerMockRecorder struct {
+	mock *MockreconExceptionScanner
+}
+
+// NewMockreconExceptionScanner creates a new mock instance.
+func NewMockreconExceptionScanner(ctrl *gomock.Controller) *MockreconExceptionScanner {
+	mock := &MockreconExceptionScanner{ctrl: ctrl}
+	mock.recorder = &MockreconExceptionScannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockreconExceptionScanner) EXPECT() *MockreconExceptionScannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *MockreconExceptionScanner) Scan(dest ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range dest {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockreconExceptionScannerMockRecorder) Scan(dest ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockreconExceptionScanner)(nil).Scan), dest...)
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/sql_report_run.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repositories/sql_report_run.go -destination=./internal/repositories/mock/sql_report_run_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReportRunRepository is a mock of ReportRunRepository interface.
+type MockReportRunRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReportRunRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockReportRunRepositoryMockRecorder is the mock recorder for MockReportRunRepository.
+type MockReportRunRepositoryMockRecorder struct {
+	mock *MockReportRunRepository
+}
+
+// NewMockReportRunRepository creates a new mock instance.
+func NewMockReportRunRepository(ctrl *gomock.Controller) *MockReportRunRepository {
+	mock := &MockReportRunRepository{ctrl: ctrl}
+	mock.recorder = &MockReportRunRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReportRunRepository) EXPECT() *MockReportRunRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Finish mocks base method.
+func (m *MockReportRunRepository) Finish(ctx context.Context, in *models.ReportRun) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockReportRunRepositoryMockRecorder) Finish(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockReportRunRepository)(nil).Finish), ctx, in)
+}
+
+// Start mocks base method.
+func (m *MockReportRunRepository) Start(ctx context.Context, in *models.ReportRun, staleAfter time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, in, staleAfter)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockReportRunRepositoryMockRecorder) Start(ctx, in, staleAfter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockReportRunRepository)(nil).Start), ctx, in, staleAfter)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/sql_tax_rate.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repositories/sql_tax_rate.go -destination=./internal/repositories/mock/sql_tax_rate_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaxRateRepository is a mock of TaxRateRepository interface.
+type MockTaxRateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxRateRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTaxRateRepositoryMockRecorder is the mock recorder for MockTaxRateRepository.
+type MockTaxRateRepositoryMockRecorder struct {
+	mock *MockTaxRateRepository
+}
+
+// NewMockTaxRateRepository creates a new mock instance.
+func NewMockTaxRateRepository(ctrl *gomock.Controller) *MockTaxRateRepository {
+	mock := &MockTaxRateRepository{ctrl: ctrl}
+	mock.recorder = &MockTaxRateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxRateRepository) EXPECT() *MockTaxRateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTaxRateRepository) Create(ctx context.Context, in *models.TaxRate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTaxRateRepositoryMockRecorder) Create(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTaxRateRepository)(nil).Create), ctx, in)
+}
+
+// GetAll mocks base method.
+func (m *MockTaxRateRepository) GetAll(ctx context.Context) (models.TaxRates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].(models.TaxRates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTaxRateRepositoryMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTaxRateRepository)(nil).GetAll), ctx)
+}
+
+// GetTaxCollected mocks base method.
+func (m *MockTaxRateRepository) GetTaxCollected(ctx context.Context, opts models.TaxReportFilterOptions) ([]models.TaxCollected, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxCollected", ctx, opts)
+	ret0, _ := ret[0].([]models.TaxCollected)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxCollected indicates an expected call of GetTaxCollected.
+func (mr *MockTaxRateRepositoryMockRecorder) GetTaxCollected(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxCollected", reflect.TypeOf((*MockTaxRateRepository)(nil).GetTaxCollected), ctx, opts)
+}
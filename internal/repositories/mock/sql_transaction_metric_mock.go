@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/sql_transaction_metric.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repositories/sql_transaction_metric.go -destination=./internal/repositories/mock/sql_transaction_metric_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransactionMetricRepository is a mock of TransactionMetricRepository interface.
+type MockTransactionMetricRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionMetricRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionMetricRepositoryMockRecorder is the mock recorder for MockTransactionMetricRepository.
+type MockTransactionMetricRepositoryMockRecorder struct {
+	mock *MockTransactionMetricRepository
+}
+
+// NewMockTransactionMetricRepository creates a new mock instance.
+func NewMockTransactionMetricRepository(ctrl *gomock.Controller) *MockTransactionMetricRepository {
+	mock := &MockTransactionMetricRepository{ctrl: ctrl}
+	mock.recorder = &MockTransactionMetricRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionMetricRepository) EXPECT() *MockTransactionMetricRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AggregateTransactions mocks base method.
+func (m *MockTransactionMetricRepository) AggregateTransactions(ctx context.Context, dates []time.Time, opts models.TransactionMetricFilterOptions) ([]models.TransactionDailyAggregate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AggregateTransactions", ctx, dates, opts)
+	ret0, _ := ret[0].([]models.TransactionDailyAggregate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AggregateTransactions indicates an expected call of AggregateTransactions.
+func (mr *MockTransactionMetricRepositoryMockRecorder) AggregateTransactions(ctx, dates, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateTransactions", reflect.TypeOf((*MockTransactionMetricRepository)(nil).AggregateTransactions), ctx, dates, opts)
+}
+
+// GetAggregatedDates mocks base method.
+func (m *MockTransactionMetricRepository) GetAggregatedDates(ctx context.Context, startDate, endDate time.Time) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregatedDates", ctx, startDate, endDate)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAggregatedDates indicates an expected call of GetAggregatedDates.
+func (mr *MockTransactionMetricRepositoryMockRecorder) GetAggregatedDates(ctx, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregatedDates", reflect.TypeOf((*MockTransactionMetricRepository)(nil).GetAggregatedDates), ctx, startDate, endDate)
+}
+
+// GetDailyAggregates mocks base method.
+func (m *MockTransactionMetricRepository) GetDailyAggregates(ctx context.Context, opts models.TransactionMetricFilterOptions) ([]models.TransactionDailyAggregate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyAggregates", ctx, opts)
+	ret0, _ := ret[0].([]models.TransactionDailyAggregate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyAggregates indicates an expected call of GetDailyAggregates.
+func (mr *MockTransactionMetricRepositoryMockRecorder) GetDailyAggregates(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyAggregates", reflect.TypeOf((*MockTransactionMetricRepository)(nil).GetDailyAggregates), ctx, opts)
+}
+
+// GetInvalidatedDates mocks base method.
+func (m *MockTransactionMetricRepository) GetInvalidatedDates(ctx context.Context) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvalidatedDates", ctx)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvalidatedDates indicates an expected call of GetInvalidatedDates.
+func (mr *MockTransactionMetricRepositoryMockRecorder) GetInvalidatedDates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvalidatedDates", reflect.TypeOf((*MockTransactionMetricRepository)(nil).GetInvalidatedDates), ctx)
+}
+
+// InvalidateDailyAggregates mocks base method.
+func (m *MockTransactionMetricRepository) InvalidateDailyAggregates(ctx context.Context, dates []time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateDailyAggregates", ctx, dates)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateDailyAggregates indicates an expected call of InvalidateDailyAggregates.
+func (mr *MockTransactionMetricRepositoryMockRecorder) InvalidateDailyAggregates(ctx, dates any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateDailyAggregates", reflect.TypeOf((*MockTransactionMetricRepository)(nil).InvalidateDailyAggregates), ctx, dates)
+}
+
+// MaterializeDailyAggregates mocks base method.
+func (m *MockTransactionMetricRepository) MaterializeDailyAggregates(ctx context.Context, date time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaterializeDailyAggregates", ctx, date)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MaterializeDailyAggregates indicates an expected call of MaterializeDailyAggregates.
+func (mr *MockTransactionMetricRepositoryMockRecorder) MaterializeDailyAggregates(ctx, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaterializeDailyAggregates", reflect.TypeOf((*MockTransactionMetricRepository)(nil).MaterializeDailyAggregates), ctx, date)
+}
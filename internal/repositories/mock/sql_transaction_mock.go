@@ -12,7 +12,6 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
-	time "time"
 
 	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
 	gomock "go.uber.org/mock/gomock"
@@ -62,21 +61,6 @@ func (mr *MockTransactionRepositoryMockRecorder) CheckRefNumbers(ctx any, refNum
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckRefNumbers", reflect.TypeOf((*MockTransactionRepository)(nil).CheckRefNumbers), varargs...)
 }
 
-// ColectRepayment mocks base method.
-func (m *MockTransactionRepository) ColectRepayment(ctx context.Context, date time.Time) (*models.CollectRepayment, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ColectRepayment", ctx, date)
-	ret0, _ := ret[0].(*models.CollectRepayment)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
-}
-
-// ColectRepayment indicates an expected call of ColectRepayment.
-func (mr *MockTransactionRepositoryMockRecorder) ColectRepayment(ctx, date any) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColectRepayment", reflect.TypeOf((*MockTransactionRepository)(nil).ColectRepayment), ctx, date)
-}
-
 // CountAll mocks base method.
 func (m *MockTransactionRepository) CountAll(ctx context.Context, opts models.TransactionFilterOptions) (int, error) {
 	m.ctrl.T.Helper()
@@ -137,34 +121,34 @@ func (mr *MockTransactionRepositoryMockRecorder) GetByTransactionTypeAndRefNumbe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTransactionTypeAndRefNumber", reflect.TypeOf((*MockTransactionRepository)(nil).GetByTransactionTypeAndRefNumber), ctx, req)
 }
 
-// GetList mocks base method.
-func (m *MockTransactionRepository) GetList(ctx context.Context, opts models.TransactionFilterOptions) ([]models.Transaction, error) {
+// GetByWalletTransactionID mocks base method.
+func (m *MockTransactionRepository) GetByWalletTransactionID(ctx context.Context, walletTransactionId, refNumber string) ([]models.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetList", ctx, opts)
+	ret := m.ctrl.Call(m, "GetByWalletTransactionID", ctx, walletTransactionId, refNumber)
 	ret0, _ := ret[0].([]models.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetList indicates an expected call of GetList.
-func (mr *MockTransactionRepositoryMockRecorder) GetList(ctx, opts any) *gomock.Call {
+// GetByWalletTransactionID indicates an expected call of GetByWalletTransactionID.
+func (mr *MockTransactionRepositoryMockRecorder) GetByWalletTransactionID(ctx, walletTransactionId, refNumber any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockTransactionRepository)(nil).GetList), ctx, opts)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByWalletTransactionID", reflect.TypeOf((*MockTransactionRepository)(nil).GetByWalletTransactionID), ctx, walletTransactionId, refNumber)
 }
 
-// GetReportRepayment mocks base method.
-func (m *MockTransactionRepository) GetReportRepayment(ctx context.Context, startDate, endDate time.Time) ([]models.ReportRepayment, error) {
+// GetList mocks base method.
+func (m *MockTransactionRepository) GetList(ctx context.Context, opts models.TransactionFilterOptions) ([]models.Transaction, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReportRepayment", ctx, startDate, endDate)
-	ret0, _ := ret[0].([]models.ReportRepayment)
+	ret := m.ctrl.Call(m, "GetList", ctx, opts)
+	ret0, _ := ret[0].([]models.Transaction)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetReportRepayment indicates an expected call of GetReportRepayment.
-func (mr *MockTransactionRepositoryMockRecorder) GetReportRepayment(ctx, startDate, endDate any) *gomock.Call {
+// GetList indicates an expected call of GetList.
+func (mr *MockTransactionRepositoryMockRecorder) GetList(ctx, opts any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportRepayment", reflect.TypeOf((*MockTransactionRepository)(nil).GetReportRepayment), ctx, startDate, endDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockTransactionRepository)(nil).GetList), ctx, opts)
 }
 
 // GetStatusCount mocks base method.
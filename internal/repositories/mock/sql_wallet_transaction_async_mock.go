@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repositories/sql_wallet_transaction_async.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repositories/sql_wallet_transaction_async.go -destination=./internal/repositories/mock/sql_wallet_transaction_async_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAsyncWalletTransactionRepository is a mock of AsyncWalletTransactionRepository interface.
+type MockAsyncWalletTransactionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAsyncWalletTransactionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAsyncWalletTransactionRepositoryMockRecorder is the mock recorder for MockAsyncWalletTransactionRepository.
+type MockAsyncWalletTransactionRepositoryMockRecorder struct {
+	mock *MockAsyncWalletTransactionRepository
+}
+
+// NewMockAsyncWalletTransactionRepository creates a new mock instance.
+func NewMockAsyncWalletTransactionRepository(ctrl *gomock.Controller) *MockAsyncWalletTransactionRepository {
+	mock := &MockAsyncWalletTransactionRepository{ctrl: ctrl}
+	mock.recorder = &MockAsyncWalletTransactionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAsyncWalletTransactionRepository) EXPECT() *MockAsyncWalletTransactionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAsyncWalletTransactionRepository) Create(ctx context.Context, in *models.AsyncWalletTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAsyncWalletTransactionRepositoryMockRecorder) Create(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAsyncWalletTransactionRepository)(nil).Create), ctx, in)
+}
+
+// GetByID mocks base method.
+func (m *MockAsyncWalletTransactionRepository) GetByID(ctx context.Context, id string) (*models.AsyncWalletTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.AsyncWalletTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAsyncWalletTransactionRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAsyncWalletTransactionRepository)(nil).GetByID), ctx, id)
+}
+
+// GetPendingCallbacks mocks base method.
+func (m *MockAsyncWalletTransactionRepository) GetPendingCallbacks(ctx context.Context, before time.Time, limit int) ([]models.AsyncWalletTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingCallbacks", ctx, before, limit)
+	ret0, _ := ret[0].([]models.AsyncWalletTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingCallbacks indicates an expected call of GetPendingCallbacks.
+func (mr *MockAsyncWalletTransactionRepositoryMockRecorder) GetPendingCallbacks(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingCallbacks", reflect.TypeOf((*MockAsyncWalletTransactionRepository)(nil).GetPendingCallbacks), ctx, before, limit)
+}
+
+// UpdateCallback mocks base method.
+func (m *MockAsyncWalletTransactionRepository) UpdateCallback(ctx context.Context, id, callbackStatus string, attempts int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCallback", ctx, id, callbackStatus, attempts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCallback indicates an expected call of UpdateCallback.
+func (mr *MockAsyncWalletTransactionRepositoryMockRecorder) UpdateCallback(ctx, id, callbackStatus, attempts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCallback", reflect.TypeOf((*MockAsyncWalletTransactionRepository)(nil).UpdateCallback), ctx, id, callbackStatus, attempts)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockAsyncWalletTransactionRepository) UpdateStatus(ctx context.Context, in *models.AsyncWalletTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockAsyncWalletTransactionRepositoryMockRecorder) UpdateStatus(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockAsyncWalletTransactionRepository)(nil).UpdateStatus), ctx, in)
+}
@@ -86,6 +86,35 @@ func (mr *MockWalletTransactionRepositoryMockRecorder) Create(ctx, in any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWalletTransactionRepository)(nil).Create), ctx, in)
 }
 
+// CreateBalances mocks base method.
+func (m *MockWalletTransactionRepository) CreateBalances(ctx context.Context, balances []models.WalletTransactionBalance) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBalances", ctx, balances)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBalances indicates an expected call of CreateBalances.
+func (mr *MockWalletTransactionRepositoryMockRecorder) CreateBalances(ctx, balances any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBalances", reflect.TypeOf((*MockWalletTransactionRepository)(nil).CreateBalances), ctx, balances)
+}
+
+// GetBalances mocks base method.
+func (m *MockWalletTransactionRepository) GetBalances(ctx context.Context, walletTransactionId string) ([]models.WalletTransactionBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalances", ctx, walletTransactionId)
+	ret0, _ := ret[0].([]models.WalletTransactionBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalances indicates an expected call of GetBalances.
+func (mr *MockWalletTransactionRepositoryMockRecorder) GetBalances(ctx, walletTransactionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalances", reflect.TypeOf((*MockWalletTransactionRepository)(nil).GetBalances), ctx, walletTransactionId)
+}
+
 // GetById mocks base method.
 func (m *MockWalletTransactionRepository) GetById(ctx context.Context, id string) (*models.WalletTransaction, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +130,21 @@ func (mr *MockWalletTransactionRepositoryMockRecorder) GetById(ctx, id any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockWalletTransactionRepository)(nil).GetById), ctx, id)
 }
 
+// GetByIdForUpdate mocks base method.
+func (m *MockWalletTransactionRepository) GetByIdForUpdate(ctx context.Context, id string) (*models.WalletTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIdForUpdate", ctx, id)
+	ret0, _ := ret[0].(*models.WalletTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIdForUpdate indicates an expected call of GetByIdForUpdate.
+func (mr *MockWalletTransactionRepositoryMockRecorder) GetByIdForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIdForUpdate", reflect.TypeOf((*MockWalletTransactionRepository)(nil).GetByIdForUpdate), ctx, id)
+}
+
 // GetByRefNumber mocks base method.
 func (m *MockWalletTransactionRepository) GetByRefNumber(ctx context.Context, refNumber string) (*models.WalletTransaction, error) {
 	m.ctrl.T.Helper()
@@ -131,6 +175,21 @@ func (mr *MockWalletTransactionRepositoryMockRecorder) List(ctx, opts any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWalletTransactionRepository)(nil).List), ctx, opts)
 }
 
+// ListByRefNumber mocks base method.
+func (m *MockWalletTransactionRepository) ListByRefNumber(ctx context.Context, refNumber, transactionType string) ([]models.WalletTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByRefNumber", ctx, refNumber, transactionType)
+	ret0, _ := ret[0].([]models.WalletTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByRefNumber indicates an expected call of ListByRefNumber.
+func (mr *MockWalletTransactionRepositoryMockRecorder) ListByRefNumber(ctx, refNumber, transactionType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByRefNumber", reflect.TypeOf((*MockWalletTransactionRepository)(nil).ListByRefNumber), ctx, refNumber, transactionType)
+}
+
 // Update mocks base method.
 func (m *MockWalletTransactionRepository) Update(ctx context.Context, id string, data models.WalletTransactionUpdate) (*models.WalletTransaction, error) {
 	m.ctrl.T.Helper()
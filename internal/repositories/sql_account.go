@@ -54,8 +54,8 @@ type accountRepository sqlRepo
 var _ AccountRepository = (*accountRepository)(nil)
 
 func (ar *accountRepository) Create(ctx context.Context, in models.CreateAccount) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
@@ -83,8 +83,8 @@ func (ar *accountRepository) Create(ctx context.Context, in models.CreateAccount
 }
 
 func (ar *accountRepository) GetList(ctx context.Context, opts models.AccountFilterOptions) (result []models.GetAccountOut, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
@@ -134,8 +134,8 @@ func (ar *accountRepository) GetList(ctx context.Context, opts models.AccountFil
 }
 
 func (ar *accountRepository) GetAllWithoutPagination(ctx context.Context) (results *[]models.Account, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
@@ -171,8 +171,8 @@ func (ar *accountRepository) GetAllWithoutPagination(ctx context.Context) (resul
 }
 
 func (ar *accountRepository) GetAllByAccountNumbers(ctx context.Context, accountNumbers []string) (results []models.Account, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
@@ -206,8 +206,8 @@ func (ar *accountRepository) GetAllByAccountNumbers(ctx context.Context, account
 }
 
 func (ar *accountRepository) GetAccountNumberEntity(ctx context.Context, accountNumbers []string) (result map[string]string, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	result = make(map[string]string)
 
@@ -243,8 +243,8 @@ func (ar *accountRepository) GetAccountNumberEntity(ctx context.Context, account
 
 // GetOneByAccountNumber will search account by it's account number on database.
 func (ar *accountRepository) GetOneByAccountNumber(ctx context.Context, accountNumber string) (result models.GetAccountOut, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
@@ -297,8 +297,8 @@ func (ar *accountRepository) GetOneByAccountNumber(ctx context.Context, accountN
 func (ar *accountRepository) GetOneByLegacyId(ctx context.Context, legacyId string) (*models.Account, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
@@ -317,8 +317,8 @@ func (ar *accountRepository) GetOneByLegacyId(ctx context.Context, legacyId stri
 }
 
 func (ar *accountRepository) CountAll(ctx context.Context, opts models.AccountFilterOptions) (total int, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
@@ -337,8 +337,8 @@ func (ar *accountRepository) CountAll(ctx context.Context, opts models.AccountFi
 }
 
 func (ar *accountRepository) CheckDataByID(ctx context.Context, id uint64) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
@@ -350,8 +350,8 @@ func (ar *accountRepository) CheckDataByID(ctx context.Context, id uint64) (err
 }
 
 func (ar *accountRepository) Upsert(ctx context.Context, en models.AccountUpsert) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
@@ -389,8 +389,8 @@ func (ar *accountRepository) Upsert(ctx context.Context, en models.AccountUpsert
 }
 
 func (ar *accountRepository) CheckAccountNumbers(ctx context.Context, accountNumbers []string) (exists map[string]bool, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	exists = make(map[string]bool)
 	for _, an := range accountNumbers {
@@ -421,8 +421,8 @@ func (ar *accountRepository) CheckAccountNumbers(ctx context.Context, accountNum
 func (ar *accountRepository) GetAccountBalances(ctx context.Context, req models.GetAccountBalanceRequest) (map[string]models.Balance, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	accountBalance := make(map[string]models.Balance)
 
@@ -472,8 +472,8 @@ func (ar *accountRepository) GetAccountBalances(ctx context.Context, req models.
 }
 
 func (ar *accountRepository) UpdateAccountBalance(ctx context.Context, accountNumber string, balance models.Balance) (updatedBalance *models.Balance, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
@@ -522,8 +522,8 @@ func (ar *accountRepository) UpdateAccountBalance(ctx context.Context, accountNu
 func (ar *accountRepository) GetTotalBalance(ctx context.Context, opts models.AccountFilterOptions) (*decimal.Decimal, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
@@ -548,8 +548,8 @@ func (ar *accountRepository) Update(ctx context.Context, id int, newData models.
 		query  = queryUpdate
 	)
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
@@ -582,8 +582,8 @@ func (ar *accountRepository) Update(ctx context.Context, id int, newData models.
 }
 
 func (ar *accountRepository) Delete(ctx context.Context, accountID int) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
@@ -606,8 +606,8 @@ func (ar *accountRepository) Delete(ctx context.Context, accountID int) (err err
 }
 
 func (ar *accountRepository) UpdateBySubCategory(ctx context.Context, in models.UpdateAccountBySubCategoryIn) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	query, args, err := buildUpdateBySubCategoryQuery(in)
 	if err != nil {
@@ -621,8 +621,8 @@ func (ar *accountRepository) UpdateBySubCategory(ctx context.Context, in models.
 }
 
 func (ar *accountRepository) DeleteByAccountNumber(ctx context.Context, accountNumber string) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
@@ -646,8 +646,8 @@ func (ar *accountRepository) DeleteByAccountNumber(ctx context.Context, accountN
 
 // GetOneByAccountNumber will search account by it's account number on database.
 func (ar *accountRepository) GetOneByAccountNumberOrLegacyId(ctx context.Context, accountNumber string) (result models.GetAccountOut, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := ar.r.extractTxWrite(ctx)
 
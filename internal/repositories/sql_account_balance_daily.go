@@ -24,8 +24,8 @@ type accountBalanceDailyRepository sqlRepo
 var _ AccountBalanceDailyRepository = (*accountBalanceDailyRepository)(nil)
 
 func (r *accountBalanceDailyRepository) ListByDate(ctx context.Context, date time.Time) (results *[]models.AccountBalanceDaily, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -60,8 +60,8 @@ func (r *accountBalanceDailyRepository) ListByDate(ctx context.Context, date tim
 }
 
 func (r *accountBalanceDailyRepository) Create(ctx context.Context, in *[]models.AccountBalanceDaily) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	tx, err := r.r.dbWrite.BeginTx(ctx, nil)
 	if err != nil {
@@ -103,8 +103,8 @@ func (r *accountBalanceDailyRepository) Create(ctx context.Context, in *[]models
 }
 
 func (r *accountBalanceDailyRepository) GetLast(ctx context.Context) (result *models.AccountBalanceDaily, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+)
+
+type ApprovalRepository interface {
+	Create(ctx context.Context, in *models.Approval) (err error)
+	GetByID(ctx context.Context, id uint64) (result *models.Approval, err error)
+	GetList(ctx context.Context, opts models.ApprovalFilterOptions) (result []models.Approval, err error)
+	CountAll(ctx context.Context, opts models.ApprovalFilterOptions) (total int, err error)
+	// UpdateState update approval which is still in fromState, common.ErrNoRowsAffected is returned otherwise
+	UpdateState(ctx context.Context, in *models.Approval, fromState models.ApprovalState) (err error)
+	// ExpirePending mark pending approvals which expire before now as expired, the ids of expired approvals are returned
+	ExpirePending(ctx context.Context, now time.Time) (ids []uint64, err error)
+	CreateActivity(ctx context.Context, in *models.ApprovalActivity) (err error)
+	ListActivities(ctx context.Context, approvalID uint64) (result []models.ApprovalActivity, err error)
+}
+
+type approvalRepo sqlRepo
+
+var _ ApprovalRepository = (*approvalRepo)(nil)
+
+func (r *approvalRepo) Create(ctx context.Context, in *models.Approval) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	return db.QueryRowContext(ctx, queryApprovalCreate,
+		in.Operation,
+		in.Payload,
+		in.Description,
+		in.State,
+		in.Maker,
+		in.MakerClientID,
+		in.ExpiresAt,
+	).Scan(&in.ID, &in.CreatedAt, &in.UpdatedAt)
+}
+
+func (r *approvalRepo) GetByID(ctx context.Context, id uint64) (result *models.Approval, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	result = &models.Approval{}
+	err = scanApproval(db.QueryRowContext(ctx, queryApprovalGetByID, id), result)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *approvalRepo) GetList(ctx context.Context, opts models.ApprovalFilterOptions) (result []models.Approval, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxRead(ctx)
+
+	query, args, err := buildListApprovalQuery(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var a models.Approval
+		if err = scanApproval(rows, &a); err != nil {
+			return result, err
+		}
+		result = append(result, a)
+	}
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (r *approvalRepo) CountAll(ctx context.Context, opts models.ApprovalFilterOptions) (total int, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxRead(ctx)
+
+	query, args, err := buildCountApprovalQuery(opts)
+	if err != nil {
+		return total, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	err = db.QueryRowContext(ctx, query, args...).Scan(&total)
+
+	return
+}
+
+func (r *approvalRepo) UpdateState(ctx context.Context, in *models.Approval, fromState models.ApprovalState) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	err = db.QueryRowContext(ctx, queryApprovalUpdateState,
+		in.ID,
+		fromState,
+		in.State,
+		in.Checker,
+		in.CheckerClientID,
+		in.ExecutionError,
+		in.DecidedAt,
+	).Scan(&in.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return common.ErrNoRowsAffected
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *approvalRepo) ExpirePending(ctx context.Context, now time.Time) (ids []uint64, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	rows, err := db.QueryContext(ctx, queryApprovalExpirePending, now)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var id uint64
+		if err = rows.Scan(&id); err != nil {
+			return ids, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return ids, err
+	}
+
+	return ids, nil
+}
+
+func (r *approvalRepo) CreateActivity(ctx context.Context, in *models.ApprovalActivity) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	return db.QueryRowContext(ctx, queryApprovalActivityCreate,
+		in.ApprovalID,
+		in.Action,
+		in.Actor,
+		in.ClientID,
+		in.FromState,
+		in.ToState,
+		in.Comment,
+	).Scan(&in.ID, &in.CreatedAt)
+}
+
+func (r *approvalRepo) ListActivities(ctx context.Context, approvalID uint64) (result []models.ApprovalActivity, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	rows, err := db.QueryContext(ctx, queryApprovalActivityList, approvalID)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var a models.ApprovalActivity
+		err = rows.Scan(
+			&a.ID,
+			&a.ApprovalID,
+			&a.Action,
+			&a.Actor,
+			&a.ClientID,
+			&a.FromState,
+			&a.ToState,
+			&a.Comment,
+			&a.CreatedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, a)
+	}
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+type approvalScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanApproval(row approvalScanner, a *models.Approval) error {
+	return row.Scan(
+		&a.ID,
+		&a.Operation,
+		&a.Payload,
+		&a.Description,
+		&a.State,
+		&a.Maker,
+		&a.MakerClientID,
+		&a.Checker,
+		&a.CheckerClientID,
+		&a.ExecutionError,
+		&a.ExpiresAt,
+		&a.DecidedAt,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	)
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	sq "github.com/Masterminds/squirrel"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+)
+
+var (
+	queryApprovalCreate = `
+		INSERT INTO approval_requests(
+			operation, payload, description, state, maker, maker_client_id, expires_at
+		)
+		VALUES(
+			$1, $2, $3, $4, $5, $6, $7
+		)
+		RETURNING
+			id, created_at, updated_at;
+	`
+
+	queryApprovalGetByID = `SELECT
+		  id,
+		  operation,
+		  payload,
+		  description,
+		  state,
+		  maker,
+		  maker_client_id,
+		  checker,
+		  checker_client_id,
+		  execution_error,
+		  expires_at,
+		  decided_at,
+		  created_at,
+		  updated_at
+		FROM approval_requests
+		WHERE id = $1;`
+
+	// queryApprovalUpdateState only update approval in the expected state,
+	// so the same approval can not be decided twice by concurrent requests
+	queryApprovalUpdateState = `UPDATE approval_requests
+		SET
+		  state = $3,
+		  checker = $4,
+		  checker_client_id = $5,
+		  execution_error = $6,
+		  decided_at = $7,
+		  updated_at = NOW()
+		WHERE
+		  id = $1 AND state = $2
+		RETURNING
+		  updated_at;`
+
+	queryApprovalExpirePending = `UPDATE approval_requests
+		SET
+		  state = 'EXPIRED',
+		  updated_at = NOW()
+		WHERE
+		  state = 'PENDING' AND expires_at <= $1
+		RETURNING
+		  id;`
+
+	queryApprovalActivityCreate = `
+		INSERT INTO approval_request_activities(
+			approval_request_id, action, actor, client_id, from_state, to_state, comment
+		)
+		VALUES(
+			$1, $2, $3, $4, $5, $6, $7
+		)
+		RETURNING
+			id, created_at;
+	`
+
+	queryApprovalActivityList = `SELECT
+		  id,
+		  approval_request_id,
+		  action,
+		  actor,
+		  client_id,
+		  from_state,
+		  to_state,
+		  comment,
+		  created_at
+		FROM approval_request_activities
+		WHERE approval_request_id = $1
+		ORDER BY id ASC;`
+)
+
+func buildFilteredApprovalQuery(cols []string, opts models.ApprovalFilterOptions) sq.SelectBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	query := psql.Select(cols...).From("approval_requests")
+
+	if opts.Operation != "" {
+		query = query.Where(sq.Eq{"operation": opts.Operation})
+	}
+
+	if opts.State != "" {
+		query = query.Where(sq.Eq{"state": opts.State})
+	}
+
+	if opts.Maker != "" {
+		query = query.Where(sq.Eq{"maker": opts.Maker})
+	}
+
+	return query
+}
+
+func buildListApprovalQuery(opts models.ApprovalFilterOptions) (sql string, args []interface{}, err error) {
+	columns := []string{
+		"id",
+		"operation",
+		"payload",
+		"description",
+		"state",
+		"maker",
+		"maker_client_id",
+		"checker",
+		"checker_client_id",
+		"execution_error",
+		"expires_at",
+		"decided_at",
+		"created_at",
+		"updated_at",
+	}
+
+	query := buildFilteredApprovalQuery(columns, opts)
+
+	if opts.AfterID != 0 {
+		query = query.Where(sq.Lt{"id": opts.AfterID})
+	}
+
+	if opts.BeforeID != 0 {
+		query = query.Where(sq.Gt{"id": opts.BeforeID})
+	}
+
+	if opts.AscendingOrder {
+		query = query.OrderBy("id ASC")
+	} else {
+		query = query.OrderBy("id DESC")
+	}
+
+	query = query.Limit(uint64(opts.Limit))
+
+	return query.ToSql()
+}
+
+func buildCountApprovalQuery(opts models.ApprovalFilterOptions) (sql string, args []interface{}, err error) {
+	columns := []string{
+		`count(1)`,
+	}
+
+	query := buildFilteredApprovalQuery(columns, opts)
+
+	return query.ToSql()
+}
@@ -0,0 +1,287 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting/mock"
+	mockFlag "bitbucket.org/Amartha/go-fp-transaction/internal/common/flag/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestApprovalRepositoryTestSuite(t *testing.T) {
+	t.Helper()
+	suite.Run(t, new(approvalRepoTestSuite))
+}
+
+type approvalRepoTestSuite struct {
+	suite.Suite
+	t       *testing.T
+	writeDB *sql.DB
+	mock    sqlmock.Sqlmock
+	repo    ApprovalRepository
+}
+
+var approvalColumns = []string{
+	"id", "operation", "payload", "description", "state", "maker", "makerClientId", "checker", "checkerClientId",
+	"executionError", "expiresAt", "decidedAt", "createdAt", "updatedAt",
+}
+
+func (suite *approvalRepoTestSuite) SetupTest() {
+	var err error
+	var cfg config.Config
+
+	suite.writeDB, suite.mock, err = sqlmock.New()
+	require.NoError(suite.T(), err)
+
+	suite.t = suite.T()
+	mockCtrl := gomock.NewController(suite.t)
+
+	suite.repo = NewSQLRepository(suite.writeDB, suite.writeDB, cfg, mockFlag.NewMockClient(mockCtrl), mock.NewMockClient(mockCtrl)).
+		GetApprovalRepository()
+}
+
+func (suite *approvalRepoTestSuite) TearDownTest() {
+	suite.writeDB.Close()
+}
+
+func (suite *approvalRepoTestSuite) TestRepository_Create() {
+	expiresAt := time.Now().Add(24 * time.Hour)
+	in := &models.Approval{
+		Operation:     models.ApprovalOperationDeleteAccount,
+		Payload:       []byte(`{"accountNumber":"123456"}`),
+		Description:   "delete account 123456",
+		State:         models.ApprovalStatePending,
+		Maker:         "finance.ops",
+		MakerClientID: "backoffice",
+		ExpiresAt:     expiresAt,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryApprovalCreate)).
+		WithArgs(in.Operation, in.Payload, in.Description, in.State, in.Maker, in.MakerClientID, in.ExpiresAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "createdAt", "updatedAt"}).AddRow(1, time.Now(), time.Now()))
+
+	err := suite.repo.Create(context.Background(), in)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, uint64(1), in.ID)
+	assert.NotNil(suite.t, in.CreatedAt)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *approvalRepoTestSuite) TestRepository_GetByID() {
+	now := time.Now()
+
+	testCases := []struct {
+		name    string
+		wantErr error
+		doMock  func()
+	}{
+		{
+			name: "happy path",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryApprovalGetByID)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows(approvalColumns).AddRow(
+						1, "DELETE_ACCOUNT", []byte(`{"accountNumber":"123456"}`), "delete account 123456", "PENDING",
+						"finance.ops", "backoffice", "", "", "", now, nil, now, now,
+					))
+			},
+		},
+		{
+			name: "not found",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryApprovalGetByID)).
+					WithArgs(uint64(1)).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: common.ErrDataNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.doMock()
+
+			got, err := suite.repo.GetByID(context.Background(), 1)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.ApprovalStatePending, got.State)
+				assert.JSONEq(t, `{"accountNumber":"123456"}`, string(got.Payload))
+				assert.Nil(t, got.DecidedAt)
+			}
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *approvalRepoTestSuite) TestRepository_GetList() {
+	opts := models.ApprovalFilterOptions{State: models.ApprovalStatePending, Limit: 11}
+
+	testCases := []struct {
+		name    string
+		wantLen int
+		wantErr bool
+		doMock  func()
+	}{
+		{
+			name:    "success get list",
+			wantLen: 1,
+			doMock: func() {
+				query, _, _ := buildListApprovalQuery(opts)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(models.ApprovalStatePending).
+					WillReturnRows(sqlmock.NewRows(approvalColumns).AddRow(
+						1, "DELETE_ACCOUNT", []byte(`{}`), "", "PENDING",
+						"finance.ops", "", "", "", "", time.Now(), nil, time.Now(), time.Now(),
+					))
+			},
+		},
+		{
+			name: "error db",
+			doMock: func() {
+				query, _, _ := buildListApprovalQuery(opts)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.doMock()
+
+			got, err := suite.repo.GetList(context.Background(), opts)
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Len(t, got, tc.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *approvalRepoTestSuite) TestRepository_UpdateState() {
+	decidedAt := time.Now()
+	in := &models.Approval{
+		ID:        1,
+		State:     models.ApprovalStateApproved,
+		Checker:   "finance.lead",
+		DecidedAt: &decidedAt,
+	}
+
+	testCases := []struct {
+		name    string
+		wantErr error
+		doMock  func()
+	}{
+		{
+			name: "happy path",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryApprovalUpdateState)).
+					WithArgs(in.ID, models.ApprovalStatePending, in.State, in.Checker, in.CheckerClientID, in.ExecutionError, in.DecidedAt).
+					WillReturnRows(sqlmock.NewRows([]string{"updatedAt"}).AddRow(time.Now()))
+			},
+		},
+		{
+			name: "already decided",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryApprovalUpdateState)).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: common.ErrNoRowsAffected,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.doMock()
+
+			err := suite.repo.UpdateState(context.Background(), in, models.ApprovalStatePending)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *approvalRepoTestSuite) TestRepository_ExpirePending() {
+	now := time.Now()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryApprovalExpirePending)).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(3))
+
+	got, err := suite.repo.ExpirePending(context.Background(), now)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, []uint64{1, 3}, got)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *approvalRepoTestSuite) TestRepository_CreateActivity() {
+	in := &models.ApprovalActivity{
+		ApprovalID: 1,
+		Action:     models.ApprovalActionSubmit,
+		Actor:      "finance.ops",
+		ClientID:   "backoffice",
+		ToState:    models.ApprovalStatePending,
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryApprovalActivityCreate)).
+		WithArgs(in.ApprovalID, in.Action, in.Actor, in.ClientID, in.FromState, in.ToState, in.Comment).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "createdAt"}).AddRow(10, time.Now()))
+
+	err := suite.repo.CreateActivity(context.Background(), in)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, uint64(10), in.ID)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *approvalRepoTestSuite) TestRepository_ListActivities() {
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryApprovalActivityList)).
+		WithArgs(uint64(1)).
+		WillReturnRows(sqlmock.
+			NewRows([]string{"id", "approvalRequestId", "action", "actor", "clientId", "fromState", "toState", "comment", "createdAt"}).
+			AddRow(1, 1, "SUBMIT", "finance.ops", "backoffice", "", "PENDING", "", time.Now()).
+			AddRow(2, 1, "APPROVE", "finance.lead", "backoffice", "PENDING", "APPROVED", "ok", time.Now()))
+
+	got, err := suite.repo.ListActivities(context.Background(), 1)
+	assert.NoError(suite.t, err)
+	assert.Len(suite.t, got, 2)
+	assert.Equal(suite.t, models.ApprovalActionApprove, got[1].Action)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
@@ -27,8 +27,8 @@ type balanceRepository sqlRepo
 var _ BalanceRepository = (*balanceRepository)(nil)
 
 func (b balanceRepository) Get(ctx context.Context, accountNumber string) (res models.AccountBalance, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := b.r.extractTxWrite(ctx)
 
@@ -73,8 +73,8 @@ func (b balanceRepository) Get(ctx context.Context, accountNumber string) (res m
 }
 
 func (b balanceRepository) GetMany(ctx context.Context, req models.GetAccountBalanceRequest) (res []models.AccountBalance, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := b.r.extractTxWrite(ctx)
 
@@ -177,8 +177,8 @@ func (b balanceRepository) getIgnoredAccounts() (accountNumbers []string, err er
 }
 
 func (b balanceRepository) AdjustAccountBalance(ctx context.Context, accountNumber string, updateAmount models.Decimal) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := b.r.extractTxWrite(ctx)
 
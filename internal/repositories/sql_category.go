@@ -24,8 +24,8 @@ type categoryRepository sqlRepo
 var _ CategoryRepository = (*categoryRepository)(nil)
 
 func (cr *categoryRepository) CheckCategoryByCode(ctx context.Context, code string) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := cr.r.extractTxWrite(ctx)
 
@@ -41,8 +41,8 @@ func (cr *categoryRepository) CheckCategoryByCode(ctx context.Context, code stri
 }
 
 func (cr *categoryRepository) GetCategorySequenceCode(ctx context.Context, code string) (seq int64, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := cr.r.extractTxWrite(ctx)
 	sequenceName := fmt.Sprintf("category_code_%s_seq", code)
@@ -60,8 +60,8 @@ func (cr *categoryRepository) GetCategorySequenceCode(ctx context.Context, code
 // Create implements CategoryRepository.
 func (r *categoryRepository) Create(ctx context.Context, in *models.CreateCategoryIn) (*models.Category, error) {
 	var err error
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -91,8 +91,8 @@ func (r *categoryRepository) Create(ctx context.Context, in *models.CreateCatego
 func (r *categoryRepository) GetByCode(ctx context.Context, code string) (*models.Category, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 	var category models.Category
@@ -117,8 +117,8 @@ func (r *categoryRepository) GetByCode(ctx context.Context, code string) (*model
 func (r *categoryRepository) List(ctx context.Context) (*[]models.Category, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
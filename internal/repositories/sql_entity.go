@@ -22,8 +22,8 @@ type entityRepository sqlRepo
 var _ EntityRepository = (*entityRepository)(nil)
 
 func (r *entityRepository) CheckEntityByCode(ctx context.Context, code string) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -40,8 +40,8 @@ func (r *entityRepository) CheckEntityByCode(ctx context.Context, code string) (
 
 // Create implements EntityRepository.
 func (r *entityRepository) Create(ctx context.Context, in *models.CreateEntityIn) (created *models.Entity, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -73,8 +73,8 @@ func (r *entityRepository) Create(ctx context.Context, in *models.CreateEntityIn
 func (r *entityRepository) GetByCode(ctx context.Context, code string) (*models.Entity, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -101,8 +101,8 @@ func (r *entityRepository) GetByCode(ctx context.Context, code string) (*models.
 func (r *entityRepository) List(ctx context.Context) (*[]models.Entity, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+)
+
+type ExportJobRepository interface {
+	Create(ctx context.Context, in *models.ExportJob) (err error)
+	GetByID(ctx context.Context, id uint64) (result *models.ExportJob, err error)
+
+	// Claim mark the job as processing, claimed is false when the job is finished or being processed by another worker
+	Claim(ctx context.Context, id uint64, staleAfter time.Duration) (claimed bool, err error)
+	UpdateProgress(ctx context.Context, id uint64, totalRows, processedRows int64) (err error)
+	Finish(ctx context.Context, in *models.ExportJob) (err error)
+}
+
+type exportJobRepo sqlRepo
+
+var _ ExportJobRepository = (*exportJobRepo)(nil)
+
+func (r *exportJobRepo) Create(ctx context.Context, in *models.ExportJob) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	return db.QueryRowContext(ctx, queryExportJobCreate,
+		in.Format,
+		in.Gzip,
+		in.Filter,
+		in.Status,
+		in.RequestedBy,
+	).Scan(&in.ID, &in.CreatedAt, &in.UpdatedAt)
+}
+
+func (r *exportJobRepo) GetByID(ctx context.Context, id uint64) (result *models.ExportJob, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	result = &models.ExportJob{}
+	err = db.QueryRowContext(ctx, queryExportJobGetByID, id).Scan(
+		&result.ID,
+		&result.Format,
+		&result.Gzip,
+		&result.Filter,
+		&result.Status,
+		&result.TotalRows,
+		&result.ProcessedRows,
+		&result.FilePath,
+		&result.ErrorMessage,
+		&result.RequestedBy,
+		&result.CreatedAt,
+		&result.UpdatedAt,
+		&result.CompletedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *exportJobRepo) Claim(ctx context.Context, id uint64, staleAfter time.Duration) (claimed bool, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	res, err := db.ExecContext(ctx, queryExportJobClaim, id, staleAfter.Seconds())
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *exportJobRepo) UpdateProgress(ctx context.Context, id uint64, totalRows, processedRows int64) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	_, err = db.ExecContext(ctx, queryExportJobUpdateProgress, id, totalRows, processedRows)
+	return err
+}
+
+func (r *exportJobRepo) Finish(ctx context.Context, in *models.ExportJob) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	_, err = db.ExecContext(ctx, queryExportJobFinish,
+		in.ID,
+		in.Status,
+		in.ProcessedRows,
+		in.FilePath,
+		in.ErrorMessage,
+	)
+	return err
+}
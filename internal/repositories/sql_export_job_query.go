@@ -0,0 +1,51 @@
+package repositories
+
+var (
+	queryExportJobCreate = `
+		INSERT INTO export_jobs(
+			format, gzip, filter, status, requested_by, created_at, updated_at
+		)
+		VALUES(
+			$1, $2, $3, $4, $5, NOW(), NOW()
+		)
+		RETURNING
+			id, created_at, updated_at;
+	`
+
+	queryExportJobGetByID = `SELECT
+		  id,
+		  format,
+		  gzip,
+		  filter,
+		  status,
+		  total_rows,
+		  processed_rows,
+		  file_path,
+		  error_message,
+		  requested_by,
+		  created_at,
+		  updated_at,
+		  completed_at
+		FROM export_jobs
+		WHERE id = $1;`
+
+	// processing job is claimable again when its progress has not been updated for a while, e.g. the worker was killed
+	queryExportJobClaim = `
+		UPDATE export_jobs
+		SET status = 'PROCESSING', processed_rows = 0, error_message = '', updated_at = NOW()
+		WHERE id = $1
+		  AND (
+		    status IN ('PENDING', 'FAILED')
+		    OR (status = 'PROCESSING' AND updated_at < NOW() - make_interval(secs => $2))
+		  );`
+
+	queryExportJobUpdateProgress = `
+		UPDATE export_jobs
+		SET total_rows = $2, processed_rows = $3, updated_at = NOW()
+		WHERE id = $1;`
+
+	queryExportJobFinish = `
+		UPDATE export_jobs
+		SET status = $2, processed_rows = $3, file_path = $4, error_message = $5, completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1;`
+)
@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting/mock"
+	mockFlag "bitbucket.org/Amartha/go-fp-transaction/internal/common/flag/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestExportJobRepositoryTestSuite(t *testing.T) {
+	t.Helper()
+	suite.Run(t, new(exportJobRepoTestSuite))
+}
+
+type exportJobRepoTestSuite struct {
+	suite.Suite
+	t       *testing.T
+	writeDB *sql.DB
+	mock    sqlmock.Sqlmock
+	repo    ExportJobRepository
+}
+
+func (suite *exportJobRepoTestSuite) SetupTest() {
+	var err error
+	var cfg config.Config
+
+	suite.writeDB, suite.mock, err = sqlmock.New()
+	require.NoError(suite.T(), err)
+
+	suite.t = suite.T()
+	mockCtrl := gomock.NewController(suite.t)
+
+	suite.repo = NewSQLRepository(suite.writeDB, suite.writeDB, cfg, mockFlag.NewMockClient(mockCtrl), mock.NewMockClient(mockCtrl)).
+		GetExportJobRepository()
+}
+
+func (suite *exportJobRepoTestSuite) TearDownTest() {
+	suite.writeDB.Close()
+}
+
+func (suite *exportJobRepoTestSuite) TestRepository_Create() {
+	in := &models.ExportJob{
+		Format:      "CSV",
+		Gzip:        true,
+		Filter:      models.ExportTransactionFilter{StartDate: "2025-01-01", EndDate: "2025-01-31"},
+		Status:      models.ExportStatusPending,
+		RequestedBy: "finance.ops",
+	}
+	now := time.Now()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryExportJobCreate)).
+		WithArgs("CSV", true, []byte(`{"startDate":"2025-01-01","endDate":"2025-01-31"}`), models.ExportStatusPending, "finance.ops").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(5, now, now))
+
+	err := suite.repo.Create(context.Background(), in)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, uint64(5), in.ID)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *exportJobRepoTestSuite) TestRepository_GetByID() {
+	now := time.Now()
+	columns := []string{
+		"id", "format", "gzip", "filter", "status", "total_rows", "processed_rows", "file_path",
+		"error_message", "requested_by", "created_at", "updated_at", "completed_at",
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryExportJobGetByID)).
+		WithArgs(5).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(5, "PARQUET", false, `{"startDate":"2025-01-01","endDate":"2025-01-31","orderType":"TOPUP"}`, "SUCCESS",
+				10, 10, "exports/2025/1/5/transaction.parquet", "", "finance.ops", now, now, now))
+
+	job, err := suite.repo.GetByID(context.Background(), 5)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, "TOPUP", job.Filter.OrderType)
+	assert.Equal(suite.t, int64(10), job.ProcessedRows)
+	assert.NotNil(suite.t, job.CompletedAt)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryExportJobGetByID)).
+		WithArgs(6).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = suite.repo.GetByID(context.Background(), 6)
+	assert.ErrorIs(suite.t, err, common.ErrDataNotFound)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *exportJobRepoTestSuite) TestRepository_Claim() {
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(queryExportJobClaim)).
+		WithArgs(5, float64(600)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(queryExportJobClaim)).
+		WithArgs(5, float64(600)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	claimed, err := suite.repo.Claim(context.Background(), 5, 10*time.Minute)
+	assert.NoError(suite.t, err)
+	assert.True(suite.t, claimed)
+
+	claimed, err = suite.repo.Claim(context.Background(), 5, 10*time.Minute)
+	assert.NoError(suite.t, err)
+	assert.False(suite.t, claimed)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *exportJobRepoTestSuite) TestRepository_UpdateProgressAndFinish() {
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(queryExportJobUpdateProgress)).
+		WithArgs(5, 100, 50).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(queryExportJobFinish)).
+		WithArgs(5, models.ExportStatusSuccess, 100, "exports/2025/1/5/transaction.csv", "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(suite.t, suite.repo.UpdateProgress(context.Background(), 5, 100, 50))
+	assert.NoError(suite.t, suite.repo.Finish(context.Background(), &models.ExportJob{
+		ID:            5,
+		Status:        models.ExportStatusSuccess,
+		ProcessedRows: 100,
+		FilePath:      "exports/2025/1/5/transaction.csv",
+	}))
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
@@ -26,8 +26,8 @@ type featureRepository sqlRepo
 var _ FeatureRepository = (*featureRepository)(nil)
 
 func (fr *featureRepository) Register(ctx context.Context, in *models.CreateWalletIn) (out models.WalletOut, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	var (
 		args []interface{}
@@ -65,8 +65,8 @@ func (fr *featureRepository) Register(ctx context.Context, in *models.CreateWall
 }
 
 func (fr *featureRepository) Update(ctx context.Context, in *models.UpdateWalletIn) (out models.WalletOut, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	var (
 		values []interface{}
@@ -129,8 +129,8 @@ func (fr *featureRepository) Update(ctx context.Context, in *models.UpdateWallet
 }
 
 func (fr *featureRepository) GetFeatureByAccountNumbers(ctx context.Context, accountNumbers []string) (out models.MapAccountFeature, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	out = make(models.MapAccountFeature)
 	db := fr.r.extractTxWrite(ctx)
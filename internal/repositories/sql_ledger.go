@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+
+	"github.com/shopspring/decimal"
+)
+
+type LedgerRepository interface {
+	// GetTrialBalance return opening balance, debit and credit of the period per account,
+	// accounts without balance and movement are excluded
+	GetTrialBalance(ctx context.Context, opts models.TrialBalanceFilterOptions) (result []models.TrialBalanceAccount, err error)
+
+	// GetOpeningBalance return the balance of the account at the beginning of date
+	GetOpeningBalance(ctx context.Context, accountNumber string, date time.Time) (balance decimal.Decimal, err error)
+
+	// GetPostings return successful transactions from or to the account within the period sorted by date
+	GetPostings(ctx context.Context, opts models.GeneralLedgerFilterOptions) (result []models.LedgerPosting, err error)
+}
+
+type ledgerRepo sqlRepo
+
+var _ LedgerRepository = (*ledgerRepo)(nil)
+
+func (r *ledgerRepo) GetTrialBalance(ctx context.Context, opts models.TrialBalanceFilterOptions) (result []models.TrialBalanceAccount, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxRead(ctx)
+
+	rows, err := db.QueryContext(ctx, queryTrialBalance,
+		opts.Entity,
+		opts.StartDate,
+		opts.EndDate,
+		string(models.TransactionStatusSuccess),
+	)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var account models.TrialBalanceAccount
+		err = rows.Scan(
+			&account.AccountNumber,
+			&account.AccountName,
+			&account.CategoryCode,
+			&account.CategoryName,
+			&account.SubCategoryCode,
+			&account.SubCategoryName,
+			&account.EntityCode,
+			&account.OpeningBalance,
+			&account.Debit,
+			&account.Credit,
+		)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, account)
+	}
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (r *ledgerRepo) GetOpeningBalance(ctx context.Context, accountNumber string, date time.Time) (balance decimal.Decimal, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxRead(ctx)
+
+	err = db.QueryRowContext(ctx, queryGeneralLedgerOpeningBalance,
+		accountNumber,
+		date,
+		string(models.TransactionStatusSuccess),
+	).Scan(&balance)
+
+	return balance, err
+}
+
+func (r *ledgerRepo) GetPostings(ctx context.Context, opts models.GeneralLedgerFilterOptions) (result []models.LedgerPosting, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxRead(ctx)
+
+	rows, err := db.QueryContext(ctx, queryGeneralLedgerPostings,
+		opts.AccountNumber,
+		opts.StartDate,
+		opts.EndDate,
+		string(models.TransactionStatusSuccess),
+	)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var posting models.LedgerPosting
+		err = rows.Scan(
+			&posting.ID,
+			&posting.TransactionID,
+			&posting.RefNumber,
+			&posting.TransactionType,
+			&posting.TransactionDate,
+			&posting.FromAccount,
+			&posting.ToAccount,
+			&posting.Amount,
+			&posting.Description,
+		)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, posting)
+	}
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
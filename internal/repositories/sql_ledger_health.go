@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+)
+
+// LedgerHealthRepository read the state of the ledger which is exposed as business metrics
+type LedgerHealthRepository interface {
+	// GetReservedWalletTransactions return count and oldest creation time of reserved wallet transactions per transaction type
+	GetReservedWalletTransactions(ctx context.Context) (result []models.ReservedWalletTransactionSummary, err error)
+
+	// GetMoneyFlowSummaries return count and total transfer of active money flow summaries per status
+	GetMoneyFlowSummaries(ctx context.Context) (result []models.MoneyFlowStatusSummary, err error)
+}
+
+type ledgerHealthRepo sqlRepo
+
+var _ LedgerHealthRepository = (*ledgerHealthRepo)(nil)
+
+func (r *ledgerHealthRepo) GetReservedWalletTransactions(ctx context.Context) (result []models.ReservedWalletTransactionSummary, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxRead(ctx)
+
+	rows, err := db.QueryContext(ctx, queryLedgerHealthReservedWalletTransactions)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var summary models.ReservedWalletTransactionSummary
+		if err = rows.Scan(&summary.TransactionType, &summary.TotalCount, &summary.OldestCreatedAt); err != nil {
+			return result, err
+		}
+		result = append(result, summary)
+	}
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (r *ledgerHealthRepo) GetMoneyFlowSummaries(ctx context.Context) (result []models.MoneyFlowStatusSummary, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxRead(ctx)
+
+	rows, err := db.QueryContext(ctx, queryLedgerHealthMoneyFlowSummaries)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var summary models.MoneyFlowStatusSummary
+		if err = rows.Scan(&summary.Status, &summary.TotalCount, &summary.TotalTransfer); err != nil {
+			return result, err
+		}
+		result = append(result, summary)
+	}
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
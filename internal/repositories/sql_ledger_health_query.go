@@ -0,0 +1,20 @@
+package repositories
+
+var (
+	// reserved wallet transaction stays PENDING until it is captured or cancelled
+	queryLedgerHealthReservedWalletTransactions = `SELECT
+		  "transactionType",
+		  COUNT(1) as total_count,
+		  MIN("createdAt") as oldest_created_at
+		FROM wallet_transaction
+		WHERE status = 'PENDING'
+		GROUP BY 1;`
+
+	queryLedgerHealthMoneyFlowSummaries = `SELECT
+		  money_flow_status,
+		  COUNT(1) as total_count,
+		  COALESCE(SUM(total_transfer), 0) as total_transfer
+		FROM money_flow_summaries
+		WHERE is_active = true
+		GROUP BY 1;`
+)
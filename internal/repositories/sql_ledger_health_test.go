@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting/mock"
+	mockFlag "bitbucket.org/Amartha/go-fp-transaction/internal/common/flag/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLedgerHealthRepositoryTestSuite(t *testing.T) {
+	t.Helper()
+	suite.Run(t, new(ledgerHealthRepoTestSuite))
+}
+
+type ledgerHealthRepoTestSuite struct {
+	suite.Suite
+	t       *testing.T
+	writeDB *sql.DB
+	mock    sqlmock.Sqlmock
+	repo    LedgerHealthRepository
+}
+
+func (suite *ledgerHealthRepoTestSuite) SetupTest() {
+	var err error
+	var cfg config.Config
+
+	suite.writeDB, suite.mock, err = sqlmock.New()
+	require.NoError(suite.T(), err)
+
+	suite.t = suite.T()
+	mockCtrl := gomock.NewController(suite.t)
+
+	suite.repo = NewSQLRepository(suite.writeDB, suite.writeDB, cfg, mockFlag.NewMockClient(mockCtrl), mock.NewMockClient(mockCtrl)).
+		GetLedgerHealthRepository()
+}
+
+func (suite *ledgerHealthRepoTestSuite) TearDownTest() {
+	suite.writeDB.Close()
+}
+
+func (suite *ledgerHealthRepoTestSuite) TestRepository_GetReservedWalletTransactions() {
+	oldest := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryLedgerHealthReservedWalletTransactions)).
+		WillReturnRows(sqlmock.NewRows([]string{"transactionType", "total_count", "oldest_created_at"}).
+			AddRow("DSBAB", 3, oldest))
+
+	result, err := suite.repo.GetReservedWalletTransactions(context.Background())
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, []models.ReservedWalletTransactionSummary{
+		{TransactionType: "DSBAB", TotalCount: 3, OldestCreatedAt: oldest},
+	}, result)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *ledgerHealthRepoTestSuite) TestRepository_GetReservedWalletTransactions_Error() {
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryLedgerHealthReservedWalletTransactions)).
+		WillReturnError(assert.AnError)
+
+	_, err := suite.repo.GetReservedWalletTransactions(context.Background())
+	assert.ErrorIs(suite.t, err, assert.AnError)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *ledgerHealthRepoTestSuite) TestRepository_GetMoneyFlowSummaries() {
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryLedgerHealthMoneyFlowSummaries)).
+		WillReturnRows(sqlmock.NewRows([]string{"money_flow_status", "total_count", "total_transfer"}).
+			AddRow("PENDING", 2, "1500000").
+			AddRow("FAILED", 1, "0"))
+
+	result, err := suite.repo.GetMoneyFlowSummaries(context.Background())
+	assert.NoError(suite.t, err)
+	require.Len(suite.t, result, 2)
+	assert.Equal(suite.t, "PENDING", result[0].Status)
+	assert.Equal(suite.t, int64(2), result[0].TotalCount)
+	assert.True(suite.t, decimal.NewFromInt(1500000).Equal(result[0].TotalTransfer))
+	assert.Equal(suite.t, "FAILED", result[1].Status)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *ledgerHealthRepoTestSuite) TestRepository_GetMoneyFlowSummaries_Error() {
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryLedgerHealthMoneyFlowSummaries)).
+		WillReturnError(assert.AnError)
+
+	_, err := suite.repo.GetMoneyFlowSummaries(context.Background())
+	assert.ErrorIs(suite.t, err, assert.AnError)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
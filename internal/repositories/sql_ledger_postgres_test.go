@@ -0,0 +1,121 @@
+//go:build e2e
+
+package repositories_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting/mock"
+	mockFlag "bitbucket.org/Amartha/go-fp-transaction/internal/common/flag/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/e2e"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ledger rows, the daily balance of 21100100000001 is taken on the first date of the period after a transaction
+// of the date is created, and a transaction before the period is created after the daily balance.
+// 21100100000002 only has daily balance before the latest one and 10000000000001 has none.
+const ledgerRows = `
+INSERT INTO account ("accountNumber", name, "entityCode") VALUES
+  ('21100100000001', 'Lender A', '001'),
+  ('21100100000002', 'Lender B', '001'),
+  ('10000000000001', 'System', '001'),
+  ('21100100000003', 'Lender C', '002');
+
+INSERT INTO account_balance_daily ("accountNumber", "date", balance, "createdAt", "updatedAt") VALUES
+  ('21100100000002', '2025-01-03', 0, '2025-01-03 00:30:00+07', '2025-01-03 00:30:00+07'),
+  ('21100100000001', '2025-01-10', 1100, '2025-01-10 00:30:00+07', '2025-01-10 00:30:00+07');
+
+INSERT INTO transaction (
+  "transactionId", "transactionDate", "fromAccount", "fromNarrative", "toAccount", "toNarrative",
+  amount, status, method, "typeTransaction", "createdAt"
+) VALUES
+  ('trx-1', '2025-01-09', '10000000000001', '', '21100100000001', '', 1000, '1', '', 'TUPVA', '2025-01-09 10:00:00+07'),
+  ('trx-2', '2025-01-10', '10000000000001', '', '21100100000001', '', 100, '1', '', 'TUPVA', '2025-01-10 00:10:00+07'),
+  ('trx-3', '2025-01-08', '21100100000001', '', '10000000000001', '', 50, '1', '', 'WDRVA', '2025-01-12 09:00:00+07'),
+  ('trx-4', '2025-01-15', '10000000000001', '', '21100100000001', '', 200, '1', '', 'TUPVA', '2025-01-15 09:00:00+07'),
+  ('trx-5', '2025-01-15', '10000000000001', '', '21100100000001', '', 700, '0', '', 'TUPVA', '2025-01-15 09:00:00+07'),
+  ('trx-6', '2025-01-05', '10000000000001', '', '21100100000002', '', 300, '1', '', 'TUPVA', '2025-01-05 09:00:00+07'),
+  ('trx-7', '2025-01-25', '10000000000001', '', '21100100000001', '', 400, '1', '', 'TUPVA', '2025-01-25 09:00:00+07'),
+  ('trx-8', '2025-01-15', '10000000000001', '', '21100100000003', '', 900, '1', '', 'TUPVA', '2025-01-15 09:00:00+07');
+`
+
+func newLedgerRepository(t *testing.T) repositories.LedgerRepository {
+	t.Helper()
+
+	postgres, err := e2e.StartPostgres()
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, postgres.Stop()) })
+
+	dbConf, err := postgres.CreateDatabase()
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, postgres.DropDatabase(dbConf.DbName)) })
+
+	db, err := sql.Open("nrpgx", e2e.DSN(dbConf))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(ledgerRows)
+	require.NoError(t, err)
+
+	mockCtrl := gomock.NewController(t)
+	return repositories.NewSQLRepository(db, db, config.Config{}, mockFlag.NewMockClient(mockCtrl), mock.NewMockClient(mockCtrl)).
+		GetLedgerRepository()
+}
+
+func TestLedgerRepository_Postgres(t *testing.T) {
+	repo := newLedgerRepository(t)
+	ctx := context.Background()
+
+	t.Run("trial balance", func(t *testing.T) {
+		accounts, err := repo.GetTrialBalance(ctx, models.TrialBalanceFilterOptions{
+			Entity:    "001",
+			StartDate: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC),
+		})
+		require.NoError(t, err)
+
+		got := map[string][3]string{}
+		for _, account := range accounts {
+			got[account.AccountNumber] = [3]string{
+				account.OpeningBalance.String(), account.Debit.String(), account.Credit.String(),
+			}
+		}
+
+		// trx-2 is in the daily balance and the period, trx-3 is created after the daily balance
+		assert.Equal(t, map[string][3]string{
+			"21100100000001": {"950", "0", "300"},
+			"21100100000002": {"300", "0", "0"},
+			"10000000000001": {"-1250", "1200", "0"},
+		}, got)
+	})
+
+	t.Run("general ledger opening balance", func(t *testing.T) {
+		tests := []struct {
+			accountNumber string
+			date          time.Time
+			want          int64
+		}{
+			{accountNumber: "21100100000001", date: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), want: 950},
+			{accountNumber: "21100100000001", date: time.Date(2025, 1, 16, 0, 0, 0, 0, time.UTC), want: 1250},
+			{accountNumber: "21100100000002", date: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), want: 300},
+			{accountNumber: "10000000000001", date: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), want: -1250},
+			{accountNumber: "21100100000009", date: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), want: 0},
+		}
+		for _, tt := range tests {
+			balance, err := repo.GetOpeningBalance(ctx, tt.accountNumber, tt.date)
+			require.NoError(t, err)
+			assert.True(t, decimal.NewFromInt(tt.want).Equal(balance), "%s at %s: %s", tt.accountNumber, tt.date, balance)
+		}
+	})
+}
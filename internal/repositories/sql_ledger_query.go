@@ -0,0 +1,99 @@
+package repositories
+
+const (
+	// the daily balance is the actual balance of the account when DoBalanceReconDaily job ran, so it contains every
+	// transaction created before the job regardless of the transaction date. The opening balance is the latest daily
+	// balance of the account taken on or before the period, plus the transactions before the period which are created
+	// after the job, minus the transactions within or after the period which are created before the job. Accounts
+	// without daily balance start from zero, so the report does not need to sum transactions from the beginning.
+	queryTrialBalance = `WITH snapshot AS (
+		  SELECT DISTINCT ON ("accountNumber") "accountNumber", balance, "updatedAt" AS "takenAt"
+		  FROM account_balance_daily
+		  WHERE "date" <= $2
+		  ORDER BY "accountNumber", "date" DESC
+		), cutoff AS (
+		  SELECT COALESCE(MIN("takenAt"), '-infinity'::timestamptz) AS "takenAt"
+		  FROM snapshot
+		), posting AS (
+		  SELECT t."fromAccount", t."toAccount", t."transactionDate", t."createdAt", t.amount
+		  FROM transaction t, cutoff
+		  WHERE (t."transactionDate" >= $2 OR t."createdAt" > cutoff."takenAt") AND t.status = $4
+		), movement AS (
+		  SELECT "fromAccount" AS "accountNumber", "transactionDate", "createdAt", amount AS debit, 0 AS credit
+		  FROM posting
+		  UNION ALL
+		  SELECT "toAccount" AS "accountNumber", "transactionDate", "createdAt", 0 AS debit, amount AS credit
+		  FROM posting
+		)
+		SELECT * FROM (
+		  SELECT
+		    a."accountNumber",
+		    COALESCE(a.name, '') AS account_name,
+		    COALESCE(a."categoryCode", '') AS category_code,
+		    COALESCE(c.name, '') AS category_name,
+		    COALESCE(a."subCategoryCode", '') AS sub_category_code,
+		    COALESCE(sc.name, '') AS sub_category_name,
+		    COALESCE(a."entityCode", '') AS entity_code,
+		    COALESCE(MAX(s.balance), 0)
+		      + COALESCE(SUM(m.credit - m.debit) FILTER (
+		        WHERE m."transactionDate" < $2 AND m."createdAt" > COALESCE(s."takenAt", '-infinity'::timestamptz)
+		      ), 0)
+		      - COALESCE(SUM(m.credit - m.debit) FILTER (
+		        WHERE m."transactionDate" >= $2 AND m."createdAt" <= s."takenAt"
+		      ), 0) AS opening_balance,
+		    COALESCE(SUM(m.debit) FILTER (WHERE m."transactionDate" BETWEEN $2 AND $3), 0) AS debit,
+		    COALESCE(SUM(m.credit) FILTER (WHERE m."transactionDate" BETWEEN $2 AND $3), 0) AS credit
+		  FROM account a
+		  LEFT JOIN category c ON c.code = a."categoryCode"
+		  LEFT JOIN sub_category sc ON sc.code = a."subCategoryCode"
+		  LEFT JOIN snapshot s ON s."accountNumber" = a."accountNumber"
+		  LEFT JOIN movement m ON m."accountNumber" = a."accountNumber"
+		  WHERE ($1::text = '' OR a."entityCode" = $1)
+		  GROUP BY a."accountNumber", a.name, a."categoryCode", c.name, a."subCategoryCode", sc.name, a."entityCode"
+		) tb
+		WHERE opening_balance <> 0 OR debit <> 0 OR credit <> 0
+		ORDER BY category_code, sub_category_code, "accountNumber";`
+
+	// same as queryTrialBalance for a single account
+	queryGeneralLedgerOpeningBalance = `WITH snapshot AS (
+		  SELECT balance, "updatedAt" AS "takenAt"
+		  FROM account_balance_daily
+		  WHERE "accountNumber" = $1 AND "date" <= $2
+		  ORDER BY "date" DESC
+		  LIMIT 1
+		), cutoff AS (
+		  SELECT
+		    COALESCE((SELECT balance FROM snapshot), 0) AS balance,
+		    COALESCE((SELECT "takenAt" FROM snapshot), '-infinity'::timestamptz) AS "takenAt"
+		)
+		SELECT cutoff.balance + COALESCE((
+		  SELECT SUM(
+		    (CASE WHEN t."toAccount" = $1 THEN t.amount ELSE 0 END - CASE WHEN t."fromAccount" = $1 THEN t.amount ELSE 0 END)
+		    * CASE WHEN t."transactionDate" < $2 THEN 1 ELSE -1 END
+		  )
+		  FROM transaction t
+		  WHERE (t."fromAccount" = $1 OR t."toAccount" = $1)
+		    AND (
+		      (t."transactionDate" < $2 AND t."createdAt" > cutoff."takenAt")
+		      OR (t."transactionDate" >= $2 AND t."createdAt" <= cutoff."takenAt")
+		    )
+		    AND t.status = $3
+		), 0)
+		FROM cutoff;`
+
+	queryGeneralLedgerPostings = `SELECT
+		  id,
+		  "transactionId",
+		  COALESCE("refNumber", ''),
+		  "typeTransaction",
+		  "transactionDate",
+		  "fromAccount",
+		  "toAccount",
+		  amount,
+		  COALESCE(description, '')
+		FROM transaction
+		WHERE ("fromAccount" = $1 OR "toAccount" = $1)
+		  AND "transactionDate" BETWEEN $2 AND $3
+		  AND status = $4
+		ORDER BY "transactionDate" ASC, id ASC;`
+)
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting/mock"
+	mockFlag "bitbucket.org/Amartha/go-fp-transaction/internal/common/flag/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLedgerRepositoryTestSuite(t *testing.T) {
+	t.Helper()
+	suite.Run(t, new(ledgerRepoTestSuite))
+}
+
+type ledgerRepoTestSuite struct {
+	suite.Suite
+	t       *testing.T
+	writeDB *sql.DB
+	mock    sqlmock.Sqlmock
+	repo    LedgerRepository
+}
+
+func (suite *ledgerRepoTestSuite) SetupTest() {
+	var err error
+	var cfg config.Config
+
+	suite.writeDB, suite.mock, err = sqlmock.New()
+	require.NoError(suite.T(), err)
+
+	suite.t = suite.T()
+	mockCtrl := gomock.NewController(suite.t)
+
+	suite.repo = NewSQLRepository(suite.writeDB, suite.writeDB, cfg, mockFlag.NewMockClient(mockCtrl), mock.NewMockClient(mockCtrl)).
+		GetLedgerRepository()
+}
+
+func (suite *ledgerRepoTestSuite) TearDownTest() {
+	suite.writeDB.Close()
+}
+
+func (suite *ledgerRepoTestSuite) TestRepository_GetTrialBalance() {
+	opts := models.TrialBalanceFilterOptions{
+		Entity:    "001",
+		StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryTrialBalance)).
+		WithArgs("001", opts.StartDate, opts.EndDate, "1").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"accountNumber", "account_name", "category_code", "category_name", "sub_category_code", "sub_category_name",
+			"entity_code", "opening_balance", "debit", "credit",
+		}).
+			AddRow("21100100000001", "John", "211", "Wallet", "21100", "Lender", "001", "1000", "0", "500").
+			AddRow("14100100000001", "Bank", "141", "Bank", "14100", "Operational", "001", "-1000", "500", "0"))
+
+	accounts, err := suite.repo.GetTrialBalance(context.Background(), opts)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, []models.TrialBalanceAccount{
+		{
+			AccountNumber: "21100100000001", AccountName: "John", CategoryCode: "211", CategoryName: "Wallet",
+			SubCategoryCode: "21100", SubCategoryName: "Lender", EntityCode: "001",
+			OpeningBalance: decimal.NewFromInt(1000), Debit: decimal.NewFromInt(0), Credit: decimal.NewFromInt(500),
+		},
+		{
+			AccountNumber: "14100100000001", AccountName: "Bank", CategoryCode: "141", CategoryName: "Bank",
+			SubCategoryCode: "14100", SubCategoryName: "Operational", EntityCode: "001",
+			OpeningBalance: decimal.NewFromInt(-1000), Debit: decimal.NewFromInt(500), Credit: decimal.NewFromInt(0),
+		},
+	}, accounts)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *ledgerRepoTestSuite) TestRepository_GetTrialBalance_Error() {
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryTrialBalance)).
+		WillReturnError(assert.AnError)
+
+	_, err := suite.repo.GetTrialBalance(context.Background(), models.TrialBalanceFilterOptions{})
+	assert.Error(suite.t, err)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *ledgerRepoTestSuite) TestRepository_GetOpeningBalance() {
+	date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryGeneralLedgerOpeningBalance)).
+		WithArgs("21100100000001", date, "1").
+		WillReturnRows(sqlmock.NewRows([]string{"balance"}).AddRow("150000"))
+
+	balance, err := suite.repo.GetOpeningBalance(context.Background(), "21100100000001", date)
+	assert.NoError(suite.t, err)
+	assert.True(suite.t, decimal.NewFromInt(150000).Equal(balance))
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *ledgerRepoTestSuite) TestRepository_GetPostings() {
+	opts := models.GeneralLedgerFilterOptions{
+		AccountNumber: "21100100000001",
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:       time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+	transactionDate := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryGeneralLedgerPostings)).
+		WithArgs(opts.AccountNumber, opts.StartDate, opts.EndDate, "1").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "transactionId", "refNumber", "typeTransaction", "transactionDate", "fromAccount", "toAccount", "amount", "description",
+		}).
+			AddRow(1, "TRX-1", "REF-1", "TUPVA", transactionDate, "14100100000001", "21100100000001", "50000", "topup"))
+
+	postings, err := suite.repo.GetPostings(context.Background(), opts)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, []models.LedgerPosting{
+		{
+			ID:              1,
+			TransactionID:   "TRX-1",
+			RefNumber:       "REF-1",
+			TransactionType: "TUPVA",
+			TransactionDate: transactionDate,
+			FromAccount:     "14100100000001",
+			ToAccount:       "21100100000001",
+			Amount:          decimal.NewFromInt(50000),
+			Description:     "topup",
+		},
+	}, postings)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
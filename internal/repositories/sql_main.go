@@ -35,9 +35,19 @@ type Repository struct {
 	scr  *subCategoryRepository
 	er   *entityRepository
 	rsr  *reconToolHistoryRepo
+	rer  *reconExceptionRepo
 	fr   *featureRepository
 	wtr  *walletTrxRepo
 	mfc  *moneyFlowRepository
+	mfbr *moneyFlowBusinessRuleRepo
+	txr  *taxRateRepo
+	ejr  *exportJobRepo
+	rrr  *reportRunRepo
+	ldr  *ledgerRepo
+	tmr  *transactionMetricRepo
+	awtr *asyncWalletTrxRepo
+	apr  *approvalRepo
+	lhr  *ledgerHealthRepo
 
 	accountConfigFromInternal AccountConfigRepository
 	accountConfigFromExternal AccountConfigRepository
@@ -67,9 +77,19 @@ func NewSQLRepository(
 	rtx.scr = (*subCategoryRepository)(&rtx.common)
 	rtx.er = (*entityRepository)(&rtx.common)
 	rtx.rsr = (*reconToolHistoryRepo)(&rtx.common)
+	rtx.rer = (*reconExceptionRepo)(&rtx.common)
 	rtx.fr = (*featureRepository)(&rtx.common)
 	rtx.wtr = (*walletTrxRepo)(&rtx.common)
 	rtx.mfc = (*moneyFlowRepository)(&rtx.common)
+	rtx.mfbr = (*moneyFlowBusinessRuleRepo)(&rtx.common)
+	rtx.txr = (*taxRateRepo)(&rtx.common)
+	rtx.ejr = (*exportJobRepo)(&rtx.common)
+	rtx.rrr = (*reportRunRepo)(&rtx.common)
+	rtx.ldr = (*ledgerRepo)(&rtx.common)
+	rtx.tmr = (*transactionMetricRepo)(&rtx.common)
+	rtx.awtr = (*asyncWalletTrxRepo)(&rtx.common)
+	rtx.apr = (*approvalRepo)(&rtx.common)
+	rtx.lhr = (*ledgerHealthRepo)(&rtx.common)
 
 	rtx.accountConfigFromInternal = (*accountConfigRepository)(&rtx.common)
 	rtx.accountConfigFromExternal = &accountConfigFromExternal{accountingClient: accounting}
@@ -88,6 +108,7 @@ type SQLRepository interface {
 	GetSubCategoryRepository() SubCategoryRepository
 	GetEntityRepository() EntityRepository
 	GetReconToolHistoryRepository() ReconToolHistoryRepository
+	GetReconExceptionRepository() ReconExceptionRepository
 	GetFeatureRepository() FeatureRepository
 	GetWalletTransactionRepository() WalletTransactionRepository
 	GetBalanceRepository() BalanceRepository
@@ -101,6 +122,15 @@ type SQLRepository interface {
 	DisableIndexScan(ctx context.Context) (err error)
 
 	GetMoneyFlowCalcRepository() MoneyFlowRepository
+	GetMoneyFlowBusinessRuleRepository() MoneyFlowBusinessRuleRepository
+	GetTaxRateRepository() TaxRateRepository
+	GetExportJobRepository() ExportJobRepository
+	GetReportRunRepository() ReportRunRepository
+	GetLedgerRepository() LedgerRepository
+	GetTransactionMetricRepository() TransactionMetricRepository
+	GetAsyncWalletTransactionRepository() AsyncWalletTransactionRepository
+	GetApprovalRepository() ApprovalRepository
+	GetLedgerHealthRepository() LedgerHealthRepository
 }
 
 var _ SQLRepository = (*Repository)(nil)
@@ -170,6 +200,10 @@ func (r *Repository) GetReconToolHistoryRepository() ReconToolHistoryRepository
 	return r.rsr
 }
 
+func (r *Repository) GetReconExceptionRepository() ReconExceptionRepository {
+	return r.rer
+}
+
 func (r *Repository) GetFeatureRepository() FeatureRepository {
 	return r.fr
 }
@@ -204,3 +238,39 @@ func (r *Repository) SubstitutePlaceholder(data string, startInt int) (res strin
 func (r *Repository) GetMoneyFlowCalcRepository() MoneyFlowRepository {
 	return r.mfc
 }
+
+func (r *Repository) GetMoneyFlowBusinessRuleRepository() MoneyFlowBusinessRuleRepository {
+	return r.mfbr
+}
+
+func (r *Repository) GetTaxRateRepository() TaxRateRepository {
+	return r.txr
+}
+
+func (r *Repository) GetExportJobRepository() ExportJobRepository {
+	return r.ejr
+}
+
+func (r *Repository) GetReportRunRepository() ReportRunRepository {
+	return r.rrr
+}
+
+func (r *Repository) GetLedgerRepository() LedgerRepository {
+	return r.ldr
+}
+
+func (r *Repository) GetTransactionMetricRepository() TransactionMetricRepository {
+	return r.tmr
+}
+
+func (r *Repository) GetAsyncWalletTransactionRepository() AsyncWalletTransactionRepository {
+	return r.awtr
+}
+
+func (r *Repository) GetApprovalRepository() ApprovalRepository {
+	return r.apr
+}
+
+func (r *Repository) GetLedgerHealthRepository() LedgerHealthRepository {
+	return r.lhr
+}
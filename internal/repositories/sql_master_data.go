@@ -0,0 +1,438 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/lib/pq"
+)
+
+// MasterDataNotifyChannel is the postgres channel notified on every master data change,
+// every instance listening to this channel reload its master data immediately
+const MasterDataNotifyChannel = "master_data_changed"
+
+// MasterDataListener deliver notification of MasterDataNotifyChannel, *pq.Listener satisfies this interface
+type MasterDataListener interface {
+	NotificationChannel() <-chan *pq.Notification
+}
+
+// NewMasterDataListener listen to MasterDataNotifyChannel using a dedicated connection,
+// the connection is re-established automatically when it is lost
+func NewMasterDataListener(dsn string) (*pq.Listener, error) {
+	listener := pq.NewListener(dsn, 10*time.Second, time.Minute, nil)
+	if err := listener.Listen(MasterDataNotifyChannel); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to listen %s: %w", MasterDataNotifyChannel, err)
+	}
+
+	return listener, nil
+}
+
+type masterDataSnapshot struct {
+	orderTypes           []models.OrderType
+	vatRevenue           []models.ConfigVatRevenue
+	orderTypeCodes       []string
+	transactionTypeCodes []string
+	refreshedAt          time.Time
+}
+
+type sqlMasterDataRepository struct {
+	r        *Repository
+	listener MasterDataListener
+
+	data atomic.Pointer[masterDataSnapshot]
+}
+
+var _ MasterDataRepository = (*sqlMasterDataRepository)(nil)
+
+// NewSQLMasterDataRepository create MasterDataRepository backed by postgres.
+// Reads are served from memory which is reloaded periodically and whenever the listener is notified,
+// listener is optional, without it changes from other instances are visible on the next interval.
+func NewSQLMasterDataRepository(r *Repository, listener MasterDataListener) MasterDataRepository {
+	repo := &sqlMasterDataRepository{
+		r:        r,
+		listener: listener,
+	}
+	repo.data.Store(&masterDataSnapshot{})
+
+	return repo
+}
+
+func (m *sqlMasterDataRepository) repopulate(ctx context.Context) error {
+	// always read from primary, replica may not have the change yet when the notification arrives
+	db := m.r.dbWrite
+
+	orderTypes, err := m.loadOrderTypes(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to read master data: %w", err)
+	}
+
+	vatRevenue, err := m.loadVATRevenue(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to read vat revenue: %w", err)
+	}
+
+	snapshot := &masterDataSnapshot{
+		orderTypes:  orderTypes,
+		vatRevenue:  vatRevenue,
+		refreshedAt: time.Now(),
+	}
+	snapshot.orderTypeCodes, snapshot.transactionTypeCodes = collectMasterDataCodes(orderTypes, time.Now())
+	m.data.Store(snapshot)
+
+	return nil
+}
+
+func (m *sqlMasterDataRepository) loadOrderTypes(ctx context.Context, db sqlTx) ([]models.OrderType, error) {
+	rows, err := db.QueryContext(ctx, queryMasterOrderTypeGetAll)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orderTypes []models.OrderType
+	indexByCode := map[string]int{}
+	for rows.Next() {
+		var orderType models.OrderType
+		if err = rows.Scan(&orderType.OrderTypeCode, &orderType.OrderTypeName, &orderType.Version); err != nil {
+			return nil, err
+		}
+		indexByCode[orderType.OrderTypeCode] = len(orderTypes)
+		orderTypes = append(orderTypes, orderType)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	trxTypeRows, err := db.QueryContext(ctx, queryMasterTransactionTypeGetAll)
+	if err != nil {
+		return nil, err
+	}
+	defer trxTypeRows.Close()
+
+	for trxTypeRows.Next() {
+		var (
+			orderTypeCode   string
+			transactionType models.TransactionType
+		)
+		if err = trxTypeRows.Scan(append([]any{&orderTypeCode}, scanMasterTransactionType(&transactionType)...)...); err != nil {
+			return nil, err
+		}
+
+		if i, ok := indexByCode[orderTypeCode]; ok {
+			orderTypes[i].TransactionTypes = append(orderTypes[i].TransactionTypes, transactionType)
+		}
+	}
+
+	return orderTypes, trxTypeRows.Err()
+}
+
+func (m *sqlMasterDataRepository) loadVATRevenue(ctx context.Context, db sqlTx) ([]models.ConfigVatRevenue, error) {
+	rows, err := db.QueryContext(ctx, queryMasterVATRevenueGetAll)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []models.ConfigVatRevenue
+	for rows.Next() {
+		var vat models.ConfigVatRevenue
+		if err = rows.Scan(&vat.Percentage, &vat.StartTime, &vat.EndTime); err != nil {
+			return nil, err
+		}
+		result = append(result, vat)
+	}
+
+	return result, rows.Err()
+}
+
+func (m *sqlMasterDataRepository) LastRefreshedAt() time.Time {
+	return m.data.Load().refreshedAt
+}
+
+func (m *sqlMasterDataRepository) RefreshDataPeriodically(ctx context.Context, interval time.Duration) {
+	err := m.repopulate(ctx)
+	if err != nil {
+		xlog.Warn(ctx, "failed to repopulate master data", xlog.Err(err))
+	}
+
+	var notification <-chan *pq.Notification
+	if m.listener != nil {
+		notification = m.listener.NotificationChannel()
+	}
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			case _, ok := <-notification:
+				// channel is closed when the listener is closed, fallback to periodic refresh only
+				if !ok {
+					notification = nil
+					continue
+				}
+			}
+
+			err := m.repopulate(ctx)
+			if err != nil {
+				xlog.Warn(ctx, "failed to repopulate master data", xlog.Err(err))
+			}
+		}
+	}()
+}
+
+func (m *sqlMasterDataRepository) GetListOrderTypeCode(_ context.Context) ([]string, error) {
+	return m.data.Load().orderTypeCodes, nil
+}
+
+func (m *sqlMasterDataRepository) GetListTransactionTypeCode(_ context.Context) ([]string, error) {
+	return m.data.Load().transactionTypeCodes, nil
+}
+
+func (m *sqlMasterDataRepository) GetListOrderType(_ context.Context, filter models.FilterMasterData) ([]models.OrderType, error) {
+	return filterOrderTypes(m.data.Load().orderTypes, filter), nil
+}
+
+func (m *sqlMasterDataRepository) GetOrderType(ctx context.Context, orderTypeCode string) (result *models.OrderType, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	return findOrderType(m.data.Load().orderTypes, orderTypeCode)
+}
+
+func (m *sqlMasterDataRepository) GetListTransactionType(_ context.Context, filter models.FilterMasterData) ([]models.TransactionType, error) {
+	return filterTransactionTypes(m.data.Load().orderTypes, filter), nil
+}
+
+func (m *sqlMasterDataRepository) GetTransactionType(ctx context.Context, transactionTypeCode string) (result *models.TransactionType, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	return findTransactionType(m.data.Load().orderTypes, transactionTypeCode)
+}
+
+func (m *sqlMasterDataRepository) GetConfigVATRevenue(_ context.Context) ([]models.ConfigVatRevenue, error) {
+	return m.data.Load().vatRevenue, nil
+}
+
+// UpsertOrderType create or replace order type with its transaction types.
+// When orderType.Version is set, common.ErrMasterDataVersionConflict is returned if the stored version is different.
+func (m *sqlMasterDataRepository) UpsertOrderType(ctx context.Context, orderType models.OrderType) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	audit := models.MasterDataAudit{
+		Entity:     models.MasterDataEntityOrderType,
+		EntityCode: orderType.OrderTypeCode,
+		Actor:      models.GetMasterDataActor(ctx),
+	}
+
+	err = m.r.Atomic(ctx, func(ctx context.Context, _ SQLRepository) error {
+		db := m.r.extractTxWrite(ctx)
+
+		current, err := m.getOrderTypeForUpdate(ctx, db, orderType.OrderTypeCode)
+		if err != nil && !errors.Is(err, common.ErrDataNotFound) {
+			return err
+		}
+
+		if current == nil {
+			audit.Action = models.MasterDataAuditActionCreate
+			orderType.Version = 1
+
+			if _, err = db.ExecContext(ctx, queryMasterOrderTypeInsert, orderType.OrderTypeCode, orderType.OrderTypeName, audit.Actor); err != nil {
+				return err
+			}
+		} else {
+			audit.Action = models.MasterDataAuditActionUpdate
+			if audit.Before, err = json.Marshal(current); err != nil {
+				return err
+			}
+
+			if orderType.Version != 0 && orderType.Version != current.Version {
+				return common.ErrMasterDataVersionConflict
+			}
+
+			err = db.QueryRowContext(ctx, queryMasterOrderTypeUpdate,
+				orderType.OrderTypeCode,
+				orderType.OrderTypeName,
+				audit.Actor,
+				current.Version,
+			).Scan(&orderType.Version)
+			if errors.Is(err, sql.ErrNoRows) {
+				return common.ErrMasterDataVersionConflict
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if _, err = db.ExecContext(ctx, queryMasterTransactionTypeDeleteByOrderType, orderType.OrderTypeCode); err != nil {
+			return err
+		}
+
+		for _, transactionType := range orderType.TransactionTypes {
+			_, err = db.ExecContext(ctx, queryMasterTransactionTypeInsert,
+				orderType.OrderTypeCode,
+				transactionType.TransactionTypeCode,
+				transactionType.TransactionTypeName,
+				transactionType.GetStatus(),
+				transactionType.EffectiveFrom,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		audit.Version = orderType.Version
+		if audit.After, err = json.Marshal(orderType); err != nil {
+			return err
+		}
+
+		return m.recordChange(ctx, db, audit)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert master order type: %w", err)
+	}
+
+	// make the change visible in this instance without waiting for the notification
+	if errReload := m.repopulate(ctx); errReload != nil {
+		xlog.Warn(ctx, "failed to repopulate master data", xlog.Err(errReload))
+	}
+
+	return nil
+}
+
+// UpsertConfigVATRevenue replace every vat revenue config with the given configs
+func (m *sqlMasterDataRepository) UpsertConfigVATRevenue(ctx context.Context, vatRevenue []models.ConfigVatRevenue) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	audit := models.MasterDataAudit{
+		Entity: models.MasterDataEntityVATRevenue,
+		Action: models.MasterDataAuditActionUpdate,
+		Actor:  models.GetMasterDataActor(ctx),
+	}
+
+	err = m.r.Atomic(ctx, func(ctx context.Context, _ SQLRepository) error {
+		db := m.r.extractTxWrite(ctx)
+
+		current, err := m.loadVATRevenue(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		if audit.Before, err = json.Marshal(current); err != nil {
+			return err
+		}
+
+		if _, err = db.ExecContext(ctx, queryMasterVATRevenueDeleteAll); err != nil {
+			return err
+		}
+
+		for _, vat := range vatRevenue {
+			_, err = db.ExecContext(ctx, queryMasterVATRevenueInsert, vat.Percentage, vat.StartTime, vat.EndTime, audit.Actor)
+			if err != nil {
+				return err
+			}
+		}
+
+		if audit.After, err = json.Marshal(vatRevenue); err != nil {
+			return err
+		}
+
+		return m.recordChange(ctx, db, audit)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update master configVATRevenue: %w", err)
+	}
+
+	if errReload := m.repopulate(ctx); errReload != nil {
+		xlog.Warn(ctx, "failed to repopulate master data", xlog.Err(errReload))
+	}
+
+	return nil
+}
+
+func (m *sqlMasterDataRepository) getOrderTypeForUpdate(ctx context.Context, db sqlTx, orderTypeCode string) (*models.OrderType, error) {
+	var orderType models.OrderType
+	err := db.QueryRowContext(ctx, queryMasterOrderTypeGetForUpdate, orderTypeCode).
+		Scan(&orderType.OrderTypeCode, &orderType.OrderTypeName, &orderType.Version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, queryMasterTransactionTypeGetByOrderType, orderTypeCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var transactionType models.TransactionType
+		if err = rows.Scan(scanMasterTransactionType(&transactionType)...); err != nil {
+			return nil, err
+		}
+		orderType.TransactionTypes = append(orderType.TransactionTypes, transactionType)
+	}
+
+	return &orderType, rows.Err()
+}
+
+// recordChange write the audit and notify other instances, both are part of the caller transaction
+func (m *sqlMasterDataRepository) recordChange(ctx context.Context, db sqlTx, audit models.MasterDataAudit) error {
+	_, err := db.ExecContext(ctx, queryMasterDataAuditInsert,
+		audit.Entity,
+		audit.EntityCode,
+		audit.Action,
+		audit.Version,
+		audit.Actor,
+		nullableJSON(audit.Before),
+		nullableJSON(audit.After),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert master data audit: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, queryMasterDataNotify, MasterDataNotifyChannel, audit.Entity+":"+audit.EntityCode)
+	if err != nil {
+		return fmt.Errorf("failed to notify master data change: %w", err)
+	}
+
+	return nil
+}
+
+// scanMasterTransactionType return scan destination of code, name, status and effective_from column
+func scanMasterTransactionType(transactionType *models.TransactionType) []any {
+	return []any{
+		&transactionType.TransactionTypeCode,
+		&transactionType.TransactionTypeName,
+		&transactionType.Status,
+		&transactionType.EffectiveFrom,
+	}
+}
+
+func nullableJSON(b json.RawMessage) any {
+	if len(b) == 0 {
+		return nil
+	}
+
+	return string(b)
+}
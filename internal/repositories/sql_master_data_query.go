@@ -0,0 +1,71 @@
+package repositories
+
+var (
+	queryMasterOrderTypeGetAll = `SELECT code, name, version
+		FROM master_order_types
+		ORDER BY code ASC;`
+
+	queryMasterTransactionTypeGetAll = `SELECT order_type_code, code, name, status, effective_from
+		FROM master_transaction_types
+		ORDER BY order_type_code ASC, code ASC;`
+
+	queryMasterVATRevenueGetAll = `SELECT percentage, start_time, end_time
+		FROM master_vat_revenue_configs
+		ORDER BY start_time ASC;`
+
+	queryMasterOrderTypeGetForUpdate = `SELECT code, name, version
+		FROM master_order_types
+		WHERE code = $1
+		FOR UPDATE;`
+
+	queryMasterTransactionTypeGetByOrderType = `SELECT code, name, status, effective_from
+		FROM master_transaction_types
+		WHERE order_type_code = $1
+		ORDER BY code ASC;`
+
+	queryMasterOrderTypeInsert = `INSERT INTO master_order_types(
+			code, name, version, created_by, updated_by, created_at, updated_at
+		)
+		VALUES(
+			$1, $2, 1, $3, $3, NOW(), NOW()
+		);`
+
+	// version in WHERE clause make sure the row is not changed since it was read
+	queryMasterOrderTypeUpdate = `UPDATE master_order_types
+		SET
+		  name = $2,
+		  version = version + 1,
+		  updated_by = $3,
+		  updated_at = NOW()
+		WHERE
+		  code = $1 AND version = $4
+		RETURNING version;`
+
+	queryMasterTransactionTypeDeleteByOrderType = `DELETE FROM master_transaction_types WHERE order_type_code = $1;`
+
+	queryMasterTransactionTypeInsert = `INSERT INTO master_transaction_types(
+			order_type_code, code, name, status, effective_from, created_at, updated_at
+		)
+		VALUES(
+			$1, $2, $3, $4, $5, NOW(), NOW()
+		);`
+
+	queryMasterVATRevenueDeleteAll = `DELETE FROM master_vat_revenue_configs;`
+
+	queryMasterVATRevenueInsert = `INSERT INTO master_vat_revenue_configs(
+			percentage, start_time, end_time, created_by, created_at
+		)
+		VALUES(
+			$1, $2, $3, $4, NOW()
+		);`
+
+	queryMasterDataAuditInsert = `INSERT INTO master_data_audits(
+			entity, entity_code, action, version, actor, before, after, created_at
+		)
+		VALUES(
+			$1, $2, $3, $4, $5, $6, $7, NOW()
+		);`
+
+	// pg_notify is only delivered when the transaction is committed
+	queryMasterDataNotify = `SELECT pg_notify($1, $2);`
+)
@@ -0,0 +1,307 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"go.uber.org/mock/gomock"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting/mock"
+	mockFlag "bitbucket.org/Amartha/go-fp-transaction/internal/common/flag/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMasterDataRepositoryTestSuite(t *testing.T) {
+	t.Helper()
+	suite.Run(t, new(masterDataRepoTestSuite))
+}
+
+type masterDataRepoTestSuite struct {
+	suite.Suite
+	t        *testing.T
+	writeDB  *sql.DB
+	mock     sqlmock.Sqlmock
+	listener *fakeMasterDataListener
+	repo     MasterDataRepository
+}
+
+type fakeMasterDataListener struct {
+	notification chan *pq.Notification
+}
+
+func (f *fakeMasterDataListener) NotificationChannel() <-chan *pq.Notification {
+	return f.notification
+}
+
+func (suite *masterDataRepoTestSuite) SetupTest() {
+	var err error
+	var cfg config.Config
+
+	suite.writeDB, suite.mock, err = sqlmock.New()
+	require.NoError(suite.T(), err)
+
+	suite.t = suite.T()
+	mockCtrl := gomock.NewController(suite.t)
+
+	suite.listener = &fakeMasterDataListener{notification: make(chan *pq.Notification)}
+	suite.repo = NewSQLMasterDataRepository(
+		NewSQLRepository(suite.writeDB, suite.writeDB, cfg, mockFlag.NewMockClient(mockCtrl), mock.NewMockClient(mockCtrl)),
+		suite.listener,
+	)
+}
+
+func (suite *masterDataRepoTestSuite) TearDownTest() {
+	suite.writeDB.Close()
+}
+
+func (suite *masterDataRepoTestSuite) expectRepopulate(orderTypeName string) {
+	suite.mock.ExpectQuery(regexp.QuoteMeta(queryMasterOrderTypeGetAll)).
+		WillReturnRows(sqlmock.NewRows([]string{"code", "name", "version"}).
+			AddRow("1001", orderTypeName, 2).
+			AddRow("1002", "Cashout Lender P2P", 1))
+	suite.mock.ExpectQuery(regexp.QuoteMeta(queryMasterTransactionTypeGetAll)).
+		WillReturnRows(sqlmock.NewRows([]string{"order_type_code", "code", "name", "status", "effective_from"}).
+			AddRow("1001", "1001001", "Top up Lender via Mandiri", models.TransactionTypeStatusActive, nil).
+			AddRow("1001", "1001002", "Top up Lender via Permata", models.TransactionTypeStatusActive, nil).
+			AddRow("1002", "1002001", "Cashout via BCA", models.TransactionTypeStatusActive, nil))
+	suite.mock.ExpectQuery(regexp.QuoteMeta(queryMasterVATRevenueGetAll)).
+		WillReturnRows(sqlmock.NewRows([]string{"percentage", "start_time", "end_time"}).
+			AddRow("11", time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func (suite *masterDataRepoTestSuite) TestRepository_RefreshDataPeriodically() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	suite.expectRepopulate("Top Up Lender (P2P)")
+	suite.repo.RefreshDataPeriodically(ctx, time.Hour)
+	require.NoError(suite.t, suite.mock.ExpectationsWereMet())
+
+	orderTypeCodes, _ := suite.repo.GetListOrderTypeCode(ctx)
+	assert.Equal(suite.t, []string{"1001", "1002"}, orderTypeCodes)
+
+	transactionTypeCodes, _ := suite.repo.GetListTransactionTypeCode(ctx)
+	assert.Equal(suite.t, []string{"1001001", "1001002", "1002001"}, transactionTypeCodes)
+
+	orderType, err := suite.repo.GetOrderType(ctx, "1001")
+	require.NoError(suite.t, err)
+	assert.Equal(suite.t, int64(2), orderType.Version)
+	assert.Len(suite.t, orderType.TransactionTypes, 2)
+
+	_, err = suite.repo.GetTransactionType(ctx, "9999999")
+	assert.ErrorIs(suite.t, err, common.ErrDataNotFound)
+
+	vatRevenue, _ := suite.repo.GetConfigVATRevenue(ctx)
+	require.Len(suite.t, vatRevenue, 1)
+	assert.True(suite.t, vatRevenue[0].Percentage.Equal(decimal.NewFromInt(11)))
+
+	// change from other instance is reloaded when notification arrives
+	suite.expectRepopulate("Top Up Lender")
+	suite.listener.notification <- &pq.Notification{Channel: MasterDataNotifyChannel, Extra: "ORDER_TYPE:1001"}
+
+	assert.Eventually(suite.t, func() bool {
+		orderType, err := suite.repo.GetOrderType(ctx, "1001")
+		return err == nil && orderType.OrderTypeName == "Top Up Lender"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (suite *masterDataRepoTestSuite) TestRepository_UpsertOrderType() {
+	orderType := models.OrderType{
+		OrderTypeCode: "1001",
+		OrderTypeName: "Top Up Lender",
+		TransactionTypes: []models.TransactionType{
+			{TransactionTypeCode: "1001001", TransactionTypeName: "Top up Lender via Mandiri"},
+		},
+	}
+	orderTypeColumns := []string{"code", "name", "version"}
+
+	testCases := []struct {
+		name    string
+		in      models.OrderType
+		doMock  func()
+		wantErr error
+	}{
+		{
+			name: "create new order type",
+			in:   orderType,
+			doMock: func() {
+				suite.mock.ExpectBegin()
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryMasterOrderTypeGetForUpdate)).
+					WithArgs("1001").
+					WillReturnError(sql.ErrNoRows)
+				suite.mock.ExpectExec(regexp.QuoteMeta(queryMasterOrderTypeInsert)).
+					WithArgs("1001", "Top Up Lender", "finance.ops").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				suite.mock.ExpectExec(regexp.QuoteMeta(queryMasterTransactionTypeDeleteByOrderType)).
+					WithArgs("1001").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				suite.mock.ExpectExec(regexp.QuoteMeta(queryMasterTransactionTypeInsert)).
+					WithArgs("1001", "1001001", "Top up Lender via Mandiri", models.TransactionTypeStatusActive, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				suite.mock.ExpectExec(regexp.QuoteMeta(queryMasterDataAuditInsert)).
+					WithArgs(models.MasterDataEntityOrderType, "1001", models.MasterDataAuditActionCreate, int64(1), "finance.ops", nil, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				suite.mock.ExpectExec(regexp.QuoteMeta(queryMasterDataNotify)).
+					WithArgs(MasterDataNotifyChannel, "ORDER_TYPE:1001").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				suite.mock.ExpectCommit()
+				suite.expectRepopulate("Top Up Lender")
+			},
+		},
+		{
+			name: "update existing order type",
+			in: func() models.OrderType {
+				in := orderType
+				in.Version = 2
+				return in
+			}(),
+			doMock: func() {
+				suite.mock.ExpectBegin()
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryMasterOrderTypeGetForUpdate)).
+					WithArgs("1001").
+					WillReturnRows(sqlmock.NewRows(orderTypeColumns).AddRow("1001", "Top Up Lender (P2P)", 2))
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryMasterTransactionTypeGetByOrderType)).
+					WithArgs("1001").
+					WillReturnRows(sqlmock.NewRows([]string{"code", "name", "status", "effective_from"}).AddRow("1001001", "Top up Lender via Mandiri", models.TransactionTypeStatusActive, nil))
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryMasterOrderTypeUpdate)).
+					WithArgs("1001", "Top Up Lender", "finance.ops", int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(3))
+				suite.mock.ExpectExec(regexp.QuoteMeta(queryMasterTransactionTypeDeleteByOrderType)).
+					WithArgs("1001").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				suite.mock.ExpectExec(regexp.QuoteMeta(queryMasterTransactionTypeInsert)).
+					WithArgs("1001", "1001001", "Top up Lender via Mandiri", models.TransactionTypeStatusActive, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				suite.mock.ExpectExec(regexp.QuoteMeta(queryMasterDataAuditInsert)).
+					WithArgs(models.MasterDataEntityOrderType, "1001", models.MasterDataAuditActionUpdate, int64(3), "finance.ops", sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				suite.mock.ExpectExec(regexp.QuoteMeta(queryMasterDataNotify)).
+					WithArgs(MasterDataNotifyChannel, "ORDER_TYPE:1001").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				suite.mock.ExpectCommit()
+				suite.expectRepopulate("Top Up Lender")
+			},
+		},
+		{
+			name: "stale version is rejected",
+			in: func() models.OrderType {
+				in := orderType
+				in.Version = 1
+				return in
+			}(),
+			doMock: func() {
+				suite.mock.ExpectBegin()
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryMasterOrderTypeGetForUpdate)).
+					WithArgs("1001").
+					WillReturnRows(sqlmock.NewRows(orderTypeColumns).AddRow("1001", "Top Up Lender (P2P)", 2))
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryMasterTransactionTypeGetByOrderType)).
+					WithArgs("1001").
+					WillReturnRows(sqlmock.NewRows([]string{"code", "name", "status", "effective_from"}))
+				suite.mock.ExpectRollback()
+			},
+			wantErr: common.ErrMasterDataVersionConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.doMock()
+
+			ctx := models.WithMasterDataActor(context.Background(), "finance.ops")
+			err := suite.repo.UpsertOrderType(ctx, tc.in)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, suite.mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *masterDataRepoTestSuite) TestRepository_UpsertConfigVATRevenue() {
+	startTime := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endTime := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	in := []models.ConfigVatRevenue{
+		{Percentage: decimal.NewFromInt(12), StartTime: startTime, EndTime: endTime},
+	}
+
+	suite.mock.ExpectBegin()
+	suite.mock.ExpectQuery(regexp.QuoteMeta(queryMasterVATRevenueGetAll)).
+		WillReturnRows(sqlmock.NewRows([]string{"percentage", "start_time", "end_time"}))
+	suite.mock.ExpectExec(regexp.QuoteMeta(queryMasterVATRevenueDeleteAll)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mock.ExpectExec(regexp.QuoteMeta(queryMasterVATRevenueInsert)).
+		WithArgs(sqlmock.AnyArg(), startTime, endTime, models.MasterDataDefaultActor).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	suite.mock.ExpectExec(regexp.QuoteMeta(queryMasterDataAuditInsert)).
+		WithArgs(models.MasterDataEntityVATRevenue, "", models.MasterDataAuditActionUpdate, int64(0), models.MasterDataDefaultActor, "null", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	suite.mock.ExpectExec(regexp.QuoteMeta(queryMasterDataNotify)).
+		WithArgs(MasterDataNotifyChannel, "VAT_REVENUE:").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mock.ExpectCommit()
+	suite.expectRepopulate("Top Up Lender")
+
+	err := suite.repo.UpsertConfigVATRevenue(context.Background(), in)
+	assert.NoError(suite.t, err)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+// fakeMasterDataRepository keep master data in memory, methods which are not overridden panic
+type fakeMasterDataRepository struct {
+	MasterDataRepository
+	orderTypes []models.OrderType
+	vatRevenue []models.ConfigVatRevenue
+}
+
+func (f *fakeMasterDataRepository) GetListOrderType(_ context.Context, filter models.FilterMasterData) ([]models.OrderType, error) {
+	return filterOrderTypes(f.orderTypes, filter), nil
+}
+
+func (f *fakeMasterDataRepository) UpsertOrderType(_ context.Context, orderType models.OrderType) error {
+	f.orderTypes = append(f.orderTypes, orderType)
+	return nil
+}
+
+func (f *fakeMasterDataRepository) GetConfigVATRevenue(_ context.Context) ([]models.ConfigVatRevenue, error) {
+	return f.vatRevenue, nil
+}
+
+func (f *fakeMasterDataRepository) UpsertConfigVATRevenue(_ context.Context, vatRevenue []models.ConfigVatRevenue) error {
+	f.vatRevenue = vatRevenue
+	return nil
+}
+
+func TestCopyMasterData(t *testing.T) {
+	ctx := context.Background()
+	src := &fakeMasterDataRepository{
+		orderTypes: []models.OrderType{
+			{OrderTypeCode: "1001", OrderTypeName: "Top Up Lender", Version: 5},
+			{OrderTypeCode: "1002", OrderTypeName: "Cashout Lender P2P"},
+		},
+		vatRevenue: []models.ConfigVatRevenue{{Percentage: decimal.NewFromInt(11)}},
+	}
+	dst := &fakeMasterDataRepository{}
+
+	require.NoError(t, CopyMasterData(ctx, src, dst))
+	assert.Equal(t, []models.OrderType{
+		{OrderTypeCode: "1001", OrderTypeName: "Top Up Lender"},
+		{OrderTypeCode: "1002", OrderTypeName: "Cashout Lender P2P"},
+	}, dst.orderTypes)
+	assert.Equal(t, src.vatRevenue, dst.vatRevenue)
+
+	assert.Error(t, CopyMasterData(ctx, &fakeMasterDataRepository{}, dst))
+}
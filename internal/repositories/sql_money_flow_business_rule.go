@@ -0,0 +1,198 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+)
+
+type MoneyFlowBusinessRuleRepository interface {
+	Create(ctx context.Context, in *models.MoneyFlowBusinessRule) (err error)
+	GetByVersion(ctx context.Context, version int64) (result *models.MoneyFlowBusinessRule, err error)
+	GetPublished(ctx context.Context) (result *models.MoneyFlowBusinessRule, err error)
+	GetList(ctx context.Context, opts models.MoneyFlowBusinessRuleFilterOptions) (result []models.MoneyFlowBusinessRule, err error)
+	UpdateDraft(ctx context.Context, in *models.MoneyFlowBusinessRule) (err error)
+	DeleteDraft(ctx context.Context, version int64) (err error)
+	ArchivePublished(ctx context.Context, actor string) (err error)
+	Publish(ctx context.Context, version int64, actor string) (err error)
+}
+
+type moneyFlowBusinessRuleRepo sqlRepo
+
+var _ MoneyFlowBusinessRuleRepository = (*moneyFlowBusinessRuleRepo)(nil)
+
+func (r *moneyFlowBusinessRuleRepo) Create(ctx context.Context, in *models.MoneyFlowBusinessRule) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	return db.QueryRowContext(ctx, queryMoneyFlowBusinessRuleCreate,
+		in.Status,
+		in.Rules,
+		in.Description,
+		in.CreatedBy,
+	).Scan(&in.Version, &in.CreatedAt, &in.UpdatedAt)
+}
+
+func (r *moneyFlowBusinessRuleRepo) GetByVersion(ctx context.Context, version int64) (result *models.MoneyFlowBusinessRule, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	result = &models.MoneyFlowBusinessRule{}
+	err = scanMoneyFlowBusinessRule(db.QueryRowContext(ctx, queryMoneyFlowBusinessRuleGetByVersion, version), result)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *moneyFlowBusinessRuleRepo) GetPublished(ctx context.Context) (result *models.MoneyFlowBusinessRule, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	result = &models.MoneyFlowBusinessRule{}
+	err = scanMoneyFlowBusinessRule(db.QueryRowContext(ctx, queryMoneyFlowBusinessRuleGetPublished), result)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *moneyFlowBusinessRuleRepo) GetList(ctx context.Context, opts models.MoneyFlowBusinessRuleFilterOptions) (result []models.MoneyFlowBusinessRule, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	query, args, err := buildListMoneyFlowBusinessRuleQuery(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var rule models.MoneyFlowBusinessRule
+		if err = scanMoneyFlowBusinessRule(rows, &rule); err != nil {
+			return result, err
+		}
+		result = append(result, rule)
+	}
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (r *moneyFlowBusinessRuleRepo) UpdateDraft(ctx context.Context, in *models.MoneyFlowBusinessRule) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	result, err := db.ExecContext(ctx, queryMoneyFlowBusinessRuleUpdateDraft,
+		in.Version,
+		in.Rules,
+		in.Description,
+		in.UpdatedBy,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkMoneyFlowBusinessRuleAffected(result)
+}
+
+func (r *moneyFlowBusinessRuleRepo) DeleteDraft(ctx context.Context, version int64) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	result, err := db.ExecContext(ctx, queryMoneyFlowBusinessRuleDeleteDraft, version)
+	if err != nil {
+		return err
+	}
+
+	return checkMoneyFlowBusinessRuleAffected(result)
+}
+
+func (r *moneyFlowBusinessRuleRepo) ArchivePublished(ctx context.Context, actor string) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	_, err = db.ExecContext(ctx, queryMoneyFlowBusinessRuleArchivePublished, actor)
+
+	return err
+}
+
+func (r *moneyFlowBusinessRuleRepo) Publish(ctx context.Context, version int64, actor string) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	result, err := db.ExecContext(ctx, queryMoneyFlowBusinessRulePublish, version, actor)
+	if err != nil {
+		return err
+	}
+
+	return checkMoneyFlowBusinessRuleAffected(result)
+}
+
+func checkMoneyFlowBusinessRuleAffected(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return common.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+type moneyFlowBusinessRuleScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMoneyFlowBusinessRule(row moneyFlowBusinessRuleScanner, rule *models.MoneyFlowBusinessRule) error {
+	return row.Scan(
+		&rule.Version,
+		&rule.Status,
+		&rule.Rules,
+		&rule.Description,
+		&rule.CreatedBy,
+		&rule.UpdatedBy,
+		&rule.PublishedBy,
+		&rule.PublishedAt,
+		&rule.CreatedAt,
+		&rule.UpdatedAt,
+	)
+}
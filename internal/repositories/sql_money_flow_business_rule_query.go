@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	sq "github.com/Masterminds/squirrel"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+)
+
+var (
+	queryMoneyFlowBusinessRuleCreate = `
+		INSERT INTO money_flow_business_rules(
+			status, rules, description, created_by, updated_by, created_at, updated_at
+		)
+		VALUES(
+			$1, $2, $3, $4, $4, NOW(), NOW()
+		)
+		RETURNING
+			version, created_at, updated_at;
+	`
+
+	queryMoneyFlowBusinessRuleColumns = `
+		  version,
+		  status,
+		  rules,
+		  COALESCE(description, '') as description,
+		  created_by,
+		  COALESCE(updated_by, '') as updated_by,
+		  COALESCE(published_by, '') as published_by,
+		  published_at,
+		  created_at,
+		  updated_at`
+
+	queryMoneyFlowBusinessRuleGetByVersion = `SELECT` + queryMoneyFlowBusinessRuleColumns + `
+		FROM money_flow_business_rules
+		WHERE version = $1;`
+
+	queryMoneyFlowBusinessRuleGetPublished = `SELECT` + queryMoneyFlowBusinessRuleColumns + `
+		FROM money_flow_business_rules
+		WHERE status = 'PUBLISHED'
+		ORDER BY version DESC
+		LIMIT 1;`
+
+	queryMoneyFlowBusinessRuleUpdateDraft = `UPDATE money_flow_business_rules
+		SET
+		  rules = $2,
+		  description = $3,
+		  updated_by = $4,
+		  updated_at = NOW()
+		WHERE
+		  version = $1 AND status = 'DRAFT'`
+
+	queryMoneyFlowBusinessRuleDeleteDraft = `DELETE FROM money_flow_business_rules WHERE version = $1 AND status = 'DRAFT'`
+
+	queryMoneyFlowBusinessRuleArchivePublished = `UPDATE money_flow_business_rules
+		SET
+		  status = 'ARCHIVED',
+		  updated_by = $1,
+		  updated_at = NOW()
+		WHERE
+		  status = 'PUBLISHED'`
+
+	queryMoneyFlowBusinessRulePublish = `UPDATE money_flow_business_rules
+		SET
+		  status = 'PUBLISHED',
+		  published_by = $2,
+		  published_at = NOW(),
+		  updated_by = $2,
+		  updated_at = NOW()
+		WHERE
+		  version = $1 AND status = 'DRAFT'`
+)
+
+func buildListMoneyFlowBusinessRuleQuery(opts models.MoneyFlowBusinessRuleFilterOptions) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	query := psql.Select(queryMoneyFlowBusinessRuleColumns).From("money_flow_business_rules")
+
+	if opts.Status != "" {
+		query = query.Where(sq.Eq{"status": opts.Status})
+	}
+
+	return query.OrderBy("version DESC").ToSql()
+}
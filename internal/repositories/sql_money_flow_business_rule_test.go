@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting/mock"
+	mockFlag "bitbucket.org/Amartha/go-fp-transaction/internal/common/flag/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMoneyFlowBusinessRuleRepositoryTestSuite(t *testing.T) {
+	t.Helper()
+	suite.Run(t, new(moneyFlowBusinessRuleRepoTestSuite))
+}
+
+type moneyFlowBusinessRuleRepoTestSuite struct {
+	suite.Suite
+	t       *testing.T
+	writeDB *sql.DB
+	mock    sqlmock.Sqlmock
+	repo    MoneyFlowBusinessRuleRepository
+}
+
+var moneyFlowBusinessRuleColumns = []string{
+	"version", "status", "rules", "description", "created_by", "updated_by",
+	"published_by", "published_at", "created_at", "updated_at",
+}
+
+func (suite *moneyFlowBusinessRuleRepoTestSuite) SetupTest() {
+	var err error
+	var cfg config.Config
+
+	suite.writeDB, suite.mock, err = sqlmock.New()
+	require.NoError(suite.T(), err)
+
+	suite.t = suite.T()
+	mockCtrl := gomock.NewController(suite.t)
+
+	suite.repo = NewSQLRepository(suite.writeDB, suite.writeDB, cfg, mockFlag.NewMockClient(mockCtrl), mock.NewMockClient(mockCtrl)).
+		GetMoneyFlowBusinessRuleRepository()
+}
+
+func (suite *moneyFlowBusinessRuleRepoTestSuite) TearDownTest() {
+	suite.writeDB.Close()
+}
+
+func (suite *moneyFlowBusinessRuleRepoTestSuite) TestRepository_Create() {
+	in := &models.MoneyFlowBusinessRule{
+		Status:    models.MoneyFlowBusinessRuleStatusDraft,
+		Rules:     models.BusinessRulesConfigs{TransactionToPaymentMap: map[string]string{"DVEST": "MF_EARN_DIVEST"}},
+		CreatedBy: "finance.ops",
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryMoneyFlowBusinessRuleCreate)).
+		WithArgs(in.Status, sqlmock.AnyArg(), in.Description, in.CreatedBy).
+		WillReturnRows(sqlmock.NewRows([]string{"version", "created_at", "updated_at"}).AddRow(3, time.Now(), time.Now()))
+
+	err := suite.repo.Create(context.Background(), in)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, int64(3), in.Version)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *moneyFlowBusinessRuleRepoTestSuite) TestRepository_GetPublished() {
+	testCases := []struct {
+		name    string
+		wantErr error
+		doMock  func()
+	}{
+		{
+			name: "happy path",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryMoneyFlowBusinessRuleGetPublished)).
+					WillReturnRows(sqlmock.NewRows(moneyFlowBusinessRuleColumns).AddRow(
+						2, "PUBLISHED", `{"transaction_to_payment_map":{"DVEST":"MF_EARN_DIVEST"}}`, "", "finance.ops", "",
+						"finance.lead", time.Now(), time.Now(), time.Now(),
+					))
+			},
+		},
+		{
+			name: "nothing published",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryMoneyFlowBusinessRuleGetPublished)).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: common.ErrDataNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.doMock()
+
+			got, err := suite.repo.GetPublished(context.Background())
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(2), got.Version)
+				assert.Equal(t, "MF_EARN_DIVEST", got.Rules.TransactionToPaymentMap["DVEST"])
+			}
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *moneyFlowBusinessRuleRepoTestSuite) TestRepository_GetList() {
+	opts := models.MoneyFlowBusinessRuleFilterOptions{Status: models.MoneyFlowBusinessRuleStatusDraft}
+	query, _, _ := buildListMoneyFlowBusinessRuleQuery(opts)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(query)).
+		WithArgs(models.MoneyFlowBusinessRuleStatusDraft).
+		WillReturnRows(sqlmock.NewRows(moneyFlowBusinessRuleColumns).AddRow(
+			3, "DRAFT", `{}`, "next rules", "finance.ops", "finance.ops",
+			"", nil, time.Now(), time.Now(),
+		))
+
+	got, err := suite.repo.GetList(context.Background(), opts)
+	assert.NoError(suite.t, err)
+	assert.Len(suite.t, got, 1)
+	assert.Nil(suite.t, got[0].PublishedAt)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *moneyFlowBusinessRuleRepoTestSuite) TestRepository_Publish() {
+	testCases := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      bool
+	}{
+		{name: "happy path", rowsAffected: 1},
+		{name: "version is not draft", rowsAffected: 0, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			suite.mock.
+				ExpectExec(regexp.QuoteMeta(queryMoneyFlowBusinessRulePublish)).
+				WithArgs(int64(3), "finance.lead").
+				WillReturnResult(sqlmock.NewResult(0, tc.rowsAffected))
+
+			err := suite.repo.Publish(context.Background(), 3, "finance.lead")
+			assert.Equal(t, tc.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
@@ -28,6 +28,13 @@ type MoneyFlowRepository interface {
 	GetTransactionProcessed(ctx context.Context, breakdownTransactionsFrom string, transactionSourceDate time.Time) (*models.MoneyFlowTransactionProcessed, error)
 	UpdateSummary(ctx context.Context, summaryID string, update models.MoneyFlowSummaryUpdate) error
 	GetSummaryIDByPapaTransactionID(ctx context.Context, papaTransactionID string) (string, error)
+	GetSummaryStatusForUpdate(ctx context.Context, summaryID string) (string, error)
+	CreateSummaryEvent(ctx context.Context, in models.CreateMoneyFlowSummaryEvent) error
+	GetSummaryEvents(ctx context.Context, summaryID string) ([]models.MoneyFlowSummaryEvent, error)
+	GetLastPapaEventTime(ctx context.Context, summaryID string) (*time.Time, error)
+	GetPendingSummariesForDisbursement(ctx context.Context, paymentType string, beforeDate, claimedBefore time.Time, limit int) ([]models.MoneyFlowSummaryForDisbursement, error)
+	ClaimSummaryForDisbursement(ctx context.Context, summaryID string, claimedBefore time.Time) (string, int, error)
+	IsSummaryDisbursing(ctx context.Context, referenceNumber string) (bool, error)
 	GetSummariesList(ctx context.Context, opts models.MoneyFlowSummaryFilterOptions) ([]models.MoneyFlowSummaryOut, error)
 	CountSummaryAll(ctx context.Context, opts models.MoneyFlowSummaryFilterOptions) (total int, err error)
 	GetSummaryDetailBySummaryID(ctx context.Context, summaryID string) (result models.MoneyFlowSummaryDetailBySummaryIDOut, err error)
@@ -61,9 +68,9 @@ const (
 		source_bank_account_number, source_bank_account_name, source_bank_name,
 		destination_bank_account_number, destination_bank_account_name, destination_bank_name,
 		related_failed_or_rejected_summary_id,
-		created_at, updated_at
+		created_at, business_rule_version, updated_at
 	)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, NOW())
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, NOW())
 	RETURNING id
 `
 
@@ -76,9 +83,9 @@ const (
         source_bank_account_number, source_bank_account_name, source_bank_name,
         destination_bank_account_number, destination_bank_account_name, destination_bank_name,
         related_failed_or_rejected_summary_id,
-        is_active, created_at, updated_at
+        is_active, created_at, business_rule_version, updated_at
     )
-    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, TRUE, $21, NOW())
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, TRUE, $21, $22, NOW())
     ON CONFLICT (transaction_source_creation_date, payment_type) 
     WHERE is_active = TRUE
     DO UPDATE SET
@@ -131,7 +138,8 @@ const (
 			mfs.destination_bank_account_name, 
 			mfs.destination_bank_name,
 			mfs.related_failed_or_rejected_summary_id,
-			COALESCE(related.total_transfer, 0) as related_total_transfer
+			COALESCE(related.total_transfer, 0) as related_total_transfer,
+			mfs.business_rule_version
 		FROM money_flow_summaries mfs
 		LEFT JOIN money_flow_summaries related ON mfs.related_failed_or_rejected_summary_id = related.id
 		WHERE mfs.id = $1 AND mfs.is_active = TRUE
@@ -205,7 +213,8 @@ const (
 			mfs.destination_bank_account_name, 
 			mfs.destination_bank_name,
 			mfs.related_failed_or_rejected_summary_id,
-			COALESCE(related.total_transfer, 0) as related_total_transfer
+			COALESCE(related.total_transfer, 0) as related_total_transfer,
+			mfs.business_rule_version
 		FROM money_flow_summaries mfs
 		LEFT JOIN money_flow_summaries related ON mfs.related_failed_or_rejected_summary_id = related.id
 		WHERE mfs.id = $1
@@ -215,8 +224,8 @@ const (
 func (mfr *moneyFlowRepository) CreateSummary(ctx context.Context, in models.CreateMoneyFlowSummary) (string, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxWrite(ctx)
 
@@ -242,6 +251,7 @@ func (mfr *moneyFlowRepository) CreateSummary(ctx context.Context, in models.Cre
 		in.DestinationBankName,
 		in.RelatedFailedOrRejectedSummaryID,
 		in.CreatedAt,
+		in.BusinessRuleVersion,
 	).Scan(&in.ID)
 
 	if err != nil {
@@ -259,8 +269,8 @@ func (mfr *moneyFlowRepository) UpsertSummary(
 ) (string, bool, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxWrite(ctx)
 
@@ -290,6 +300,7 @@ func (mfr *moneyFlowRepository) UpsertSummary(
 		in.DestinationBankName,
 		in.RelatedFailedOrRejectedSummaryID,
 		in.CreatedAt,
+		in.BusinessRuleVersion,
 	).Scan(&summaryID, &totalTransfer, &isNewRecord)
 
 	if err != nil {
@@ -333,8 +344,8 @@ func getRelatedIDString(relatedID *string) string {
 func (mfr *moneyFlowRepository) CreateDetailedSummary(ctx context.Context, in models.CreateDetailedMoneyFlowSummary) error {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxWrite(ctx)
 
@@ -363,8 +374,8 @@ func (mfr *moneyFlowRepository) CreateDetailedSummary(ctx context.Context, in mo
 func (mfr *moneyFlowRepository) GetTransactionProcessed(ctx context.Context, breakdownTransactionsFrom string, transactionSourceDate time.Time) (*models.MoneyFlowTransactionProcessed, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -389,8 +400,8 @@ func (mfr *moneyFlowRepository) GetTransactionProcessed(ctx context.Context, bre
 func (mfr *moneyFlowRepository) UpdateSummary(ctx context.Context, summaryID string, updates models.MoneyFlowSummaryUpdate) error {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxWrite(ctx)
 
@@ -469,8 +480,8 @@ func (mfr *moneyFlowRepository) UpdateSummary(ctx context.Context, summaryID str
 func (mfr *moneyFlowRepository) GetSummaryIDByPapaTransactionID(ctx context.Context, papaTransactionID string) (string, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -489,8 +500,8 @@ func (mfr *moneyFlowRepository) GetSummaryIDByPapaTransactionID(ctx context.Cont
 func (mfr *moneyFlowRepository) GetSummariesList(ctx context.Context, opts models.MoneyFlowSummaryFilterOptions) ([]models.MoneyFlowSummaryOut, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -545,8 +556,8 @@ func (mfr *moneyFlowRepository) GetSummariesList(ctx context.Context, opts model
 }
 
 func (mfr *moneyFlowRepository) CountSummaryAll(ctx context.Context, opts models.MoneyFlowSummaryFilterOptions) (total int, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -565,8 +576,8 @@ func (mfr *moneyFlowRepository) CountSummaryAll(ctx context.Context, opts models
 }
 
 func (mfr *moneyFlowRepository) GetSummaryDetailBySummaryID(ctx context.Context, summaryID string) (result models.MoneyFlowSummaryDetailBySummaryIDOut, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxWrite(ctx)
 
@@ -589,6 +600,7 @@ func (mfr *moneyFlowRepository) GetSummaryDetailBySummaryID(ctx context.Context,
 		&result.DestinationBankName,
 		&result.RelatedFailedOrRejectedSummaryID,
 		&result.RelatedTotalTransfer,
+		&result.BusinessRuleVersion,
 	)
 	if err != nil {
 		return
@@ -600,8 +612,8 @@ func (mfr *moneyFlowRepository) GetSummaryDetailBySummaryID(ctx context.Context,
 func (mfr *moneyFlowRepository) GetDetailedTransactionsBySummaryID(ctx context.Context, opts models.DetailedTransactionFilterOptions) ([]models.DetailedTransactionOut, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -646,8 +658,8 @@ func (mfr *moneyFlowRepository) GetDetailedTransactionsBySummaryID(ctx context.C
 }
 
 func (mfr *moneyFlowRepository) CountDetailedTransactions(ctx context.Context, opts models.DetailedTransactionFilterOptions) (total int, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -670,8 +682,8 @@ func (mfr *moneyFlowRepository) CountDetailedTransactions(ctx context.Context, o
 func (mfr *moneyFlowRepository) GetAllDetailedTransactionsBySummaryID(ctx context.Context, summaryID string, relatedSummaryID *string, refNumber string) ([]models.DetailedTransactionOut, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -758,8 +770,8 @@ func (mfr *moneyFlowRepository) GetLastFailedOrRejectedTransaction(
 ) (*models.FailedOrRejectedTransaction, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -797,8 +809,8 @@ func (mfr *moneyFlowRepository) HasPendingTransactionAfterFailedOrRejected(
 ) (bool, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -821,8 +833,8 @@ func (mfr *moneyFlowRepository) HasPendingTransactionAfterFailedOrRejected(
 func (mfr *moneyFlowRepository) HasInProgressTransaction(ctx context.Context, transactionType string, paymentType string) (bool, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -851,8 +863,8 @@ func (mfr *moneyFlowRepository) HasPendingTransactionBefore(
 ) (bool, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -875,8 +887,8 @@ func (mfr *moneyFlowRepository) HasPendingTransactionBefore(
 func (mfr *moneyFlowRepository) UpdateActivationStatus(ctx context.Context, summaryID string, isActive bool) error {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxWrite(ctx)
 
@@ -898,8 +910,8 @@ func (mfr *moneyFlowRepository) UpdateActivationStatus(ctx context.Context, summ
 }
 
 func (mfr *moneyFlowRepository) GetSummaryDetailBySummaryIDAllStatus(ctx context.Context, summaryID string) (result models.MoneyFlowSummaryDetailBySummaryIDOut, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxWrite(ctx)
 
@@ -921,6 +933,7 @@ func (mfr *moneyFlowRepository) GetSummaryDetailBySummaryIDAllStatus(ctx context
 		&result.DestinationBankName,
 		&result.RelatedFailedOrRejectedSummaryID,
 		&result.RelatedTotalTransfer,
+		&result.BusinessRuleVersion,
 	)
 	if err != nil {
 		return
@@ -932,8 +945,8 @@ func (mfr *moneyFlowRepository) GetSummaryDetailBySummaryIDAllStatus(ctx context
 // EstimateCountDetailedTransactions estimates total count using EXPLAIN query
 // Much faster than actual COUNT for large datasets (milliseconds vs seconds)
 func (mfr *moneyFlowRepository) EstimateCountDetailedTransactions(ctx context.Context, opts models.DetailedTransactionFilterOptions) (total int, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -993,8 +1006,8 @@ func parseExplainRows(jsonResult string) (int, error) {
 func (mfr *moneyFlowRepository) GetDetailedTransactionIDsWithMapping(ctx context.Context, opts models.DetailedTransactionFilterOptions) (map[string]string, []string, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -1058,8 +1071,8 @@ func (mfr *moneyFlowRepository) GetDetailedTransactionIDsWithMapping(ctx context
 func (mfr *moneyFlowRepository) GetTransactionsByIDs(ctx context.Context, transactionIDs []string, refNumber string) ([]models.DetailedTransactionOut, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -1147,8 +1160,8 @@ func (mfr *moneyFlowRepository) GetDetailedTransactionsChunk(
 ) ([]models.DetailedTransactionCSVOut, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
@@ -1311,8 +1324,8 @@ func (mfr *moneyFlowRepository) GetAllDetailedTransactionsForDownloadOptimized(
 ) ([]models.DetailedTransactionCSVOut, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := mfr.r.extractTxRead(ctx)
 
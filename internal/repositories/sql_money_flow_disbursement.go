@@ -0,0 +1,139 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+)
+
+var queryGetPendingSummariesForDisbursement = `
+	SELECT
+		mfs.id, mfs.reference_number, mfs.transaction_type, mfs.payment_type,
+		mfs.transaction_source_creation_date, mfs.total_transfer,
+		COALESCE(related.total_transfer, 0)
+	FROM money_flow_summaries mfs
+	LEFT JOIN money_flow_summaries related ON related.id = mfs.related_failed_or_rejected_summary_id
+	WHERE mfs.is_active = TRUE
+		AND (
+			mfs.money_flow_status = 'PENDING'
+			OR (mfs.money_flow_status = 'DISBURSING' AND mfs.updated_at < $3)
+		)
+		AND mfs.payment_type = $1
+		AND mfs.transaction_source_creation_date < $2
+	ORDER BY mfs.transaction_source_creation_date ASC
+	LIMIT $4
+`
+
+var queryClaimSummaryForDisbursement = `
+	WITH current AS (
+		SELECT id, money_flow_status FROM money_flow_summaries WHERE id = $1 FOR UPDATE
+	)
+	UPDATE money_flow_summaries mfs
+	SET money_flow_status = 'DISBURSING', disbursement_attempts = mfs.disbursement_attempts + 1, updated_at = NOW()
+	FROM current
+	WHERE mfs.id = current.id
+		AND mfs.is_active = TRUE
+		AND (
+			current.money_flow_status = 'PENDING'
+			OR (current.money_flow_status = 'DISBURSING' AND mfs.updated_at < $2)
+		)
+	RETURNING current.money_flow_status, mfs.disbursement_attempts
+`
+
+var queryIsSummaryDisbursing = `
+	SELECT EXISTS (
+		SELECT 1 FROM money_flow_summaries
+		WHERE reference_number = $1 AND is_active = TRUE AND money_flow_status = 'DISBURSING'
+	)
+`
+
+// GetPendingSummariesForDisbursement returns active PENDING summaries created before the given date, oldest first.
+// DISBURSING summaries claimed before claimedBefore are returned as well, their run is considered dead
+func (mfr *moneyFlowRepository) GetPendingSummariesForDisbursement(ctx context.Context, paymentType string, beforeDate, claimedBefore time.Time, limit int) ([]models.MoneyFlowSummaryForDisbursement, error) {
+	var err error
+
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := mfr.r.extractTxRead(ctx)
+
+	rows, err := db.QueryContext(ctx, queryGetPendingSummariesForDisbursement, paymentType, beforeDate, claimedBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := []models.MoneyFlowSummaryForDisbursement{}
+	for rows.Next() {
+		var summary models.MoneyFlowSummaryForDisbursement
+		err = rows.Scan(
+			&summary.ID,
+			&summary.ReferenceNumber,
+			&summary.TransactionType,
+			&summary.PaymentType,
+			&summary.TransactionSourceCreationDate,
+			&summary.TotalTransfer,
+			&summary.RelatedTotalTransfer,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
+
+// ClaimSummaryForDisbursement moves PENDING summary, or DISBURSING summary claimed before claimedBefore, to DISBURSING
+// and returns its previous status and the attempts of the transfer request including this one.
+// common.ErrNoRowsAffected is returned when the summary cannot be claimed
+func (mfr *moneyFlowRepository) ClaimSummaryForDisbursement(ctx context.Context, summaryID string, claimedBefore time.Time) (string, int, error) {
+	var err error
+
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := mfr.r.extractTxWrite(ctx)
+
+	var (
+		fromStatus string
+		attempts   int
+	)
+	err = db.QueryRowContext(ctx, queryClaimSummaryForDisbursement, summaryID, claimedBefore).Scan(&fromStatus, &attempts)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", 0, common.ErrNoRowsAffected
+		}
+		return "", 0, err
+	}
+
+	return fromStatus, attempts, nil
+}
+
+// IsSummaryDisbursing returns true when the active summary of the reference number is DISBURSING,
+// its transfer is requested but the PAPA transaction ID is not recorded yet
+func (mfr *moneyFlowRepository) IsSummaryDisbursing(ctx context.Context, referenceNumber string) (bool, error) {
+	var err error
+
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := mfr.r.extractTxRead(ctx)
+
+	var disbursing bool
+	if err = db.QueryRowContext(ctx, queryIsSummaryDisbursing, referenceNumber).Scan(&disbursing); err != nil {
+		return false, err
+	}
+
+	return disbursing, nil
+}
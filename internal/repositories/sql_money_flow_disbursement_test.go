@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+)
+
+func (suite *moneyFlowTestSuite) TestGetPendingSummariesForDisbursement_Success() {
+	beforeDate := time.Date(2025, 10, 22, 0, 0, 0, 0, time.UTC)
+	claimedBefore := time.Date(2025, 10, 22, 15, 45, 0, 0, time.UTC)
+
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(queryGetPendingSummariesForDisbursement)).
+		WithArgs("MF_EARN_DIVEST", beforeDate, claimedBefore, 10).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "reference_number", "transaction_type", "payment_type",
+			"transaction_source_creation_date", "total_transfer", "related_total_transfer",
+		}).AddRow("summary-123", "MF-2025-10-21-summary-123", "DVEST", "MF_EARN_DIVEST", beforeDate.AddDate(0, 0, -1), "1000", "0"))
+
+	summaries, err := suite.moneyFlowRepo.GetPendingSummariesForDisbursement(context.Background(), "MF_EARN_DIVEST", beforeDate, claimedBefore, 10)
+
+	assert.NoError(suite.t, err)
+	assert.Len(suite.t, summaries, 1)
+	assert.Equal(suite.t, "summary-123", summaries[0].ID)
+	assert.NoError(suite.t, suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *moneyFlowTestSuite) TestClaimSummaryForDisbursement_Success() {
+	claimedBefore := time.Now().Add(-15 * time.Minute)
+
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(queryClaimSummaryForDisbursement)).
+		WithArgs("summary-123", claimedBefore).
+		WillReturnRows(sqlmock.NewRows([]string{"money_flow_status", "disbursement_attempts"}).AddRow("PENDING", 1))
+
+	fromStatus, attempts, err := suite.moneyFlowRepo.ClaimSummaryForDisbursement(context.Background(), "summary-123", claimedBefore)
+
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, "PENDING", fromStatus)
+	assert.Equal(suite.t, 1, attempts)
+	assert.NoError(suite.t, suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *moneyFlowTestSuite) TestClaimSummaryForDisbursement_AlreadyClaimed() {
+	claimedBefore := time.Now().Add(-15 * time.Minute)
+
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(queryClaimSummaryForDisbursement)).
+		WithArgs("summary-123", claimedBefore).
+		WillReturnError(sql.ErrNoRows)
+
+	_, _, err := suite.moneyFlowRepo.ClaimSummaryForDisbursement(context.Background(), "summary-123", claimedBefore)
+
+	assert.ErrorIs(suite.t, err, common.ErrNoRowsAffected)
+	assert.NoError(suite.t, suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *moneyFlowTestSuite) TestIsSummaryDisbursing_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(queryIsSummaryDisbursing)).
+		WithArgs("MF-2025-10-21-summary-123").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	disbursing, err := suite.moneyFlowRepo.IsSummaryDisbursing(context.Background(), "MF-2025-10-21-summary-123")
+
+	assert.NoError(suite.t, err)
+	assert.True(suite.t, disbursing)
+	assert.NoError(suite.t, suite.mockSql.ExpectationsWereMet())
+}
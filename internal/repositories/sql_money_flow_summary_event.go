@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/constants"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+)
+
+var (
+	queryGetSummaryStatusForUpdate = `
+		SELECT money_flow_status
+		FROM money_flow_summaries
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	queryCreateSummaryEvent = `
+		INSERT INTO money_flow_summary_events (
+			summary_id, from_status, to_status, source, actor,
+			papa_transaction_id, papa_event_payload, event_time
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	queryGetSummaryEvents = `
+		SELECT id, summary_id, from_status, to_status, source, actor,
+			papa_transaction_id, papa_event_payload, event_time, created_at
+		FROM money_flow_summary_events
+		WHERE summary_id = $1
+		ORDER BY event_time ASC, id ASC
+	`
+
+	queryGetLastPapaEventTime = `
+		SELECT MAX(event_time)
+		FROM money_flow_summary_events
+		WHERE summary_id = $1 AND source = $2
+	`
+)
+
+// GetSummaryStatusForUpdate locks the summary row until the transaction ends,
+// so concurrent transitions of the same summary are recorded one after another
+func (mfr *moneyFlowRepository) GetSummaryStatusForUpdate(ctx context.Context, summaryID string) (string, error) {
+	var err error
+
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := mfr.r.extractTxWrite(ctx)
+
+	var status string
+	err = db.QueryRowContext(ctx, queryGetSummaryStatusForUpdate, summaryID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", common.ErrDataNotFound
+		}
+		return "", err
+	}
+
+	return status, nil
+}
+
+func (mfr *moneyFlowRepository) CreateSummaryEvent(ctx context.Context, in models.CreateMoneyFlowSummaryEvent) error {
+	var err error
+
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := mfr.r.extractTxWrite(ctx)
+
+	var payload any
+	if len(in.PapaEventPayload) > 0 {
+		payload = []byte(in.PapaEventPayload)
+	}
+
+	_, err = db.ExecContext(ctx, queryCreateSummaryEvent,
+		in.SummaryID,
+		in.FromStatus,
+		in.ToStatus,
+		in.Source,
+		in.Actor,
+		in.PapaTransactionID,
+		payload,
+		in.EventTime,
+	)
+
+	return err
+}
+
+func (mfr *moneyFlowRepository) GetSummaryEvents(ctx context.Context, summaryID string) ([]models.MoneyFlowSummaryEvent, error) {
+	var err error
+
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := mfr.r.extractTxRead(ctx)
+
+	rows, err := db.QueryContext(ctx, queryGetSummaryEvents, summaryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []models.MoneyFlowSummaryEvent{}
+	for rows.Next() {
+		var (
+			event   models.MoneyFlowSummaryEvent
+			payload []byte
+		)
+
+		err = rows.Scan(
+			&event.ID,
+			&event.SummaryID,
+			&event.FromStatus,
+			&event.ToStatus,
+			&event.Source,
+			&event.Actor,
+			&event.PapaTransactionID,
+			&payload,
+			&event.EventTime,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		event.PapaEventPayload = payload
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetLastPapaEventTime returns event time of the latest transition coming from PAPA stream, nil if there is none
+func (mfr *moneyFlowRepository) GetLastPapaEventTime(ctx context.Context, summaryID string) (*time.Time, error) {
+	var err error
+
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := mfr.r.extractTxWrite(ctx)
+
+	var lastEventTime sql.NullTime
+	err = db.QueryRowContext(ctx, queryGetLastPapaEventTime, summaryID, constants.MoneyFlowEventSourcePapaStream).Scan(&lastEventTime)
+	if err != nil {
+		return nil, err
+	}
+
+	if !lastEventTime.Valid {
+		return nil, nil
+	}
+
+	return &lastEventTime.Time, nil
+}
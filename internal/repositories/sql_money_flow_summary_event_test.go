@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/constants"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+)
+
+func (suite *moneyFlowTestSuite) TestGetSummaryStatusForUpdate_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(queryGetSummaryStatusForUpdate)).
+		WithArgs("summary-123").
+		WillReturnRows(sqlmock.NewRows([]string{"money_flow_status"}).AddRow("IN_PROGRESS"))
+
+	status, err := suite.moneyFlowRepo.GetSummaryStatusForUpdate(context.Background(), "summary-123")
+
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, "IN_PROGRESS", status)
+	assert.NoError(suite.t, suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *moneyFlowTestSuite) TestGetSummaryStatusForUpdate_NotFound() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(queryGetSummaryStatusForUpdate)).
+		WithArgs("summary-123").
+		WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.moneyFlowRepo.GetSummaryStatusForUpdate(context.Background(), "summary-123")
+
+	assert.ErrorIs(suite.t, err, common.ErrDataNotFound)
+	assert.NoError(suite.t, suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *moneyFlowTestSuite) TestCreateSummaryEvent_Success() {
+	eventTime := time.Now()
+	payload := json.RawMessage(`{"id":"PAPA-123","status":"SUCCESSFUL"}`)
+
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(queryCreateSummaryEvent)).
+		WithArgs("summary-123", "IN_PROGRESS", "SUCCESSFUL", constants.MoneyFlowEventSourcePapaStream, "",
+			"PAPA-123", []byte(payload), eventTime).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := suite.moneyFlowRepo.CreateSummaryEvent(context.Background(), models.CreateMoneyFlowSummaryEvent{
+		SummaryID:         "summary-123",
+		FromStatus:        "IN_PROGRESS",
+		ToStatus:          "SUCCESSFUL",
+		Source:            constants.MoneyFlowEventSourcePapaStream,
+		PapaTransactionID: "PAPA-123",
+		PapaEventPayload:  payload,
+		EventTime:         eventTime,
+	})
+
+	assert.NoError(suite.t, err)
+	assert.NoError(suite.t, suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *moneyFlowTestSuite) TestGetSummaryEvents_Success() {
+	now := time.Now()
+
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(queryGetSummaryEvents)).
+		WithArgs("summary-123").
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "summary_id", "from_status", "to_status", "source", "actor",
+			"papa_transaction_id", "papa_event_payload", "event_time", "created_at",
+		}).
+			AddRow(1, "summary-123", "", "PENDING", constants.MoneyFlowEventSourceSystem, "", "", nil, now, now).
+			AddRow(2, "summary-123", "PENDING", "IN_PROGRESS", constants.MoneyFlowEventSourceAPI, "finance.ops", "PAPA-123", nil, now, now))
+
+	events, err := suite.moneyFlowRepo.GetSummaryEvents(context.Background(), "summary-123")
+
+	assert.NoError(suite.t, err)
+	assert.Len(suite.t, events, 2)
+	assert.Equal(suite.t, "IN_PROGRESS", events[1].ToStatus)
+	assert.Equal(suite.t, "finance.ops", events[1].Actor)
+	assert.NoError(suite.t, suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *moneyFlowTestSuite) TestGetLastPapaEventTime() {
+	eventTime := time.Now()
+
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(queryGetLastPapaEventTime)).
+		WithArgs("summary-123", constants.MoneyFlowEventSourcePapaStream).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(eventTime))
+
+	got, err := suite.moneyFlowRepo.GetLastPapaEventTime(context.Background(), "summary-123")
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, eventTime, *got)
+
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(queryGetLastPapaEventTime)).
+		WithArgs("summary-456", constants.MoneyFlowEventSourcePapaStream).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(nil))
+
+	got, err = suite.moneyFlowRepo.GetLastPapaEventTime(context.Background(), "summary-456")
+	assert.NoError(suite.t, err)
+	assert.Nil(suite.t, got)
+
+	assert.NoError(suite.t, suite.mockSql.ExpectationsWereMet())
+}
@@ -0,0 +1,299 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+)
+
+type ReconExceptionRepository interface {
+	BulkCreate(ctx context.Context, in []models.ReconException) (err error)
+	DeleteUntouchedByReconHistoryID(ctx context.Context, reconHistoryID uint64) (err error)
+	GetByID(ctx context.Context, id uint64) (result *models.ReconException, err error)
+	GetByIDForUpdate(ctx context.Context, id uint64) (result *models.ReconException, err error)
+	GetByReconHistoryID(ctx context.Context, reconHistoryID uint64) (result []models.ReconException, err error)
+	GetList(ctx context.Context, opts models.ReconExceptionFilterOptions) (result []models.ReconException, err error)
+	CountAll(ctx context.Context, opts models.ReconExceptionFilterOptions) (total int, err error)
+	Update(ctx context.Context, in *models.ReconException) (err error)
+	CreateActivity(ctx context.Context, in *models.ReconExceptionActivity) (err error)
+	ListActivities(ctx context.Context, reconExceptionID uint64) (result []models.ReconExceptionActivity, err error)
+}
+
+type reconExceptionRepo sqlRepo
+
+var _ ReconExceptionRepository = (*reconExceptionRepo)(nil)
+
+func (r *reconExceptionRepo) BulkCreate(ctx context.Context, in []models.ReconException) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	if len(in) == 0 {
+		return nil
+	}
+
+	db := r.r.extractTxWrite(ctx)
+
+	const batchSize int = 500 // pg can handle max 65535 params
+	for _, chunk := range common.ChunkBy(in, batchSize) {
+		valueStrings := make([]string, 0, len(chunk))
+		valueArgs := make([]interface{}, 0, len(chunk)*9)
+		for _, e := range chunk {
+			valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, ?, ?, NOW(), NOW())")
+			valueArgs = append(valueArgs,
+				e.ReconHistoryID,
+				e.Identifier,
+				e.RefNumber,
+				e.Amount,
+				e.PaymentDate,
+				e.OrderType,
+				e.TransactionType,
+				e.ReconStatus,
+				e.State,
+			)
+		}
+
+		query := common.ReplaceSQL(fmt.Sprintf(queryReconExceptionBulkCreate, strings.Join(valueStrings, ",")), "?")
+		if _, err = db.ExecContext(ctx, query, valueArgs...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteUntouchedByReconHistoryID delete exceptions of the recon history which are not worked by operator yet,
+// exception which is assigned, closed or has activity is kept along with its activities
+func (r *reconExceptionRepo) DeleteUntouchedByReconHistoryID(ctx context.Context, reconHistoryID uint64) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	_, err = db.ExecContext(ctx, queryReconExceptionDeleteUntouchedByReconHistoryID, reconHistoryID)
+
+	return err
+}
+
+func (r *reconExceptionRepo) GetByID(ctx context.Context, id uint64) (result *models.ReconException, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	result = &models.ReconException{}
+	err = scanReconException(db.QueryRowContext(ctx, queryReconExceptionGetByID, id), result)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetByIDForUpdate locks the exception row until the transaction in ctx is finished
+func (r *reconExceptionRepo) GetByIDForUpdate(ctx context.Context, id uint64) (result *models.ReconException, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	result = &models.ReconException{}
+	err = scanReconException(db.QueryRowContext(ctx, queryReconExceptionGetByIDForUpdate, id), result)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *reconExceptionRepo) GetByReconHistoryID(ctx context.Context, reconHistoryID uint64) (result []models.ReconException, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	rows, err := db.QueryContext(ctx, queryReconExceptionGetByReconHistoryID, reconHistoryID)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var e models.ReconException
+		if err = scanReconException(rows, &e); err != nil {
+			return result, err
+		}
+		result = append(result, e)
+	}
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (r *reconExceptionRepo) GetList(ctx context.Context, opts models.ReconExceptionFilterOptions) (result []models.ReconException, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	query, args, err := buildListReconExceptionQuery(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var e models.ReconException
+		if err = scanReconException(rows, &e); err != nil {
+			return result, err
+		}
+		result = append(result, e)
+	}
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (r *reconExceptionRepo) CountAll(ctx context.Context, opts models.ReconExceptionFilterOptions) (total int, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	query, args, err := buildCountReconExceptionQuery(opts)
+	if err != nil {
+		return total, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	err = db.QueryRowContext(ctx, query, args...).Scan(&total)
+
+	return
+}
+
+func (r *reconExceptionRepo) Update(ctx context.Context, in *models.ReconException) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	result, err := db.ExecContext(ctx, queryReconExceptionUpdate,
+		in.ID,
+		in.State,
+		in.Assignee,
+		in.ResolutionNote,
+		in.AdjustmentTransactionID,
+		in.ResolvedBy,
+		in.ResolvedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return common.ErrNoRowsAffected
+	}
+
+	return nil
+}
+
+func (r *reconExceptionRepo) CreateActivity(ctx context.Context, in *models.ReconExceptionActivity) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	return db.QueryRowContext(ctx, queryReconExceptionActivityCreate,
+		in.ReconExceptionID,
+		in.Action,
+		in.Actor,
+		in.FromState,
+		in.ToState,
+		in.Comment,
+	).Scan(&in.ID, &in.CreatedAt)
+}
+
+func (r *reconExceptionRepo) ListActivities(ctx context.Context, reconExceptionID uint64) (result []models.ReconExceptionActivity, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	rows, err := db.QueryContext(ctx, queryReconExceptionActivityList, reconExceptionID)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var a models.ReconExceptionActivity
+		err = rows.Scan(
+			&a.ID,
+			&a.ReconExceptionID,
+			&a.Action,
+			&a.Actor,
+			&a.FromState,
+			&a.ToState,
+			&a.Comment,
+			&a.CreatedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, a)
+	}
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+type reconExceptionScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReconException(row reconExceptionScanner, e *models.ReconException) error {
+	return row.Scan(
+		&e.ID,
+		&e.ReconHistoryID,
+		&e.Identifier,
+		&e.RefNumber,
+		&e.Amount,
+		&e.PaymentDate,
+		&e.OrderType,
+		&e.TransactionType,
+		&e.ReconStatus,
+		&e.State,
+		&e.Assignee,
+		&e.ResolutionNote,
+		&e.AdjustmentTransactionID,
+		&e.ResolvedBy,
+		&e.ResolvedAt,
+		&e.CreatedAt,
+		&e.UpdatedAt,
+	)
+}
@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	sq "github.com/Masterminds/squirrel"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+)
+
+var (
+	queryReconExceptionBulkCreate = `
+		INSERT INTO recon_exception(
+			"reconHistoryId", "identifier", "refNumber", "amount", "paymentDate",
+			"orderType", "transactionType", "reconStatus", "state", "createdAt", "updatedAt"
+		) VALUES %s`
+
+	// exception which is assigned, moved from OPEN or has any activity is kept as it is worked by operator
+	queryReconExceptionDeleteUntouchedByReconHistoryID = `DELETE FROM recon_exception e
+		WHERE e."reconHistoryId" = $1
+		  AND e."state" = 'OPEN'
+		  AND COALESCE(e."assignee", '') = ''
+		  AND NOT EXISTS (
+		    SELECT 1 FROM recon_exception_activity a WHERE a."reconExceptionId" = e."id"
+		  )`
+
+	queryReconExceptionSelect = `SELECT
+		  "id",
+		  "reconHistoryId",
+		  "identifier",
+		  COALESCE("refNumber", '') as "refNumber",
+		  "amount",
+		  COALESCE("paymentDate", '') as "paymentDate",
+		  "orderType",
+		  "transactionType",
+		  "reconStatus",
+		  "state",
+		  COALESCE("assignee", '') as "assignee",
+		  COALESCE("resolutionNote", '') as "resolutionNote",
+		  COALESCE("adjustmentTransactionId", '') as "adjustmentTransactionId",
+		  COALESCE("resolvedBy", '') as "resolvedBy",
+		  "resolvedAt",
+		  "createdAt",
+		  "updatedAt"
+		FROM "recon_exception"`
+
+	queryReconExceptionGetByID = queryReconExceptionSelect + ` WHERE id = $1;`
+
+	queryReconExceptionGetByIDForUpdate = queryReconExceptionSelect + ` WHERE id = $1 FOR UPDATE;`
+
+	queryReconExceptionGetByReconHistoryID = queryReconExceptionSelect + ` WHERE "reconHistoryId" = $1 ORDER BY "id" ASC;`
+
+	queryReconExceptionUpdate = `UPDATE recon_exception
+		SET
+		  "state" = $2,
+		  "assignee" = $3,
+		  "resolutionNote" = $4,
+		  "adjustmentTransactionId" = $5,
+		  "resolvedBy" = $6,
+		  "resolvedAt" = $7,
+		  "updatedAt" = NOW()
+		WHERE
+		  id = $1`
+
+	queryReconExceptionActivityCreate = `
+		INSERT INTO recon_exception_activity(
+			"reconExceptionId", "action", "actor", "fromState", "toState", "comment", "createdAt"
+		)
+		VALUES(
+			$1, $2, $3, $4, $5, $6, NOW()
+		)
+		RETURNING
+			"id", "createdAt";
+	`
+
+	queryReconExceptionActivityList = `SELECT
+		  "id",
+		  "reconExceptionId",
+		  "action",
+		  "actor",
+		  COALESCE("fromState", '') as "fromState",
+		  COALESCE("toState", '') as "toState",
+		  COALESCE("comment", '') as "comment",
+		  "createdAt"
+		FROM "recon_exception_activity"
+		WHERE "reconExceptionId" = $1
+		ORDER BY "id" ASC;`
+)
+
+func buildFilteredReconExceptionQuery(cols []string, opts models.ReconExceptionFilterOptions) sq.SelectBuilder {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	query := psql.Select(cols...).From("recon_exception")
+
+	if opts.ReconHistoryID != 0 {
+		query = query.Where(sq.Eq{`"reconHistoryId"`: opts.ReconHistoryID})
+	}
+
+	if opts.State != "" {
+		query = query.Where(sq.Eq{`"state"`: opts.State})
+	}
+
+	if opts.Assignee != "" {
+		query = query.Where(sq.Eq{`"assignee"`: opts.Assignee})
+	}
+
+	return query
+}
+
+func buildListReconExceptionQuery(opts models.ReconExceptionFilterOptions) (sql string, args []interface{}, err error) {
+	columns := []string{
+		`"id"`,
+		`"reconHistoryId"`,
+		`"identifier"`,
+		`COALESCE("refNumber", '') as "refNumber"`,
+		`"amount"`,
+		`COALESCE("paymentDate", '') as "paymentDate"`,
+		`"orderType"`,
+		`"transactionType"`,
+		`"reconStatus"`,
+		`"state"`,
+		`COALESCE("assignee", '') as "assignee"`,
+		`COALESCE("resolutionNote", '') as "resolutionNote"`,
+		`COALESCE("adjustmentTransactionId", '') as "adjustmentTransactionId"`,
+		`COALESCE("resolvedBy", '') as "resolvedBy"`,
+		`"resolvedAt"`,
+		`"createdAt"`,
+		`"updatedAt"`,
+	}
+
+	query := buildFilteredReconExceptionQuery(columns, opts)
+
+	if opts.AfterID != 0 {
+		query = query.Where(sq.Lt{`"id"`: opts.AfterID})
+	}
+
+	if opts.BeforeID != 0 {
+		query = query.Where(sq.Gt{`"id"`: opts.BeforeID})
+	}
+
+	if opts.AscendingOrder {
+		query = query.OrderBy(`"id" ASC`)
+	} else {
+		query = query.OrderBy(`"id" DESC`)
+	}
+
+	query = query.Limit(uint64(opts.Limit))
+
+	return query.ToSql()
+}
+
+func buildCountReconExceptionQuery(opts models.ReconExceptionFilterOptions) (sql string, args []interface{}, err error) {
+	columns := []string{
+		`count(1)`,
+	}
+
+	query := buildFilteredReconExceptionQuery(columns, opts)
+
+	return query.ToSql()
+}
@@ -0,0 +1,442 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting/mock"
+	mockFlag "bitbucket.org/Amartha/go-fp-transaction/internal/common/flag/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestReconExceptionRepositoryTestSuite(t *testing.T) {
+	t.Helper()
+	suite.Run(t, new(reconExceptionRepoTestSuite))
+}
+
+type reconExceptionRepoTestSuite struct {
+	suite.Suite
+	t       *testing.T
+	writeDB *sql.DB
+	mock    sqlmock.Sqlmock
+	repo    ReconExceptionRepository
+}
+
+var reconExceptionColumns = []string{
+	"id", "reconHistoryId", "identifier", "refNumber", "amount", "paymentDate", "orderType", "transactionType",
+	"reconStatus", "state", "assignee", "resolutionNote", "adjustmentTransactionId", "resolvedBy", "resolvedAt",
+	"createdAt", "updatedAt",
+}
+
+func (suite *reconExceptionRepoTestSuite) SetupTest() {
+	var err error
+	var cfg config.Config
+
+	suite.writeDB, suite.mock, err = sqlmock.New()
+	require.NoError(suite.T(), err)
+
+	suite.t = suite.T()
+	mockCtrl := gomock.NewController(suite.t)
+
+	suite.repo = NewSQLRepository(suite.writeDB, suite.writeDB, cfg, mockFlag.NewMockClient(mockCtrl), mock.NewMockClient(mockCtrl)).
+		GetReconExceptionRepository()
+}
+
+func (suite *reconExceptionRepoTestSuite) TearDownTest() {
+	suite.writeDB.Close()
+}
+
+func (suite *reconExceptionRepoTestSuite) TestRepository_BulkCreate() {
+	in := []models.ReconException{
+		{ReconHistoryID: 1, Identifier: "a", Amount: decimal.NewFromInt(100), State: models.ReconExceptionStateOpen},
+		{ReconHistoryID: 1, Identifier: "b", Amount: decimal.NewFromInt(200), State: models.ReconExceptionStateOpen},
+	}
+
+	testCases := []struct {
+		name    string
+		in      []models.ReconException
+		wantErr bool
+		doMock  func()
+	}{
+		{
+			name: "happy path",
+			in:   in,
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(`INSERT INTO recon_exception`)).
+					WithArgs(
+						uint64(1), "a", "", in[0].Amount, "", "", "", "", models.ReconExceptionStateOpen,
+						uint64(1), "b", "", in[1].Amount, "", "", "", "", models.ReconExceptionStateOpen,
+					).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name:   "empty input",
+			doMock: func() {},
+		},
+		{
+			name: "error db",
+			in:   in,
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(`INSERT INTO recon_exception`)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.doMock()
+
+			err := suite.repo.BulkCreate(context.Background(), tc.in)
+			assert.Equal(t, tc.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *reconExceptionRepoTestSuite) TestRepository_GetByID() {
+	now := time.Now()
+
+	testCases := []struct {
+		name    string
+		wantErr error
+		doMock  func()
+	}{
+		{
+			name: "happy path",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryReconExceptionGetByID)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows(reconExceptionColumns).AddRow(
+						1, 1, "a", "", "100", "01-Jan-2023", "TOPUP", "TOPUP",
+						"Not Exists in DB, Exists in CSV", "OPEN", "", "", "", "", nil,
+						now, now,
+					))
+			},
+		},
+		{
+			name: "not found",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryReconExceptionGetByID)).
+					WithArgs(uint64(1)).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: common.ErrDataNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.doMock()
+
+			got, err := suite.repo.GetByID(context.Background(), 1)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.ReconExceptionStateOpen, got.State)
+				assert.Nil(t, got.ResolvedAt)
+			}
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *reconExceptionRepoTestSuite) TestRepository_DeleteUntouchedByReconHistoryID() {
+	testCases := []struct {
+		name    string
+		wantErr bool
+		doMock  func()
+	}{
+		{
+			name: "happy path",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryReconExceptionDeleteUntouchedByReconHistoryID)).
+					WithArgs(uint64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name: "error db",
+			doMock: func() {
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryReconExceptionDeleteUntouchedByReconHistoryID)).
+					WithArgs(uint64(1)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.doMock()
+
+			err := suite.repo.DeleteUntouchedByReconHistoryID(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *reconExceptionRepoTestSuite) TestRepository_GetByIDForUpdate() {
+	now := time.Now()
+
+	testCases := []struct {
+		name    string
+		wantErr error
+		doMock  func()
+	}{
+		{
+			name: "happy path",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryReconExceptionGetByIDForUpdate)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows(reconExceptionColumns).AddRow(
+						1, 1, "a", "", "100", "01-Jan-2023", "TOPUP", "TOPUP",
+						"Not Exists in DB, Exists in CSV", "INVESTIGATING", "finance.ops", "", "", "", nil,
+						now, now,
+					))
+			},
+		},
+		{
+			name: "not found",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryReconExceptionGetByIDForUpdate)).
+					WithArgs(uint64(1)).
+					WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: common.ErrDataNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.doMock()
+
+			got, err := suite.repo.GetByIDForUpdate(context.Background(), 1)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.ReconExceptionStateInvestigating, got.State)
+				assert.Equal(t, "finance.ops", got.Assignee)
+			}
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *reconExceptionRepoTestSuite) TestRepository_GetByReconHistoryID() {
+	now := time.Now()
+
+	testCases := []struct {
+		name    string
+		wantLen int
+		wantErr bool
+		doMock  func()
+	}{
+		{
+			name:    "happy path",
+			wantLen: 2,
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryReconExceptionGetByReconHistoryID)).
+					WithArgs(uint64(1)).
+					WillReturnRows(sqlmock.NewRows(reconExceptionColumns).
+						AddRow(
+							1, 1, "a", "", "100", "01-Jan-2023", "TOPUP", "TOPUP",
+							"Not Exists in DB, Exists in CSV", "INVESTIGATING", "finance.ops", "", "", "", nil,
+							now, now,
+						).
+						AddRow(
+							2, 1, "b", "", "200", "01-Jan-2023", "TOPUP", "TOPUP",
+							"Not Exists in DB, Exists in CSV", "RESOLVED", "", "matched manually", "", "finance.ops", now,
+							now, now,
+						))
+			},
+		},
+		{
+			name: "error db",
+			doMock: func() {
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(queryReconExceptionGetByReconHistoryID)).
+					WithArgs(uint64(1)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.doMock()
+
+			got, err := suite.repo.GetByReconHistoryID(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Len(t, got, tc.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *reconExceptionRepoTestSuite) TestRepository_GetList() {
+	opts := models.ReconExceptionFilterOptions{ReconHistoryID: 1, State: models.ReconExceptionStateOpen, Limit: 11}
+
+	testCases := []struct {
+		name    string
+		wantLen int
+		wantErr bool
+		doMock  func()
+	}{
+		{
+			name:    "success get list",
+			wantLen: 1,
+			doMock: func() {
+				query, _, _ := buildListReconExceptionQuery(opts)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(uint64(1), models.ReconExceptionStateOpen).
+					WillReturnRows(sqlmock.NewRows(reconExceptionColumns).AddRow(
+						1, 1, "a", "", "100", "01-Jan-2023", "TOPUP", "TOPUP",
+						"Not Exists in DB, Exists in CSV", "OPEN", "", "", "", "", nil,
+						time.Now(), time.Now(),
+					))
+			},
+		},
+		{
+			name: "error db",
+			doMock: func() {
+				query, _, _ := buildListReconExceptionQuery(opts)
+				suite.mock.
+					ExpectQuery(regexp.QuoteMeta(query)).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.doMock()
+
+			got, err := suite.repo.GetList(context.Background(), opts)
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Len(t, got, tc.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *reconExceptionRepoTestSuite) TestRepository_Update() {
+	resolvedAt := time.Now()
+	in := &models.ReconException{
+		ID:             1,
+		State:          models.ReconExceptionStateResolved,
+		ResolutionNote: "done",
+		ResolvedBy:     "finance.ops",
+		ResolvedAt:     &resolvedAt,
+	}
+
+	testCases := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      bool
+	}{
+		{name: "happy path", rowsAffected: 1},
+		{name: "no rows affected", rowsAffected: 0, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			suite.mock.
+				ExpectExec(regexp.QuoteMeta(queryReconExceptionUpdate)).
+				WithArgs(in.ID, in.State, in.Assignee, in.ResolutionNote, in.AdjustmentTransactionID, in.ResolvedBy, in.ResolvedAt).
+				WillReturnResult(sqlmock.NewResult(0, tc.rowsAffected))
+
+			err := suite.repo.Update(context.Background(), in)
+			assert.Equal(t, tc.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *reconExceptionRepoTestSuite) TestRepository_CreateActivity() {
+	in := &models.ReconExceptionActivity{
+		ReconExceptionID: 1,
+		Action:           models.ReconExceptionActionComment,
+		Actor:            "finance.ops",
+		FromState:        models.ReconExceptionStateOpen,
+		ToState:          models.ReconExceptionStateOpen,
+		Comment:          "checking",
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryReconExceptionActivityCreate)).
+		WithArgs(in.ReconExceptionID, in.Action, in.Actor, in.FromState, in.ToState, in.Comment).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "createdAt"}).AddRow(10, time.Now()))
+
+	err := suite.repo.CreateActivity(context.Background(), in)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, uint64(10), in.ID)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *reconExceptionRepoTestSuite) TestRepository_ListActivities() {
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryReconExceptionActivityList)).
+		WithArgs(uint64(1)).
+		WillReturnRows(sqlmock.
+			NewRows([]string{"id", "reconExceptionId", "action", "actor", "fromState", "toState", "comment", "createdAt"}).
+			AddRow(1, 1, "ASSIGN", "finance.lead", "OPEN", "INVESTIGATING", "assigned to finance.ops", time.Now()).
+			AddRow(2, 1, "COMMENT", "finance.ops", "INVESTIGATING", "INVESTIGATING", "checking", time.Now()))
+
+	got, err := suite.repo.ListActivities(context.Background(), 1)
+	assert.NoError(suite.t, err)
+	assert.Len(suite.t, got, 2)
+	assert.Equal(suite.t, models.ReconExceptionActionAssign, got[0].Action)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
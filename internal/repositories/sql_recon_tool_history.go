@@ -26,8 +26,8 @@ type reconToolHistoryRepo sqlRepo
 var _ ReconToolHistoryRepository = (*reconToolHistoryRepo)(nil)
 
 func (r *reconToolHistoryRepo) Create(ctx context.Context, in *models.CreateReconToolHistoryIn) (created *models.ReconToolHistory, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -57,8 +57,8 @@ func (r *reconToolHistoryRepo) Create(ctx context.Context, in *models.CreateReco
 }
 
 func (r *reconToolHistoryRepo) GetList(ctx context.Context, opts models.ReconToolHistoryFilterOptions) (result []models.ReconToolHistory, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -83,6 +83,7 @@ func (r *reconToolHistoryRepo) GetList(ctx context.Context, opts models.ReconToo
 			&rth.ResultFilePath,
 			&rth.UploadedFilePath,
 			&rth.Status,
+			&rth.Summary,
 			&rth.CreatedAt,
 			&rth.UpdatedAt,
 		)
@@ -99,8 +100,8 @@ func (r *reconToolHistoryRepo) GetList(ctx context.Context, opts models.ReconToo
 }
 
 func (r *reconToolHistoryRepo) CountAll(ctx context.Context, opts models.ReconToolHistoryFilterOptions) (total int, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -117,8 +118,8 @@ func (r *reconToolHistoryRepo) CountAll(ctx context.Context, opts models.ReconTo
 }
 
 func (r *reconToolHistoryRepo) GetById(ctx context.Context, id uint64) (result *models.ReconToolHistory, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -131,6 +132,7 @@ func (r *reconToolHistoryRepo) GetById(ctx context.Context, id uint64) (result *
 		&result.ResultFilePath,
 		&result.UploadedFilePath,
 		&result.Status,
+		&result.Summary,
 		&result.CreatedAt,
 		&result.UpdatedAt,
 	)
@@ -146,8 +148,8 @@ func (r *reconToolHistoryRepo) GetById(ctx context.Context, id uint64) (result *
 
 func (r *reconToolHistoryRepo) DeleteByID(ctx context.Context, id string) error {
 	var err error
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -170,8 +172,8 @@ func (r *reconToolHistoryRepo) DeleteByID(ctx context.Context, id string) error
 }
 
 func (r *reconToolHistoryRepo) Update(ctx context.Context, id uint64, in *models.ReconToolHistory) (updated *models.ReconToolHistory, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -183,6 +185,7 @@ func (r *reconToolHistoryRepo) Update(ctx context.Context, id uint64, in *models
 		in.UploadedFilePath,
 		in.ResultFilePath,
 		in.Status,
+		in.Summary,
 	}
 	result, err := db.ExecContext(ctx, queryReconToolHistoryUpdate, args...)
 	if err != nil {
@@ -28,6 +28,7 @@ var (
 		  COALESCE("resultFilePath", '') as "resultFilePath",
 		  COALESCE("uploadedFilePath", '') as "uploadedFilePath",
 		  COALESCE("status", '') as "status",
+		  COALESCE("summary", '{}') as "summary",
 		  "createdAt",
 		  "updatedAt"
 		FROM "recon_tool_history"
@@ -41,6 +42,7 @@ var (
 		  "uploadedFilePath" = $5,
 		  "resultFilePath" = $6,
 		  "status" = $7,
+		  "summary" = $8,
 		  "updatedAt" = NOW()
 		WHERE
 		  id = $1`
@@ -79,6 +81,7 @@ func buildListReconToolHistoryQuery(opts models.ReconToolHistoryFilterOptions) (
 		`COALESCE("resultFilePath", '') as "resultFilePath"`,
 		`COALESCE("uploadedFilePath", '') as "uploadedFilePath"`,
 		`COALESCE("status", '') as "status"`,
+		`COALESCE("summary", '{}') as "summary"`,
 		`"createdAt"`,
 		`"updatedAt"`,
 	}
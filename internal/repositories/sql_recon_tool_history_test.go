@@ -205,10 +205,11 @@ func (suite *reconToolHistoryRepoTestSuite) TestRepository_GetList() {
 								`"resultFilePath"`,
 								`"uploadedFilePath"`,
 								`"status"`,
+								`"summary"`,
 								`"createdAt"`,
 								`"updatedAt"`,
 							}).
-							AddRow(1, "TOPUP", "TOPUP", time.Now(), "my_file1.txt", "my_file2.txt", "SUCCESS", time.Now(), time.Now()),
+							AddRow(1, "TOPUP", "TOPUP", time.Now(), "my_file1.txt", "my_file2.txt", "SUCCESS", []byte(`{"Match":1}`), time.Now(), time.Now()),
 					)
 			},
 			wantErr: false,
@@ -332,10 +333,11 @@ func (suite *reconToolHistoryRepoTestSuite) TestRepository_GetById() {
 								`"resultFilePath"`,
 								`"uploadedFilePath"`,
 								`"status"`,
+								`"summary"`,
 								`"createdAt"`,
 								`"updatedAt"`,
 							}).
-							AddRow(1, "TOPUP", "TOPUP", time.Now(), "my_file1.txt", "my_file2.txt", "SUCCESS", time.Now(), time.Now()),
+							AddRow(1, "TOPUP", "TOPUP", time.Now(), "my_file1.txt", "my_file2.txt", "SUCCESS", []byte(`{"Match":1}`), time.Now(), time.Now()),
 					)
 			},
 			wantErr: false,
@@ -415,6 +417,7 @@ func (suite *reconToolHistoryRepoTestSuite) TestRepository_Update() {
 						args.in.UploadedFilePath,
 						args.in.ResultFilePath,
 						args.in.Status,
+						args.in.Summary,
 					).
 					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
@@ -445,6 +448,7 @@ func (suite *reconToolHistoryRepoTestSuite) TestRepository_Update() {
 						args.in.UploadedFilePath,
 						args.in.ResultFilePath,
 						args.in.Status,
+						args.in.Summary,
 					).
 					WillReturnError(assert.AnError)
 			},
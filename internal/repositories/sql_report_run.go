@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+)
+
+type ReportRunRepository interface {
+	// Start record the run of report period, started is false when the period has been generated or is being generated
+	Start(ctx context.Context, in *models.ReportRun, staleAfter time.Duration) (started bool, err error)
+	Finish(ctx context.Context, in *models.ReportRun) (err error)
+}
+
+type reportRunRepo sqlRepo
+
+var _ ReportRunRepository = (*reportRunRepo)(nil)
+
+func (r *reportRunRepo) Start(ctx context.Context, in *models.ReportRun, staleAfter time.Duration) (started bool, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	err = db.QueryRowContext(ctx, queryReportRunStart,
+		in.ReportName,
+		in.PeriodStart,
+		in.PeriodEnd,
+		staleAfter.Seconds(),
+	).Scan(&in.ID, &in.Status, &in.StartedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *reportRunRepo) Finish(ctx context.Context, in *models.ReportRun) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	_, err = db.ExecContext(ctx, queryReportRunFinish,
+		in.ID,
+		in.Status,
+		in.RowCount,
+		in.FilePath,
+		in.ErrorMessage,
+	)
+	return err
+}
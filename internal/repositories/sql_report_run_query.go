@@ -0,0 +1,25 @@
+package repositories
+
+var (
+	// failed run is retried, running run is taken over when it has not finished for a while, e.g. the worker was killed
+	queryReportRunStart = `
+		INSERT INTO scheduled_report_runs(
+			report_name, period_start, period_end, status, started_at
+		)
+		VALUES(
+			$1, $2, $3, 'RUNNING', NOW()
+		)
+		ON CONFLICT (report_name, period_start) DO UPDATE
+		SET period_end = EXCLUDED.period_end, status = 'RUNNING', row_count = 0, file_path = '', error_message = '',
+		    started_at = NOW(), finished_at = NULL
+		WHERE scheduled_report_runs.status = 'FAILED'
+		   OR (scheduled_report_runs.status = 'RUNNING' AND scheduled_report_runs.started_at < NOW() - make_interval(secs => $4))
+		RETURNING
+			id, status, started_at;
+	`
+
+	queryReportRunFinish = `
+		UPDATE scheduled_report_runs
+		SET status = $2, row_count = $3, file_path = $4, error_message = $5, finished_at = NOW()
+		WHERE id = $1;`
+)
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting/mock"
+	mockFlag "bitbucket.org/Amartha/go-fp-transaction/internal/common/flag/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestReportRunRepositoryTestSuite(t *testing.T) {
+	t.Helper()
+	suite.Run(t, new(reportRunRepoTestSuite))
+}
+
+type reportRunRepoTestSuite struct {
+	suite.Suite
+	t       *testing.T
+	writeDB *sql.DB
+	mock    sqlmock.Sqlmock
+	repo    ReportRunRepository
+}
+
+func (suite *reportRunRepoTestSuite) SetupTest() {
+	var err error
+	var cfg config.Config
+
+	suite.writeDB, suite.mock, err = sqlmock.New()
+	require.NoError(suite.T(), err)
+
+	suite.t = suite.T()
+	mockCtrl := gomock.NewController(suite.t)
+
+	suite.repo = NewSQLRepository(suite.writeDB, suite.writeDB, cfg, mockFlag.NewMockClient(mockCtrl), mock.NewMockClient(mockCtrl)).
+		GetReportRunRepository()
+}
+
+func (suite *reportRunRepoTestSuite) TearDownTest() {
+	suite.writeDB.Close()
+}
+
+func (suite *reportRunRepoTestSuite) TestRepository_Start() {
+	periodStart := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	now := time.Now()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryReportRunStart)).
+		WithArgs("monthly-topup", periodStart, periodEnd, float64(3600)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "started_at"}).AddRow(3, models.ReportRunStatusRunning, now))
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryReportRunStart)).
+		WithArgs("monthly-topup", periodStart, periodEnd, float64(3600)).
+		WillReturnError(sql.ErrNoRows)
+
+	run := &models.ReportRun{ReportName: "monthly-topup", PeriodStart: periodStart, PeriodEnd: periodEnd}
+	started, err := suite.repo.Start(context.Background(), run, time.Hour)
+	assert.NoError(suite.t, err)
+	assert.True(suite.t, started)
+	assert.Equal(suite.t, uint64(3), run.ID)
+	assert.Equal(suite.t, models.ReportRunStatusRunning, run.Status)
+
+	started, err = suite.repo.Start(context.Background(), run, time.Hour)
+	assert.NoError(suite.t, err)
+	assert.False(suite.t, started)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *reportRunRepoTestSuite) TestRepository_Finish() {
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(queryReportRunFinish)).
+		WithArgs(3, models.ReportRunStatusSuccess, 20, "reports/monthly-topup/2025/01/monthly-topup-2025-01-01_2025-01-31.csv", "").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(suite.t, suite.repo.Finish(context.Background(), &models.ReportRun{
+		ID:       3,
+		Status:   models.ReportRunStatusSuccess,
+		RowCount: 20,
+		FilePath: "reports/monthly-topup/2025/01/monthly-topup-2025-01-01_2025-01-31.csv",
+	}))
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
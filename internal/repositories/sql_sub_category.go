@@ -22,8 +22,8 @@ type subCategoryRepository sqlRepo
 var _ SubCategoryRepository = (*subCategoryRepository)(nil)
 
 func (scr *subCategoryRepository) CheckSubCategoryByCodeAndCategoryCode(ctx context.Context, code, categoryCode string) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := scr.r.extractTxWrite(ctx)
 
@@ -42,8 +42,8 @@ func (scr *subCategoryRepository) CheckSubCategoryByCodeAndCategoryCode(ctx cont
 func (r *subCategoryRepository) GetByCode(ctx context.Context, code string) (*models.SubCategory, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -69,8 +69,8 @@ func (r *subCategoryRepository) GetByCode(ctx context.Context, code string) (*mo
 
 // Create implements SubCategoryRepository.
 func (r *subCategoryRepository) Create(ctx context.Context, in *models.CreateSubCategory) (created *models.SubCategory, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -102,8 +102,8 @@ func (r *subCategoryRepository) Create(ctx context.Context, in *models.CreateSub
 func (r *subCategoryRepository) GetAll(ctx context.Context) (*[]models.SubCategory, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := r.r.extractTxWrite(ctx)
 
@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+
+	"github.com/lib/pq"
+)
+
+type TaxRateRepository interface {
+	Create(ctx context.Context, in *models.TaxRate) (err error)
+	GetAll(ctx context.Context) (result models.TaxRates, err error)
+
+	// GetTaxCollected sum tax leg transactions per entity, month and transaction type
+	GetTaxCollected(ctx context.Context, opts models.TaxReportFilterOptions) (result []models.TaxCollected, err error)
+}
+
+type taxRateRepo sqlRepo
+
+var _ TaxRateRepository = (*taxRateRepo)(nil)
+
+func (r *taxRateRepo) Create(ctx context.Context, in *models.TaxRate) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	return db.QueryRowContext(ctx, queryTaxRateCreate,
+		in.TaxType,
+		in.Entity,
+		in.LoanKind,
+		in.TransactionType,
+		in.Rate,
+		in.EffectiveFrom,
+		in.EffectiveTo,
+		in.Description,
+		in.CreatedBy,
+	).Scan(&in.ID, &in.CreatedAt)
+}
+
+func (r *taxRateRepo) GetAll(ctx context.Context) (result models.TaxRates, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	rows, err := db.QueryContext(ctx, queryTaxRateGetAll)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var rate models.TaxRate
+		err = rows.Scan(
+			&rate.ID,
+			&rate.TaxType,
+			&rate.Entity,
+			&rate.LoanKind,
+			&rate.TransactionType,
+			&rate.Rate,
+			&rate.EffectiveFrom,
+			&rate.EffectiveTo,
+			&rate.Description,
+			&rate.CreatedBy,
+			&rate.CreatedAt,
+		)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, rate)
+	}
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (r *taxRateRepo) GetTaxCollected(ctx context.Context, opts models.TaxReportFilterOptions) (result []models.TaxCollected, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	transactionTypes := make([]string, 0, len(opts.TransactionTypes))
+	for transactionType := range opts.TransactionTypes {
+		transactionTypes = append(transactionTypes, transactionType)
+	}
+	sort.Strings(transactionTypes)
+
+	db := r.r.extractTxRead(ctx)
+
+	rows, err := db.QueryContext(ctx, queryTaxCollectedReport,
+		pq.Array(transactionTypes),
+		opts.StartDate,
+		opts.EndDate,
+		string(models.TransactionStatusSuccess),
+		opts.Entity,
+	)
+	if err != nil {
+		return
+	}
+
+	defer rows.Close()
+	for rows.Next() {
+		var collected models.TaxCollected
+		err = rows.Scan(
+			&collected.Entity,
+			&collected.Period,
+			&collected.TransactionType,
+			&collected.TotalAmount,
+			&collected.TotalTransactions,
+		)
+		if err != nil {
+			return result, err
+		}
+		collected.TaxType = opts.TransactionTypes[collected.TransactionType]
+		result = append(result, collected)
+	}
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
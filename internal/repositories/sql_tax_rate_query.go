@@ -0,0 +1,44 @@
+package repositories
+
+var (
+	queryTaxRateCreate = `
+		INSERT INTO tax_rates(
+			tax_type, entity, loan_kind, transaction_type, rate, effective_from, effective_to, description, created_by, created_at
+		)
+		VALUES(
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, NOW()
+		)
+		RETURNING
+			id, created_at;
+	`
+
+	queryTaxRateGetAll = `SELECT
+		  id,
+		  tax_type,
+		  entity,
+		  loan_kind,
+		  transaction_type,
+		  rate,
+		  effective_from,
+		  effective_to,
+		  COALESCE(description, '') as description,
+		  created_by,
+		  created_at
+		FROM tax_rates
+		ORDER BY tax_type ASC, effective_from ASC, id ASC;`
+
+	// transaction date is in local time, so the report period follows the accounting date instead of transaction time in UTC
+	queryTaxCollectedReport = `SELECT
+		  COALESCE(metadata->>'entity', '') as entity,
+		  to_char("transactionDate", 'YYYY-MM') as period,
+		  "typeTransaction",
+		  SUM(amount) as total_amount,
+		  COUNT(1) as total_transactions
+		FROM transaction
+		WHERE "typeTransaction" = ANY($1)
+		  AND "transactionDate" BETWEEN $2 AND $3
+		  AND status = $4
+		  AND ($5::text = '' OR metadata->>'entity' = $5)
+		GROUP BY 1, 2, 3
+		ORDER BY 1, 2, 3;`
+)
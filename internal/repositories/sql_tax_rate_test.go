@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting/mock"
+	mockFlag "bitbucket.org/Amartha/go-fp-transaction/internal/common/flag/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestTaxRateRepositoryTestSuite(t *testing.T) {
+	t.Helper()
+	suite.Run(t, new(taxRateRepoTestSuite))
+}
+
+type taxRateRepoTestSuite struct {
+	suite.Suite
+	t       *testing.T
+	writeDB *sql.DB
+	mock    sqlmock.Sqlmock
+	repo    TaxRateRepository
+}
+
+func (suite *taxRateRepoTestSuite) SetupTest() {
+	var err error
+	var cfg config.Config
+
+	suite.writeDB, suite.mock, err = sqlmock.New()
+	require.NoError(suite.T(), err)
+
+	suite.t = suite.T()
+	mockCtrl := gomock.NewController(suite.t)
+
+	suite.repo = NewSQLRepository(suite.writeDB, suite.writeDB, cfg, mockFlag.NewMockClient(mockCtrl), mock.NewMockClient(mockCtrl)).
+		GetTaxRateRepository()
+}
+
+func (suite *taxRateRepoTestSuite) TearDownTest() {
+	suite.writeDB.Close()
+}
+
+func (suite *taxRateRepoTestSuite) TestRepository_Create() {
+	effectiveFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	in := &models.TaxRate{
+		TaxType:       models.TaxTypeVAT,
+		Entity:        "AMF",
+		Rate:          decimal.NewFromFloat(0.12),
+		EffectiveFrom: effectiveFrom,
+		CreatedBy:     "finance.tax",
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryTaxRateCreate)).
+		WithArgs(in.TaxType, "AMF", "", "", in.Rate, effectiveFrom, sqlmock.AnyArg(), "", "finance.tax").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(7, time.Now()))
+
+	err := suite.repo.Create(context.Background(), in)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, int64(7), in.ID)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *taxRateRepoTestSuite) TestRepository_GetAll() {
+	effectiveFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryTaxRateGetAll)).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "tax_type", "entity", "loan_kind", "transaction_type", "rate",
+			"effective_from", "effective_to", "description", "created_by", "created_at",
+		}).
+			AddRow(1, "VAT", "", "", "", "0.11", effectiveFrom, nil, "", "system", effectiveFrom).
+			AddRow(2, "WHT", "AMF", "MODAL_LOAN", "RPYAF", "0.02", effectiveFrom, effectiveFrom.AddDate(1, 0, 0), "pph 23", "finance.tax", effectiveFrom))
+
+	rates, err := suite.repo.GetAll(context.Background())
+	assert.NoError(suite.t, err)
+	assert.Len(suite.t, rates, 2)
+	assert.Nil(suite.t, rates[0].EffectiveTo)
+	assert.Equal(suite.t, models.TaxTypeWHT, rates[1].TaxType)
+	assert.True(suite.t, decimal.NewFromFloat(0.02).Equal(rates[1].Rate))
+	assert.NotNil(suite.t, rates[1].EffectiveTo)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *taxRateRepoTestSuite) TestRepository_GetTaxCollected() {
+	opts := models.TaxReportFilterOptions{
+		StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		TransactionTypes: map[string]models.TaxType{
+			"RPYAG": models.TaxTypeVAT,
+			"RPYAC": models.TaxTypeWHT,
+		},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryTaxCollectedReport)).
+		WithArgs(pq.Array([]string{"RPYAC", "RPYAG"}), opts.StartDate, opts.EndDate, "1", "").
+		WillReturnRows(sqlmock.NewRows([]string{"entity", "period", "typeTransaction", "total_amount", "total_transactions"}).
+			AddRow("AMF", "2025-01", "RPYAC", "2000", 4).
+			AddRow("AMF", "2025-01", "RPYAG", "11000", 10))
+
+	collected, err := suite.repo.GetTaxCollected(context.Background(), opts)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, []models.TaxCollected{
+		{Entity: "AMF", Period: "2025-01", TaxType: models.TaxTypeWHT, TransactionType: "RPYAC", TotalAmount: decimal.NewFromInt(2000), TotalTransactions: 4},
+		{Entity: "AMF", Period: "2025-01", TaxType: models.TaxTypeVAT, TransactionType: "RPYAG", TotalAmount: decimal.NewFromInt(11000), TotalTransactions: 10},
+	}, collected)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
 	"time"
@@ -25,9 +26,8 @@ type TransactionRepository interface {
 	GetTrxId(ctx context.Context, id int64) (object models.Transaction, err error)
 	StreamAll(ctx context.Context, opts models.TransactionFilterOptions) <-chan models.TransactionStreamResult
 	GetByTransactionID(ctx context.Context, transactionId string) (trx *models.Transaction, err error)
+	GetByWalletTransactionID(ctx context.Context, walletTransactionId, refNumber string) ([]models.Transaction, error)
 	UpdateStatus(ctx context.Context, id uint64, status string) (trx *models.Transaction, err error)
-	GetReportRepayment(ctx context.Context, startDate, endDate time.Time) ([]models.ReportRepayment, error)
-	ColectRepayment(ctx context.Context, date time.Time) (res *models.CollectRepayment, err error)
 }
 
 type transactionRepository sqlRepo
@@ -35,8 +35,8 @@ type transactionRepository sqlRepo
 var _ TransactionRepository = (*transactionRepository)(nil)
 
 func (tr *transactionRepository) Store(ctx context.Context, en *models.Transaction) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := tr.r.extractTxWrite(ctx)
 
@@ -67,12 +67,12 @@ func (tr *transactionRepository) Store(ctx context.Context, en *models.Transacti
 		return
 	}
 
-	return
+	return tr.invalidateDailyAggregates(ctx, en.TransactionDate)
 }
 
 func (tr *transactionRepository) StoreBulkTransaction(ctx context.Context, en []*models.Transaction) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := tr.r.extractTxWrite(ctx)
 
@@ -80,7 +80,7 @@ func (tr *transactionRepository) StoreBulkTransaction(ctx context.Context, en []
 	valueArgs := []interface{}{}
 
 	for _, req := range en {
-		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		valueStrings = append(valueStrings, "(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 		valueArgs = append(valueArgs, req.TransactionID)
 		valueArgs = append(valueArgs, req.TransactionDate)
 		valueArgs = append(valueArgs, req.FromAccount)
@@ -98,10 +98,11 @@ func (tr *transactionRepository) StoreBulkTransaction(ctx context.Context, en []
 		valueArgs = append(valueArgs, req.OrderType)
 		valueArgs = append(valueArgs, req.TransactionTime)
 		valueArgs = append(valueArgs, req.Currency)
+		valueArgs = append(valueArgs, sql.NullString{String: req.WalletTransactionID, Valid: req.WalletTransactionID != ""})
 	}
 
 	storeTrxQueryBulk := fmt.Sprintf(`INSERT INTO "transaction" ("transactionId", "transactionDate", "fromAccount", "toAccount", "fromNarrative", "toNarrative", 
-		"amount", "status", "method", "typeTransaction", "description", "refNumber", "metadata", "orderTime", "orderType", "transactionTime", "currency") VALUES %s`, strings.Join(valueStrings, ","))
+		"amount", "status", "method", "typeTransaction", "description", "refNumber", "metadata", "orderTime", "orderType", "transactionTime", "currency", "walletTransactionId") VALUES %s`, strings.Join(valueStrings, ","))
 
 	sqlStr := common.ReplaceSQL(storeTrxQueryBulk, "?")
 
@@ -110,12 +111,17 @@ func (tr *transactionRepository) StoreBulkTransaction(ctx context.Context, en []
 		return err
 	}
 
-	return
+	dates := make([]time.Time, len(en))
+	for i, req := range en {
+		dates[i] = req.TransactionDate
+	}
+
+	return tr.invalidateDailyAggregates(ctx, dates...)
 }
 
 func (tr *transactionRepository) CheckRefNumbers(ctx context.Context, refNumbers ...string) (exists map[string]bool, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := tr.r.extractTxWrite(ctx)
 
@@ -144,8 +150,8 @@ func (tr *transactionRepository) CheckRefNumbers(ctx context.Context, refNumbers
 }
 
 func (tr *transactionRepository) GetByID(ctx context.Context, id uint64) (en *models.Transaction, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := tr.r.extractTxWrite(ctx)
 
@@ -186,8 +192,8 @@ func (tr *transactionRepository) GetByID(ctx context.Context, id uint64) (en *mo
 func (tr *transactionRepository) GetList(ctx context.Context, opts models.TransactionFilterOptions) ([]models.Transaction, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := tr.r.mustWithRead(ctx)
 
@@ -242,8 +248,8 @@ func (tr *transactionRepository) GetList(ctx context.Context, opts models.Transa
 }
 
 func (tr *transactionRepository) GetStatusCount(ctx context.Context, threshold uint, opts models.TransactionFilterOptions) (out models.StatusCountTransaction, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := tr.r.extractTxWrite(ctx)
 
@@ -263,8 +269,8 @@ func (tr *transactionRepository) GetStatusCount(ctx context.Context, threshold u
 }
 
 func (tr *transactionRepository) CountAll(ctx context.Context, opts models.TransactionFilterOptions) (total int, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := tr.r.extractTxWrite(ctx)
 
@@ -282,8 +288,8 @@ func (tr *transactionRepository) CountAll(ctx context.Context, opts models.Trans
 }
 
 func (tr *transactionRepository) GetTrxId(ctx context.Context, id int64) (object models.Transaction, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := tr.r.extractTxWrite(ctx)
 
@@ -375,8 +381,8 @@ func (tr *transactionRepository) StreamAll(ctx context.Context, opts models.Tran
 func (tr *transactionRepository) GetByTransactionTypeAndRefNumber(ctx context.Context, req *models.TransactionGetByTypeAndRefNumberRequest) (*models.GetTransactionOut, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := tr.r.extractTxWrite(ctx)
 
@@ -408,8 +414,8 @@ func (tr *transactionRepository) GetByTransactionTypeAndRefNumber(ctx context.Co
 
 // GetByTransactionID will get transaction by transactionId.
 func (tr *transactionRepository) GetByTransactionID(ctx context.Context, transactionId string) (trx *models.Transaction, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := tr.r.extractTxWrite(ctx)
 
@@ -438,10 +444,70 @@ func (tr *transactionRepository) GetByTransactionID(ctx context.Context, transac
 	return
 }
 
+// GetByWalletTransactionID will get child transactions of a wallet transaction ordered by id.
+// Transaction stored before the wallet transaction link exists has no walletTransactionId,
+// so it is matched by refNumber instead, empty refNumber disables the match.
+func (tr *transactionRepository) GetByWalletTransactionID(ctx context.Context, walletTransactionId, refNumber string) (res []models.Transaction, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := tr.r.extractTxRead(ctx)
+
+	rows, err := db.QueryContext(ctx, queryTransactionByWalletTransactionID, walletTransactionId, refNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var trx models.Transaction
+		var linkedWalletTransactionId sql.NullString
+		err = rows.Scan(
+			&trx.ID,
+			&trx.TransactionID,
+			&trx.TransactionDate,
+			&trx.TransactionTime,
+			&trx.FromAccount,
+			&trx.ToAccount,
+			&trx.FromNarrative,
+			&trx.ToNarrative,
+			&trx.Amount,
+			&trx.Status,
+			&trx.Method,
+			&trx.TypeTransaction,
+			&trx.Description,
+			&trx.RefNumber,
+			&trx.OrderTime,
+			&trx.OrderType,
+			&trx.Currency,
+			&trx.Metadata,
+			&trx.CreatedAt,
+			&trx.UpdatedAt,
+			&linkedWalletTransactionId)
+		if err != nil {
+			return nil, err
+		}
+
+		trx.WalletTransactionID = linkedWalletTransactionId.String
+		trx.TransactionTime = trx.TransactionTime.In(common.GetLocation())
+		trx.OrderTime = trx.OrderTime.In(common.GetLocation())
+		trx.CreatedAt = trx.CreatedAt.In(common.GetLocation())
+		trx.UpdatedAt = trx.UpdatedAt.In(common.GetLocation())
+
+		res = append(res, trx)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // UpdateStatus will update transaction status based on ID.
 func (tr *transactionRepository) UpdateStatus(ctx context.Context, id uint64, status string) (trx *models.Transaction, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := tr.r.extractTxWrite(ctx)
 
@@ -460,68 +526,32 @@ func (tr *transactionRepository) UpdateStatus(ctx context.Context, id uint64, st
 	}
 
 	trx, err = tr.GetByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = tr.invalidateDailyAggregates(ctx, trx.TransactionDate)
 	return
 }
 
-func (tr *transactionRepository) GetReportRepayment(ctx context.Context, startDate, endDate time.Time) ([]models.ReportRepayment, error) {
-	var err error
-
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
-
-	db := tr.r.extractTxRead(ctx)
-
-	rows, err := db.QueryContext(ctx, queryReportRepayment, startDate, endDate)
+// invalidateDailyAggregates invalidate materialized aggregates of the closed days among dates,
+// so back-dated transactions and status updates are not missing from transaction metrics
+func (tr *transactionRepository) invalidateDailyAggregates(ctx context.Context, dates ...time.Time) error {
+	today, err := common.NowZeroTime()
 	if err != nil {
-		return nil, fmt.Errorf("failed to run query: %w", err)
+		return err
 	}
-	defer rows.Close()
 
-	var result []models.ReportRepayment
-	for rows.Next() {
-		var rr models.ReportRepayment
-		err = rows.Scan(
-			&rr.TransactionDate,
-			&rr.Outstanding,
-			&rr.Principal,
-			&rr.Amartha,
-			&rr.Lender,
-			&rr.PPN,
-			&rr.PPh,
-			&rr.Total,
-		)
-		if err != nil {
-			return nil, err
+	var closedDates []time.Time
+	for _, date := range dates {
+		if date.Format(time.DateOnly) < today.Format(time.DateOnly) {
+			closedDates = append(closedDates, date)
 		}
-		result = append(result, rr)
-	}
-	if err := rows.Err(); err != nil {
-		return result, err
 	}
 
-	return result, nil
-}
-
-func (tr *transactionRepository) ColectRepayment(ctx context.Context, date time.Time) (res *models.CollectRepayment, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
-
-	db := tr.r.extractTxRead(ctx)
-
-	res = &models.CollectRepayment{}
-	err = db.QueryRowContext(ctx, queryCollectRepayment, date).Scan(
-		&res.TransactionDate,
-		&res.Outstanding,
-		&res.Principal,
-		&res.Amartha,
-		&res.Lender,
-		&res.PPN,
-		&res.PPh,
-	)
-
-	if err != nil {
-		return res, fmt.Errorf("failed to run query: %w", err)
+	if len(closedDates) == 0 {
+		return nil
 	}
 
-	return res, nil
+	return tr.r.tmr.InvalidateDailyAggregates(ctx, closedDates)
 }
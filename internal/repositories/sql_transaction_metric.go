@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+
+	"github.com/lib/pq"
+)
+
+type TransactionMetricRepository interface {
+	// AggregateTransactions sum transactions of the dates per day and dimension directly from transaction table
+	AggregateTransactions(ctx context.Context, dates []time.Time, opts models.TransactionMetricFilterOptions) (result []models.TransactionDailyAggregate, err error)
+
+	// GetDailyAggregates return materialized aggregates between start and end date of opts
+	GetDailyAggregates(ctx context.Context, opts models.TransactionMetricFilterOptions) (result []models.TransactionDailyAggregate, err error)
+
+	// GetAggregatedDates return the dates between start and end date that have been materialized and not invalidated
+	GetAggregatedDates(ctx context.Context, startDate, endDate time.Time) (result []time.Time, err error)
+
+	// GetInvalidatedDates return the materialized dates that have been invalidated and must be materialized again
+	GetInvalidatedDates(ctx context.Context) (result []time.Time, err error)
+
+	// InvalidateDailyAggregates mark materialized aggregates of the dates as stale,
+	// they are aggregated from transaction table until the dates are materialized again
+	InvalidateDailyAggregates(ctx context.Context, dates []time.Time) (err error)
+
+	// MaterializeDailyAggregates replace aggregates of the date with the current transactions,
+	// it runs multiple statements so it must be called in an atomic transaction
+	MaterializeDailyAggregates(ctx context.Context, date time.Time) (err error)
+}
+
+type transactionMetricRepo sqlRepo
+
+var _ TransactionMetricRepository = (*transactionMetricRepo)(nil)
+
+func (r *transactionMetricRepo) AggregateTransactions(ctx context.Context, dates []time.Time, opts models.TransactionMetricFilterOptions) (result []models.TransactionDailyAggregate, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	transactionDates := make([]string, len(dates))
+	for i, date := range dates {
+		transactionDates[i] = date.Format(time.DateOnly)
+	}
+
+	db := r.r.extractTxRead(ctx)
+
+	rows, err := db.QueryContext(ctx, queryTransactionMetricAggregate,
+		pq.Array(transactionDates),
+		pq.Array(opts.OrderTypes),
+		pq.Array(opts.TransactionTypes),
+		pq.Array(opts.Statuses),
+		opts.Entity,
+		opts.ProductTypeName,
+	)
+	if err != nil {
+		return
+	}
+
+	return scanTransactionDailyAggregates(rows)
+}
+
+func (r *transactionMetricRepo) GetDailyAggregates(ctx context.Context, opts models.TransactionMetricFilterOptions) (result []models.TransactionDailyAggregate, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxRead(ctx)
+
+	rows, err := db.QueryContext(ctx, queryTransactionMetricDailyAggregates,
+		opts.StartDate,
+		opts.EndDate,
+		pq.Array(opts.OrderTypes),
+		pq.Array(opts.TransactionTypes),
+		pq.Array(opts.Statuses),
+		opts.Entity,
+		opts.ProductTypeName,
+	)
+	if err != nil {
+		return
+	}
+
+	return scanTransactionDailyAggregates(rows)
+}
+
+func (r *transactionMetricRepo) GetAggregatedDates(ctx context.Context, startDate, endDate time.Time) (result []time.Time, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxRead(ctx)
+
+	rows, err := db.QueryContext(ctx, queryTransactionMetricAggregatedDates, startDate, endDate)
+	if err != nil {
+		return
+	}
+
+	return scanTransactionMetricDates(rows)
+}
+
+func (r *transactionMetricRepo) GetInvalidatedDates(ctx context.Context) (result []time.Time, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxRead(ctx)
+
+	rows, err := db.QueryContext(ctx, queryTransactionMetricInvalidatedDates)
+	if err != nil {
+		return
+	}
+
+	return scanTransactionMetricDates(rows)
+}
+
+func (r *transactionMetricRepo) InvalidateDailyAggregates(ctx context.Context, dates []time.Time) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	transactionDates := make([]string, len(dates))
+	for i, date := range dates {
+		transactionDates[i] = date.Format(time.DateOnly)
+	}
+
+	db := r.r.extractTxWrite(ctx)
+
+	_, err = db.ExecContext(ctx, queryTransactionMetricInvalidateAggregatedDates, pq.Array(transactionDates))
+	return err
+}
+
+func (r *transactionMetricRepo) MaterializeDailyAggregates(ctx context.Context, date time.Time) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	// the date is upserted first so it stays locked while the aggregates are replaced, a transaction which
+	// invalidates the date meanwhile waits for the lock and its invalidation is kept after the commit
+	for _, query := range []string{
+		queryTransactionMetricUpsertAggregatedDate,
+		queryTransactionMetricDeleteDailyAggregates,
+		queryTransactionMetricInsertDailyAggregates,
+	} {
+		if _, err = db.ExecContext(ctx, query, date); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanTransactionDailyAggregates(rows *sql.Rows) (result []models.TransactionDailyAggregate, err error) {
+	defer rows.Close()
+	for rows.Next() {
+		var aggregate models.TransactionDailyAggregate
+		err = rows.Scan(
+			&aggregate.TransactionDate,
+			&aggregate.OrderType,
+			&aggregate.TransactionType,
+			&aggregate.Entity,
+			&aggregate.ProductTypeName,
+			&aggregate.Status,
+			&aggregate.TotalAmount,
+			&aggregate.TotalCount,
+		)
+		if err != nil {
+			return result, err
+		}
+		result = append(result, aggregate)
+	}
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func scanTransactionMetricDates(rows *sql.Rows) (result []time.Time, err error) {
+	defer rows.Close()
+	for rows.Next() {
+		var date time.Time
+		if err = rows.Scan(&date); err != nil {
+			return result, err
+		}
+		result = append(result, date)
+	}
+	if err = rows.Err(); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package repositories
+
+var (
+	// entity is taken from transaction metadata like the tax report and product type from the source account,
+	// falling back to the destination account when the source account has none
+	queryTransactionMetricAggregate = `SELECT
+		  t."transactionDate",
+		  COALESCE(t."orderType", '') as "orderType",
+		  t."typeTransaction",
+		  COALESCE(t.metadata->>'entity', '') as entity,
+		  COALESCE(NULLIF(af."productTypeName", ''), at."productTypeName", '') as "productTypeName",
+		  t.status,
+		  COALESCE(SUM(t.amount), 0) as total_amount,
+		  COUNT(1) as total_count
+		FROM transaction t
+		LEFT JOIN account af ON t."fromAccount" = af."accountNumber"
+		LEFT JOIN account at ON t."toAccount" = at."accountNumber"
+		WHERE t."transactionDate" = ANY($1::date[])
+		  AND (COALESCE(cardinality($2::text[]), 0) = 0 OR t."orderType" = ANY($2))
+		  AND (COALESCE(cardinality($3::text[]), 0) = 0 OR t."typeTransaction" = ANY($3))
+		  AND (COALESCE(cardinality($4::text[]), 0) = 0 OR t.status = ANY($4))
+		  AND ($5::text = '' OR COALESCE(t.metadata->>'entity', '') = $5)
+		  AND ($6::text = '' OR COALESCE(NULLIF(af."productTypeName", ''), at."productTypeName", '') = $6)
+		GROUP BY 1, 2, 3, 4, 5, 6;`
+
+	queryTransactionMetricDailyAggregates = `SELECT
+		  "transactionDate",
+		  "orderType",
+		  "typeTransaction",
+		  entity,
+		  "productTypeName",
+		  status,
+		  total_amount,
+		  total_count
+		FROM transaction_daily_aggregates
+		WHERE "transactionDate" BETWEEN $1 AND $2
+		  AND (COALESCE(cardinality($3::text[]), 0) = 0 OR "orderType" = ANY($3))
+		  AND (COALESCE(cardinality($4::text[]), 0) = 0 OR "typeTransaction" = ANY($4))
+		  AND (COALESCE(cardinality($5::text[]), 0) = 0 OR status = ANY($5))
+		  AND ($6::text = '' OR entity = $6)
+		  AND ($7::text = '' OR "productTypeName" = $7);`
+
+	queryTransactionMetricAggregatedDates = `SELECT "transactionDate"
+		FROM transaction_aggregate_dates
+		WHERE "transactionDate" BETWEEN $1 AND $2 AND invalidated_at IS NULL
+		ORDER BY "transactionDate" ASC;`
+
+	queryTransactionMetricInvalidatedDates = `SELECT "transactionDate"
+		FROM transaction_aggregate_dates
+		WHERE invalidated_at IS NOT NULL
+		ORDER BY "transactionDate" ASC;`
+
+	queryTransactionMetricInvalidateAggregatedDates = `UPDATE transaction_aggregate_dates
+		SET invalidated_at = NOW()
+		WHERE "transactionDate" = ANY($1::date[]) AND invalidated_at IS NULL;`
+
+	queryTransactionMetricDeleteDailyAggregates = `DELETE FROM transaction_daily_aggregates WHERE "transactionDate" = $1;`
+
+	queryTransactionMetricInsertDailyAggregates = `INSERT INTO transaction_daily_aggregates(
+		  "transactionDate", "orderType", "typeTransaction", entity, "productTypeName", status, total_amount, total_count
+		)
+		SELECT
+		  t."transactionDate",
+		  COALESCE(t."orderType", ''),
+		  t."typeTransaction",
+		  COALESCE(t.metadata->>'entity', ''),
+		  COALESCE(NULLIF(af."productTypeName", ''), at."productTypeName", ''),
+		  t.status,
+		  COALESCE(SUM(t.amount), 0),
+		  COUNT(1)
+		FROM transaction t
+		LEFT JOIN account af ON t."fromAccount" = af."accountNumber"
+		LEFT JOIN account at ON t."toAccount" = at."accountNumber"
+		WHERE t."transactionDate" = $1
+		GROUP BY 1, 2, 3, 4, 5, 6;`
+
+	queryTransactionMetricUpsertAggregatedDate = `INSERT INTO transaction_aggregate_dates("transactionDate", aggregated_at)
+		VALUES($1, NOW())
+		ON CONFLICT ("transactionDate") DO UPDATE SET aggregated_at = NOW(), invalidated_at = NULL;`
+)
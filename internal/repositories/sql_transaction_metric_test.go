@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting/mock"
+	mockFlag "bitbucket.org/Amartha/go-fp-transaction/internal/common/flag/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestTransactionMetricRepositoryTestSuite(t *testing.T) {
+	t.Helper()
+	suite.Run(t, new(transactionMetricRepoTestSuite))
+}
+
+type transactionMetricRepoTestSuite struct {
+	suite.Suite
+	t       *testing.T
+	writeDB *sql.DB
+	mock    sqlmock.Sqlmock
+	repo    TransactionMetricRepository
+}
+
+var transactionMetricAggregateColumns = []string{
+	"transactionDate", "orderType", "typeTransaction", "entity", "productTypeName", "status", "total_amount", "total_count",
+}
+
+func (suite *transactionMetricRepoTestSuite) SetupTest() {
+	var err error
+	var cfg config.Config
+
+	suite.writeDB, suite.mock, err = sqlmock.New()
+	require.NoError(suite.T(), err)
+
+	suite.t = suite.T()
+	mockCtrl := gomock.NewController(suite.t)
+
+	suite.repo = NewSQLRepository(suite.writeDB, suite.writeDB, cfg, mockFlag.NewMockClient(mockCtrl), mock.NewMockClient(mockCtrl)).
+		GetTransactionMetricRepository()
+}
+
+func (suite *transactionMetricRepoTestSuite) TearDownTest() {
+	suite.writeDB.Close()
+}
+
+func (suite *transactionMetricRepoTestSuite) TestRepository_AggregateTransactions() {
+	date := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	opts := models.TransactionMetricFilterOptions{
+		TransactionTypes: []string{"RPYAD"},
+		Entity:           "AMF",
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryTransactionMetricAggregate)).
+		WithArgs(pq.Array([]string{"2025-01-02"}), pq.Array([]string(nil)), pq.Array([]string{"RPYAD"}), pq.Array([]string(nil)), "AMF", "").
+		WillReturnRows(sqlmock.NewRows(transactionMetricAggregateColumns).
+			AddRow(date, "REPAYMENT", "RPYAD", "AMF", "Modal", "1", "150000", 3))
+
+	result, err := suite.repo.AggregateTransactions(context.Background(), []time.Time{date}, opts)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, []models.TransactionDailyAggregate{
+		{
+			TransactionDate: date,
+			OrderType:       "REPAYMENT",
+			TransactionType: "RPYAD",
+			Entity:          "AMF",
+			ProductTypeName: "Modal",
+			Status:          "1",
+			TotalAmount:     decimal.NewFromInt(150000),
+			TotalCount:      3,
+		},
+	}, result)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *transactionMetricRepoTestSuite) TestRepository_GetDailyAggregates() {
+	opts := models.TransactionMetricFilterOptions{
+		StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		Statuses:  []string{"1"},
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryTransactionMetricDailyAggregates)).
+		WithArgs(opts.StartDate, opts.EndDate, pq.Array([]string(nil)), pq.Array([]string(nil)), pq.Array([]string{"1"}), "", "").
+		WillReturnRows(sqlmock.NewRows(transactionMetricAggregateColumns).
+			AddRow(opts.StartDate, "REPAYMENT", "RPYAF", "", "", "1", "500", 1))
+
+	result, err := suite.repo.GetDailyAggregates(context.Background(), opts)
+	assert.NoError(suite.t, err)
+	require.Len(suite.t, result, 1)
+	assert.Equal(suite.t, "RPYAF", result[0].TransactionType)
+	assert.True(suite.t, decimal.NewFromInt(500).Equal(result[0].TotalAmount))
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *transactionMetricRepoTestSuite) TestRepository_GetDailyAggregates_Error() {
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryTransactionMetricDailyAggregates)).
+		WillReturnError(assert.AnError)
+
+	_, err := suite.repo.GetDailyAggregates(context.Background(), models.TransactionMetricFilterOptions{})
+	assert.Error(suite.t, err)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *transactionMetricRepoTestSuite) TestRepository_GetAggregatedDates() {
+	startDate := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryTransactionMetricAggregatedDates)).
+		WithArgs(startDate, endDate).
+		WillReturnRows(sqlmock.NewRows([]string{"transactionDate"}).AddRow(startDate))
+
+	result, err := suite.repo.GetAggregatedDates(context.Background(), startDate, endDate)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, []time.Time{startDate}, result)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *transactionMetricRepoTestSuite) TestRepository_GetInvalidatedDates() {
+	date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryTransactionMetricInvalidatedDates)).
+		WillReturnRows(sqlmock.NewRows([]string{"transactionDate"}).AddRow(date))
+
+	result, err := suite.repo.GetInvalidatedDates(context.Background())
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, []time.Time{date}, result)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *transactionMetricRepoTestSuite) TestRepository_InvalidateDailyAggregates() {
+	dates := []time.Time{
+		time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	suite.mock.ExpectExec(regexp.QuoteMeta(queryTransactionMetricInvalidateAggregatedDates)).
+		WithArgs(pq.Array([]string{"2025-01-01", "2025-01-02"})).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := suite.repo.InvalidateDailyAggregates(context.Background(), dates)
+	assert.NoError(suite.t, err)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *transactionMetricRepoTestSuite) TestRepository_MaterializeDailyAggregates() {
+	date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	suite.mock.ExpectExec(regexp.QuoteMeta(queryTransactionMetricUpsertAggregatedDate)).
+		WithArgs(date).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mock.ExpectExec(regexp.QuoteMeta(queryTransactionMetricDeleteDailyAggregates)).
+		WithArgs(date).WillReturnResult(sqlmock.NewResult(0, 10))
+	suite.mock.ExpectExec(regexp.QuoteMeta(queryTransactionMetricInsertDailyAggregates)).
+		WithArgs(date).WillReturnResult(sqlmock.NewResult(0, 12))
+
+	err := suite.repo.MaterializeDailyAggregates(context.Background(), date)
+	assert.NoError(suite.t, err)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *transactionMetricRepoTestSuite) TestRepository_MaterializeDailyAggregates_Error() {
+	date := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	suite.mock.ExpectExec(regexp.QuoteMeta(queryTransactionMetricUpsertAggregatedDate)).
+		WithArgs(date).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mock.ExpectExec(regexp.QuoteMeta(queryTransactionMetricDeleteDailyAggregates)).
+		WithArgs(date).WillReturnResult(sqlmock.NewResult(0, 10))
+	suite.mock.ExpectExec(regexp.QuoteMeta(queryTransactionMetricInsertDailyAggregates)).
+		WithArgs(date).WillReturnError(assert.AnError)
+
+	err := suite.repo.MaterializeDailyAggregates(context.Background(), date)
+	assert.ErrorIs(suite.t, err, assert.AnError)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
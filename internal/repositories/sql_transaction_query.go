@@ -2,11 +2,13 @@ package repositories
 
 import (
 	"fmt"
+	"strings"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/lib/pq"
 )
 
 const (
@@ -61,6 +63,33 @@ const (
 	queryTransactionByTransactionID = `SELECT "id", "transactionId", "transactionDate", "fromAccount", "toAccount", "fromNarrative", "toNarrative", "amount", "status", "method", "typeTransaction", "description", "refNumber", "metadata", "createdAt", "updatedAt"
 		FROM "transaction" WHERE "transactionId" = $1;`
 
+	queryTransactionByWalletTransactionID = `SELECT
+						"id",
+						"transactionId",
+						"transactionDate",
+						"transactionTime",
+						"fromAccount",
+						"toAccount",
+						"fromNarrative",
+						"toNarrative",
+						"amount",
+						"status",
+						"method",
+						"typeTransaction",
+						"description",
+						"refNumber",
+						"orderTime",
+						"orderType",
+						"currency",
+						"metadata",
+						"createdAt",
+						"updatedAt",
+						"walletTransactionId"
+					FROM "transaction"
+					WHERE "walletTransactionId" = $1
+						OR ($2 <> '' AND "walletTransactionId" IS NULL AND "refNumber" = $2)
+					ORDER BY "id" ASC;`
+
 	queryUpdateTransactionStatus = `
 		UPDATE "transaction"
 		SET
@@ -104,54 +133,6 @@ const (
 		WHERE relname = 'transaction' or relname = 'transaction_default'
 		order by reltuples desc
 		limit 1;`
-
-	queryCollectRepayment = `
-WITH transaction_summary AS (
-    SELECT
-        "transactionDate" AS "transactionDate",
-        SUM(CASE WHEN "typeTransaction" = 'RPYAE' THEN amount ELSE 0 END) AS "outstanding",
-        SUM(CASE WHEN "typeTransaction" = 'RPYAD' THEN amount ELSE 0 END) AS "principal",
-        SUM(CASE WHEN "typeTransaction" = 'RPYAF' THEN amount ELSE 0 END) AS "amartha",
-        SUM(CASE WHEN "typeTransaction" = 'RPYAB' THEN amount ELSE 0 END) AS "lender",
-        SUM(CASE WHEN "typeTransaction" = 'RPYAG' THEN amount ELSE 0 END) AS "ppn",
-        SUM(CASE WHEN "typeTransaction" = 'RPYAC' THEN amount ELSE 0 END) AS "pph"
-    FROM transaction
-    WHERE "typeTransaction" IN ('RPYAE', 'RPYAD', 'RPYAF', 'RPYAB', 'RPYAG', 'RPYAC')
-      AND "transactionDate" = $1::date
-    GROUP BY "transactionDate"
-)
-SELECT 
-    COALESCE(ts."transactionDate", $1::date) AS "transactionDate",
-    COALESCE(ts."outstanding", 0) AS "outstanding",
-    COALESCE(ts."principal", 0) AS "principal",
-    COALESCE(ts."amartha", 0) AS "amartha",
-    COALESCE(ts."lender", 0) AS "lender",
-    COALESCE(ts."ppn", 0) AS "ppn",
-    COALESCE(ts."pph", 0) AS "pph"
-FROM transaction_summary ts
-RIGHT JOIN (SELECT $1::date AS "transactionDate") d ON ts."transactionDate" = d."transactionDate";
-`
-
-	queryReportRepayment = `
-	WITH transaction_summary AS (
-    SELECT
-        "transactionDate" AS "transactionDate",
-        SUM(CASE WHEN "typeTransaction" = 'RPYAE' THEN amount ELSE 0 END) AS "outstanding",
-        SUM(CASE WHEN "typeTransaction" = 'RPYAD' THEN amount ELSE 0 END) AS "principal",
-        SUM(CASE WHEN "typeTransaction" = 'RPYAF' THEN amount ELSE 0 END) AS "amartha",
-        SUM(CASE WHEN "typeTransaction" = 'RPYAB' THEN amount ELSE 0 END) AS "lender",
-        SUM(CASE WHEN "typeTransaction" = 'RPYAG' THEN amount ELSE 0 END) AS "ppn",
-        SUM(CASE WHEN "typeTransaction" = 'RPYAC' THEN amount ELSE 0 END) AS "pph"
-    FROM transaction
-    WHERE "typeTransaction" IN ('RPYAE', 'RPYAD', 'RPYAF', 'RPYAB', 'RPYAG', 'RPYAC')
-      AND "transactionDate" >= $1::date AND "transactionDate" < $2::date
-    GROUP BY "transactionDate"
-)
-SELECT *,
-       ("principal" + "amartha" + "lender" + "ppn" + "pph") AS "total"
-FROM transaction_summary
-ORDER BY "transactionDate" DESC;
-`
 )
 
 func buildStreamAllTransactionQuery(opts models.TransactionStreamAllOptions) (sql string, args []interface{}, err error) {
@@ -332,9 +313,59 @@ func buildFilteredTransactionQuery(cols []string, opts models.TransactionFilterO
 		query = query.Where(sq.Eq{`transaction."transactionDate"`: td})
 	}
 
+	if opts.AccountNumber != "" {
+		query = query.Where(sq.Or{
+			sq.Eq{`transaction."fromAccount"`: opts.AccountNumber},
+			sq.Eq{`transaction."toAccount"`: opts.AccountNumber},
+		})
+	}
+
+	if len(opts.Statuses) > 0 {
+		query = query.Where(sq.Eq{`transaction."status"`: opts.Statuses})
+	}
+
+	if len(opts.RefNumbers) > 0 {
+		query = query.Where(sq.Eq{`transaction."refNumber"`: opts.RefNumbers})
+	}
+
+	if opts.MinAmount != nil {
+		query = query.Where(sq.GtOrEq{`transaction."amount"`: *opts.MinAmount})
+	}
+
+	if opts.MaxAmount != nil {
+		query = query.Where(sq.LtOrEq{`transaction."amount"`: *opts.MaxAmount})
+	}
+
+	if len(opts.MetadataFilters) > 0 {
+		query = query.Where(buildMetadataFilter(`transaction."metadata"`, opts.MetadataFilters))
+	}
+
 	return query
 }
 
+// buildMetadataFilter compile metadata filters of JSONB column into where clause,
+// the path and value are always sent as placeholder arguments.
+// eq use containment operator so it can use the GIN index of the column,
+// contains is case-insensitive substring match of the value in the path
+func buildMetadataFilter(column string, filters []models.MetadataFilter) sq.And {
+	conditions := sq.And{}
+	for _, f := range filters {
+		switch f.Operator {
+		case models.MetadataFilterOperatorEqual:
+			conditions = append(conditions, sq.Expr(column+` @> ?::jsonb`, f.ToContainmentJSON()))
+		case models.MetadataFilterOperatorContains:
+			conditions = append(conditions, sq.Expr(column+` #>> ?::text[] ILIKE ?`, pq.Array(f.Path), "%"+escapeLikePattern(f.Value)+"%"))
+		}
+	}
+
+	return conditions
+}
+
+// escapeLikePattern escape wildcard characters of LIKE, so user input is matched literally
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
 func buildStatusCountTransactionQuery(threshold uint, opts models.TransactionFilterOptions) (sql string, args []interface{}, err error) {
 	subQuery := buildFilteredTransactionQuery([]string{"1"}, opts)
 	subQuery = subQuery.Limit(uint64(threshold + 1))
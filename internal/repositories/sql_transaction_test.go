@@ -142,6 +142,11 @@ func (suite *TransactionTestSuite) TestRepository_Store() {
 						WillReturnRows(sqlmock.
 							NewRows([]string{"id", "createdAt", "updatedAt"}).
 							AddRow(1, ct, ct))
+					// the transaction is back-dated
+					suite.mock.
+						ExpectExec(regexp.QuoteMeta(queryTransactionMetricInvalidateAggregatedDates)).
+						WithArgs(pq.Array([]string{"2023-02-01"})).
+						WillReturnResult(sqlmock.NewResult(0, 1))
 				},
 			},
 			wantErr: false,
@@ -824,6 +829,67 @@ func (suite *TransactionTestSuite) Test_TransactionRepository_GetByTransactionID
 	}
 }
 
+func (suite *TransactionTestSuite) Test_TransactionRepository_GetByWalletTransactionID() {
+	walletTransactionId := "41d03147-c017-4176-8a1a-0b7ec735cc29"
+	refNumber := "FT2303000001"
+	columns := []string{"id", "transactionId", "transactionDate", "transactionTime", "fromAccount", "toAccount", "fromNarrative", "toNarrative", "amount", "status", "method", "typeTransaction", "description", "refNumber", "orderTime", "orderType", "currency", "metadata", "createdAt", "updatedAt", "walletTransactionId"}
+	date := common.Now()
+
+	testCases := []struct {
+		name       string
+		setupMocks func()
+		wantLen    int
+		wantErr    bool
+	}{
+		{
+			name: "happy path - linked and legacy child transaction",
+			setupMocks: func() {
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryTransactionByWalletTransactionID)).
+					WithArgs(walletTransactionId, refNumber).
+					WillReturnRows(sqlmock.NewRows(columns).
+						AddRow(1, "d8b8a1d0-0b7e-4c5b-9a4e-2a1b1c1d1e1f", date, date, "1234567890", "0987654321", "from narrative", "to narrative", 100000, "1", "TUPVA", "TUPVA", "topup", refNumber, date, "TUP", "IDR", "{}", date, date, walletTransactionId).
+						AddRow(2, "e8b8a1d0-0b7e-4c5b-9a4e-2a1b1c1d1e1f", date, date, "0987654321", "1111111111", "from narrative", "to narrative", 1000, "1", "TUPVA", "ADMFE", "fee", refNumber, date, "TUP", "IDR", "{}", date, date, nil),
+					)
+			},
+			wantLen: 2,
+		},
+		{
+			name: "failed - err db",
+			setupMocks: func() {
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryTransactionByWalletTransactionID)).
+					WithArgs(walletTransactionId, refNumber).
+					WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed - err scan",
+			setupMocks: func() {
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryTransactionByWalletTransactionID)).
+					WithArgs(walletTransactionId, refNumber).
+					WillReturnRows(sqlmock.
+						NewRows([]string{"InvalidColumn"}).
+						AddRow(nil),
+					)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		suite.t.Run(tc.name, func(t *testing.T) {
+			tc.setupMocks()
+
+			res, err := suite.repo.GetByWalletTransactionID(context.Background(), walletTransactionId, refNumber)
+			assert.Equal(t, tc.wantErr, err != nil)
+			assert.Len(t, res, tc.wantLen)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func (suite *TransactionTestSuite) Test_TransactionRepository_UpdateStatus() {
 	testCases := []struct {
 		name         string
@@ -849,6 +915,28 @@ func (suite *TransactionTestSuite) Test_TransactionRepository_UpdateStatus() {
 			},
 			wantErr: false,
 		},
+		{
+			name:         "happy path back-dated transaction",
+			rowsAffected: 1,
+			doMock: func(id uint64, status string, rowsAffected int64) {
+				transactionDate := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryUpdateTransactionStatus)).
+					WithArgs(status, id).
+					WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+				suite.mock.ExpectQuery(regexp.QuoteMeta(getByIDQuery)).
+					WithArgs(id).
+					WillReturnRows(sqlmock.NewRows(
+						[]string{"id", "transactionId", "transactionDate", "transactionTime", "fromAccount", "toAccount", "fromNarrative", "toNarrative", "amount", "status", "method", "typeTransaction", "description", "refNumber", "orderTime", "orderType", "currency", "metadata", "createdAt", "updatedAt"}).
+						AddRow(1, "TRX1678947359CiBM08mvRqi0z5fD1VdQng", transactionDate, time.Now(), "1234567890", "0987654321", "from narrative", "to narrative", 100000, "success", "transfer", "debit", "transfer", "FT2303000001", time.Now(), "INV", models.IDRCurrency, "{}", time.Now(), time.Now()),
+					)
+				suite.mock.
+					ExpectExec(regexp.QuoteMeta(queryTransactionMetricInvalidateAggregatedDates)).
+					WithArgs(pq.Array([]string{"2023-02-01"})).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			wantErr: false,
+		},
 		{
 			name:         "failed - no rows affected",
 			rowsAffected: 0,
@@ -888,3 +976,48 @@ func (suite *TransactionTestSuite) Test_TransactionRepository_UpdateStatus() {
 		})
 	}
 }
+
+func Test_buildListTransactionQuery_AdvancedFilter(t *testing.T) {
+	startDate := common.Now()
+	minAmount := decimal.NewFromInt(1000)
+	maxAmount := decimal.NewFromInt(5000)
+
+	query, args, err := buildListTransactionQuery(models.TransactionFilterOptions{
+		StartDate:     &startDate,
+		EndDate:       &startDate,
+		AccountNumber: "189513",
+		Statuses:      []string{"0", "2"},
+		RefNumbers:    []string{"REF-1", "REF-2"},
+		MinAmount:     &minAmount,
+		MaxAmount:     &maxAmount,
+		MetadataFilters: []models.MetadataFilter{
+			{Path: []string{"loanAccountNumber"}, Operator: models.MetadataFilterOperatorEqual, Value: "LA-1"},
+			{Path: []string{"partner", "name"}, Operator: models.MetadataFilterOperatorContains, Value: "50%_off'; DROP TABLE transaction; --"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, query, `(transaction."fromAccount" = $3 OR transaction."toAccount" = $4)`)
+	assert.Contains(t, query, `transaction."status" IN ($5,$6)`)
+	assert.Contains(t, query, `transaction."refNumber" IN ($7,$8)`)
+	assert.Contains(t, query, `transaction."amount" >= $9`)
+	assert.Contains(t, query, `transaction."amount" <= $10`)
+	assert.Contains(t, query, `(transaction."metadata" @> $11::jsonb AND transaction."metadata" #>> $12::text[] ILIKE $13)`)
+	assert.NotContains(t, query, "DROP TABLE")
+
+	assert.Equal(t, []interface{}{
+		&startDate,
+		&startDate,
+		"189513",
+		"189513",
+		"0",
+		"2",
+		"REF-1",
+		"REF-2",
+		"1000",
+		"5000",
+		`{"loanAccountNumber":"LA-1"}`,
+		pq.Array([]string{"partner", "name"}),
+		`%50\%\_off'; DROP TABLE transaction; --%`,
+	}, args)
+}
@@ -8,13 +8,19 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+
+	"github.com/shopspring/decimal"
 )
 
 type WalletTransactionRepository interface {
 	Create(ctx context.Context, in models.NewWalletTransaction) (*models.WalletTransaction, error)
 	GetById(ctx context.Context, id string) (*models.WalletTransaction, error)
+	GetByIdForUpdate(ctx context.Context, id string) (*models.WalletTransaction, error)
 	Update(ctx context.Context, id string, data models.WalletTransactionUpdate) (*models.WalletTransaction, error)
 	GetByRefNumber(ctx context.Context, refNumber string) (*models.WalletTransaction, error)
+	ListByRefNumber(ctx context.Context, refNumber, transactionType string) ([]models.WalletTransaction, error)
+	CreateBalances(ctx context.Context, balances []models.WalletTransactionBalance) error
+	GetBalances(ctx context.Context, walletTransactionId string) ([]models.WalletTransactionBalance, error)
 	CheckTransactionTypeAndReferenceNumber(ctx context.Context, trxType, refNumber string) (*models.WalletTransaction, error)
 	List(ctx context.Context, opts models.WalletTrxFilterOptions) ([]models.WalletTransaction, error)
 	CountAll(ctx context.Context, opts models.WalletTrxFilterOptions) (total int, err error)
@@ -27,8 +33,8 @@ var _ WalletTransactionRepository = (*walletTrxRepo)(nil)
 func (e *walletTrxRepo) Create(ctx context.Context, in models.NewWalletTransaction) (*models.WalletTransaction, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := e.r.extractTxWrite(ctx)
 
@@ -55,6 +61,7 @@ func (e *walletTrxRepo) Create(ctx context.Context, in models.NewWalletTransacti
 			&description,
 			&created.Metadata,
 			&created.CreatedAt,
+			&created.CapturedAmount,
 		)
 	if err != nil {
 		return nil, err
@@ -72,15 +79,30 @@ func (e *walletTrxRepo) Create(ctx context.Context, in models.NewWalletTransacti
 func (e *walletTrxRepo) GetById(ctx context.Context, id string) (*models.WalletTransaction, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	return e.getById(ctx, queryWalletTrxGetByID, id)
+}
+
+// GetByIdForUpdate locks the wallet transaction row until the transaction in ctx is finished,
+// so concurrent capture of the same reservation is processed one by one
+func (e *walletTrxRepo) GetByIdForUpdate(ctx context.Context, id string) (*models.WalletTransaction, error) {
+	var err error
+
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	return e.getById(ctx, queryWalletTrxGetByIDForUpdate, id)
+}
 
+func (e *walletTrxRepo) getById(ctx context.Context, query, id string) (*models.WalletTransaction, error) {
 	db := e.r.extractTxWrite(ctx)
 
 	var destinationAccountNumber, description sql.NullString
 	var wt models.WalletTransaction
 
-	err = db.QueryRowContext(ctx, queryWalletTrxGetByID, id).
+	err := db.QueryRowContext(ctx, query, id).
 		Scan(
 			&wt.ID,
 			&wt.Status,
@@ -95,6 +117,7 @@ func (e *walletTrxRepo) GetById(ctx context.Context, id string) (*models.WalletT
 			&description,
 			&wt.Metadata,
 			&wt.CreatedAt,
+			&wt.CapturedAmount,
 		)
 	if err != nil {
 		return nil, err
@@ -110,8 +133,8 @@ func (e *walletTrxRepo) GetById(ctx context.Context, id string) (*models.WalletT
 }
 
 func (e *walletTrxRepo) Update(ctx context.Context, id string, data models.WalletTransactionUpdate) (res *models.WalletTransaction, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := e.r.extractTxWrite(ctx)
 
@@ -138,6 +161,7 @@ func (e *walletTrxRepo) Update(ctx context.Context, id string, data models.Walle
 			&description,
 			&wt.Metadata,
 			&wt.CreatedAt,
+			&wt.CapturedAmount,
 		)
 	if err != nil {
 		return nil, err
@@ -155,8 +179,8 @@ func (e *walletTrxRepo) Update(ctx context.Context, id string, data models.Walle
 func (e *walletTrxRepo) GetByRefNumber(ctx context.Context, refNumber string) (*models.WalletTransaction, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := e.r.extractTxWrite(ctx)
 
@@ -176,10 +200,103 @@ func (e *walletTrxRepo) GetByRefNumber(ctx context.Context, refNumber string) (*
 	return &created, nil
 }
 
+// ListByRefNumber returns at most two wallet transactions with the refNumber, empty transactionType matches any type,
+// so the caller knows whether the refNumber alone identifies a single wallet transaction
+func (e *walletTrxRepo) ListByRefNumber(ctx context.Context, refNumber, transactionType string) (res []models.WalletTransaction, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := e.r.extractTxWrite(ctx)
+
+	rows, err := db.QueryContext(ctx, queryWalletTrxListByRefNumber, refNumber, transactionType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var wt models.WalletTransaction
+		if err = rows.Scan(&wt.ID, &wt.Status, &wt.TransactionType); err != nil {
+			return nil, err
+		}
+
+		wt.RefNumber = refNumber
+		res = append(res, wt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// CreateBalances stores balance snapshots of accounts changed by a wallet transaction
+func (e *walletTrxRepo) CreateBalances(ctx context.Context, balances []models.WalletTransactionBalance) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	if len(balances) == 0 {
+		return nil
+	}
+
+	db := e.r.extractTxWrite(ctx)
+
+	query, args, err := buildCreateWalletTrxBalances(balances)
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, query, args...)
+
+	return err
+}
+
+// GetBalances returns balance snapshots of a wallet transaction in the order they are stored
+func (e *walletTrxRepo) GetBalances(ctx context.Context, walletTransactionId string) (res []models.WalletTransactionBalance, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := e.r.extractTxWrite(ctx)
+
+	rows, err := db.QueryContext(ctx, queryWalletTrxBalanceGetByWalletTransactionID, walletTransactionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var b models.WalletTransactionBalance
+		var actualBefore, pendingBefore, actualAfter, pendingAfter decimal.Decimal
+		err = rows.Scan(
+			&b.WalletTransactionID,
+			&b.AccountNumber,
+			&actualBefore,
+			&pendingBefore,
+			&actualAfter,
+			&pendingAfter,
+			&b.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		b.Before = models.NewBalance(actualBefore, pendingBefore)
+		b.After = models.NewBalance(actualAfter, pendingAfter)
+		res = append(res, b)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func (e *walletTrxRepo) List(ctx context.Context, opts models.WalletTrxFilterOptions) ([]models.WalletTransaction, error) {
 	var err error
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := e.r.extractTxWrite(ctx)
 
@@ -230,8 +347,8 @@ func (e *walletTrxRepo) List(ctx context.Context, opts models.WalletTrxFilterOpt
 }
 
 func (e *walletTrxRepo) CountAll(ctx context.Context, opts models.WalletTrxFilterOptions) (total int, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := e.r.extractTxWrite(ctx)
 
@@ -248,8 +365,8 @@ func (e *walletTrxRepo) CountAll(ctx context.Context, opts models.WalletTrxFilte
 
 func (e *walletTrxRepo) CheckTransactionTypeAndReferenceNumber(ctx context.Context, trxType, refNumber string) (*models.WalletTransaction, error) {
 	var err error
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	db := e.r.extractTxWrite(ctx)
 
@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+)
+
+type AsyncWalletTransactionRepository interface {
+	Create(ctx context.Context, in *models.AsyncWalletTransaction) (err error)
+	GetByID(ctx context.Context, id string) (result *models.AsyncWalletTransaction, err error)
+
+	// UpdateStatus update status and outcome of the transaction, processed time is set when the status is finished.
+	// Callback status is only updated when it is not empty
+	UpdateStatus(ctx context.Context, in *models.AsyncWalletTransaction) (err error)
+
+	// GetPendingCallbacks return the oldest transactions whose callback is pending and not updated since before
+	GetPendingCallbacks(ctx context.Context, before time.Time, limit int) (result []models.AsyncWalletTransaction, err error)
+
+	// UpdateCallback record the result of the callback and the number of attempts made to deliver it
+	UpdateCallback(ctx context.Context, id, callbackStatus string, attempts int) (err error)
+}
+
+type asyncWalletTrxRepo sqlRepo
+
+var _ AsyncWalletTransactionRepository = (*asyncWalletTrxRepo)(nil)
+
+func (r *asyncWalletTrxRepo) Create(ctx context.Context, in *models.AsyncWalletTransaction) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	return db.QueryRowContext(ctx, queryAsyncWalletTransactionCreate,
+		in.ID,
+		in.ClientId,
+		in.IdempotencyKey,
+		in.RefNumber,
+		in.TransactionType,
+		in.AccountNumber,
+		in.Status,
+	).Scan(&in.CreatedAt, &in.UpdatedAt)
+}
+
+func (r *asyncWalletTrxRepo) GetByID(ctx context.Context, id string) (result *models.AsyncWalletTransaction, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxRead(ctx)
+
+	result = &models.AsyncWalletTransaction{}
+	err = db.QueryRowContext(ctx, queryAsyncWalletTransactionGetByID, id).Scan(scanAsyncWalletTransaction(result)...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.ErrDataNotFound
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *asyncWalletTrxRepo) UpdateStatus(ctx context.Context, in *models.AsyncWalletTransaction) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	err = db.QueryRowContext(ctx, queryAsyncWalletTransactionUpdateStatus,
+		in.ID,
+		in.Status,
+		in.WalletTransactionId,
+		in.ErrorCode,
+		in.ErrorMessage,
+		in.CallbackStatus,
+	).Scan(&in.UpdatedAt, &in.ProcessedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return common.ErrDataNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *asyncWalletTrxRepo) GetPendingCallbacks(ctx context.Context, before time.Time, limit int) (result []models.AsyncWalletTransaction, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxRead(ctx)
+
+	rows, err := db.QueryContext(ctx, queryAsyncWalletTransactionGetPendingCallbacks, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.AsyncWalletTransaction
+		if err = rows.Scan(scanAsyncWalletTransaction(&row)...); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *asyncWalletTrxRepo) UpdateCallback(ctx context.Context, id, callbackStatus string, attempts int) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	db := r.r.extractTxWrite(ctx)
+
+	_, err = db.ExecContext(ctx, queryAsyncWalletTransactionUpdateCallback, id, callbackStatus, attempts)
+	return err
+}
+
+func scanAsyncWalletTransaction(in *models.AsyncWalletTransaction) []any {
+	return []any{
+		&in.ID,
+		&in.ClientId,
+		&in.IdempotencyKey,
+		&in.RefNumber,
+		&in.TransactionType,
+		&in.AccountNumber,
+		&in.Status,
+		&in.WalletTransactionId,
+		&in.ErrorCode,
+		&in.ErrorMessage,
+		&in.CallbackStatus,
+		&in.CallbackAttempts,
+		&in.CreatedAt,
+		&in.UpdatedAt,
+		&in.ProcessedAt,
+	}
+}
@@ -0,0 +1,73 @@
+package repositories
+
+var (
+	queryAsyncWalletTransactionCreate = `
+		INSERT INTO wallet_transaction_async_requests(
+			id, client_id, idempotency_key, ref_number, transaction_type, account_number, status, created_at, updated_at
+		)
+		VALUES(
+			$1, $2, $3, $4, $5, $6, $7, NOW(), NOW()
+		)
+		RETURNING
+			created_at, updated_at;
+	`
+
+	queryAsyncWalletTransactionGetByID = `SELECT
+		  id,
+		  client_id,
+		  idempotency_key,
+		  ref_number,
+		  transaction_type,
+		  account_number,
+		  status,
+		  wallet_transaction_id,
+		  error_code,
+		  error_message,
+		  callback_status,
+		  callback_attempts,
+		  created_at,
+		  updated_at,
+		  processed_at
+		FROM wallet_transaction_async_requests
+		WHERE id = $1;`
+
+	queryAsyncWalletTransactionUpdateStatus = `UPDATE wallet_transaction_async_requests
+		SET
+		  status = $2,
+		  wallet_transaction_id = $3,
+		  error_code = $4,
+		  error_message = $5,
+		  callback_status = CASE WHEN $6::text = '' THEN callback_status ELSE $6 END,
+		  updated_at = NOW(),
+		  processed_at = CASE WHEN $2::text IN ('SUCCEEDED', 'FAILED') THEN NOW() ELSE NULL END
+		WHERE id = $1
+		RETURNING updated_at, processed_at;`
+
+	queryAsyncWalletTransactionGetPendingCallbacks = `SELECT
+		  id,
+		  client_id,
+		  idempotency_key,
+		  ref_number,
+		  transaction_type,
+		  account_number,
+		  status,
+		  wallet_transaction_id,
+		  error_code,
+		  error_message,
+		  callback_status,
+		  callback_attempts,
+		  created_at,
+		  updated_at,
+		  processed_at
+		FROM wallet_transaction_async_requests
+		WHERE callback_status = 'PENDING' AND updated_at < $1
+		ORDER BY updated_at
+		LIMIT $2;`
+
+	queryAsyncWalletTransactionUpdateCallback = `UPDATE wallet_transaction_async_requests
+		SET
+		  callback_status = $2,
+		  callback_attempts = callback_attempts + $3,
+		  updated_at = NOW()
+		WHERE id = $1;`
+)
@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting/mock"
+	mockFlag "bitbucket.org/Amartha/go-fp-transaction/internal/common/flag/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestAsyncWalletTransactionRepositoryTestSuite(t *testing.T) {
+	t.Helper()
+	suite.Run(t, new(asyncWalletTrxRepoTestSuite))
+}
+
+type asyncWalletTrxRepoTestSuite struct {
+	suite.Suite
+	t       *testing.T
+	writeDB *sql.DB
+	mock    sqlmock.Sqlmock
+	repo    AsyncWalletTransactionRepository
+}
+
+func (suite *asyncWalletTrxRepoTestSuite) SetupTest() {
+	var err error
+	var cfg config.Config
+
+	suite.writeDB, suite.mock, err = sqlmock.New()
+	require.NoError(suite.T(), err)
+
+	suite.t = suite.T()
+	mockCtrl := gomock.NewController(suite.t)
+
+	suite.repo = NewSQLRepository(suite.writeDB, suite.writeDB, cfg, mockFlag.NewMockClient(mockCtrl), mock.NewMockClient(mockCtrl)).
+		GetAsyncWalletTransactionRepository()
+}
+
+func (suite *asyncWalletTrxRepoTestSuite) TearDownTest() {
+	suite.writeDB.Close()
+}
+
+func (suite *asyncWalletTrxRepoTestSuite) TestRepository_Create() {
+	in := &models.AsyncWalletTransaction{
+		ID:              "0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e",
+		ClientId:        "async-client",
+		IdempotencyKey:  "idempotency-key",
+		RefNumber:       "REF1",
+		TransactionType: "TUPVA",
+		AccountNumber:   "111",
+		Status:          models.AsyncWalletTransactionStatusQueued,
+	}
+	now := time.Now()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryAsyncWalletTransactionCreate)).
+		WithArgs(in.ID, "async-client", "idempotency-key", "REF1", "TUPVA", "111", models.AsyncWalletTransactionStatusQueued).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(now, now))
+
+	err := suite.repo.Create(context.Background(), in)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, now, in.CreatedAt)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *asyncWalletTrxRepoTestSuite) TestRepository_GetByID() {
+	id := "0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e"
+	now := time.Now()
+	columns := []string{
+		"id", "client_id", "idempotency_key", "ref_number", "transaction_type", "account_number", "status",
+		"wallet_transaction_id", "error_code", "error_message", "callback_status", "callback_attempts",
+		"created_at", "updated_at", "processed_at",
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryAsyncWalletTransactionGetByID)).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(id, "async-client", "idempotency-key", "REF1", "TUPVA", "111", "SUCCEEDED",
+				"41d03147-c017-4176-8a1a-0b7ec735cc29", "", "", "SENT", 2, now, now, now))
+
+	result, err := suite.repo.GetByID(context.Background(), id)
+	assert.NoError(suite.t, err)
+	assert.Equal(suite.t, models.AsyncWalletTransactionStatusSucceeded, result.Status)
+	assert.Equal(suite.t, "41d03147-c017-4176-8a1a-0b7ec735cc29", result.WalletTransactionId)
+	assert.Equal(suite.t, 2, result.CallbackAttempts)
+	assert.NotNil(suite.t, result.ProcessedAt)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryAsyncWalletTransactionGetByID)).
+		WithArgs(id).
+		WillReturnError(sql.ErrNoRows)
+
+	_, err = suite.repo.GetByID(context.Background(), id)
+	assert.ErrorIs(suite.t, err, common.ErrDataNotFound)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *asyncWalletTrxRepoTestSuite) TestRepository_UpdateStatus() {
+	in := &models.AsyncWalletTransaction{
+		ID:             "0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e",
+		Status:         models.AsyncWalletTransactionStatusFailed,
+		ErrorCode:      models.WalletTransactionErrorCodeUnprocessable,
+		ErrorMessage:   "insufficient balance",
+		CallbackStatus: models.AsyncCallbackStatusPending,
+	}
+	now := time.Now()
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryAsyncWalletTransactionUpdateStatus)).
+		WithArgs(in.ID, "FAILED", "", "UNPROCESSABLE_TRANSACTION", "insufficient balance", "PENDING").
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at", "processed_at"}).AddRow(now, now))
+
+	err := suite.repo.UpdateStatus(context.Background(), in)
+	assert.NoError(suite.t, err)
+	assert.NotNil(suite.t, in.ProcessedAt)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryAsyncWalletTransactionUpdateStatus)).
+		WithArgs(in.ID, "FAILED", "", "UNPROCESSABLE_TRANSACTION", "insufficient balance", "PENDING").
+		WillReturnError(sql.ErrNoRows)
+
+	err = suite.repo.UpdateStatus(context.Background(), in)
+	assert.ErrorIs(suite.t, err, common.ErrDataNotFound)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *asyncWalletTrxRepoTestSuite) TestRepository_GetPendingCallbacks() {
+	now := time.Now()
+	columns := []string{
+		"id", "client_id", "idempotency_key", "ref_number", "transaction_type", "account_number", "status",
+		"wallet_transaction_id", "error_code", "error_message", "callback_status", "callback_attempts",
+		"created_at", "updated_at", "processed_at",
+	}
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryAsyncWalletTransactionGetPendingCallbacks)).
+		WithArgs(now, 100).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e", "async-client", "idempotency-key", "REF1", "TUPVA", "111", "SUCCEEDED",
+				"41d03147-c017-4176-8a1a-0b7ec735cc29", "", "", "PENDING", 0, now, now, now).
+			AddRow("5a3b7ef0-58a4-4c43-9a39-5c0c55b0f1a2", "async-client", "idempotency-key-2", "REF2", "TUPVA", "111", "FAILED",
+				"", "UNPROCESSABLE_TRANSACTION", "insufficient balance", "PENDING", 3, now, now, now))
+
+	result, err := suite.repo.GetPendingCallbacks(context.Background(), now, 100)
+	assert.NoError(suite.t, err)
+	assert.Len(suite.t, result, 2)
+	assert.Equal(suite.t, "REF1", result[0].RefNumber)
+	assert.Equal(suite.t, 3, result[1].CallbackAttempts)
+
+	suite.mock.
+		ExpectQuery(regexp.QuoteMeta(queryAsyncWalletTransactionGetPendingCallbacks)).
+		WithArgs(now, 100).
+		WillReturnError(assert.AnError)
+
+	_, err = suite.repo.GetPendingCallbacks(context.Background(), now, 100)
+	assert.ErrorIs(suite.t, err, assert.AnError)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
+
+func (suite *asyncWalletTrxRepoTestSuite) TestRepository_UpdateCallback() {
+	suite.mock.
+		ExpectExec(regexp.QuoteMeta(queryAsyncWalletTransactionUpdateCallback)).
+		WithArgs("0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e", models.AsyncCallbackStatusSent, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := suite.repo.UpdateCallback(context.Background(), "0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e", models.AsyncCallbackStatusSent, 1)
+	assert.NoError(suite.t, err)
+	assert.NoError(suite.t, suite.mock.ExpectationsWereMet())
+}
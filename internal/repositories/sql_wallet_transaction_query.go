@@ -24,7 +24,7 @@ var (
 			"id", "status", "accountNumber", "destinationAccountNumber", "refNumber", 
 			"transactionType", "transactionTime", "transactionFlow",
 			"netAmount", "breakdownAmounts",
-			"description", "metadata", "createdAt";
+			"description", "metadata", "createdAt", "capturedAmount";
 	`
 
 	queryWalletTrxGetByID = `
@@ -32,11 +32,22 @@ var (
 			"id", "status", "accountNumber", "destinationAccountNumber", "refNumber", 
 			"transactionType", "transactionTime", "transactionFlow",
 			"netAmount", "breakdownAmounts",
-			"description", "metadata", "createdAt"
+			"description", "metadata", "createdAt", "capturedAmount"
 		FROM "wallet_transaction"
 		WHERE "id" = $1;
 	`
 
+	queryWalletTrxGetByIDForUpdate = `
+		SELECT
+			"id", "status", "accountNumber", "destinationAccountNumber", "refNumber", 
+			"transactionType", "transactionTime", "transactionFlow",
+			"netAmount", "breakdownAmounts",
+			"description", "metadata", "createdAt", "capturedAmount"
+		FROM "wallet_transaction"
+		WHERE "id" = $1
+		FOR UPDATE;
+	`
+
 	queryWalletTrxUpdateStatus = `
 		UPDATE "wallet_transaction"
 		SET "status" = $2, "updatedAt" = now()
@@ -50,6 +61,27 @@ var (
 
 	queryWalletTrxGetByRefNumber = `SELECT "id", "status" FROM "wallet_transaction" w WHERE w."refNumber" = $1;`
 
+	// at most two rows are needed to know whether the refNumber is ambiguous
+	queryWalletTrxListByRefNumber = `
+		SELECT "id", "status", "transactionType"
+		FROM "wallet_transaction"
+		WHERE "refNumber" = $1
+			AND ($2 = '' OR "transactionType" = $2)
+		ORDER BY "createdAt" DESC
+		LIMIT 2;
+	`
+
+	queryWalletTrxBalanceGetByWalletTransactionID = `
+		SELECT
+			"walletTransactionId", "accountNumber",
+			"actualBalanceBefore", "pendingBalanceBefore",
+			"actualBalanceAfter", "pendingBalanceAfter",
+			"createdAt"
+		FROM "wallet_transaction_balance"
+		WHERE "walletTransactionId" = $1
+		ORDER BY "id" ASC;
+	`
+
 	queryWalletTrxGetByTransactionTypeAndRefNumber = `SELECT
 			"id",
 			"accountNumber",
@@ -197,6 +229,26 @@ func buildFilteredWalletTrxQuery(cols []string, opts models.WalletTrxFilterOptio
 		query = query.Where(sq.Eq{`"refNumber"`: opts.RefNumber})
 	}
 
+	if len(opts.RefNumbers) > 0 {
+		query = query.Where(sq.Eq{`"refNumber"`: opts.RefNumbers})
+	}
+
+	if len(opts.Statuses) > 0 {
+		query = query.Where(sq.Eq{`"status"`: opts.Statuses})
+	}
+
+	if opts.MinAmount != nil {
+		query = query.Where(sq.GtOrEq{`"netAmount"`: *opts.MinAmount})
+	}
+
+	if opts.MaxAmount != nil {
+		query = query.Where(sq.LtOrEq{`"netAmount"`: *opts.MaxAmount})
+	}
+
+	if len(opts.MetadataFilters) > 0 {
+		query = query.Where(buildMetadataFilter(`wallet_transaction."metadata"`, opts.MetadataFilters))
+	}
+
 	if len(opts.AccountNumbers) > 0 {
 		accountQuery := query.Where(sq.Eq{`wallet_transaction."accountNumber"`: opts.AccountNumbers})
 		destinationQuery := query.Where(sq.Eq{`wallet_transaction."destinationAccountNumber"`: opts.AccountNumbers})
@@ -209,6 +261,28 @@ func buildFilteredWalletTrxQuery(cols []string, opts models.WalletTrxFilterOptio
 	return query
 }
 
+func buildCreateWalletTrxBalances(balances []models.WalletTransactionBalance) (sql string, args []interface{}, err error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	query := psql.
+		Insert("wallet_transaction_balance").
+		Columns(
+			`"walletTransactionId"`, `"accountNumber"`,
+			`"actualBalanceBefore"`, `"pendingBalanceBefore"`,
+			`"actualBalanceAfter"`, `"pendingBalanceAfter"`,
+		)
+
+	for _, b := range balances {
+		query = query.Values(
+			b.WalletTransactionID, b.AccountNumber,
+			b.Before.Actual(), b.Before.Pending(),
+			b.After.Actual(), b.After.Pending(),
+		)
+	}
+
+	return query.ToSql()
+}
+
 func buildUpdateWalletTrx(id string, data models.WalletTransactionUpdate) (sql string, args []interface{}, err error) {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
@@ -228,11 +302,23 @@ func buildUpdateWalletTrx(id string, data models.WalletTransactionUpdate) (sql s
 		query = query.Set(`"metadata"`, data.Metadata)
 	}
 
+	if data.NetAmount != nil {
+		query = query.Set(`"netAmount"`, data.NetAmount)
+	}
+
+	if data.Amounts != nil {
+		query = query.Set(`"breakdownAmounts"`, data.Amounts)
+	}
+
+	if data.CapturedAmount != nil {
+		query = query.Set(`"capturedAmount"`, data.CapturedAmount)
+	}
+
 	query = query.Suffix(`RETURNING
 			"id", "status", "accountNumber", "destinationAccountNumber", "refNumber", 
 			"transactionType", "transactionTime", "transactionFlow",
 			"netAmount", "breakdownAmounts",
-			"description", "metadata", "createdAt"`)
+			"description", "metadata", "createdAt", "capturedAmount"`)
 
 	return query.ToSql()
 }
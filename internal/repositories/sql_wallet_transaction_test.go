@@ -93,13 +93,13 @@ func (suite *walletTransactionTestSuite) TestRepository_Create() {
 						"id", "status", "accountNumber", "destinationAccountNumber", "refNumber",
 						"transactionType", "transactionTime", "transactionFlow",
 						"netAmount", "breakdownAmounts",
-						"description", "metadata", "createdAt",
+						"description", "metadata", "createdAt", "capturedAmount",
 					}).
 					AddRow(
 						id, "PENDING", "666", "999", "ref_123",
 						"DSBAB", time.Now(), "cashin",
 						100, "[]",
-						"desc", "{}", time.Now(),
+						"desc", "{}", time.Now(), 0,
 					)
 				suite.mock.ExpectQuery(regexp.QuoteMeta(queryWalletTrxCreate)).WillReturnRows(rows)
 			},
@@ -162,13 +162,13 @@ func (suite *walletTransactionTestSuite) TestRepository_GetById() {
 						"id", "status", "accountNumber", "destinationAccountNumber", "refNumber",
 						"transactionType", "transactionTime", "transactionFlow",
 						"netAmount", "breakdownAmounts",
-						"description", "metadata", "createdAt",
+						"description", "metadata", "createdAt", "capturedAmount",
 					}).
 					AddRow(
 						"123123", "PENDING", "666", "999", "ref_123",
 						"DSBAB", ct, "cashin",
 						100, "[]",
-						"desc", "{}", ct,
+						"desc", "{}", ct, 0,
 					)
 				suite.mock.ExpectQuery(regexp.QuoteMeta(queryWalletTrxGetByID)).WillReturnRows(rows)
 			},
@@ -222,6 +222,86 @@ func (suite *walletTransactionTestSuite) TestRepository_GetById() {
 	}
 }
 
+func (suite *walletTransactionTestSuite) TestRepository_GetByIdForUpdate() {
+	ct := time.Now()
+
+	testCases := []struct {
+		name       string
+		args       string
+		setupMocks func()
+		wantErr    bool
+		wantData   *models.WalletTransaction
+	}{
+		{
+			name: "success get partially captured transaction",
+			args: "123123",
+			setupMocks: func() {
+				rows := sqlmock.
+					NewRows([]string{
+						"id", "status", "accountNumber", "destinationAccountNumber", "refNumber",
+						"transactionType", "transactionTime", "transactionFlow",
+						"netAmount", "breakdownAmounts",
+						"description", "metadata", "createdAt", "capturedAmount",
+					}).
+					AddRow(
+						"123123", "PENDING", "666", "999", "ref_123",
+						"DSBAB", ct, "cashout",
+						100, "[]",
+						"desc", "{}", ct, 40,
+					)
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryWalletTrxGetByIDForUpdate)).WithArgs("123123").WillReturnRows(rows)
+			},
+			wantData: &models.WalletTransaction{
+				ID:                       "123123",
+				Status:                   "PENDING",
+				AccountNumber:            "666",
+				DestinationAccountNumber: "999",
+				RefNumber:                "ref_123",
+				TransactionType:          "DSBAB",
+				TransactionTime:          ct,
+				TransactionFlow:          "cashout",
+				NetAmount: models.Amount{
+					ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(100)),
+					Currency:     models.IDRCurrency,
+				},
+				Amounts:        models.Amounts{},
+				Description:    "desc",
+				Metadata:       models.WalletMetadata{},
+				CreatedAt:      ct,
+				CapturedAmount: decimal.NewFromInt(40),
+			},
+			wantErr: false,
+		},
+		{
+			name: "failed - err sql",
+			args: "123123",
+			setupMocks: func() {
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryWalletTrxGetByIDForUpdate)).WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			if tt.setupMocks != nil {
+				tt.setupMocks()
+			}
+
+			actual, err := suite.repo.GetByIdForUpdate(context.Background(), tt.args)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if !cmp.Equal(tt.wantData, actual) {
+				t.Errorf("Result and Expected differ: (-got +want)\n%s", cmp.Diff(tt.wantData, actual))
+			}
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func (suite *walletTransactionTestSuite) TestRepository_Update() {
 	ct := time.Now()
 	statusSuccess := models.WalletTransactionStatusSuccess
@@ -230,7 +310,7 @@ func (suite *walletTransactionTestSuite) TestRepository_Update() {
 			"id", "status", "accountNumber", "destinationAccountNumber", "refNumber", 
 			"transactionType", "transactionTime", "transactionFlow",
 			"netAmount", "breakdownAmounts",
-			"description", "metadata", "createdAt"`
+			"description", "metadata", "createdAt", "capturedAmount"`
 
 	type args struct {
 		id   string
@@ -260,13 +340,13 @@ func (suite *walletTransactionTestSuite) TestRepository_Update() {
 						"id", "status", "accountNumber", "destinationAccountNumber", "refNumber",
 						"transactionType", "transactionTime", "transactionFlow",
 						"netAmount", "breakdownAmounts",
-						"description", "metadata", "createdAt",
+						"description", "metadata", "createdAt", "capturedAmount",
 					}).
 					AddRow(
 						"123123", "SUCCESS", "666", "999", "ref_123",
 						"DSBAB", ct, "cashin",
 						100, "[]",
-						"desc", "{}", ct,
+						"desc", "{}", ct, 0,
 					)
 
 				suite.mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(rows)
@@ -376,6 +456,184 @@ func (suite *walletTransactionTestSuite) TestRepository_GetByRefNumber() {
 	}
 }
 
+func (suite *walletTransactionTestSuite) TestRepository_ListByRefNumber() {
+	testCases := []struct {
+		name            string
+		transactionType string
+		setupMocks      func(transactionType string)
+		wantLen         int
+		wantErr         bool
+	}{
+		{
+			name: "happy path - refNumber is used by more than one transaction type",
+			setupMocks: func(transactionType string) {
+				rows := sqlmock.NewRows([]string{"id", "status", "transactionType"}).
+					AddRow("1", "SUCCESS", "TUPVA").
+					AddRow("2", "SUCCESS", "RFDVA")
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryWalletTrxListByRefNumber)).
+					WithArgs("refNumber", transactionType).
+					WillReturnRows(rows)
+			},
+			wantLen: 2,
+		},
+		{
+			name:            "happy path - filtered by transaction type",
+			transactionType: "TUPVA",
+			setupMocks: func(transactionType string) {
+				rows := sqlmock.NewRows([]string{"id", "status", "transactionType"}).AddRow("1", "SUCCESS", "TUPVA")
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryWalletTrxListByRefNumber)).
+					WithArgs("refNumber", transactionType).
+					WillReturnRows(rows)
+			},
+			wantLen: 1,
+		},
+		{
+			name: "failed - err sql",
+			setupMocks: func(transactionType string) {
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryWalletTrxListByRefNumber)).WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks(tt.transactionType)
+
+			res, err := suite.repo.ListByRefNumber(context.Background(), "refNumber", tt.transactionType)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Len(t, res, tt.wantLen)
+			for _, wt := range res {
+				assert.Equal(t, "refNumber", wt.RefNumber)
+			}
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *walletTransactionTestSuite) TestRepository_CreateBalances() {
+	balances := []models.WalletTransactionBalance{
+		{
+			WalletTransactionID: "trx-1",
+			AccountNumber:       "111",
+			Before:              models.NewBalance(decimal.NewFromInt(100), decimal.Zero),
+			After:               models.NewBalance(decimal.NewFromInt(90), decimal.Zero),
+		},
+		{
+			WalletTransactionID: "trx-1",
+			AccountNumber:       "222",
+			Before:              models.NewBalance(decimal.Zero, decimal.Zero),
+			After:               models.NewBalance(decimal.NewFromInt(10), decimal.Zero),
+		},
+	}
+	query, _, err := buildCreateWalletTrxBalances(balances)
+	require.NoError(suite.t, err)
+
+	testCases := []struct {
+		name       string
+		balances   []models.WalletTransactionBalance
+		setupMocks func()
+		wantErr    bool
+	}{
+		{
+			name:     "happy path",
+			balances: balances,
+			setupMocks: func() {
+				suite.mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(
+						"trx-1", "111", decimal.NewFromInt(100), decimal.Zero, decimal.NewFromInt(90), decimal.Zero,
+						"trx-1", "222", decimal.Zero, decimal.Zero, decimal.NewFromInt(10), decimal.Zero,
+					).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name: "happy path - nothing to store",
+		},
+		{
+			name:     "failed - err sql",
+			balances: balances,
+			setupMocks: func() {
+				suite.mock.ExpectExec(regexp.QuoteMeta(query)).WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			if tt.setupMocks != nil {
+				tt.setupMocks()
+			}
+
+			err := suite.repo.CreateBalances(context.Background(), tt.balances)
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+func (suite *walletTransactionTestSuite) TestRepository_GetBalances() {
+	createdAt := time.Date(2025, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		setupMocks func()
+		wantErr    bool
+	}{
+		{
+			name: "happy path",
+			setupMocks: func() {
+				rows := sqlmock.NewRows([]string{
+					"walletTransactionId", "accountNumber",
+					"actualBalanceBefore", "pendingBalanceBefore",
+					"actualBalanceAfter", "pendingBalanceAfter",
+					"createdAt",
+				}).AddRow("trx-1", "111", "100", "0", "90", "0", createdAt)
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryWalletTrxBalanceGetByWalletTransactionID)).
+					WithArgs("trx-1").
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "failed - err sql",
+			setupMocks: func() {
+				suite.mock.ExpectQuery(regexp.QuoteMeta(queryWalletTrxBalanceGetByWalletTransactionID)).WillReturnError(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range testCases {
+		tt := tt
+		suite.t.Run(tt.name, func(t *testing.T) {
+			tt.setupMocks()
+
+			res, err := suite.repo.GetBalances(context.Background(), "trx-1")
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				if assert.Len(t, res, 1) {
+					assert.Equal(t, "111", res[0].AccountNumber)
+					assert.True(t, decimal.NewFromInt(100).Equal(res[0].Before.Actual()))
+					assert.True(t, decimal.NewFromInt(90).Equal(res[0].After.Actual()))
+					assert.Equal(t, createdAt, res[0].CreatedAt)
+				}
+			}
+
+			if err = suite.mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
 func (suite *walletTransactionTestSuite) TestRepository_List() {
 	defaultColumns := []string{
 		"id", "status", "accountNumber",
@@ -614,3 +872,24 @@ func (suite *walletTransactionTestSuite) TestRepository_CountAll() {
 		})
 	}
 }
+
+func Test_buildListWalletTrxQuery_AdvancedFilter(t *testing.T) {
+	minAmount := decimal.NewFromInt(1000)
+
+	query, args, err := buildListWalletTrxQuery(models.WalletTrxFilterOptions{
+		AccountNumber: "21100100000001",
+		Statuses:      []string{"PENDING"},
+		RefNumbers:    []string{"REF-1", "REF-2"},
+		MinAmount:     &minAmount,
+		MetadataFilters: []models.MetadataFilter{
+			{Path: []string{"loanAccountNumber"}, Operator: models.MetadataFilterOperatorEqual, Value: "LA-1"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, query, `"refNumber" IN ($2,$3)`)
+	assert.Contains(t, query, `"status" IN ($4)`)
+	assert.Contains(t, query, `"netAmount" >= $5`)
+	assert.Contains(t, query, `wallet_transaction."metadata" @> $6::jsonb`)
+	assert.Equal(t, []interface{}{"21100100000001", "REF-1", "REF-2", "PENDING", "1000", `{"loanAccountNumber":"LA-1"}`}, args[:6])
+}
@@ -34,8 +34,8 @@ type account service
 var _ AccountService = (*account)(nil)
 
 func (as *account) Create(ctx context.Context, in models.CreateAccount) (out models.CreateAccount, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	in.IsHVT = slices.Contains(as.srv.conf.AccountConfig.HVTSubCategoryCodes, in.SubCategoryCode)
 
@@ -50,8 +50,8 @@ func (as *account) Create(ctx context.Context, in models.CreateAccount) (out mod
 }
 
 func (as *account) GetList(ctx context.Context, opts models.AccountFilterOptions) (accounts []models.GetAccountOut, total int, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	accRepo := as.srv.sqlRepo.GetAccountRepository()
 
@@ -74,8 +74,8 @@ func (as *account) GetList(ctx context.Context, opts models.AccountFilterOptions
 
 // GetOneByAccountNumber will search account by it's account number then parse it to AccountResponse.
 func (as *account) GetOneByAccountNumber(ctx context.Context, accountNumber string) (result models.GetAccountOut, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	result, err = as.srv.sqlRepo.GetAccountRepository().GetOneByAccountNumber(ctx, accountNumber)
 	if err != nil {
@@ -87,8 +87,8 @@ func (as *account) GetOneByAccountNumber(ctx context.Context, accountNumber stri
 }
 
 func (as *account) Upsert(ctx context.Context, in models.AccountUpsert) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	if in.Status == "" {
 		in.Status = common.MapAccountStatus[common.ACCOUNT_STATUS_ACTIVE]
@@ -107,8 +107,8 @@ func (as *account) Upsert(ctx context.Context, in models.AccountUpsert) (err err
 func (as *account) GetTotalBalance(ctx context.Context, opts models.AccountFilterOptions) (*decimal.Decimal, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	accRepo := as.srv.sqlRepo.GetAccountRepository()
 	totalBalance, err := accRepo.GetTotalBalance(ctx, opts)
@@ -120,8 +120,8 @@ func (as *account) GetTotalBalance(ctx context.Context, opts models.AccountFilte
 }
 
 func (as *account) GetACuanAccountNumber(ctx context.Context, accountNumber string) (updatedAccountNumber string, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	accRepo := as.srv.sqlRepo.GetAccountRepository()
 
@@ -144,8 +144,8 @@ func (as *account) GetACuanAccountNumber(ctx context.Context, accountNumber stri
 
 // GetOneByAccountNumber will search account by it's account number then parse it to AccountResponse.
 func (as *account) GetOneByAccountNumberOrLegacyId(ctx context.Context, accountNumber string) (result models.GetAccountOut, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	result, err = as.srv.sqlRepo.GetAccountRepository().GetOneByAccountNumberOrLegacyId(ctx, accountNumber)
 	if err != nil {
@@ -158,8 +158,8 @@ func (as *account) GetOneByAccountNumberOrLegacyId(ctx context.Context, accountN
 
 // Update will search account by accountNumber then update the data.
 func (as *account) Update(ctx context.Context, in models.UpdateAccountIn) (current models.GetAccountOut, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	err = as.srv.sqlRepo.Atomic(ctx, func(ctx context.Context, r repositories.SQLRepository) error {
 		accRepo := r.GetAccountRepository()
@@ -204,8 +204,8 @@ func (as *account) Update(ctx context.Context, in models.UpdateAccountIn) (curre
 // if accountNumber is registered, get the second account by 1st account's legacyID.
 // And if legacyID account found, delete it.
 func (as *account) RemoveDuplicateAccountMigration(ctx context.Context, accountNumber string) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	accRepo := as.srv.sqlRepo.GetAccountRepository()
 
@@ -273,8 +273,8 @@ func (as *account) RemoveDuplicateAccountMigration(ctx context.Context, accountN
 }
 
 func (as *account) UpdateBySubCategory(ctx context.Context, in models.UpdateAccountBySubCategoryIn) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	accRepo := as.srv.sqlRepo.GetAccountRepository()
 
@@ -287,8 +287,8 @@ func (as *account) UpdateBySubCategory(ctx context.Context, in models.UpdateAcco
 }
 
 func (as *account) Delete(ctx context.Context, accountNumber string) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	accRepo := as.srv.sqlRepo.GetAccountRepository()
 
@@ -0,0 +1,522 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"slices"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+)
+
+const (
+	logMessageApproval = "[APPROVAL]"
+
+	defaultApprovalExpiryTime = 24 * time.Hour
+
+	// approvalExecutionGracePeriod is the time approved approval is left to the approve request,
+	// approval still approved after the period is executed by the job
+	approvalExecutionGracePeriod = time.Minute
+	approvalExecutionBatchSize   = 100
+)
+
+type ApprovalService interface {
+	// IsRequired return true if the operation has to be approved by another user before executed
+	IsRequired(operation models.ApprovalOperation) bool
+	Submit(ctx context.Context, req models.SubmitApprovalRequest) (approval *models.Approval, err error)
+	List(ctx context.Context, opts models.ApprovalFilterOptions) (approvals []models.Approval, total int, err error)
+	GetByID(ctx context.Context, id uint64) (approval *models.Approval, err error)
+	Approve(ctx context.Context, req models.DecideApprovalRequest) (approval *models.Approval, err error)
+	Reject(ctx context.Context, req models.DecideApprovalRequest) (approval *models.Approval, err error)
+	Comment(ctx context.Context, req models.CommentApprovalRequest) (activity *models.ApprovalActivity, err error)
+	ExpirePending(ctx context.Context, now time.Time) (expired int, err error)
+
+	// ExecuteApproved execute approved approvals which are not executed by the approve request e.g. the service
+	// is stopped after the decision is committed, it is run by job
+	ExecuteApproved(ctx context.Context, now time.Time) (executed int, err error)
+}
+
+type approval service
+
+var _ ApprovalService = (*approval)(nil)
+
+func (s *approval) IsRequired(operation models.ApprovalOperation) bool {
+	return slices.Contains(s.srv.conf.Approval.RequiredOperations, string(operation))
+}
+
+// Submit store the operation as pending approval, the operation is not executed until it is approved
+func (s *approval) Submit(ctx context.Context, req models.SubmitApprovalRequest) (approval *models.Approval, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	payload, err := json.Marshal(req.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	expiryTime := s.srv.conf.Approval.ExpiryTime
+	if expiryTime <= 0 {
+		expiryTime = defaultApprovalExpiryTime
+	}
+
+	approval = &models.Approval{
+		Operation:     req.Operation,
+		Payload:       payload,
+		Description:   req.Description,
+		State:         models.ApprovalStatePending,
+		Maker:         req.Maker,
+		MakerClientID: req.ClientID,
+		ExpiresAt:     common.Now().Add(expiryTime),
+	}
+
+	err = s.srv.sqlRepo.Atomic(ctx, func(actx context.Context, r repositories.SQLRepository) error {
+		repo := r.GetApprovalRepository()
+
+		if err := repo.Create(actx, approval); err != nil {
+			return err
+		}
+
+		return repo.CreateActivity(actx, &models.ApprovalActivity{
+			ApprovalID: approval.ID,
+			Action:     models.ApprovalActionSubmit,
+			Actor:      req.Maker,
+			ClientID:   req.ClientID,
+			ToState:    models.ApprovalStatePending,
+			Comment:    req.Description,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	xlog.Info(ctx, logMessageApproval,
+		xlog.String("operation", "submit"),
+		xlog.Uint64("approval_id", approval.ID),
+		xlog.String("approval_operation", string(approval.Operation)),
+		xlog.String("maker", approval.Maker))
+
+	return approval, nil
+}
+
+func (s *approval) List(ctx context.Context, opts models.ApprovalFilterOptions) (approvals []models.Approval, total int, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	repo := s.srv.sqlRepo.GetApprovalRepository()
+
+	approvals, err = repo.GetList(ctx, opts)
+	if err != nil {
+		return approvals, total, err
+	}
+
+	total, err = repo.CountAll(ctx, opts)
+	if err != nil {
+		return
+	}
+
+	return approvals, total, nil
+}
+
+// GetByID return approval with its activities as audit trail
+func (s *approval) GetByID(ctx context.Context, id uint64) (approval *models.Approval, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	repo := s.srv.sqlRepo.GetApprovalRepository()
+
+	approval, err = repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	approval.Activities, err = repo.ListActivities(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return approval, nil
+}
+
+// Approve decide the approval then execute the operation with the stored payload.
+// Failure of the operation does not revert the decision, the approval is marked as failed with the error instead.
+// Approval which can not be claimed for execution stays approved and it is executed by ExecuteApproved
+func (s *approval) Approve(ctx context.Context, req models.DecideApprovalRequest) (approval *models.Approval, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	approval, err = s.decide(ctx, req, models.ApprovalStateApproved, models.ApprovalActionApprove)
+	if err != nil {
+		return nil, err
+	}
+
+	if errRun := s.run(ctx, approval, true); errRun != nil {
+		xlog.Warn(ctx, logMessageApproval, xlog.Uint64("approval_id", approval.ID), xlog.Err(fmt.Errorf("failed to claim approval: %w", errRun)))
+	}
+
+	return approval, nil
+}
+
+func (s *approval) Reject(ctx context.Context, req models.DecideApprovalRequest) (approval *models.Approval, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	return s.decide(ctx, req, models.ApprovalStateRejected, models.ApprovalActionReject)
+}
+
+// Comment add note to approval, comment is still allowed after approval is decided
+func (s *approval) Comment(ctx context.Context, req models.CommentApprovalRequest) (activity *models.ApprovalActivity, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	repo := s.srv.sqlRepo.GetApprovalRepository()
+
+	a, err := repo.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	activity = &models.ApprovalActivity{
+		ApprovalID: a.ID,
+		Action:     models.ApprovalActionComment,
+		Actor:      req.Actor,
+		ClientID:   req.ClientID,
+		FromState:  a.State,
+		ToState:    a.State,
+		Comment:    req.Comment,
+	}
+	if err = repo.CreateActivity(ctx, activity); err != nil {
+		return nil, err
+	}
+
+	return activity, nil
+}
+
+// ExpirePending mark every pending approval which is already expired, it is run by job
+// so expired approvals do not stay pending in the list
+func (s *approval) ExpirePending(ctx context.Context, now time.Time) (expired int, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	err = s.srv.sqlRepo.Atomic(ctx, func(actx context.Context, r repositories.SQLRepository) error {
+		repo := r.GetApprovalRepository()
+
+		ids, err := repo.ExpirePending(actx, now)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			err = repo.CreateActivity(actx, &models.ApprovalActivity{
+				ApprovalID: id,
+				Action:     models.ApprovalActionExpire,
+				Actor:      models.ApprovalSystemActor,
+				FromState:  models.ApprovalStatePending,
+				ToState:    models.ApprovalStateExpired,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		expired = len(ids)
+		return nil
+	})
+
+	return expired, err
+}
+
+func (s *approval) ExecuteApproved(ctx context.Context, now time.Time) (executed int, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	repo := s.srv.sqlRepo.GetApprovalRepository()
+	decidedBefore := now.Add(-approvalExecutionGracePeriod)
+
+	var afterID uint64
+	for {
+		var approvals []models.Approval
+		approvals, err = repo.GetList(ctx, models.ApprovalFilterOptions{
+			State:          models.ApprovalStateApproved,
+			Limit:          approvalExecutionBatchSize,
+			AscendingOrder: true,
+			AfterID:        afterID,
+		})
+		if err != nil {
+			return executed, err
+		}
+
+		for i := range approvals {
+			a := &approvals[i]
+			afterID = a.ID
+
+			if a.DecidedAt != nil && a.DecidedAt.After(decidedBefore) {
+				continue
+			}
+
+			// the job exits once it is returned, so upload is not run in background
+			if errRun := s.run(ctx, a, false); errRun != nil {
+				// claimed by the approve request or another job
+				if errors.Is(errRun, common.ErrApprovalNotPending) {
+					continue
+				}
+				return executed, errRun
+			}
+			executed++
+		}
+
+		if len(approvals) < approvalExecutionBatchSize {
+			return executed, nil
+		}
+	}
+}
+
+// decide move pending approval to the next state, maker can not decide its own approval.
+// The actor is only a verified user when the client is trusted to assert it, otherwise it is the client itself,
+// so a client can only decide approval submitted by itself when both maker and checker are verified users
+func (s *approval) decide(ctx context.Context, req models.DecideApprovalRequest, nextState models.ApprovalState, action models.ApprovalAction) (*models.Approval, error) {
+	repo := s.srv.sqlRepo.GetApprovalRepository()
+
+	a, err := repo.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.State != models.ApprovalStatePending {
+		return nil, common.ErrApprovalNotPending
+	}
+
+	now := common.Now()
+	if a.IsExpired(now) {
+		s.expire(ctx, a)
+		return nil, common.ErrApprovalExpired
+	}
+
+	sameClient := req.ClientID == a.MakerClientID
+	if req.Actor == a.Maker || sameClient && (req.Actor == req.ClientID || a.Maker == a.MakerClientID) {
+		return nil, common.ErrApprovalSameActor
+	}
+
+	a.State = nextState
+	a.Checker = req.Actor
+	a.CheckerClientID = req.ClientID
+	a.DecidedAt = &now
+
+	err = s.transition(ctx, a, models.ApprovalStatePending, models.ApprovalActivity{
+		Action:   action,
+		Actor:    req.Actor,
+		ClientID: req.ClientID,
+		Comment:  req.Comment,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	xlog.Info(ctx, logMessageApproval,
+		xlog.String("operation", string(action)),
+		xlog.Uint64("approval_id", a.ID),
+		xlog.String("approval_operation", string(a.Operation)),
+		xlog.String("checker", a.Checker))
+
+	return a, nil
+}
+
+// run claim the approved approval as executing then execute its operation, error is returned when
+// the approval can not be claimed. Result of the operation is recorded in the approval instead
+func (s *approval) run(ctx context.Context, a *models.Approval, background bool) error {
+	a.State = models.ApprovalStateExecuting
+
+	err := s.transition(ctx, a, models.ApprovalStateApproved, models.ApprovalActivity{
+		Action:   models.ApprovalActionExecute,
+		Actor:    models.ApprovalSystemActor,
+		ClientID: a.CheckerClientID,
+	})
+	if err != nil {
+		a.State = models.ApprovalStateApproved
+		return err
+	}
+
+	startBackground, errExecute := s.execute(ctx, a, background)
+	if errExecute != nil {
+		s.markFailed(ctx, a, models.ApprovalStateExecuting, errExecute)
+		return nil
+	}
+
+	a.State = models.ApprovalStateExecuted
+	err = s.transition(ctx, a, models.ApprovalStateExecuting, models.ApprovalActivity{
+		Action:   models.ApprovalActionExecute,
+		Actor:    models.ApprovalSystemActor,
+		ClientID: a.CheckerClientID,
+	})
+	if err != nil {
+		a.State = models.ApprovalStateExecuting
+		xlog.Warn(ctx, logMessageApproval, xlog.Uint64("approval_id", a.ID), xlog.Err(fmt.Errorf("failed to mark approval as executed: %w", err)))
+	}
+
+	// background operation is started once the state is stored, so its failure moves the approval from that state
+	if startBackground != nil {
+		startBackground()
+	}
+
+	return nil
+}
+
+// transition update the state of approval and record the activity in the same transaction
+func (s *approval) transition(ctx context.Context, a *models.Approval, fromState models.ApprovalState, activity models.ApprovalActivity) error {
+	return s.srv.sqlRepo.Atomic(ctx, func(actx context.Context, r repositories.SQLRepository) error {
+		repo := r.GetApprovalRepository()
+
+		if err := repo.UpdateState(actx, a, fromState); err != nil {
+			if errors.Is(err, common.ErrNoRowsAffected) {
+				return common.ErrApprovalNotPending
+			}
+			return err
+		}
+
+		activity.ApprovalID = a.ID
+		activity.FromState = fromState
+		activity.ToState = a.State
+
+		return repo.CreateActivity(actx, &activity)
+	})
+}
+
+func (s *approval) expire(ctx context.Context, a *models.Approval) {
+	a.State = models.ApprovalStateExpired
+
+	err := s.transition(ctx, a, models.ApprovalStatePending, models.ApprovalActivity{
+		Action: models.ApprovalActionExpire,
+		Actor:  models.ApprovalSystemActor,
+	})
+	if err != nil {
+		xlog.Warn(ctx, logMessageApproval, xlog.Uint64("approval_id", a.ID), xlog.Err(fmt.Errorf("failed to expire approval: %w", err)))
+	}
+}
+
+// markFailed record the error of operation executed after approved
+func (s *approval) markFailed(ctx context.Context, a *models.Approval, fromState models.ApprovalState, errExecute error) {
+	a.State = models.ApprovalStateFailed
+	a.ExecutionError = errExecute.Error()
+
+	xlog.Warn(ctx, logMessageApproval, xlog.Uint64("approval_id", a.ID), xlog.Err(fmt.Errorf("failed to execute approved operation: %w", errExecute)))
+
+	err := s.transition(ctx, a, fromState, models.ApprovalActivity{
+		Action:   models.ApprovalActionExecute,
+		Actor:    models.ApprovalSystemActor,
+		ClientID: a.CheckerClientID,
+		Comment:  a.ExecutionError,
+	})
+	if err != nil {
+		xlog.Warn(ctx, logMessageApproval, xlog.Uint64("approval_id", a.ID), xlog.Err(fmt.Errorf("failed to mark approval as failed: %w", err)))
+	}
+}
+
+// execute call the existing service method of the operation with the stored payload,
+// operation which takes long is returned as startBackground when background is true, it is started by the caller
+func (s *approval) execute(ctx context.Context, a *models.Approval, background bool) (startBackground func(), err error) {
+	switch a.Operation {
+	case models.ApprovalOperationPublishTransaction:
+		var in models.DoPublishTransactionRequest
+		if err = json.Unmarshal(a.Payload, &in); err != nil {
+			return nil, err
+		}
+
+		_, err = s.srv.Transaction.PublishTransaction(ctx, in)
+		return nil, err
+
+	case models.ApprovalOperationDeleteAccount:
+		var in models.ApprovalDeleteAccountPayload
+		if err = json.Unmarshal(a.Payload, &in); err != nil {
+			return nil, err
+		}
+
+		return nil, s.srv.Account.Delete(ctx, in.AccountNumber)
+
+	case models.ApprovalOperationUpdateMoneyFlowSummary:
+		var in models.ApprovalUpdateMoneyFlowSummaryPayload
+		if err = json.Unmarshal(a.Payload, &in); err != nil {
+			return nil, err
+		}
+
+		return nil, s.srv.MoneyFlowCalc.UpdateSummary(ctx, in.SummaryID, in.Request)
+
+	case models.ApprovalOperationUpsertVATConfig:
+		var in []models.ConfigVatRevenue
+		if err = json.Unmarshal(a.Payload, &in); err != nil {
+			return nil, err
+		}
+
+		// the change is recorded in master data audit as made by the maker
+		return nil, s.srv.MasterData.UpsertVATConfig(models.WithMasterDataActor(ctx, a.Maker), in)
+
+	case models.ApprovalOperationUploadWalletTransaction:
+		return s.executeUploadWalletTransaction(ctx, a, background)
+
+	default:
+		return nil, common.ErrUnsupportedApprovalOperation
+	}
+}
+
+// executeUploadWalletTransaction process the file in background same as direct upload,
+// the result of each row is sent by email so only failure of the whole file is recorded in approval
+func (s *approval) executeUploadWalletTransaction(ctx context.Context, a *models.Approval, background bool) (func(), error) {
+	var in models.ApprovalUploadWalletTransactionPayload
+	if err := json.Unmarshal(a.Payload, &in); err != nil {
+		return nil, err
+	}
+
+	file, err := newMultipartFileHeader(in.FileName, in.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	if !background {
+		return nil, s.srv.File.UploadWalletTransaction(ctx, file, in.ReportTo, in.ClientID)
+	}
+
+	return func() {
+		// copy the approval, it is returned to the caller while the upload is running.
+		// It is started after the approval is executed, failure moves the approval from the stored state
+		approved := *a
+		bgCtx := context.WithoutCancel(ctx)
+		go func() {
+			if errUpload := s.srv.File.UploadWalletTransaction(bgCtx, file, in.ReportTo, in.ClientID); errUpload != nil {
+				s.markFailed(bgCtx, &approved, approved.State, errUpload)
+			}
+		}()
+	}, nil
+}
+
+// newMultipartFileHeader rebuild the uploaded file from the content stored in approval
+func newMultipartFileHeader(fileName string, content []byte) (*multipart.FileHeader, error) {
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	part, err := w.CreateFormFile("files", fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = part.Write(content); err != nil {
+		return nil, err
+	}
+
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	form, err := multipart.NewReader(&body, w.Boundary()).ReadForm(int64(body.Len()))
+	if err != nil {
+		return nil, err
+	}
+
+	return form.File["files"][0], nil
+}
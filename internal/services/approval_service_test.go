@@ -0,0 +1,520 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestApprovalService_IsRequired(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	assert.True(t, testHelper.approvalSvc.IsRequired(models.ApprovalOperationDeleteAccount))
+	assert.False(t, testHelper.approvalSvc.IsRequired(models.ApprovalOperationPublishTransaction))
+}
+
+func TestApprovalService_Submit(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	type args struct {
+		ctx context.Context
+		req models.SubmitApprovalRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr bool
+	}{
+		{
+			name: "success submit",
+			args: args{
+				ctx: context.Background(),
+				req: models.SubmitApprovalRequest{
+					Operation:   models.ApprovalOperationDeleteAccount,
+					Payload:     models.ApprovalDeleteAccountPayload{AccountNumber: "123456"},
+					Description: "delete account 123456",
+					Maker:       "finance.ops",
+					ClientID:    "backoffice",
+				},
+			},
+			doMock: func(args args) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockApprovalRepository.EXPECT().Create(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, in *models.Approval) error {
+						assert.Equal(t, models.ApprovalStatePending, in.State)
+						assert.JSONEq(t, `{"accountNumber":"123456"}`, string(in.Payload))
+						assert.WithinDuration(t, time.Now().Add(24*time.Hour), in.ExpiresAt, time.Minute)
+						in.ID = 1
+						return nil
+					})
+				testHelper.mockApprovalRepository.EXPECT().CreateActivity(args.ctx, &models.ApprovalActivity{
+					ApprovalID: 1,
+					Action:     models.ApprovalActionSubmit,
+					Actor:      "finance.ops",
+					ClientID:   "backoffice",
+					ToState:    models.ApprovalStatePending,
+					Comment:    "delete account 123456",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "failed create approval",
+			args: args{
+				ctx: context.Background(),
+				req: models.SubmitApprovalRequest{
+					Operation: models.ApprovalOperationDeleteAccount,
+					Payload:   models.ApprovalDeleteAccountPayload{AccountNumber: "123456"},
+					Maker:     "finance.ops",
+				},
+			},
+			doMock: func(args args) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockApprovalRepository.EXPECT().Create(args.ctx, gomock.Any()).Return(assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			got, err := testHelper.approvalSvc.Submit(tt.args.ctx, tt.args.req)
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, uint64(1), got.ID)
+			}
+		})
+	}
+}
+
+func TestApprovalService_Approve(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	pendingApproval := func() *models.Approval {
+		return &models.Approval{
+			ID:        1,
+			Operation: models.ApprovalOperationDeleteAccount,
+			Payload:   []byte(`{"accountNumber":"123456"}`),
+			State:     models.ApprovalStatePending,
+			Maker:     "finance.ops",
+			ExpiresAt: time.Now().Add(time.Hour),
+		}
+	}
+
+	type args struct {
+		ctx context.Context
+		req models.DecideApprovalRequest
+	}
+	tests := []struct {
+		name      string
+		args      args
+		doMock    func(args args)
+		wantState models.ApprovalState
+		wantErr   error
+	}{
+		{
+			name: "success approve and execute operation",
+			args: args{
+				ctx: context.Background(),
+				req: models.DecideApprovalRequest{ID: 1, Comment: "ok", Actor: "finance.lead", ClientID: "backoffice"},
+			},
+			doMock: func(args args) {
+				testHelper.mockApprovalRepository.EXPECT().GetByID(args.ctx, uint64(1)).Return(pendingApproval(), nil)
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockApprovalRepository.EXPECT().UpdateState(args.ctx, gomock.Any(), models.ApprovalStatePending).
+					DoAndReturn(func(ctx context.Context, in *models.Approval, fromState models.ApprovalState) error {
+						assert.Equal(t, models.ApprovalStateApproved, in.State)
+						assert.Equal(t, "finance.lead", in.Checker)
+						assert.NotNil(t, in.DecidedAt)
+						return nil
+					})
+				testHelper.mockApprovalRepository.EXPECT().CreateActivity(args.ctx, &models.ApprovalActivity{
+					ApprovalID: 1,
+					Action:     models.ApprovalActionApprove,
+					Actor:      "finance.lead",
+					ClientID:   "backoffice",
+					FromState:  models.ApprovalStatePending,
+					ToState:    models.ApprovalStateApproved,
+					Comment:    "ok",
+				}).Return(nil)
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					}).Times(2)
+				gomock.InOrder(
+					testHelper.mockApprovalRepository.EXPECT().UpdateState(args.ctx, gomock.Any(), models.ApprovalStateApproved).
+						DoAndReturn(func(ctx context.Context, in *models.Approval, fromState models.ApprovalState) error {
+							assert.Equal(t, models.ApprovalStateExecuting, in.State)
+							return nil
+						}),
+					testHelper.mockApprovalRepository.EXPECT().CreateActivity(args.ctx, gomock.Any()).Return(nil),
+					testHelper.mockAccRepository.EXPECT().DeleteByAccountNumber(args.ctx, "123456").Return(nil),
+					testHelper.mockApprovalRepository.EXPECT().UpdateState(args.ctx, gomock.Any(), models.ApprovalStateExecuting).
+						DoAndReturn(func(ctx context.Context, in *models.Approval, fromState models.ApprovalState) error {
+							assert.Equal(t, models.ApprovalStateExecuted, in.State)
+							return nil
+						}),
+					testHelper.mockApprovalRepository.EXPECT().CreateActivity(args.ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, in *models.ApprovalActivity) error {
+							assert.Equal(t, models.ApprovalActionExecute, in.Action)
+							assert.Equal(t, models.ApprovalStateExecuted, in.ToState)
+							return nil
+						}),
+				)
+			},
+			wantState: models.ApprovalStateExecuted,
+		},
+		{
+			name: "success approve but operation failed",
+			args: args{
+				ctx: context.Background(),
+				req: models.DecideApprovalRequest{ID: 1, Actor: "finance.lead"},
+			},
+			doMock: func(args args) {
+				testHelper.mockApprovalRepository.EXPECT().GetByID(args.ctx, uint64(1)).Return(pendingApproval(), nil)
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					}).Times(3)
+				gomock.InOrder(
+					testHelper.mockApprovalRepository.EXPECT().UpdateState(args.ctx, gomock.Any(), models.ApprovalStatePending).Return(nil),
+					testHelper.mockApprovalRepository.EXPECT().CreateActivity(args.ctx, gomock.Any()).Return(nil),
+					testHelper.mockApprovalRepository.EXPECT().UpdateState(args.ctx, gomock.Any(), models.ApprovalStateApproved).Return(nil),
+					testHelper.mockApprovalRepository.EXPECT().CreateActivity(args.ctx, gomock.Any()).Return(nil),
+					testHelper.mockAccRepository.EXPECT().DeleteByAccountNumber(args.ctx, "123456").Return(common.ErrNoRowsAffected),
+					testHelper.mockApprovalRepository.EXPECT().UpdateState(args.ctx, gomock.Any(), models.ApprovalStateExecuting).
+						DoAndReturn(func(ctx context.Context, in *models.Approval, fromState models.ApprovalState) error {
+							assert.Equal(t, models.ApprovalStateFailed, in.State)
+							assert.Equal(t, common.ErrNoRowsAffected.Error(), in.ExecutionError)
+							return nil
+						}),
+					testHelper.mockApprovalRepository.EXPECT().CreateActivity(args.ctx, gomock.Any()).
+						DoAndReturn(func(ctx context.Context, in *models.ApprovalActivity) error {
+							assert.Equal(t, models.ApprovalActionExecute, in.Action)
+							assert.Equal(t, models.ApprovalSystemActor, in.Actor)
+							return nil
+						}),
+				)
+			},
+			wantState: models.ApprovalStateFailed,
+		},
+		{
+			name: "success approve but claim failed is left approved",
+			args: args{
+				ctx: context.Background(),
+				req: models.DecideApprovalRequest{ID: 1, Actor: "finance.lead"},
+			},
+			doMock: func(args args) {
+				testHelper.mockApprovalRepository.EXPECT().GetByID(args.ctx, uint64(1)).Return(pendingApproval(), nil)
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					}).Times(2)
+				gomock.InOrder(
+					testHelper.mockApprovalRepository.EXPECT().UpdateState(args.ctx, gomock.Any(), models.ApprovalStatePending).Return(nil),
+					testHelper.mockApprovalRepository.EXPECT().CreateActivity(args.ctx, gomock.Any()).Return(nil),
+					testHelper.mockApprovalRepository.EXPECT().UpdateState(args.ctx, gomock.Any(), models.ApprovalStateApproved).Return(assert.AnError),
+				)
+			},
+			wantState: models.ApprovalStateApproved,
+		},
+		{
+			name: "failed approve by unverified user of the maker client",
+			args: args{
+				ctx: context.Background(),
+				req: models.DecideApprovalRequest{ID: 1, Actor: "backoffice", ClientID: "backoffice"},
+			},
+			doMock: func(args args) {
+				a := pendingApproval()
+				a.MakerClientID = "backoffice"
+				testHelper.mockApprovalRepository.EXPECT().GetByID(args.ctx, uint64(1)).Return(a, nil)
+			},
+			wantErr: common.ErrApprovalSameActor,
+		},
+		{
+			name: "failed approve submitted by unverified user of the checker client",
+			args: args{
+				ctx: context.Background(),
+				req: models.DecideApprovalRequest{ID: 1, Actor: "finance.lead", ClientID: "backoffice"},
+			},
+			doMock: func(args args) {
+				a := pendingApproval()
+				a.Maker = "backoffice"
+				a.MakerClientID = "backoffice"
+				testHelper.mockApprovalRepository.EXPECT().GetByID(args.ctx, uint64(1)).Return(a, nil)
+			},
+			wantErr: common.ErrApprovalSameActor,
+		},
+		{
+			name: "failed approve by maker",
+			args: args{
+				ctx: context.Background(),
+				req: models.DecideApprovalRequest{ID: 1, Actor: "finance.ops"},
+			},
+			doMock: func(args args) {
+				testHelper.mockApprovalRepository.EXPECT().GetByID(args.ctx, uint64(1)).Return(pendingApproval(), nil)
+			},
+			wantErr: common.ErrApprovalSameActor,
+		},
+		{
+			name: "failed approve decided approval",
+			args: args{
+				ctx: context.Background(),
+				req: models.DecideApprovalRequest{ID: 1, Actor: "finance.lead"},
+			},
+			doMock: func(args args) {
+				a := pendingApproval()
+				a.State = models.ApprovalStateRejected
+				testHelper.mockApprovalRepository.EXPECT().GetByID(args.ctx, uint64(1)).Return(a, nil)
+			},
+			wantErr: common.ErrApprovalNotPending,
+		},
+		{
+			name: "failed approve expired approval",
+			args: args{
+				ctx: context.Background(),
+				req: models.DecideApprovalRequest{ID: 1, Actor: "finance.lead"},
+			},
+			doMock: func(args args) {
+				a := pendingApproval()
+				a.ExpiresAt = time.Now().Add(-time.Minute)
+				testHelper.mockApprovalRepository.EXPECT().GetByID(args.ctx, uint64(1)).Return(a, nil)
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockApprovalRepository.EXPECT().UpdateState(args.ctx, gomock.Any(), models.ApprovalStatePending).Return(nil)
+				testHelper.mockApprovalRepository.EXPECT().CreateActivity(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, in *models.ApprovalActivity) error {
+						assert.Equal(t, models.ApprovalActionExpire, in.Action)
+						assert.Equal(t, models.ApprovalStateExpired, in.ToState)
+						return nil
+					})
+			},
+			wantErr: common.ErrApprovalExpired,
+		},
+		{
+			name: "failed approve decided concurrently",
+			args: args{
+				ctx: context.Background(),
+				req: models.DecideApprovalRequest{ID: 1, Actor: "finance.lead"},
+			},
+			doMock: func(args args) {
+				testHelper.mockApprovalRepository.EXPECT().GetByID(args.ctx, uint64(1)).Return(pendingApproval(), nil)
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockApprovalRepository.EXPECT().UpdateState(args.ctx, gomock.Any(), models.ApprovalStatePending).
+					Return(common.ErrNoRowsAffected)
+			},
+			wantErr: common.ErrApprovalNotPending,
+		},
+		{
+			name: "failed approval not found",
+			args: args{
+				ctx: context.Background(),
+				req: models.DecideApprovalRequest{ID: 1, Actor: "finance.lead"},
+			},
+			doMock: func(args args) {
+				testHelper.mockApprovalRepository.EXPECT().GetByID(args.ctx, uint64(1)).Return(nil, common.ErrDataNotFound)
+			},
+			wantErr: common.ErrDataNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			got, err := testHelper.approvalSvc.Approve(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantState, got.State)
+		})
+	}
+}
+
+func TestApprovalService_Approve_UploadFailedInBackground(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	payload, err := json.Marshal(models.ApprovalUploadWalletTransactionPayload{
+		FileName: "upload.csv",
+		Content:  []byte("Transaction Date,Reference Number\n"),
+		ReportTo: "finance.ops@amartha.com",
+		ClientID: "backoffice",
+	})
+	assert.NoError(t, err)
+
+	testHelper.mockApprovalRepository.EXPECT().GetByID(gomock.Any(), uint64(1)).Return(&models.Approval{
+		ID:        1,
+		Operation: models.ApprovalOperationUploadWalletTransaction,
+		Payload:   payload,
+		State:     models.ApprovalStatePending,
+		Maker:     "finance.ops",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	testHelper.mockSQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+			return steps(ctx, testHelper.mockSQLRepository)
+		}).Times(4)
+
+	// the upload is started after the approval is executed, so its failure is recorded from the executed state
+	failed := make(chan struct{})
+	gomock.InOrder(
+		testHelper.mockApprovalRepository.EXPECT().UpdateState(gomock.Any(), gomock.Any(), models.ApprovalStatePending).Return(nil),
+		testHelper.mockApprovalRepository.EXPECT().CreateActivity(gomock.Any(), gomock.Any()).Return(nil),
+		testHelper.mockApprovalRepository.EXPECT().UpdateState(gomock.Any(), gomock.Any(), models.ApprovalStateApproved).Return(nil),
+		testHelper.mockApprovalRepository.EXPECT().CreateActivity(gomock.Any(), gomock.Any()).Return(nil),
+		testHelper.mockApprovalRepository.EXPECT().UpdateState(gomock.Any(), gomock.Any(), models.ApprovalStateExecuting).
+			DoAndReturn(func(ctx context.Context, in *models.Approval, fromState models.ApprovalState) error {
+				assert.Equal(t, models.ApprovalStateExecuted, in.State)
+				// an upload started too early has the time to fail before the approval is executed
+				time.Sleep(50 * time.Millisecond)
+				return nil
+			}),
+		testHelper.mockApprovalRepository.EXPECT().CreateActivity(gomock.Any(), gomock.Any()).Return(nil),
+		testHelper.mockCacheRepository.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", assert.AnError),
+		testHelper.mockApprovalRepository.EXPECT().UpdateState(gomock.Any(), gomock.Any(), models.ApprovalStateExecuted).
+			DoAndReturn(func(ctx context.Context, in *models.Approval, fromState models.ApprovalState) error {
+				assert.Equal(t, models.ApprovalStateFailed, in.State)
+				assert.Equal(t, assert.AnError.Error(), in.ExecutionError)
+				return nil
+			}),
+		testHelper.mockApprovalRepository.EXPECT().CreateActivity(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, in *models.ApprovalActivity) error {
+				assert.Equal(t, models.ApprovalStateExecuted, in.FromState)
+				assert.Equal(t, models.ApprovalStateFailed, in.ToState)
+				close(failed)
+				return nil
+			}),
+	)
+
+	got, err := testHelper.approvalSvc.Approve(context.Background(), models.DecideApprovalRequest{ID: 1, Actor: "finance.lead"})
+	assert.NoError(t, err)
+	assert.Equal(t, models.ApprovalStateExecuted, got.State)
+
+	select {
+	case <-failed:
+	case <-time.After(time.Second):
+		t.Fatal("failure of the upload is not recorded")
+	}
+}
+
+func TestApprovalService_Reject(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	ctx := context.Background()
+	req := models.DecideApprovalRequest{ID: 1, Comment: "wrong account", Actor: "finance.lead"}
+
+	testHelper.mockApprovalRepository.EXPECT().GetByID(ctx, uint64(1)).Return(&models.Approval{
+		ID:        1,
+		Operation: models.ApprovalOperationDeleteAccount,
+		State:     models.ApprovalStatePending,
+		Maker:     "finance.ops",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}, nil)
+	testHelper.mockSQLRepository.EXPECT().Atomic(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+			return steps(ctx, testHelper.mockSQLRepository)
+		})
+	testHelper.mockApprovalRepository.EXPECT().UpdateState(ctx, gomock.Any(), models.ApprovalStatePending).Return(nil)
+	testHelper.mockApprovalRepository.EXPECT().CreateActivity(ctx, &models.ApprovalActivity{
+		ApprovalID: 1,
+		Action:     models.ApprovalActionReject,
+		Actor:      "finance.lead",
+		FromState:  models.ApprovalStatePending,
+		ToState:    models.ApprovalStateRejected,
+		Comment:    "wrong account",
+	}).Return(nil)
+
+	got, err := testHelper.approvalSvc.Reject(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ApprovalStateRejected, got.State)
+}
+
+func TestApprovalService_ExpirePending(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	testHelper.mockSQLRepository.EXPECT().Atomic(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+			return steps(ctx, testHelper.mockSQLRepository)
+		})
+	testHelper.mockApprovalRepository.EXPECT().ExpirePending(ctx, now).Return([]uint64{1, 2}, nil)
+	testHelper.mockApprovalRepository.EXPECT().CreateActivity(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, in *models.ApprovalActivity) error {
+			assert.Equal(t, models.ApprovalActionExpire, in.Action)
+			assert.Equal(t, models.ApprovalStateExpired, in.ToState)
+			return nil
+		}).Times(2)
+
+	got, err := testHelper.approvalSvc.ExpirePending(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, got)
+}
+
+func TestApprovalService_ExecuteApproved(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	ctx := context.Background()
+	now := time.Now()
+	decidedAt := now.Add(-time.Hour)
+	justDecidedAt := now.Add(-time.Second)
+
+	opts := models.ApprovalFilterOptions{
+		State:          models.ApprovalStateApproved,
+		Limit:          100,
+		AscendingOrder: true,
+	}
+
+	testHelper.mockApprovalRepository.EXPECT().GetList(ctx, opts).Return([]models.Approval{
+		{ID: 1, Operation: models.ApprovalOperationDeleteAccount, Payload: []byte(`{"accountNumber":"123456"}`), State: models.ApprovalStateApproved, DecidedAt: &decidedAt},
+		{ID: 2, Operation: models.ApprovalOperationDeleteAccount, Payload: []byte(`{"accountNumber":"654321"}`), State: models.ApprovalStateApproved, DecidedAt: &decidedAt},
+		{ID: 3, Operation: models.ApprovalOperationDeleteAccount, Payload: []byte(`{"accountNumber":"111111"}`), State: models.ApprovalStateApproved, DecidedAt: &justDecidedAt},
+	}, nil)
+	testHelper.mockSQLRepository.EXPECT().Atomic(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+			return steps(ctx, testHelper.mockSQLRepository)
+		}).Times(3)
+	gomock.InOrder(
+		testHelper.mockApprovalRepository.EXPECT().UpdateState(ctx, gomock.Any(), models.ApprovalStateApproved).Return(nil),
+		testHelper.mockApprovalRepository.EXPECT().CreateActivity(ctx, gomock.Any()).Return(nil),
+		testHelper.mockAccRepository.EXPECT().DeleteByAccountNumber(ctx, "123456").Return(nil),
+		testHelper.mockApprovalRepository.EXPECT().UpdateState(ctx, gomock.Any(), models.ApprovalStateExecuting).Return(nil),
+		testHelper.mockApprovalRepository.EXPECT().CreateActivity(ctx, gomock.Any()).Return(nil),
+		// the second approval is claimed by the approve request
+		testHelper.mockApprovalRepository.EXPECT().UpdateState(ctx, gomock.Any(), models.ApprovalStateApproved).Return(common.ErrNoRowsAffected),
+	)
+
+	got, err := testHelper.approvalSvc.ExecuteApproved(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got)
+}
@@ -18,8 +18,8 @@ type balance service
 var _ BalanceService = (*balance)(nil)
 
 func (b balance) Get(ctx context.Context, accountNumber string) (res models.AccountBalance, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	repoBalance := b.srv.sqlRepo.GetBalanceRepository()
 
@@ -33,8 +33,8 @@ func (b balance) Get(ctx context.Context, accountNumber string) (res models.Acco
 }
 
 func (b balance) AdjustAccountBalance(ctx context.Context, accountNumber string, delta models.Decimal) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	repoBalance := b.srv.sqlRepo.GetBalanceRepository()
 
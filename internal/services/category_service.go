@@ -19,8 +19,8 @@ var _ CategoryService = (*category)(nil)
 
 // Create implements CategoryService.
 func (s *category) Create(ctx context.Context, req models.CreateCategoryIn) (output *models.Category, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	// Check existing
 	exist, err := s.srv.sqlRepo.GetCategoryRepository().GetByCode(ctx, req.Code)
@@ -46,8 +46,8 @@ func (s *category) Create(ctx context.Context, req models.CreateCategoryIn) (out
 
 // GetAll implements CategoryService.
 func (s *category) GetAll(ctx context.Context) (output *[]models.Category, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	// Get data
 	categories, err := s.srv.sqlRepo.GetCategoryRepository().List(ctx)
@@ -4,12 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"time"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 
+	"github.com/shopspring/decimal"
 	"golang.org/x/exp/slices"
 )
 
@@ -117,6 +117,36 @@ func getWalletBalanceCancelCalculator(trxFlow models.TransactionFlow) walletBala
 	}
 }
 
+type walletBalanceCaptureCalculator func(ctx context.Context, trxSet models.WalletTransactionSet, releaseAmount decimal.Decimal, accountBalance map[string]models.Balance) (map[string]models.Balance, error)
+
+// getWalletBalanceCaptureCalculator returns calculator for partial capture, cashin is not supported
+// because reserved cashin is not allowed when wallet transaction is created
+func getWalletBalanceCaptureCalculator(trxFlow models.TransactionFlow) walletBalanceCaptureCalculator {
+	switch trxFlow {
+	case models.TransactionFlowCashOut, models.TransactionFlowTransfer, models.TransactionFlowRefund:
+		return func(ctx context.Context, trxSet models.WalletTransactionSet, releaseAmount decimal.Decimal, accountBalance map[string]models.Balance) (map[string]models.Balance, error) {
+			return trxSet.CalculateCapture(ctx, releaseAmount, accountBalance)
+		}
+	default:
+		return func(ctx context.Context, trxSet models.WalletTransactionSet, releaseAmount decimal.Decimal, accountBalance map[string]models.Balance) (map[string]models.Balance, error) {
+			return nil, fmt.Errorf("%w: %s", common.ErrUnsupportedReservedTransactionFlow, trxFlow)
+		}
+	}
+}
+
+func getWalletBalanceIncreaseReservationCalculator(trxFlow models.TransactionFlow) walletBalanceCalculator {
+	switch trxFlow {
+	case models.TransactionFlowCashOut, models.TransactionFlowTransfer, models.TransactionFlowRefund:
+		return func(ctx context.Context, trxSet models.WalletTransactionSet, accountBalance map[string]models.Balance) (map[string]models.Balance, error) {
+			return trxSet.CalculateIncreaseReservation(ctx, accountBalance)
+		}
+	default:
+		return func(ctx context.Context, trxSet models.WalletTransactionSet, accountBalance map[string]models.Balance) (map[string]models.Balance, error) {
+			return nil, fmt.Errorf("%w: %s", common.ErrUnsupportedReservedTransactionFlow, trxFlow)
+		}
+	}
+}
+
 func getCacheKeyAccountAndBalance(accountNumbers ...string) []string {
 	var cacheKeys []string
 	for _, accountNumber := range accountNumbers {
@@ -319,7 +349,3 @@ func updateTransactionAccountNumber(trxReq []models.TransactionReq, balances []m
 
 	return trxReq
 }
-
-func getCacheKeyReportRepayment(startDate, endDate time.Time) string {
-	return fmt.Sprintf("go-fp:report-repayment:%s:%s", startDate.Format(time.DateOnly), endDate.Format(time.DateOnly))
-}
@@ -5,6 +5,8 @@ import (
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/shopspring/decimal"
 )
 
 func Test_checkDatabaseError(t *testing.T) {
@@ -42,3 +44,59 @@ func Test_checkDatabaseError(t *testing.T) {
 		})
 	}
 }
+
+func Test_proportionalShare(t *testing.T) {
+	amount := decimal.NewFromInt(100)
+	total := decimal.NewFromInt(3)
+
+	// three captures of 1 out of 3, each share is rounded but they add up to the whole amount
+	first := proportionalShare(amount, total, decimal.Zero, decimal.NewFromInt(1))
+	second := proportionalShare(amount, total, decimal.NewFromInt(1), decimal.NewFromInt(2))
+	third := proportionalShare(amount, total, decimal.NewFromInt(2), total)
+
+	if !first.Equal(decimal.RequireFromString("33.33")) {
+		t.Errorf("first share = %s, want 33.33", first)
+	}
+
+	if !second.Equal(decimal.RequireFromString("33.34")) {
+		t.Errorf("second share = %s, want 33.34", second)
+	}
+
+	if !first.Add(second).Add(third).Equal(amount) {
+		t.Errorf("sum of shares = %s, want %s", first.Add(second).Add(third), amount)
+	}
+}
+
+func Test_scaleAmounts(t *testing.T) {
+	detail := func(typ, value string) models.AmountDetail {
+		return models.AmountDetail{
+			Type:   typ,
+			Amount: &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.RequireFromString(value))},
+		}
+	}
+	amounts := models.Amounts{detail("principal", "3333.33"), detail("fee", "3333.33"), {Type: "empty"}, detail("tax", "3333.34")}
+	part := decimal.RequireFromString("1000.01")
+	total := decimal.NewFromInt(10000)
+
+	// each breakdown alone rounds to 333.34 which adds up to 1000.02, the last one takes the remainder instead
+	scaled := scaleAmounts(amounts, part, total)
+
+	sum := decimal.Zero
+	for _, a := range scaled {
+		if a.Amount != nil {
+			sum = sum.Add(a.Amount.ValueDecimal.Decimal)
+		}
+	}
+
+	if !sum.Equal(part) {
+		t.Errorf("sum of breakdowns = %s, want %s", sum, part)
+	}
+
+	if !scaled[3].Amount.ValueDecimal.Equal(decimal.RequireFromString("333.33")) {
+		t.Errorf("last breakdown = %s, want 333.33", scaled[3].Amount.ValueDecimal)
+	}
+
+	if scaled[2].Amount != nil {
+		t.Errorf("empty breakdown is scaled")
+	}
+}
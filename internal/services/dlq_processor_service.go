@@ -36,8 +36,8 @@ type dlqProcessor service
 var _ DLQProcessorService = (*dlqProcessor)(nil)
 
 func (d dlqProcessor) SendNotificationOrderFailure(ctx context.Context, message models.FailedMessage) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	var orderPayload goacuanlib.Payload[goacuanlib.DataOrder]
 	err = json.Unmarshal(message.Payload, &orderPayload)
@@ -61,8 +61,8 @@ func (d dlqProcessor) SendNotificationOrderFailure(ctx context.Context, message
 }
 
 func (d dlqProcessor) SendNotificationAccountFailure(ctx context.Context, message models.FailedMessage) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	var accountPayload goacuanlib.Payload[goacuanlib.DataAccount]
 	err = json.Unmarshal(message.Payload, &accountPayload)
@@ -82,8 +82,8 @@ func (d dlqProcessor) SendNotificationAccountFailure(ctx context.Context, messag
 }
 
 func (d dlqProcessor) SendNotificationBalanceHvtFailure(ctx context.Context, message models.FailedMessage) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	var hvtBalancePayload models.UpdateBalanceHVTPayload
 	err = json.Unmarshal(message.Payload, &hvtBalancePayload)
@@ -103,8 +103,8 @@ func (d dlqProcessor) SendNotificationBalanceHvtFailure(ctx context.Context, mes
 }
 
 func (d dlqProcessor) SendNotificationRetryFailure(ctx context.Context, operation, message string) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	xlog.Error(ctx, "[DLQ-ERROR]", 
 		xlog.String("operation", fmt.Sprintf("[DLQ Retry Failure]: %s", operation)),
@@ -115,7 +115,7 @@ func (d dlqProcessor) SendNotificationRetryFailure(ctx context.Context, operatio
 
 
 func (d dlqProcessor) RetryAccountMutation(ctx context.Context, message models.FailedMessage) (err error) {
-	monitor := monitoring.New(ctx)
+	ctx, monitor := monitoring.Start(ctx)
 
 	defer func() {
 		monitor.Finish(monitoring.WithFinishCheckError(err))
@@ -169,7 +169,7 @@ func (d dlqProcessor) RetryAccountMutation(ctx context.Context, message models.F
 }
 
 func (d dlqProcessor) RetryCreateOrderTransaction(ctx context.Context, message models.FailedMessage) (err error) {
-	monitor := monitoring.New(ctx)
+	ctx, monitor := monitoring.Start(ctx)
 	defer func() {
 		monitor.Finish(monitoring.WithFinishCheckError(err))
 
@@ -236,8 +236,8 @@ func (d dlqProcessor) sendJob(ctx context.Context, url, body any, status models.
 }
 
 func (d dlqProcessor) GetStatusRetry(ctx context.Context, processRetryId string) (status models.StatusRetryDLQ, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	cacheKey := models.GetCacheKeyStatusRetryDLQ(processRetryId)
 
@@ -255,8 +255,8 @@ func (d dlqProcessor) GetStatusRetry(ctx context.Context, processRetryId string)
 }
 
 func (d dlqProcessor) UpsertStatusRetry(ctx context.Context, processRetryId string, status models.StatusRetryDLQ) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	rawData, err := json.Marshal(status)
 	if err != nil {
@@ -19,8 +19,8 @@ var _ EntityService = (*entity)(nil)
 
 // Create implements EntityService.
 func (s *entity) Create(ctx context.Context, req models.CreateEntityIn) (out *models.Entity, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	// Check exist
 	exist, err := s.srv.sqlRepo.GetEntityRepository().GetByCode(ctx, req.Code)
@@ -44,8 +44,8 @@ func (s *entity) Create(ctx context.Context, req models.CreateEntityIn) (out *mo
 
 // GetAll implements EntityService.
 func (s *entity) GetAll(ctx context.Context) (out *[]models.Entity, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	// Get data
 	out, err = s.srv.sqlRepo.GetEntityRepository().List(ctx)
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/exportfile"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+)
+
+type ExportService interface {
+	// CreateExport store export job and queue it to be processed by export worker
+	CreateExport(ctx context.Context, req models.DoCreateExportRequest) (job *models.ExportJob, err error)
+
+	// GetExport return export job status, download url is available when the job has succeeded
+	GetExport(ctx context.Context, id uint64) (res *models.DoGetExportResponse, err error)
+
+	ProcessExportTaskQueue(ctx context.Context, id uint64) error
+}
+
+type export service
+
+var _ ExportService = (*export)(nil)
+
+const (
+	defaultExportURLExpiryTime    = 15 * time.Minute
+	defaultExportProgressInterval = 10_000
+	defaultExportStaleAfter       = 10 * time.Minute
+)
+
+func (s *export) CreateExport(ctx context.Context, req models.DoCreateExportRequest) (job *models.ExportJob, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	// validate filter before queued, so the requester get the error immediately
+	if _, err = req.Filter.ToFilterOptions(s.srv.conf.Export.MaxDateRangeDays); err != nil {
+		return nil, err
+	}
+
+	repo := s.srv.sqlRepo.GetExportJobRepository()
+
+	in := req.ToExportJob()
+	if err = repo.Create(ctx, &in); err != nil {
+		return nil, err
+	}
+
+	if err = s.srv.exportPub.Publish(ctx, models.ExportPublisher{
+		ID:   strconv.FormatUint(in.ID, 10),
+		Task: models.ExportTaskName,
+	}); err != nil {
+		xlog.Errorf(ctx, "failed to publish export job: %v", err)
+
+		in.Status = models.ExportStatusFailed
+		in.ErrorMessage = "failed to queue export job"
+		if errFinish := repo.Finish(ctx, &in); errFinish != nil {
+			xlog.Errorf(ctx, "failed to update export job: %v", errFinish)
+		}
+
+		return nil, common.ErrUnableToCreate
+	}
+
+	return &in, nil
+}
+
+func (s *export) GetExport(ctx context.Context, id uint64) (res *models.DoGetExportResponse, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	job, err := s.srv.sqlRepo.GetExportJobRepository().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var url string
+	if job.Status == models.ExportStatusSuccess && job.FilePath != "" {
+		expireDuration := defaultExportURLExpiryTime
+		if s.srv.conf.Export.URLExpiryTime != 0 {
+			expireDuration = time.Duration(s.srv.conf.Export.URLExpiryTime) * time.Minute
+		}
+
+		url, err = s.srv.cloudStorage.GetSignedURL(job.FilePath, expireDuration)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := job.ToModelResponse(url)
+
+	return &result, nil
+}
+
+func (s *export) ProcessExportTaskQueue(ctx context.Context, id uint64) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	repo := s.srv.sqlRepo.GetExportJobRepository()
+
+	job, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	staleAfter := defaultExportStaleAfter
+	if s.srv.conf.Export.StaleAfter != 0 {
+		staleAfter = time.Duration(s.srv.conf.Export.StaleAfter) * time.Minute
+	}
+
+	claimed, err := repo.Claim(ctx, id, staleAfter)
+	if err != nil {
+		return err
+	}
+
+	// message is redelivered while the job is finished or still processed by another worker
+	if !claimed {
+		xlog.Info(ctx, "[EXPORT]",
+			xlog.String("operation", "skip export job"),
+			xlog.Uint64("export_id", id),
+			xlog.String("status", job.Status))
+		return nil
+	}
+
+	xlog.Info(ctx, "[EXPORT]",
+		xlog.String("operation", "start export job"),
+		xlog.Uint64("export_id", id))
+
+	job.Status = models.ExportStatusProcessing
+	job.ProcessedRows = 0
+	job.ErrorMessage = ""
+
+	err = s.exportTransactions(ctx, job)
+	if err != nil {
+		job.Status = models.ExportStatusFailed
+		job.ErrorMessage = err.Error()
+	} else {
+		job.Status = models.ExportStatusSuccess
+	}
+
+	if errFinish := repo.Finish(ctx, job); errFinish != nil {
+		if err != nil {
+			xlog.Warn(ctx, "[EXPORT]", xlog.Uint64("export_id", id), xlog.Err(errFinish))
+			return err
+		}
+		return errFinish
+	}
+
+	xlog.Info(ctx, "[EXPORT]",
+		xlog.String("operation", "finish export job"),
+		xlog.Uint64("export_id", id),
+		xlog.String("status", job.Status),
+		xlog.Int64("processed_rows", job.ProcessedRows))
+
+	return err
+}
+
+// exportTransactions stream transactions into the export file in cloud storage, the file is not saved when it fails
+func (s *export) exportTransactions(ctx context.Context, job *models.ExportJob) (err error) {
+	// date range is validated on create, the limit is not applied again since the config may have changed
+	opts, err := job.Filter.ToFilterOptions(0)
+	if err != nil {
+		return err
+	}
+
+	trxRepo := s.srv.sqlRepo.GetTransactionRepository()
+	repo := s.srv.sqlRepo.GetExportJobRepository()
+
+	total, err := trxRepo.CountAll(ctx, *opts)
+	if err != nil {
+		return fmt.Errorf("failed to count transactions: %w", err)
+	}
+	job.TotalRows = int64(total)
+
+	if err = repo.UpdateProgress(ctx, job.ID, job.TotalRows, 0); err != nil {
+		return fmt.Errorf("failed to update progress: %w", err)
+	}
+
+	payload := job.CloudStoragePayload()
+	err = uploadExportFile(ctx, s.srv.cloudStorage, payload, exportfile.Format(job.Format), job.Gzip, header,
+		func(ctx context.Context, fw exportfile.Writer) error {
+			return s.writeTransactions(ctx, job, fw, *opts)
+		})
+	if err != nil {
+		return err
+	}
+
+	job.FilePath = payload.GetFilePath()
+
+	return nil
+}
+
+func (s *export) writeTransactions(ctx context.Context, job *models.ExportJob, fw exportfile.Writer, opts models.TransactionFilterOptions) error {
+	orderTypes, err := s.srv.masterDataRepo.GetListOrderType(ctx, models.FilterMasterData{})
+	if err != nil {
+		return fmt.Errorf("unable to GetListOrderType: %w", err)
+	}
+	mapOrderTypes, mapTransactionTypes := models.MakeOrderTypesMap(orderTypes)
+
+	progressInterval := int64(defaultExportProgressInterval)
+	if s.srv.conf.Export.ProgressInterval > 0 {
+		progressInterval = int64(s.srv.conf.Export.ProgressInterval)
+	}
+
+	repo := s.srv.sqlRepo.GetExportJobRepository()
+	for trx := range s.srv.sqlRepo.GetTransactionRepository().StreamAll(ctx, opts) {
+		if trx.Err != nil {
+			return fmt.Errorf("failed to read stream: %w", trx.Err)
+		}
+
+		if err = fw.Write(toTransactionFileRow(trx.Data, mapOrderTypes, mapTransactionTypes)); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
+		}
+
+		job.ProcessedRows++
+		if job.ProcessedRows%progressInterval == 0 {
+			if errProgress := repo.UpdateProgress(ctx, job.ID, job.TotalRows, job.ProcessedRows); errProgress != nil {
+				xlog.Warn(ctx, "[EXPORT]", xlog.Uint64("export_id", job.ID), xlog.Err(errProgress))
+			}
+		}
+	}
+
+	return nil
+}
+
+// uploadExportFile stream the rows written by write into a file of the format in cloud storage,
+// the uploaded file is discarded when write fails
+func uploadExportFile(
+	ctx context.Context,
+	cloudStorage repositories.CloudStorageRepository,
+	payload *models.CloudStoragePayload,
+	format exportfile.Format,
+	gz bool,
+	header []string,
+	write func(ctx context.Context, fw exportfile.Writer) error,
+) (err error) {
+	// cancel the upload context before closing the writer discards the uploaded chunks
+	uploadCtx, cancelUpload := context.WithCancel(ctx)
+	defer cancelUpload()
+
+	storageWriter := cloudStorage.NewWriter(uploadCtx, payload)
+
+	var out io.Writer = storageWriter
+	var gzWriter *gzip.Writer
+	if gz {
+		gzWriter = gzip.NewWriter(storageWriter)
+		out = gzWriter
+	}
+
+	err = writeExportFile(uploadCtx, out, format, header, write)
+	if err == nil && gzWriter != nil {
+		err = gzWriter.Close()
+	}
+	if err != nil {
+		cancelUpload()
+		_ = storageWriter.Close()
+		return err
+	}
+
+	if err = storageWriter.Close(); err != nil {
+		return fmt.Errorf("failed to upload file: %w", err)
+	}
+
+	return nil
+}
+
+func writeExportFile(ctx context.Context, w io.Writer, format exportfile.Format, header []string, write func(ctx context.Context, fw exportfile.Writer) error) error {
+	fw, err := exportfile.New(format, w, header)
+	if err != nil {
+		return err
+	}
+
+	if err = write(ctx, fw); err != nil {
+		return err
+	}
+
+	if err = fw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize file: %w", err)
+	}
+
+	return nil
+}
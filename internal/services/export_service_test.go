@@ -0,0 +1,320 @@
+package services_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type exportFileWriter struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (w *exportFileWriter) Close() error {
+	w.closed = true
+	return nil
+}
+
+func exportFilter() models.ExportTransactionFilter {
+	return models.ExportTransactionFilter{StartDate: "2025-01-01", EndDate: "2025-01-31"}
+}
+
+func TestExportService_CreateExport(t *testing.T) {
+	req := models.DoCreateExportRequest{
+		Format: "CSV",
+		Gzip:   true,
+		Filter: exportFilter(),
+		Actor:  "finance.ops",
+	}
+
+	tests := []struct {
+		name    string
+		req     models.DoCreateExportRequest
+		doMock  func(th testServiceHelper)
+		wantErr error
+	}{
+		{
+			name: "success",
+			req:  req,
+			doMock: func(th testServiceHelper) {
+				th.mockExportJobRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, job *models.ExportJob) error {
+						assert.Equal(t, models.ExportStatusPending, job.Status)
+						assert.Equal(t, "finance.ops", job.RequestedBy)
+						job.ID = 1
+						return nil
+					})
+				th.mockExportPublisher.EXPECT().Publish(gomock.Any(), models.ExportPublisher{
+					ID:   "1",
+					Task: models.ExportTaskName,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "invalid date range",
+			req: models.DoCreateExportRequest{
+				Format: "CSV",
+				Filter: models.ExportTransactionFilter{StartDate: "2025-02-01", EndDate: "2025-01-31"},
+				Actor:  "finance.ops",
+			},
+			wantErr: common.ErrInvalidDateRange,
+		},
+		{
+			name: "failed create job",
+			req:  req,
+			doMock: func(th testServiceHelper) {
+				th.mockExportJobRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "failed publish job",
+			req:  req,
+			doMock: func(th testServiceHelper) {
+				th.mockExportJobRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				th.mockExportPublisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(assert.AnError)
+				th.mockExportJobRepository.EXPECT().Finish(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, job *models.ExportJob) error {
+						assert.Equal(t, models.ExportStatusFailed, job.Status)
+						return nil
+					})
+			},
+			wantErr: common.ErrUnableToCreate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testHelper := serviceTestHelper(t)
+			if tt.doMock != nil {
+				tt.doMock(testHelper)
+			}
+
+			job, err := testHelper.exportSvc.CreateExport(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), job.ID)
+		})
+	}
+}
+
+func TestExportService_GetExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		doMock  func(th testServiceHelper)
+		wantURL string
+		wantErr bool
+	}{
+		{
+			name: "success with download url",
+			doMock: func(th testServiceHelper) {
+				th.mockExportJobRepository.EXPECT().GetByID(gomock.Any(), uint64(1)).Return(&models.ExportJob{
+					ID:       1,
+					Status:   models.ExportStatusSuccess,
+					FilePath: "exports/2025/01/transaction-1-2025-01-01_2025-01-31.csv",
+				}, nil)
+				th.mockGcs.EXPECT().GetSignedURL("exports/2025/01/transaction-1-2025-01-01_2025-01-31.csv", 15*time.Minute).
+					Return("https://storage.test/export.csv", nil)
+			},
+			wantURL: "https://storage.test/export.csv",
+		},
+		{
+			name: "success still processing",
+			doMock: func(th testServiceHelper) {
+				th.mockExportJobRepository.EXPECT().GetByID(gomock.Any(), uint64(1)).Return(&models.ExportJob{
+					ID:            1,
+					Status:        models.ExportStatusProcessing,
+					TotalRows:     4,
+					ProcessedRows: 1,
+				}, nil)
+			},
+		},
+		{
+			name: "not found",
+			doMock: func(th testServiceHelper) {
+				th.mockExportJobRepository.EXPECT().GetByID(gomock.Any(), uint64(1)).Return(nil, common.ErrDataNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name: "failed sign url",
+			doMock: func(th testServiceHelper) {
+				th.mockExportJobRepository.EXPECT().GetByID(gomock.Any(), uint64(1)).Return(&models.ExportJob{
+					ID:       1,
+					Status:   models.ExportStatusSuccess,
+					FilePath: "exports/2025/01/transaction-1.csv",
+				}, nil)
+				th.mockGcs.EXPECT().GetSignedURL(gomock.Any(), gomock.Any()).Return("", assert.AnError)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testHelper := serviceTestHelper(t)
+			tt.doMock(testHelper)
+
+			res, err := testHelper.exportSvc.GetExport(context.Background(), 1)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			if err == nil {
+				assert.Equal(t, tt.wantURL, res.DownloadURL)
+			}
+		})
+	}
+}
+
+func TestExportService_ProcessExportTaskQueue(t *testing.T) {
+	createdAt := time.Date(2025, 2, 1, 8, 0, 0, 0, time.UTC)
+	newJob := func(gz bool) *models.ExportJob {
+		return &models.ExportJob{
+			ID:        1,
+			Format:    "CSV",
+			Gzip:      gz,
+			Filter:    exportFilter(),
+			Status:    models.ExportStatusPending,
+			CreatedAt: createdAt,
+		}
+	}
+	streamTransactions := func(results ...models.TransactionStreamResult) func(context.Context, models.TransactionFilterOptions) <-chan models.TransactionStreamResult {
+		return func(context.Context, models.TransactionFilterOptions) <-chan models.TransactionStreamResult {
+			chanTrx := make(chan models.TransactionStreamResult)
+			go func() {
+				defer close(chanTrx)
+				for _, result := range results {
+					chanTrx <- result
+				}
+			}()
+			return chanTrx
+		}
+	}
+	trx := models.Transaction{TransactionID: "trx-1", RefNumber: "ref-1", TypeTransaction: "TUPVA"}
+
+	tests := []struct {
+		name       string
+		gzip       bool
+		doMock     func(th testServiceHelper, file *exportFileWriter)
+		wantStatus string
+		wantRows   []string
+		wantErr    bool
+	}{
+		{
+			name: "success",
+			doMock: func(th testServiceHelper, file *exportFileWriter) {
+				th.mockExportJobRepository.EXPECT().Claim(gomock.Any(), uint64(1), 10*time.Minute).Return(true, nil)
+				th.mockTrxRepository.EXPECT().CountAll(gomock.Any(), gomock.Any()).Return(1, nil)
+				th.mockExportJobRepository.EXPECT().UpdateProgress(gomock.Any(), uint64(1), int64(1), int64(0)).Return(nil)
+				th.mockMasterData.EXPECT().GetListOrderType(gomock.Any(), models.FilterMasterData{}).Return([]models.OrderType{}, nil)
+				th.mockGcs.EXPECT().NewWriter(gomock.Any(), &models.CloudStoragePayload{
+					Filename: "transaction-1-2025-01-01_2025-01-31.csv",
+					Path:     "exports/2025/02",
+				}).Return(file)
+				th.mockTrxRepository.EXPECT().StreamAll(gomock.Any(), gomock.Any()).
+					DoAndReturn(streamTransactions(models.TransactionStreamResult{Data: trx}))
+			},
+			wantStatus: models.ExportStatusSuccess,
+			wantRows:   []string{"Transaction ID", "trx-1,ref-1"},
+		},
+		{
+			name: "success gzip",
+			gzip: true,
+			doMock: func(th testServiceHelper, file *exportFileWriter) {
+				th.mockExportJobRepository.EXPECT().Claim(gomock.Any(), uint64(1), gomock.Any()).Return(true, nil)
+				th.mockTrxRepository.EXPECT().CountAll(gomock.Any(), gomock.Any()).Return(1, nil)
+				th.mockExportJobRepository.EXPECT().UpdateProgress(gomock.Any(), uint64(1), int64(1), int64(0)).Return(nil)
+				th.mockMasterData.EXPECT().GetListOrderType(gomock.Any(), gomock.Any()).Return([]models.OrderType{}, nil)
+				th.mockGcs.EXPECT().NewWriter(gomock.Any(), gomock.Any()).Return(file)
+				th.mockTrxRepository.EXPECT().StreamAll(gomock.Any(), gomock.Any()).
+					DoAndReturn(streamTransactions(models.TransactionStreamResult{Data: trx}))
+			},
+			wantStatus: models.ExportStatusSuccess,
+			wantRows:   []string{"Transaction ID", "trx-1,ref-1"},
+		},
+		{
+			name: "skip job claimed by another worker",
+			doMock: func(th testServiceHelper, file *exportFileWriter) {
+				th.mockExportJobRepository.EXPECT().Claim(gomock.Any(), uint64(1), gomock.Any()).Return(false, nil)
+			},
+		},
+		{
+			name: "failed read stream",
+			doMock: func(th testServiceHelper, file *exportFileWriter) {
+				th.mockExportJobRepository.EXPECT().Claim(gomock.Any(), uint64(1), gomock.Any()).Return(true, nil)
+				th.mockTrxRepository.EXPECT().CountAll(gomock.Any(), gomock.Any()).Return(1, nil)
+				th.mockExportJobRepository.EXPECT().UpdateProgress(gomock.Any(), uint64(1), int64(1), int64(0)).Return(nil)
+				th.mockMasterData.EXPECT().GetListOrderType(gomock.Any(), gomock.Any()).Return([]models.OrderType{}, nil)
+				th.mockGcs.EXPECT().NewWriter(gomock.Any(), gomock.Any()).Return(file)
+				th.mockTrxRepository.EXPECT().StreamAll(gomock.Any(), gomock.Any()).
+					DoAndReturn(streamTransactions(models.TransactionStreamResult{Err: assert.AnError}))
+			},
+			wantStatus: models.ExportStatusFailed,
+			wantErr:    true,
+		},
+		{
+			name: "failed count transactions",
+			doMock: func(th testServiceHelper, file *exportFileWriter) {
+				th.mockExportJobRepository.EXPECT().Claim(gomock.Any(), uint64(1), gomock.Any()).Return(true, nil)
+				th.mockTrxRepository.EXPECT().CountAll(gomock.Any(), gomock.Any()).Return(0, assert.AnError)
+			},
+			wantStatus: models.ExportStatusFailed,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testHelper := serviceTestHelper(t)
+			file := &exportFileWriter{}
+
+			job := newJob(tt.gzip)
+			testHelper.mockExportJobRepository.EXPECT().GetByID(gomock.Any(), uint64(1)).Return(job, nil)
+			tt.doMock(testHelper, file)
+			if tt.wantStatus != "" {
+				testHelper.mockExportJobRepository.EXPECT().Finish(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, job *models.ExportJob) error {
+						assert.Equal(t, tt.wantStatus, job.Status)
+						return nil
+					})
+			}
+
+			err := testHelper.exportSvc.ProcessExportTaskQueue(context.Background(), 1)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+
+			if tt.wantRows == nil {
+				return
+			}
+
+			assert.True(t, file.closed)
+			assert.Equal(t, "exports/2025/02/"+job.CloudStoragePayload().Filename, job.FilePath)
+
+			var content io.Reader = &file.Buffer
+			if tt.gzip {
+				gz, errGzip := gzip.NewReader(content)
+				require.NoError(t, errGzip)
+				content = gz
+			}
+			raw, errRead := io.ReadAll(content)
+			require.NoError(t, errRead)
+
+			lines := strings.Split(strings.TrimSpace(string(raw)), "\n")
+			require.Len(t, lines, len(tt.wantRows))
+			for i, prefix := range tt.wantRows {
+				assert.True(t, strings.HasPrefix(lines[i], prefix), lines[i])
+			}
+		})
+	}
+}
@@ -36,8 +36,8 @@ var _ FileService = (*file)(nil)
 func (s *file) Upload(ctx context.Context, file *multipart.FileHeader) error {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	reportNumber, nowDate, err := s.getReportNumberFromCache(ctx, models.TransactionIDManualPrefix)
 	if err != nil {
@@ -85,8 +85,8 @@ func (s *file) UploadWalletTransaction(ctx context.Context, file *multipart.File
 		hasFailedData = false
 	)
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	reportNumber, nowDate, err := s.getReportNumberFromCache(ctx, models.WalletTransactionIDManualPrefix)
 	if err != nil {
@@ -210,8 +210,8 @@ func (s *file) sendErrorToEmail(ctx context.Context, fileBuffer bytes.Buffer, re
 }
 
 func (s *file) logProcessError(ctx context.Context, refNumber string, processErr error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(processErr))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(processErr)) }()
 
 	username, ok := ctx.Value(models.CtxKeyNgmisHeader).(string)
 	if !ok {
@@ -289,8 +289,8 @@ func (s *file) getReportNumberFromCache(ctx context.Context, cachePrefix string)
 }
 
 func (s *file) UploadWalletTransactionFromGCS(ctx context.Context, filePath, bucketName, clientID string, isPublish bool) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	errChannels := make(chan models.ErrWalletTransaction, 0)
 	var wg sync.WaitGroup
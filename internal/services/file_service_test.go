@@ -290,6 +290,8 @@ func TestFileService_UploadWalletTransaction(t *testing.T) {
 						walletTrxRepo.EXPECT().
 							Create(gomock.AssignableToTypeOf(ctx), gomock.AssignableToTypeOf(newWalletTrx)).
 							Return(&created, nil)
+						walletTrxRepo.EXPECT().CreateBalances(gomock.Any(), gomock.Any()).
+							Return(nil)
 						acuanRepo.EXPECT().StoreBulkTransaction(gomock.Any(), gomock.Any()).
 							Return(nil)
 						testHelper.mockCacheRepository.EXPECT().Del(gomock.AssignableToTypeOf(ctx), gomock.AssignableToTypeOf([]string{})).
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+)
+
+type LedgerReportService interface {
+	// GetTrialBalance return opening balance, debit, credit and closing balance per account of the entity
+	// grouped by category and sub category
+	GetTrialBalance(ctx context.Context, req models.DoGetTrialBalanceRequest) (report *models.DoGetTrialBalanceResponse, err error)
+
+	// GetGeneralLedger return the postings of the account with running balance
+	GetGeneralLedger(ctx context.Context, req models.DoGetGeneralLedgerRequest) (report *models.DoGetGeneralLedgerResponse, err error)
+}
+
+type ledgerReport service
+
+var _ LedgerReportService = (*ledgerReport)(nil)
+
+func (s *ledgerReport) GetTrialBalance(ctx context.Context, req models.DoGetTrialBalanceRequest) (report *models.DoGetTrialBalanceResponse, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	opts, err := req.ToFilterOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	accounts, err := s.srv.sqlRepo.GetLedgerRepository().GetTrialBalance(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trial balance: %w", err)
+	}
+
+	result := models.NewTrialBalance(opts, accounts)
+
+	return &result, nil
+}
+
+func (s *ledgerReport) GetGeneralLedger(ctx context.Context, req models.DoGetGeneralLedgerRequest) (report *models.DoGetGeneralLedgerResponse, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	opts, err := req.ToFilterOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.srv.sqlRepo.GetAccountRepository().GetOneByAccountNumber(ctx, opts.AccountNumber)
+	if err != nil {
+		if errors.Is(err, common.ErrNoRows) {
+			return nil, fmt.Errorf("account %s %w", opts.AccountNumber, common.ErrDataNotFound)
+		}
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	repo := s.srv.sqlRepo.GetLedgerRepository()
+
+	openingBalance, err := repo.GetOpeningBalance(ctx, opts.AccountNumber, opts.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get opening balance: %w", err)
+	}
+
+	postings, err := repo.GetPostings(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get postings: %w", err)
+	}
+
+	result := models.NewGeneralLedger(opts, account, openingBalance, postings)
+
+	return &result, nil
+}
@@ -0,0 +1,168 @@
+package services_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLedgerReportService_GetTrialBalance(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     models.DoGetTrialBalanceRequest
+		doMock  func(th testServiceHelper)
+		check   func(t *testing.T, report *models.DoGetTrialBalanceResponse)
+		wantErr error
+	}{
+		{
+			name: "success debits equal credits",
+			req:  models.DoGetTrialBalanceRequest{Entity: "001", Date: "2025-01-31", From: "2025-01-01"},
+			doMock: func(th testServiceHelper) {
+				th.mockLedgerRepository.EXPECT().GetTrialBalance(gomock.Any(), models.TrialBalanceFilterOptions{
+					Entity:    "001",
+					StartDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					EndDate:   time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+				}).Return([]models.TrialBalanceAccount{
+					{AccountNumber: "21100100000001", CategoryCode: "211", SubCategoryCode: "21100", OpeningBalance: decimal.NewFromInt(1000), Credit: decimal.NewFromInt(500)},
+					{AccountNumber: "14100100000001", CategoryCode: "141", SubCategoryCode: "14100", OpeningBalance: decimal.NewFromInt(-1000), Debit: decimal.NewFromInt(500)},
+				}, nil)
+			},
+			check: func(t *testing.T, report *models.DoGetTrialBalanceResponse) {
+				assert.True(t, report.IsBalanced)
+				assert.True(t, report.Total.Debit.Equal(report.Total.Credit))
+				assert.True(t, decimal.NewFromInt(500).Equal(report.Total.Debit))
+				require.Len(t, report.Categories, 2)
+				assert.Equal(t, "141", report.Categories[0].Code)
+				assert.True(t, decimal.NewFromInt(1500).Equal(report.Categories[1].Total.ClosingBalance))
+			},
+		},
+		{
+			name: "success date without movement",
+			req:  models.DoGetTrialBalanceRequest{Date: "2025-01-31"},
+			doMock: func(th testServiceHelper) {
+				th.mockLedgerRepository.EXPECT().GetTrialBalance(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+			check: func(t *testing.T, report *models.DoGetTrialBalanceResponse) {
+				assert.True(t, report.IsBalanced)
+				assert.Equal(t, "2025-01-31", report.StartDate)
+				assert.Empty(t, report.Categories)
+			},
+		},
+		{
+			name:    "invalid date range",
+			req:     models.DoGetTrialBalanceRequest{Date: "2025-01-01", From: "2025-01-31"},
+			wantErr: common.ErrInvalidDateRange,
+		},
+		{
+			name: "failed get trial balance",
+			req:  models.DoGetTrialBalanceRequest{Date: "2025-01-31"},
+			doMock: func(th testServiceHelper) {
+				th.mockLedgerRepository.EXPECT().GetTrialBalance(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := serviceTestHelper(t)
+			if tt.doMock != nil {
+				tt.doMock(th)
+			}
+
+			report, err := th.ledgerReportSvc.GetTrialBalance(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.check(t, report)
+		})
+	}
+}
+
+func TestLedgerReportService_GetGeneralLedger(t *testing.T) {
+	req := models.DoGetGeneralLedgerRequest{Account: "21100100000001", From: "2025-01-01", To: "2025-01-31"}
+	opts := models.GeneralLedgerFilterOptions{
+		AccountNumber: "21100100000001",
+		StartDate:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:       time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name    string
+		doMock  func(th testServiceHelper)
+		wantErr error
+	}{
+		{
+			name: "success",
+			doMock: func(th testServiceHelper) {
+				th.mockAccRepository.EXPECT().GetOneByAccountNumber(gomock.Any(), "21100100000001").
+					Return(models.GetAccountOut{AccountNumber: "21100100000001", Entity: "001"}, nil)
+				th.mockLedgerRepository.EXPECT().GetOpeningBalance(gomock.Any(), "21100100000001", opts.StartDate).
+					Return(decimal.NewFromInt(1000), nil)
+				th.mockLedgerRepository.EXPECT().GetPostings(gomock.Any(), opts).Return([]models.LedgerPosting{
+					{ID: 1, FromAccount: "14100100000001", ToAccount: "21100100000001", Amount: decimal.NewFromInt(500)},
+					{ID: 2, FromAccount: "21100100000001", ToAccount: "14100100000001", Amount: decimal.NewFromInt(200)},
+				}, nil)
+			},
+		},
+		{
+			name: "account not found",
+			doMock: func(th testServiceHelper) {
+				th.mockAccRepository.EXPECT().GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{}, sql.ErrNoRows)
+			},
+			wantErr: common.ErrDataNotFound,
+		},
+		{
+			name: "failed get opening balance",
+			doMock: func(th testServiceHelper) {
+				th.mockAccRepository.EXPECT().GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{AccountNumber: "21100100000001"}, nil)
+				th.mockLedgerRepository.EXPECT().GetOpeningBalance(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(decimal.Decimal{}, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "failed get postings",
+			doMock: func(th testServiceHelper) {
+				th.mockAccRepository.EXPECT().GetOneByAccountNumber(gomock.Any(), gomock.Any()).
+					Return(models.GetAccountOut{AccountNumber: "21100100000001"}, nil)
+				th.mockLedgerRepository.EXPECT().GetOpeningBalance(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(decimal.NewFromInt(1000), nil)
+				th.mockLedgerRepository.EXPECT().GetPostings(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := serviceTestHelper(t)
+			tt.doMock(th)
+
+			report, err := th.ledgerReportSvc.GetGeneralLedger(context.Background(), req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "001", report.EntityCode)
+			assert.Len(t, report.Entries, 2)
+			assert.True(t, decimal.NewFromInt(1300).Equal(report.ClosingBalance))
+		})
+	}
+}
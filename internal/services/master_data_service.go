@@ -2,12 +2,17 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
 
+	xlog "bitbucket.org/Amartha/go-x/log"
+
 	"golang.org/x/exp/slices"
 )
 
@@ -20,6 +25,14 @@ type MasterDataService interface {
 	CreateOrderType(ctx context.Context, ot models.OrderType) (err error)
 	UpdateOrderType(ctx context.Context, ot models.OrderType) (err error)
 
+	CreateTransactionType(ctx context.Context, req models.CreateTransactionTypeRequest) (output *models.OrderType, err error)
+	UpdateTransactionType(ctx context.Context, req models.UpdateTransactionTypeRequest) (output *models.OrderType, err error)
+	DeactivateTransactionType(ctx context.Context, req models.DeactivateTransactionTypeRequest) (output *models.OrderType, err error)
+
+	// GetConsistencyReport cross check master data against registered transformers,
+	// accepted transaction type config and money flow transaction mapping
+	GetConsistencyReport(ctx context.Context) (output *models.MasterDataConsistencyReport, err error)
+
 	GetAllVATConfig(ctx context.Context) (output []models.ConfigVatRevenue, err error)
 	UpsertVATConfig(ctx context.Context, configs []models.ConfigVatRevenue) (err error)
 }
@@ -28,8 +41,8 @@ type masterData service
 
 // GetOneTransactionType implements MasterDataService.
 func (m *masterData) GetOneTransactionType(ctx context.Context, transactionTypeCode string) (output *models.TransactionType, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	return m.srv.masterDataRepo.GetTransactionType(ctx, transactionTypeCode)
 }
@@ -37,30 +50,30 @@ func (m *masterData) GetOneTransactionType(ctx context.Context, transactionTypeC
 var _ MasterDataService = (*masterData)(nil)
 
 func (m *masterData) GetAllOrderType(ctx context.Context, filter models.FilterMasterData) (output []models.OrderType, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	return m.srv.masterDataRepo.GetListOrderType(ctx, filter)
 }
 
 // GetOneOrderType implements MasterDataService.
 func (m *masterData) GetOneOrderType(ctx context.Context, orderTypeCode string) (output *models.OrderType, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	return m.srv.masterDataRepo.GetOrderType(ctx, orderTypeCode)
 }
 
 func (m *masterData) GetAllTransactionType(ctx context.Context, filter models.FilterMasterData) (output []models.TransactionType, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	return m.srv.masterDataRepo.GetListTransactionType(ctx, filter)
 }
 
 func (m *masterData) CreateOrderType(ctx context.Context, ot models.OrderType) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	codes, err := m.srv.masterDataRepo.GetListOrderTypeCode(ctx)
 	if err != nil {
@@ -75,8 +88,8 @@ func (m *masterData) CreateOrderType(ctx context.Context, ot models.OrderType) (
 }
 
 func (m *masterData) UpdateOrderType(ctx context.Context, ot models.OrderType) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	codes, err := m.srv.masterDataRepo.GetListOrderTypeCode(ctx)
 	if err != nil {
@@ -91,6 +104,115 @@ func (m *masterData) UpdateOrderType(ctx context.Context, ot models.OrderType) (
 	return m.srv.masterDataRepo.UpsertOrderType(ctx, ot)
 }
 
+// CreateTransactionType add transaction type into order type, transaction type code must be unique across order types
+func (m *masterData) CreateTransactionType(ctx context.Context, req models.CreateTransactionTypeRequest) (output *models.OrderType, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	_, err = m.srv.masterDataRepo.GetTransactionType(ctx, req.TransactionTypeCode)
+	if err == nil {
+		return nil, common.ErrDataExist
+	}
+	if !errors.Is(err, common.ErrDataNotFound) {
+		return nil, err
+	}
+
+	return m.changeTransactionTypes(ctx, req.OrderTypeCode, req.Version, func(ot *models.OrderType) error {
+		ot.TransactionTypes = append(ot.TransactionTypes, req.ToTransactionType())
+		return nil
+	})
+}
+
+func (m *masterData) UpdateTransactionType(ctx context.Context, req models.UpdateTransactionTypeRequest) (output *models.OrderType, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	return m.changeTransactionTypes(ctx, req.OrderTypeCode, req.Version, func(ot *models.OrderType) error {
+		for i := range ot.TransactionTypes {
+			if ot.TransactionTypes[i].TransactionTypeCode == req.TransactionTypeCode {
+				req.ApplyTo(&ot.TransactionTypes[i])
+				return nil
+			}
+		}
+
+		return common.ErrDataNotFound
+	})
+}
+
+// DeactivateTransactionType stop accepting the transaction type, it is kept in master data for existing transactions
+func (m *masterData) DeactivateTransactionType(ctx context.Context, req models.DeactivateTransactionTypeRequest) (output *models.OrderType, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	return m.UpdateTransactionType(ctx, models.UpdateTransactionTypeRequest{
+		OrderTypeCode:       req.OrderTypeCode,
+		TransactionTypeCode: req.TransactionTypeCode,
+		Status:              models.TransactionTypeStatusInactive,
+		Version:             req.Version,
+	})
+}
+
+// changeTransactionTypes apply change on transaction types of the order type and store it,
+// the stored order type is returned so the caller get the new version
+func (m *masterData) changeTransactionTypes(ctx context.Context, orderTypeCode string, version int64, change func(ot *models.OrderType) error) (*models.OrderType, error) {
+	current, err := m.srv.masterDataRepo.GetOrderType(ctx, orderTypeCode)
+	if err != nil {
+		return nil, err
+	}
+
+	// order type is shared with master data in memory, it must not be changed in place
+	orderType := *current
+	orderType.TransactionTypes = slices.Clone(current.TransactionTypes)
+	if err = change(&orderType); err != nil {
+		return nil, err
+	}
+
+	orderType.Version = version
+	if err = m.srv.masterDataRepo.UpsertOrderType(ctx, orderType); err != nil {
+		return nil, err
+	}
+
+	return m.srv.masterDataRepo.GetOrderType(ctx, orderTypeCode)
+}
+
+func (m *masterData) GetConsistencyReport(ctx context.Context) (output *models.MasterDataConsistencyReport, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	orderTypes, err := m.srv.masterDataRepo.GetListOrderType(ctx, models.FilterMasterData{})
+	if err != nil {
+		return nil, err
+	}
+
+	src := models.MasterDataConsistencySources{
+		OrderTypes:               orderTypes,
+		TransformerTypes:         m.srv.WalletTrx.newMapTransformer().TransactionTypes(),
+		AcceptedTransactionTypes: m.srv.conf.TransactionValidationConfig.AcceptedTransactionType,
+		MoneyFlowRulesSource:     models.MoneyFlowRulesSourceUnavailable,
+	}
+
+	// report is still useful without money flow, so missing business rules is not an error
+	rules, errRules := m.srv.MoneyFlowCalc.loadBusinessRules(ctx)
+	if errRules != nil {
+		xlog.Warn(ctx, "money flow business rules is not available for consistency report", xlog.Err(errRules))
+	} else {
+		src.MoneyFlowRulesSource = models.MoneyFlowRulesSourceFeatureFlag
+		if rules.Version > 0 {
+			src.MoneyFlowRulesSource = models.MoneyFlowRulesSourcePublished
+			src.MoneyFlowRulesVersion = rules.Version
+		}
+
+		for transactionType := range rules.TransactionToPaymentMap {
+			src.MoneyFlowTransactionTypes = append(src.MoneyFlowTransactionTypes, transactionType)
+		}
+		sort.Strings(src.MoneyFlowTransactionTypes)
+	}
+
+	report := models.BuildMasterDataConsistencyReport(src, time.Now())
+
+	return &report, nil
+}
+
 func (m *masterData) EnsureTransactionTypeExist(ctx context.Context, transactionTypes []string) (err error) {
 	masterTrxTypes, err := m.srv.masterDataRepo.GetListTransactionTypeCode(ctx)
 	if err != nil {
@@ -128,15 +250,15 @@ func (m *masterData) EnsureOrderTypeExist(ctx context.Context, orderTypes []stri
 }
 
 func (m *masterData) GetAllVATConfig(ctx context.Context) (output []models.ConfigVatRevenue, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	return m.srv.masterDataRepo.GetConfigVATRevenue(ctx)
 }
 
 func (m *masterData) UpsertVATConfig(ctx context.Context, configs []models.ConfigVatRevenue) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	return m.srv.masterDataRepo.UpsertConfigVATRevenue(ctx, configs)
 }
@@ -3,12 +3,16 @@ package services_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 
+	"github.com/Unleash/unleash-client-go/v3/api"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 )
 
 func Test_masterData_CreateOrderType(t *testing.T) {
@@ -515,3 +519,201 @@ func Test_masterData_UpsertVATConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_masterData_CreateTransactionType(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	ctx := context.Background()
+
+	orderType := models.OrderType{
+		OrderTypeCode: "1002",
+		OrderTypeName: "Cashout Lender P2P",
+		Version:       3,
+		TransactionTypes: []models.TransactionType{
+			{TransactionTypeCode: "1002001", TransactionTypeName: "Request Cashout"},
+		},
+	}
+	req := models.CreateTransactionTypeRequest{
+		OrderTypeCode:       "1002",
+		TransactionTypeCode: "1002002",
+		TransactionTypeName: "Reject Request Cashout",
+		Version:             3,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		stored := orderType
+		stored.Version = 4
+
+		testHelper.mockMasterData.EXPECT().GetTransactionType(ctx, req.TransactionTypeCode).Return(nil, common.ErrDataNotFound)
+		testHelper.mockMasterData.EXPECT().GetOrderType(ctx, req.OrderTypeCode).Return(&orderType, nil)
+		testHelper.mockMasterData.EXPECT().UpsertOrderType(ctx, models.OrderType{
+			OrderTypeCode: "1002",
+			OrderTypeName: "Cashout Lender P2P",
+			Version:       3,
+			TransactionTypes: []models.TransactionType{
+				{TransactionTypeCode: "1002001", TransactionTypeName: "Request Cashout"},
+				{TransactionTypeCode: "1002002", TransactionTypeName: "Reject Request Cashout", Status: models.TransactionTypeStatusActive},
+			},
+		}).Return(nil)
+		testHelper.mockMasterData.EXPECT().GetOrderType(ctx, req.OrderTypeCode).Return(&stored, nil)
+
+		got, err := testHelper.masterDataService.CreateTransactionType(ctx, req)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(4), got.Version)
+		// cached master data must not be changed before it is stored
+		assert.Len(t, orderType.TransactionTypes, 1)
+	})
+
+	t.Run("failed, transaction type code already exists", func(t *testing.T) {
+		testHelper.mockMasterData.EXPECT().GetTransactionType(ctx, req.TransactionTypeCode).Return(&models.TransactionType{}, nil)
+
+		_, err := testHelper.masterDataService.CreateTransactionType(ctx, req)
+		assert.ErrorIs(t, err, common.ErrDataExist)
+	})
+
+	t.Run("failed, version conflict", func(t *testing.T) {
+		testHelper.mockMasterData.EXPECT().GetTransactionType(ctx, req.TransactionTypeCode).Return(nil, common.ErrDataNotFound)
+		testHelper.mockMasterData.EXPECT().GetOrderType(ctx, req.OrderTypeCode).Return(&orderType, nil)
+		testHelper.mockMasterData.EXPECT().UpsertOrderType(ctx, gomock.Any()).Return(common.ErrMasterDataVersionConflict)
+
+		_, err := testHelper.masterDataService.CreateTransactionType(ctx, req)
+		assert.ErrorIs(t, err, common.ErrMasterDataVersionConflict)
+	})
+}
+
+func Test_masterData_UpdateTransactionType(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	ctx := context.Background()
+	effectiveFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	orderType := models.OrderType{
+		OrderTypeCode: "1002",
+		OrderTypeName: "Cashout Lender P2P",
+		TransactionTypes: []models.TransactionType{
+			{TransactionTypeCode: "1002001", TransactionTypeName: "Request Cashout"},
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		testHelper.mockMasterData.EXPECT().GetOrderType(ctx, "1002").Return(&orderType, nil)
+		testHelper.mockMasterData.EXPECT().UpsertOrderType(ctx, models.OrderType{
+			OrderTypeCode: "1002",
+			OrderTypeName: "Cashout Lender P2P",
+			TransactionTypes: []models.TransactionType{
+				{TransactionTypeCode: "1002001", TransactionTypeName: "Request Cashout", EffectiveFrom: &effectiveFrom},
+			},
+		}).Return(nil)
+		testHelper.mockMasterData.EXPECT().GetOrderType(ctx, "1002").Return(&orderType, nil)
+
+		_, err := testHelper.masterDataService.UpdateTransactionType(ctx, models.UpdateTransactionTypeRequest{
+			OrderTypeCode:       "1002",
+			TransactionTypeCode: "1002001",
+			EffectiveFrom:       &effectiveFrom,
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, orderType.TransactionTypes[0].EffectiveFrom)
+	})
+
+	t.Run("failed, transaction type not in order type", func(t *testing.T) {
+		testHelper.mockMasterData.EXPECT().GetOrderType(ctx, "1002").Return(&orderType, nil)
+
+		_, err := testHelper.masterDataService.UpdateTransactionType(ctx, models.UpdateTransactionTypeRequest{
+			OrderTypeCode:       "1002",
+			TransactionTypeCode: "1001001",
+		})
+		assert.ErrorIs(t, err, common.ErrDataNotFound)
+	})
+}
+
+func Test_masterData_DeactivateTransactionType(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	ctx := context.Background()
+
+	orderType := models.OrderType{
+		OrderTypeCode: "1002",
+		OrderTypeName: "Cashout Lender P2P",
+		Version:       2,
+		TransactionTypes: []models.TransactionType{
+			{TransactionTypeCode: "1002001", TransactionTypeName: "Request Cashout"},
+		},
+	}
+
+	testHelper.mockMasterData.EXPECT().GetOrderType(ctx, "1002").Return(&orderType, nil)
+	testHelper.mockMasterData.EXPECT().UpsertOrderType(ctx, models.OrderType{
+		OrderTypeCode: "1002",
+		OrderTypeName: "Cashout Lender P2P",
+		Version:       2,
+		TransactionTypes: []models.TransactionType{
+			{TransactionTypeCode: "1002001", TransactionTypeName: "Request Cashout", Status: models.TransactionTypeStatusInactive},
+		},
+	}).Return(nil)
+	testHelper.mockMasterData.EXPECT().GetOrderType(ctx, "1002").Return(&orderType, nil)
+
+	_, err := testHelper.masterDataService.DeactivateTransactionType(ctx, models.DeactivateTransactionTypeRequest{
+		OrderTypeCode:       "1002",
+		TransactionTypeCode: "1002001",
+		Version:             2,
+	})
+	assert.NoError(t, err)
+}
+
+func Test_masterData_GetConsistencyReport(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	ctx := context.Background()
+
+	testHelper.mockFlagClient.EXPECT().
+		IsEnabled(testHelper.config.FeatureFlagKeyLookup.UseAccountConfigFromExternal).
+		Return(false).AnyTimes()
+
+	orderTypes := []models.OrderType{
+		{
+			OrderTypeCode: "1002",
+			TransactionTypes: []models.TransactionType{
+				{TransactionTypeCode: "NOTRF", TransactionTypeName: "No Transformer"},
+				{TransactionTypeCode: "ACRF", TransactionTypeName: "Accrual", Status: models.TransactionTypeStatusInactive},
+			},
+		},
+	}
+
+	t.Run("money flow from feature flag", func(t *testing.T) {
+		testHelper.mockMasterData.EXPECT().GetListOrderType(ctx, models.FilterMasterData{}).Return(orderTypes, nil)
+		testHelper.mockFlagClient.EXPECT().
+			GetVariant(testHelper.config.FeatureFlagKeyLookup.MoneyFlowCalcBusinessRulesConfig).
+			Return(&api.Variant{
+				Name: "business_rules",
+				Payload: api.Payload{
+					Type: "json",
+					Value: `{"payment_configs":{"MF_UNKNOWN":{"transaction_type":"UNKWN"}},` +
+						`"transaction_to_payment_map":{"UNKWN":"MF_UNKNOWN"}}`,
+				},
+				Enabled: true,
+			})
+
+		got, err := testHelper.masterDataService.GetConsistencyReport(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, models.MoneyFlowRulesSourceFeatureFlag, got.MoneyFlowRulesSource)
+		assert.Equal(t, 2, got.TotalTransactionTypes)
+		assert.Equal(t, 1, got.TotalMoneyFlowMappings)
+		assert.Contains(t, got.Issues, models.MasterDataConsistencyIssue{TransactionTypeCode: "NOTRF", Issue: models.ConsistencyIssueAcceptedWithoutTransformer})
+		assert.Contains(t, got.Issues, models.MasterDataConsistencyIssue{TransactionTypeCode: "ACRF", Issue: models.ConsistencyIssueInactiveButAccepted})
+		assert.Contains(t, got.Issues, models.MasterDataConsistencyIssue{TransactionTypeCode: "UNKWN", Issue: models.ConsistencyIssueMoneyFlowUndefined})
+	})
+
+	t.Run("money flow rules unavailable", func(t *testing.T) {
+		testHelper.mockMasterData.EXPECT().GetListOrderType(ctx, models.FilterMasterData{}).Return(orderTypes, nil)
+		testHelper.mockFlagClient.EXPECT().
+			GetVariant(testHelper.config.FeatureFlagKeyLookup.MoneyFlowCalcBusinessRulesConfig).
+			Return(nil)
+
+		got, err := testHelper.masterDataService.GetConsistencyReport(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, models.MoneyFlowRulesSourceUnavailable, got.MoneyFlowRulesSource)
+		assert.Zero(t, got.TotalMoneyFlowMappings)
+	})
+
+	t.Run("failed get order types", func(t *testing.T) {
+		testHelper.mockMasterData.EXPECT().GetListOrderType(ctx, models.FilterMasterData{}).Return(nil, assert.AnError)
+
+		_, err := testHelper.masterDataService.GetConsistencyReport(ctx)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,178 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/approval_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/approval_service.go -destination=./internal/services/mock/approval_service_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockApprovalService is a mock of ApprovalService interface.
+type MockApprovalService struct {
+	ctrl     *gomock.Controller
+	recorder *MockApprovalServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockApprovalServiceMockRecorder is the mock recorder for MockApprovalService.
+type MockApprovalServiceMockRecorder struct {
+	mock *MockApprovalService
+}
+
+// NewMockApprovalService creates a new mock instance.
+func NewMockApprovalService(ctrl *gomock.Controller) *MockApprovalService {
+	mock := &MockApprovalService{ctrl: ctrl}
+	mock.recorder = &MockApprovalServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockApprovalService) EXPECT() *MockApprovalServiceMockRecorder {
+	return m.recorder
+}
+
+// Approve mocks base method.
+func (m *MockApprovalService) Approve(ctx context.Context, req models.DecideApprovalRequest) (*models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, req)
+	ret0, _ := ret[0].(*models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockApprovalServiceMockRecorder) Approve(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockApprovalService)(nil).Approve), ctx, req)
+}
+
+// Comment mocks base method.
+func (m *MockApprovalService) Comment(ctx context.Context, req models.CommentApprovalRequest) (*models.ApprovalActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Comment", ctx, req)
+	ret0, _ := ret[0].(*models.ApprovalActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Comment indicates an expected call of Comment.
+func (mr *MockApprovalServiceMockRecorder) Comment(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Comment", reflect.TypeOf((*MockApprovalService)(nil).Comment), ctx, req)
+}
+
+// ExecuteApproved mocks base method.
+func (m *MockApprovalService) ExecuteApproved(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteApproved", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteApproved indicates an expected call of ExecuteApproved.
+func (mr *MockApprovalServiceMockRecorder) ExecuteApproved(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteApproved", reflect.TypeOf((*MockApprovalService)(nil).ExecuteApproved), ctx, now)
+}
+
+// ExpirePending mocks base method.
+func (m *MockApprovalService) ExpirePending(ctx context.Context, now time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpirePending", ctx, now)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpirePending indicates an expected call of ExpirePending.
+func (mr *MockApprovalServiceMockRecorder) ExpirePending(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpirePending", reflect.TypeOf((*MockApprovalService)(nil).ExpirePending), ctx, now)
+}
+
+// GetByID mocks base method.
+func (m *MockApprovalService) GetByID(ctx context.Context, id uint64) (*models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockApprovalServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockApprovalService)(nil).GetByID), ctx, id)
+}
+
+// IsRequired mocks base method.
+func (m *MockApprovalService) IsRequired(operation models.ApprovalOperation) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsRequired", operation)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsRequired indicates an expected call of IsRequired.
+func (mr *MockApprovalServiceMockRecorder) IsRequired(operation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsRequired", reflect.TypeOf((*MockApprovalService)(nil).IsRequired), operation)
+}
+
+// List mocks base method.
+func (m *MockApprovalService) List(ctx context.Context, opts models.ApprovalFilterOptions) ([]models.Approval, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, opts)
+	ret0, _ := ret[0].([]models.Approval)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockApprovalServiceMockRecorder) List(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockApprovalService)(nil).List), ctx, opts)
+}
+
+// Reject mocks base method.
+func (m *MockApprovalService) Reject(ctx context.Context, req models.DecideApprovalRequest) (*models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, req)
+	ret0, _ := ret[0].(*models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockApprovalServiceMockRecorder) Reject(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockApprovalService)(nil).Reject), ctx, req)
+}
+
+// Submit mocks base method.
+func (m *MockApprovalService) Submit(ctx context.Context, req models.SubmitApprovalRequest) (*models.Approval, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submit", ctx, req)
+	ret0, _ := ret[0].(*models.Approval)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockApprovalServiceMockRecorder) Submit(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockApprovalService)(nil).Submit), ctx, req)
+}
@@ -0,0 +1,86 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/export_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/export_service.go -destination=./internal/services/mock/export_service_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExportService is a mock of ExportService interface.
+type MockExportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockExportServiceMockRecorder is the mock recorder for MockExportService.
+type MockExportServiceMockRecorder struct {
+	mock *MockExportService
+}
+
+// NewMockExportService creates a new mock instance.
+func NewMockExportService(ctrl *gomock.Controller) *MockExportService {
+	mock := &MockExportService{ctrl: ctrl}
+	mock.recorder = &MockExportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportService) EXPECT() *MockExportServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateExport mocks base method.
+func (m *MockExportService) CreateExport(ctx context.Context, req models.DoCreateExportRequest) (*models.ExportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateExport", ctx, req)
+	ret0, _ := ret[0].(*models.ExportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateExport indicates an expected call of CreateExport.
+func (mr *MockExportServiceMockRecorder) CreateExport(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateExport", reflect.TypeOf((*MockExportService)(nil).CreateExport), ctx, req)
+}
+
+// GetExport mocks base method.
+func (m *MockExportService) GetExport(ctx context.Context, id uint64) (*models.DoGetExportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, id)
+	ret0, _ := ret[0].(*models.DoGetExportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockExportServiceMockRecorder) GetExport(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockExportService)(nil).GetExport), ctx, id)
+}
+
+// ProcessExportTaskQueue mocks base method.
+func (m *MockExportService) ProcessExportTaskQueue(ctx context.Context, id uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessExportTaskQueue", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessExportTaskQueue indicates an expected call of ProcessExportTaskQueue.
+func (mr *MockExportServiceMockRecorder) ProcessExportTaskQueue(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessExportTaskQueue", reflect.TypeOf((*MockExportService)(nil).ProcessExportTaskQueue), ctx, id)
+}
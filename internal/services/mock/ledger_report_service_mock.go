@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/ledger_report_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/ledger_report_service.go -destination=./internal/services/mock/ledger_report_service_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLedgerReportService is a mock of LedgerReportService interface.
+type MockLedgerReportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLedgerReportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockLedgerReportServiceMockRecorder is the mock recorder for MockLedgerReportService.
+type MockLedgerReportServiceMockRecorder struct {
+	mock *MockLedgerReportService
+}
+
+// NewMockLedgerReportService creates a new mock instance.
+func NewMockLedgerReportService(ctrl *gomock.Controller) *MockLedgerReportService {
+	mock := &MockLedgerReportService{ctrl: ctrl}
+	mock.recorder = &MockLedgerReportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLedgerReportService) EXPECT() *MockLedgerReportServiceMockRecorder {
+	return m.recorder
+}
+
+// GetGeneralLedger mocks base method.
+func (m *MockLedgerReportService) GetGeneralLedger(ctx context.Context, req models.DoGetGeneralLedgerRequest) (*models.DoGetGeneralLedgerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeneralLedger", ctx, req)
+	ret0, _ := ret[0].(*models.DoGetGeneralLedgerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeneralLedger indicates an expected call of GetGeneralLedger.
+func (mr *MockLedgerReportServiceMockRecorder) GetGeneralLedger(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeneralLedger", reflect.TypeOf((*MockLedgerReportService)(nil).GetGeneralLedger), ctx, req)
+}
+
+// GetTrialBalance mocks base method.
+func (m *MockLedgerReportService) GetTrialBalance(ctx context.Context, req models.DoGetTrialBalanceRequest) (*models.DoGetTrialBalanceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrialBalance", ctx, req)
+	ret0, _ := ret[0].(*models.DoGetTrialBalanceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrialBalance indicates an expected call of GetTrialBalance.
+func (mr *MockLedgerReportServiceMockRecorder) GetTrialBalance(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrialBalance", reflect.TypeOf((*MockLedgerReportService)(nil).GetTrialBalance), ctx, req)
+}
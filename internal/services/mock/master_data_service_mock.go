@@ -55,6 +55,36 @@ func (mr *MockMasterDataServiceMockRecorder) CreateOrderType(ctx, ot any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderType", reflect.TypeOf((*MockMasterDataService)(nil).CreateOrderType), ctx, ot)
 }
 
+// CreateTransactionType mocks base method.
+func (m *MockMasterDataService) CreateTransactionType(ctx context.Context, req models.CreateTransactionTypeRequest) (*models.OrderType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransactionType", ctx, req)
+	ret0, _ := ret[0].(*models.OrderType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransactionType indicates an expected call of CreateTransactionType.
+func (mr *MockMasterDataServiceMockRecorder) CreateTransactionType(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransactionType", reflect.TypeOf((*MockMasterDataService)(nil).CreateTransactionType), ctx, req)
+}
+
+// DeactivateTransactionType mocks base method.
+func (m *MockMasterDataService) DeactivateTransactionType(ctx context.Context, req models.DeactivateTransactionTypeRequest) (*models.OrderType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateTransactionType", ctx, req)
+	ret0, _ := ret[0].(*models.OrderType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateTransactionType indicates an expected call of DeactivateTransactionType.
+func (mr *MockMasterDataServiceMockRecorder) DeactivateTransactionType(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateTransactionType", reflect.TypeOf((*MockMasterDataService)(nil).DeactivateTransactionType), ctx, req)
+}
+
 // GetAllOrderType mocks base method.
 func (m *MockMasterDataService) GetAllOrderType(ctx context.Context, filter models.FilterMasterData) ([]models.OrderType, error) {
 	m.ctrl.T.Helper()
@@ -100,6 +130,21 @@ func (mr *MockMasterDataServiceMockRecorder) GetAllVATConfig(ctx any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllVATConfig", reflect.TypeOf((*MockMasterDataService)(nil).GetAllVATConfig), ctx)
 }
 
+// GetConsistencyReport mocks base method.
+func (m *MockMasterDataService) GetConsistencyReport(ctx context.Context) (*models.MasterDataConsistencyReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConsistencyReport", ctx)
+	ret0, _ := ret[0].(*models.MasterDataConsistencyReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConsistencyReport indicates an expected call of GetConsistencyReport.
+func (mr *MockMasterDataServiceMockRecorder) GetConsistencyReport(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConsistencyReport", reflect.TypeOf((*MockMasterDataService)(nil).GetConsistencyReport), ctx)
+}
+
 // GetOneOrderType mocks base method.
 func (m *MockMasterDataService) GetOneOrderType(ctx context.Context, orderTypeCode string) (*models.OrderType, error) {
 	m.ctrl.T.Helper()
@@ -144,6 +189,21 @@ func (mr *MockMasterDataServiceMockRecorder) UpdateOrderType(ctx, ot any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderType", reflect.TypeOf((*MockMasterDataService)(nil).UpdateOrderType), ctx, ot)
 }
 
+// UpdateTransactionType mocks base method.
+func (m *MockMasterDataService) UpdateTransactionType(ctx context.Context, req models.UpdateTransactionTypeRequest) (*models.OrderType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransactionType", ctx, req)
+	ret0, _ := ret[0].(*models.OrderType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransactionType indicates an expected call of UpdateTransactionType.
+func (mr *MockMasterDataServiceMockRecorder) UpdateTransactionType(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionType", reflect.TypeOf((*MockMasterDataService)(nil).UpdateTransactionType), ctx, req)
+}
+
 // UpsertVATConfig mocks base method.
 func (m *MockMasterDataService) UpsertVATConfig(ctx context.Context, configs []models.ConfigVatRevenue) error {
 	m.ctrl.T.Helper()
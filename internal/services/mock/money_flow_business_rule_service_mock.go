@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/money_flow_business_rule_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/money_flow_business_rule_service.go -destination=./internal/services/mock/money_flow_business_rule_service_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMoneyFlowBusinessRuleService is a mock of MoneyFlowBusinessRuleService interface.
+type MockMoneyFlowBusinessRuleService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMoneyFlowBusinessRuleServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockMoneyFlowBusinessRuleServiceMockRecorder is the mock recorder for MockMoneyFlowBusinessRuleService.
+type MockMoneyFlowBusinessRuleServiceMockRecorder struct {
+	mock *MockMoneyFlowBusinessRuleService
+}
+
+// NewMockMoneyFlowBusinessRuleService creates a new mock instance.
+func NewMockMoneyFlowBusinessRuleService(ctrl *gomock.Controller) *MockMoneyFlowBusinessRuleService {
+	mock := &MockMoneyFlowBusinessRuleService{ctrl: ctrl}
+	mock.recorder = &MockMoneyFlowBusinessRuleServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMoneyFlowBusinessRuleService) EXPECT() *MockMoneyFlowBusinessRuleServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMoneyFlowBusinessRuleService) Create(ctx context.Context, req models.CreateMoneyFlowBusinessRuleRequest) (*models.MoneyFlowBusinessRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, req)
+	ret0, _ := ret[0].(*models.MoneyFlowBusinessRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMoneyFlowBusinessRuleServiceMockRecorder) Create(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMoneyFlowBusinessRuleService)(nil).Create), ctx, req)
+}
+
+// Delete mocks base method.
+func (m *MockMoneyFlowBusinessRuleService) Delete(ctx context.Context, version int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMoneyFlowBusinessRuleServiceMockRecorder) Delete(ctx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMoneyFlowBusinessRuleService)(nil).Delete), ctx, version)
+}
+
+// GetByVersion mocks base method.
+func (m *MockMoneyFlowBusinessRuleService) GetByVersion(ctx context.Context, version int64) (*models.MoneyFlowBusinessRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByVersion", ctx, version)
+	ret0, _ := ret[0].(*models.MoneyFlowBusinessRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByVersion indicates an expected call of GetByVersion.
+func (mr *MockMoneyFlowBusinessRuleServiceMockRecorder) GetByVersion(ctx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByVersion", reflect.TypeOf((*MockMoneyFlowBusinessRuleService)(nil).GetByVersion), ctx, version)
+}
+
+// GetList mocks base method.
+func (m *MockMoneyFlowBusinessRuleService) GetList(ctx context.Context, opts models.MoneyFlowBusinessRuleFilterOptions) ([]models.MoneyFlowBusinessRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetList", ctx, opts)
+	ret0, _ := ret[0].([]models.MoneyFlowBusinessRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetList indicates an expected call of GetList.
+func (mr *MockMoneyFlowBusinessRuleServiceMockRecorder) GetList(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockMoneyFlowBusinessRuleService)(nil).GetList), ctx, opts)
+}
+
+// GetPublished mocks base method.
+func (m *MockMoneyFlowBusinessRuleService) GetPublished(ctx context.Context) (*models.MoneyFlowBusinessRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublished", ctx)
+	ret0, _ := ret[0].(*models.MoneyFlowBusinessRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublished indicates an expected call of GetPublished.
+func (mr *MockMoneyFlowBusinessRuleServiceMockRecorder) GetPublished(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublished", reflect.TypeOf((*MockMoneyFlowBusinessRuleService)(nil).GetPublished), ctx)
+}
+
+// Publish mocks base method.
+func (m *MockMoneyFlowBusinessRuleService) Publish(ctx context.Context, req models.PublishMoneyFlowBusinessRuleRequest) (*models.MoneyFlowBusinessRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, req)
+	ret0, _ := ret[0].(*models.MoneyFlowBusinessRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockMoneyFlowBusinessRuleServiceMockRecorder) Publish(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockMoneyFlowBusinessRuleService)(nil).Publish), ctx, req)
+}
+
+// RefreshPeriodically mocks base method.
+func (m *MockMoneyFlowBusinessRuleService) RefreshPeriodically(ctx context.Context, interval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RefreshPeriodically", ctx, interval)
+}
+
+// RefreshPeriodically indicates an expected call of RefreshPeriodically.
+func (mr *MockMoneyFlowBusinessRuleServiceMockRecorder) RefreshPeriodically(ctx, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshPeriodically", reflect.TypeOf((*MockMoneyFlowBusinessRuleService)(nil).RefreshPeriodically), ctx, interval)
+}
+
+// Update mocks base method.
+func (m *MockMoneyFlowBusinessRuleService) Update(ctx context.Context, req models.UpdateMoneyFlowBusinessRuleRequest) (*models.MoneyFlowBusinessRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, req)
+	ret0, _ := ret[0].(*models.MoneyFlowBusinessRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMoneyFlowBusinessRuleServiceMockRecorder) Update(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMoneyFlowBusinessRuleService)(nil).Update), ctx, req)
+}
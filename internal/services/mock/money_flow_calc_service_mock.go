@@ -12,6 +12,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "bitbucket.org/Amartha/go-acuan-lib/model"
 	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
@@ -59,6 +60,21 @@ func (mr *MockMoneyFlowServiceMockRecorder) CheckEligibleTransaction(ctx, paymen
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckEligibleTransaction", reflect.TypeOf((*MockMoneyFlowService)(nil).CheckEligibleTransaction), ctx, paymentType, breakdownTransactionType)
 }
 
+// DisburseDueSummaries mocks base method.
+func (m *MockMoneyFlowService) DisburseDueSummaries(ctx context.Context, now time.Time) (models.MoneyFlowDisbursementResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisburseDueSummaries", ctx, now)
+	ret0, _ := ret[0].(models.MoneyFlowDisbursementResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisburseDueSummaries indicates an expected call of DisburseDueSummaries.
+func (mr *MockMoneyFlowServiceMockRecorder) DisburseDueSummaries(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisburseDueSummaries", reflect.TypeOf((*MockMoneyFlowService)(nil).DisburseDueSummaries), ctx, now)
+}
+
 // DownloadDetailedTransactionsBySummaryID mocks base method.
 func (m *MockMoneyFlowService) DownloadDetailedTransactionsBySummaryID(ctx context.Context, req models.DownloadDetailedTransactionsRequest) error {
 	m.ctrl.T.Helper()
@@ -120,6 +136,21 @@ func (mr *MockMoneyFlowServiceMockRecorder) GetSummaryDetailBySummaryID(ctx, sum
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummaryDetailBySummaryID", reflect.TypeOf((*MockMoneyFlowService)(nil).GetSummaryDetailBySummaryID), ctx, summaryID)
 }
 
+// GetSummaryHistory mocks base method.
+func (m *MockMoneyFlowService) GetSummaryHistory(ctx context.Context, summaryID string) ([]models.MoneyFlowSummaryEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSummaryHistory", ctx, summaryID)
+	ret0, _ := ret[0].([]models.MoneyFlowSummaryEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSummaryHistory indicates an expected call of GetSummaryHistory.
+func (mr *MockMoneyFlowServiceMockRecorder) GetSummaryHistory(ctx, summaryID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSummaryHistory", reflect.TypeOf((*MockMoneyFlowService)(nil).GetSummaryHistory), ctx, summaryID)
+}
+
 // ProcessTransactionNotification mocks base method.
 func (m *MockMoneyFlowService) ProcessTransactionNotification(ctx context.Context, notification model.Payload[model.DataOrder]) error {
 	m.ctrl.T.Helper()
@@ -135,17 +166,17 @@ func (mr *MockMoneyFlowServiceMockRecorder) ProcessTransactionNotification(ctx,
 }
 
 // ProcessTransactionStream mocks base method.
-func (m *MockMoneyFlowService) ProcessTransactionStream(ctx context.Context, arg1 event.Event) error {
+func (m *MockMoneyFlowService) ProcessTransactionStream(ctx context.Context, event event.Event, papaEvent models.PapaTransactionEvent) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ProcessTransactionStream", ctx, arg1)
+	ret := m.ctrl.Call(m, "ProcessTransactionStream", ctx, event, papaEvent)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ProcessTransactionStream indicates an expected call of ProcessTransactionStream.
-func (mr *MockMoneyFlowServiceMockRecorder) ProcessTransactionStream(ctx, arg1 any) *gomock.Call {
+func (mr *MockMoneyFlowServiceMockRecorder) ProcessTransactionStream(ctx, event, papaEvent any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessTransactionStream", reflect.TypeOf((*MockMoneyFlowService)(nil).ProcessTransactionStream), ctx, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessTransactionStream", reflect.TypeOf((*MockMoneyFlowService)(nil).ProcessTransactionStream), ctx, event, papaEvent)
 }
 
 // UpdateActivationStatus mocks base method.
@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/recon_exception_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/recon_exception_service.go -destination=./internal/services/mock/recon_exception_service_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReconExceptionService is a mock of ReconExceptionService interface.
+type MockReconExceptionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReconExceptionServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockReconExceptionServiceMockRecorder is the mock recorder for MockReconExceptionService.
+type MockReconExceptionServiceMockRecorder struct {
+	mock *MockReconExceptionService
+}
+
+// NewMockReconExceptionService creates a new mock instance.
+func NewMockReconExceptionService(ctrl *gomock.Controller) *MockReconExceptionService {
+	mock := &MockReconExceptionService{ctrl: ctrl}
+	mock.recorder = &MockReconExceptionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReconExceptionService) EXPECT() *MockReconExceptionServiceMockRecorder {
+	return m.recorder
+}
+
+// Assign mocks base method.
+func (m *MockReconExceptionService) Assign(ctx context.Context, req models.AssignReconExceptionRequest) (*models.ReconException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, req)
+	ret0, _ := ret[0].(*models.ReconException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockReconExceptionServiceMockRecorder) Assign(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockReconExceptionService)(nil).Assign), ctx, req)
+}
+
+// Comment mocks base method.
+func (m *MockReconExceptionService) Comment(ctx context.Context, req models.CommentReconExceptionRequest) (*models.ReconExceptionActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Comment", ctx, req)
+	ret0, _ := ret[0].(*models.ReconExceptionActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Comment indicates an expected call of Comment.
+func (mr *MockReconExceptionServiceMockRecorder) Comment(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Comment", reflect.TypeOf((*MockReconExceptionService)(nil).Comment), ctx, req)
+}
+
+// GetByID mocks base method.
+func (m *MockReconExceptionService) GetByID(ctx context.Context, id uint64) (*models.ReconException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*models.ReconException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockReconExceptionServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockReconExceptionService)(nil).GetByID), ctx, id)
+}
+
+// List mocks base method.
+func (m *MockReconExceptionService) List(ctx context.Context, opts models.ReconExceptionFilterOptions) ([]models.ReconException, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, opts)
+	ret0, _ := ret[0].([]models.ReconException)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockReconExceptionServiceMockRecorder) List(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockReconExceptionService)(nil).List), ctx, opts)
+}
+
+// Resolve mocks base method.
+func (m *MockReconExceptionService) Resolve(ctx context.Context, req models.ResolveReconExceptionRequest) (*models.ReconException, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, req)
+	ret0, _ := ret[0].(*models.ReconException)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockReconExceptionServiceMockRecorder) Resolve(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockReconExceptionService)(nil).Resolve), ctx, req)
+}
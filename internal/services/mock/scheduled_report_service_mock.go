@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/scheduled_report_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/scheduled_report_service.go -destination=./internal/services/mock/scheduled_report_service_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockScheduledReportService is a mock of ScheduledReportService interface.
+type MockScheduledReportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduledReportServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockScheduledReportServiceMockRecorder is the mock recorder for MockScheduledReportService.
+type MockScheduledReportServiceMockRecorder struct {
+	mock *MockScheduledReportService
+}
+
+// NewMockScheduledReportService creates a new mock instance.
+func NewMockScheduledReportService(ctrl *gomock.Controller) *MockScheduledReportService {
+	mock := &MockScheduledReportService{ctrl: ctrl}
+	mock.recorder = &MockScheduledReportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduledReportService) EXPECT() *MockScheduledReportServiceMockRecorder {
+	return m.recorder
+}
+
+// RunScheduledReports mocks base method.
+func (m *MockScheduledReportService) RunScheduledReports(ctx context.Context, req models.RunScheduledReportsRequest) (models.RunScheduledReportsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunScheduledReports", ctx, req)
+	ret0, _ := ret[0].(models.RunScheduledReportsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunScheduledReports indicates an expected call of RunScheduledReports.
+func (mr *MockScheduledReportServiceMockRecorder) RunScheduledReports(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunScheduledReports", reflect.TypeOf((*MockScheduledReportService)(nil).RunScheduledReports), ctx, req)
+}
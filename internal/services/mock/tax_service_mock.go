@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/tax_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/tax_service.go -destination=./internal/services/mock/tax_service_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaxService is a mock of TaxService interface.
+type MockTaxService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTaxServiceMockRecorder is the mock recorder for MockTaxService.
+type MockTaxServiceMockRecorder struct {
+	mock *MockTaxService
+}
+
+// NewMockTaxService creates a new mock instance.
+func NewMockTaxService(ctrl *gomock.Controller) *MockTaxService {
+	mock := &MockTaxService{ctrl: ctrl}
+	mock.recorder = &MockTaxServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxService) EXPECT() *MockTaxServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateTaxRate mocks base method.
+func (m *MockTaxService) CreateTaxRate(ctx context.Context, req models.DoCreateTaxRateRequest) (*models.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaxRate", ctx, req)
+	ret0, _ := ret[0].(*models.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTaxRate indicates an expected call of CreateTaxRate.
+func (mr *MockTaxServiceMockRecorder) CreateTaxRate(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaxRate", reflect.TypeOf((*MockTaxService)(nil).CreateTaxRate), ctx, req)
+}
+
+// GetTaxRates mocks base method.
+func (m *MockTaxService) GetTaxRates(ctx context.Context) (models.TaxRates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxRates", ctx)
+	ret0, _ := ret[0].(models.TaxRates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxRates indicates an expected call of GetTaxRates.
+func (mr *MockTaxServiceMockRecorder) GetTaxRates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxRates", reflect.TypeOf((*MockTaxService)(nil).GetTaxRates), ctx)
+}
+
+// GetTaxReport mocks base method.
+func (m *MockTaxService) GetTaxReport(ctx context.Context, req models.DoGetTaxReportRequest) (*models.DoGetTaxReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaxReport", ctx, req)
+	ret0, _ := ret[0].(*models.DoGetTaxReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaxReport indicates an expected call of GetTaxReport.
+func (mr *MockTaxServiceMockRecorder) GetTaxReport(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaxReport", reflect.TypeOf((*MockTaxService)(nil).GetTaxReport), ctx, req)
+}
+
+// ListTaxRates mocks base method.
+func (m *MockTaxService) ListTaxRates(ctx context.Context) (models.TaxRates, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaxRates", ctx)
+	ret0, _ := ret[0].(models.TaxRates)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaxRates indicates an expected call of ListTaxRates.
+func (mr *MockTaxServiceMockRecorder) ListTaxRates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaxRates", reflect.TypeOf((*MockTaxService)(nil).ListTaxRates), ctx)
+}
+
+// RefreshPeriodically mocks base method.
+func (m *MockTaxService) RefreshPeriodically(ctx context.Context, interval time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RefreshPeriodically", ctx, interval)
+}
+
+// RefreshPeriodically indicates an expected call of RefreshPeriodically.
+func (mr *MockTaxServiceMockRecorder) RefreshPeriodically(ctx, interval any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshPeriodically", reflect.TypeOf((*MockTaxService)(nil).RefreshPeriodically), ctx, interval)
+}
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/services/transaction_metric_service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/services/transaction_metric_service.go -destination=./internal/services/mock/transaction_metric_service_mock.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTransactionMetricService is a mock of TransactionMetricService interface.
+type MockTransactionMetricService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionMetricServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockTransactionMetricServiceMockRecorder is the mock recorder for MockTransactionMetricService.
+type MockTransactionMetricServiceMockRecorder struct {
+	mock *MockTransactionMetricService
+}
+
+// NewMockTransactionMetricService creates a new mock instance.
+func NewMockTransactionMetricService(ctrl *gomock.Controller) *MockTransactionMetricService {
+	mock := &MockTransactionMetricService{ctrl: ctrl}
+	mock.recorder = &MockTransactionMetricServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionMetricService) EXPECT() *MockTransactionMetricServiceMockRecorder {
+	return m.recorder
+}
+
+// GetDailyAggregates mocks base method.
+func (m *MockTransactionMetricService) GetDailyAggregates(ctx context.Context, opts models.TransactionMetricFilterOptions) ([]models.TransactionDailyAggregate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyAggregates", ctx, opts)
+	ret0, _ := ret[0].([]models.TransactionDailyAggregate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyAggregates indicates an expected call of GetDailyAggregates.
+func (mr *MockTransactionMetricServiceMockRecorder) GetDailyAggregates(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyAggregates", reflect.TypeOf((*MockTransactionMetricService)(nil).GetDailyAggregates), ctx, opts)
+}
+
+// GetTransactionMetrics mocks base method.
+func (m *MockTransactionMetricService) GetTransactionMetrics(ctx context.Context, req models.DoGetTransactionMetricsRequest) (models.DoGetTransactionMetricsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransactionMetrics", ctx, req)
+	ret0, _ := ret[0].(models.DoGetTransactionMetricsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransactionMetrics indicates an expected call of GetTransactionMetrics.
+func (mr *MockTransactionMetricServiceMockRecorder) GetTransactionMetrics(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactionMetrics", reflect.TypeOf((*MockTransactionMetricService)(nil).GetTransactionMetrics), ctx, req)
+}
+
+// MaterializeDailyAggregates mocks base method.
+func (m *MockTransactionMetricService) MaterializeDailyAggregates(ctx context.Context, startDate, endDate time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaterializeDailyAggregates", ctx, startDate, endDate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MaterializeDailyAggregates indicates an expected call of MaterializeDailyAggregates.
+func (mr *MockTransactionMetricServiceMockRecorder) MaterializeDailyAggregates(ctx, startDate, endDate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaterializeDailyAggregates", reflect.TypeOf((*MockTransactionMetricService)(nil).MaterializeDailyAggregates), ctx, startDate, endDate)
+}
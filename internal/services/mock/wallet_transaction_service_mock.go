@@ -71,6 +71,50 @@ func (mr *MockWalletTrxServiceMockRecorder) EnqueueTransaction(ctx, in any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueTransaction", reflect.TypeOf((*MockWalletTrxService)(nil).EnqueueTransaction), ctx, in)
 }
 
+// FailAsyncTransaction mocks base method.
+func (m *MockWalletTrxService) FailAsyncTransaction(ctx context.Context, in models.CreateWalletTransactionRequest, cause error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailAsyncTransaction", ctx, in, cause)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FailAsyncTransaction indicates an expected call of FailAsyncTransaction.
+func (mr *MockWalletTrxServiceMockRecorder) FailAsyncTransaction(ctx, in, cause any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailAsyncTransaction", reflect.TypeOf((*MockWalletTrxService)(nil).FailAsyncTransaction), ctx, in, cause)
+}
+
+// GetAsyncTransaction mocks base method.
+func (m *MockWalletTrxService) GetAsyncTransaction(ctx context.Context, id, clientId string) (*models.AsyncWalletTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAsyncTransaction", ctx, id, clientId)
+	ret0, _ := ret[0].(*models.AsyncWalletTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAsyncTransaction indicates an expected call of GetAsyncTransaction.
+func (mr *MockWalletTrxServiceMockRecorder) GetAsyncTransaction(ctx, id, clientId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAsyncTransaction", reflect.TypeOf((*MockWalletTrxService)(nil).GetAsyncTransaction), ctx, id, clientId)
+}
+
+// GetDetail mocks base method.
+func (m *MockWalletTrxService) GetDetail(ctx context.Context, req models.DoGetWalletTransactionDetailRequest) (*models.WalletTransactionDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDetail", ctx, req)
+	ret0, _ := ret[0].(*models.WalletTransactionDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDetail indicates an expected call of GetDetail.
+func (mr *MockWalletTrxServiceMockRecorder) GetDetail(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDetail", reflect.TypeOf((*MockWalletTrxService)(nil).GetDetail), ctx, req)
+}
+
 // List mocks base method.
 func (m *MockWalletTrxService) List(ctx context.Context, opts models.WalletTrxFilterOptions) ([]models.WalletTransaction, int, error) {
 	m.ctrl.T.Helper()
@@ -87,6 +131,21 @@ func (mr *MockWalletTrxServiceMockRecorder) List(ctx, opts any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockWalletTrxService)(nil).List), ctx, opts)
 }
 
+// ProcessAsyncTransaction mocks base method.
+func (m *MockWalletTrxService) ProcessAsyncTransaction(ctx context.Context, in models.CreateWalletTransactionRequest) (*models.WalletTransaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessAsyncTransaction", ctx, in)
+	ret0, _ := ret[0].(*models.WalletTransaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessAsyncTransaction indicates an expected call of ProcessAsyncTransaction.
+func (mr *MockWalletTrxServiceMockRecorder) ProcessAsyncTransaction(ctx, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessAsyncTransaction", reflect.TypeOf((*MockWalletTrxService)(nil).ProcessAsyncTransaction), ctx, in)
+}
+
 // ProcessReservedTransaction mocks base method.
 func (m *MockWalletTrxService) ProcessReservedTransaction(ctx context.Context, req models.UpdateStatusWalletTransactionRequest) (*models.WalletTransaction, error) {
 	m.ctrl.T.Helper()
@@ -101,3 +160,18 @@ func (mr *MockWalletTrxServiceMockRecorder) ProcessReservedTransaction(ctx, req
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessReservedTransaction", reflect.TypeOf((*MockWalletTrxService)(nil).ProcessReservedTransaction), ctx, req)
 }
+
+// SendAsyncCallbacks mocks base method.
+func (m *MockWalletTrxService) SendAsyncCallbacks(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAsyncCallbacks", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendAsyncCallbacks indicates an expected call of SendAsyncCallbacks.
+func (mr *MockWalletTrxServiceMockRecorder) SendAsyncCallbacks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAsyncCallbacks", reflect.TypeOf((*MockWalletTrxService)(nil).SendAsyncCallbacks), ctx)
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+)
+
+type MoneyFlowBusinessRuleService interface {
+	GetList(ctx context.Context, opts models.MoneyFlowBusinessRuleFilterOptions) (rules []models.MoneyFlowBusinessRule, err error)
+	GetByVersion(ctx context.Context, version int64) (rule *models.MoneyFlowBusinessRule, err error)
+	GetPublished(ctx context.Context) (rule *models.MoneyFlowBusinessRule, err error)
+	Create(ctx context.Context, req models.CreateMoneyFlowBusinessRuleRequest) (rule *models.MoneyFlowBusinessRule, err error)
+	Update(ctx context.Context, req models.UpdateMoneyFlowBusinessRuleRequest) (rule *models.MoneyFlowBusinessRule, err error)
+	Delete(ctx context.Context, version int64) (err error)
+	Publish(ctx context.Context, req models.PublishMoneyFlowBusinessRuleRequest) (rule *models.MoneyFlowBusinessRule, err error)
+	RefreshPeriodically(ctx context.Context, interval time.Duration)
+}
+
+type moneyFlowBusinessRule service
+
+var _ MoneyFlowBusinessRuleService = (*moneyFlowBusinessRule)(nil)
+
+func (s *moneyFlowBusinessRule) GetList(ctx context.Context, opts models.MoneyFlowBusinessRuleFilterOptions) (rules []models.MoneyFlowBusinessRule, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	return s.srv.sqlRepo.GetMoneyFlowBusinessRuleRepository().GetList(ctx, opts)
+}
+
+func (s *moneyFlowBusinessRule) GetByVersion(ctx context.Context, version int64) (rule *models.MoneyFlowBusinessRule, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	return s.srv.sqlRepo.GetMoneyFlowBusinessRuleRepository().GetByVersion(ctx, version)
+}
+
+func (s *moneyFlowBusinessRule) GetPublished(ctx context.Context) (rule *models.MoneyFlowBusinessRule, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	return s.srv.sqlRepo.GetMoneyFlowBusinessRuleRepository().GetPublished(ctx)
+}
+
+// Create store new business rules as draft, rules are validated when the draft is published
+func (s *moneyFlowBusinessRule) Create(ctx context.Context, req models.CreateMoneyFlowBusinessRuleRequest) (rule *models.MoneyFlowBusinessRule, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	rule = &models.MoneyFlowBusinessRule{
+		Status:      models.MoneyFlowBusinessRuleStatusDraft,
+		Rules:       req.Rules,
+		Description: req.Description,
+		CreatedBy:   req.Actor,
+		UpdatedBy:   req.Actor,
+	}
+	if err = s.srv.sqlRepo.GetMoneyFlowBusinessRuleRepository().Create(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *moneyFlowBusinessRule) Update(ctx context.Context, req models.UpdateMoneyFlowBusinessRuleRequest) (rule *models.MoneyFlowBusinessRule, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	repo := s.srv.sqlRepo.GetMoneyFlowBusinessRuleRepository()
+
+	rule, err = repo.GetByVersion(ctx, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.Status != models.MoneyFlowBusinessRuleStatusDraft {
+		return nil, common.ErrMoneyFlowBusinessRuleNotDraft
+	}
+
+	rule.Rules = req.Rules
+	rule.Description = req.Description
+	rule.UpdatedBy = req.Actor
+	if err = repo.UpdateDraft(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (s *moneyFlowBusinessRule) Delete(ctx context.Context, version int64) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	repo := s.srv.sqlRepo.GetMoneyFlowBusinessRuleRepository()
+
+	rule, err := repo.GetByVersion(ctx, version)
+	if err != nil {
+		return err
+	}
+
+	if rule.Status != models.MoneyFlowBusinessRuleStatusDraft {
+		return common.ErrMoneyFlowBusinessRuleNotDraft
+	}
+
+	return repo.DeleteDraft(ctx, version)
+}
+
+// Publish validate the draft and make it the active business rules.
+// Previously published version is archived so only one version is active at a time.
+func (s *moneyFlowBusinessRule) Publish(ctx context.Context, req models.PublishMoneyFlowBusinessRuleRequest) (rule *models.MoneyFlowBusinessRule, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	repo := s.srv.sqlRepo.GetMoneyFlowBusinessRuleRepository()
+
+	rule, err = repo.GetByVersion(ctx, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.Status != models.MoneyFlowBusinessRuleStatusDraft {
+		return nil, common.ErrMoneyFlowBusinessRuleNotDraft
+	}
+
+	if err = rule.Rules.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", common.ErrInvalidMoneyFlowBusinessRules, err)
+	}
+
+	err = s.srv.sqlRepo.Atomic(ctx, func(actx context.Context, r repositories.SQLRepository) error {
+		repo := r.GetMoneyFlowBusinessRuleRepository()
+
+		if err := repo.ArchivePublished(actx, req.Actor); err != nil {
+			return err
+		}
+
+		if err := repo.Publish(actx, req.Version, req.Actor); err != nil {
+			return err
+		}
+
+		rule, err = repo.GetByVersion(actx, req.Version)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// other instances will pick up the new version on next refresh
+	s.srv.businessRules.Store(rule.ActiveRules())
+
+	xlog.Info(ctx, "[MONEY-FLOW-BUSINESS-RULE]",
+		xlog.String("operation", "publish"),
+		xlog.Int64("version", rule.Version),
+		xlog.String("published_by", req.Actor))
+
+	return rule, nil
+}
+
+// RefreshPeriodically load published business rules into memory and keep it updated in background.
+// When nothing is published yet, money flow calculation keeps using business rules from feature flag.
+func (s *moneyFlowBusinessRule) RefreshPeriodically(ctx context.Context, interval time.Duration) {
+	err := s.refresh(ctx)
+	if err != nil {
+		xlog.Warn(ctx, "failed to refresh money flow business rules", xlog.Err(err))
+	}
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				err := s.refresh(ctx)
+				if err != nil {
+					xlog.Warn(ctx, "failed to refresh money flow business rules", xlog.Err(err))
+				}
+			}
+		}
+	}()
+}
+
+func (s *moneyFlowBusinessRule) refresh(ctx context.Context) error {
+	rule, err := s.srv.sqlRepo.GetMoneyFlowBusinessRuleRepository().GetPublished(ctx)
+	if err != nil {
+		if errors.Is(err, common.ErrDataNotFound) {
+			s.srv.businessRules.Store(nil)
+			return nil
+		}
+		return err
+	}
+
+	s.srv.businessRules.Store(rule.ActiveRules())
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+
+	"github.com/Unleash/unleash-client-go/v3/api"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func validBusinessRulesConfigs() models.BusinessRulesConfigs {
+	bank := models.BankInfo{
+		AccountNumber:     "21100100000001",
+		BankCode:          "014",
+		BankName:          "BCA",
+		BankAccountNumber: "1234567890",
+		BankAccountName:   "PT Amartha",
+	}
+
+	return models.BusinessRulesConfigs{
+		PaymentConfigs: map[string]models.BusinessRuleConfig{
+			"MF_EARN_DIVEST": {
+				TransactionType: "DVEST",
+				RequestToPAPA:   models.RequestToPAPA{Description: "earn divest"},
+				Source:          bank,
+				Destination:     bank,
+			},
+		},
+		TransactionToPaymentMap: map[string]string{
+			"DVEST": "MF_EARN_DIVEST",
+		},
+	}
+}
+
+func TestMoneyFlowBusinessRuleService_Publish(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	type args struct {
+		ctx context.Context
+		req models.PublishMoneyFlowBusinessRuleRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr error
+	}{
+		{
+			name: "success publish draft",
+			args: args{
+				ctx: context.Background(),
+				req: models.PublishMoneyFlowBusinessRuleRequest{Version: 2, Actor: "finance.lead"},
+			},
+			doMock: func(args args) {
+				testHelper.mockBusinessRuleRepository.EXPECT().GetByVersion(args.ctx, int64(2)).
+					Return(&models.MoneyFlowBusinessRule{
+						Version: 2,
+						Status:  models.MoneyFlowBusinessRuleStatusDraft,
+						Rules:   validBusinessRulesConfigs(),
+					}, nil)
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockBusinessRuleRepository.EXPECT().ArchivePublished(args.ctx, "finance.lead").Return(nil)
+				testHelper.mockBusinessRuleRepository.EXPECT().Publish(args.ctx, int64(2), "finance.lead").Return(nil)
+				testHelper.mockBusinessRuleRepository.EXPECT().GetByVersion(args.ctx, int64(2)).
+					Return(&models.MoneyFlowBusinessRule{
+						Version:     2,
+						Status:      models.MoneyFlowBusinessRuleStatusPublished,
+						Rules:       validBusinessRulesConfigs(),
+						PublishedBy: "finance.lead",
+					}, nil)
+			},
+		},
+		{
+			name: "failed publish incomplete rules",
+			args: args{
+				ctx: context.Background(),
+				req: models.PublishMoneyFlowBusinessRuleRequest{Version: 3, Actor: "finance.lead"},
+			},
+			doMock: func(args args) {
+				rules := validBusinessRulesConfigs()
+				rules.TransactionToPaymentMap["DVEST"] = "MF_UNKNOWN"
+				testHelper.mockBusinessRuleRepository.EXPECT().GetByVersion(args.ctx, int64(3)).
+					Return(&models.MoneyFlowBusinessRule{
+						Version: 3,
+						Status:  models.MoneyFlowBusinessRuleStatusDraft,
+						Rules:   rules,
+					}, nil)
+			},
+			wantErr: common.ErrInvalidMoneyFlowBusinessRules,
+		},
+		{
+			name: "failed publish archived version",
+			args: args{
+				ctx: context.Background(),
+				req: models.PublishMoneyFlowBusinessRuleRequest{Version: 1, Actor: "finance.lead"},
+			},
+			doMock: func(args args) {
+				testHelper.mockBusinessRuleRepository.EXPECT().GetByVersion(args.ctx, int64(1)).
+					Return(&models.MoneyFlowBusinessRule{
+						Version: 1,
+						Status:  models.MoneyFlowBusinessRuleStatusArchived,
+						Rules:   validBusinessRulesConfigs(),
+					}, nil)
+			},
+			wantErr: common.ErrMoneyFlowBusinessRuleNotDraft,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			got, err := testHelper.businessRuleSvc.Publish(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, models.MoneyFlowBusinessRuleStatusPublished, got.Status)
+
+			// published version is used right away without reading feature flag
+			config, paymentType, err := testHelper.moneyFlowSvc.CheckEligibleTransaction(tt.args.ctx, "", "DVEST")
+			assert.NoError(t, err)
+			assert.Equal(t, "MF_EARN_DIVEST", paymentType)
+			assert.Equal(t, got.Version, config.Version)
+		})
+	}
+}
+
+func TestMoneyFlowBusinessRuleService_Update(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	type args struct {
+		ctx context.Context
+		req models.UpdateMoneyFlowBusinessRuleRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr error
+	}{
+		{
+			name: "success update draft",
+			args: args{
+				ctx: context.Background(),
+				req: models.UpdateMoneyFlowBusinessRuleRequest{
+					Version:     2,
+					Rules:       validBusinessRulesConfigs(),
+					Description: "fix bank name",
+					Actor:       "finance.ops",
+				},
+			},
+			doMock: func(args args) {
+				testHelper.mockBusinessRuleRepository.EXPECT().GetByVersion(args.ctx, int64(2)).
+					Return(&models.MoneyFlowBusinessRule{Version: 2, Status: models.MoneyFlowBusinessRuleStatusDraft}, nil)
+				testHelper.mockBusinessRuleRepository.EXPECT().UpdateDraft(args.ctx, &models.MoneyFlowBusinessRule{
+					Version:     2,
+					Status:      models.MoneyFlowBusinessRuleStatusDraft,
+					Rules:       args.req.Rules,
+					Description: "fix bank name",
+					UpdatedBy:   "finance.ops",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "failed update published version",
+			args: args{
+				ctx: context.Background(),
+				req: models.UpdateMoneyFlowBusinessRuleRequest{Version: 1, Actor: "finance.ops"},
+			},
+			doMock: func(args args) {
+				testHelper.mockBusinessRuleRepository.EXPECT().GetByVersion(args.ctx, int64(1)).
+					Return(&models.MoneyFlowBusinessRule{Version: 1, Status: models.MoneyFlowBusinessRuleStatusPublished}, nil)
+			},
+			wantErr: common.ErrMoneyFlowBusinessRuleNotDraft,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			_, err := testHelper.businessRuleSvc.Update(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMoneyFlowService_CheckEligibleTransaction_FallbackToFeatureFlag(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	testHelper.mockFlagClient.EXPECT().
+		GetVariant(testHelper.config.FeatureFlagKeyLookup.MoneyFlowCalcBusinessRulesConfig).
+		Return(&api.Variant{
+			Name: "business_rules",
+			Payload: api.Payload{
+				Type: "json",
+				Value: `{"payment_configs":{"MF_EARN_DIVEST":{"transaction_type":"DVEST"}},` +
+					`"transaction_to_payment_map":{"DVEST":"MF_EARN_DIVEST"}}`,
+			},
+			Enabled: true,
+		})
+
+	config, paymentType, err := testHelper.moneyFlowSvc.CheckEligibleTransaction(context.Background(), "", "DVEST")
+	assert.NoError(t, err)
+	assert.Equal(t, "MF_EARN_DIVEST", paymentType)
+	assert.Equal(t, int64(0), config.Version)
+}
@@ -22,6 +22,7 @@ func (h *BusinessRulesHelper) GetByPaymentType(paymentType string) (*models.Busi
 	if !exists {
 		return nil, fmt.Errorf("payment type not found: %s", paymentType)
 	}
+	config.Version = h.configs.Version
 	return &config, nil
 }
 
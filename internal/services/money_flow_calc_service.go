@@ -12,6 +12,7 @@ import (
 	gopaymentlib "bitbucket.org/Amartha/go-payment-lib/payment-api/models/event"
 	xlog "bitbucket.org/Amartha/go-x/log"
 
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/constants"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
@@ -23,13 +24,15 @@ import (
 type MoneyFlowService interface {
 	ProcessTransactionNotification(ctx context.Context, notification goacuanlib.Payload[goacuanlib.DataOrder]) error
 	CheckEligibleTransaction(ctx context.Context, paymentType, breakdownTransactionType string) (*models.BusinessRuleConfig, string, error)
-	ProcessTransactionStream(ctx context.Context, event gopaymentlib.Event) error
+	ProcessTransactionStream(ctx context.Context, event gopaymentlib.Event, papaEvent models.PapaTransactionEvent) error
 	GetSummariesList(ctx context.Context, opts models.MoneyFlowSummaryFilterOptions) ([]models.MoneyFlowSummaryOut, int, error)
 	GetSummaryDetailBySummaryID(ctx context.Context, summaryID string) (result models.MoneyFlowSummaryDetailBySummaryIDOut, err error)
 	GetDetailedTransactionsBySummaryID(ctx context.Context, summaryID string, opts models.DetailedTransactionFilterOptions) ([]models.DetailedTransactionOut, int, error)
 	UpdateSummary(ctx context.Context, summaryID string, req models.UpdateMoneyFlowSummaryRequest) error
 	DownloadDetailedTransactionsBySummaryID(ctx context.Context, req models.DownloadDetailedTransactionsRequest) error
 	UpdateActivationStatus(ctx context.Context, summaryID string, isActive bool) error
+	GetSummaryHistory(ctx context.Context, summaryID string) ([]models.MoneyFlowSummaryEvent, error)
+	DisburseDueSummaries(ctx context.Context, now time.Time) (models.MoneyFlowDisbursementResult, error)
 }
 
 type moneyFlowCalc service
@@ -39,8 +42,8 @@ var _ MoneyFlowService = (*moneyFlowCalc)(nil)
 func (mf *moneyFlowCalc) CheckEligibleTransaction(ctx context.Context, paymentType, breakdownTransactionType string) (*models.BusinessRuleConfig, string, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	businessRulesData, err := mf.loadBusinessRules(ctx)
 	if err != nil {
@@ -102,6 +105,7 @@ func (mf *moneyFlowCalc) processSingleTransaction(
 			DestinationBankAccountNumber:  brd.Destination.BankAccountNumber,
 			DestinationBankAccountName:    brd.Destination.BankAccountName,
 			DestinationBankName:           brd.Destination.BankName,
+			BusinessRuleVersion:           brd.Version,
 			CreatedAt:                     timeNow, // Keep UTC for created_at
 		}
 
@@ -136,11 +140,13 @@ func (mf *moneyFlowCalc) convertToJakartaDate(utcTime time.Time) time.Time {
 	)
 }
 
-func (mf *moneyFlowCalc) ProcessTransactionStream(ctx context.Context, event gopaymentlib.Event) error {
+// ProcessTransactionStream applies PAPA status to the summary and records the transition.
+// Events older than the last recorded PAPA event of the summary are rejected.
+func (mf *moneyFlowCalc) ProcessTransactionStream(ctx context.Context, event gopaymentlib.Event, papaEvent models.PapaTransactionEvent) error {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	// Check whether payment type is eligible or not
 	businessRulesData, _, err := mf.CheckEligibleTransaction(ctx, event.PaymentType.ConvertSingleAPI().ToString(), "")
@@ -163,6 +169,19 @@ func (mf *moneyFlowCalc) ProcessTransactionStream(ctx context.Context, event gop
 	}
 
 	if summaryID == "" {
+		// event of a transfer requested by automatic disbursement can arrive before its PAPA transaction ID
+		// is recorded, it is retried instead of dropped
+		var disbursing bool
+		disbursing, err = mf.srv.sqlRepo.GetMoneyFlowCalcRepository().IsSummaryDisbursing(ctx, event.ReferenceNumber)
+		if err != nil {
+			return fmt.Errorf("failed to check disbursing summary: %w", err)
+		}
+
+		if disbursing {
+			err = fmt.Errorf("%w: reference number %s", common.ErrMoneyFlowSummaryDisbursing, event.ReferenceNumber)
+			return err
+		}
+
 		xlog.Warn(ctx, "[MONEY-FLOW-UPDATE] Summary id not found for transaction",
 			xlog.String("papa_transaction_id", event.ID),
 			xlog.String("ref_number", event.ReferenceNumber))
@@ -186,7 +205,38 @@ func (mf *moneyFlowCalc) ProcessTransactionStream(ctx context.Context, event gop
 		}
 	}
 
-	err = mf.srv.sqlRepo.GetMoneyFlowCalcRepository().UpdateSummary(ctx, summaryID, updateReq)
+	err = mf.srv.sqlRepo.Atomic(ctx, func(ctx context.Context, r repositories.SQLRepository) error {
+		repo := r.GetMoneyFlowCalcRepository()
+
+		currentStatus, err := repo.GetSummaryStatusForUpdate(ctx, summaryID)
+		if err != nil {
+			return err
+		}
+
+		lastEventTime, err := repo.GetLastPapaEventTime(ctx, summaryID)
+		if err != nil {
+			return err
+		}
+
+		if lastEventTime != nil && papaEvent.Timestamp.Before(*lastEventTime) {
+			return fmt.Errorf("%w: event at %s, last event at %s", common.ErrMoneyFlowEventOutOfOrder,
+				papaEvent.Timestamp.Format(time.RFC3339Nano), lastEventTime.Format(time.RFC3339Nano))
+		}
+
+		if err = repo.UpdateSummary(ctx, summaryID, updateReq); err != nil {
+			return err
+		}
+
+		return repo.CreateSummaryEvent(ctx, models.CreateMoneyFlowSummaryEvent{
+			SummaryID:         summaryID,
+			FromStatus:        currentStatus,
+			ToStatus:          status,
+			Source:            constants.MoneyFlowEventSourcePapaStream,
+			PapaTransactionID: event.ID,
+			PapaEventPayload:  papaEvent.Payload,
+			EventTime:         papaEvent.Timestamp,
+		})
+	})
 	if err != nil {
 		xlog.Error(ctx, "[MONEY-FLOW-UPDATE] Failed to update status",
 			xlog.String("summary_id", summaryID),
@@ -208,8 +258,8 @@ func (mf *moneyFlowCalc) ProcessTransactionStream(ctx context.Context, event gop
 func (mf *moneyFlowCalc) GetSummariesList(ctx context.Context, opts models.MoneyFlowSummaryFilterOptions) ([]models.MoneyFlowSummaryOut, int, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	mfsRepo := mf.srv.sqlRepo.GetMoneyFlowCalcRepository()
 
@@ -229,8 +279,8 @@ func (mf *moneyFlowCalc) GetSummariesList(ctx context.Context, opts models.Money
 }
 
 func (mf *moneyFlowCalc) GetSummaryDetailBySummaryID(ctx context.Context, summaryID string) (result models.MoneyFlowSummaryDetailBySummaryIDOut, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	result, err = mf.srv.sqlRepo.GetMoneyFlowCalcRepository().GetSummaryDetailBySummaryID(ctx, summaryID)
 	if err != nil {
@@ -244,8 +294,8 @@ func (mf *moneyFlowCalc) GetSummaryDetailBySummaryID(ctx context.Context, summar
 func (mf *moneyFlowCalc) GetDetailedTransactionsBySummaryID(ctx context.Context, summaryID string, opts models.DetailedTransactionFilterOptions) ([]models.DetailedTransactionOut, int, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	mfsRepo := mf.srv.sqlRepo.GetMoneyFlowCalcRepository()
 
@@ -302,8 +352,8 @@ func (mf *moneyFlowCalc) GetDetailedTransactionsBySummaryID(ctx context.Context,
 func (mf *moneyFlowCalc) UpdateSummary(ctx context.Context, summaryID string, req models.UpdateMoneyFlowSummaryRequest) error {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	// Validate request
 	validator := NewUpdateValidator(mf.srv.sqlRepo.GetMoneyFlowCalcRepository())
@@ -329,15 +379,79 @@ func (mf *moneyFlowCalc) UpdateSummary(ctx context.Context, summaryID string, re
 		return err
 	}
 
-	if err = mf.srv.sqlRepo.GetMoneyFlowCalcRepository().UpdateSummary(ctx, summaryID, *updateModel); err != nil {
+	var fromStatus string
+	var errTransition error
+	err = mf.srv.sqlRepo.Atomic(ctx, func(ctx context.Context, r repositories.SQLRepository) error {
+		repo := r.GetMoneyFlowCalcRepository()
+
+		// status is read again under lock, it may be changed after the summary was read
+		var err error
+		fromStatus, err = repo.GetSummaryStatusForUpdate(ctx, summaryID)
+		if err != nil {
+			return err
+		}
+
+		if fromStatus != currentSummary.Status {
+			lockedSummary := currentSummary
+			lockedSummary.Status = fromStatus
+			if errTransition = validator.ValidateTransition(ctx, req, lockedSummary); errTransition != nil {
+				mf.logValidationError(ctx, summaryID, fromStatus, req, errTransition)
+				return errTransition
+			}
+		}
+
+		if err := repo.UpdateSummary(ctx, summaryID, *updateModel); err != nil {
+			return err
+		}
+
+		// only status changes are transitions, other fields are kept in summary itself
+		if req.MoneyFlowStatus == nil {
+			return nil
+		}
+
+		var papaTransactionID string
+		if req.PapaTransactionID != nil {
+			papaTransactionID = *req.PapaTransactionID
+		}
+
+		return repo.CreateSummaryEvent(ctx, models.CreateMoneyFlowSummaryEvent{
+			SummaryID:         summaryID,
+			FromStatus:        fromStatus,
+			ToStatus:          *req.MoneyFlowStatus,
+			Source:            constants.MoneyFlowEventSourceAPI,
+			Actor:             req.Actor,
+			PapaTransactionID: papaTransactionID,
+			EventTime:         time.Now(),
+		})
+	})
+	if errTransition != nil {
+		return errTransition
+	}
+	if err != nil {
 		mf.logUpdateError(ctx, summaryID, err)
 		return fmt.Errorf("failed to update money flow summary: %w", err)
 	}
 
-	mf.logUpdateSuccess(ctx, summaryID, req, currentSummary.Status)
+	mf.logUpdateSuccess(ctx, summaryID, req, fromStatus)
 	return nil
 }
 
+// GetSummaryHistory returns status transitions of a summary ordered by event time
+func (mf *moneyFlowCalc) GetSummaryHistory(ctx context.Context, summaryID string) (events []models.MoneyFlowSummaryEvent, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	repo := mf.srv.sqlRepo.GetMoneyFlowCalcRepository()
+
+	// make sure unknown summary returns not found instead of empty history
+	if _, err = repo.GetSummaryDetailBySummaryID(ctx, summaryID); err != nil {
+		err = checkDatabaseError(err, models.ErrKeySummaryIdnotFound)
+		return nil, err
+	}
+
+	return repo.GetSummaryEvents(ctx, summaryID)
+}
+
 // logValidationError logs validation errors
 func (mf *moneyFlowCalc) logValidationError(
 	ctx context.Context,
@@ -472,8 +586,8 @@ func (mf *moneyFlowCalc) DownloadDetailedTransactionsBySummaryID(
 ) error {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	startTime := time.Now()
 
@@ -656,8 +770,8 @@ func (mf *moneyFlowCalc) ProcessTransactionNotification(
 ) error {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	// Validate notification
 	validator := NewNotificationValidator()
@@ -775,8 +889,13 @@ func getCreationDate(orderTime time.Time) time.Time {
 	)
 }
 
-// loadBusinessRules loads and validates business rules from feature flag
+// loadBusinessRules loads published business rules from memory,
+// falls back to business rules from feature flag until the first version is published
 func (mf *moneyFlowCalc) loadBusinessRules(ctx context.Context) (*models.BusinessRulesConfigs, error) {
+	if published := mf.srv.businessRules.Load(); published != nil {
+		return published, nil
+	}
+
 	variant := mf.srv.flag.GetVariant(mf.srv.conf.FeatureFlagKeyLookup.MoneyFlowCalcBusinessRulesConfig)
 
 	if variant == nil {
@@ -809,8 +928,8 @@ func (mf *moneyFlowCalc) loadBusinessRules(ctx context.Context) (*models.Busines
 func (mf *moneyFlowCalc) UpdateActivationStatus(ctx context.Context, summaryID string, isActive bool) error {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	// Check if summary exists and get its details
 	summary, err := mf.srv.sqlRepo.GetMoneyFlowCalcRepository().GetSummaryDetailBySummaryIDAllStatus(ctx, summaryID)
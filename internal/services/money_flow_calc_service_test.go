@@ -0,0 +1,389 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	gopaymentlib "bitbucket.org/Amartha/go-payment-lib/payment-api/models/event"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/constants"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+
+	"github.com/Unleash/unleash-client-go/v3/api"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestMoneyFlowService_ProcessTransactionStream(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	testHelper.mockFlagClient.EXPECT().
+		GetVariant(testHelper.config.FeatureFlagKeyLookup.MoneyFlowCalcBusinessRulesConfig).
+		Return(&api.Variant{
+			Name: "business_rules",
+			Payload: api.Payload{
+				Type: "json",
+				Value: `{"payment_configs":{"MF_EARN_DIVEST":{"transaction_type":"DVEST"}},` +
+					`"transaction_to_payment_map":{"DVEST":"MF_EARN_DIVEST"}}`,
+			},
+			Enabled: true,
+		}).AnyTimes()
+
+	lastEventTime := time.Date(2025, 10, 21, 15, 0, 0, 0, time.UTC)
+	payload := json.RawMessage(`{"id":"PAPA-123","status":"SUCCESSFUL"}`)
+
+	type args struct {
+		ctx       context.Context
+		event     gopaymentlib.Event
+		papaEvent models.PapaTransactionEvent
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr error
+	}{
+		{
+			name: "success record transition from papa stream",
+			args: args{
+				ctx:       context.Background(),
+				event:     gopaymentlib.Event{ID: "PAPA-123", PaymentType: "MF_EARN_DIVEST", Status: "SUCCESSFUL"},
+				papaEvent: models.PapaTransactionEvent{Payload: payload, Timestamp: lastEventTime.Add(time.Minute)},
+			},
+			doMock: func(args args) {
+				testHelper.mockMoneyFlowRepository.EXPECT().GetSummaryIDByPapaTransactionID(args.ctx, "PAPA-123").Return("summary-123", nil)
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockMoneyFlowRepository.EXPECT().GetSummaryStatusForUpdate(args.ctx, "summary-123").Return("IN_PROGRESS", nil)
+				testHelper.mockMoneyFlowRepository.EXPECT().GetLastPapaEventTime(args.ctx, "summary-123").Return(&lastEventTime, nil)
+				testHelper.mockMoneyFlowRepository.EXPECT().UpdateSummary(args.ctx, "summary-123", gomock.Any()).Return(nil)
+				testHelper.mockMoneyFlowRepository.EXPECT().CreateSummaryEvent(args.ctx, models.CreateMoneyFlowSummaryEvent{
+					SummaryID:         "summary-123",
+					FromStatus:        "IN_PROGRESS",
+					ToStatus:          "SUCCESSFUL",
+					Source:            constants.MoneyFlowEventSourcePapaStream,
+					PapaTransactionID: "PAPA-123",
+					PapaEventPayload:  payload,
+					EventTime:         args.papaEvent.Timestamp,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "failed reject out of order event",
+			args: args{
+				ctx:       context.Background(),
+				event:     gopaymentlib.Event{ID: "PAPA-123", PaymentType: "MF_EARN_DIVEST", Status: "FAILED"},
+				papaEvent: models.PapaTransactionEvent{Payload: payload, Timestamp: lastEventTime.Add(-time.Minute)},
+			},
+			doMock: func(args args) {
+				testHelper.mockMoneyFlowRepository.EXPECT().GetSummaryIDByPapaTransactionID(args.ctx, "PAPA-123").Return("summary-123", nil)
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockMoneyFlowRepository.EXPECT().GetSummaryStatusForUpdate(args.ctx, "summary-123").Return("SUCCESSFUL", nil)
+				testHelper.mockMoneyFlowRepository.EXPECT().GetLastPapaEventTime(args.ctx, "summary-123").Return(&lastEventTime, nil)
+			},
+			wantErr: common.ErrMoneyFlowEventOutOfOrder,
+		},
+		{
+			name: "failed event arrived before transfer of disbursing summary is recorded",
+			args: args{
+				ctx:       context.Background(),
+				event:     gopaymentlib.Event{ID: "PAPA-123", ReferenceNumber: "MF-2025-10-21-summary-123", PaymentType: "MF_EARN_DIVEST", Status: "SUCCESSFUL"},
+				papaEvent: models.PapaTransactionEvent{Payload: payload, Timestamp: lastEventTime},
+			},
+			doMock: func(args args) {
+				testHelper.mockMoneyFlowRepository.EXPECT().GetSummaryIDByPapaTransactionID(args.ctx, "PAPA-123").Return("", nil)
+				testHelper.mockMoneyFlowRepository.EXPECT().IsSummaryDisbursing(args.ctx, "MF-2025-10-21-summary-123").Return(true, nil)
+			},
+			wantErr: common.ErrMoneyFlowSummaryDisbursing,
+		},
+		{
+			name: "success skip event of unknown summary",
+			args: args{
+				ctx:       context.Background(),
+				event:     gopaymentlib.Event{ID: "PAPA-456", ReferenceNumber: "OTHER-REF", PaymentType: "MF_EARN_DIVEST", Status: "SUCCESSFUL"},
+				papaEvent: models.PapaTransactionEvent{Payload: payload, Timestamp: lastEventTime},
+			},
+			doMock: func(args args) {
+				testHelper.mockMoneyFlowRepository.EXPECT().GetSummaryIDByPapaTransactionID(args.ctx, "PAPA-456").Return("", nil)
+				testHelper.mockMoneyFlowRepository.EXPECT().IsSummaryDisbursing(args.ctx, "OTHER-REF").Return(false, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			err := testHelper.moneyFlowSvc.ProcessTransactionStream(tt.args.ctx, tt.args.event, tt.args.papaEvent)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMoneyFlowService_UpdateSummary_RecordsTransition(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	ctx := context.Background()
+	status := constants.MoneyFlowStatusInProgress
+	papaTransactionID := "PAPA-123"
+
+	testHelper.mockMoneyFlowRepository.EXPECT().GetSummaryDetailBySummaryID(ctx, "summary-123").
+		Return(models.MoneyFlowSummaryDetailBySummaryIDOut{ID: "summary-123", Status: constants.MoneyFlowStatusPending}, nil)
+	testHelper.mockSQLRepository.EXPECT().Atomic(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+			return steps(ctx, testHelper.mockSQLRepository)
+		})
+	testHelper.mockMoneyFlowRepository.EXPECT().GetSummaryStatusForUpdate(ctx, "summary-123").
+		Return(constants.MoneyFlowStatusPending, nil)
+	testHelper.mockMoneyFlowRepository.EXPECT().UpdateSummary(ctx, "summary-123", gomock.Any()).Return(nil)
+	testHelper.mockMoneyFlowRepository.EXPECT().CreateSummaryEvent(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, in models.CreateMoneyFlowSummaryEvent) error {
+			assert.Equal(t, constants.MoneyFlowStatusPending, in.FromStatus)
+			assert.Equal(t, constants.MoneyFlowStatusInProgress, in.ToStatus)
+			assert.Equal(t, constants.MoneyFlowEventSourceAPI, in.Source)
+			assert.Equal(t, "finance.ops", in.Actor)
+			assert.Equal(t, papaTransactionID, in.PapaTransactionID)
+			return nil
+		})
+
+	err := testHelper.moneyFlowSvc.UpdateSummary(ctx, "summary-123", models.UpdateMoneyFlowSummaryRequest{
+		MoneyFlowStatus:   &status,
+		PapaTransactionID: &papaTransactionID,
+		Actor:             "finance.ops",
+	})
+	assert.NoError(t, err)
+}
+
+func TestMoneyFlowService_UpdateSummary_StatusChangedConcurrently(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	ctx := context.Background()
+	status := constants.MoneyFlowStatusInProgress
+	papaTransactionID := "PAPA-123"
+
+	testHelper.mockMoneyFlowRepository.EXPECT().GetSummaryDetailBySummaryID(ctx, "summary-123").
+		Return(models.MoneyFlowSummaryDetailBySummaryIDOut{ID: "summary-123", Status: constants.MoneyFlowStatusPending}, nil)
+	testHelper.mockSQLRepository.EXPECT().Atomic(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+			return steps(ctx, testHelper.mockSQLRepository)
+		})
+	// other request moved the summary after it was read, transition is validated again from the locked status
+	testHelper.mockMoneyFlowRepository.EXPECT().GetSummaryStatusForUpdate(ctx, "summary-123").
+		Return(constants.MoneyFlowStatusSuccess, nil)
+
+	err := testHelper.moneyFlowSvc.UpdateSummary(ctx, "summary-123", models.UpdateMoneyFlowSummaryRequest{
+		MoneyFlowStatus:   &status,
+		PapaTransactionID: &papaTransactionID,
+		Actor:             "finance.ops",
+	})
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "failed to update money flow summary")
+}
+
+func TestMoneyFlowService_DisburseDueSummaries(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	testHelper.mockFlagClient.EXPECT().
+		GetVariant(testHelper.config.FeatureFlagKeyLookup.MoneyFlowCalcBusinessRulesConfig).
+		Return(&api.Variant{
+			Name: "business_rules",
+			Payload: api.Payload{
+				Type: "json",
+				Value: `{"payment_configs":{"MF_EARN_DIVEST":{"transaction_type":"DVEST",` +
+					`"request_to_papa":{"description":"earn divest"},` +
+					`"source":{"bank_code":"014","bank_account_number":"1234567890"},` +
+					`"destination":{"bank_code":"008","bank_account_number":"0987654321"}}},` +
+					`"transaction_to_payment_map":{"DVEST":"MF_EARN_DIVEST"}}`,
+			},
+			Enabled: true,
+		}).AnyTimes()
+
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	today := time.Date(2025, 10, 22, 0, 0, 0, 0, jakarta)
+	summary := models.MoneyFlowSummaryForDisbursement{
+		ID:                            "summary-123",
+		ReferenceNumber:               "MF-2025-10-21-summary-123",
+		TransactionType:               "DVEST",
+		PaymentType:                   "MF_EARN_DIVEST",
+		TransactionSourceCreationDate: today.AddDate(0, 0, -1),
+		TotalTransfer:                 decimal.NewFromInt(1000),
+	}
+
+	// payment API rejects transfer without amount
+	rejectedSummary := summary
+	rejectedSummary.ID = "summary-456"
+	rejectedSummary.TotalTransfer = decimal.Zero
+
+	var firstTransferID string
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		doMock  func(ctx context.Context)
+		want    models.MoneyFlowDisbursementResult
+		wantErr bool
+	}{
+		{
+			name: "skip before cut-off time",
+			now:  today.Add(14 * time.Hour),
+		},
+		{
+			name: "success move pending summary to in progress",
+			now:  today.Add(16 * time.Hour),
+			doMock: func(ctx context.Context) {
+				testHelper.mockMoneyFlowRepository.EXPECT().
+					GetPendingSummariesForDisbursement(ctx, "MF_EARN_DIVEST", gomock.Any(), gomock.Any(), 10).
+					Return([]models.MoneyFlowSummaryForDisbursement{summary}, nil)
+				testHelper.mockSQLRepository.EXPECT().Atomic(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					}).Times(2)
+
+				// summary is claimed before the transfer is requested
+				claim := testHelper.mockMoneyFlowRepository.EXPECT().
+					ClaimSummaryForDisbursement(ctx, "summary-123", today.Add(16*time.Hour-15*time.Minute)).
+					Return(constants.MoneyFlowStatusPending, 1, nil)
+				claimEvent := testHelper.mockMoneyFlowRepository.EXPECT().CreateSummaryEvent(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, in models.CreateMoneyFlowSummaryEvent) error {
+						assert.Equal(t, constants.MoneyFlowStatusPending, in.FromStatus)
+						assert.Equal(t, constants.MoneyFlowStatusDisbursing, in.ToStatus)
+						assert.Empty(t, in.PapaTransactionID)
+						return nil
+					}).After(claim)
+
+				// outcome of the transfer is recorded in second transaction
+				testHelper.mockMoneyFlowRepository.EXPECT().GetSummaryStatusForUpdate(ctx, "summary-123").
+					Return(constants.MoneyFlowStatusDisbursing, nil).After(claimEvent)
+				testHelper.mockMoneyFlowRepository.EXPECT().UpdateSummary(ctx, "summary-123", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, update models.MoneyFlowSummaryUpdate) error {
+						assert.Equal(t, constants.MoneyFlowStatusInProgress, *update.MoneyFlowStatus)
+						assert.NotEmpty(t, *update.PapaTransactionID)
+						assert.NotNil(t, update.RequestedDate)
+						firstTransferID = *update.PapaTransactionID
+						return nil
+					})
+				testHelper.mockMoneyFlowRepository.EXPECT().CreateSummaryEvent(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, in models.CreateMoneyFlowSummaryEvent) error {
+						assert.Equal(t, constants.MoneyFlowStatusDisbursing, in.FromStatus)
+						assert.Equal(t, constants.MoneyFlowStatusInProgress, in.ToStatus)
+						assert.Equal(t, constants.MoneyFlowEventSourceSystem, in.Source)
+						assert.Equal(t, constants.MoneyFlowDisbursementActor, in.Actor)
+						return nil
+					}).After(claimEvent)
+			},
+			want: models.MoneyFlowDisbursementResult{Disbursed: 1},
+		},
+		{
+			name: "skip summary claimed by other run",
+			now:  today.Add(16*time.Hour + 15*time.Minute),
+			doMock: func(ctx context.Context) {
+				testHelper.mockMoneyFlowRepository.EXPECT().
+					GetPendingSummariesForDisbursement(ctx, "MF_EARN_DIVEST", gomock.Any(), gomock.Any(), 10).
+					Return([]models.MoneyFlowSummaryForDisbursement{summary}, nil)
+				testHelper.mockSQLRepository.EXPECT().Atomic(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockMoneyFlowRepository.EXPECT().
+					ClaimSummaryForDisbursement(ctx, "summary-123", gomock.Any()).
+					Return("", 0, common.ErrNoRowsAffected)
+			},
+			want: models.MoneyFlowDisbursementResult{Skipped: 1},
+		},
+		{
+			name: "failed transfer release summary to pending",
+			now:  today.Add(16*time.Hour + 30*time.Minute),
+			doMock: func(ctx context.Context) {
+				testHelper.mockMoneyFlowRepository.EXPECT().
+					GetPendingSummariesForDisbursement(ctx, "MF_EARN_DIVEST", gomock.Any(), gomock.Any(), 10).
+					Return([]models.MoneyFlowSummaryForDisbursement{rejectedSummary}, nil)
+				testHelper.mockSQLRepository.EXPECT().Atomic(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					}).Times(2)
+				testHelper.mockMoneyFlowRepository.EXPECT().
+					ClaimSummaryForDisbursement(ctx, "summary-456", gomock.Any()).
+					Return(constants.MoneyFlowStatusPending, 4, nil)
+				testHelper.mockMoneyFlowRepository.EXPECT().CreateSummaryEvent(ctx, gomock.Any()).Return(nil)
+				testHelper.mockMoneyFlowRepository.EXPECT().GetSummaryStatusForUpdate(ctx, "summary-456").
+					Return(constants.MoneyFlowStatusDisbursing, nil)
+				testHelper.mockMoneyFlowRepository.EXPECT().UpdateSummary(ctx, "summary-456", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, update models.MoneyFlowSummaryUpdate) error {
+						assert.Equal(t, constants.MoneyFlowStatusPending, *update.MoneyFlowStatus)
+						assert.Nil(t, update.PapaTransactionID)
+						return nil
+					})
+				testHelper.mockMoneyFlowRepository.EXPECT().CreateSummaryEvent(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, in models.CreateMoneyFlowSummaryEvent) error {
+						assert.Equal(t, constants.MoneyFlowStatusDisbursing, in.FromStatus)
+						assert.Equal(t, constants.MoneyFlowStatusPending, in.ToStatus)
+						return nil
+					})
+			},
+			want:    models.MoneyFlowDisbursementResult{Failed: 1},
+			wantErr: true,
+		},
+		{
+			name: "failed transfer of last attempt move summary to failed",
+			now:  today.Add(16*time.Hour + 45*time.Minute),
+			doMock: func(ctx context.Context) {
+				testHelper.mockMoneyFlowRepository.EXPECT().
+					GetPendingSummariesForDisbursement(ctx, "MF_EARN_DIVEST", gomock.Any(), gomock.Any(), 10).
+					Return([]models.MoneyFlowSummaryForDisbursement{rejectedSummary}, nil)
+				testHelper.mockSQLRepository.EXPECT().Atomic(ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					}).Times(2)
+				testHelper.mockMoneyFlowRepository.EXPECT().
+					ClaimSummaryForDisbursement(ctx, "summary-456", gomock.Any()).
+					Return(constants.MoneyFlowStatusPending, 5, nil)
+				testHelper.mockMoneyFlowRepository.EXPECT().CreateSummaryEvent(ctx, gomock.Any()).Return(nil)
+				testHelper.mockMoneyFlowRepository.EXPECT().GetSummaryStatusForUpdate(ctx, "summary-456").
+					Return(constants.MoneyFlowStatusDisbursing, nil)
+				testHelper.mockMoneyFlowRepository.EXPECT().UpdateSummary(ctx, "summary-456", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, update models.MoneyFlowSummaryUpdate) error {
+						assert.Equal(t, constants.MoneyFlowStatusFailed, *update.MoneyFlowStatus)
+						return nil
+					})
+				testHelper.mockMoneyFlowRepository.EXPECT().CreateSummaryEvent(ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, in models.CreateMoneyFlowSummaryEvent) error {
+						assert.Equal(t, constants.MoneyFlowStatusDisbursing, in.FromStatus)
+						assert.Equal(t, constants.MoneyFlowStatusFailed, in.ToStatus)
+						return nil
+					})
+			},
+			want:    models.MoneyFlowDisbursementResult{Failed: 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.doMock != nil {
+				tt.doMock(ctx)
+			}
+
+			got, err := testHelper.moneyFlowSvc.DisburseDueSummaries(ctx, tt.now)
+			assert.Equal(t, tt.wantErr, err != nil, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Contains(t, firstTransferID, "FAKE-")
+}
@@ -0,0 +1,288 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/constants"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/payment"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+)
+
+const (
+	defaultMoneyFlowDisbursementBatchSize    = 100
+	defaultMoneyFlowDisbursementClaimTimeout = 15 * time.Minute
+	defaultMoneyFlowDisbursementMaxAttempts  = 5
+)
+
+// DisburseDueSummaries sends PENDING summaries of previous days to payment API once cut-off time of the
+// payment type has passed, then moves them to IN_PROGRESS. Summary is claimed as DISBURSING before the transfer
+// is requested, so concurrent runs do not send it twice. Summary left DISBURSING by a dead run is sent again
+// after the claim timeout, summary ID is used as idempotency key so payment API returns the existing transfer.
+// Summary whose transfer request keeps failing is moved to FAILED after the max attempts.
+func (mf *moneyFlowCalc) DisburseDueSummaries(ctx context.Context, now time.Time) (result models.MoneyFlowDisbursementResult, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	cfg := mf.srv.conf.MoneyFlowDisbursement
+
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultMoneyFlowDisbursementBatchSize
+	}
+
+	paymentTypes := make([]string, 0, len(cfg.CutOffTimeByPaymentType))
+	for paymentType := range cfg.CutOffTimeByPaymentType {
+		paymentTypes = append(paymentTypes, paymentType)
+	}
+	sort.Strings(paymentTypes)
+
+	claimTimeout := cfg.ClaimTimeout
+	if claimTimeout <= 0 {
+		claimTimeout = defaultMoneyFlowDisbursementClaimTimeout
+	}
+	claimedBefore := now.Add(-claimTimeout)
+
+	today := mf.convertToJakartaDate(now)
+
+	var errs error
+	for _, paymentType := range paymentTypes {
+		due, err := mf.isPastCutOff(now, cfg.CutOffTimeByPaymentType[paymentType])
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("invalid cut-off time for %s: %w", paymentType, err))
+			continue
+		}
+
+		if !due {
+			continue
+		}
+
+		brd, _, err := mf.CheckEligibleTransaction(ctx, paymentType, "")
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to get business rules for %s: %w", paymentType, err))
+			continue
+		}
+
+		summaries, err := mf.srv.sqlRepo.GetMoneyFlowCalcRepository().GetPendingSummariesForDisbursement(ctx, paymentType, today, claimedBefore, batchSize)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to get pending summaries for %s: %w", paymentType, err))
+			continue
+		}
+
+		for _, summary := range summaries {
+			disbursed, err := mf.disburseSummary(ctx, summary, brd, claimedBefore)
+			switch {
+			case err != nil:
+				result.Failed++
+				errs = errors.Join(errs, err)
+			case !disbursed:
+				result.Skipped++
+			default:
+				result.Disbursed++
+			}
+		}
+	}
+
+	xlog.Info(ctx, constants.LogPrefixMoneyFlowDisbursement+" Finished disbursement run",
+		xlog.Int("disbursed", result.Disbursed),
+		xlog.Int("skipped", result.Skipped),
+		xlog.Int("failed", result.Failed))
+
+	return result, errs
+}
+
+// disburseSummary returns false when the summary is claimed by other run or no longer PENDING
+func (mf *moneyFlowCalc) disburseSummary(
+	ctx context.Context,
+	summary models.MoneyFlowSummaryForDisbursement,
+	brd *models.BusinessRuleConfig,
+	claimedBefore time.Time,
+) (bool, error) {
+	claimed, attempts, err := mf.claimSummaryForDisbursement(ctx, summary.ID, claimedBefore)
+	if err != nil {
+		mf.logUpdateError(ctx, summary.ID, err)
+		return false, fmt.Errorf("failed to claim summary %s: %w", summary.ID, err)
+	}
+
+	if !claimed {
+		xlog.Warn(ctx, constants.LogPrefixMoneyFlowDisbursement+" Summary is claimed by other run or no longer pending, skipped",
+			xlog.String("summary_id", summary.ID))
+		return false, nil
+	}
+
+	transfer, err := mf.srv.paymentClient.CreateTransfer(ctx, payment.RequestCreateTransfer{
+		IdempotencyKey:  summary.ID,
+		ReferenceNumber: summary.ReferenceNumber,
+		PaymentType:     summary.PaymentType,
+		Amount:          summary.TransferAmount(),
+		Description:     brd.RequestToPAPA.Description,
+		Source:          toPaymentBankAccount(brd.Source),
+		Destination:     toPaymentBankAccount(brd.Destination),
+	})
+	if err != nil {
+		xlog.Error(ctx, constants.LogPrefixMoneyFlowDisbursement+" Failed to create transfer",
+			xlog.String("summary_id", summary.ID),
+			xlog.String("payment_type", summary.PaymentType),
+			xlog.Err(err))
+		err = fmt.Errorf("failed to create transfer for summary %s: %w", summary.ID, err)
+
+		// release the claim so next run requests the transfer again with the same idempotency key,
+		// until the attempts are used up and the summary is left to finance as FAILED
+		releaseStatus := constants.MoneyFlowStatusPending
+		if attempts >= mf.maxDisbursementAttempts() {
+			releaseStatus = constants.MoneyFlowStatusFailed
+			xlog.Error(ctx, constants.LogPrefixMoneyFlowDisbursement+" Transfer attempts are used up, summary is failed",
+				xlog.String("summary_id", summary.ID),
+				xlog.Int("attempts", attempts))
+		}
+
+		_, errRelease := mf.finishDisbursement(ctx, summary.ID, releaseStatus, "")
+		if errRelease != nil {
+			mf.logUpdateError(ctx, summary.ID, errRelease)
+			err = errors.Join(err, fmt.Errorf("failed to release summary %s: %w", summary.ID, errRelease))
+		}
+
+		return false, err
+	}
+
+	disbursed, err := mf.finishDisbursement(ctx, summary.ID, constants.MoneyFlowStatusInProgress, transfer.ID)
+	if err != nil {
+		mf.logUpdateError(ctx, summary.ID, err)
+		return false, fmt.Errorf("failed to update summary %s after transfer %s: %w", summary.ID, transfer.ID, err)
+	}
+
+	if !disbursed {
+		xlog.Warn(ctx, constants.LogPrefixMoneyFlowDisbursement+" Summary is no longer disbursing, skipped",
+			xlog.String("summary_id", summary.ID),
+			xlog.String("papa_transaction_id", transfer.ID))
+		return false, nil
+	}
+
+	xlog.Info(ctx, constants.LogPrefixMoneyFlowDisbursement+" Summary disbursed",
+		xlog.String("summary_id", summary.ID),
+		xlog.String("payment_type", summary.PaymentType),
+		xlog.String("papa_transaction_id", transfer.ID),
+		xlog.String("amount", summary.TransferAmount().String()))
+
+	return true, nil
+}
+
+// claimSummaryForDisbursement moves the summary to DISBURSING and returns the attempts of the transfer request,
+// it returns false when other run has claimed it or it is no longer PENDING
+func (mf *moneyFlowCalc) claimSummaryForDisbursement(ctx context.Context, summaryID string, claimedBefore time.Time) (bool, int, error) {
+	var (
+		claimed  bool
+		attempts int
+	)
+	err := mf.srv.sqlRepo.Atomic(ctx, func(ctx context.Context, r repositories.SQLRepository) error {
+		repo := r.GetMoneyFlowCalcRepository()
+
+		fromStatus, claimAttempts, err := repo.ClaimSummaryForDisbursement(ctx, summaryID, claimedBefore)
+		if err != nil {
+			if errors.Is(err, common.ErrNoRowsAffected) {
+				return nil
+			}
+			return err
+		}
+
+		claimed, attempts = true, claimAttempts
+
+		return repo.CreateSummaryEvent(ctx, models.CreateMoneyFlowSummaryEvent{
+			SummaryID:  summaryID,
+			FromStatus: fromStatus,
+			ToStatus:   constants.MoneyFlowStatusDisbursing,
+			Source:     constants.MoneyFlowEventSourceSystem,
+			Actor:      constants.MoneyFlowDisbursementActor,
+			EventTime:  time.Now(),
+		})
+	})
+
+	return claimed, attempts, err
+}
+
+// finishDisbursement records the outcome of transfer request, it returns false when the summary is no longer DISBURSING
+func (mf *moneyFlowCalc) finishDisbursement(ctx context.Context, summaryID, status, papaTransactionID string) (bool, error) {
+	var finished bool
+	err := mf.srv.sqlRepo.Atomic(ctx, func(ctx context.Context, r repositories.SQLRepository) error {
+		repo := r.GetMoneyFlowCalcRepository()
+
+		currentStatus, err := repo.GetSummaryStatusForUpdate(ctx, summaryID)
+		if err != nil {
+			return err
+		}
+
+		if currentStatus != constants.MoneyFlowStatusDisbursing {
+			return nil
+		}
+
+		if err = models.ValidateMoneyFlowStatusTransition(currentStatus, status); err != nil {
+			return err
+		}
+
+		eventTime := time.Now()
+		update := models.MoneyFlowSummaryUpdate{
+			MoneyFlowStatus: &status,
+		}
+		if papaTransactionID != "" {
+			update.PapaTransactionID = &papaTransactionID
+			update.RequestedDate = &eventTime
+		}
+
+		if err = repo.UpdateSummary(ctx, summaryID, update); err != nil {
+			return err
+		}
+
+		finished = true
+
+		return repo.CreateSummaryEvent(ctx, models.CreateMoneyFlowSummaryEvent{
+			SummaryID:         summaryID,
+			FromStatus:        currentStatus,
+			ToStatus:          status,
+			Source:            constants.MoneyFlowEventSourceSystem,
+			Actor:             constants.MoneyFlowDisbursementActor,
+			PapaTransactionID: papaTransactionID,
+			EventTime:         eventTime,
+		})
+	})
+
+	return finished, err
+}
+
+func (mf *moneyFlowCalc) maxDisbursementAttempts() int {
+	if maxAttempts := mf.srv.conf.MoneyFlowDisbursement.MaxAttempts; maxAttempts > 0 {
+		return maxAttempts
+	}
+
+	return defaultMoneyFlowDisbursementMaxAttempts
+}
+
+// isPastCutOff checks whether now has passed cut-off time (HH:mm, Asia/Jakarta) of today
+func (mf *moneyFlowCalc) isPastCutOff(now time.Time, cutOff string) (bool, error) {
+	cutOffTime, err := time.Parse("15:04", cutOff)
+	if err != nil {
+		return false, err
+	}
+
+	today := mf.convertToJakartaDate(now)
+	cutOffToday := today.Add(time.Duration(cutOffTime.Hour())*time.Hour + time.Duration(cutOffTime.Minute())*time.Minute)
+
+	return !now.Before(cutOffToday), nil
+}
+
+func toPaymentBankAccount(b models.BankInfo) payment.BankAccount {
+	return payment.BankAccount{
+		AccountNumber:     b.AccountNumber,
+		BankCode:          b.BankCode,
+		BankName:          b.BankName,
+		BankAccountNumber: b.BankAccountNumber,
+		BankAccountName:   b.BankAccountName,
+	}
+}
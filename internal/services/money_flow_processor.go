@@ -54,6 +54,19 @@ func (tp *TransactionProcessor) ProcessOrUpdate(
 		return "", fmt.Errorf("failed to upsert summary: %w", err)
 	}
 
+	// First transition of the summary, later ones come from API or PAPA stream
+	if isNewRecord {
+		err = tp.repo.CreateSummaryEvent(ctx, models.CreateMoneyFlowSummaryEvent{
+			SummaryID: summaryID,
+			ToStatus:  summaryData.MoneyFlowStatus,
+			Source:    constants.MoneyFlowEventSourceSystem,
+			EventTime: summaryData.CreatedAt,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to create summary event: %w", err)
+		}
+	}
+
 	// Create detailed summary entry (links to transaction table)
 	if err := tp.transactionRepository.CreateDetailedSummary(ctx, summaryID, acuanTransactionID); err != nil {
 		return "", err
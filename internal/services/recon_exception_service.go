@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+)
+
+type ReconExceptionService interface {
+	List(ctx context.Context, opts models.ReconExceptionFilterOptions) (exceptions []models.ReconException, total int, err error)
+	GetByID(ctx context.Context, id uint64) (exception *models.ReconException, err error)
+	Assign(ctx context.Context, req models.AssignReconExceptionRequest) (exception *models.ReconException, err error)
+	Comment(ctx context.Context, req models.CommentReconExceptionRequest) (activity *models.ReconExceptionActivity, err error)
+	Resolve(ctx context.Context, req models.ResolveReconExceptionRequest) (exception *models.ReconException, err error)
+}
+
+type reconException service
+
+var _ ReconExceptionService = (*reconException)(nil)
+
+func (s *reconException) List(ctx context.Context, opts models.ReconExceptionFilterOptions) (exceptions []models.ReconException, total int, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	repo := s.srv.sqlRepo.GetReconExceptionRepository()
+
+	exceptions, err = repo.GetList(ctx, opts)
+	if err != nil {
+		return exceptions, total, err
+	}
+
+	total, err = repo.CountAll(ctx, opts)
+	if err != nil {
+		return
+	}
+
+	return exceptions, total, nil
+}
+
+// GetByID return exception with its activities as audit trail
+func (s *reconException) GetByID(ctx context.Context, id uint64) (exception *models.ReconException, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	repo := s.srv.sqlRepo.GetReconExceptionRepository()
+
+	exception, err = repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	exception.Activities, err = repo.ListActivities(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return exception, nil
+}
+
+// Assign set the person who follow up the exception, open exception is moved to investigating
+func (s *reconException) Assign(ctx context.Context, req models.AssignReconExceptionRequest) (exception *models.ReconException, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	err = s.srv.sqlRepo.Atomic(ctx, func(actx context.Context, r repositories.SQLRepository) error {
+		repo := r.GetReconExceptionRepository()
+
+		e, err := repo.GetByIDForUpdate(actx, req.ID)
+		if err != nil {
+			return err
+		}
+
+		if e.State.IsClosed() {
+			return common.ErrReconExceptionClosed
+		}
+
+		fromState := e.State
+		if e.State == models.ReconExceptionStateOpen {
+			e.State = models.ReconExceptionStateInvestigating
+		}
+		e.Assignee = req.Assignee
+
+		if err = repo.Update(actx, e); err != nil {
+			return err
+		}
+
+		err = repo.CreateActivity(actx, &models.ReconExceptionActivity{
+			ReconExceptionID: e.ID,
+			Action:           models.ReconExceptionActionAssign,
+			Actor:            req.Actor,
+			FromState:        fromState,
+			ToState:          e.State,
+			Comment:          fmt.Sprintf("assigned to %s", req.Assignee),
+		})
+		if err != nil {
+			return err
+		}
+
+		exception = e
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return exception, nil
+}
+
+// Comment add note to exception, comment is still allowed after exception is closed
+func (s *reconException) Comment(ctx context.Context, req models.CommentReconExceptionRequest) (activity *models.ReconExceptionActivity, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	repo := s.srv.sqlRepo.GetReconExceptionRepository()
+
+	e, err := repo.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	activity = &models.ReconExceptionActivity{
+		ReconExceptionID: e.ID,
+		Action:           models.ReconExceptionActionComment,
+		Actor:            req.Actor,
+		FromState:        e.State,
+		ToState:          e.State,
+		Comment:          req.Comment,
+	}
+	if err = repo.CreateActivity(ctx, activity); err != nil {
+		return nil, err
+	}
+
+	return activity, nil
+}
+
+// Resolve close the exception as resolved or written off.
+// The exception is locked until it is closed, so concurrent resolution waits and then sees it closed.
+// When adjustment is requested, wallet transaction is created in its own unit of work using ref number derived
+// from exception id, so retrying failed resolution will reuse the same adjustment instead of posting it twice.
+func (s *reconException) Resolve(ctx context.Context, req models.ResolveReconExceptionRequest) (exception *models.ReconException, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	nextState := models.ReconExceptionState(req.State)
+
+	err = s.srv.sqlRepo.Atomic(ctx, func(actx context.Context, r repositories.SQLRepository) error {
+		repo := r.GetReconExceptionRepository()
+
+		e, err := repo.GetByIDForUpdate(actx, req.ID)
+		if err != nil {
+			return err
+		}
+
+		if e.State.IsClosed() {
+			return common.ErrReconExceptionClosed
+		}
+		if !e.State.CanTransitionTo(nextState) {
+			return common.ErrReconExceptionInvalidState
+		}
+
+		if req.Adjustment != nil {
+			adjustment, err := s.createAdjustment(ctx, *e, *req.Adjustment, req.ClientId)
+			if err != nil {
+				return fmt.Errorf("failed to create adjustment: %w", err)
+			}
+			e.AdjustmentTransactionID = adjustment.ID
+		}
+
+		action := models.ReconExceptionActionResolve
+		if nextState == models.ReconExceptionStateWrittenOff {
+			action = models.ReconExceptionActionWriteOff
+		}
+
+		now := common.Now()
+		fromState := e.State
+		e.State = nextState
+		e.ResolutionNote = req.Note
+		e.ResolvedBy = req.Actor
+		e.ResolvedAt = &now
+
+		if err = repo.Update(actx, e); err != nil {
+			return err
+		}
+
+		err = repo.CreateActivity(actx, &models.ReconExceptionActivity{
+			ReconExceptionID: e.ID,
+			Action:           action,
+			Actor:            req.Actor,
+			FromState:        fromState,
+			ToState:          nextState,
+			Comment:          req.Note,
+		})
+		if err != nil {
+			return err
+		}
+
+		exception = e
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	xlog.Info(ctx, "[RECON-EXCEPTION]",
+		xlog.String("operation", "resolve"),
+		xlog.Uint64("recon_exception_id", exception.ID),
+		xlog.String("state", string(exception.State)),
+		xlog.String("adjustment_transaction_id", exception.AdjustmentTransactionID))
+
+	return exception, nil
+}
+
+func (s *reconException) createAdjustment(ctx context.Context, e models.ReconException, req models.ReconExceptionAdjustmentRequest, clientId string) (*models.WalletTransaction, error) {
+	// ref number alone identify the adjustment, retry with other transaction type must not post another one
+	existing, err := s.srv.sqlRepo.GetWalletTransactionRepository().GetByRefNumber(ctx, e.AdjustmentRefNumber())
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	return s.srv.WalletTrx.CreateTransaction(ctx, req.ToCreateWalletTransactionRequest(e, clientId, common.Now()))
+}
@@ -0,0 +1,281 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReconExceptionService_Assign(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	type args struct {
+		ctx context.Context
+		req models.AssignReconExceptionRequest
+	}
+	tests := []struct {
+		name      string
+		args      args
+		doMock    func(args args)
+		wantState models.ReconExceptionState
+		wantErr   error
+	}{
+		{
+			name: "success assign open exception",
+			args: args{
+				ctx: context.Background(),
+				req: models.AssignReconExceptionRequest{ID: 1, Assignee: "finance.ops", Actor: "finance.lead"},
+			},
+			doMock: func(args args) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockReconExceptionRepository.EXPECT().GetByIDForUpdate(args.ctx, args.req.ID).
+					Return(&models.ReconException{ID: 1, State: models.ReconExceptionStateOpen}, nil)
+				testHelper.mockReconExceptionRepository.EXPECT().Update(args.ctx, &models.ReconException{
+					ID:       1,
+					State:    models.ReconExceptionStateInvestigating,
+					Assignee: "finance.ops",
+				}).Return(nil)
+				testHelper.mockReconExceptionRepository.EXPECT().CreateActivity(args.ctx, &models.ReconExceptionActivity{
+					ReconExceptionID: 1,
+					Action:           models.ReconExceptionActionAssign,
+					Actor:            "finance.lead",
+					FromState:        models.ReconExceptionStateOpen,
+					ToState:          models.ReconExceptionStateInvestigating,
+					Comment:          "assigned to finance.ops",
+				}).Return(nil)
+			},
+			wantState: models.ReconExceptionStateInvestigating,
+		},
+		{
+			name: "failed assign closed exception",
+			args: args{
+				ctx: context.Background(),
+				req: models.AssignReconExceptionRequest{ID: 1, Assignee: "finance.ops", Actor: "finance.lead"},
+			},
+			doMock: func(args args) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockReconExceptionRepository.EXPECT().GetByIDForUpdate(args.ctx, args.req.ID).
+					Return(&models.ReconException{ID: 1, State: models.ReconExceptionStateResolved}, nil)
+			},
+			wantErr: common.ErrReconExceptionClosed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			got, err := testHelper.reconExceptionSvc.Assign(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantState, got.State)
+		})
+	}
+}
+
+func TestReconExceptionService_Comment(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	type args struct {
+		ctx context.Context
+		req models.CommentReconExceptionRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr bool
+	}{
+		{
+			name: "success comment",
+			args: args{
+				ctx: context.Background(),
+				req: models.CommentReconExceptionRequest{ID: 1, Comment: "bank statement requested", Actor: "finance.ops"},
+			},
+			doMock: func(args args) {
+				testHelper.mockReconExceptionRepository.EXPECT().GetByID(args.ctx, args.req.ID).
+					Return(&models.ReconException{ID: 1, State: models.ReconExceptionStateInvestigating}, nil)
+				testHelper.mockReconExceptionRepository.EXPECT().CreateActivity(args.ctx, &models.ReconExceptionActivity{
+					ReconExceptionID: 1,
+					Action:           models.ReconExceptionActionComment,
+					Actor:            "finance.ops",
+					FromState:        models.ReconExceptionStateInvestigating,
+					ToState:          models.ReconExceptionStateInvestigating,
+					Comment:          "bank statement requested",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "exception not found",
+			args: args{
+				ctx: context.Background(),
+				req: models.CommentReconExceptionRequest{ID: 1, Comment: "bank statement requested", Actor: "finance.ops"},
+			},
+			doMock: func(args args) {
+				testHelper.mockReconExceptionRepository.EXPECT().GetByID(args.ctx, args.req.ID).
+					Return(nil, common.ErrDataNotFound)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			_, err := testHelper.reconExceptionSvc.Comment(tt.args.ctx, tt.args.req)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func TestReconExceptionService_Resolve(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	openException := func() *models.ReconException {
+		return &models.ReconException{
+			ID:             7,
+			ReconHistoryID: 1,
+			Identifier:     "86861101189513",
+			Amount:         decimal.NewFromInt(100000),
+			State:          models.ReconExceptionStateInvestigating,
+		}
+	}
+
+	type args struct {
+		ctx context.Context
+		req models.ResolveReconExceptionRequest
+	}
+	tests := []struct {
+		name           string
+		args           args
+		doMock         func(args args)
+		wantState      models.ReconExceptionState
+		wantAdjustment string
+		wantErr        error
+	}{
+		{
+			name: "success write off without adjustment",
+			args: args{
+				ctx: context.Background(),
+				req: models.ResolveReconExceptionRequest{ID: 7, State: "WRITTEN_OFF", Note: "immaterial", Actor: "finance.ops"},
+			},
+			doMock: func(args args) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockReconExceptionRepository.EXPECT().GetByIDForUpdate(args.ctx, args.req.ID).Return(openException(), nil)
+				testHelper.mockReconExceptionRepository.EXPECT().Update(args.ctx, gomock.Any()).Return(nil)
+				testHelper.mockReconExceptionRepository.EXPECT().CreateActivity(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, in *models.ReconExceptionActivity) error {
+						assert.Equal(t, models.ReconExceptionActionWriteOff, in.Action)
+						assert.Equal(t, models.ReconExceptionStateWrittenOff, in.ToState)
+						return nil
+					})
+			},
+			wantState: models.ReconExceptionStateWrittenOff,
+		},
+		{
+			name: "success resolve reusing existing adjustment",
+			args: args{
+				ctx: context.Background(),
+				req: models.ResolveReconExceptionRequest{
+					ID:    7,
+					State: "RESOLVED",
+					Note:  "bank fee adjustment",
+					Actor: "finance.ops",
+					Adjustment: &models.ReconExceptionAdjustmentRequest{
+						AccountNumber:   "21100100000001",
+						TransactionType: "ADJRC",
+						TransactionFlow: models.TransactionFlowCashIn,
+						NetAmount:       models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(2500)), Currency: "IDR"},
+					},
+				},
+			},
+			doMock: func(args args) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockReconExceptionRepository.EXPECT().GetByIDForUpdate(args.ctx, args.req.ID).Return(openException(), nil)
+				testHelper.mockWalletTrxRepository.EXPECT().GetByRefNumber(args.ctx, "RECONEXC7").
+					Return(&models.WalletTransaction{ID: "wallet-trx-1"}, nil)
+				testHelper.mockReconExceptionRepository.EXPECT().Update(args.ctx, gomock.Any()).Return(nil)
+				testHelper.mockReconExceptionRepository.EXPECT().CreateActivity(args.ctx, gomock.Any()).Return(nil)
+			},
+			wantState:      models.ReconExceptionStateResolved,
+			wantAdjustment: "wallet-trx-1",
+		},
+		{
+			name: "failed resolve closed exception",
+			args: args{
+				ctx: context.Background(),
+				req: models.ResolveReconExceptionRequest{ID: 7, State: "RESOLVED", Note: "duplicate", Actor: "finance.ops"},
+			},
+			doMock: func(args args) {
+				e := openException()
+				e.State = models.ReconExceptionStateWrittenOff
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockReconExceptionRepository.EXPECT().GetByIDForUpdate(args.ctx, args.req.ID).Return(e, nil)
+			},
+			wantErr: common.ErrReconExceptionClosed,
+		},
+		{
+			name: "failed resolve with invalid state",
+			args: args{
+				ctx: context.Background(),
+				req: models.ResolveReconExceptionRequest{ID: 7, State: "OPEN", Note: "reopen", Actor: "finance.ops"},
+			},
+			doMock: func(args args) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(args.ctx, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						return steps(ctx, testHelper.mockSQLRepository)
+					})
+				testHelper.mockReconExceptionRepository.EXPECT().GetByIDForUpdate(args.ctx, args.req.ID).Return(openException(), nil)
+			},
+			wantErr: common.ErrReconExceptionInvalidState,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			got, err := testHelper.reconExceptionSvc.Resolve(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantState, got.State)
+			assert.Equal(t, tt.wantAdjustment, got.AdjustmentTransactionID)
+			assert.Equal(t, tt.args.req.Actor, got.ResolvedBy)
+			assert.NotNil(t, got.ResolvedAt)
+		})
+	}
+}
@@ -180,8 +180,8 @@ func (s *reconService) getLastDailyBalance(ctx context.Context) (lastDailyBalanc
 func (s *reconService) UploadReconTemplate(ctx context.Context, req *models.UploadReconFileRequest) error {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	// Check header
 	ctx, cancel := context.WithCancel(ctx)
@@ -282,8 +282,8 @@ func (s *reconService) deleteReconFile(ctx context.Context, gcsPayload *models.C
 }
 
 func (s *reconService) GetListReconHistory(ctx context.Context, opts models.ReconToolHistoryFilterOptions) (reconHistories []models.ReconToolHistory, total int, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	repo := s.srv.sqlRepo.GetReconToolHistoryRepository()
 
@@ -301,8 +301,8 @@ func (s *reconService) GetListReconHistory(ctx context.Context, opts models.Reco
 }
 
 func (s *reconService) GetResultFileURL(ctx context.Context, id uint64) (url string, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	repo := s.srv.sqlRepo.GetReconToolHistoryRepository()
 
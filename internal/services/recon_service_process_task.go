@@ -15,13 +15,45 @@ import (
 	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
 
 	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/shopspring/decimal"
 )
 
 type storageRecon localstorage.LocalStorage[[]models.ReconRecord]
 
+type storageReconKey localstorage.LocalStorage[string]
+
+// reconExceptionBatchSize is the number of exceptions buffered before saved to database
+const reconExceptionBatchSize = 500
+
+// reconProcess hold the state of a single recon task
+type reconProcess struct {
+	history *models.ReconToolHistory
+	rule    models.ReconMatchingRule
+
+	// trx store transactions grouped by identifier
+	trx storageRecon
+
+	// unmatched store csv rows that is not matched yet, only used for one-to-many matching
+	unmatched storageRecon
+
+	// secondary store secondary key to identifier, only used when secondary key enabled
+	secondary storageReconKey
+
+	report  *csv.Writer
+	summary models.ReconSummary
+
+	// exceptions buffer unmatched records that will be saved for follow up
+	exceptions []models.ReconException
+
+	// kept count exceptions of previous attempt which are worked by operator, keyed by match key,
+	// the same unmatched record is not saved again
+	kept map[string]int
+}
+
 func (s *reconService) ProcessReconTaskQueue(ctx context.Context, reconHistoryId uint64) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	reconHistory, err := s.prepareReconHistory(ctx, reconHistoryId)
 	if err != nil {
@@ -29,22 +61,47 @@ func (s *reconService) ProcessReconTaskQueue(ctx context.Context, reconHistoryId
 	}
 
 	// Log recon start
-	xlog.Info(ctx, "[RECON-INFO]", 
+	xlog.Info(ctx, "[RECON-INFO]",
 		xlog.String("operation", "Start process for recon data"),
 		xlog.Uint64("recon_history_id", reconHistoryId))
 
-	ls, err := s.createLocalStorage()
+	p := &reconProcess{
+		history: reconHistory,
+		rule:    s.getReconMatchingRule(reconHistory.TransactionType),
+		summary: models.ReconSummary{},
+	}
+
+	p.trx, err = s.createLocalStorage("reconTool")
 	if err != nil {
 		return err
 	}
-	defer s.closeLocalStorage(ls)
+	defer s.closeLocalStorage(p.trx)
+
+	if p.rule.EnableOneToMany {
+		p.unmatched, err = s.createLocalStorage("reconToolUnmatched")
+		if err != nil {
+			return err
+		}
+		defer s.closeLocalStorage(p.unmatched)
+	}
+
+	if p.rule.UseSecondaryKey() {
+		p.secondary, err = localstorage.NewBadgerStorage[string]("reconToolSecondaryKey")
+		if err != nil {
+			return fmt.Errorf("failed to make local storage: %w", err)
+		}
+		defer func() {
+			p.secondary.Close()
+			p.secondary.Clean()
+		}()
+	}
 
-	err = s.streamTransactionsToLocalStorage(ctx, ls, *reconHistory)
+	err = s.streamTransactionsToLocalStorage(ctx, p)
 	if err != nil {
 		return err
 	}
 
-	resultFilePath, err := s.reconcileRecordsAndGenerateReport(ctx, reconHistory, ls)
+	resultFilePath, err := s.reconcileRecordsAndGenerateReport(ctx, p)
 	if err != nil {
 		errUpdateStatus := s.updateReconHistoryStatus(ctx, reconHistory, models.ReconHistoryStatusFailed, "")
 		if errUpdateStatus != nil {
@@ -54,21 +111,41 @@ func (s *reconService) ProcessReconTaskQueue(ctx context.Context, reconHistoryId
 		return err
 	}
 
+	reconHistory.Summary = p.summary
 	err = s.updateReconHistoryStatus(ctx, reconHistory, models.ReconHistoryStatusSuccess, resultFilePath)
 	if err != nil {
 		return err
 	}
 
 	// Log recon completion
-	xlog.Info(ctx, "[RECON-INFO]", 
+	xlog.Info(ctx, "[RECON-INFO]",
 		xlog.String("operation", "Finish process for recon data"),
-		xlog.Uint64("recon_history_id", reconHistoryId))
+		xlog.Uint64("recon_history_id", reconHistoryId),
+		xlog.Any("summary", p.summary))
 
 	return nil
 }
 
-func (s *reconService) createLocalStorage() (storageRecon, error) {
-	ls, err := localstorage.NewBadgerStorage[[]models.ReconRecord]("reconTool")
+// getReconMatchingRule return matching rule for transaction type, fallback to default rule
+func (s *reconService) getReconMatchingRule(transactionType string) models.ReconMatchingRule {
+	cfg := s.srv.conf.ReconEngine.MatchingRule
+	if override, ok := s.srv.conf.ReconEngine.MatchingRuleByTransactionType[transactionType]; ok {
+		cfg = override
+	}
+
+	return models.ReconMatchingRule{
+		AmountToleranceAbsolute: decimal.NewFromFloat(cfg.AmountToleranceAbsolute),
+		AmountTolerancePercent:  decimal.NewFromFloat(cfg.AmountTolerancePercent),
+		DateWindowDays:          cfg.DateWindowDays,
+		EnableManyToOne:         cfg.EnableManyToOne,
+		EnableOneToMany:         cfg.EnableOneToMany,
+		MaxGroupSize:            cfg.MaxGroupSize,
+		SecondaryKey:            cfg.SecondaryKey,
+	}
+}
+
+func (s *reconService) createLocalStorage(bucket string) (storageRecon, error) {
+	ls, err := localstorage.NewBadgerStorage[[]models.ReconRecord](bucket)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make local storage: %w", err)
 	}
@@ -99,13 +176,22 @@ func (s *reconService) prepareReconHistory(ctx context.Context, reconHistoryId u
 	return reconHistory, nil
 }
 
-func (s *reconService) streamTransactionsToLocalStorage(ctx context.Context, ls storageRecon, reconHistory models.ReconToolHistory) error {
+func (s *reconService) streamTransactionsToLocalStorage(ctx context.Context, p *reconProcess) error {
 	repoTransaction := s.srv.sqlRepo.GetTransactionRepository()
 
-	chanTrx := repoTransaction.StreamAll(ctx, models.TransactionFilterOptions{
-		TransactionDate: reconHistory.TransactionDate,
-		TransactionType: reconHistory.TransactionType,
-	})
+	opts := models.TransactionFilterOptions{
+		TransactionType: p.history.TransactionType,
+	}
+	if p.rule.UseDateWindow() {
+		// load transactions around recon date, so T+N posting can still be matched
+		startDate, endDate := p.rule.DateRange(*p.history.TransactionDate)
+		opts.StartDate = &startDate
+		opts.EndDate = &endDate
+	} else {
+		opts.TransactionDate = p.history.TransactionDate
+	}
+
+	chanTrx := repoTransaction.StreamAll(ctx, opts)
 	for trx := range chanTrx {
 		if trx.Err != nil {
 			return fmt.Errorf("failed to stream transaction: %w", trx.Err)
@@ -116,26 +202,33 @@ func (s *reconService) streamTransactionsToLocalStorage(ctx context.Context, ls
 			return fmt.Errorf("failed to convert transaction to recon record: %w", err)
 		}
 
-		records, err := ls.Get(rr.Identifier)
+		records, err := p.trx.Get(rr.Identifier)
 		if err != nil {
 			return fmt.Errorf("failed to get value from localstorage: %w", err)
 		}
 
 		records = append(records, *rr)
 
-		err = ls.Set(rr.Identifier, records)
+		err = p.trx.Set(rr.Identifier, records)
 		if err != nil {
 			return fmt.Errorf("failed to set value to localstorage: %w", err)
 		}
+
+		if p.secondary != nil && rr.RefNumber != rr.Identifier {
+			err = p.secondary.Set(rr.RefNumber, rr.Identifier)
+			if err != nil {
+				return fmt.Errorf("failed to set value to localstorage: %w", err)
+			}
+		}
 	}
 
 	return nil
 }
 
-func (s *reconService) reconcileRecordsAndGenerateReport(ctx context.Context, reconHistory *models.ReconToolHistory, ls storageRecon) (string, error) {
+func (s *reconService) reconcileRecordsAndGenerateReport(ctx context.Context, p *reconProcess) (string, error) {
 	repoGCS := s.srv.cloudStorage
 
-	gcsUploadedFilePayload := models.NewCloudStoragePayload(reconHistory.UploadedFilePath)
+	gcsUploadedFilePayload := models.NewCloudStoragePayload(p.history.UploadedFilePath)
 	fileReader, err := repoGCS.NewReader(ctx, &gcsUploadedFilePayload)
 	if err != nil {
 		return "", fmt.Errorf("failed to read csv file: %w", err)
@@ -153,14 +246,19 @@ func (s *reconService) reconcileRecordsAndGenerateReport(ctx context.Context, re
 		err = resultFile.Close()
 	}()
 
-	reportFile := csv.NewWriter(resultFile)
-	defer reportFile.Flush()
+	p.report = csv.NewWriter(resultFile)
+	defer p.report.Flush()
 
-	err = reportFile.Write(models.CSVHeaderReconRecord)
+	err = p.report.Write(models.CSVHeaderReconRecord)
 	if err != nil {
 		return "", fmt.Errorf("failed to write header to file: %w", err)
 	}
 
+	err = s.prepareReconExceptions(ctx, p)
+	if err != nil {
+		return "", err
+	}
+
 	csvReader := csv.NewReader(fileReader)
 	for {
 		row, err := csvReader.Read()
@@ -171,32 +269,55 @@ func (s *reconService) reconcileRecordsAndGenerateReport(ctx context.Context, re
 			return "", fmt.Errorf("failed to read csv row: %w", err)
 		}
 
-		err = s.processCSVRow(row, reconHistory, ls, reportFile)
+		err = s.processCSVRow(row, p)
 		if err != nil {
 			return "", fmt.Errorf("failed to process csv row: %w", err)
 		}
+
+		err = s.saveReconExceptions(ctx, p, false)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// try to settle a single transaction with several csv rows
+	if p.unmatched != nil {
+		err = s.processUnmatchedCSVRows(p)
+		if err != nil {
+			return "", fmt.Errorf("failed to process unmatched csv row: %w", err)
+		}
 	}
 
 	// check if there is any record in localstorage that not exists in csv
 	// if exists, write it to report file with status "Exists in DB, Not Exists in CSV"
-	err = ls.ForEach(func(key string, value []models.ReconRecord) error {
+	reconDate := p.reconDate()
+	err = p.trx.ForEach(func(key string, value []models.ReconRecord) error {
 		for _, record := range value {
+			// transaction outside recon date is only loaded as candidate for date window matching
+			if p.rule.UseDateWindow() && record.PaymentDate != reconDate {
+				continue
+			}
+
 			record.Status = models.StatusReconRecordExistsDBNotExistsCSV
-			err = reportFile.Write(record.ToCSVRow(*reconHistory))
-			if err != nil {
-				return fmt.Errorf("failed to write payload to file: %w", err)
+			if err := p.write(record); err != nil {
+				return err
 			}
 		}
-		return nil
+		return s.saveReconExceptions(ctx, p, false)
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to loop localstorage: %w", err)
 	}
 
+	err = s.saveReconExceptions(ctx, p, true)
+	if err != nil {
+		return "", err
+	}
+
 	return gcsResultFilePayload.GetFilePath(), nil
 }
 
-func (s *reconService) processCSVRow(row []string, reconHistory *models.ReconToolHistory, ls storageRecon, reportFile *csv.Writer) error {
+func (s *reconService) processCSVRow(row []string, p *reconProcess) error {
 	isHeader := strings.Contains(strings.Join(row, ","), "identifier,amount,payment_date")
 	if isHeader {
 		return nil
@@ -208,76 +329,131 @@ func (s *reconService) processCSVRow(row []string, reconHistory *models.ReconToo
 		if len(row) > 0 {
 			identifier = row[0]
 		}
-		rr := models.ReconRecord{Identifier: identifier}
-		err = reportFile.Write(rr.ToCSVRowWithErr(*reconHistory, err))
-		if err != nil {
-			return fmt.Errorf("failed to write payload to file: %w", err)
-		}
-		return nil
+		return p.writeErr(models.ReconRecord{Identifier: identifier}, err)
 	}
 
-	if csvRecord.PaymentDate != reconHistory.TransactionDate.Format(common.DateFormatDDMMMYYYY) {
+	if !p.rule.DateWithinWindow(csvRecord.PaymentDate, p.reconDate()) {
 		return nil
 	}
 
-	acuanRecords, err := ls.Get(csvRecord.Identifier)
+	match, err := p.matchRecord(csvRecord)
 	if err != nil {
-		err = reportFile.Write(csvRecord.ToCSVRowWithErr(*reconHistory, err))
-		if err != nil {
-			return fmt.Errorf("failed to write payload to file: %w", err)
-		}
-		return nil
+		return p.writeErr(*csvRecord, err)
 	}
 
-	if len(acuanRecords) == 0 {
-		csvRecord.Status = models.StatusReconRecordNotExistsDBExistsCSV
-		err = reportFile.Write(csvRecord.ToCSVRow(*reconHistory))
-		if err != nil {
-			return fmt.Errorf("failed to write payload to file: %w", err)
+	if !match {
+		if p.unmatched != nil {
+			// defer the decision until all csv rows are read, it may be part of one-to-many settlement
+			return p.deferUnmatched(*csvRecord)
 		}
-		return nil
-	}
 
-	var match bool
-	for i := 0; i < len(acuanRecords); i++ {
-		if acuanRecords[i].Amount.Equal(csvRecord.Amount) {
-			match = true
-			csvRecord.Match = true
-			csvRecord.RefNumber = acuanRecords[i].RefNumber
-			csvRecord.Status = models.StatusReconRecordMatch
-			acuanRecords = append(acuanRecords[:i], acuanRecords[i+1:]...)
-			break
+		// row from other date is only used when it match, otherwise it belongs to other recon date
+		if csvRecord.PaymentDate != p.reconDate() {
+			return nil
 		}
-	}
-	if !match {
+
 		csvRecord.Status = models.StatusReconRecordNotExistsDBExistsCSV
 	}
 
-	if len(acuanRecords) > 0 {
-		err = ls.Set(csvRecord.Identifier, acuanRecords)
+	return p.write(*csvRecord)
+}
+
+// processUnmatchedCSVRows match the remaining transactions with group of unmatched csv rows,
+// then write the rest of csv rows as "Not Exists in DB, Exists in CSV"
+func (s *reconService) processUnmatchedCSVRows(p *reconProcess) error {
+	reconDate := p.reconDate()
+
+	return p.unmatched.ForEach(func(key string, csvRecords []models.ReconRecord) error {
+		candidates, err := p.trx.Get(key)
 		if err != nil {
-			err = reportFile.Write(csvRecord.ToCSVRowWithErr(*reconHistory, err))
-			if err != nil {
-				return fmt.Errorf("failed to write payload to file: %w", err)
+			for _, record := range csvRecords {
+				if err := p.writeErr(record, err); err != nil {
+					return err
+				}
 			}
 			return nil
 		}
-	} else {
-		err = ls.Delete(csvRecord.Identifier)
-		if err != nil {
-			err = reportFile.Write(csvRecord.ToCSVRowWithErr(*reconHistory, err))
-			if err != nil {
-				return fmt.Errorf("failed to write payload to file: %w", err)
+
+		var settled []int
+		for i, candidate := range candidates {
+			result := p.rule.FindGroup(candidate, csvRecords, models.ReconMatchRuleOneToMany)
+			if !result.Matched() {
+				continue
+			}
+
+			for _, idx := range result.Indexes {
+				record := csvRecords[idx]
+				record.SetMatch(result, candidate.RefNumber)
+				if err := p.write(record); err != nil {
+					return err
+				}
+			}
+
+			csvRecords = models.RemoveReconRecords(csvRecords, result.Indexes)
+			settled = append(settled, i)
+		}
+
+		if len(settled) > 0 {
+			if err := p.saveCandidates(key, models.RemoveReconRecords(candidates, settled)); err != nil {
+				for _, record := range csvRecords {
+					if err := p.writeErr(record, err); err != nil {
+						return err
+					}
+				}
+				return nil
 			}
-			return nil
 		}
+
+		for _, record := range csvRecords {
+			if record.PaymentDate != reconDate {
+				continue
+			}
+
+			record.Status = models.StatusReconRecordNotExistsDBExistsCSV
+			if err := p.write(record); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// prepareReconExceptions clean up exceptions from previous attempt which are not worked yet,
+// so retrying recon will not duplicate them. Worked exceptions are kept along with their activities
+func (s *reconService) prepareReconExceptions(ctx context.Context, p *reconProcess) error {
+	repo := s.srv.sqlRepo.GetReconExceptionRepository()
+
+	err := repo.DeleteUntouchedByReconHistoryID(ctx, uint64(p.history.ID))
+	if err != nil {
+		return fmt.Errorf("failed to delete recon exceptions: %w", err)
 	}
 
-	err = reportFile.Write(csvRecord.ToCSVRow(*reconHistory))
+	kept, err := repo.GetByReconHistoryID(ctx, uint64(p.history.ID))
 	if err != nil {
-		return fmt.Errorf("failed to write payload to file: %w", err)
+		return fmt.Errorf("failed to get kept recon exceptions: %w", err)
+	}
+
+	p.kept = make(map[string]int, len(kept))
+	for _, e := range kept {
+		p.kept[e.MatchKey()]++
+	}
+
+	return nil
+}
+
+// saveReconExceptions save buffered exceptions when buffer is full or force is true
+func (s *reconService) saveReconExceptions(ctx context.Context, p *reconProcess, force bool) error {
+	if len(p.exceptions) == 0 || (!force && len(p.exceptions) < reconExceptionBatchSize) {
+		return nil
 	}
 
+	err := s.srv.sqlRepo.GetReconExceptionRepository().BulkCreate(ctx, p.exceptions)
+	if err != nil {
+		return fmt.Errorf("failed to save recon exceptions: %w", err)
+	}
+	p.exceptions = p.exceptions[:0]
+
 	return nil
 }
 
@@ -291,3 +467,118 @@ func (s *reconService) updateReconHistoryStatus(ctx context.Context, rh *models.
 
 	return nil
 }
+
+func (p *reconProcess) reconDate() string {
+	return p.history.TransactionDate.Format(common.DateFormatDDMMMYYYY)
+}
+
+// matchRecord try to match record using identifier, then fallback to secondary key.
+// Matched transactions will be removed from local storage.
+func (p *reconProcess) matchRecord(record *models.ReconRecord) (bool, error) {
+	match, err := p.matchByKey(record, record.Identifier, false)
+	if err != nil || match || p.secondary == nil {
+		return match, err
+	}
+
+	identifier, err := p.secondary.Get(record.Identifier)
+	if err != nil {
+		return false, err
+	}
+	if identifier == "" || identifier == record.Identifier {
+		return false, nil
+	}
+
+	return p.matchByKey(record, identifier, true)
+}
+
+func (p *reconProcess) matchByKey(record *models.ReconRecord, key string, bySecondaryKey bool) (bool, error) {
+	candidates, err := p.trx.Get(key)
+	if err != nil {
+		return false, err
+	}
+	if len(candidates) == 0 {
+		return false, nil
+	}
+
+	var refNumber string
+	result := p.rule.FindMatch(*record, candidates)
+	if result.Matched() {
+		refNumber = candidates[result.Indexes[0]].RefNumber
+	} else if p.rule.EnableManyToOne {
+		result = p.rule.FindGroup(*record, candidates, models.ReconMatchRuleManyToOne)
+		refNumber = models.JoinReconRefNumbers(candidates, result.Indexes)
+	}
+
+	if !result.Matched() {
+		return false, nil
+	}
+
+	result.BySecondaryKey = bySecondaryKey
+	record.SetMatch(result, refNumber)
+
+	err = p.saveCandidates(key, models.RemoveReconRecords(candidates, result.Indexes))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (p *reconProcess) saveCandidates(key string, candidates []models.ReconRecord) error {
+	if len(candidates) > 0 {
+		return p.trx.Set(key, candidates)
+	}
+
+	return p.trx.Delete(key)
+}
+
+func (p *reconProcess) deferUnmatched(record models.ReconRecord) error {
+	records, err := p.unmatched.Get(record.Identifier)
+	if err != nil {
+		return p.writeErr(record, err)
+	}
+
+	err = p.unmatched.Set(record.Identifier, append(records, record))
+	if err != nil {
+		return p.writeErr(record, err)
+	}
+
+	return nil
+}
+
+// addException buffer exception unless the same record is kept from previous attempt
+func (p *reconProcess) addException(e models.ReconException) {
+	key := e.MatchKey()
+	if p.kept[key] > 0 {
+		p.kept[key]--
+		return
+	}
+
+	p.exceptions = append(p.exceptions, e)
+}
+
+func (p *reconProcess) write(record models.ReconRecord) error {
+	p.summary.Add(record.Status)
+
+	if models.IsReconExceptionStatus(record.Status) {
+		p.addException(models.NewReconException(*p.history, record))
+	}
+
+	err := p.report.Write(record.ToCSVRow(*p.history))
+	if err != nil {
+		return fmt.Errorf("failed to write payload to file: %w", err)
+	}
+
+	return nil
+}
+
+func (p *reconProcess) writeErr(record models.ReconRecord, errRecord error) error {
+	p.summary.AddError()
+
+	err := p.report.Write(record.ToCSVRowWithErr(*p.history, errRecord))
+	if err != nil {
+		return fmt.Errorf("failed to write payload to file: %w", err)
+	}
+
+	return nil
+}
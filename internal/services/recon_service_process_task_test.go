@@ -17,6 +17,7 @@ func Test_ReconService_ProcessReconTaskQueue(t *testing.T) {
 	reconSUT := initReconSUT(t)
 	reconSUT.mockSQLRepo.EXPECT().GetReconToolHistoryRepository().Return(reconSUT.mockReconToolHistoryRepo).AnyTimes()
 	reconSUT.mockSQLRepo.EXPECT().GetTransactionRepository().Return(reconSUT.mockTransactionRepository).AnyTimes()
+	reconSUT.mockSQLRepo.EXPECT().GetReconExceptionRepository().Return(reconSUT.mockReconExceptionRepo).AnyTimes()
 
 	defaultTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
 	timeNow := time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)
@@ -26,6 +27,22 @@ func Test_ReconService_ProcessReconTaskQueue(t *testing.T) {
 		id  uint64
 	}
 
+	expectReconExceptions := func(args args, kept []models.ReconException, wantIdentifiers ...string) {
+		reconSUT.mockReconExceptionRepo.EXPECT().DeleteUntouchedByReconHistoryID(args.ctx, args.id).Return(nil)
+		reconSUT.mockReconExceptionRepo.EXPECT().GetByReconHistoryID(args.ctx, args.id).Return(kept, nil)
+		reconSUT.mockReconExceptionRepo.EXPECT().BulkCreate(args.ctx, gomock.Any()).
+			DoAndReturn(func(ctx context.Context, in []models.ReconException) error {
+				var identifiers []string
+				for _, e := range in {
+					assert.Equal(t, models.ReconExceptionStateOpen, e.State)
+					assert.Equal(t, args.id, e.ReconHistoryID)
+					identifiers = append(identifiers, e.Identifier)
+				}
+				assert.Equal(t, wantIdentifiers, identifiers)
+				return nil
+			})
+	}
+
 	type mockData struct {
 		resultFile *os.File
 		inputFile  *os.File
@@ -119,6 +136,7 @@ func Test_ReconService_ProcessReconTaskQueue(t *testing.T) {
 
 				md.resultFile, _ = os.CreateTemp("", "test_file_recon_csv_result")
 				reconSUT.mockStorageRepo.EXPECT().NewWriter(gomock.Any(), gomock.Any()).Return(md.resultFile)
+				expectReconExceptions(args, nil, "123456_only_in_csv", "123456_only_in_db", "86861101189513")
 
 				rh.Status = models.ReconHistoryStatusSuccess
 				reconSUT.mockReconToolHistoryRepo.EXPECT().Update(args.ctx, args.id, rh)
@@ -128,12 +146,12 @@ func Test_ReconService_ProcessReconTaskQueue(t *testing.T) {
 				os.Remove(md.resultFile.Name())
 			},
 			wantErr: false,
-			wantResult: []byte("identifier,amount,orderType,transactionType,transactionDate,refNumber,lenderId,customerName,reconDate,match,status\n" +
-				"123456,100000,TOPUP,TOPUP,01-Jan-2023,123456,,,2023-01-10 07:00:00,true,Match\n" +
-				"123456_only_in_csv,100000,TOPUP,TOPUP,01-Jan-2023,,,,2023-01-10 07:00:00,false,\"Not Exists in DB, Exists in CSV\"\n" +
-				"86861101189513,100021,TOPUP,TOPUP,01-Jan-2023,ref_number_va_permata,,,2023-01-10 07:00:00,true,Match\n" +
-				"123456_only_in_db,100001,TOPUP,TOPUP,01-Jan-2023,123456_only_in_db,,,2023-01-10 07:00:00,false,\"Exists in DB, Not Exists in CSV\"\n" +
-				"86861101189513,100023,TOPUP,TOPUP,01-Jan-2023,ref_number_va_permata,,,2023-01-10 07:00:00,false,\"Exists in DB, Not Exists in CSV\"\n"),
+			wantResult: []byte("identifier,amount,orderType,transactionType,transactionDate,refNumber,lenderId,customerName,reconDate,match,status,matchRule,matchConfidence\n" +
+				"123456,100000,TOPUP,TOPUP,01-Jan-2023,123456,,,2023-01-10 07:00:00,true,Match,EXACT,100\n" +
+				"123456_only_in_csv,100000,TOPUP,TOPUP,01-Jan-2023,,,,2023-01-10 07:00:00,false,\"Not Exists in DB, Exists in CSV\",,\n" +
+				"86861101189513,100021,TOPUP,TOPUP,01-Jan-2023,ref_number_va_permata,,,2023-01-10 07:00:00,true,Match,EXACT,100\n" +
+				"123456_only_in_db,100001,TOPUP,TOPUP,01-Jan-2023,123456_only_in_db,,,2023-01-10 07:00:00,false,\"Exists in DB, Not Exists in CSV\",,\n" +
+				"86861101189513,100023,TOPUP,TOPUP,01-Jan-2023,ref_number_va_permata,,,2023-01-10 07:00:00,false,\"Exists in DB, Not Exists in CSV\",,\n"),
 		},
 		{
 			name: "success recon task queue",
@@ -226,6 +244,17 @@ func Test_ReconService_ProcessReconTaskQueue(t *testing.T) {
 
 				md.resultFile, _ = os.CreateTemp("", "test_file_recon_csv_result")
 				reconSUT.mockStorageRepo.EXPECT().NewWriter(gomock.Any(), gomock.Any()).Return(md.resultFile)
+				// exception worked by operator on previous attempt is kept, so it is not created again
+				kept := []models.ReconException{{
+					ID:             1,
+					ReconHistoryID: args.id,
+					Identifier:     "123456_only_in_csv",
+					Amount:         decimal.NewFromInt(100000),
+					PaymentDate:    "01-Jan-2023",
+					ReconStatus:    models.StatusReconRecordNotExistsDBExistsCSV.Title(),
+					State:          models.ReconExceptionStateInvestigating,
+				}}
+				expectReconExceptions(args, kept, "123456_only_in_db", "86861101189513", "86861101189513")
 
 				rh.Status = models.ReconHistoryStatusSuccess
 				reconSUT.mockReconToolHistoryRepo.EXPECT().Update(args.ctx, args.id, rh)
@@ -235,13 +264,13 @@ func Test_ReconService_ProcessReconTaskQueue(t *testing.T) {
 				os.Remove(md.resultFile.Name())
 			},
 			wantErr: false,
-			wantResult: []byte("identifier,amount,orderType,transactionType,transactionDate,refNumber,lenderId,customerName,reconDate,match,status\n" +
-				"123456,100000,TOPUP,TOPUP,01-Jan-2023,123456,,,2023-01-10 07:00:00,true,Match\n" +
-				"123456_only_in_csv,100000,TOPUP,TOPUP,01-Jan-2023,,,,2023-01-10 07:00:00,false,\"Not Exists in DB, Exists in CSV\"\n" +
-				"86861101189513,100021,TOPUP,TOPUP,01-Jan-2023,ref_number_va_permata,,,2023-01-10 07:00:00,true,Match\n" +
-				"123456_only_in_db,100001,TOPUP,TOPUP,01-Jan-2023,123456_only_in_db,,,2023-01-10 07:00:00,false,\"Exists in DB, Not Exists in CSV\"\n" +
-				"86861101189513,100021,TOPUP,TOPUP,01-Jan-2023,ref_number_va_permata_2,,,2023-01-10 07:00:00,false,\"Exists in DB, Not Exists in CSV\"\n" +
-				"86861101189513,100023,TOPUP,TOPUP,01-Jan-2023,ref_number_va_permata,,,2023-01-10 07:00:00,false,\"Exists in DB, Not Exists in CSV\"\n"),
+			wantResult: []byte("identifier,amount,orderType,transactionType,transactionDate,refNumber,lenderId,customerName,reconDate,match,status,matchRule,matchConfidence\n" +
+				"123456,100000,TOPUP,TOPUP,01-Jan-2023,123456,,,2023-01-10 07:00:00,true,Match,EXACT,100\n" +
+				"123456_only_in_csv,100000,TOPUP,TOPUP,01-Jan-2023,,,,2023-01-10 07:00:00,false,\"Not Exists in DB, Exists in CSV\",,\n" +
+				"86861101189513,100021,TOPUP,TOPUP,01-Jan-2023,ref_number_va_permata,,,2023-01-10 07:00:00,true,Match,EXACT,100\n" +
+				"123456_only_in_db,100001,TOPUP,TOPUP,01-Jan-2023,123456_only_in_db,,,2023-01-10 07:00:00,false,\"Exists in DB, Not Exists in CSV\",,\n" +
+				"86861101189513,100021,TOPUP,TOPUP,01-Jan-2023,ref_number_va_permata_2,,,2023-01-10 07:00:00,false,\"Exists in DB, Not Exists in CSV\",,\n" +
+				"86861101189513,100023,TOPUP,TOPUP,01-Jan-2023,ref_number_va_permata,,,2023-01-10 07:00:00,false,\"Exists in DB, Not Exists in CSV\",,\n"),
 		},
 		{
 			name: "failed to get recon history",
@@ -11,6 +11,7 @@ import (
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
 	mock2 "bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting/mock"
 	mock3 "bitbucket.org/Amartha/go-fp-transaction/internal/common/flag/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/payment"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/transaction_notification/mock"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
@@ -42,6 +43,7 @@ type reconSUT struct {
 	mockAccRepo               *mockRepo.MockAccountRepository
 	mockReconToolHistoryRepo  *mockRepo.MockReconToolHistoryRepository
 	mockTransactionRepository *mockRepo.MockTransactionRepository
+	mockReconExceptionRepo    *mockRepo.MockReconExceptionRepository
 
 	mockFileRepo        *mockRepo.MockFileRepository
 	mockDDDNotification *mockDDD.MockDDDNotification
@@ -70,6 +72,7 @@ func initReconSUT(t *testing.T) reconSUT {
 	mockAccRepo := mockRepo.NewMockAccountRepository(mockCtrl)
 	mockReconToolHistoryRepo := mockRepo.NewMockReconToolHistoryRepository(mockCtrl)
 	mockTransactionRepository := mockRepo.NewMockTransactionRepository(mockCtrl)
+	mockReconExceptionRepo := mockRepo.NewMockReconExceptionRepository(mockCtrl)
 	mockAcuanClient := mockAcuanClient.NewMockAcuanClient(mockCtrl)
 	mockAccountingClient := mock2.NewMockClient(mockCtrl)
 	mockIDGenerator := mockIDGenerator.NewMockGenerator(mockCtrl)
@@ -102,7 +105,10 @@ func initReconSUT(t *testing.T) reconSUT {
 		mockBalanceHVTPublisher,
 		mockNotificationPublisher,
 		mockWalletTransactionAsync,
+		nil,
 		mockAccountingClient,
+		payment.NewFake(),
+		nil,
 		mockFlagClient,
 		nil,
 	)
@@ -123,6 +129,7 @@ func initReconSUT(t *testing.T) reconSUT {
 		mockAccRepo:               mockAccRepo,
 		mockReconToolHistoryRepo:  mockReconToolHistoryRepo,
 		mockTransactionRepository: mockTransactionRepository,
+		mockReconExceptionRepo:    mockReconExceptionRepo,
 
 		mockFileRepo:        mockFileRepo,
 		mockDDDNotification: mockDDDNotification,
@@ -0,0 +1,385 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/ddd_notification"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/exportfile"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+)
+
+type ScheduledReportService interface {
+	// RunScheduledReports generate the reports which are due on each date of the request,
+	// periods which have been generated are skipped so the same range can be run again safely
+	RunScheduledReports(ctx context.Context, req models.RunScheduledReportsRequest) (result models.RunScheduledReportsResult, err error)
+}
+
+type scheduledReport service
+
+var _ ScheduledReportService = (*scheduledReport)(nil)
+
+const (
+	defaultReportURLExpiryTime = 7 * 24 * time.Hour
+	defaultReportEmailFrom     = "noreply@amartha.com"
+	defaultReportEmailTemplate = "2024-mis-internal"
+
+	// reportRunStaleAfter is the time after which a running report is generated again, e.g. the worker was killed
+	reportRunStaleAfter = time.Hour
+
+	reportMoneyFlowPageSize = 1000
+)
+
+var (
+	balanceReportHeader = []string{"Account Number", "Date", "Balance"}
+
+	moneyFlowReportHeader = []string{
+		"Summary ID",
+		"Transaction Source Creation Date",
+		"Payment Type",
+		"Total Transfer",
+		"Status",
+		"Requested Date",
+		"Actual Date",
+	}
+)
+
+func (s *scheduledReport) RunScheduledReports(ctx context.Context, req models.RunScheduledReportsRequest) (result models.RunScheduledReportsResult, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	if req.EndDate.Before(req.StartDate) {
+		return result, common.ErrInvalidDateRange
+	}
+
+	definitions, err := s.definitions(req.ReportName)
+	if err != nil {
+		return result, err
+	}
+
+	var errs []error
+	for date := req.StartDate; !date.After(req.EndDate); date = date.AddDate(0, 0, 1) {
+		for _, definition := range definitions {
+			if !definition.IsDue(date) {
+				continue
+			}
+
+			generated, errRun := s.runReport(ctx, definition, date)
+			switch {
+			case errRun != nil:
+				result.Failed++
+				errs = append(errs, fmt.Errorf("%s on %s: %w", definition.Name, date.Format(common.DateFormatYYYYMMDD), errRun))
+				xlog.Warn(ctx, "[SCHEDULED-REPORT]",
+					xlog.String("report_name", definition.Name),
+					xlog.String("date", date.Format(common.DateFormatYYYYMMDD)),
+					xlog.Err(errRun))
+			case !generated:
+				result.Skipped++
+			default:
+				result.Succeeded++
+			}
+		}
+	}
+
+	if result.Failed > 0 {
+		return result, fmt.Errorf("%d scheduled reports failed: %w", result.Failed, errors.Join(errs...))
+	}
+
+	return result, nil
+}
+
+// definitions return the active report definitions sorted by name, name limit the definitions to a report
+func (s *scheduledReport) definitions(name string) ([]models.ReportDefinition, error) {
+	configs := s.srv.conf.ScheduledReport.Definitions
+	if name != "" {
+		if _, ok := configs[name]; !ok {
+			return nil, fmt.Errorf("%w: %s", common.ErrReportNotFound, name)
+		}
+	}
+
+	var definitions []models.ReportDefinition
+	for reportName, cfg := range configs {
+		if cfg.Disabled || (name != "" && reportName != name) {
+			continue
+		}
+
+		definition := models.ReportDefinition{
+			Name:        reportName,
+			Query:       cfg.Query,
+			Params:      cfg.Params,
+			Schedule:    cfg.Schedule,
+			ScheduleDay: cfg.ScheduleDay,
+			Format:      cfg.Format,
+			Gzip:        cfg.Gzip,
+			Path:        cfg.Path,
+			Recipients:  cfg.Recipients,
+		}
+		if definition.Format == "" {
+			definition.Format = string(exportfile.FormatCSV)
+		}
+
+		if err := definition.Validate(); err != nil {
+			return nil, err
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	slices.SortFunc(definitions, func(a, b models.ReportDefinition) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return definitions, nil
+}
+
+// runReport generate the report of the period reported on date, generated is false when the period has been generated
+func (s *scheduledReport) runReport(ctx context.Context, definition models.ReportDefinition, date time.Time) (generated bool, err error) {
+	repo := s.srv.sqlRepo.GetReportRunRepository()
+
+	periodStart, periodEnd := definition.Period(date)
+	run := &models.ReportRun{
+		ReportName:  definition.Name,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+	}
+
+	started, err := repo.Start(ctx, run, reportRunStaleAfter)
+	if err != nil {
+		return false, fmt.Errorf("failed to start report run: %w", err)
+	}
+	if !started {
+		return false, nil
+	}
+
+	err = s.generateReport(ctx, definition, run)
+	if err != nil {
+		run.Status = models.ReportRunStatusFailed
+		run.ErrorMessage = err.Error()
+	} else {
+		run.Status = models.ReportRunStatusSuccess
+	}
+
+	if errFinish := repo.Finish(ctx, run); errFinish != nil {
+		if err != nil {
+			xlog.Warn(ctx, "[SCHEDULED-REPORT]", xlog.String("report_name", definition.Name), xlog.Err(errFinish))
+			return false, err
+		}
+		return false, fmt.Errorf("failed to finish report run: %w", errFinish)
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	xlog.Info(ctx, "[SCHEDULED-REPORT]",
+		xlog.String("operation", "generate report"),
+		xlog.String("report_name", definition.Name),
+		xlog.String("period_start", periodStart.Format(common.DateFormatYYYYMMDD)),
+		xlog.String("period_end", periodEnd.Format(common.DateFormatYYYYMMDD)),
+		xlog.Int64("row_count", run.RowCount),
+		xlog.String("file_path", run.FilePath))
+
+	// the report has been generated, failed delivery is not retried by generating the report again
+	if len(definition.Recipients) > 0 {
+		if errSend := s.sendReport(ctx, definition, run); errSend != nil {
+			xlog.Warn(ctx, "[SCHEDULED-REPORT]",
+				xlog.String("operation", "send report"),
+				xlog.String("report_name", definition.Name),
+				xlog.Err(errSend))
+		}
+	}
+
+	return true, nil
+}
+
+func (s *scheduledReport) generateReport(ctx context.Context, definition models.ReportDefinition, run *models.ReportRun) error {
+	var (
+		reportHeader []string
+		write        func(ctx context.Context, fw exportfile.Writer) error
+	)
+
+	switch definition.Query {
+	case models.ReportQueryTransaction:
+		opts, err := definition.TransactionFilter(run.PeriodStart, run.PeriodEnd).ToFilterOptions(0)
+		if err != nil {
+			return err
+		}
+
+		reportHeader = header
+		write = func(ctx context.Context, fw exportfile.Writer) error {
+			return s.writeTransactions(ctx, run, fw, *opts)
+		}
+	case models.ReportQueryBalance:
+		reportHeader = balanceReportHeader
+		write = func(ctx context.Context, fw exportfile.Writer) error {
+			return s.writeBalances(ctx, run, fw)
+		}
+	case models.ReportQueryMoneyFlow:
+		reportHeader = moneyFlowReportHeader
+		write = func(ctx context.Context, fw exportfile.Writer) error {
+			return s.writeMoneyFlowSummaries(ctx, definition, run, fw)
+		}
+	default:
+		return fmt.Errorf("%w: unknown query %q", common.ErrInvalidReportDefinition, definition.Query)
+	}
+
+	payload := definition.CloudStoragePayload(run.PeriodStart, run.PeriodEnd)
+	err := uploadExportFile(ctx, s.srv.cloudStorage, payload, exportfile.Format(definition.Format), definition.Gzip, reportHeader, write)
+	if err != nil {
+		return err
+	}
+
+	run.FilePath = payload.GetFilePath()
+
+	return nil
+}
+
+func (s *scheduledReport) writeTransactions(ctx context.Context, run *models.ReportRun, fw exportfile.Writer, opts models.TransactionFilterOptions) error {
+	orderTypes, err := s.srv.masterDataRepo.GetListOrderType(ctx, models.FilterMasterData{})
+	if err != nil {
+		return fmt.Errorf("unable to GetListOrderType: %w", err)
+	}
+	mapOrderTypes, mapTransactionTypes := models.MakeOrderTypesMap(orderTypes)
+
+	for trx := range s.srv.sqlRepo.GetTransactionRepository().StreamAll(ctx, opts) {
+		if trx.Err != nil {
+			return fmt.Errorf("failed to read stream: %w", trx.Err)
+		}
+
+		if err = fw.Write(toTransactionFileRow(trx.Data, mapOrderTypes, mapTransactionTypes)); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
+		}
+		run.RowCount++
+	}
+
+	return nil
+}
+
+// writeBalances write the closing balances at the end of period
+func (s *scheduledReport) writeBalances(ctx context.Context, run *models.ReportRun, fw exportfile.Writer) error {
+	balances, err := s.srv.sqlRepo.GetAccountBalanceDailyRepository().ListByDate(ctx, run.PeriodEnd)
+	if err != nil {
+		return fmt.Errorf("failed to get daily balances: %w", err)
+	}
+
+	for _, balance := range *balances {
+		var date string
+		if balance.Date != nil {
+			date = balance.Date.Format(common.DateFormatYYYYMMDD)
+		}
+
+		if err = fw.Write([]string{balance.AccountNumber, date, balance.Balance.String()}); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
+		}
+		run.RowCount++
+	}
+
+	return nil
+}
+
+func (s *scheduledReport) writeMoneyFlowSummaries(ctx context.Context, definition models.ReportDefinition, run *models.ReportRun, fw exportfile.Writer) error {
+	opts := models.MoneyFlowSummaryFilterOptions{
+		PaymentType:                        definition.Param("paymentType"),
+		Status:                             definition.Param("status"),
+		TransactionSourceCreationDateStart: &run.PeriodStart,
+		TransactionSourceCreationDateEnd:   &run.PeriodEnd,
+		Limit:                              reportMoneyFlowPageSize,
+	}
+
+	repo := s.srv.sqlRepo.GetMoneyFlowCalcRepository()
+	for {
+		summaries, err := repo.GetSummariesList(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to get money flow summaries: %w", err)
+		}
+
+		for _, summary := range summaries {
+			row := []string{
+				summary.ID,
+				summary.TransactionSourceCreationDate.Format(common.DateFormatYYYYMMDD),
+				summary.PaymentType,
+				summary.TotalTransfer.String(),
+				summary.MoneyFlowStatus,
+				formatReportTime(summary.RequestedDate),
+				formatReportTime(summary.ActualDate),
+			}
+			if err = fw.Write(row); err != nil {
+				return fmt.Errorf("failed to write row: %w", err)
+			}
+			run.RowCount++
+		}
+
+		if len(summaries) < reportMoneyFlowPageSize {
+			return nil
+		}
+
+		last := summaries[len(summaries)-1]
+		opts.Cursor = &models.MoneyFlowSummaryCursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
+func (s *scheduledReport) sendReport(ctx context.Context, definition models.ReportDefinition, run *models.ReportRun) error {
+	conf := s.srv.conf.ScheduledReport
+
+	expireDuration := defaultReportURLExpiryTime
+	if conf.URLExpiryTime > 0 {
+		expireDuration = time.Duration(conf.URLExpiryTime) * time.Minute
+	}
+
+	url, err := s.srv.cloudStorage.GetSignedURL(run.FilePath, expireDuration)
+	if err != nil {
+		return fmt.Errorf("failed to get signed url: %w", err)
+	}
+
+	from := defaultReportEmailFrom
+	if conf.EmailFrom != "" {
+		from = conf.EmailFrom
+	}
+
+	template := defaultReportEmailTemplate
+	if conf.EmailTemplate != "" {
+		template = conf.EmailTemplate
+	}
+
+	var cc []ddd_notification.Cc
+	for _, recipient := range definition.Recipients[1:] {
+		cc = append(cc, ddd_notification.Cc{Email: recipient})
+	}
+
+	periodStart := run.PeriodStart.Format(common.DateFormatYYYYMMDD)
+	periodEnd := run.PeriodEnd.Format(common.DateFormatYYYYMMDD)
+
+	return s.srv.dddNotification.SendEmail(ctx, ddd_notification.RequestEmail{
+		From:     from,
+		FromName: "Amartha",
+		Subject:  fmt.Sprintf("REPORT %s %s - %s", definition.Name, periodStart, periodEnd),
+		To:       definition.Recipients[0],
+		Template: template,
+		CC:       cc,
+		Subs: []any{
+			map[string]any{
+				"reportName":  definition.Name,
+				"periodStart": periodStart,
+				"periodEnd":   periodEnd,
+				"rowCount":    run.RowCount,
+				"downloadUrl": url,
+			},
+		},
+	})
+}
+
+func formatReportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return common.FormatDatetimeToStringInLocalTime(*t, common.DateFormatYYYYMMDDWithTime)
+}
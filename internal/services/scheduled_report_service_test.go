@@ -0,0 +1,187 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/ddd_notification"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScheduledReportService_RunScheduledReports(t *testing.T) {
+	date := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	balanceDate := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	startRun := func(started bool) func(ctx context.Context, run *models.ReportRun, staleAfter time.Duration) (bool, error) {
+		return func(ctx context.Context, run *models.ReportRun, staleAfter time.Duration) (bool, error) {
+			run.ID = 1
+			return started, nil
+		}
+	}
+
+	tests := []struct {
+		name       string
+		req        models.RunScheduledReportsRequest
+		doMock     func(th testServiceHelper, file *exportFileWriter)
+		wantResult models.RunScheduledReportsResult
+		wantErr    error
+		wantFile   string
+	}{
+		{
+			name: "success generate due reports",
+			req:  models.RunScheduledReportsRequest{StartDate: date, EndDate: date},
+			doMock: func(th testServiceHelper, file *exportFileWriter) {
+				th.mockReportRunRepository.EXPECT().Start(gomock.Any(), &models.ReportRun{
+					ReportName:  "balance-daily",
+					PeriodStart: balanceDate,
+					PeriodEnd:   balanceDate,
+				}, time.Hour).DoAndReturn(startRun(true))
+				th.mockAccBalanceDailyRepository.EXPECT().ListByDate(gomock.Any(), balanceDate).
+					Return(&[]models.AccountBalanceDaily{
+						{AccountNumber: "21100100000001", Date: &balanceDate, Balance: decimal.NewFromInt(15000)},
+					}, nil)
+				th.mockGcs.EXPECT().NewWriter(gomock.Any(), &models.CloudStoragePayload{
+					Filename: "balance-daily-2025-01-31_2025-01-31.csv",
+					Path:     "reports/balance-daily/2025/01",
+				}).Return(file)
+				th.mockReportRunRepository.EXPECT().Finish(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, run *models.ReportRun) error {
+						assert.Equal(t, models.ReportRunStatusSuccess, run.Status)
+						assert.Equal(t, int64(1), run.RowCount)
+						assert.Equal(t, "reports/balance-daily/2025/01/balance-daily-2025-01-31_2025-01-31.csv", run.FilePath)
+						return nil
+					})
+				th.mockGcs.EXPECT().GetSignedURL("reports/balance-daily/2025/01/balance-daily-2025-01-31_2025-01-31.csv", 7*24*time.Hour).
+					Return("https://storage/balance-daily", nil)
+				th.mockDDDNotification.EXPECT().SendEmail(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, req ddd_notification.RequestEmail) error {
+						assert.Equal(t, "finance@amartha.com", req.To)
+						assert.Equal(t, []ddd_notification.Cc{{Email: "ops@amartha.com"}}, req.CC)
+						return nil
+					})
+
+				th.mockReportRunRepository.EXPECT().Start(gomock.Any(), &models.ReportRun{
+					ReportName:  "money-flow-monthly",
+					PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					PeriodEnd:   balanceDate,
+				}, time.Hour).DoAndReturn(startRun(true))
+				th.mockMoneyFlowRepository.EXPECT().GetSummariesList(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, opts models.MoneyFlowSummaryFilterOptions) ([]models.MoneyFlowSummaryOut, error) {
+						assert.Equal(t, "MF_EARN_DIVEST", opts.PaymentType)
+						assert.Nil(t, opts.Cursor)
+						return []models.MoneyFlowSummaryOut{
+							{ID: "summary-1", PaymentType: "MF_EARN_DIVEST", TotalTransfer: decimal.NewFromInt(100000), MoneyFlowStatus: "SUCCESS"},
+						}, nil
+					})
+				th.mockGcs.EXPECT().NewWriter(gomock.Any(), &models.CloudStoragePayload{
+					Filename: "money-flow-monthly-2025-01-01_2025-01-31.csv.gz",
+					Path:     "reports/money-flow-monthly/2025/01",
+				}).Return(&exportFileWriter{})
+				th.mockReportRunRepository.EXPECT().Finish(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantResult: models.RunScheduledReportsResult{Succeeded: 2},
+			wantFile:   "Account Number,Date,Balance\n21100100000001,2025-01-31,15000\n",
+		},
+		{
+			name: "success skip generated periods",
+			req:  models.RunScheduledReportsRequest{StartDate: date, EndDate: date},
+			doMock: func(th testServiceHelper, file *exportFileWriter) {
+				th.mockReportRunRepository.EXPECT().Start(gomock.Any(), gomock.Any(), time.Hour).
+					DoAndReturn(startRun(false)).Times(2)
+			},
+			wantResult: models.RunScheduledReportsResult{Skipped: 2},
+		},
+		{
+			name: "success backfill a report",
+			req: models.RunScheduledReportsRequest{
+				StartDate:  date,
+				EndDate:    date.AddDate(0, 0, 2),
+				ReportName: "money-flow-monthly",
+			},
+			doMock: func(th testServiceHelper, file *exportFileWriter) {
+				th.mockReportRunRepository.EXPECT().Start(gomock.Any(), gomock.Any(), time.Hour).
+					DoAndReturn(startRun(false))
+			},
+			wantResult: models.RunScheduledReportsResult{Skipped: 1},
+		},
+		{
+			name: "success when failed to send report",
+			req:  models.RunScheduledReportsRequest{StartDate: date, EndDate: date, ReportName: "balance-daily"},
+			doMock: func(th testServiceHelper, file *exportFileWriter) {
+				th.mockReportRunRepository.EXPECT().Start(gomock.Any(), gomock.Any(), time.Hour).DoAndReturn(startRun(true))
+				th.mockAccBalanceDailyRepository.EXPECT().ListByDate(gomock.Any(), balanceDate).
+					Return(&[]models.AccountBalanceDaily{}, nil)
+				th.mockGcs.EXPECT().NewWriter(gomock.Any(), gomock.Any()).Return(file)
+				th.mockReportRunRepository.EXPECT().Finish(gomock.Any(), gomock.Any()).Return(nil)
+				th.mockGcs.EXPECT().GetSignedURL(gomock.Any(), gomock.Any()).Return("", assert.AnError)
+			},
+			wantResult: models.RunScheduledReportsResult{Succeeded: 1},
+			wantFile:   "Account Number,Date,Balance\n",
+		},
+		{
+			name: "failed generate report",
+			req:  models.RunScheduledReportsRequest{StartDate: date, EndDate: date, ReportName: "balance-daily"},
+			doMock: func(th testServiceHelper, file *exportFileWriter) {
+				th.mockReportRunRepository.EXPECT().Start(gomock.Any(), gomock.Any(), time.Hour).DoAndReturn(startRun(true))
+				th.mockAccBalanceDailyRepository.EXPECT().ListByDate(gomock.Any(), balanceDate).Return(nil, assert.AnError)
+				th.mockGcs.EXPECT().NewWriter(gomock.Any(), gomock.Any()).Return(file)
+				th.mockReportRunRepository.EXPECT().Finish(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, run *models.ReportRun) error {
+						assert.Equal(t, models.ReportRunStatusFailed, run.Status)
+						assert.NotEmpty(t, run.ErrorMessage)
+						return nil
+					})
+			},
+			wantResult: models.RunScheduledReportsResult{Failed: 1},
+			wantErr:    assert.AnError,
+		},
+		{
+			name: "failed start report run",
+			req:  models.RunScheduledReportsRequest{StartDate: date, EndDate: date, ReportName: "balance-daily"},
+			doMock: func(th testServiceHelper, file *exportFileWriter) {
+				th.mockReportRunRepository.EXPECT().Start(gomock.Any(), gomock.Any(), time.Hour).Return(false, assert.AnError)
+			},
+			wantResult: models.RunScheduledReportsResult{Failed: 1},
+			wantErr:    assert.AnError,
+		},
+		{
+			name:    "failed unknown report",
+			req:     models.RunScheduledReportsRequest{StartDate: date, EndDate: date, ReportName: "unknown"},
+			wantErr: common.ErrReportNotFound,
+		},
+		{
+			name:    "failed invalid date range",
+			req:     models.RunScheduledReportsRequest{StartDate: date, EndDate: date.AddDate(0, 0, -1)},
+			wantErr: common.ErrInvalidDateRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := serviceTestHelper(t)
+			file := &exportFileWriter{}
+			if tt.doMock != nil {
+				tt.doMock(th, file)
+			}
+
+			result, err := th.scheduledReportSvc.RunScheduledReports(context.Background(), tt.req)
+			assert.Equal(t, tt.wantResult, result)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			if tt.wantFile != "" {
+				assert.Equal(t, tt.wantFile, file.String())
+				assert.True(t, file.closed)
+			}
+		})
+	}
+}
@@ -8,10 +8,14 @@ import (
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/idgenerator"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/mapper"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/metrics"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/payment"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/publisher"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/queueunicorn"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/safeaccess"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/transaction_notification"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/webhook"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
 
 	kafkaRecon "bitbucket.org/Amartha/go-fp-transaction/internal/deliveries/consumer/kafka_recon"
@@ -35,6 +39,7 @@ type Services struct {
 	balanceHVTPub           publisher.Publisher
 	transactionNotification transaction_notification.TransactionNotificationPublisher
 	walletTransactionAsync  publisher.Publisher
+	exportPub               publisher.Publisher
 
 	consumerRecon      kafkaRecon.Consumer
 	acuanClient        acuanclient.AcuanClient
@@ -42,26 +47,43 @@ type Services struct {
 	dddNotification    ddd_notification.DDDNotification
 	queueUnicornClient queueunicorn.Client
 	accountingClient   accounting.Client
+	paymentClient      payment.Client
+	webhookClient      webhook.Client
 	accountMapper      mapper.AccountMapper
 	flag               flag.Client
 	metrics            metrics.Metrics
 
+	// businessRules is the published money flow business rules, nil until a version is published
+	businessRules safeaccess.Value[*models.BusinessRulesConfigs]
+
+	// taxRates is the rate table of tax engine, empty until it is loaded
+	taxRates safeaccess.Value[models.TaxRates]
+
 	common service
 
-	Account       *account
-	Balance       *balance
-	Transaction   *transaction
-	Storage       *storage
-	Entity        *entity
-	Category      *category
-	SubCategory   *subCategory
-	File          *file
-	DLQProcessor  *dlqProcessor
-	MasterData    *masterData
-	Recon         *reconService
-	WalletAccount *walletAccount
-	WalletTrx     *walletTrx
-	MoneyFlowCalc *moneyFlowCalc
+	Account        *account
+	Balance        *balance
+	Transaction    *transaction
+	Storage        *storage
+	Entity         *entity
+	Category       *category
+	SubCategory    *subCategory
+	File           *file
+	DLQProcessor   *dlqProcessor
+	MasterData     *masterData
+	Recon          *reconService
+	ReconException *reconException
+	WalletAccount  *walletAccount
+	WalletTrx      *walletTrx
+	MoneyFlowCalc  *moneyFlowCalc
+
+	MoneyFlowBusinessRule *moneyFlowBusinessRule
+	Tax                   *tax
+	Export                *export
+	ScheduledReport       *scheduledReport
+	LedgerReport          *ledgerReport
+	TransactionMetric     *transactionMetric
+	Approval              *approval
 }
 
 func New(
@@ -80,7 +102,10 @@ func New(
 	balanceHVTPub publisher.Publisher,
 	transactionNotification transaction_notification.TransactionNotificationPublisher,
 	walletTransactionAsync publisher.Publisher,
+	exportPub publisher.Publisher,
 	accountingClient accounting.Client,
+	paymentClient payment.Client,
+	webhookClient webhook.Client,
 	flag flag.Client,
 	metrics metrics.Metrics,
 ) *Services {
@@ -97,10 +122,13 @@ func New(
 		dddNotification:         dddNotification,
 		queueUnicornClient:      queueUnicornClient,
 		accountingClient:        accountingClient,
+		paymentClient:           paymentClient,
+		webhookClient:           webhookClient,
 		reconPub:                reconPub,
 		balanceHVTPub:           balanceHVTPub,
 		transactionNotification: transactionNotification,
 		walletTransactionAsync:  walletTransactionAsync,
+		exportPub:               exportPub,
 		flag:                    flag,
 		metrics:                 metrics,
 	}
@@ -118,6 +146,14 @@ func New(
 	srv.WalletAccount = (*walletAccount)(&srv.common)
 	srv.WalletTrx = (*walletTrx)(&srv.common)
 	srv.MoneyFlowCalc = (*moneyFlowCalc)(&srv.common)
+	srv.ReconException = (*reconException)(&srv.common)
+	srv.MoneyFlowBusinessRule = (*moneyFlowBusinessRule)(&srv.common)
+	srv.Tax = (*tax)(&srv.common)
+	srv.Export = (*export)(&srv.common)
+	srv.ScheduledReport = (*scheduledReport)(&srv.common)
+	srv.LedgerReport = (*ledgerReport)(&srv.common)
+	srv.TransactionMetric = (*transactionMetric)(&srv.common)
+	srv.Approval = (*approval)(&srv.common)
 
 	return srv
 }
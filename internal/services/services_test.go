@@ -7,9 +7,12 @@ import (
 	mock3 "bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting/mock"
 	mock4 "bitbucket.org/Amartha/go-fp-transaction/internal/common/flag/mock"
 	mock5 "bitbucket.org/Amartha/go-fp-transaction/internal/common/metrics/mock"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/payment"
 	mockQueueUnicorn "bitbucket.org/Amartha/go-fp-transaction/internal/common/queueunicorn/mock"
 	mock2 "bitbucket.org/Amartha/go-fp-transaction/internal/common/transaction_notification/mock"
+	mockWebhook "bitbucket.org/Amartha/go-fp-transaction/internal/common/webhook/mock"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/config"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories/mock"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/services"
 
@@ -41,6 +44,16 @@ type testServiceHelper struct {
 	mockTrxRepository             *mock.MockTransactionRepository
 	mockFeatureRepository         *mock.MockFeatureRepository
 	mockWalletTrxRepository       *mock.MockWalletTransactionRepository
+	mockReconExceptionRepository  *mock.MockReconExceptionRepository
+	mockBusinessRuleRepository    *mock.MockMoneyFlowBusinessRuleRepository
+	mockMoneyFlowRepository       *mock.MockMoneyFlowRepository
+	mockTaxRateRepository         *mock.MockTaxRateRepository
+	mockExportJobRepository       *mock.MockExportJobRepository
+	mockReportRunRepository       *mock.MockReportRunRepository
+	mockLedgerRepository          *mock.MockLedgerRepository
+	mockMetricRepository          *mock.MockTransactionMetricRepository
+	mockAsyncTrxRepository        *mock.MockAsyncWalletTransactionRepository
+	mockApprovalRepository        *mock.MockApprovalRepository
 	mockCacheRepository           *mock.MockCacheRepository
 	mockGcs                       *mock.MockCloudStorageRepository
 	mockAcuanClient               *mockAcuanClient.MockAcuanClient
@@ -53,6 +66,10 @@ type testServiceHelper struct {
 	mockQueueUnicornClient      *mockQueueUnicorn.MockClient
 	mockFlagClient              *mock4.MockClient
 	mockTransactionNotification *mock2.MockTransactionNotificationPublisher
+	mockExportPublisher         *mockPublisher.MockPublisher
+	mockWalletTrxPublisher      *mockPublisher.MockPublisher
+	mockBalanceHVTPublisher     *mockPublisher.MockPublisher
+	mockWebhookClient           *mockWebhook.MockClient
 
 	transactionService   services.TransactionService
 	accountService       services.AccountService
@@ -66,6 +83,15 @@ type testServiceHelper struct {
 	masterDataService    services.MasterDataService
 	walletAccountService services.WalletAccountService
 	walletTrxService     services.WalletTrxService
+	reconExceptionSvc    services.ReconExceptionService
+	businessRuleSvc      services.MoneyFlowBusinessRuleService
+	moneyFlowSvc         services.MoneyFlowService
+	taxSvc               services.TaxService
+	exportSvc            services.ExportService
+	scheduledReportSvc   services.ScheduledReportService
+	ledgerReportSvc      services.LedgerReportService
+	transactionMetricSvc services.TransactionMetricService
+	approvalSvc          services.ApprovalService
 }
 
 func serviceTestHelper(t *testing.T) testServiceHelper {
@@ -86,6 +112,16 @@ func serviceTestHelper(t *testing.T) testServiceHelper {
 	mockWalletTransactionRepository := mock.NewMockWalletTransactionRepository(mockCtrl)
 	mockFeatureRepository := mock.NewMockFeatureRepository(mockCtrl)
 	mockAccountConfigRepository := mock.NewMockAccountConfigRepository(mockCtrl)
+	mockReconExceptionRepository := mock.NewMockReconExceptionRepository(mockCtrl)
+	mockBusinessRuleRepository := mock.NewMockMoneyFlowBusinessRuleRepository(mockCtrl)
+	mockMoneyFlowRepository := mock.NewMockMoneyFlowRepository(mockCtrl)
+	mockTaxRateRepository := mock.NewMockTaxRateRepository(mockCtrl)
+	mockExportJobRepository := mock.NewMockExportJobRepository(mockCtrl)
+	mockReportRunRepository := mock.NewMockReportRunRepository(mockCtrl)
+	mockLedgerRepository := mock.NewMockLedgerRepository(mockCtrl)
+	mockMetricRepository := mock.NewMockTransactionMetricRepository(mockCtrl)
+	mockAsyncTrxRepository := mock.NewMockAsyncWalletTransactionRepository(mockCtrl)
+	mockApprovalRepository := mock.NewMockApprovalRepository(mockCtrl)
 
 	mockCacheRepository := mock.NewMockCacheRepository(mockCtrl)
 	mockCloudStorageRepository := mock.NewMockCloudStorageRepository(mockCtrl)
@@ -98,12 +134,15 @@ func serviceTestHelper(t *testing.T) testServiceHelper {
 	mockReconPublisher := mockPublisher.NewMockPublisher(mockCtrl)
 	mockBalanceHVTPub := mockPublisher.NewMockPublisher(mockCtrl)
 	mockWalletTransaction := mockPublisher.NewMockPublisher(mockCtrl)
+	mockExportPublisher := mockPublisher.NewMockPublisher(mockCtrl)
 	mockNotificationPublisher := mock2.NewMockTransactionNotificationPublisher(mockCtrl)
 	mockAccountingClient := mock3.NewMockClient(mockCtrl)
 	mockFlagClient := mock4.NewMockClient(mockCtrl)
+	mockWebhookClient := mockWebhook.NewMockClient(mockCtrl)
 
 	mockMetrics := mock5.NewMockMetrics(mockCtrl)
 	mockMetrics.EXPECT().GetBalancePrometheus().Return(nil).AnyTimes()
+	mockMetrics.EXPECT().GetBusinessPrometheus().Return(nil).AnyTimes()
 
 	mockSQLRepository.EXPECT().GetAccountRepository().Return(mockAccountRepository).AnyTimes()
 	mockSQLRepository.EXPECT().GetBalanceRepository().Return(mockBalanceRepository).AnyTimes()
@@ -116,10 +155,24 @@ func serviceTestHelper(t *testing.T) testServiceHelper {
 	mockSQLRepository.EXPECT().GetFeatureRepository().Return(mockFeatureRepository).AnyTimes()
 	mockSQLRepository.EXPECT().GetAccountConfigExternalRepository().Return(mockAccountConfigRepository).AnyTimes()
 	mockSQLRepository.EXPECT().GetAccountConfigInternalRepository().Return(mockAccountConfigRepository).AnyTimes()
+	mockSQLRepository.EXPECT().GetReconExceptionRepository().Return(mockReconExceptionRepository).AnyTimes()
+	mockSQLRepository.EXPECT().GetMoneyFlowBusinessRuleRepository().Return(mockBusinessRuleRepository).AnyTimes()
+	mockSQLRepository.EXPECT().GetMoneyFlowCalcRepository().Return(mockMoneyFlowRepository).AnyTimes()
+	mockSQLRepository.EXPECT().GetTaxRateRepository().Return(mockTaxRateRepository).AnyTimes()
+	mockSQLRepository.EXPECT().GetExportJobRepository().Return(mockExportJobRepository).AnyTimes()
+	mockSQLRepository.EXPECT().GetReportRunRepository().Return(mockReportRunRepository).AnyTimes()
+	mockSQLRepository.EXPECT().GetLedgerRepository().Return(mockLedgerRepository).AnyTimes()
+	mockSQLRepository.EXPECT().GetTransactionMetricRepository().Return(mockMetricRepository).AnyTimes()
+	mockSQLRepository.EXPECT().GetAsyncWalletTransactionRepository().Return(mockAsyncTrxRepository).AnyTimes()
+	mockSQLRepository.EXPECT().GetApprovalRepository().Return(mockApprovalRepository).AnyTimes()
 
 	conf := config.Config{
 		TransactionConfig: config.TransactionConfig{
-			BatchSize: 1000,
+			BatchSize:                        1000,
+			AsyncWalletTransactionForClients: []string{"async-client", "async-client-without-callback"},
+			AsyncWalletTransactionCallbacks: map[string]config.WebhookCallbackConfig{
+				"async-client": {URL: "https://client.amartha.com/callback", Secret: "secret"},
+			},
 		},
 		AccountConfig: config.AccountConfig{
 			AccountNumberPadWidth: 8,
@@ -153,6 +206,34 @@ func serviceTestHelper(t *testing.T) testServiceHelper {
 		FeatureFlagKeyLookup: config.FeatureFlagKeyLookup{
 			BalanceLimitToggle: "balance_limit_toggle",
 		},
+		MoneyFlowDisbursement: config.MoneyFlowDisbursementConfig{
+			CutOffTimeByPaymentType: map[string]string{"MF_EARN_DIVEST": "15:00"},
+			BatchSize:               10,
+		},
+		ScheduledReport: config.ScheduledReportConfig{
+			Definitions: map[string]config.ReportDefinitionConfig{
+				"balance-daily": {
+					Query:      models.ReportQueryBalance,
+					Schedule:   models.ReportScheduleDaily,
+					Recipients: []string{"finance@amartha.com", "ops@amartha.com"},
+				},
+				"money-flow-monthly": {
+					Query:       models.ReportQueryMoneyFlow,
+					Params:      map[string]string{"paymentType": "MF_EARN_DIVEST"},
+					Schedule:    models.ReportScheduleMonthly,
+					ScheduleDay: 1,
+					Gzip:        true,
+				},
+				"transaction-weekly": {
+					Disabled: true,
+					Query:    models.ReportQueryTransaction,
+					Schedule: models.ReportScheduleWeekly,
+				},
+			},
+		},
+		Approval: config.ApprovalConfig{
+			RequiredOperations: []string{string(models.ApprovalOperationDeleteAccount)},
+		},
 	}
 	serv := services.New(
 		conf,
@@ -170,7 +251,10 @@ func serviceTestHelper(t *testing.T) testServiceHelper {
 		mockBalanceHVTPub,
 		mockNotificationPublisher,
 		mockWalletTransaction,
+		mockExportPublisher,
 		mockAccountingClient,
+		payment.NewFake(),
+		mockWebhookClient,
 		mockFlagClient,
 		mockMetrics,
 	)
@@ -189,6 +273,16 @@ func serviceTestHelper(t *testing.T) testServiceHelper {
 		mockWalletTrxRepository:       mockWalletTransactionRepository,
 		mockFeatureRepository:         mockFeatureRepository,
 		mockFileRepo:                  mockFileRepo,
+		mockReconExceptionRepository:  mockReconExceptionRepository,
+		mockBusinessRuleRepository:    mockBusinessRuleRepository,
+		mockMoneyFlowRepository:       mockMoneyFlowRepository,
+		mockTaxRateRepository:         mockTaxRateRepository,
+		mockExportJobRepository:       mockExportJobRepository,
+		mockReportRunRepository:       mockReportRunRepository,
+		mockLedgerRepository:          mockLedgerRepository,
+		mockMetricRepository:          mockMetricRepository,
+		mockAsyncTrxRepository:        mockAsyncTrxRepository,
+		mockApprovalRepository:        mockApprovalRepository,
 
 		mockMasterData:              mockMasterDataRepo,
 		mockCacheRepository:         mockCacheRepository,
@@ -200,6 +294,10 @@ func serviceTestHelper(t *testing.T) testServiceHelper {
 		mockQueueUnicornClient:      mockQueueUnicornClient,
 		mockFlagClient:              mockFlagClient,
 		mockTransactionNotification: mockNotificationPublisher,
+		mockExportPublisher:         mockExportPublisher,
+		mockWalletTrxPublisher:      mockWalletTransaction,
+		mockBalanceHVTPublisher:     mockBalanceHVTPub,
+		mockWebhookClient:           mockWebhookClient,
 
 		transactionService:   serv.Transaction,
 		accountService:       serv.Account,
@@ -213,5 +311,14 @@ func serviceTestHelper(t *testing.T) testServiceHelper {
 		masterDataService:    serv.MasterData,
 		walletAccountService: serv.WalletAccount,
 		walletTrxService:     serv.WalletTrx,
+		reconExceptionSvc:    serv.ReconException,
+		businessRuleSvc:      serv.MoneyFlowBusinessRule,
+		moneyFlowSvc:         serv.MoneyFlowCalc,
+		taxSvc:               serv.Tax,
+		exportSvc:            serv.Export,
+		scheduledReportSvc:   serv.ScheduledReport,
+		ledgerReportSvc:      serv.LedgerReport,
+		transactionMetricSvc: serv.TransactionMetric,
+		approvalSvc:          serv.Approval,
 	}
 }
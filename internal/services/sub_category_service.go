@@ -19,8 +19,8 @@ var _ SubCategoryService = (*subCategory)(nil)
 
 // Create implements SubCategoryService.
 func (s *subCategory) Create(ctx context.Context, req models.CreateSubCategory) (output *models.SubCategory, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	// Check category
 	cat, err := s.srv.sqlRepo.GetCategoryRepository().GetByCode(ctx, req.CategoryCode)
@@ -54,8 +54,8 @@ func (s *subCategory) Create(ctx context.Context, req models.CreateSubCategory)
 
 // GetAll implements SubCategoryService.
 func (s *subCategory) GetAll(ctx context.Context) (out *[]models.SubCategory, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	out, err = s.srv.sqlRepo.GetSubCategoryRepository().GetAll(ctx)
 	if err != nil {
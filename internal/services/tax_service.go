@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/services/transformer"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+)
+
+type TaxService interface {
+	// GetTaxRates return cached tax rate table which is used by tax engine
+	GetTaxRates(ctx context.Context) (rates models.TaxRates, err error)
+	ListTaxRates(ctx context.Context) (rates models.TaxRates, err error)
+	CreateTaxRate(ctx context.Context, req models.DoCreateTaxRateRequest) (rate *models.TaxRate, err error)
+	GetTaxReport(ctx context.Context, req models.DoGetTaxReportRequest) (report *models.DoGetTaxReportResponse, err error)
+	RefreshPeriodically(ctx context.Context, interval time.Duration)
+}
+
+type tax service
+
+var (
+	_ TaxService                  = (*tax)(nil)
+	_ transformer.TaxRateProvider = (*tax)(nil)
+)
+
+// default tax legs created by transformers, used when config tax is not set
+var (
+	defaultVATTransactionTypes = []string{"DSBRQ", "RPYAG"}
+	defaultWHTTransactionTypes = []string{"RPYAC"}
+)
+
+func (s *tax) GetTaxRates(_ context.Context) (models.TaxRates, error) {
+	return s.srv.taxRates.Load(), nil
+}
+
+func (s *tax) ListTaxRates(ctx context.Context) (rates models.TaxRates, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	return s.srv.sqlRepo.GetTaxRateRepository().GetAll(ctx)
+}
+
+// CreateTaxRate store new rate, rate is never updated so the history of tax rates is kept.
+// To change a rate, create a new one with later effectiveFrom or more specific attributes.
+func (s *tax) CreateTaxRate(ctx context.Context, req models.DoCreateTaxRateRequest) (rate *models.TaxRate, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	if err = req.Validate(); err != nil {
+		return nil, err
+	}
+
+	in := req.ToTaxRate()
+	if err = s.srv.sqlRepo.GetTaxRateRepository().Create(ctx, &in); err != nil {
+		return nil, err
+	}
+
+	// new rate is used immediately by this instance, other instances pick it up on the next refresh
+	if errRefresh := s.refresh(ctx); errRefresh != nil {
+		xlog.Warn(ctx, "failed to refresh tax rates", xlog.Err(errRefresh))
+	}
+
+	return &in, nil
+}
+
+func (s *tax) GetTaxReport(ctx context.Context, req models.DoGetTaxReportRequest) (report *models.DoGetTaxReportResponse, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	opts, err := req.ToFilterOptions(s.taxTransactionTypes())
+	if err != nil {
+		return nil, err
+	}
+
+	collected, err := s.srv.sqlRepo.GetTaxRateRepository().GetTaxCollected(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := models.NewTaxReport(opts, collected)
+
+	return &result, nil
+}
+
+func (s *tax) taxTransactionTypes() map[string]models.TaxType {
+	vatTransactionTypes := s.srv.conf.Tax.VATTransactionTypes
+	if len(vatTransactionTypes) == 0 {
+		vatTransactionTypes = defaultVATTransactionTypes
+	}
+
+	whtTransactionTypes := s.srv.conf.Tax.WHTTransactionTypes
+	if len(whtTransactionTypes) == 0 {
+		whtTransactionTypes = defaultWHTTransactionTypes
+	}
+
+	result := map[string]models.TaxType{}
+	for _, transactionType := range vatTransactionTypes {
+		result[transactionType] = models.TaxTypeVAT
+	}
+	for _, transactionType := range whtTransactionTypes {
+		result[transactionType] = models.TaxTypeWHT
+	}
+
+	return result
+}
+
+// RefreshPeriodically load tax rates into memory and keep it updated in background.
+// When no rate is loaded, tax engine keeps using VAT revenue config from master data.
+func (s *tax) RefreshPeriodically(ctx context.Context, interval time.Duration) {
+	err := s.refresh(ctx)
+	if err != nil {
+		xlog.Warn(ctx, "failed to refresh tax rates", xlog.Err(err))
+	}
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				ticker.Stop()
+				return
+			case <-ticker.C:
+				err := s.refresh(ctx)
+				if err != nil {
+					xlog.Warn(ctx, "failed to refresh tax rates", xlog.Err(err))
+				}
+			}
+		}
+	}()
+}
+
+func (s *tax) refresh(ctx context.Context) error {
+	rates, err := s.srv.sqlRepo.GetTaxRateRepository().GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.srv.taxRates.Store(rates)
+
+	return nil
+}
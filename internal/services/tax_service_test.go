@@ -0,0 +1,182 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTaxService_CreateTaxRate(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	effectiveFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		ctx context.Context
+		req models.DoCreateTaxRateRequest
+	}
+	tests := []struct {
+		name      string
+		args      args
+		doMock    func(args args)
+		wantRates int
+		wantErr   error
+	}{
+		{
+			name: "success create and refresh cached rates",
+			args: args{
+				ctx: context.Background(),
+				req: models.DoCreateTaxRateRequest{
+					TaxType:       string(models.TaxTypeVAT),
+					Rate:          decimal.NewFromFloat(0.12),
+					EffectiveFrom: effectiveFrom,
+					Actor:         "finance.tax",
+				},
+			},
+			doMock: func(args args) {
+				testHelper.mockTaxRateRepository.EXPECT().Create(args.ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, in *models.TaxRate) error {
+						in.ID = 1
+						return nil
+					})
+				testHelper.mockTaxRateRepository.EXPECT().GetAll(args.ctx).
+					Return(models.TaxRates{{ID: 1, TaxType: models.TaxTypeVAT, Rate: args.req.Rate, EffectiveFrom: effectiveFrom}}, nil)
+			},
+			wantRates: 1,
+		},
+		{
+			name: "failed invalid rate",
+			args: args{
+				ctx: context.Background(),
+				req: models.DoCreateTaxRateRequest{
+					TaxType:       string(models.TaxTypeVAT),
+					Rate:          decimal.NewFromInt(12),
+					EffectiveFrom: effectiveFrom,
+					Actor:         "finance.tax",
+				},
+			},
+			wantErr: common.ErrInvalidTaxRate,
+		},
+		{
+			name: "failed create rate",
+			args: args{
+				ctx: context.Background(),
+				req: models.DoCreateTaxRateRequest{
+					TaxType:       string(models.TaxTypeWHT),
+					Rate:          decimal.NewFromFloat(0.02),
+					EffectiveFrom: effectiveFrom,
+					Actor:         "finance.tax",
+				},
+			},
+			doMock: func(args args) {
+				testHelper.mockTaxRateRepository.EXPECT().Create(args.ctx, gomock.Any()).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			got, err := testHelper.taxSvc.CreateTaxRate(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, got)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), got.ID)
+			assert.Equal(t, "finance.tax", got.CreatedBy)
+
+			rates, err := testHelper.taxSvc.GetTaxRates(tt.args.ctx)
+			assert.NoError(t, err)
+			assert.Len(t, rates, tt.wantRates)
+		})
+	}
+}
+
+func TestTaxService_GetTaxReport(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	type args struct {
+		ctx context.Context
+		req models.DoGetTaxReportRequest
+	}
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr error
+	}{
+		{
+			name: "success use default tax transaction types",
+			args: args{
+				ctx: context.Background(),
+				req: models.DoGetTaxReportRequest{StartDate: "2025-01-01", EndDate: "2025-01-31", Entity: "AMF"},
+			},
+			doMock: func(args args) {
+				testHelper.mockTaxRateRepository.EXPECT().
+					GetTaxCollected(args.ctx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, opts models.TaxReportFilterOptions) ([]models.TaxCollected, error) {
+						assert.Equal(t, "AMF", opts.Entity)
+						assert.Equal(t, map[string]models.TaxType{
+							"DSBRQ": models.TaxTypeVAT,
+							"RPYAG": models.TaxTypeVAT,
+							"RPYAC": models.TaxTypeWHT,
+						}, opts.TransactionTypes)
+
+						return []models.TaxCollected{
+							{Entity: "AMF", Period: "2025-01", TaxType: models.TaxTypeVAT, TransactionType: "RPYAG", TotalAmount: decimal.NewFromInt(11000), TotalTransactions: 3},
+						}, nil
+					})
+			},
+		},
+		{
+			name: "failed invalid date range",
+			args: args{
+				ctx: context.Background(),
+				req: models.DoGetTaxReportRequest{StartDate: "2025-02-01", EndDate: "2025-01-31"},
+			},
+			wantErr: common.ErrInvalidDateRange,
+		},
+		{
+			name: "failed get tax collected",
+			args: args{
+				ctx: context.Background(),
+				req: models.DoGetTaxReportRequest{StartDate: "2025-01-01", EndDate: "2025-01-31"},
+			},
+			doMock: func(args args) {
+				testHelper.mockTaxRateRepository.EXPECT().GetTaxCollected(args.ctx, gomock.Any()).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			got, err := testHelper.taxSvc.GetTaxReport(tt.args.ctx, tt.args.req)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, models.TaxReportKind, got.Kind)
+			assert.Len(t, got.Details, 1)
+			assert.Len(t, got.Totals, 1)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+
+	xlog "bitbucket.org/Amartha/go-x/log"
+)
+
+const defaultMetricMaxDateRangeDays = 366
+
+type TransactionMetricService interface {
+	// GetTransactionMetrics return sum, count and average of transactions per interval and group by dimensions,
+	// closed days are read from materialized aggregates and the other days from transaction table
+	GetTransactionMetrics(ctx context.Context, req models.DoGetTransactionMetricsRequest) (resp models.DoGetTransactionMetricsResponse, err error)
+
+	// GetDailyAggregates return daily aggregates of opts, closed days are read from materialized aggregates
+	// and the other days from transaction table. Only successful transactions are aggregated when opts has no status
+	GetDailyAggregates(ctx context.Context, opts models.TransactionMetricFilterOptions) (result []models.TransactionDailyAggregate, err error)
+
+	// MaterializeDailyAggregates aggregate every closed day between start and end date, days that are not closed yet are skipped.
+	// Days invalidated by back-dated transactions or status updates are materialized again
+	MaterializeDailyAggregates(ctx context.Context, startDate, endDate time.Time) (err error)
+}
+
+type transactionMetric service
+
+var _ TransactionMetricService = (*transactionMetric)(nil)
+
+func (s *transactionMetric) GetTransactionMetrics(ctx context.Context, req models.DoGetTransactionMetricsRequest) (resp models.DoGetTransactionMetricsResponse, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	maxDays := s.srv.conf.TransactionMetric.MaxDateRangeDays
+	if maxDays <= 0 {
+		maxDays = defaultMetricMaxDateRangeDays
+	}
+
+	opts, err := req.ToFilterOptions(maxDays)
+	if err != nil {
+		return resp, err
+	}
+
+	aggregates, err := s.GetDailyAggregates(ctx, opts)
+	if err != nil {
+		return resp, err
+	}
+
+	return models.NewTransactionMetrics(opts, aggregates), nil
+}
+
+func (s *transactionMetric) MaterializeDailyAggregates(ctx context.Context, startDate, endDate time.Time) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	if endDate.Before(startDate) {
+		return common.ErrInvalidDateRange
+	}
+
+	today, err := common.NowZeroTime()
+	if err != nil {
+		return err
+	}
+
+	materialized := map[string]bool{}
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		if !isClosedDay(date, today) {
+			xlog.Warn(ctx, "skip materialize transaction aggregates, the day is not closed yet", xlog.String("date", date.Format(time.DateOnly)))
+			continue
+		}
+
+		if err = s.materializeDay(ctx, date); err != nil {
+			return err
+		}
+		materialized[date.Format(time.DateOnly)] = true
+	}
+
+	invalidatedDates, err := s.srv.sqlRepo.GetTransactionMetricRepository().GetInvalidatedDates(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get invalidated dates: %w", err)
+	}
+
+	for _, date := range invalidatedDates {
+		if materialized[date.Format(time.DateOnly)] {
+			continue
+		}
+
+		if err = s.materializeDay(ctx, date); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *transactionMetric) materializeDay(ctx context.Context, date time.Time) error {
+	err := s.srv.sqlRepo.Atomic(ctx, func(ctx context.Context, r repositories.SQLRepository) error {
+		return r.GetTransactionMetricRepository().MaterializeDailyAggregates(ctx, date)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to materialize transaction aggregates of %s: %w", date.Format(time.DateOnly), err)
+	}
+
+	xlog.Infof(ctx, "materialized transaction aggregates of %s", date.Format(time.DateOnly))
+
+	return nil
+}
+
+func (s *transactionMetric) GetDailyAggregates(ctx context.Context, opts models.TransactionMetricFilterOptions) (result []models.TransactionDailyAggregate, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	if len(opts.Statuses) == 0 {
+		opts.Statuses = []string{models.TransactionStatusSuccessNum}
+	}
+
+	today, err := common.NowZeroTime()
+	if err != nil {
+		return nil, err
+	}
+
+	repo := s.srv.sqlRepo.GetTransactionMetricRepository()
+
+	aggregatedDates, err := repo.GetAggregatedDates(ctx, opts.StartDate, opts.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get aggregated dates: %w", err)
+	}
+
+	aggregated := make(map[string]bool, len(aggregatedDates))
+	for _, date := range aggregatedDates {
+		aggregated[date.Format(time.DateOnly)] = true
+	}
+
+	var liveDates []time.Time
+	for date := opts.StartDate; !date.After(opts.EndDate); date = date.AddDate(0, 0, 1) {
+		if !isClosedDay(date, today) || !aggregated[date.Format(time.DateOnly)] {
+			liveDates = append(liveDates, date)
+		}
+	}
+
+	if len(aggregated) > 0 {
+		materialized, err := repo.GetDailyAggregates(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get daily aggregates: %w", err)
+		}
+
+		for _, aggregate := range materialized {
+			if isClosedDay(aggregate.TransactionDate, today) {
+				result = append(result, aggregate)
+			}
+		}
+	}
+
+	if len(liveDates) > 0 {
+		live, err := repo.AggregateTransactions(ctx, liveDates, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to aggregate transactions: %w", err)
+		}
+		result = append(result, live...)
+	}
+
+	return result, nil
+}
+
+// isClosedDay check whether no more transaction is expected on the date, that is the date is before today
+func isClosedDay(date, today time.Time) bool {
+	return date.Format(time.DateOnly) < today.Format(time.DateOnly)
+}
@@ -0,0 +1,286 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories/mock"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func metricDate(day int) time.Time {
+	return time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestTransactionMetricService_GetTransactionMetrics(t *testing.T) {
+	req := models.DoGetTransactionMetricsRequest{
+		StartDate: "2025-01-01",
+		EndDate:   "2025-01-03",
+		Interval:  models.MetricIntervalMonth,
+		GroupBy:   []string{models.MetricGroupByTransactionType},
+	}
+
+	tests := []struct {
+		name        string
+		req         models.DoGetTransactionMetricsRequest
+		doMock      func(th testServiceHelper)
+		wantMetrics []models.TransactionMetric
+		wantErr     error
+	}{
+		{
+			name: "success combine daily aggregates and transactions of days not aggregated",
+			req:  req,
+			doMock: func(th testServiceHelper) {
+				th.mockMetricRepository.EXPECT().GetAggregatedDates(gomock.Any(), metricDate(1), metricDate(3)).
+					Return([]time.Time{metricDate(1), metricDate(2)}, nil)
+				th.mockMetricRepository.EXPECT().GetDailyAggregates(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, opts models.TransactionMetricFilterOptions) ([]models.TransactionDailyAggregate, error) {
+						assert.Equal(t, metricDate(1), opts.StartDate)
+						assert.Equal(t, metricDate(3), opts.EndDate)
+						// only successful transactions when no status is requested
+						assert.Equal(t, []string{models.TransactionStatusSuccessNum}, opts.Statuses)
+						return []models.TransactionDailyAggregate{
+							{TransactionDate: metricDate(1), TransactionType: "RPYAD", TotalAmount: decimal.NewFromInt(1000), TotalCount: 2},
+							{TransactionDate: metricDate(2), TransactionType: "RPYAD", TotalAmount: decimal.NewFromInt(500), TotalCount: 1},
+						}, nil
+					})
+				th.mockMetricRepository.EXPECT().AggregateTransactions(gomock.Any(), []time.Time{metricDate(3)}, gomock.Any()).
+					Return([]models.TransactionDailyAggregate{
+						{TransactionDate: metricDate(3), TransactionType: "RPYAF", TotalAmount: decimal.NewFromInt(100), TotalCount: 1},
+					}, nil)
+			},
+			wantMetrics: []models.TransactionMetric{
+				{Period: "2025-01-01", TransactionType: "RPYAD", Sum: decimal.NewFromInt(1500), Count: 3, Avg: decimal.RequireFromString("500.00")},
+				{Period: "2025-01-01", TransactionType: "RPYAF", Sum: decimal.NewFromInt(100), Count: 1, Avg: decimal.RequireFromString("100.00")},
+			},
+		},
+		{
+			name: "success aggregate transactions when no day is aggregated",
+			req:  req,
+			doMock: func(th testServiceHelper) {
+				th.mockMetricRepository.EXPECT().GetAggregatedDates(gomock.Any(), metricDate(1), metricDate(3)).Return(nil, nil)
+				th.mockMetricRepository.EXPECT().AggregateTransactions(gomock.Any(), []time.Time{metricDate(1), metricDate(2), metricDate(3)}, gomock.Any()).
+					Return(nil, nil)
+			},
+			wantMetrics: []models.TransactionMetric{},
+		},
+		{
+			name:    "failed invalid date range",
+			req:     models.DoGetTransactionMetricsRequest{StartDate: "2025-01-03", EndDate: "2025-01-01"},
+			wantErr: common.ErrInvalidDateRange,
+		},
+		{
+			name:    "failed date range is too long",
+			req:     models.DoGetTransactionMetricsRequest{StartDate: "2024-01-01", EndDate: "2025-01-01"},
+			wantErr: common.ErrMetricDateRangeExceeded,
+		},
+		{
+			name: "failed get aggregated dates",
+			req:  req,
+			doMock: func(th testServiceHelper) {
+				th.mockMetricRepository.EXPECT().GetAggregatedDates(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "failed aggregate transactions",
+			req:  req,
+			doMock: func(th testServiceHelper) {
+				th.mockMetricRepository.EXPECT().GetAggregatedDates(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+				th.mockMetricRepository.EXPECT().AggregateTransactions(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := serviceTestHelper(t)
+			if tt.doMock != nil {
+				tt.doMock(th)
+			}
+
+			resp, err := th.transactionMetricSvc.GetTransactionMetrics(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, models.TransactionMetricsKind, resp.Kind)
+			assert.Equal(t, models.MetricIntervalMonth, resp.Interval)
+			assert.Equal(t, tt.wantMetrics, resp.Metrics)
+		})
+	}
+}
+
+func TestTransactionMetricService_MaterializeDailyAggregates(t *testing.T) {
+	today, err := common.NowZeroTime()
+	require.NoError(t, err)
+
+	materialize := func(th testServiceHelper, date time.Time, err error) *gomock.Call {
+		return th.mockSQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, f func(ctx context.Context, r repositories.SQLRepository) error) error {
+				atomicRepo := mock.NewMockSQLRepository(th.mockCtrl)
+				atomicMetricRepo := mock.NewMockTransactionMetricRepository(th.mockCtrl)
+
+				atomicRepo.EXPECT().GetTransactionMetricRepository().Return(atomicMetricRepo)
+				atomicMetricRepo.EXPECT().MaterializeDailyAggregates(gomock.Any(), date).Return(err)
+
+				return f(ctx, atomicRepo)
+			})
+	}
+
+	tests := []struct {
+		name      string
+		startDate time.Time
+		endDate   time.Time
+		doMock    func(th testServiceHelper)
+		wantErr   error
+	}{
+		{
+			name:      "success materialize every day",
+			startDate: metricDate(1),
+			endDate:   metricDate(2),
+			doMock: func(th testServiceHelper) {
+				gomock.InOrder(
+					materialize(th, metricDate(1), nil),
+					materialize(th, metricDate(2), nil),
+				)
+				th.mockMetricRepository.EXPECT().GetInvalidatedDates(gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			name:      "success skip days which are not closed",
+			startDate: today.AddDate(0, 0, -1),
+			endDate:   today.AddDate(0, 0, 1),
+			doMock: func(th testServiceHelper) {
+				materialize(th, today.AddDate(0, 0, -1), nil)
+				th.mockMetricRepository.EXPECT().GetInvalidatedDates(gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			name:      "success materialize invalidated days again",
+			startDate: metricDate(10),
+			endDate:   metricDate(10),
+			doMock: func(th testServiceHelper) {
+				gomock.InOrder(
+					materialize(th, metricDate(10), nil),
+					th.mockMetricRepository.EXPECT().GetInvalidatedDates(gomock.Any()).
+						Return([]time.Time{metricDate(3), metricDate(10)}, nil),
+					materialize(th, metricDate(3), nil),
+				)
+			},
+		},
+		{
+			name:      "failed get invalidated dates",
+			startDate: metricDate(1),
+			endDate:   metricDate(1),
+			doMock: func(th testServiceHelper) {
+				materialize(th, metricDate(1), nil)
+				th.mockMetricRepository.EXPECT().GetInvalidatedDates(gomock.Any()).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name:      "failed materialize",
+			startDate: metricDate(1),
+			endDate:   metricDate(2),
+			doMock: func(th testServiceHelper) {
+				materialize(th, metricDate(1), assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name:      "failed invalid date range",
+			startDate: metricDate(2),
+			endDate:   metricDate(1),
+			wantErr:   common.ErrInvalidDateRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := serviceTestHelper(t)
+			if tt.doMock != nil {
+				tt.doMock(th)
+			}
+
+			err := th.transactionMetricSvc.MaterializeDailyAggregates(context.Background(), tt.startDate, tt.endDate)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTransactionService_RepaymentFromMetrics(t *testing.T) {
+	today, err := common.NowZeroTime()
+	require.NoError(t, err)
+	yesterday := today.AddDate(0, 0, -1)
+
+	t.Run("report repayment of last 7 days", func(t *testing.T) {
+		th := serviceTestHelper(t)
+
+		th.mockMetricRepository.EXPECT().GetAggregatedDates(gomock.Any(), yesterday.AddDate(0, 0, -6), yesterday).
+			Return([]time.Time{yesterday.AddDate(0, 0, -6)}, nil)
+		th.mockMetricRepository.EXPECT().GetDailyAggregates(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, opts models.TransactionMetricFilterOptions) ([]models.TransactionDailyAggregate, error) {
+				assert.Equal(t, models.RepaymentTransactionTypes, opts.TransactionTypes)
+				assert.Equal(t, []string{models.TransactionStatusSuccessNum}, opts.Statuses)
+				return []models.TransactionDailyAggregate{
+					{TransactionDate: yesterday.AddDate(0, 0, -6), TransactionType: "RPYAD", TotalAmount: decimal.NewFromInt(1000), TotalCount: 1},
+				}, nil
+			})
+		th.mockMetricRepository.EXPECT().AggregateTransactions(gomock.Any(), gomock.Len(6), gomock.Any()).
+			Return([]models.TransactionDailyAggregate{
+				{TransactionDate: yesterday, TransactionType: "RPYAF", TotalAmount: decimal.NewFromInt(100), TotalCount: 1},
+				{TransactionDate: yesterday, TransactionType: "RPYAE", TotalAmount: decimal.NewFromInt(900), TotalCount: 1},
+			}, nil)
+
+		out, err := th.transactionService.GetReportRepayment(context.Background())
+		require.NoError(t, err)
+		require.Len(t, out, 2)
+		assert.True(t, decimal.NewFromInt(1000).Equal(out[0].Principal))
+		assert.True(t, decimal.NewFromInt(1000).Equal(out[0].Total))
+		assert.True(t, decimal.NewFromInt(900).Equal(out[1].Outstanding))
+		assert.True(t, decimal.NewFromInt(100).Equal(out[1].Total))
+	})
+
+	t.Run("collect repayment of yesterday", func(t *testing.T) {
+		th := serviceTestHelper(t)
+
+		th.mockMetricRepository.EXPECT().GetAggregatedDates(gomock.Any(), yesterday, yesterday).Return(nil, nil)
+		th.mockMetricRepository.EXPECT().AggregateTransactions(gomock.Any(), []time.Time{yesterday}, gomock.Any()).
+			Return([]models.TransactionDailyAggregate{
+				{TransactionDate: yesterday, TransactionType: "RPYAB", TotalAmount: decimal.NewFromInt(700), TotalCount: 1},
+			}, nil)
+
+		out, err := th.transactionService.CollectRepayment(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, yesterday, out.TransactionDate)
+		assert.True(t, decimal.NewFromInt(700).Equal(out.Lender.Decimal))
+		assert.True(t, out.PPN.Valid)
+		assert.True(t, out.PPN.Decimal.IsZero())
+	})
+
+	t.Run("failed collect repayment", func(t *testing.T) {
+		th := serviceTestHelper(t)
+
+		th.mockMetricRepository.EXPECT().GetAggregatedDates(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+
+		_, err := th.transactionService.CollectRepayment(context.Background())
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
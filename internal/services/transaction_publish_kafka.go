@@ -8,8 +8,8 @@ import (
 )
 
 func (ts *transaction) PublishTransaction(ctx context.Context, in models.DoPublishTransactionRequest) (out models.DoPublishTransactionResponse, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	if in.RefNumber == "" {
 		in.RefNumber = ts.srv.idgenerator.Generate(models.TransactionIDManualPrefix)
@@ -66,9 +66,41 @@ var header = []string{
 	"Metadata",
 }
 
+// toTransactionFileRow return the row of downloaded or exported transaction file, the columns follow header
+func toTransactionFileRow(trx models.Transaction, mapOrderTypes, mapTransactionTypes map[string]string) []string {
+	t := trx.ToGetTransactionOut(mapOrderTypes, mapTransactionTypes)
+
+	amount := "0"
+	if trx.Amount.Valid {
+		amount = trx.Amount.Decimal.String()
+	}
+
+	return []string{
+		t.TransactionID,
+		t.RefNumber,
+		t.OrderType,
+		t.OrderTypeName,
+		t.TransactionType,
+		t.TransactionTypeName,
+		t.TransactionTime.In(common.GetLocation()).Format(common.DateFormatYYYYMMDDWithTime),
+		t.FromAccount,
+		t.FromAccountName,
+		t.FromAccountProductTypeName,
+		t.ToAccount,
+		t.ToAccountName,
+		t.ToAccountProductTypeName,
+		amount,
+		t.Status,
+		t.Description,
+		t.Method,
+		t.Currency,
+		t.Metadata,
+	}
+}
+
 func (ts *transaction) DownloadTransactionFileCSV(ctx context.Context, req models.DownloadTransactionRequest) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	trxRepo := ts.srv.sqlRepo.GetTransactionRepository()
 
@@ -103,34 +135,7 @@ func (ts *transaction) DownloadTransactionFileCSV(ctx context.Context, req model
 			return err
 		}
 
-		t := trx.Data.ToGetTransactionOut(mapOrderTypes, mapTransactionTypes)
-
-		amount := "0"
-		if trx.Data.Amount.Valid {
-			amount = trx.Data.Amount.Decimal.String()
-		}
-
-		err = w.Write([]string{
-			t.TransactionID,
-			t.RefNumber,
-			t.OrderType,
-			t.OrderTypeName,
-			t.TransactionType,
-			t.TransactionTypeName,
-			t.TransactionTime.In(common.GetLocation()).Format(common.DateFormatYYYYMMDDWithTime),
-			t.FromAccount,
-			t.FromAccountName,
-			t.FromAccountProductTypeName,
-			t.ToAccount,
-			t.ToAccountName,
-			t.ToAccountProductTypeName,
-			amount,
-			t.Status,
-			t.Description,
-			t.Method,
-			t.Currency,
-			t.Metadata,
-		})
+		err = w.Write(toTransactionFileRow(trx.Data, mapOrderTypes, mapTransactionTypes))
 		if err != nil {
 			err = fmt.Errorf("failed to write row: %w", err)
 			return err
@@ -149,8 +154,8 @@ func (ts *transaction) DownloadTransactionFileCSV(ctx context.Context, req model
 }
 
 func (ts *transaction) DownloadV2TransactionFileCSV(ctx context.Context, req models.DownloadTransactionRequest) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	trxRepo := ts.srv.sqlRepo.GetTransactionRepository()
 
@@ -242,34 +247,7 @@ func (ts *transaction) DownloadV2TransactionFileCSV(ctx context.Context, req mod
 			return fmt.Errorf("failed to read stream: %w", trx.Err)
 		}
 
-		t := trx.Data.ToGetTransactionOut(mapOrderTypes, mapTransactionTypes)
-
-		amount := "0"
-		if trx.Data.Amount.Valid {
-			amount = trx.Data.Amount.Decimal.String()
-		}
-
-		row := []string{
-			t.TransactionID,
-			t.RefNumber,
-			t.OrderType,
-			t.OrderTypeName,
-			t.TransactionType,
-			t.TransactionTypeName,
-			t.TransactionTime.In(common.GetLocation()).Format(common.DateFormatYYYYMMDDWithTime),
-			t.FromAccount,
-			t.FromAccountName,
-			t.FromAccountProductTypeName,
-			t.ToAccount,
-			t.ToAccountName,
-			t.ToAccountProductTypeName,
-			amount,
-			t.Status,
-			t.Description,
-			t.Method,
-			t.Currency,
-			t.Metadata,
-		}
+		row := toTransactionFileRow(trx.Data, mapOrderTypes, mapTransactionTypes)
 
 		// build csv row bytes
 		rowBytes, buildErr := buildRowBytes(row)
@@ -404,8 +382,8 @@ func (ts *transaction) DownloadV2TransactionFileCSV(ctx context.Context, req mod
 }
 
 func (ts *transaction) GetAllTransaction(ctx context.Context, opts models.TransactionFilterOptions) (result []models.GetTransactionOut, total int, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	trxRepo := ts.srv.sqlRepo.GetTransactionRepository()
 
@@ -462,8 +440,8 @@ func (ts *transaction) ensureAccountExists(ctx context.Context, accountNumbers .
 }
 
 func (ts *transaction) GenerateTransactionReport(ctx context.Context) (urls []string, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -575,8 +553,8 @@ func (ts *transaction) deleteReportFiles(ctx context.Context, reportDate time.Ti
 }
 
 func (ts *transaction) StoreTransaction(ctx context.Context, req models.TransactionReq, processType models.TransactionStoreProcessType, clientID string) (out models.GetTransactionOut, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	en, err := req.ToRequest()
 	if err != nil {
@@ -676,8 +654,8 @@ func (ts *transaction) publishNotificationSuccess(ctx context.Context, trx model
 }
 
 func (ts *transaction) StoreBulkTransaction(ctx context.Context, req []models.TransactionReq) (err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	ops := "TransactionService.StoreBulkTransaction"
 
@@ -739,8 +717,8 @@ func (ts *transaction) StoreBulkTransaction(ctx context.Context, req []models.Tr
 func (ts *transaction) GetByTransactionTypeAndRefNumber(ctx context.Context, req *models.TransactionGetByTypeAndRefNumberRequest) (*models.GetTransactionOut, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	transaction, err := ts.srv.sqlRepo.GetTransactionRepository().
 		GetByTransactionTypeAndRefNumber(ctx, req)
@@ -755,8 +733,8 @@ func (ts *transaction) GetByTransactionTypeAndRefNumber(ctx context.Context, req
 // CommitReservedTransaction is the next function executed after reserve a transaction.
 // Commit will change transaction to SUCCESS and update balance accordingly.
 func (ts *transaction) CommitReservedTransaction(ctx context.Context, transactionID, clientID string) (trx *models.Transaction, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	trx, err = ts.srv.sqlRepo.GetTransactionRepository().GetByTransactionID(ctx, transactionID)
 	if err != nil {
@@ -831,8 +809,8 @@ func (ts *transaction) CommitReservedTransaction(ctx context.Context, transactio
 // CancelReservedTransaction is the next function executed after reserve a transaction.
 // Cancel will change transaction to CANCEL and rollback fromAccount balance.
 func (ts *transaction) CancelReservedTransaction(ctx context.Context, transactionID string) (trx *models.Transaction, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	trx, err = ts.srv.sqlRepo.GetTransactionRepository().GetByTransactionID(ctx, transactionID)
 	if err != nil {
@@ -897,8 +875,8 @@ func (ts *transaction) CancelReservedTransaction(ctx context.Context, transactio
 }
 
 func (ts *transaction) GetStatusCount(ctx context.Context, threshold uint, opts models.TransactionFilterOptions) (out models.StatusCountTransaction, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	trxRepo := ts.srv.sqlRepo.GetTransactionRepository()
 
@@ -911,56 +889,52 @@ func (ts *transaction) GetStatusCount(ctx context.Context, threshold uint, opts
 }
 
 func (ts *transaction) GetReportRepayment(ctx context.Context) (out []models.ReportRepayment, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
-	// calculate date range: yesterday and 6 days before
-	endDate := time.Now().AddDate(0, 0, -1).Truncate(24 * time.Hour)
-	startDate := endDate.AddDate(0, 0, -6)
-
-	trxRepo := ts.srv.sqlRepo.GetTransactionRepository()
-	ttl := 24 * time.Hour
-
-	// Loop day-by-day, get (or cache) each day's result, then merge
-	for d := startDate; !d.After(endDate); d = d.AddDate(0, 0, 1) {
-		dayStart := d
-		dayEnd := d.AddDate(0, 0, 1) // half-open: [dayStart, dayEnd)
-
-		var dayRes []models.ReportRepayment
-		opts := models.GetOrSetCacheOptions[[]models.ReportRepayment]{
-			Key: getCacheKeyReportRepayment(dayStart, dayEnd),
-			TTL: ttl,
-			Fn: func() ([]models.ReportRepayment, error) {
-				return trxRepo.GetReportRepayment(ctx, dayStart, dayEnd)
-			},
-		}
+	today, err := common.NowZeroTime()
+	if err != nil {
+		return nil, err
+	}
 
-		dayRes, err = repositories.GetOrSetCache[[]models.ReportRepayment](ctx, ts.srv.cacheRepo, opts)
-		if err != nil {
-			return nil, err
-		}
+	// yesterday and 6 days before, all of them are closed days so they are read from daily aggregates
+	endDate := today.AddDate(0, 0, -1)
+	startDate := endDate.AddDate(0, 0, -6)
 
-		out = append(out, dayRes...)
+	aggregates, err := ts.srv.TransactionMetric.GetDailyAggregates(ctx, models.TransactionMetricFilterOptions{
+		StartDate:        startDate,
+		EndDate:          endDate,
+		TransactionTypes: models.RepaymentTransactionTypes,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return out, nil
+	return models.NewReportRepayments(aggregates), nil
 }
 
 func (ts *transaction) CollectRepayment(ctx context.Context) (out *models.CollectRepayment, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
-	yesterday := common.Now().AddDate(0, 0, -1)
+	today, err := common.NowZeroTime()
+	if err != nil {
+		return nil, err
+	}
+	yesterday := today.AddDate(0, 0, -1)
 
 	xlog.Infof(ctx, "start collect repayment with date : %s", yesterday)
 
-	trxRepo := ts.srv.sqlRepo.GetTransactionRepository()
-	out, err = trxRepo.ColectRepayment(ctx, yesterday)
+	aggregates, err := ts.srv.TransactionMetric.GetDailyAggregates(ctx, models.TransactionMetricFilterOptions{
+		StartDate:        yesterday,
+		EndDate:          yesterday,
+		TransactionTypes: models.RepaymentTransactionTypes,
+	})
 	if err != nil {
-		return out, err
+		return nil, err
 	}
 
 	xlog.Infof(ctx, "finish collect repayment with date : %s", yesterday)
 
-	return out, nil
+	return models.NewCollectRepayment(yesterday, aggregates), nil
 }
@@ -120,25 +120,34 @@ func WithVATRevenueConfig(vatConfig []models.ConfigVatRevenue) VATOpts {
 
 // calculateVAT calculates the VAT amount from the given amount(including VAT)
 func calculateVAT(amount decimal.Decimal, transactionTime time.Time, opts ...VATOpts) (decimal.Decimal, error) {
+	rate, err := vatRevenueRate(transactionTime, opts...)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	one := decimal.NewFromInt(1)
+	calculatedPercentage := rate.Div(one.Add(rate))
+
+	return amount.Mul(calculatedPercentage).Round(0), nil
+}
+
+// vatRevenueRate return VAT rate which is active at the transaction time, default is 0.11 when there is no config
+func vatRevenueRate(transactionTime time.Time, opts ...VATOpts) (decimal.Decimal, error) {
 	opt := &vatOpts{}
 	for _, fnOpt := range opts {
 		fnOpt(opt)
 	}
 
-	// default 0.11
-	calculatedPercentage := decimal.NewFromFloat(0.11).Div(decimal.NewFromFloat(1.11))
-
-	if len(opt.manager.Config) > 0 {
-		configVAT, err := opt.manager.GetActiveConfig(transactionTime)
-		if err != nil {
-			return decimal.Zero, err
-		}
+	if len(opt.manager.Config) == 0 {
+		return decimal.NewFromFloat(0.11), nil
+	}
 
-		one := decimal.NewFromInt(1)
-		calculatedPercentage = configVAT.Percentage.Div(one.Add(configVAT.Percentage))
+	configVAT, err := opt.manager.GetActiveConfig(transactionTime)
+	if err != nil {
+		return decimal.Zero, err
 	}
 
-	return amount.Mul(calculatedPercentage).Round(0), nil
+	return configVAT.Percentage, nil
 }
 
 func getLoanAccountNumber(metadata models.WalletMetadata) string {
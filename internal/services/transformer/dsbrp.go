@@ -15,6 +15,19 @@ type dsbrpTransformer struct {
 	baseWalletTransactionTransformer
 }
 
+func (t dsbrpTransformer) getEntityCode(ctx context.Context, accountNumber string) (string, error) {
+	account, err := t.accountRepository.GetCachedAccount(ctx, accountNumber)
+	if err != nil {
+		return "", err
+	}
+
+	if account.Entity == "" {
+		return "", common.ErrMissingEntityFromAccount
+	}
+
+	return account.Entity, nil
+}
+
 func (t dsbrpTransformer) createDefaultDSB(
 	ctx context.Context,
 	fromAccount, toAccount string,
@@ -22,19 +35,23 @@ func (t dsbrpTransformer) createDefaultDSB(
 	typeTransaction string,
 	parentWalletTransaction models.WalletTransaction,
 ) (res models.TransactionReq, err error) {
-	status, err := transformWalletTransactionStatus(parentWalletTransaction.Status)
-	if err != nil {
-		return
-	}
-
-	account, err := t.accountRepository.GetCachedAccount(ctx, toAccount)
+	entityCode, err := t.getEntityCode(ctx, toAccount)
 	if err != nil {
 		return res, err
 	}
 
-	entityCode := account.Entity
-	if entityCode == "" {
-		return res, common.ErrMissingEntityFromAccount
+	return t.createDSB(fromAccount, toAccount, entityCode, amount, typeTransaction, parentWalletTransaction)
+}
+
+func (t dsbrpTransformer) createDSB(
+	fromAccount, toAccount, entityCode string,
+	amount decimal.Decimal,
+	typeTransaction string,
+	parentWalletTransaction models.WalletTransaction,
+) (res models.TransactionReq, err error) {
+	status, err := transformWalletTransactionStatus(parentWalletTransaction.Status)
+	if err != nil {
+		return
 	}
 
 	metadata := parentWalletTransaction.Metadata
@@ -131,26 +148,29 @@ func (t dsbrpTransformer) Transform(ctx context.Context, amount models.Amount, p
 			return nil, err
 		}
 
-		configVAT, err := t.masterDataRepository.GetConfigVATRevenue(ctx)
+		// entity of VAT out account is needed by DSBRQ, it is also the entity of the tax lookup
+		vatEntityCode, err := t.getEntityCode(ctx, VATOut)
 		if err != nil {
 			return nil, err
 		}
 
-		amountDSBRQ, err := calculateVAT(
-			amount.ValueDecimal.Decimal,
-			parentWalletTransaction.TransactionTime,
-			WithVATRevenueConfig(configVAT),
-		)
+		tax, err := t.taxEngine.Split(ctx, amount.ValueDecimal.Decimal, models.TaxLookup{
+			Entity:          t.config.AccountConfig.MapAccountEntity[vatEntityCode],
+			LoanKind:        parentWalletTransaction.Description,
+			TransactionType: "DSBRP",
+			At:              parentWalletTransaction.TransactionTime,
+		})
 		if err != nil {
 			return nil, err
 		}
 
+		amountDSBRQ := tax.VAT
 		amountDSBRP := amount.ValueDecimal.Sub(amountDSBRQ)
 
-		txDSBRQ, err := t.createDefaultDSB(
-			ctx,
+		txDSBRQ, err := t.createDSB(
 			t.config.AccountConfig.SystemAccountNumber,
 			VATOut,
+			vatEntityCode,
 			amountDSBRQ,
 			"DSBRQ",
 			parentWalletTransaction,
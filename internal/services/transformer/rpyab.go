@@ -35,19 +35,28 @@ func (t rpyabTransformer) Transform(ctx context.Context, amount models.Amount, p
 
 	finalAmount := amount.ValueDecimal.Decimal
 
+	// WHT of the lender return is posted by RPYAC, the difference from the requested RPYAC amount stays with the lender
+	requestedRpyac := getTotalAmount(parentWalletTransaction, "RPYAC")
+	rpyacAmount := requestedRpyac
+	if requestedRpyac.GreaterThan(decimal.Zero) {
+		rpyacAmount, err = t.taxEngine.LenderReturnWHT(ctx, parentWalletTransaction, models.TaxLookup{
+			Entity:          t.config.AccountConfig.MapAccountEntity[entityCode],
+			LoanKind:        parentWalletTransaction.Description,
+			TransactionType: "RPYAC",
+			At:              parentWalletTransaction.TransactionTime,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		finalAmount = finalAmount.Add(requestedRpyac).Sub(rpyacAmount)
+	}
+
 	// Check feature flag for RPYAB + RPYAC adjustment
 	isNeedJogressRpyabRpyac := t.config.FeatureFlag.EnableRpyabRpyacAdjustment
-	if isNeedJogressRpyabRpyac {
+	if isNeedJogressRpyabRpyac && rpyacAmount.GreaterThan(decimal.Zero) {
 		// If feature flag is ON, add RPYAC amount to RPYAB only if RPYAC > 0
-		for _, amt := range parentWalletTransaction.Amounts {
-			if amt.Type == "RPYAC" && amt.Amount != nil {
-				rpyacAmount := amt.Amount.ValueDecimal.Decimal
-				if rpyacAmount.GreaterThan(decimal.Zero) {
-					finalAmount = finalAmount.Add(rpyacAmount)
-				}
-				break
-			}
-		}
+		finalAmount = finalAmount.Add(rpyacAmount)
 	}
 
 	return []models.TransactionReq{
@@ -34,7 +34,7 @@ func (t rpyacTransformer) Transform(ctx context.Context, amount models.Amount, p
 		}
 	}
 
-	wht2326, err := t.accountConfigRepository.GetWht2326(ctx, accountNumber, parentWalletTransaction.Description)
+	wht2326, err := t.taxEngine.WHTAccount(ctx, accountNumber, parentWalletTransaction.Description)
 	if err != nil {
 		return nil, err
 	}
@@ -49,6 +49,16 @@ func (t rpyacTransformer) Transform(ctx context.Context, amount models.Amount, p
 		return res, common.ErrMissingEntityFromAccount
 	}
 
+	wht, err := t.taxEngine.LenderReturnWHT(ctx, parentWalletTransaction, models.TaxLookup{
+		Entity:          t.config.AccountConfig.MapAccountEntity[entityCode],
+		LoanKind:        parentWalletTransaction.Description,
+		TransactionType: "RPYAC",
+		At:              parentWalletTransaction.TransactionTime,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	metadata := parentWalletTransaction.Metadata
 	metadata = t.MutateMetadataByAccountEntity(entityCode, metadata)
 
@@ -67,7 +77,7 @@ func (t rpyacTransformer) Transform(ctx context.Context, amount models.Amount, p
 			FromAccount:     fromAccount,
 			ToAccount:       wht2326,
 			TransactionDate: common.FormatDatetimeToStringInLocalTime(parentWalletTransaction.TransactionTime, common.DateFormatYYYYMMDD),
-			Amount:          decimal.NewNullDecimal(amount.ValueDecimal.Decimal),
+			Amount:          decimal.NewNullDecimal(wht),
 			Status:          string(status),
 			TypeTransaction: "RPYAC",
 			OrderType:       "RPY",
@@ -20,11 +20,6 @@ func (t rpyafTransformer) Transform(ctx context.Context, amount models.Amount, p
 		return nil, err
 	}
 
-	configVAT, err := t.masterDataRepository.GetConfigVATRevenue(ctx)
-	if err != nil {
-		return nil, err
-	}
-
 	if parentWalletTransaction.Description == "" {
 		return nil, common.ErrMissingDescription
 	}
@@ -65,14 +60,16 @@ func (t rpyafTransformer) Transform(ctx context.Context, amount models.Amount, p
 
 	var rpyagAmount decimal.Decimal
 	if entity != "AWF" {
-		rpyagAmount, err = calculateVAT(
-			amount.ValueDecimal.Decimal,
-			parentWalletTransaction.TransactionTime,
-			WithVATRevenueConfig(configVAT),
-		)
+		tax, err := t.taxEngine.Split(ctx, amount.ValueDecimal.Decimal, models.TaxLookup{
+			Entity:          entity,
+			LoanKind:        parentWalletTransaction.Description,
+			TransactionType: "RPYAF",
+			At:              parentWalletTransaction.TransactionTime,
+		})
 		if err != nil {
 			return nil, err
 		}
+		rpyagAmount = tax.VAT
 		rpyafAmount = amount.ValueDecimal.Decimal.Sub(rpyagAmount)
 	}
 
@@ -0,0 +1,100 @@
+package transformer
+
+import (
+	"context"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+
+	"github.com/shopspring/decimal"
+)
+
+// TaxRateProvider provide the tax rate table, rates are expected to be cached since it is called for every transformed transaction
+type TaxRateProvider interface {
+	GetTaxRates(ctx context.Context) (models.TaxRates, error)
+}
+
+// TaxEngine split gross amount into net, VAT and WHT legs based on the tax rate table.
+// When no VAT rate is defined for the transaction, VAT revenue config of master data is used,
+// and when no WHT rate is defined, WHT amount of the request is used,
+// so existing transformers keep the same result until a rate is configured.
+type TaxEngine struct {
+	rates                   TaxRateProvider
+	masterDataRepository    repositories.MasterDataRepository
+	accountConfigRepository repositories.AccountConfigRepository
+}
+
+func NewTaxEngine(
+	rates TaxRateProvider,
+	masterDataRepository repositories.MasterDataRepository,
+	accountConfigRepository repositories.AccountConfigRepository,
+) TaxEngine {
+	return TaxEngine{
+		rates:                   rates,
+		masterDataRepository:    masterDataRepository,
+		accountConfigRepository: accountConfigRepository,
+	}
+}
+
+func (e TaxEngine) getRates(ctx context.Context) (models.TaxRates, error) {
+	if e.rates == nil {
+		return nil, nil
+	}
+
+	return e.rates.GetTaxRates(ctx)
+}
+
+// Split return tax legs of the gross amount which already include VAT
+func (e TaxEngine) Split(ctx context.Context, gross decimal.Decimal, lookup models.TaxLookup) (models.TaxSplit, error) {
+	rates, err := e.getRates(ctx)
+	if err != nil {
+		return models.TaxSplit{}, err
+	}
+
+	whtRate := decimal.Zero
+	if rate, ok := rates.Find(models.TaxTypeWHT, lookup); ok {
+		whtRate = rate.Rate
+	}
+
+	if rate, ok := rates.Find(models.TaxTypeVAT, lookup); ok {
+		return models.SplitGross(gross, rate.Rate, whtRate), nil
+	}
+
+	configVAT, err := e.masterDataRepository.GetConfigVATRevenue(ctx)
+	if err != nil {
+		return models.TaxSplit{}, err
+	}
+
+	vatRate, err := vatRevenueRate(lookup.At, WithVATRevenueConfig(configVAT))
+	if err != nil {
+		return models.TaxSplit{}, err
+	}
+
+	return models.SplitGross(gross, vatRate, whtRate), nil
+}
+
+// LenderReturnWHT return WHT withheld from lender return of a repayment.
+// Lender return before tax is the RPYAB plus RPYAC amount, RPYAC is the withheld part, so the total is kept.
+// When no WHT rate is defined for the lookup, RPYAC amount of the repayment is used.
+func (e TaxEngine) LenderReturnWHT(ctx context.Context, repayment models.WalletTransaction, lookup models.TaxLookup) (decimal.Decimal, error) {
+	requested := getTotalAmount(repayment, "RPYAC")
+
+	rates, err := e.getRates(ctx)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	rate, ok := rates.Find(models.TaxTypeWHT, lookup)
+	if !ok {
+		return requested, nil
+	}
+
+	gross := getTotalAmount(repayment, "RPYAB").Add(requested)
+
+	return models.SplitGross(gross, decimal.Zero, rate.Rate).WHT, nil
+}
+
+// WHTAccount return account which receives WHT withheld from the loan
+func (e TaxEngine) WHTAccount(ctx context.Context, loanAccountNumber, loanKind string) (string, error) {
+	return e.accountConfigRepository.GetWht2326(ctx, loanAccountNumber, loanKind)
+}
@@ -0,0 +1,163 @@
+package transformer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories/mock"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type staticTaxRates struct {
+	rates models.TaxRates
+	err   error
+}
+
+func (s staticTaxRates) GetTaxRates(_ context.Context) (models.TaxRates, error) {
+	return s.rates, s.err
+}
+
+func TestTaxEngine_Split(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	gross := decimal.NewFromInt(111_000)
+
+	tests := []struct {
+		name      string
+		rates     TaxRateProvider
+		mockData  func(m *mock.MockMasterDataRepository)
+		lookup    models.TaxLookup
+		wantVAT   decimal.Decimal
+		wantWHT   decimal.Decimal
+		wantError bool
+	}{
+		{
+			name: "VAT and WHT from tax rate table",
+			rates: staticTaxRates{rates: models.TaxRates{
+				{TaxType: models.TaxTypeVAT, Rate: decimal.NewFromFloat(0.11), EffectiveFrom: since},
+				{TaxType: models.TaxTypeWHT, Entity: "AMF", Rate: decimal.NewFromFloat(0.02), EffectiveFrom: since},
+			}},
+			lookup:  models.TaxLookup{Entity: "AMF", TransactionType: "RPYAF", At: at},
+			wantVAT: decimal.NewFromInt(11_000),
+			wantWHT: decimal.NewFromInt(2_000),
+		},
+		{
+			name:  "VAT fallback to master data config",
+			rates: staticTaxRates{},
+			mockData: func(m *mock.MockMasterDataRepository) {
+				m.EXPECT().GetConfigVATRevenue(gomock.Any()).Return([]models.ConfigVatRevenue{
+					{Percentage: decimal.NewFromFloat(0.11), StartTime: since, EndTime: since.AddDate(1, 0, 0)},
+				}, nil)
+			},
+			lookup:  models.TaxLookup{Entity: "AMF", At: at},
+			wantVAT: decimal.NewFromInt(11_000),
+			wantWHT: decimal.Zero,
+		},
+		{
+			name: "without tax rate provider",
+			mockData: func(m *mock.MockMasterDataRepository) {
+				m.EXPECT().GetConfigVATRevenue(gomock.Any()).Return(nil, nil)
+			},
+			lookup:  models.TaxLookup{At: at},
+			wantVAT: decimal.NewFromInt(11_000),
+			wantWHT: decimal.Zero,
+		},
+		{
+			name:      "error get tax rates",
+			rates:     staticTaxRates{err: errors.New("some error")},
+			lookup:    models.TaxLookup{At: at},
+			wantError: true,
+		},
+		{
+			name:  "error get master data config",
+			rates: staticTaxRates{},
+			mockData: func(m *mock.MockMasterDataRepository) {
+				m.EXPECT().GetConfigVATRevenue(gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			lookup:    models.TaxLookup{At: at},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			masterData := mock.NewMockMasterDataRepository(mockCtrl)
+			if tt.mockData != nil {
+				tt.mockData(masterData)
+			}
+
+			got, err := NewTaxEngine(tt.rates, masterData, nil).Split(ctx, gross, tt.lookup)
+			if tt.wantError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, tt.wantVAT.Equal(got.VAT), "VAT %s", got.VAT)
+			assert.True(t, tt.wantWHT.Equal(got.WHT), "WHT %s", got.WHT)
+			assert.True(t, gross.Equal(got.Net.Add(got.VAT).Add(got.WHT)))
+		})
+	}
+}
+
+func TestTaxEngine_LenderReturnWHT(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	amountOf := func(value int64) *models.Amount {
+		return &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(value))}
+	}
+	repayment := models.WalletTransaction{
+		TransactionType: "RPYAA",
+		Amounts: []models.AmountDetail{
+			{Type: "RPYAB", Amount: amountOf(97_000)},
+			{Type: "RPYAC", Amount: amountOf(3_000)},
+		},
+	}
+	lookup := models.TaxLookup{Entity: "AMF", TransactionType: "RPYAC", At: at}
+
+	tests := []struct {
+		name      string
+		rates     TaxRateProvider
+		wantWHT   decimal.Decimal
+		wantError bool
+	}{
+		{
+			name: "WHT from tax rate table",
+			rates: staticTaxRates{rates: models.TaxRates{
+				{TaxType: models.TaxTypeWHT, Entity: "AMF", TransactionType: "RPYAC", Rate: decimal.NewFromFloat(0.15), EffectiveFrom: since},
+			}},
+			wantWHT: decimal.NewFromInt(15_000),
+		},
+		{
+			name:    "requested amount without WHT rate",
+			rates:   staticTaxRates{},
+			wantWHT: decimal.NewFromInt(3_000),
+		},
+		{
+			name:      "error get tax rates",
+			rates:     staticTaxRates{err: errors.New("some error")},
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewTaxEngine(tt.rates, nil, nil).LenderReturnWHT(ctx, repayment, lookup)
+			if tt.wantError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, tt.wantWHT.Equal(got), "WHT %s", got)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package transformer
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/accounting"
@@ -40,6 +41,7 @@ type baseWalletTransactionTransformer struct {
 	transaction             repositories.TransactionRepository
 	walletTransaction       repositories.WalletTransactionRepository
 	accountConfigRepository repositories.AccountConfigRepository
+	taxEngine               TaxEngine
 }
 
 // MapTransformer is a map that will be used to get transformer for specified transaction type
@@ -54,6 +56,7 @@ func NewMapTransformer(
 	accountConfigRepository repositories.AccountConfigRepository,
 	walletTransaction repositories.WalletTransactionRepository,
 	featureFlag flag.Client,
+	taxRates TaxRateProvider,
 ) MapTransformer {
 	baseTransformer := baseWalletTransactionTransformer{
 		config:                  config,
@@ -64,6 +67,7 @@ func NewMapTransformer(
 		accountConfigRepository: accountConfigRepository,
 		walletTransaction:       walletTransaction,
 		flag:                    featureFlag,
+		taxEngine:               NewTaxEngine(taxRates, masterDataRepository, accountConfigRepository),
 	}
 
 	// register all transformer here
@@ -311,12 +315,23 @@ func (m MapTransformer) GetTransformer(transactionType string) (Transformer, err
 	return transformer, nil
 }
 
+// TransactionTypes return sorted transaction types which have registered transformer
+func (m MapTransformer) TransactionTypes() []string {
+	res := make([]string, 0, len(m))
+	for transactionType := range m {
+		res = append(res, transactionType)
+	}
+	sort.Strings(res)
+
+	return res
+}
+
 // Transform will transform wallet transaction to acuan transaction
 // since there are many transaction type in wallet transaction, we need to get the transformer for specified transaction type
 // then we will use the transformer to transform the wallet transaction to acuan transaction
 func (m MapTransformer) Transform(ctx context.Context, in models.WalletTransaction) (res []models.TransactionReq, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	transformer, err := m.GetTransformer(in.TransactionType)
 	if err != nil {
@@ -352,5 +367,10 @@ func (m MapTransformer) Transform(ctx context.Context, in models.WalletTransacti
 		}
 	}
 
+	// link every child transaction to its parent, so the posting can be traced back to the wallet transaction
+	for i := range res {
+		res[i].WalletTransactionID = in.ID
+	}
+
 	return res, errs.ErrorOrNil()
 }
@@ -61,6 +61,18 @@ func TestMapTransformer_GetTransformer(t *testing.T) {
 	}
 }
 
+func TestMapTransformer_TransactionTypes(t *testing.T) {
+	m := MapTransformer{
+		"TUPVA": &itrtfTransformer{},
+		"ITRTF": &itrtfTransformer{},
+	}
+
+	want := []string{"ITRTF", "TUPVA"}
+	if got := m.TransactionTypes(); !reflect.DeepEqual(got, want) {
+		t.Errorf("TransactionTypes() got = %v, want %v", got, want)
+	}
+}
+
 func TestMapTransformer_Transform(t *testing.T) {
 
 	mockCtrl := gomock.NewController(t)
@@ -91,6 +103,7 @@ func TestMapTransformer_Transform(t *testing.T) {
 		mockAccountConfigRepo,
 		mockWalletTransactionRepo,
 		mockFlag,
+		nil,
 	)
 
 	ct := time.Now()
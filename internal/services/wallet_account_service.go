@@ -17,8 +17,8 @@ type walletAccount service
 var _ WalletAccountService = (*walletAccount)(nil)
 
 func (wa *walletAccount) CreateAccountFeature(ctx context.Context, payload models.CreateWalletIn) (out *models.WalletOut, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	// not found in config and eligible list
 	if _, ok := wa.srv.conf.AccountFeatureConfig[*payload.Feature.Preset]; !ok {
@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// capturePrecision is the number of decimal places used when splitting child transactions of a reservation
+const capturePrecision int32 = 2
+
+// captureReservedTransaction captures captureAmount of a reserved wallet transaction.
+// Child transactions of the whole reservation are split proportionally, so the sum of all captures
+// is equal to the reserved child transactions. When isFinal is true, the remaining reservation is released
+// and the wallet transaction becomes SUCCESS
+func (ts *walletTrx) captureReservedTransaction(
+	ctx context.Context,
+	req models.UpdateStatusWalletTransactionRequest,
+	captureAmount decimal.Decimal,
+	isFinal bool,
+) (*models.WalletTransaction, error) {
+	if captureAmount.IsNegative() || (captureAmount.IsZero() && !isFinal) {
+		return nil, common.ErrInvalidAmount
+	}
+
+	var walletTrx *models.WalletTransaction
+	var acuanTransactions []models.Transaction
+	var updatedBalances map[string]models.Balance
+	var currentBalances map[string]models.Balance
+
+	// see CreateTransactionAtomic for the reason of this timeout
+	maxWaitingTimeDB := 8 * time.Second
+	dbCtx, cancelDB := context.WithTimeout(ctx, maxWaitingTimeDB)
+	defer cancelDB()
+	err := ts.srv.sqlRepo.Atomic(dbCtx, func(atomicCtx context.Context, r repositories.SQLRepository) error {
+		walletTrxRepo := r.GetWalletTransactionRepository()
+
+		// lock the reservation, so concurrent captures are processed one after another
+		reserved, errAtomic := walletTrxRepo.GetByIdForUpdate(atomicCtx, req.TransactionId)
+		if errAtomic != nil {
+			return fmt.Errorf("unable to get transaction: %w", checkDatabaseError(errAtomic))
+		}
+
+		if reserved.Status != models.WalletTransactionStatusPending {
+			return common.ErrTransactionNotReserved
+		}
+
+		remaining := reserved.RemainingAmount()
+		if captureAmount.GreaterThan(remaining) {
+			return fmt.Errorf("%w: capture %s, remaining %s", common.ErrCaptureAmountExceedsRemaining, captureAmount, remaining)
+		}
+
+		total := reserved.NetAmount.ValueDecimal.Decimal
+		capturedBefore := reserved.CapturedAmount
+		capturedAfter := capturedBefore.Add(captureAmount)
+		isFinalCapture := isFinal || capturedAfter.Equal(total)
+
+		maps.Copy(reserved.Metadata, req.Metadata)
+
+		// the whole reservation is transformed, it produces the same child transactions as the ones reserved,
+		// stored child transactions are the captured ones, so they are transformed as committed
+		committed := *reserved
+		committed.Status = models.WalletTransactionStatusSuccess
+		committed.TransactionTime = req.TransactionTime
+
+		childTransactions, errAtomic := ts.newMapTransformer().Transform(atomicCtx, committed)
+		if errAtomic != nil {
+			return fmt.Errorf("unable to transform wallet transaction: %w", errAtomic)
+		}
+
+		abs, errAtomic := r.GetBalanceRepository().GetMany(atomicCtx,
+			models.GetAccountBalanceRequest{
+				AccountNumbers:               getAccountNumbersForUpdateBalance(childTransactions),
+				ForUpdate:                    true,
+				AccountNumbersExcludedFromDB: ts.srv.conf.AccountConfig.ExcludedBalanceUpdateAccountNumbers,
+			},
+		)
+		if errAtomic != nil {
+			return fmt.Errorf("unable to get current balance: %w", errAtomic)
+		}
+
+		errAtomic = validateAccountExistsInTransactions(childTransactions, abs)
+		if errAtomic != nil {
+			return errAtomic
+		}
+
+		childTransactions = updateTransactionAccountNumber(childTransactions, abs)
+		currentBalances = models.ConvertToBalanceMap(abs)
+
+		updatedBalances = make(map[string]models.Balance)
+		maps.Copy(updatedBalances, currentBalances)
+
+		calculateCapture := getWalletBalanceCaptureCalculator(reserved.TransactionFlow)
+		var capturedChildTransactions []models.TransactionReq
+		var fromAccounts []string
+		for _, ct := range childTransactions {
+			captureChild := proportionalShare(ct.Amount.Decimal, total, capturedBefore, capturedAfter)
+
+			releaseChild := decimal.Zero
+			if isFinalCapture {
+				releaseChild = ct.Amount.Decimal.Sub(proportionalShare(ct.Amount.Decimal, total, decimal.Zero, capturedAfter))
+			}
+
+			if captureChild.IsZero() && releaseChild.IsZero() {
+				continue
+			}
+
+			trxSet := models.NewWalletTransactionSet(ct.FromAccount, ct.ToAccount, captureChild, ct.TypeTransaction)
+			updatedBalances, errAtomic = calculateCapture(atomicCtx, trxSet, releaseChild, updatedBalances)
+			if errAtomic != nil {
+				return fmt.Errorf("calculate balance failed: %w", errAtomic)
+			}
+
+			fromAccounts = append(fromAccounts, ct.FromAccount)
+
+			if !captureChild.IsZero() {
+				ct.Amount = decimal.NewNullDecimal(captureChild)
+				capturedChildTransactions = append(capturedChildTransactions, ct)
+			}
+		}
+
+		// HVT update of each capture is told apart by the captured amount after it
+		captured := *reserved
+		captured.CapturedAmount = capturedAfter
+
+		errAtomic = ts.updateReservedBalances(atomicCtx, r.GetAccountRepository(), captured, currentBalances, updatedBalances, fromAccounts)
+		if errAtomic != nil {
+			return errAtomic
+		}
+
+		errAtomic = walletTrxRepo.CreateBalances(atomicCtx, models.NewWalletTransactionBalances(reserved.ID, currentBalances, updatedBalances))
+		if errAtomic != nil {
+			return fmt.Errorf("unable to store balance snapshot: %w", errAtomic)
+		}
+
+		nextStatus := models.WalletTransactionStatusPending
+		if isFinalCapture {
+			nextStatus = models.WalletTransactionStatusSuccess
+		}
+
+		walletTrx, errAtomic = walletTrxRepo.Update(atomicCtx, req.TransactionId, models.WalletTransactionUpdate{
+			Status:          &nextStatus,
+			TransactionTime: &req.TransactionTime,
+			Metadata:        &reserved.Metadata,
+			CapturedAmount:  &capturedAfter,
+		})
+		if errAtomic != nil {
+			return fmt.Errorf("unable to update status: %w", errAtomic)
+		}
+
+		acuanTransactions, errAtomic = ts.insertChildTransactions(atomicCtx, r.GetTransactionRepository(), capturedChildTransactions)
+		if errAtomic != nil {
+			return fmt.Errorf("unable to store acuan transaction: %w", errAtomic)
+		}
+
+		// success is notified once with every captured child transaction, including the ones of previous captures
+		if isFinalCapture && capturedBefore.IsPositive() {
+			acuanTransactions, errAtomic = r.GetTransactionRepository().GetByWalletTransactionID(atomicCtx, reserved.ID, reserved.RefNumber)
+			if errAtomic != nil {
+				return fmt.Errorf("unable to get captured acuan transactions: %w", errAtomic)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// partial capture keeps the reservation pending, only the captured amount is moved
+	business := ts.srv.metrics.GetBusinessPrometheus()
+	if walletTrx.Status != models.WalletTransactionStatusPending {
+		business.RecordWalletTransaction(*walletTrx, req.ClientId)
+	}
+	business.RecordAmountMoved(*walletTrx, captureAmount)
+
+	// reservation which is still pending is not notified as success, it is notified when the final capture is done
+	if walletTrx.Status != models.WalletTransactionStatusSuccess || len(acuanTransactions) == 0 {
+		return walletTrx, nil
+	}
+
+	// see CreateTransactionAtomic for the reason of this timeout
+	maxWaitingTimeKafka := 7 * time.Second
+	kafkaCtx, cancelKafka := context.WithTimeout(context.WithoutCancel(ctx), maxWaitingTimeKafka)
+	defer cancelKafka()
+	err = ts.publishNotificationCreateWalletTransactionSuccess(
+		kafkaCtx,
+		*walletTrx,
+		acuanTransactions,
+		currentBalances,
+		updatedBalances,
+		req.ClientId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to publish notification: %w", err)
+	}
+
+	return walletTrx, nil
+}
+
+// increaseReservedTransaction tops up a reserved wallet transaction which has not been captured yet.
+// The breakdown amounts are increased proportionally to the net amount
+func (ts *walletTrx) increaseReservedTransaction(ctx context.Context, req models.UpdateStatusWalletTransactionRequest) (*models.WalletTransaction, error) {
+	if req.Amount == nil || req.Amount.ValueDecimal.LessThanOrEqual(decimal.Zero) {
+		return nil, common.ErrInvalidAmount
+	}
+
+	increaseAmount := req.Amount.ValueDecimal.Decimal
+
+	var walletTrx *models.WalletTransaction
+
+	// see CreateTransactionAtomic for the reason of this timeout
+	maxWaitingTimeDB := 8 * time.Second
+	dbCtx, cancelDB := context.WithTimeout(ctx, maxWaitingTimeDB)
+	defer cancelDB()
+	err := ts.srv.sqlRepo.Atomic(dbCtx, func(atomicCtx context.Context, r repositories.SQLRepository) error {
+		walletTrxRepo := r.GetWalletTransactionRepository()
+
+		reserved, errAtomic := walletTrxRepo.GetByIdForUpdate(atomicCtx, req.TransactionId)
+		if errAtomic != nil {
+			return fmt.Errorf("unable to get transaction: %w", checkDatabaseError(errAtomic))
+		}
+
+		if reserved.Status != models.WalletTransactionStatusPending {
+			return common.ErrTransactionNotReserved
+		}
+
+		// captured child transactions are split by the reserved amount, changing it afterward breaks the split
+		if !reserved.CapturedAmount.IsZero() {
+			return common.ErrReservedTransactionAlreadyCaptured
+		}
+
+		total := reserved.NetAmount.ValueDecimal.Decimal
+		increment := *reserved
+		increment.NetAmount.ValueDecimal = models.NewDecimalFromExternal(increaseAmount)
+		increment.Amounts = scaleAmounts(reserved.Amounts, increaseAmount, total)
+
+		childTransactions, errAtomic := ts.newMapTransformer().Transform(atomicCtx, increment)
+		if errAtomic != nil {
+			return fmt.Errorf("unable to transform wallet transaction: %w", errAtomic)
+		}
+
+		abs, errAtomic := r.GetBalanceRepository().GetMany(atomicCtx,
+			models.GetAccountBalanceRequest{
+				AccountNumbers:               getAccountNumbersForUpdateBalance(childTransactions),
+				ForUpdate:                    true,
+				AccountNumbersExcludedFromDB: ts.srv.conf.AccountConfig.ExcludedBalanceUpdateAccountNumbers,
+			},
+		)
+		if errAtomic != nil {
+			return fmt.Errorf("unable to get current balance: %w", errAtomic)
+		}
+
+		errAtomic = validateAccountExistsInTransactions(childTransactions, abs)
+		if errAtomic != nil {
+			return errAtomic
+		}
+
+		childTransactions = updateTransactionAccountNumber(childTransactions, abs)
+		currentBalances := models.ConvertToBalanceMap(abs)
+		balances := models.ConvertToBalanceMap(abs)
+
+		calculateIncrease := getWalletBalanceIncreaseReservationCalculator(reserved.TransactionFlow)
+		var fromAccounts []string
+		for _, ct := range childTransactions {
+			trxSet := models.NewWalletTransactionSet(ct.FromAccount, ct.ToAccount, ct.Amount.Decimal, ct.TypeTransaction)
+
+			balances, errAtomic = calculateIncrease(atomicCtx, trxSet, balances)
+			if errAtomic != nil {
+				return fmt.Errorf("calculate balance failed: %w", errAtomic)
+			}
+
+			fromAccounts = append(fromAccounts, ct.FromAccount)
+		}
+
+		// only the reserved accounts are changed, the same as creating reserved transaction
+		accRepo := r.GetAccountRepository()
+		for accountNumber, balance := range balances {
+			if balance.IsSkipBalanceUpdateOnDB() || !slices.Contains(fromAccounts, accountNumber) {
+				continue
+			}
+
+			_, errAtomic = accRepo.UpdateAccountBalance(atomicCtx, accountNumber, balance)
+			if errAtomic != nil {
+				return fmt.Errorf("unable to update balance: %w", errAtomic)
+			}
+		}
+
+		errAtomic = walletTrxRepo.CreateBalances(atomicCtx, models.NewWalletTransactionBalances(reserved.ID, currentBalances, balances))
+		if errAtomic != nil {
+			return fmt.Errorf("unable to store balance snapshot: %w", errAtomic)
+		}
+
+		netAmount := reserved.NetAmount
+		netAmount.ValueDecimal = models.NewDecimalFromExternal(total.Add(increaseAmount))
+		amounts := addAmounts(reserved.Amounts, increment.Amounts)
+
+		maps.Copy(reserved.Metadata, req.Metadata)
+
+		walletTrx, errAtomic = walletTrxRepo.Update(atomicCtx, req.TransactionId, models.WalletTransactionUpdate{
+			Metadata:  &reserved.Metadata,
+			NetAmount: &netAmount,
+			Amounts:   &amounts,
+		})
+		if errAtomic != nil {
+			return fmt.Errorf("unable to update reserved amount: %w", errAtomic)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return walletTrx, nil
+}
+
+// proportionalShare returns part of amount which belongs to the captured range (from, to] of total.
+// Cumulative shares are rounded instead of each share, so shares of consecutive ranges always add up to amount
+func proportionalShare(amount, total, from, to decimal.Decimal) decimal.Decimal {
+	return cumulativeShare(amount, total, to).Sub(cumulativeShare(amount, total, from))
+}
+
+func cumulativeShare(amount, total, upTo decimal.Decimal) decimal.Decimal {
+	if upTo.GreaterThanOrEqual(total) {
+		return amount
+	}
+
+	if upTo.LessThanOrEqual(decimal.Zero) || total.IsZero() {
+		return decimal.Zero
+	}
+
+	return amount.Mul(upTo).Div(total).Round(capturePrecision)
+}
+
+// scaleAmounts returns breakdown amounts multiplied by part/total. Their sum is scaled and rounded once, the last
+// breakdown takes what is left of it so rounding each one does not change the sum
+func scaleAmounts(amounts models.Amounts, part, total decimal.Decimal) models.Amounts {
+	if total.IsZero() {
+		return amounts
+	}
+
+	last := -1
+	sum := decimal.Zero
+	for i, a := range amounts {
+		if a.Amount != nil {
+			last = i
+			sum = sum.Add(a.Amount.ValueDecimal.Decimal)
+		}
+	}
+
+	scaled := make(models.Amounts, 0, len(amounts))
+	remainder := sum.Mul(part).Div(total).Round(capturePrecision)
+	for i, a := range amounts {
+		if a.Amount == nil {
+			scaled = append(scaled, a)
+			continue
+		}
+
+		value := remainder
+		if i != last {
+			value = a.Amount.ValueDecimal.Mul(part).Div(total).Round(capturePrecision)
+			remainder = remainder.Sub(value)
+		}
+
+		amount := *a.Amount
+		amount.ValueDecimal = models.NewDecimalFromExternal(value)
+		scaled = append(scaled, models.AmountDetail{Type: a.Type, Amount: &amount})
+	}
+
+	return scaled
+}
+
+// addAmounts sums breakdown amounts with the same position, both must come from the same wallet transaction
+func addAmounts(amounts, additions models.Amounts) models.Amounts {
+	sum := make(models.Amounts, 0, len(amounts))
+	for i, a := range amounts {
+		if a.Amount == nil || i >= len(additions) || additions[i].Amount == nil {
+			sum = append(sum, a)
+			continue
+		}
+
+		amount := *a.Amount
+		amount.ValueDecimal = models.NewDecimalFromExternal(a.Amount.ValueDecimal.Add(additions[i].Amount.ValueDecimal.Decimal))
+		sum = append(sum, models.AmountDetail{Type: a.Type, Amount: &amount})
+	}
+
+	return sum
+}
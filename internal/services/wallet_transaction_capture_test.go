@@ -0,0 +1,632 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
+	mockRepo "bitbucket.org/Amartha/go-fp-transaction/internal/repositories/mock"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_WalletTrxService_ProcessReservedTransaction_PartialCapture(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+	reservedAmount := decimal.NewFromInt(10000)
+
+	// actual: 90000, pending: 10000 (reserved)
+	accountBalances := func() []models.AccountBalance {
+		return []models.AccountBalance{
+			{
+				AccountNumber: "111",
+				Balance:       models.NewBalance(decimal.NewFromInt(90000), decimal.NewFromInt(10000)),
+			},
+			{
+				AccountNumber: "222",
+				Balance:       models.NewBalance(decimal.Zero, decimal.Zero),
+			},
+		}
+	}
+
+	reservedTrx := func(id string, captured decimal.Decimal) *models.WalletTransaction {
+		return &models.WalletTransaction{
+			ID:                       id,
+			TransactionType:          "ITRTF",
+			AccountNumber:            "111",
+			DestinationAccountNumber: "222",
+			Status:                   models.WalletTransactionStatusPending,
+			NetAmount: models.Amount{
+				ValueDecimal: models.NewDecimalFromExternal(reservedAmount),
+			},
+			TransactionFlow: models.TransactionFlowTransfer,
+			Metadata:        models.WalletMetadata{},
+			CapturedAmount:  captured,
+		}
+	}
+
+	// capturedTransactions returns child transactions stored by each capture of the reservation
+	capturedTransactions := func(amounts ...int64) []models.Transaction {
+		var trx []models.Transaction
+		for _, amount := range amounts {
+			trx = append(trx, models.Transaction{
+				TransactionID: uuid.New().String(),
+				FromAccount:   "111",
+				ToAccount:     "222",
+				Amount:        decimal.NewNullDecimal(decimal.NewFromInt(amount)),
+				Status:        "1",
+			})
+		}
+		return trx
+	}
+
+	newAtomicHelper := func() *testServiceHelper {
+		sqlRepo := mockRepo.NewMockSQLRepository(testHelper.mockCtrl)
+		accRepo := mockRepo.NewMockAccountRepository(testHelper.mockCtrl)
+		balanceRepo := mockRepo.NewMockBalanceRepository(testHelper.mockCtrl)
+		walletTrxRepo := mockRepo.NewMockWalletTransactionRepository(testHelper.mockCtrl)
+		acuanRepo := mockRepo.NewMockTransactionRepository(testHelper.mockCtrl)
+
+		sqlRepo.EXPECT().GetAccountRepository().Return(accRepo).AnyTimes()
+		sqlRepo.EXPECT().GetWalletTransactionRepository().Return(walletTrxRepo).AnyTimes()
+		sqlRepo.EXPECT().GetTransactionRepository().Return(acuanRepo).AnyTimes()
+		sqlRepo.EXPECT().GetBalanceRepository().Return(balanceRepo).AnyTimes()
+
+		return &testServiceHelper{
+			mockAccRepository:       accRepo,
+			mockBalanceRepository:   balanceRepo,
+			mockWalletTrxRepository: walletTrxRepo,
+			mockSQLRepository:       sqlRepo,
+			mockTrxRepository:       acuanRepo,
+		}
+	}
+
+	// expectBalances asserts balances stored for each account after the process
+	expectBalances := func(h *testServiceHelper, want map[string]models.Balance) {
+		h.mockAccRepository.EXPECT().
+			UpdateAccountBalance(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, accountNumber string, balance models.Balance) (*models.Balance, error) {
+				wantBalance, ok := want[accountNumber]
+				if assert.True(t, ok, "unexpected balance update of %s", accountNumber) {
+					assert.True(t, wantBalance.Actual().Equal(balance.Actual()), "actual balance of %s: %s", accountNumber, balance.Actual())
+					assert.True(t, wantBalance.Pending().Equal(balance.Pending()), "pending balance of %s: %s", accountNumber, balance.Pending())
+				}
+				return &balance, nil
+			}).
+			Times(len(want))
+		h.mockWalletTrxRepository.EXPECT().
+			CreateBalances(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, balances []models.WalletTransactionBalance) error {
+				for _, b := range balances {
+					wantBalance := want[b.AccountNumber]
+					assert.True(t, wantBalance.Actual().Equal(b.After.Actual()), "snapshot actual balance of %s: %s", b.AccountNumber, b.After.Actual())
+					assert.True(t, wantBalance.Pending().Equal(b.After.Pending()), "snapshot pending balance of %s: %s", b.AccountNumber, b.After.Pending())
+				}
+				return nil
+			})
+	}
+
+	type args struct {
+		req           models.UpdateStatusWalletTransactionRequest
+		capturedSoFar decimal.Decimal
+	}
+
+	tests := []struct {
+		name    string
+		args    args
+		doMock  func(args args)
+		wantErr error
+	}{
+		{
+			name: "success capture first tranche and keep the rest reserved",
+			args: args{
+				req: models.UpdateStatusWalletTransactionRequest{
+					TransactionId: "trx-1",
+					Action:        models.TransactionRequestCommitStatus,
+					Amount:        &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(4000))},
+				},
+				capturedSoFar: decimal.Zero,
+			},
+			doMock: func(args args) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						h := newAtomicHelper()
+						h.mockWalletTrxRepository.EXPECT().GetByIdForUpdate(gomock.Any(), args.req.TransactionId).
+							Return(reservedTrx(args.req.TransactionId, args.capturedSoFar), nil)
+						h.mockBalanceRepository.EXPECT().GetMany(gomock.Any(), gomock.Any()).Return(accountBalances(), nil)
+						expectBalances(h, map[string]models.Balance{
+							"111": models.NewBalance(decimal.NewFromInt(86000), decimal.NewFromInt(6000)),
+							"222": models.NewBalance(decimal.NewFromInt(4000), decimal.Zero),
+						})
+						h.mockWalletTrxRepository.EXPECT().Update(gomock.Any(), args.req.TransactionId, gomock.Any()).
+							DoAndReturn(func(_ context.Context, _ string, update models.WalletTransactionUpdate) (*models.WalletTransaction, error) {
+								assert.Equal(t, models.WalletTransactionStatusPending, *update.Status)
+								assert.True(t, decimal.NewFromInt(4000).Equal(*update.CapturedAmount))
+								return reservedTrx(args.req.TransactionId, *update.CapturedAmount), nil
+							})
+						h.mockTrxRepository.EXPECT().StoreBulkTransaction(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(_ context.Context, trx []*models.Transaction) error {
+								if assert.Len(t, trx, 1) {
+									assert.True(t, decimal.NewFromInt(4000).Equal(trx[0].Amount.Decimal))
+									assert.Equal(t, "111", trx[0].FromAccount)
+									assert.Equal(t, "222", trx[0].ToAccount)
+								}
+								return nil
+							})
+
+						return steps(ctx, h.mockSQLRepository)
+					})
+				// reservation is still pending, success is notified by the final capture
+			},
+		},
+		{
+			name: "success final capture releases the remaining reservation",
+			args: args{
+				req: models.UpdateStatusWalletTransactionRequest{
+					TransactionId:  "trx-2",
+					Action:         models.TransactionRequestCommitStatus,
+					Amount:         &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(4000))},
+					IsFinalCapture: true,
+				},
+				capturedSoFar: decimal.Zero,
+			},
+			doMock: func(args args) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						h := newAtomicHelper()
+						h.mockWalletTrxRepository.EXPECT().GetByIdForUpdate(gomock.Any(), args.req.TransactionId).
+							Return(reservedTrx(args.req.TransactionId, args.capturedSoFar), nil)
+						h.mockBalanceRepository.EXPECT().GetMany(gomock.Any(), gomock.Any()).Return(accountBalances(), nil)
+						expectBalances(h, map[string]models.Balance{
+							"111": models.NewBalance(decimal.NewFromInt(86000), decimal.Zero),
+							"222": models.NewBalance(decimal.NewFromInt(4000), decimal.Zero),
+						})
+						h.mockWalletTrxRepository.EXPECT().Update(gomock.Any(), args.req.TransactionId, gomock.Any()).
+							DoAndReturn(func(_ context.Context, _ string, update models.WalletTransactionUpdate) (*models.WalletTransaction, error) {
+								assert.Equal(t, models.WalletTransactionStatusSuccess, *update.Status)
+								assert.True(t, decimal.NewFromInt(4000).Equal(*update.CapturedAmount))
+								return reservedTrx(args.req.TransactionId, *update.CapturedAmount), nil
+							})
+						h.mockTrxRepository.EXPECT().StoreBulkTransaction(gomock.Any(), gomock.Any()).Return(nil)
+
+						return steps(ctx, h.mockSQLRepository)
+					})
+				testHelper.mockTransactionNotification.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "success commit without amount captures the remaining amount",
+			args: args{
+				req: models.UpdateStatusWalletTransactionRequest{
+					TransactionId: "trx-3",
+					Action:        models.TransactionRequestCommitStatus,
+				},
+				capturedSoFar: decimal.NewFromInt(4000),
+			},
+			doMock: func(args args) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						h := newAtomicHelper()
+						h.mockWalletTrxRepository.EXPECT().GetByIdForUpdate(gomock.Any(), args.req.TransactionId).
+							Return(reservedTrx(args.req.TransactionId, args.capturedSoFar), nil)
+						h.mockBalanceRepository.EXPECT().GetMany(gomock.Any(), gomock.Any()).Return([]models.AccountBalance{
+							{AccountNumber: "111", Balance: models.NewBalance(decimal.NewFromInt(86000), decimal.NewFromInt(6000))},
+							{AccountNumber: "222", Balance: models.NewBalance(decimal.NewFromInt(4000), decimal.Zero)},
+						}, nil)
+						expectBalances(h, map[string]models.Balance{
+							"111": models.NewBalance(decimal.NewFromInt(80000), decimal.Zero),
+							"222": models.NewBalance(decimal.NewFromInt(10000), decimal.Zero),
+						})
+						h.mockWalletTrxRepository.EXPECT().Update(gomock.Any(), args.req.TransactionId, gomock.Any()).
+							DoAndReturn(func(_ context.Context, _ string, update models.WalletTransactionUpdate) (*models.WalletTransaction, error) {
+								assert.Equal(t, models.WalletTransactionStatusSuccess, *update.Status)
+								assert.True(t, reservedAmount.Equal(*update.CapturedAmount))
+								return reservedTrx(args.req.TransactionId, *update.CapturedAmount), nil
+							})
+						h.mockTrxRepository.EXPECT().StoreBulkTransaction(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(_ context.Context, trx []*models.Transaction) error {
+								if assert.Len(t, trx, 1) {
+									assert.True(t, decimal.NewFromInt(6000).Equal(trx[0].Amount.Decimal))
+								}
+								return nil
+							})
+						h.mockTrxRepository.EXPECT().GetByWalletTransactionID(gomock.Any(), args.req.TransactionId, gomock.Any()).
+							Return(capturedTransactions(4000, 6000), nil)
+
+						return steps(ctx, h.mockSQLRepository)
+					})
+				testHelper.mockTransactionNotification.EXPECT().Publish(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, payload models.TransactionNotificationPayload) error {
+						assert.Len(t, payload.AcuanData.Body.Data.Order.Transactions, 2)
+						return nil
+					})
+			},
+		},
+		{
+			name: "success cancel after partial capture only releases the remaining reservation",
+			args: args{
+				req: models.UpdateStatusWalletTransactionRequest{
+					TransactionId: "trx-4",
+					Action:        models.TransactionRequestCancelStatus,
+				},
+				capturedSoFar: decimal.NewFromInt(4000),
+			},
+			doMock: func(args args) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						h := newAtomicHelper()
+						h.mockWalletTrxRepository.EXPECT().GetByIdForUpdate(gomock.Any(), args.req.TransactionId).
+							Return(reservedTrx(args.req.TransactionId, args.capturedSoFar), nil)
+						h.mockBalanceRepository.EXPECT().GetMany(gomock.Any(), gomock.Any()).Return([]models.AccountBalance{
+							{AccountNumber: "111", Balance: models.NewBalance(decimal.NewFromInt(86000), decimal.NewFromInt(6000))},
+							{AccountNumber: "222", Balance: models.NewBalance(decimal.NewFromInt(4000), decimal.Zero)},
+						}, nil)
+						expectBalances(h, map[string]models.Balance{
+							"111": models.NewBalance(decimal.NewFromInt(86000), decimal.Zero),
+							"222": models.NewBalance(decimal.NewFromInt(4000), decimal.Zero),
+						})
+						h.mockWalletTrxRepository.EXPECT().Update(gomock.Any(), args.req.TransactionId, gomock.Any()).
+							DoAndReturn(func(_ context.Context, _ string, update models.WalletTransactionUpdate) (*models.WalletTransaction, error) {
+								assert.Equal(t, models.WalletTransactionStatusSuccess, *update.Status)
+								assert.True(t, decimal.NewFromInt(4000).Equal(*update.CapturedAmount))
+								return reservedTrx(args.req.TransactionId, *update.CapturedAmount), nil
+							})
+						h.mockTrxRepository.EXPECT().GetByWalletTransactionID(gomock.Any(), args.req.TransactionId, gomock.Any()).
+							Return(capturedTransactions(4000), nil)
+
+						return steps(ctx, h.mockSQLRepository)
+					})
+				testHelper.mockTransactionNotification.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "failed - capture more than remaining amount",
+			args: args{
+				req: models.UpdateStatusWalletTransactionRequest{
+					TransactionId: "trx-5",
+					Action:        models.TransactionRequestCommitStatus,
+					Amount:        &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(10001))},
+				},
+				capturedSoFar: decimal.Zero,
+			},
+			doMock: func(args args) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						h := newAtomicHelper()
+						h.mockWalletTrxRepository.EXPECT().GetByIdForUpdate(gomock.Any(), args.req.TransactionId).
+							Return(reservedTrx(args.req.TransactionId, args.capturedSoFar), nil)
+
+						return steps(ctx, h.mockSQLRepository)
+					})
+			},
+			wantErr: common.ErrCaptureAmountExceedsRemaining,
+		},
+		{
+			// the first read still sees nothing captured, but another capture has been committed
+			// before the row lock is acquired, so the locked row is the one which is validated
+			name: "failed - concurrent capture already took the remaining amount",
+			args: args{
+				req: models.UpdateStatusWalletTransactionRequest{
+					TransactionId: "trx-6",
+					Action:        models.TransactionRequestCommitStatus,
+					Amount:        &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(6000))},
+				},
+				capturedSoFar: decimal.Zero,
+			},
+			doMock: func(args args) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						h := newAtomicHelper()
+						h.mockWalletTrxRepository.EXPECT().GetByIdForUpdate(gomock.Any(), args.req.TransactionId).
+							Return(reservedTrx(args.req.TransactionId, decimal.NewFromInt(5000)), nil)
+
+						return steps(ctx, h.mockSQLRepository)
+					})
+			},
+			wantErr: common.ErrCaptureAmountExceedsRemaining,
+		},
+		{
+			name: "failed - concurrent final capture already finished the reservation",
+			args: args{
+				req: models.UpdateStatusWalletTransactionRequest{
+					TransactionId: "trx-7",
+					Action:        models.TransactionRequestCommitStatus,
+					Amount:        &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(1000))},
+				},
+				capturedSoFar: decimal.Zero,
+			},
+			doMock: func(args args) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						h := newAtomicHelper()
+						finished := reservedTrx(args.req.TransactionId, decimal.NewFromInt(4000))
+						finished.Status = models.WalletTransactionStatusSuccess
+						h.mockWalletTrxRepository.EXPECT().GetByIdForUpdate(gomock.Any(), args.req.TransactionId).
+							Return(finished, nil)
+
+						return steps(ctx, h.mockSQLRepository)
+					})
+			},
+			wantErr: common.ErrTransactionNotReserved,
+		},
+		{
+			name: "failed - negative capture amount",
+			args: args{
+				req: models.UpdateStatusWalletTransactionRequest{
+					TransactionId: "trx-8",
+					Action:        models.TransactionRequestCommitStatus,
+					Amount:        &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(-1))},
+				},
+				capturedSoFar: decimal.Zero,
+			},
+			wantErr: common.ErrInvalidAmount,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			testHelper.mockWalletTrxRepository.EXPECT().
+				GetById(gomock.Any(), tt.args.req.TransactionId).
+				Return(reservedTrx(tt.args.req.TransactionId, tt.args.capturedSoFar), nil)
+			testHelper.mockFlagClient.EXPECT().
+				IsEnabled(testHelper.config.FeatureFlagKeyLookup.UseAccountConfigFromExternal).
+				Return(false).
+				AnyTimes()
+
+			if tt.doMock != nil {
+				tt.doMock(tt.args)
+			}
+
+			_, err := testHelper.walletTrxService.ProcessReservedTransaction(context.Background(), tt.args.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_WalletTrxService_ProcessReservedTransaction_Increase(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	reservedTrx := func(id string, captured decimal.Decimal) *models.WalletTransaction {
+		return &models.WalletTransaction{
+			ID:                       id,
+			TransactionType:          "ITRTF",
+			AccountNumber:            "111",
+			DestinationAccountNumber: "222",
+			Status:                   models.WalletTransactionStatusPending,
+			NetAmount: models.Amount{
+				ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(10000)),
+			},
+			Amounts: models.Amounts{
+				{Type: "ITRTF", Amount: &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.RequireFromString("33.33"))}},
+				{Type: "ITRTF", Amount: &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.RequireFromString("33.33"))}},
+				{Type: "ITRTF", Amount: &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.RequireFromString("33.34"))}},
+			},
+			TransactionFlow: models.TransactionFlowTransfer,
+			Metadata:        models.WalletMetadata{},
+			CapturedAmount:  captured,
+		}
+	}
+
+	increaseReq := func(id string, amount int64) models.UpdateStatusWalletTransactionRequest {
+		return models.UpdateStatusWalletTransactionRequest{
+			TransactionId: id,
+			Action:        models.TransactionRequestIncreaseStatus,
+			Amount:        &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(amount))},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		req      models.UpdateStatusWalletTransactionRequest
+		captured decimal.Decimal
+		doMock   func(req models.UpdateStatusWalletTransactionRequest, captured decimal.Decimal)
+		wantErr  error
+	}{
+		{
+			name:     "success increase reservation",
+			req:      increaseReq("trx-1", 5000),
+			captured: decimal.Zero,
+			doMock: func(req models.UpdateStatusWalletTransactionRequest, captured decimal.Decimal) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						sqlRepo := mockRepo.NewMockSQLRepository(testHelper.mockCtrl)
+						accRepo := mockRepo.NewMockAccountRepository(testHelper.mockCtrl)
+						balanceRepo := mockRepo.NewMockBalanceRepository(testHelper.mockCtrl)
+						walletTrxRepo := mockRepo.NewMockWalletTransactionRepository(testHelper.mockCtrl)
+						sqlRepo.EXPECT().GetAccountRepository().Return(accRepo).AnyTimes()
+						sqlRepo.EXPECT().GetWalletTransactionRepository().Return(walletTrxRepo).AnyTimes()
+						sqlRepo.EXPECT().GetBalanceRepository().Return(balanceRepo).AnyTimes()
+
+						walletTrxRepo.EXPECT().GetByIdForUpdate(gomock.Any(), req.TransactionId).Return(reservedTrx(req.TransactionId, captured), nil)
+						balanceRepo.EXPECT().GetMany(gomock.Any(), gomock.Any()).Return([]models.AccountBalance{
+							{AccountNumber: "111", Balance: models.NewBalance(decimal.NewFromInt(90000), decimal.NewFromInt(10000))},
+							{AccountNumber: "222", Balance: models.NewBalance(decimal.Zero, decimal.Zero)},
+						}, nil)
+
+						// only the reserved account is updated
+						accRepo.EXPECT().UpdateAccountBalance(gomock.Any(), "111", gomock.Any()).
+							DoAndReturn(func(_ context.Context, _ string, balance models.Balance) (*models.Balance, error) {
+								assert.True(t, decimal.RequireFromString("15050").Equal(balance.Pending()))
+								assert.True(t, decimal.NewFromInt(90000).Equal(balance.Actual()))
+								return &balance, nil
+							})
+						walletTrxRepo.EXPECT().CreateBalances(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(_ context.Context, balances []models.WalletTransactionBalance) error {
+								if assert.Len(t, balances, 1) {
+									assert.Equal(t, "111", balances[0].AccountNumber)
+									assert.True(t, decimal.NewFromInt(10000).Equal(balances[0].Before.Pending()))
+									assert.True(t, decimal.RequireFromString("15050").Equal(balances[0].After.Pending()))
+								}
+								return nil
+							})
+						walletTrxRepo.EXPECT().Update(gomock.Any(), req.TransactionId, gomock.Any()).
+							DoAndReturn(func(_ context.Context, _ string, update models.WalletTransactionUpdate) (*models.WalletTransaction, error) {
+								assert.Nil(t, update.Status)
+								assert.True(t, decimal.NewFromInt(15000).Equal(update.NetAmount.ValueDecimal.Decimal))
+
+								// each breakdown is scaled to 16.67 on its own, still they add up to half of their sum
+								sum := decimal.Zero
+								for _, a := range *update.Amounts {
+									sum = sum.Add(a.Amount.ValueDecimal.Decimal)
+								}
+								assert.True(t, decimal.NewFromInt(150).Equal(sum), "sum of breakdowns = %s", sum)
+								return reservedTrx(req.TransactionId, captured), nil
+							})
+
+						return steps(ctx, sqlRepo)
+					})
+			},
+		},
+		{
+			name:     "failed - reservation is partially captured",
+			req:      increaseReq("trx-2", 5000),
+			captured: decimal.NewFromInt(4000),
+			doMock: func(req models.UpdateStatusWalletTransactionRequest, captured decimal.Decimal) {
+				testHelper.mockSQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						sqlRepo := mockRepo.NewMockSQLRepository(testHelper.mockCtrl)
+						walletTrxRepo := mockRepo.NewMockWalletTransactionRepository(testHelper.mockCtrl)
+						sqlRepo.EXPECT().GetWalletTransactionRepository().Return(walletTrxRepo).AnyTimes()
+
+						walletTrxRepo.EXPECT().GetByIdForUpdate(gomock.Any(), req.TransactionId).Return(reservedTrx(req.TransactionId, captured), nil)
+
+						return steps(ctx, sqlRepo)
+					})
+			},
+			wantErr: common.ErrReservedTransactionAlreadyCaptured,
+		},
+		{
+			name:     "failed - missing amount",
+			req:      models.UpdateStatusWalletTransactionRequest{TransactionId: "trx-3", Action: models.TransactionRequestIncreaseStatus},
+			captured: decimal.Zero,
+			wantErr:  common.ErrInvalidAmount,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			testHelper.mockWalletTrxRepository.EXPECT().
+				GetById(gomock.Any(), tt.req.TransactionId).
+				Return(reservedTrx(tt.req.TransactionId, tt.captured), nil)
+			testHelper.mockFlagClient.EXPECT().
+				IsEnabled(testHelper.config.FeatureFlagKeyLookup.UseAccountConfigFromExternal).
+				Return(false).
+				AnyTimes()
+
+			if tt.doMock != nil {
+				tt.doMock(tt.req, tt.captured)
+			}
+
+			_, err := testHelper.walletTrxService.ProcessReservedTransaction(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_WalletTrxService_ProcessReservedTransaction_PartialCaptureIntoHVT(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	reservedTrx := func(captured decimal.Decimal) *models.WalletTransaction {
+		return &models.WalletTransaction{
+			ID:                       "trx-hvt",
+			RefNumber:                "REF-HVT",
+			TransactionType:          "ITRTF",
+			AccountNumber:            "111",
+			DestinationAccountNumber: "222",
+			Status:                   models.WalletTransactionStatusPending,
+			NetAmount: models.Amount{
+				ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(10000)),
+			},
+			TransactionFlow: models.TransactionFlowTransfer,
+			Metadata:        models.WalletMetadata{},
+			CapturedAmount:  captured,
+		}
+	}
+
+	testHelper.mockFlagClient.EXPECT().
+		IsEnabled(testHelper.config.FeatureFlagKeyLookup.UseAccountConfigFromExternal).
+		Return(false).
+		AnyTimes()
+
+	var published []models.UpdateBalanceHVTPayload
+	testHelper.mockBalanceHVTPublisher.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, message any, _ ...any) error {
+			published = append(published, message.(models.UpdateBalanceHVTPayload))
+			return nil
+		}).
+		Times(2)
+
+	capture := func(capturedSoFar decimal.Decimal, amount int64) {
+		testHelper.mockWalletTrxRepository.EXPECT().GetById(gomock.Any(), "trx-hvt").Return(reservedTrx(capturedSoFar), nil)
+		testHelper.mockSQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+				sqlRepo := mockRepo.NewMockSQLRepository(testHelper.mockCtrl)
+				accRepo := mockRepo.NewMockAccountRepository(testHelper.mockCtrl)
+				balanceRepo := mockRepo.NewMockBalanceRepository(testHelper.mockCtrl)
+				walletTrxRepo := mockRepo.NewMockWalletTransactionRepository(testHelper.mockCtrl)
+				acuanRepo := mockRepo.NewMockTransactionRepository(testHelper.mockCtrl)
+				sqlRepo.EXPECT().GetAccountRepository().Return(accRepo).AnyTimes()
+				sqlRepo.EXPECT().GetWalletTransactionRepository().Return(walletTrxRepo).AnyTimes()
+				sqlRepo.EXPECT().GetTransactionRepository().Return(acuanRepo).AnyTimes()
+				sqlRepo.EXPECT().GetBalanceRepository().Return(balanceRepo).AnyTimes()
+
+				walletTrxRepo.EXPECT().GetByIdForUpdate(gomock.Any(), "trx-hvt").Return(reservedTrx(capturedSoFar), nil)
+				balanceRepo.EXPECT().GetMany(gomock.Any(), gomock.Any()).Return([]models.AccountBalance{
+					{AccountNumber: "111", Balance: models.NewBalance(decimal.NewFromInt(90000), decimal.NewFromInt(10000).Sub(capturedSoFar))},
+					{AccountNumber: "222", Balance: models.NewBalance(capturedSoFar, decimal.Zero, models.WithHVT())},
+				}, nil)
+
+				// balance of HVT account is updated by the consumer of the published message
+				accRepo.EXPECT().UpdateAccountBalance(gomock.Any(), "111", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, balance models.Balance) (*models.Balance, error) {
+						return &balance, nil
+					})
+				walletTrxRepo.EXPECT().CreateBalances(gomock.Any(), gomock.Any()).Return(nil)
+				walletTrxRepo.EXPECT().Update(gomock.Any(), "trx-hvt", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, update models.WalletTransactionUpdate) (*models.WalletTransaction, error) {
+						return reservedTrx(*update.CapturedAmount), nil
+					})
+				acuanRepo.EXPECT().StoreBulkTransaction(gomock.Any(), gomock.Any()).Return(nil)
+
+				return steps(ctx, sqlRepo)
+			})
+
+		_, err := testHelper.walletTrxService.ProcessReservedTransaction(context.Background(), models.UpdateStatusWalletTransactionRequest{
+			TransactionId: "trx-hvt",
+			Action:        models.TransactionRequestCommitStatus,
+			Amount:        &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(amount))},
+		})
+		assert.NoError(t, err)
+	}
+
+	capture(decimal.Zero, 4000)
+	capture(decimal.NewFromInt(4000), 3000)
+
+	// both credits reach the HVT account, each capture is deduplicated on its own
+	if assert.Len(t, published, 2) {
+		assert.Equal(t, "222", published[0].AccountNumber)
+		assert.True(t, decimal.NewFromInt(4000).Equal(published[0].UpdateAmount.ValueDecimal.Decimal))
+		assert.Equal(t, "4000", published[0].CapturedAmount)
+
+		assert.Equal(t, "222", published[1].AccountNumber)
+		assert.True(t, decimal.NewFromInt(3000).Equal(published[1].UpdateAmount.ValueDecimal.Decimal))
+		assert.Equal(t, "7000", published[1].CapturedAmount)
+
+		assert.Equal(t, published[0].WalletTransactionId, published[1].WalletTransactionId)
+		assert.Equal(t, published[0].RefNumber, published[1].RefNumber)
+	}
+}
@@ -2,18 +2,24 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/metrics"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/common/publisher"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/monitoring"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/services/transformer"
 
+	xlog "bitbucket.org/Amartha/go-x/log"
+
+	"github.com/google/uuid"
 	"github.com/hashicorp/go-multierror"
 	"github.com/shopspring/decimal"
 	"golang.org/x/exp/maps"
@@ -23,12 +29,35 @@ import (
 type WalletTrxService interface {
 	CreateTransaction(ctx context.Context, in models.CreateWalletTransactionRequest) (*models.WalletTransaction, error)
 	EnqueueTransaction(ctx context.Context, in models.CreateWalletTransactionRequest) (*models.WalletTransaction, error)
+
+	// ProcessAsyncTransaction create the enqueued transaction and record its outcome. Unexpected error leaves
+	// the transaction processing so the message can be retried, rejected transaction is finished as failed
+	ProcessAsyncTransaction(ctx context.Context, in models.CreateWalletTransactionRequest) (*models.WalletTransaction, error)
+
+	// FailAsyncTransaction finish the enqueued transaction as failed once its message is sent to DLQ,
+	// transaction which is already finished is kept as is
+	FailAsyncTransaction(ctx context.Context, in models.CreateWalletTransactionRequest, cause error) error
+
+	// SendAsyncCallbacks send the pending callbacks of finished async transactions to the clients
+	SendAsyncCallbacks(ctx context.Context) (sent int, err error)
+
+	// GetAsyncTransaction return the enqueued transaction, it is not found when it is submitted by another client
+	GetAsyncTransaction(ctx context.Context, id, clientId string) (*models.AsyncWalletTransaction, error)
+
 	ProcessReservedTransaction(ctx context.Context, req models.UpdateStatusWalletTransactionRequest) (*models.WalletTransaction, error)
 	List(ctx context.Context, opts models.WalletTrxFilterOptions) (transactions []models.WalletTransaction, total int, err error)
+	GetDetail(ctx context.Context, req models.DoGetWalletTransactionDetailRequest) (detail *models.WalletTransactionDetail, err error)
 }
 
 type walletTrx service
 
+var logMessageAsyncWalletTransaction = "[ASYNC-WALLET-TRANSACTION]"
+
+const (
+	asyncCallbackBatchSize          = 100
+	defaultAsyncCallbackMaxAttempts = 10
+)
+
 var _ WalletTrxService = (*walletTrx)(nil)
 
 // CreateTransaction will process request for new wallet transaction
@@ -36,8 +65,8 @@ var _ WalletTrxService = (*walletTrx)(nil)
 func (ts *walletTrx) CreateTransaction(ctx context.Context, in models.CreateWalletTransactionRequest) (*models.WalletTransaction, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	trx, err := ts.validateTransactionTypeAndRefNumber(ctx, in)
 	if err != nil {
@@ -51,6 +80,7 @@ func (ts *walletTrx) CreateTransaction(ctx context.Context, in models.CreateWall
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
 
+	// the net amount is adjusted before the request is enqueued, the consumer stores the enqueued amount as it is
 	lceRolloutFlag := ts.srv.flag.IsEnabled(ts.srv.conf.FeatureFlagKeyLookup.LceRollout)
 	if slices.Contains(models.AllowedTransactionTypesForLceRollout, in.TransactionType) && lceRolloutFlag {
 		for _, transactionAmount := range in.Amounts {
@@ -65,6 +95,11 @@ func (ts *walletTrx) CreateTransaction(ctx context.Context, in models.CreateWall
 		return ts.EnqueueTransaction(ctx, in)
 	}
 
+	return ts.createTransaction(ctx, in)
+}
+
+// createTransaction store the validated and adjusted request, it is shared by synchronous request and the consumer of enqueued request
+func (ts *walletTrx) createTransaction(ctx context.Context, in models.CreateWalletTransactionRequest) (*models.WalletTransaction, error) {
 	return ts.CreateTransactionAtomic(ctx, in.ToNewWalletTransaction(), in.IsReserved, true, in.ClientId)
 }
 
@@ -78,6 +113,20 @@ func (ts *walletTrx) getAccountConfigRepository() repositories.AccountConfigRepo
 	return ts.srv.sqlRepo.GetAccountConfigInternalRepository()
 }
 
+func (ts *walletTrx) newMapTransformer() transformer.MapTransformer {
+	return transformer.NewMapTransformer(
+		ts.srv.conf,
+		ts.srv.masterDataRepo,
+		ts.srv.accountingClient,
+		ts.srv.sqlRepo.GetAccountRepository(),
+		ts.srv.sqlRepo.GetTransactionRepository(),
+		ts.getAccountConfigRepository(),
+		ts.srv.sqlRepo.GetWalletTransactionRepository(),
+		ts.srv.flag,
+		ts.srv.Tax,
+	)
+}
+
 func (ts *walletTrx) CreateTransactionAtomic(ctx context.Context, nwt models.NewWalletTransaction, isReserved, isPublish bool, clientID string) (*models.WalletTransaction, error) {
 	// assume that the handler timeout is 16 seconds
 	// maxWaitingTimeDB is the maximum time to wait for database operations to complete, usually it should be less than 8 seconds
@@ -92,17 +141,7 @@ func (ts *walletTrx) CreateTransactionAtomic(ctx context.Context, nwt models.New
 	var currentBalances map[string]models.Balance
 	var hvtPayloadsToPublish []models.UpdateBalanceHVTPayload
 
-	mapTransformer := transformer.NewMapTransformer(
-		ts.srv.conf,
-		ts.srv.masterDataRepo,
-		ts.srv.accountingClient,
-		ts.srv.sqlRepo.GetAccountRepository(),
-		ts.srv.sqlRepo.GetTransactionRepository(),
-		ts.getAccountConfigRepository(),
-		ts.srv.sqlRepo.GetWalletTransactionRepository(),
-		ts.srv.flag,
-	)
-	childTransactions, err := mapTransformer.Transform(ctx, nwt.ToWalletTransaction())
+	childTransactions, err := ts.newMapTransformer().Transform(ctx, nwt.ToWalletTransaction())
 	if err != nil {
 		return nil, fmt.Errorf("unable to transform wallet transaction: %w", err)
 	}
@@ -199,6 +238,11 @@ func (ts *walletTrx) CreateTransactionAtomic(ctx context.Context, nwt models.New
 			return fmt.Errorf("unable to create wallet transaction: %w", errAtomic)
 		}
 
+		errAtomic = walletTrxRepo.CreateBalances(atomicCtx, models.NewWalletTransactionBalances(created.ID, currentBalances, updatedBalances))
+		if errAtomic != nil {
+			return fmt.Errorf("unable to store balance snapshot: %w", errAtomic)
+		}
+
 		if !isReserved {
 			acuanTransactions, errAtomic = ts.insertChildTransactions(atomicCtx, acuanTrxRepo, childTransactions)
 			if errAtomic != nil {
@@ -209,9 +253,16 @@ func (ts *walletTrx) CreateTransactionAtomic(ctx context.Context, nwt models.New
 		return nil
 	})
 	if err != nil {
+		ts.recordRejection(nwt.TransactionType, nwt.TransactionFlow, clientID, err)
 		return created, err
 	}
 
+	business := ts.srv.metrics.GetBusinessPrometheus()
+	business.RecordWalletTransaction(*created, clientID)
+	if !isReserved {
+		business.RecordAmountMoved(*created, created.NetAmount.ValueDecimal.Decimal)
+	}
+
 	// maxWaitingTimeKafka is the maximum time to wait for kafka publish to complete, kafka client has been set to 2 seconds
 	// so we set the max waiting time to be longer than that
 	// please be aware that this timeout is associated with the kafka client timeout
@@ -221,8 +272,9 @@ func (ts *walletTrx) CreateTransactionAtomic(ctx context.Context, nwt models.New
 		postCommitErrors = multierror.Append(postCommitErrors, ts.enrichTransactionsWithEntityData(ctx, acuanTransactions))
 	}
 
+	// the request ctx is not cancelled but its values are kept, so the published messages have the trace and correlation id
 	maxWaitingTimeKafka := 7 * time.Second
-	kafkaCtx, cancelKafka := context.WithTimeout(context.Background(), maxWaitingTimeKafka)
+	kafkaCtx, cancelKafka := context.WithTimeout(context.WithoutCancel(ctx), maxWaitingTimeKafka)
 	defer cancelKafka()
 
 	for _, payload := range hvtPayloadsToPublish {
@@ -270,15 +322,16 @@ func (ts *walletTrx) enrichTransactionsWithEntityData(ctx context.Context, trans
 	return nil
 }
 
-// ProcessReservedTransaction will process wallet transaction to COMMIT or CANCEL
+// ProcessReservedTransaction will process wallet transaction to COMMIT or CANCEL,
+// it also captures part of the reservation or increases it, see models.UpdateStatusWalletTransactionRequest
 func (ts *walletTrx) ProcessReservedTransaction(ctx context.Context, req models.UpdateStatusWalletTransactionRequest) (*models.WalletTransaction, error) {
 	var err error
 	var acuanTransactions []models.Transaction
 	var updatedBalances map[string]models.Balance
 	var currentBalances map[string]models.Balance
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	walletTrx, err := ts.srv.sqlRepo.GetWalletTransactionRepository().GetById(ctx, req.TransactionId)
 	if err != nil {
@@ -291,6 +344,8 @@ func (ts *walletTrx) ProcessReservedTransaction(ctx context.Context, req models.
 		req.TransactionTime = time.Now()
 	}
 
+	isPartiallyCaptured := !walletTrx.CapturedAmount.IsZero()
+
 	var balanceCalculator walletBalanceCalculator
 	var nextWalletTrxStatus models.WalletTransactionStatus
 	if req.Action == models.TransactionRequestCommitStatus {
@@ -298,6 +353,13 @@ func (ts *walletTrx) ProcessReservedTransaction(ctx context.Context, req models.
 			return walletTrx, nil
 		}
 
+		// commit without amount captures everything left of a partially captured reservation
+		if req.Amount != nil {
+			return ts.captureReservedTransaction(ctx, req, req.Amount.ValueDecimal.Decimal, req.IsFinalCapture)
+		} else if isPartiallyCaptured {
+			return ts.captureReservedTransaction(ctx, req, walletTrx.RemainingAmount(), true)
+		}
+
 		balanceCalculator = getWalletBalanceCommitCalculator(walletTrx.TransactionFlow)
 		nextWalletTrxStatus = models.WalletTransactionStatusSuccess
 	} else if req.Action == models.TransactionRequestCancelStatus {
@@ -305,8 +367,15 @@ func (ts *walletTrx) ProcessReservedTransaction(ctx context.Context, req models.
 			return walletTrx, nil
 		}
 
+		// captured part can't be cancelled, only the remaining reservation is released
+		if isPartiallyCaptured {
+			return ts.captureReservedTransaction(ctx, req, decimal.Zero, true)
+		}
+
 		balanceCalculator = getWalletBalanceCancelCalculator(walletTrx.TransactionFlow)
 		nextWalletTrxStatus = models.WalletTransactionStatusCancel
+	} else if req.Action == models.TransactionRequestIncreaseStatus {
+		return ts.increaseReservedTransaction(ctx, req)
 	} else {
 		return nil, fmt.Errorf("action not supported: %s", req.Action)
 	}
@@ -315,6 +384,9 @@ func (ts *walletTrx) ProcessReservedTransaction(ctx context.Context, req models.
 		return nil, common.ErrTransactionNotReserved
 	}
 
+	// walletTrx is replaced by the updated one inside the atomic steps, it is nil when the update fails
+	transactionType, transactionFlow := walletTrx.TransactionType, walletTrx.TransactionFlow
+
 	// assume that the handler timeout is 16 seconds
 	// maxWaitingTimeDB is the maximum time to wait for database operations to complete, usually it should be less than 8 seconds
 	// because we have several operations in one transaction, including select for update, insert, and update
@@ -328,6 +400,17 @@ func (ts *walletTrx) ProcessReservedTransaction(ctx context.Context, req models.
 		walletTrxRepo := r.GetWalletTransactionRepository()
 		acuanTrxRepo := r.GetTransactionRepository()
 
+		// lock the reservation and check it again, it may be captured, committed or cancelled by concurrent request
+		reserved, errAtomic := walletTrxRepo.GetByIdForUpdate(atomicCtx, req.TransactionId)
+		if errAtomic != nil {
+			return fmt.Errorf("unable to get transaction: %w", checkDatabaseError(errAtomic))
+		}
+
+		if reserved.Status != models.WalletTransactionStatusPending || !reserved.CapturedAmount.IsZero() {
+			return common.ErrTransactionNotReserved
+		}
+		walletTrx = reserved
+
 		// merge metadata
 		maps.Copy(walletTrx.Metadata, req.Metadata)
 
@@ -342,18 +425,7 @@ func (ts *walletTrx) ProcessReservedTransaction(ctx context.Context, req models.
 		}
 
 		// create child transaction (depend on transaction type)
-		mapTransformer := transformer.NewMapTransformer(
-			ts.srv.conf,
-			ts.srv.masterDataRepo,
-			ts.srv.accountingClient,
-			ts.srv.sqlRepo.GetAccountRepository(),
-			ts.srv.sqlRepo.GetTransactionRepository(),
-			ts.getAccountConfigRepository(),
-			ts.srv.sqlRepo.GetWalletTransactionRepository(),
-			ts.srv.flag,
-		)
-
-		childTransactions, errAtomic := mapTransformer.Transform(atomicCtx, *walletTrx)
+		childTransactions, errAtomic := ts.newMapTransformer().Transform(atomicCtx, *walletTrx)
 		if errAtomic != nil {
 			return fmt.Errorf("unable to transform wallet transaction: %w", err)
 		}
@@ -397,42 +469,14 @@ func (ts *walletTrx) ProcessReservedTransaction(ctx context.Context, req models.
 		}
 
 		// update balances
-		for accountNumber, balance := range updatedBalances {
-			if balance.IsSkipBalanceUpdateOnDB() {
-				continue
-			}
-
-			isEligibleForHVT := !slices.Contains(fromAccounts, accountNumber) && balance.IsHVT()
-			if isEligibleForHVT {
-				prevBalance, ok := currentBalances[accountNumber]
-				if !ok {
-					return fmt.Errorf("unable to get previous balance for account number %s", accountNumber)
-				}
-
-				diffAmount := balance.Available().Sub(prevBalance.Available())
-				errAtomic = ts.srv.balanceHVTPub.Publish(atomicCtx, models.UpdateBalanceHVTPayload{
-					Kind:                "balanceUpdateHVT",
-					WalletTransactionId: walletTrx.ID,
-					RefNumber:           walletTrx.RefNumber,
-					AccountNumber:       accountNumber,
-					UpdateAmount: models.Amount{
-						ValueDecimal: models.NewDecimalFromExternal(diffAmount),
-						Currency:     "IDR",
-					},
-				}, publisher.WithKey(accountNumber))
-				if errAtomic != nil {
-					return fmt.Errorf("unable to publish balance hvt: %w", errAtomic)
-				}
-
-				continue
-			}
-
-			ub, errUpdateBalance := accRepo.UpdateAccountBalance(atomicCtx, accountNumber, balance)
-			if errUpdateBalance != nil {
-				return fmt.Errorf("unable to update balance: %w", errUpdateBalance)
-			}
+		errAtomic = ts.updateReservedBalances(atomicCtx, accRepo, *walletTrx, currentBalances, updatedBalances, fromAccounts)
+		if errAtomic != nil {
+			return errAtomic
+		}
 
-			updatedBalances[accountNumber] = *ub
+		errAtomic = walletTrxRepo.CreateBalances(atomicCtx, models.NewWalletTransactionBalances(walletTrx.ID, currentBalances, updatedBalances))
+		if errAtomic != nil {
+			return fmt.Errorf("unable to store balance snapshot: %w", errAtomic)
 		}
 
 		// insert to "transaction" table if SUCCESS
@@ -446,15 +490,22 @@ func (ts *walletTrx) ProcessReservedTransaction(ctx context.Context, req models.
 		return nil
 	})
 	if err != nil {
+		ts.recordRejection(transactionType, transactionFlow, req.ClientId, err)
 		return nil, err
 	}
 
+	business := ts.srv.metrics.GetBusinessPrometheus()
+	business.RecordWalletTransaction(*walletTrx, req.ClientId)
+	if walletTrx.Status == models.WalletTransactionStatusSuccess {
+		business.RecordAmountMoved(*walletTrx, walletTrx.NetAmount.ValueDecimal.Decimal)
+	}
+
 	// maxWaitingTimeKafka is the maximum time to wait for kafka publish to complete, kafka client has been set to 2 seconds
 	// so we set the max waiting time to be longer than that
 	// please be aware that this timeout is associated with the kafka client timeout
 	// if the kafka client timeout is changed, this timeout should be changed too
 	maxWaitingTimeKafka := 7 * time.Second
-	kafkaCtx, cancelKafka := context.WithTimeout(context.Background(), maxWaitingTimeKafka)
+	kafkaCtx, cancelKafka := context.WithTimeout(context.WithoutCancel(ctx), maxWaitingTimeKafka)
 	defer cancelKafka()
 	if walletTrx.Status == models.WalletTransactionStatusSuccess {
 		err = ts.publishNotificationCreateWalletTransactionSuccess(
@@ -473,6 +524,77 @@ func (ts *walletTrx) ProcessReservedTransaction(ctx context.Context, req models.
 	return walletTrx, nil
 }
 
+// recordRejection count wallet transaction rejected by the balance check, other errors are not a business rejection
+func (ts *walletTrx) recordRejection(transactionType string, transactionFlow models.TransactionFlow, clientID string, err error) {
+	var reason string
+	switch {
+	case errors.Is(err, common.ErrNegativeBalanceReached):
+		reason = metrics.RejectionNegativeLimit
+	case errors.Is(err, common.ErrInsufficientAvailableBalance), errors.Is(err, common.ErrInsufficientPendingBalance):
+		reason = metrics.RejectionInsufficientBalance
+	default:
+		return
+	}
+
+	ts.srv.metrics.GetBusinessPrometheus().RecordRejection(transactionType, transactionFlow, clientID, reason)
+}
+
+// updateReservedBalances stores balances changed by processing reserved transaction,
+// HVT accounts which only receive funds are published to be updated asynchronously
+func (ts *walletTrx) updateReservedBalances(
+	ctx context.Context,
+	accRepo repositories.AccountRepository,
+	walletTrx models.WalletTransaction,
+	currentBalances map[string]models.Balance,
+	updatedBalances map[string]models.Balance,
+	fromAccounts []string) error {
+
+	for accountNumber, balance := range updatedBalances {
+		if balance.IsSkipBalanceUpdateOnDB() {
+			continue
+		}
+
+		isEligibleForHVT := !slices.Contains(fromAccounts, accountNumber) && balance.IsHVT()
+		if isEligibleForHVT {
+			prevBalance, ok := currentBalances[accountNumber]
+			if !ok {
+				return fmt.Errorf("unable to get previous balance for account number %s", accountNumber)
+			}
+
+			diffAmount := balance.Available().Sub(prevBalance.Available())
+			payload := models.UpdateBalanceHVTPayload{
+				Kind:                "balanceUpdateHVT",
+				WalletTransactionId: walletTrx.ID,
+				RefNumber:           walletTrx.RefNumber,
+				AccountNumber:       accountNumber,
+				UpdateAmount: models.Amount{
+					ValueDecimal: models.NewDecimalFromExternal(diffAmount),
+					Currency:     "IDR",
+				},
+			}
+			if walletTrx.CapturedAmount.IsPositive() {
+				payload.CapturedAmount = walletTrx.CapturedAmount.String()
+			}
+
+			err := ts.srv.balanceHVTPub.Publish(ctx, payload, publisher.WithKey(accountNumber))
+			if err != nil {
+				return fmt.Errorf("unable to publish balance hvt: %w", err)
+			}
+
+			continue
+		}
+
+		ub, err := accRepo.UpdateAccountBalance(ctx, accountNumber, balance)
+		if err != nil {
+			return fmt.Errorf("unable to update balance: %w", err)
+		}
+
+		updatedBalances[accountNumber] = *ub
+	}
+
+	return nil
+}
+
 func (ts *walletTrx) publishNotificationCreateWalletTransactionSuccess(
 	ctx context.Context,
 	walletTransaction models.WalletTransaction,
@@ -532,15 +654,25 @@ func (ts *walletTrx) insertChildTransactions(ctx context.Context, acuanRepo repo
 }
 
 func (ts *walletTrx) validateTransactionInput(ctx context.Context, in models.CreateWalletTransactionRequest) error {
-	// Init master
+	acceptedTransactionType, err := ts.acceptedTransactionTypes(ctx)
+	if err != nil {
+		return err
+	}
+
+	return validateTransactionRequest(in, acceptedTransactionType)
+}
+
+// acceptedTransactionTypes return the transaction types of config and master data
+func (ts *walletTrx) acceptedTransactionTypes(ctx context.Context) ([]string, error) {
 	tTypes, err := ts.srv.masterDataRepo.GetListTransactionTypeCode(ctx)
 	if err != nil {
-		return fmt.Errorf("unable get trxType master data: %w", err)
+		return nil, fmt.Errorf("unable get trxType master data: %w", err)
 	}
-	acceptedTransactionType := append(ts.srv.conf.TransactionValidationConfig.AcceptedTransactionType, tTypes...)
 
-	// Start Validation
+	return append(slices.Clone(ts.srv.conf.TransactionValidationConfig.AcceptedTransactionType), tTypes...), nil
+}
 
+func validateTransactionRequest(in models.CreateWalletTransactionRequest, acceptedTransactionType []string) error {
 	trxTime, err := common.ParseStringToDatetime(time.RFC3339, in.TransactionTime)
 	if err != nil {
 		return fmt.Errorf("unable to parse transaction time: %w", err)
@@ -578,8 +710,8 @@ func (ts *walletTrx) validateTransactionInput(ctx context.Context, in models.Cre
 }
 
 func (ts *walletTrx) List(ctx context.Context, opts models.WalletTrxFilterOptions) (transactions []models.WalletTransaction, total int, err error) {
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
 
 	repo := ts.srv.sqlRepo.GetWalletTransactionRepository()
 
@@ -596,11 +728,81 @@ func (ts *walletTrx) List(ctx context.Context, opts models.WalletTrxFilterOption
 	return calculateTotalAmountOfTransactions(transactions), total, nil
 }
 
+// GetDetail return wallet transaction by id or refNumber with its child transactions
+// and the balances of accounts before and after they are changed by the wallet transaction
+func (ts *walletTrx) GetDetail(ctx context.Context, req models.DoGetWalletTransactionDetailRequest) (detail *models.WalletTransactionDetail, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	walletTrxRepo := ts.srv.sqlRepo.GetWalletTransactionRepository()
+
+	id := req.TransactionId
+	if id == "" {
+		found, errGet := walletTrxRepo.ListByRefNumber(ctx, req.RefNumber, req.TransactionType)
+		if errGet != nil {
+			return nil, fmt.Errorf("unable to get wallet transaction by refNumber: %w", errGet)
+		}
+
+		switch len(found) {
+		case 0:
+			return nil, common.ErrDataNotFound
+		case 1:
+			id = found[0].ID
+		default:
+			return nil, fmt.Errorf("%w: %s", common.ErrAmbiguousRefNumber, req.RefNumber)
+		}
+	}
+
+	walletTransaction, err := walletTrxRepo.GetById(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, common.ErrDataNotFound
+		}
+
+		return nil, fmt.Errorf("unable to get wallet transaction: %w", err)
+	}
+
+	// legacy child transactions are matched by refNumber, it is only done when no other wallet transaction has the refNumber
+	sameRefNumber, err := walletTrxRepo.ListByRefNumber(ctx, walletTransaction.RefNumber, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to get wallet transaction by refNumber: %w", err)
+	}
+
+	legacyRefNumber := walletTransaction.RefNumber
+	if len(sameRefNumber) > 1 {
+		legacyRefNumber = ""
+	}
+
+	transactions, err := ts.srv.sqlRepo.GetTransactionRepository().GetByWalletTransactionID(ctx, walletTransaction.ID, legacyRefNumber)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get child transactions: %w", err)
+	}
+
+	balances, err := walletTrxRepo.GetBalances(ctx, walletTransaction.ID)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get balances: %w", err)
+	}
+
+	return &models.WalletTransactionDetail{
+		WalletTransaction: *walletTransaction,
+		Transactions:      transactions,
+		Balances:          balances,
+	}, nil
+}
+
 func (ts *walletTrx) EnqueueTransaction(ctx context.Context, in models.CreateWalletTransactionRequest) (*models.WalletTransaction, error) {
 	var err error
 
-	monitor := monitoring.New(ctx)
-	defer monitor.Finish(monitoring.WithFinishCheckError(err))
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	in.AsyncId = uuid.New().String()
+
+	asyncRepo := ts.srv.sqlRepo.GetAsyncWalletTransactionRepository()
+	intake := models.NewAsyncWalletTransaction(in)
+	if err = asyncRepo.Create(ctx, &intake); err != nil {
+		return nil, fmt.Errorf("unable to create async transaction: %w", err)
+	}
 
 	accounts := []string{in.AccountNumber, in.DestinationAccountNumber}
 	slices.Sort(accounts)
@@ -614,14 +816,231 @@ func (ts *walletTrx) EnqueueTransaction(ctx context.Context, in models.CreateWal
 
 	err = ts.srv.walletTransactionAsync.Publish(ctx, in, opts...)
 	if err != nil {
+		// the message will never be consumed, so mark the intake as failed instead of leaving it queued
+		intake.Status = models.AsyncWalletTransactionStatusFailed
+		intake.ErrorCode = models.WalletTransactionErrorCodeInternal
+		intake.ErrorMessage = err.Error()
+		if errUpdate := asyncRepo.UpdateStatus(ctx, &intake); errUpdate != nil {
+			xlog.Warn(ctx, logMessageAsyncWalletTransaction, xlog.String("asyncId", intake.ID), xlog.Err(errUpdate))
+		}
+
 		return nil, err
 	}
 
 	return &models.WalletTransaction{
-		Status: models.WalletTransactionStatusPending,
+		Status:  models.WalletTransactionStatusPending,
+		AsyncId: in.AsyncId,
 	}, nil
 }
 
+func (ts *walletTrx) ProcessAsyncTransaction(ctx context.Context, in models.CreateWalletTransactionRequest) (*models.WalletTransaction, error) {
+	var err error
+
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	// messages enqueued before the intake table existed have no async id, there is nothing to track
+	if in.AsyncId == "" {
+		return ts.processAsyncTransaction(ctx, in)
+	}
+
+	asyncRepo := ts.srv.sqlRepo.GetAsyncWalletTransactionRepository()
+	intake := &models.AsyncWalletTransaction{
+		ID:       in.AsyncId,
+		ClientId: in.ClientId,
+		Status:   models.AsyncWalletTransactionStatusProcessing,
+	}
+	if err = asyncRepo.UpdateStatus(ctx, intake); err != nil {
+		return nil, fmt.Errorf("unable to update async transaction status: %w", err)
+	}
+
+	created, errProcess := ts.processAsyncTransaction(ctx, in)
+	if errProcess != nil {
+		// unexpected error is retried by the consumer, the intake stays processing until it is retried
+		// successfully or the message is sent to DLQ, see FailAsyncTransaction
+		if !models.IsWalletTransactionRejected(errProcess) {
+			err = errProcess
+			return nil, err
+		}
+
+		intake.Status = models.AsyncWalletTransactionStatusFailed
+		intake.ErrorCode = models.WalletTransactionErrorCode(errProcess)
+		intake.ErrorMessage = errProcess.Error()
+	} else {
+		intake.Status = models.AsyncWalletTransactionStatusSucceeded
+		intake.WalletTransactionId = created.ID
+	}
+
+	if err = ts.finishAsyncTransaction(ctx, intake); err != nil {
+		return nil, err
+	}
+
+	if errProcess != nil {
+		err = errProcess
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (ts *walletTrx) FailAsyncTransaction(ctx context.Context, in models.CreateWalletTransactionRequest, cause error) (err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	if in.AsyncId == "" {
+		return nil
+	}
+
+	intake, err := ts.srv.sqlRepo.GetAsyncWalletTransactionRepository().GetByID(ctx, in.AsyncId)
+	if err != nil {
+		return err
+	}
+
+	// rejected transaction is finished before it is sent to DLQ
+	if intake.IsFinished() {
+		return nil
+	}
+
+	intake.Status = models.AsyncWalletTransactionStatusFailed
+	intake.WalletTransactionId = ""
+	intake.ErrorCode = models.WalletTransactionErrorCode(cause)
+	intake.ErrorMessage = cause.Error()
+
+	return ts.finishAsyncTransaction(ctx, intake)
+}
+
+// finishAsyncTransaction record the outcome of the transaction, the callback of the client is marked as pending
+// in the same update and sent by SendAsyncCallbacks, so a slow client never blocks the consumer
+func (ts *walletTrx) finishAsyncTransaction(ctx context.Context, intake *models.AsyncWalletTransaction) error {
+	callback, ok := ts.srv.conf.TransactionConfig.AsyncWalletTransactionCallbacks[intake.ClientId]
+	if ok && callback.URL != "" {
+		intake.CallbackStatus = models.AsyncCallbackStatusPending
+	}
+
+	if err := ts.srv.sqlRepo.GetAsyncWalletTransactionRepository().UpdateStatus(ctx, intake); err != nil {
+		return fmt.Errorf("unable to update async transaction status: %w", err)
+	}
+
+	return nil
+}
+
+// processAsyncTransaction validate the enqueued request again since balances may have changed while it is queued
+func (ts *walletTrx) processAsyncTransaction(ctx context.Context, in models.CreateWalletTransactionRequest) (*models.WalletTransaction, error) {
+	// failures of the lookups are not validation errors, they are retried by the consumer
+	trx, err := ts.validateTransactionTypeAndRefNumber(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("unable to check transaction type and ref number: %w", err)
+	}
+	if trx != nil {
+		return trx, nil
+	}
+
+	acceptedTransactionType, err := ts.acceptedTransactionTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateTransactionRequest(in, acceptedTransactionType); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
+
+	return ts.createTransaction(ctx, in)
+}
+
+func (ts *walletTrx) SendAsyncCallbacks(ctx context.Context) (sent int, err error) {
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	asyncRepo := ts.srv.sqlRepo.GetAsyncWalletTransactionRepository()
+
+	// callbacks updated by this run are not picked again, failed ones are retried by the next run
+	startedAt := time.Now()
+	for {
+		var intakes []models.AsyncWalletTransaction
+		intakes, err = asyncRepo.GetPendingCallbacks(ctx, startedAt, asyncCallbackBatchSize)
+		if err != nil {
+			return sent, fmt.Errorf("unable to get pending callbacks: %w", err)
+		}
+
+		for _, intake := range intakes {
+			if ts.sendAsyncCallback(ctx, intake) {
+				sent++
+			}
+		}
+
+		if len(intakes) < asyncCallbackBatchSize {
+			return sent, nil
+		}
+	}
+}
+
+// sendAsyncCallback notify the client about the outcome of the enqueued transaction, the callback stays pending
+// after a failed attempt until the max attempts is reached. It returns true when the callback is delivered
+func (ts *walletTrx) sendAsyncCallback(ctx context.Context, intake models.AsyncWalletTransaction) bool {
+	asyncRepo := ts.srv.sqlRepo.GetAsyncWalletTransactionRepository()
+
+	logFields := []xlog.Field{
+		xlog.String("asyncId", intake.ID),
+		xlog.String("clientId", intake.ClientId),
+	}
+
+	// the callback of the client is removed from config after the transaction is finished
+	callback, ok := ts.srv.conf.TransactionConfig.AsyncWalletTransactionCallbacks[intake.ClientId]
+	if !ok || callback.URL == "" {
+		xlog.Warn(ctx, logMessageAsyncWalletTransaction, append(logFields, xlog.String("error", "callback is not configured"))...)
+		if err := asyncRepo.UpdateCallback(ctx, intake.ID, models.AsyncCallbackStatusFailed, 0); err != nil {
+			xlog.Warn(ctx, logMessageAsyncWalletTransaction, append(logFields, xlog.Err(err))...)
+		}
+		return false
+	}
+
+	maxAttempts := ts.srv.conf.TransactionConfig.AsyncWalletTransactionCallbackMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultAsyncCallbackMaxAttempts
+	}
+
+	callbackStatus := models.AsyncCallbackStatusSent
+	attempts, err := ts.srv.webhookClient.Send(ctx, callback.URL, callback.Secret, intake.ToModelResponse())
+	if err != nil {
+		callbackStatus = models.AsyncCallbackStatusPending
+		if intake.CallbackAttempts+attempts >= maxAttempts {
+			callbackStatus = models.AsyncCallbackStatusFailed
+		}
+		xlog.Warn(ctx, logMessageAsyncWalletTransaction, append(logFields, xlog.Err(err))...)
+	}
+
+	if errUpdate := asyncRepo.UpdateCallback(ctx, intake.ID, callbackStatus, attempts); errUpdate != nil {
+		xlog.Warn(ctx, logMessageAsyncWalletTransaction, append(logFields, xlog.Err(errUpdate))...)
+	}
+
+	return err == nil
+}
+
+func (ts *walletTrx) GetAsyncTransaction(ctx context.Context, id, clientId string) (*models.AsyncWalletTransaction, error) {
+	var err error
+
+	ctx, monitor := monitoring.Start(ctx)
+	defer func() { monitor.Finish(monitoring.WithFinishCheckError(err)) }()
+
+	// id column is uuid, any other value can not exist
+	if _, err = uuid.Parse(id); err != nil {
+		err = common.ErrDataNotFound
+		return nil, err
+	}
+
+	intake, err := ts.srv.sqlRepo.GetAsyncWalletTransactionRepository().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if intake.ClientId != clientId {
+		err = common.ErrDataNotFound
+		return nil, err
+	}
+
+	return intake, nil
+}
+
 func (ts *walletTrx) validateTransactionTypeAndRefNumber(ctx context.Context, in models.CreateWalletTransactionRequest) (data *models.WalletTransaction, err error) {
 	var list models.ListTransactionType
 	variantTransactionTypeAndRefNumber := ts.srv.flag.GetVariant(ts.srv.conf.FeatureFlagKeyLookup.GetVariantTransactionTypeAndRefNumber)
@@ -2,10 +2,13 @@ package services_test
 
 import (
 	"context"
+	"database/sql"
 	"github.com/Unleash/unleash-client-go/v3/api"
 	"testing"
 	"time"
 
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common"
+	"bitbucket.org/Amartha/go-fp-transaction/internal/common/publisher"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/models"
 	"bitbucket.org/Amartha/go-fp-transaction/internal/repositories"
 	mockRepo "bitbucket.org/Amartha/go-fp-transaction/internal/repositories/mock"
@@ -129,6 +132,15 @@ func Test_WalletTrxService_CreateTransaction(t *testing.T) {
 						atomicHelper.mockWalletTrxRepository.EXPECT().
 							Create(gomock.Any(), gomock.AssignableToTypeOf(args.ToNewWalletTransaction())).
 							Return(&created, nil)
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							CreateBalances(gomock.Any(), gomock.Any()).
+							DoAndReturn(func(_ context.Context, balances []models.WalletTransactionBalance) error {
+								assert.NotEmpty(t, balances)
+								for _, b := range balances {
+									assert.Equal(t, created.ID, b.WalletTransactionID)
+								}
+								return nil
+							})
 						atomicHelper.mockTrxRepository.EXPECT().
 							StoreBulkTransaction(gomock.Any(), gomock.Any()).
 							Return(nil)
@@ -730,6 +742,10 @@ func Test_WalletTrxService_ProcessReservedTransaction_Commit(t *testing.T) {
 					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
 						atomicHelper := mockAtomicHelper()
 
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							GetByIdForUpdate(gomock.Any(), args.TransactionId).
+							Return(&models.WalletTransaction{ID: args.TransactionId, Status: models.WalletTransactionStatusPending}, nil)
+
 						walletTrx.Status = models.WalletTransactionStatusSuccess
 						atomicHelper.mockWalletTrxRepository.EXPECT().
 							Update(gomock.Any(), args.TransactionId, gomock.Any()).
@@ -744,6 +760,10 @@ func Test_WalletTrxService_ProcessReservedTransaction_Commit(t *testing.T) {
 							Return(&defaultAccountBalances[0].Balance, nil).
 							Times(3)
 
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							CreateBalances(gomock.Any(), gomock.Any()).
+							Return(nil)
+
 						atomicHelper.mockTrxRepository.EXPECT().
 							StoreBulkTransaction(gomock.Any(), gomock.Any()).
 							Return(nil)
@@ -784,6 +804,33 @@ func Test_WalletTrxService_ProcessReservedTransaction_Commit(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "failed - reservation captured by concurrent request",
+			doMock: func(args models.UpdateStatusWalletTransactionRequest) {
+				testHelper.mockWalletTrxRepository.EXPECT().
+					GetById(gomock.Any(), args.TransactionId).
+					Return(&models.WalletTransaction{
+						Status: models.WalletTransactionStatusPending,
+					}, nil)
+
+				testHelper.mockSQLRepository.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
+						atomicHelper := mockAtomicHelper()
+
+						// captured after the reservation was read, commit must not move the whole amount again
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							GetByIdForUpdate(gomock.Any(), args.TransactionId).
+							Return(&models.WalletTransaction{
+								ID:             args.TransactionId,
+								Status:         models.WalletTransactionStatusPending,
+								CapturedAmount: decimal.NewFromInt(4000),
+							}, nil)
+
+						return steps(ctx, atomicHelper.mockSQLRepository)
+					})
+			},
+			wantErr: true,
+		},
 		{
 			name: "failed - unable update status wallet transaction",
 			doMock: func(args models.UpdateStatusWalletTransactionRequest) {
@@ -801,6 +848,10 @@ func Test_WalletTrxService_ProcessReservedTransaction_Commit(t *testing.T) {
 					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
 						atomicHelper := mockAtomicHelper()
 
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							GetByIdForUpdate(gomock.Any(), args.TransactionId).
+							Return(&models.WalletTransaction{ID: args.TransactionId, Status: models.WalletTransactionStatusPending}, nil)
+
 						atomicHelper.mockWalletTrxRepository.EXPECT().
 							Update(gomock.Any(), gomock.Any(), gomock.Any()).
 							Return(nil, assert.AnError)
@@ -837,6 +888,10 @@ func Test_WalletTrxService_ProcessReservedTransaction_Commit(t *testing.T) {
 					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
 						atomicHelper := mockAtomicHelper()
 
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							GetByIdForUpdate(gomock.Any(), args.TransactionId).
+							Return(&models.WalletTransaction{ID: args.TransactionId, Status: models.WalletTransactionStatusPending}, nil)
+
 						walletTrx.Status = models.WalletTransactionStatusSuccess
 						atomicHelper.mockWalletTrxRepository.EXPECT().
 							Update(gomock.Any(), args.TransactionId, gomock.Any()).
@@ -878,6 +933,10 @@ func Test_WalletTrxService_ProcessReservedTransaction_Commit(t *testing.T) {
 					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
 						atomicHelper := mockAtomicHelper()
 
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							GetByIdForUpdate(gomock.Any(), args.TransactionId).
+							Return(&models.WalletTransaction{ID: args.TransactionId, Status: models.WalletTransactionStatusPending}, nil)
+
 						walletTrx.Status = models.WalletTransactionStatusSuccess
 						atomicHelper.mockWalletTrxRepository.EXPECT().
 							Update(gomock.Any(), args.TransactionId, gomock.Any()).
@@ -923,6 +982,10 @@ func Test_WalletTrxService_ProcessReservedTransaction_Commit(t *testing.T) {
 					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
 						atomicHelper := mockAtomicHelper()
 
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							GetByIdForUpdate(gomock.Any(), args.TransactionId).
+							Return(&models.WalletTransaction{ID: args.TransactionId, Status: models.WalletTransactionStatusPending}, nil)
+
 						walletTrx.Status = models.WalletTransactionStatusSuccess
 						atomicHelper.mockWalletTrxRepository.EXPECT().
 							Update(gomock.Any(), args.TransactionId, gomock.Any()).
@@ -937,6 +1000,10 @@ func Test_WalletTrxService_ProcessReservedTransaction_Commit(t *testing.T) {
 							Return(&defaultAccountBalances[0].Balance, nil).
 							Times(3)
 
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							CreateBalances(gomock.Any(), gomock.Any()).
+							Return(nil)
+
 						atomicHelper.mockTrxRepository.EXPECT().
 							StoreBulkTransaction(gomock.Any(), gomock.Any()).
 							Return(assert.AnError)
@@ -972,6 +1039,10 @@ func Test_WalletTrxService_ProcessReservedTransaction_Commit(t *testing.T) {
 					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
 						atomicHelper := mockAtomicHelper()
 
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							GetByIdForUpdate(gomock.Any(), args.TransactionId).
+							Return(&models.WalletTransaction{ID: args.TransactionId, Status: models.WalletTransactionStatusPending}, nil)
+
 						walletTrx.Status = models.WalletTransactionStatusSuccess
 						atomicHelper.mockWalletTrxRepository.EXPECT().
 							Update(gomock.Any(), args.TransactionId, gomock.Any()).
@@ -986,6 +1057,10 @@ func Test_WalletTrxService_ProcessReservedTransaction_Commit(t *testing.T) {
 							Return(&defaultAccountBalances[0].Balance, nil).
 							Times(3)
 
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							CreateBalances(gomock.Any(), gomock.Any()).
+							Return(nil)
+
 						atomicHelper.mockTrxRepository.EXPECT().
 							StoreBulkTransaction(gomock.Any(), gomock.Any()).
 							Return(nil)
@@ -1092,6 +1167,10 @@ func Test_WalletTrxService_ProcessReservedTransaction_Cancel(t *testing.T) {
 					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
 						atomicHelper := mockAtomicHelper()
 
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							GetByIdForUpdate(gomock.Any(), args.TransactionId).
+							Return(&models.WalletTransaction{ID: args.TransactionId, Status: models.WalletTransactionStatusPending}, nil)
+
 						walletTrx.Status = models.WalletTransactionStatusCancel
 						atomicHelper.mockWalletTrxRepository.EXPECT().
 							Update(gomock.Any(), args.TransactionId, gomock.Any()).
@@ -1110,6 +1189,10 @@ func Test_WalletTrxService_ProcessReservedTransaction_Cancel(t *testing.T) {
 							Return(&models.Balance{}, nil).
 							Times(3)
 
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							CreateBalances(gomock.Any(), gomock.Any()).
+							Return(nil)
+
 						return steps(ctx, atomicHelper.mockSQLRepository)
 					})
 			},
@@ -1154,6 +1237,10 @@ func Test_WalletTrxService_ProcessReservedTransaction_Cancel(t *testing.T) {
 					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
 						atomicHelper := mockAtomicHelper()
 
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							GetByIdForUpdate(gomock.Any(), args.TransactionId).
+							Return(&models.WalletTransaction{ID: args.TransactionId, Status: models.WalletTransactionStatusPending}, nil)
+
 						atomicHelper.mockWalletTrxRepository.EXPECT().
 							Update(gomock.Any(), args.TransactionId, gomock.Any()).
 							Return(nil, assert.AnError)
@@ -1190,6 +1277,10 @@ func Test_WalletTrxService_ProcessReservedTransaction_Cancel(t *testing.T) {
 					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
 						atomicHelper := mockAtomicHelper()
 
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							GetByIdForUpdate(gomock.Any(), args.TransactionId).
+							Return(&models.WalletTransaction{ID: args.TransactionId, Status: models.WalletTransactionStatusPending}, nil)
+
 						walletTrx.Status = models.WalletTransactionStatusCancel
 						atomicHelper.mockWalletTrxRepository.EXPECT().
 							Update(gomock.Any(), args.TransactionId, gomock.Any()).
@@ -1230,6 +1321,10 @@ func Test_WalletTrxService_ProcessReservedTransaction_Cancel(t *testing.T) {
 					DoAndReturn(func(ctx context.Context, steps func(ctx context.Context, r repositories.SQLRepository) error) error {
 						atomicHelper := mockAtomicHelper()
 
+						atomicHelper.mockWalletTrxRepository.EXPECT().
+							GetByIdForUpdate(gomock.Any(), args.TransactionId).
+							Return(&models.WalletTransaction{ID: args.TransactionId, Status: models.WalletTransactionStatusPending}, nil)
+
 						walletTrx.Status = models.WalletTransactionStatusCancel
 						atomicHelper.mockWalletTrxRepository.EXPECT().
 							Update(gomock.Any(), args.TransactionId, gomock.Any()).
@@ -1306,3 +1401,615 @@ func Test_WalletTrxService_List(t *testing.T) {
 		})
 	}
 }
+
+func Test_WalletTrxService_GetDetail(t *testing.T) {
+	testHelper := serviceTestHelper(t)
+
+	walletTrx := &models.WalletTransaction{
+		ID:              "41d03147-c017-4176-8a1a-0b7ec735cc29",
+		RefNumber:       "REF-0001",
+		TransactionType: "TUPVA",
+	}
+	childTransactions := []models.Transaction{
+		{ID: 1, FromAccount: "111", ToAccount: "222", WalletTransactionID: walletTrx.ID},
+		{ID: 2, FromAccount: "222", ToAccount: "333", WalletTransactionID: walletTrx.ID},
+	}
+	balances := []models.WalletTransactionBalance{
+		{
+			WalletTransactionID: walletTrx.ID,
+			AccountNumber:       "111",
+			Before:              models.NewBalance(decimal.NewFromInt(100), decimal.Zero),
+			After:               models.NewBalance(decimal.NewFromInt(90), decimal.Zero),
+		},
+	}
+	uniqueRefNumber := []models.WalletTransaction{{ID: walletTrx.ID, TransactionType: walletTrx.TransactionType}}
+	sharedRefNumber := []models.WalletTransaction{
+		{ID: walletTrx.ID, TransactionType: walletTrx.TransactionType},
+		{ID: "a1b2c3d4-0000-0000-0000-000000000000", TransactionType: "RFDVA"},
+	}
+
+	tests := []struct {
+		name             string
+		req              models.DoGetWalletTransactionDetailRequest
+		doMock           func()
+		wantTransactions int
+		wantBalances     int
+		wantErr          error
+	}{
+		{
+			name: "happy path - by id",
+			req:  models.DoGetWalletTransactionDetailRequest{TransactionId: walletTrx.ID},
+			doMock: func() {
+				testHelper.mockWalletTrxRepository.EXPECT().GetById(gomock.Any(), walletTrx.ID).Return(walletTrx, nil)
+				testHelper.mockWalletTrxRepository.EXPECT().ListByRefNumber(gomock.Any(), walletTrx.RefNumber, "").Return(uniqueRefNumber, nil)
+				testHelper.mockTrxRepository.EXPECT().GetByWalletTransactionID(gomock.Any(), walletTrx.ID, walletTrx.RefNumber).Return(childTransactions, nil)
+				testHelper.mockWalletTrxRepository.EXPECT().GetBalances(gomock.Any(), walletTrx.ID).Return(balances, nil)
+			},
+			wantTransactions: 2,
+			wantBalances:     1,
+		},
+		{
+			name: "happy path - by refNumber without child transaction",
+			req:  models.DoGetWalletTransactionDetailRequest{RefNumber: walletTrx.RefNumber},
+			doMock: func() {
+				testHelper.mockWalletTrxRepository.EXPECT().ListByRefNumber(gomock.Any(), walletTrx.RefNumber, "").Return(uniqueRefNumber, nil).Times(2)
+				testHelper.mockWalletTrxRepository.EXPECT().GetById(gomock.Any(), walletTrx.ID).Return(walletTrx, nil)
+				testHelper.mockTrxRepository.EXPECT().GetByWalletTransactionID(gomock.Any(), walletTrx.ID, walletTrx.RefNumber).Return(nil, nil)
+				testHelper.mockWalletTrxRepository.EXPECT().GetBalances(gomock.Any(), walletTrx.ID).Return(nil, nil)
+			},
+		},
+		{
+			name: "happy path - shared refNumber with transactionType does not match legacy transactions by refNumber",
+			req:  models.DoGetWalletTransactionDetailRequest{RefNumber: walletTrx.RefNumber, TransactionType: walletTrx.TransactionType},
+			doMock: func() {
+				testHelper.mockWalletTrxRepository.EXPECT().ListByRefNumber(gomock.Any(), walletTrx.RefNumber, walletTrx.TransactionType).Return(uniqueRefNumber, nil)
+				testHelper.mockWalletTrxRepository.EXPECT().GetById(gomock.Any(), walletTrx.ID).Return(walletTrx, nil)
+				testHelper.mockWalletTrxRepository.EXPECT().ListByRefNumber(gomock.Any(), walletTrx.RefNumber, "").Return(sharedRefNumber, nil)
+				testHelper.mockTrxRepository.EXPECT().GetByWalletTransactionID(gomock.Any(), walletTrx.ID, "").Return(childTransactions, nil)
+				testHelper.mockWalletTrxRepository.EXPECT().GetBalances(gomock.Any(), walletTrx.ID).Return(balances, nil)
+			},
+			wantTransactions: 2,
+			wantBalances:     1,
+		},
+		{
+			name: "failed - refNumber not found",
+			req:  models.DoGetWalletTransactionDetailRequest{RefNumber: "unknown"},
+			doMock: func() {
+				testHelper.mockWalletTrxRepository.EXPECT().ListByRefNumber(gomock.Any(), "unknown", "").Return(nil, nil)
+			},
+			wantErr: common.ErrDataNotFound,
+		},
+		{
+			name: "failed - refNumber is used by more than one wallet transaction",
+			req:  models.DoGetWalletTransactionDetailRequest{RefNumber: walletTrx.RefNumber},
+			doMock: func() {
+				testHelper.mockWalletTrxRepository.EXPECT().ListByRefNumber(gomock.Any(), walletTrx.RefNumber, "").Return(sharedRefNumber, nil)
+			},
+			wantErr: common.ErrAmbiguousRefNumber,
+		},
+		{
+			name: "failed - id not found",
+			req:  models.DoGetWalletTransactionDetailRequest{TransactionId: "unknown"},
+			doMock: func() {
+				testHelper.mockWalletTrxRepository.EXPECT().GetById(gomock.Any(), "unknown").Return(nil, sql.ErrNoRows)
+			},
+			wantErr: common.ErrDataNotFound,
+		},
+		{
+			name: "failed - get child transactions",
+			req:  models.DoGetWalletTransactionDetailRequest{TransactionId: walletTrx.ID},
+			doMock: func() {
+				testHelper.mockWalletTrxRepository.EXPECT().GetById(gomock.Any(), walletTrx.ID).Return(walletTrx, nil)
+				testHelper.mockWalletTrxRepository.EXPECT().ListByRefNumber(gomock.Any(), walletTrx.RefNumber, "").Return(uniqueRefNumber, nil)
+				testHelper.mockTrxRepository.EXPECT().GetByWalletTransactionID(gomock.Any(), walletTrx.ID, walletTrx.RefNumber).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "failed - get balances",
+			req:  models.DoGetWalletTransactionDetailRequest{TransactionId: walletTrx.ID},
+			doMock: func() {
+				testHelper.mockWalletTrxRepository.EXPECT().GetById(gomock.Any(), walletTrx.ID).Return(walletTrx, nil)
+				testHelper.mockWalletTrxRepository.EXPECT().ListByRefNumber(gomock.Any(), walletTrx.RefNumber, "").Return(uniqueRefNumber, nil)
+				testHelper.mockTrxRepository.EXPECT().GetByWalletTransactionID(gomock.Any(), walletTrx.ID, walletTrx.RefNumber).Return(childTransactions, nil)
+				testHelper.mockWalletTrxRepository.EXPECT().GetBalances(gomock.Any(), walletTrx.ID).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.doMock != nil {
+				tt.doMock()
+			}
+
+			detail, err := testHelper.walletTrxService.GetDetail(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, walletTrx.ID, detail.ID)
+			assert.Len(t, detail.Transactions, tt.wantTransactions)
+			assert.Len(t, detail.Balances, tt.wantBalances)
+		})
+	}
+}
+
+func Test_WalletTrxService_CreateTransaction_Async(t *testing.T) {
+	args := models.CreateWalletTransactionRequest{
+		TransactionType:          "TUPVA",
+		TransactionFlow:          models.TransactionFlowTransfer,
+		AccountNumber:            "222",
+		DestinationAccountNumber: "111",
+		RefNumber:                "333",
+		TransactionTime:          time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		ClientId:                 "async-client",
+		IdempotencyKey:           "idempotency-key",
+	}
+
+	mockValidation := func(th testServiceHelper) {
+		th.mockFlagClient.EXPECT().
+			GetVariant(th.config.FeatureFlagKeyLookup.GetVariantTransactionTypeAndRefNumber).
+			Return(&api.Variant{})
+		th.mockMasterData.EXPECT().GetListTransactionTypeCode(gomock.Any()).Return([]string{"TUPVA", "FPEPD", "ITDED"}, nil)
+		th.mockFlagClient.EXPECT().IsEnabled(th.config.FeatureFlagKeyLookup.LceRollout).Return(true)
+	}
+
+	tests := []struct {
+		name    string
+		args    func() models.CreateWalletTransactionRequest
+		doMock  func(th testServiceHelper)
+		wantErr error
+	}{
+		{
+			name: "happy path - enqueue with async id",
+			doMock: func(th testServiceHelper) {
+				mockValidation(th)
+
+				var asyncId string
+				th.mockAsyncTrxRepository.EXPECT().Create(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, in *models.AsyncWalletTransaction) error {
+						assert.NotEmpty(t, in.ID)
+						assert.Equal(t, models.AsyncWalletTransactionStatusQueued, in.Status)
+						assert.Equal(t, args.ClientId, in.ClientId)
+						assert.Equal(t, args.RefNumber, in.RefNumber)
+						asyncId = in.ID
+						return nil
+					})
+				th.mockWalletTrxPublisher.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, payload any, _ ...publisher.PublishOption) error {
+						assert.Equal(t, asyncId, payload.(models.CreateWalletTransactionRequest).AsyncId)
+						return nil
+					})
+			},
+		},
+		{
+			name: "happy path - enqueue the net amount adjusted for LCE",
+			args: func() models.CreateWalletTransactionRequest {
+				in := args
+				in.TransactionType = "FPEPD"
+				in.NetAmount = models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(10000))}
+				in.Amounts = models.Amounts{
+					{Type: "ITDED", Amount: &models.Amount{ValueDecimal: models.NewDecimalFromExternal(decimal.NewFromInt(1500))}},
+				}
+				return in
+			},
+			doMock: func(th testServiceHelper) {
+				mockValidation(th)
+
+				// the consumer stores the enqueued amount as it is, so it is adjusted once here
+				th.mockAsyncTrxRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				th.mockWalletTrxPublisher.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, payload any, _ ...publisher.PublishOption) error {
+						netAmount := payload.(models.CreateWalletTransactionRequest).NetAmount.ValueDecimal.Decimal
+						assert.True(t, decimal.NewFromInt(8500).Equal(netAmount), "net amount = %s", netAmount)
+						return nil
+					})
+			},
+		},
+		{
+			name: "failed - create intake",
+			doMock: func(th testServiceHelper) {
+				mockValidation(th)
+				th.mockAsyncTrxRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "failed - publish mark the intake as failed",
+			doMock: func(th testServiceHelper) {
+				mockValidation(th)
+				th.mockAsyncTrxRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+				th.mockWalletTrxPublisher.EXPECT().Publish(gomock.Any(), gomock.Any(), gomock.Any()).Return(assert.AnError)
+				th.mockAsyncTrxRepository.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, in *models.AsyncWalletTransaction) error {
+						assert.Equal(t, models.AsyncWalletTransactionStatusFailed, in.Status)
+						assert.Equal(t, models.WalletTransactionErrorCodeInternal, in.ErrorCode)
+						return nil
+					})
+			},
+			wantErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := serviceTestHelper(t)
+			tt.doMock(th)
+
+			in := args
+			if tt.args != nil {
+				in = tt.args()
+			}
+
+			created, err := th.walletTrxService.CreateTransaction(context.Background(), in)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, models.WalletTransactionStatusPending, created.Status)
+			assert.NotEmpty(t, created.AsyncId)
+			assert.Empty(t, created.ID)
+		})
+	}
+}
+
+func Test_WalletTrxService_ProcessAsyncTransaction(t *testing.T) {
+	asyncId := "0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e"
+	existing := &models.WalletTransaction{ID: "41d03147-c017-4176-8a1a-0b7ec735cc29", Status: models.WalletTransactionStatusSuccess}
+
+	args := models.CreateWalletTransactionRequest{
+		TransactionType:          "TUPVA",
+		TransactionFlow:          models.TransactionFlowTransfer,
+		AccountNumber:            "222",
+		DestinationAccountNumber: "111",
+		RefNumber:                "333",
+		TransactionTime:          time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		ClientId:                 "async-client",
+		AsyncId:                  asyncId,
+	}
+
+	// the transaction has been created, so processing returns it without creating a new one
+	mockAlreadyCreated := func(th testServiceHelper) {
+		th.mockFlagClient.EXPECT().
+			GetVariant(th.config.FeatureFlagKeyLookup.GetVariantTransactionTypeAndRefNumber).
+			Return(&api.Variant{Payload: api.Payload{Value: `{"transactionType": ["TUPVA"]}`}})
+		th.mockWalletTrxRepository.EXPECT().
+			CheckTransactionTypeAndReferenceNumber(gomock.Any(), args.TransactionType, args.RefNumber).
+			Return(existing, nil)
+	}
+
+	mockUpdateStatus := func(th testServiceHelper, status, walletTrxId, errorCode, callbackStatus string) *gomock.Call {
+		return th.mockAsyncTrxRepository.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, in *models.AsyncWalletTransaction) error {
+				assert.Equal(t, asyncId, in.ID)
+				assert.Equal(t, status, in.Status)
+				assert.Equal(t, walletTrxId, in.WalletTransactionId)
+				assert.Equal(t, errorCode, in.ErrorCode)
+				assert.Equal(t, callbackStatus, in.CallbackStatus)
+				return nil
+			})
+	}
+
+	tests := []struct {
+		name    string
+		args    func() models.CreateWalletTransactionRequest
+		doMock  func(th testServiceHelper)
+		wantErr error
+	}{
+		{
+			name: "happy path - succeeded and callback pending",
+			doMock: func(th testServiceHelper) {
+				gomock.InOrder(
+					mockUpdateStatus(th, models.AsyncWalletTransactionStatusProcessing, "", "", ""),
+					mockUpdateStatus(th, models.AsyncWalletTransactionStatusSucceeded, existing.ID, "", models.AsyncCallbackStatusPending),
+				)
+				mockAlreadyCreated(th)
+			},
+		},
+		{
+			name: "happy path - client without callback",
+			args: func() models.CreateWalletTransactionRequest {
+				in := args
+				in.ClientId = "async-client-without-callback"
+				return in
+			},
+			doMock: func(th testServiceHelper) {
+				mockUpdateStatus(th, models.AsyncWalletTransactionStatusProcessing, "", "", "")
+				mockUpdateStatus(th, models.AsyncWalletTransactionStatusSucceeded, existing.ID, "", "")
+				mockAlreadyCreated(th)
+			},
+		},
+		{
+			name: "happy path - message without async id is not tracked",
+			args: func() models.CreateWalletTransactionRequest {
+				in := args
+				in.AsyncId = ""
+				return in
+			},
+			doMock: mockAlreadyCreated,
+		},
+		{
+			name: "failed - rejected transaction is finished as failed",
+			args: func() models.CreateWalletTransactionRequest {
+				in := args
+				in.TransactionType = "UNKNOWN"
+				return in
+			},
+			doMock: func(th testServiceHelper) {
+				th.mockFlagClient.EXPECT().
+					GetVariant(th.config.FeatureFlagKeyLookup.GetVariantTransactionTypeAndRefNumber).
+					Return(&api.Variant{})
+				th.mockMasterData.EXPECT().GetListTransactionTypeCode(gomock.Any()).Return([]string{"TUPVA"}, nil)
+
+				gomock.InOrder(
+					mockUpdateStatus(th, models.AsyncWalletTransactionStatusProcessing, "", "", ""),
+					mockUpdateStatus(th, models.AsyncWalletTransactionStatusFailed, "", models.WalletTransactionErrorCodeInvalidRequest, models.AsyncCallbackStatusPending),
+				)
+			},
+			wantErr: common.ErrInvalidTransactionType,
+		},
+		{
+			name: "failed - unexpected error keeps the transaction processing",
+			doMock: func(th testServiceHelper) {
+				th.mockFlagClient.EXPECT().
+					GetVariant(th.config.FeatureFlagKeyLookup.GetVariantTransactionTypeAndRefNumber).
+					Return(&api.Variant{Payload: api.Payload{Value: `{"transactionType": ["TUPVA"]}`}})
+				th.mockWalletTrxRepository.EXPECT().
+					CheckTransactionTypeAndReferenceNumber(gomock.Any(), args.TransactionType, args.RefNumber).
+					Return(nil, assert.AnError)
+
+				mockUpdateStatus(th, models.AsyncWalletTransactionStatusProcessing, "", "", "")
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "failed - mark as processing",
+			doMock: func(th testServiceHelper) {
+				th.mockAsyncTrxRepository.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Return(common.ErrDataNotFound)
+			},
+			wantErr: common.ErrDataNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := serviceTestHelper(t)
+			tt.doMock(th)
+
+			in := args
+			if tt.args != nil {
+				in = tt.args()
+			}
+
+			created, err := th.walletTrxService.ProcessAsyncTransaction(context.Background(), in)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, existing.ID, created.ID)
+		})
+	}
+}
+
+func Test_WalletTrxService_FailAsyncTransaction(t *testing.T) {
+	asyncId := "0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e"
+	args := models.CreateWalletTransactionRequest{ClientId: "async-client", AsyncId: asyncId}
+
+	tests := []struct {
+		name    string
+		args    func() models.CreateWalletTransactionRequest
+		doMock  func(th testServiceHelper)
+		wantErr error
+	}{
+		{
+			name: "happy path - processing transaction is failed with callback pending",
+			doMock: func(th testServiceHelper) {
+				th.mockAsyncTrxRepository.EXPECT().GetByID(gomock.Any(), asyncId).
+					Return(&models.AsyncWalletTransaction{ID: asyncId, ClientId: "async-client", Status: models.AsyncWalletTransactionStatusProcessing}, nil)
+				th.mockAsyncTrxRepository.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, in *models.AsyncWalletTransaction) error {
+						assert.Equal(t, models.AsyncWalletTransactionStatusFailed, in.Status)
+						assert.Equal(t, models.WalletTransactionErrorCodeInternal, in.ErrorCode)
+						assert.Equal(t, assert.AnError.Error(), in.ErrorMessage)
+						assert.Equal(t, models.AsyncCallbackStatusPending, in.CallbackStatus)
+						return nil
+					})
+			},
+		},
+		{
+			name: "happy path - finished transaction is kept",
+			doMock: func(th testServiceHelper) {
+				th.mockAsyncTrxRepository.EXPECT().GetByID(gomock.Any(), asyncId).
+					Return(&models.AsyncWalletTransaction{ID: asyncId, Status: models.AsyncWalletTransactionStatusFailed}, nil)
+			},
+		},
+		{
+			name: "happy path - message without async id is not tracked",
+			args: func() models.CreateWalletTransactionRequest {
+				return models.CreateWalletTransactionRequest{}
+			},
+			doMock: func(th testServiceHelper) {},
+		},
+		{
+			name: "failed - get transaction",
+			doMock: func(th testServiceHelper) {
+				th.mockAsyncTrxRepository.EXPECT().GetByID(gomock.Any(), asyncId).Return(nil, common.ErrDataNotFound)
+			},
+			wantErr: common.ErrDataNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := serviceTestHelper(t)
+			tt.doMock(th)
+
+			in := args
+			if tt.args != nil {
+				in = tt.args()
+			}
+
+			err := th.walletTrxService.FailAsyncTransaction(context.Background(), in, assert.AnError)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_WalletTrxService_SendAsyncCallbacks(t *testing.T) {
+	succeeded := models.AsyncWalletTransaction{
+		ID:             "0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e",
+		ClientId:       "async-client",
+		Status:         models.AsyncWalletTransactionStatusSucceeded,
+		CallbackStatus: models.AsyncCallbackStatusPending,
+	}
+	failed := models.AsyncWalletTransaction{
+		ID:               "5a3b7ef0-58a4-4c43-9a39-5c0c55b0f1a2",
+		ClientId:         "async-client",
+		Status:           models.AsyncWalletTransactionStatusFailed,
+		CallbackStatus:   models.AsyncCallbackStatusPending,
+		CallbackAttempts: 8,
+	}
+
+	tests := []struct {
+		name     string
+		doMock   func(th testServiceHelper)
+		wantSent int
+		wantErr  error
+	}{
+		{
+			name: "happy path - callback sent",
+			doMock: func(th testServiceHelper) {
+				gomock.InOrder(
+					th.mockAsyncTrxRepository.EXPECT().GetPendingCallbacks(gomock.Any(), gomock.Any(), 100).
+						Return([]models.AsyncWalletTransaction{succeeded}, nil),
+					th.mockWebhookClient.EXPECT().
+						Send(gomock.Any(), "https://client.amartha.com/callback", "secret", succeeded.ToModelResponse()).
+						Return(1, nil),
+					th.mockAsyncTrxRepository.EXPECT().UpdateCallback(gomock.Any(), succeeded.ID, models.AsyncCallbackStatusSent, 1).Return(nil),
+				)
+			},
+			wantSent: 1,
+		},
+		{
+			name: "happy path - failed callback stays pending until max attempts",
+			doMock: func(th testServiceHelper) {
+				th.mockAsyncTrxRepository.EXPECT().GetPendingCallbacks(gomock.Any(), gomock.Any(), 100).
+					Return([]models.AsyncWalletTransaction{succeeded, failed}, nil)
+				th.mockWebhookClient.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), succeeded.ToModelResponse()).Return(1, assert.AnError)
+				th.mockAsyncTrxRepository.EXPECT().UpdateCallback(gomock.Any(), succeeded.ID, models.AsyncCallbackStatusPending, 1).Return(nil)
+				th.mockWebhookClient.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any(), failed.ToModelResponse()).Return(2, assert.AnError)
+				th.mockAsyncTrxRepository.EXPECT().UpdateCallback(gomock.Any(), failed.ID, models.AsyncCallbackStatusFailed, 2).Return(nil)
+			},
+		},
+		{
+			name: "happy path - client without callback is failed",
+			doMock: func(th testServiceHelper) {
+				intake := succeeded
+				intake.ClientId = "async-client-without-callback"
+
+				th.mockAsyncTrxRepository.EXPECT().GetPendingCallbacks(gomock.Any(), gomock.Any(), 100).
+					Return([]models.AsyncWalletTransaction{intake}, nil)
+				th.mockAsyncTrxRepository.EXPECT().UpdateCallback(gomock.Any(), intake.ID, models.AsyncCallbackStatusFailed, 0).Return(nil)
+			},
+		},
+		{
+			name: "failed - get pending callbacks",
+			doMock: func(th testServiceHelper) {
+				th.mockAsyncTrxRepository.EXPECT().GetPendingCallbacks(gomock.Any(), gomock.Any(), 100).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := serviceTestHelper(t)
+			tt.doMock(th)
+
+			sent, err := th.walletTrxService.SendAsyncCallbacks(context.Background())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSent, sent)
+		})
+	}
+}
+
+func Test_WalletTrxService_GetAsyncTransaction(t *testing.T) {
+	asyncId := "0c6f4a4e-3a53-4d47-9b5f-2f0f3c1c6c1e"
+	intake := &models.AsyncWalletTransaction{
+		ID:       asyncId,
+		ClientId: "async-client",
+		Status:   models.AsyncWalletTransactionStatusQueued,
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		clientId string
+		doMock   func(th testServiceHelper)
+		wantErr  error
+	}{
+		{
+			name:     "happy path",
+			id:       asyncId,
+			clientId: "async-client",
+			doMock: func(th testServiceHelper) {
+				th.mockAsyncTrxRepository.EXPECT().GetByID(gomock.Any(), asyncId).Return(intake, nil)
+			},
+		},
+		{
+			name:     "failed - submitted by another client",
+			id:       asyncId,
+			clientId: "another-client",
+			doMock: func(th testServiceHelper) {
+				th.mockAsyncTrxRepository.EXPECT().GetByID(gomock.Any(), asyncId).Return(intake, nil)
+			},
+			wantErr: common.ErrDataNotFound,
+		},
+		{
+			name:     "failed - invalid id",
+			id:       "not-uuid",
+			clientId: "async-client",
+			wantErr:  common.ErrDataNotFound,
+		},
+		{
+			name:     "failed - get intake",
+			id:       asyncId,
+			clientId: "async-client",
+			doMock: func(th testServiceHelper) {
+				th.mockAsyncTrxRepository.EXPECT().GetByID(gomock.Any(), asyncId).Return(nil, assert.AnError)
+			},
+			wantErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			th := serviceTestHelper(t)
+			if tt.doMock != nil {
+				tt.doMock(th)
+			}
+
+			got, err := th.walletTrxService.GetAsyncTransaction(context.Background(), tt.id, tt.clientId)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, intake, got)
+		})
+	}
+}
@@ -150,3 +150,304 @@ ALTER TABLE public.account
 
 -- create INDEX account.name relate task ATRX-1014
 CREATE INDEX IF NOT EXISTS idx_account_name_lower ON account (LOWER(name));
+
+ALTER TABLE public.recon_tool_history
+    ADD COLUMN IF NOT EXISTS "summary" JSONB NULL DEFAULT '{}'::JSONB;
+
+CREATE TABLE IF NOT EXISTS public.recon_exception (
+    "id" BIGSERIAL PRIMARY KEY,
+    "reconHistoryId" BIGINT NOT NULL REFERENCES recon_tool_history("id") ON DELETE CASCADE,
+    "identifier" VARCHAR(255) NOT NULL,
+    "refNumber" VARCHAR(255) NULL,
+    "amount" NUMERIC(23, 8) NOT NULL,
+    "paymentDate" VARCHAR(20) NULL,
+    "orderType" VARCHAR(50) NOT NULL,
+    "transactionType" VARCHAR(50) NOT NULL,
+    "reconStatus" VARCHAR(50) NOT NULL,
+    "state" VARCHAR(20) NOT NULL DEFAULT 'OPEN',
+    "assignee" VARCHAR(255) NULL,
+    "resolutionNote" TEXT NULL,
+    "adjustmentTransactionId" VARCHAR(50) NULL,
+    "resolvedBy" VARCHAR(255) NULL,
+    "resolvedAt" TIMESTAMP WITH TIME ZONE NULL,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    "updatedAt" TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS recon_exception_recon_history_id_index ON recon_exception("reconHistoryId");
+CREATE INDEX IF NOT EXISTS recon_exception_state_assignee_index ON recon_exception("state", "assignee");
+
+CREATE TABLE IF NOT EXISTS public.recon_exception_activity (
+    "id" BIGSERIAL PRIMARY KEY,
+    "reconExceptionId" BIGINT NOT NULL REFERENCES recon_exception("id") ON DELETE CASCADE,
+    "action" VARCHAR(20) NOT NULL,
+    "actor" VARCHAR(255) NOT NULL,
+    "fromState" VARCHAR(20) NULL,
+    "toState" VARCHAR(20) NULL,
+    "comment" TEXT NULL,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS recon_exception_activity_recon_exception_id_index ON recon_exception_activity("reconExceptionId");
+
+CREATE TABLE IF NOT EXISTS public.money_flow_business_rules (
+    version BIGSERIAL PRIMARY KEY,
+    status VARCHAR(20) NOT NULL DEFAULT 'DRAFT',
+    rules JSONB NOT NULL DEFAULT '{}'::JSONB,
+    description TEXT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    updated_by VARCHAR(255) NULL,
+    published_by VARCHAR(255) NULL,
+    published_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+-- only one version can be published at a time
+CREATE UNIQUE INDEX IF NOT EXISTS money_flow_business_rules_published_unique ON money_flow_business_rules(status) WHERE status = 'PUBLISHED';
+
+-- 0 means the summary was produced by business rules from feature flag
+ALTER TABLE public.money_flow_summaries
+    ADD COLUMN IF NOT EXISTS business_rule_version BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS public.money_flow_summary_events (
+    id BIGSERIAL PRIMARY KEY,
+    summary_id UUID NOT NULL,
+    from_status VARCHAR(20) NOT NULL DEFAULT '',
+    to_status VARCHAR(20) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    papa_transaction_id VARCHAR(255) NOT NULL DEFAULT '',
+    papa_event_payload JSONB NULL,
+    event_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS money_flow_summary_events_summary_id_index ON money_flow_summary_events(summary_id, event_time);
+
+-- part of reserved "netAmount" which has been captured, used for partial capture of reserved wallet transaction
+ALTER TABLE public.wallet_transaction
+    ADD COLUMN IF NOT EXISTS "capturedAmount" NUMERIC(23, 8) NOT NULL DEFAULT 0;
+
+-- link each child transaction to the wallet transaction which produced it, null for legacy or non wallet transaction
+ALTER TABLE public.transaction
+    ADD COLUMN IF NOT EXISTS "walletTransactionId" UUID NULL;
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS transaction_wallet_transaction_id_index ON transaction("walletTransactionId");
+
+-- balance of every account before and after it is changed by a wallet transaction,
+-- a reserved transaction has a row per account for reserving and for every capture, commit or cancel
+CREATE TABLE IF NOT EXISTS public.wallet_transaction_balance (
+    "id" BIGSERIAL PRIMARY KEY,
+    "walletTransactionId" UUID NOT NULL,
+    "accountNumber" VARCHAR(50) NOT NULL,
+    "actualBalanceBefore" NUMERIC(23, 8) NOT NULL,
+    "pendingBalanceBefore" NUMERIC(23, 8) NOT NULL,
+    "actualBalanceAfter" NUMERIC(23, 8) NOT NULL,
+    "pendingBalanceAfter" NUMERIC(23, 8) NOT NULL,
+    "createdAt" TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS wallet_transaction_balance_wallet_transaction_id_index ON wallet_transaction_balance("walletTransactionId", "id");
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS wallet_transaction_ref_number_type_index ON wallet_transaction("refNumber", "transactionType");
+
+-- advanced transaction search
+-- metadata equality filter is compiled into containment (@>) which is served by jsonb_path_ops GIN index
+CREATE INDEX CONCURRENTLY IF NOT EXISTS transaction_metadata_gin_index ON transaction USING GIN ("metadata" jsonb_path_ops);
+CREATE INDEX CONCURRENTLY IF NOT EXISTS wallet_transaction_metadata_gin_index ON wallet_transaction USING GIN ("metadata" jsonb_path_ops);
+
+-- from-or-to account filter
+CREATE INDEX CONCURRENTLY IF NOT EXISTS transaction_from_account_date_index ON transaction("fromAccount", "transactionDate");
+CREATE INDEX CONCURRENTLY IF NOT EXISTS transaction_to_account_date_index ON transaction("toAccount", "transactionDate");
+
+-- most transactions are success, status filter of the rare statuses only need to scan these partial indexes
+CREATE INDEX CONCURRENTLY IF NOT EXISTS transaction_not_success_date_index ON transaction("transactionDate", "status") WHERE "status" <> '1';
+CREATE INDEX CONCURRENTLY IF NOT EXISTS wallet_transaction_not_success_time_index ON wallet_transaction("transactionTime", "status") WHERE "status" <> 'SUCCESS';
+
+-- master data stored in postgres, used when master_data.backend is "postgres"
+CREATE TABLE IF NOT EXISTS public.master_order_types (
+    code VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    version BIGINT NOT NULL DEFAULT 1,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS public.master_transaction_types (
+    order_type_code VARCHAR(50) NOT NULL REFERENCES master_order_types(code),
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    PRIMARY KEY (order_type_code, code)
+);
+
+CREATE TABLE IF NOT EXISTS public.master_vat_revenue_configs (
+    id BIGSERIAL PRIMARY KEY,
+    percentage NUMERIC(10, 4) NOT NULL,
+    start_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+-- before and after are json snapshot of the changed entity, before is null on create
+CREATE TABLE IF NOT EXISTS public.master_data_audits (
+    id BIGSERIAL PRIMARY KEY,
+    entity VARCHAR(50) NOT NULL,
+    entity_code VARCHAR(50) NOT NULL DEFAULT '',
+    action VARCHAR(20) NOT NULL,
+    version BIGINT NOT NULL DEFAULT 0,
+    actor VARCHAR(255) NOT NULL,
+    before JSONB NULL,
+    after JSONB NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS master_data_audits_entity_index ON master_data_audits(entity, entity_code, created_at);
+
+-- transaction type can be deactivated or scheduled, empty effective_from means always effective
+ALTER TABLE public.master_transaction_types
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+    ADD COLUMN IF NOT EXISTS effective_from TIMESTAMP WITH TIME ZONE NULL;
+
+-- tax rate table of tax engine, empty entity / loan_kind / transaction_type match any value
+CREATE TABLE IF NOT EXISTS public.tax_rates (
+    id BIGSERIAL PRIMARY KEY,
+    tax_type VARCHAR(10) NOT NULL,
+    entity VARCHAR(50) NOT NULL DEFAULT '',
+    loan_kind VARCHAR(50) NOT NULL DEFAULT '',
+    transaction_type VARCHAR(50) NOT NULL DEFAULT '',
+    rate NUMERIC(10, 4) NOT NULL,
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL,
+    effective_to TIMESTAMP WITH TIME ZONE NULL,
+    description TEXT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX CONCURRENTLY IF NOT EXISTS transaction_type_transaction_date_index ON transaction ("typeTransaction", "transactionDate");
+
+-- asynchronous transaction export, filter is the request filter and file_path is the object path in cloud storage
+CREATE TABLE IF NOT EXISTS public.export_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    format VARCHAR(20) NOT NULL,
+    gzip BOOLEAN NOT NULL DEFAULT FALSE,
+    filter JSONB NOT NULL DEFAULT '{}'::JSONB,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    total_rows BIGINT NOT NULL DEFAULT 0,
+    processed_rows BIGINT NOT NULL DEFAULT 0,
+    file_path VARCHAR(255) NOT NULL DEFAULT '',
+    error_message TEXT NOT NULL DEFAULT '',
+    requested_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+-- run history of scheduled reports, a report is generated once per period unless the run failed
+CREATE TABLE IF NOT EXISTS public.scheduled_report_runs (
+    id BIGSERIAL PRIMARY KEY,
+    report_name VARCHAR(100) NOT NULL,
+    period_start DATE NOT NULL,
+    period_end DATE NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    row_count BIGINT NOT NULL DEFAULT 0,
+    file_path VARCHAR(255) NOT NULL DEFAULT '',
+    error_message TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NULL,
+    CONSTRAINT scheduled_report_runs_report_name_period_start_key UNIQUE (report_name, period_start)
+);
+
+-- materialized daily totals of transactions for metrics, a closed day is replaced as a whole by the aggregation job
+CREATE TABLE IF NOT EXISTS public.transaction_daily_aggregates (
+    "transactionDate" DATE NOT NULL,
+    "orderType" VARCHAR(50) NOT NULL,
+    "typeTransaction" TEXT NOT NULL,
+    entity TEXT NOT NULL,
+    "productTypeName" TEXT NOT NULL,
+    status TEXT NOT NULL,
+    total_amount NUMERIC NOT NULL,
+    total_count BIGINT NOT NULL,
+    CONSTRAINT transaction_daily_aggregates_pkey PRIMARY KEY ("transactionDate", "orderType", "typeTransaction", entity, "productTypeName", status)
+);
+
+-- days of transaction_daily_aggregates that have been materialized, a day without transactions has no aggregate rows
+CREATE TABLE IF NOT EXISTS public.transaction_aggregate_dates (
+    "transactionDate" DATE PRIMARY KEY,
+    aggregated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+-- intake of asynchronous wallet transactions, a row is created on submission and updated by the consumer
+CREATE TABLE IF NOT EXISTS public.wallet_transaction_async_requests (
+    id UUID PRIMARY KEY,
+    client_id VARCHAR(100) NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL DEFAULT '',
+    ref_number VARCHAR(255) NOT NULL,
+    transaction_type VARCHAR(50) NOT NULL,
+    account_number VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    wallet_transaction_id VARCHAR(255) NOT NULL DEFAULT '',
+    error_code VARCHAR(50) NOT NULL DEFAULT '',
+    error_message TEXT NOT NULL DEFAULT '',
+    callback_status VARCHAR(20) NOT NULL DEFAULT '',
+    callback_attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    processed_at TIMESTAMP WITH TIME ZONE NULL
+);
+
+-- maker-checker approval of sensitive operations, payload is the input of the operation executed on approval
+CREATE TABLE IF NOT EXISTS public.approval_requests (
+    id BIGSERIAL PRIMARY KEY,
+    operation VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::JSONB,
+    description TEXT NOT NULL DEFAULT '',
+    state VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    maker VARCHAR(255) NOT NULL,
+    maker_client_id VARCHAR(100) NOT NULL DEFAULT '',
+    checker VARCHAR(255) NOT NULL DEFAULT '',
+    checker_client_id VARCHAR(100) NOT NULL DEFAULT '',
+    execution_error TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    decided_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS approval_requests_state_expires_at_index ON approval_requests(state, expires_at);
+
+CREATE TABLE IF NOT EXISTS public.approval_request_activities (
+    id BIGSERIAL PRIMARY KEY,
+    approval_request_id BIGINT NOT NULL REFERENCES approval_requests(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    client_id VARCHAR(100) NOT NULL DEFAULT '',
+    from_state VARCHAR(20) NOT NULL DEFAULT '',
+    to_state VARCHAR(20) NOT NULL DEFAULT '',
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS approval_request_activities_approval_request_id_index ON approval_request_activities(approval_request_id);
+
+-- reserved wallet transactions counted by the business metrics collector
+CREATE INDEX CONCURRENTLY IF NOT EXISTS wallet_transaction_pending_type_index ON wallet_transaction("transactionType", "createdAt") WHERE "status" = 'PENDING';
+
+-- transactions created after the daily balance snapshot, used by trial balance and general ledger opening balance
+CREATE INDEX CONCURRENTLY IF NOT EXISTS transaction_created_at_index ON transaction("createdAt");
+
+-- materialized days invalidated by back-dated transactions or status updates, they are materialized again by the job
+ALTER TABLE public.transaction_aggregate_dates
+    ADD COLUMN IF NOT EXISTS invalidated_at TIMESTAMP WITH TIME ZONE NULL;
+
+-- callbacks of async wallet transactions waiting to be sent by SendAsyncWalletTransactionCallbacks job
+CREATE INDEX CONCURRENTLY IF NOT EXISTS wallet_transaction_async_requests_callback_pending_index ON wallet_transaction_async_requests(updated_at) WHERE callback_status = 'PENDING';
+
+-- failed transfer requests of automatic disbursement, summary is moved to FAILED once the limit is reached
+ALTER TABLE public.money_flow_summaries
+    ADD COLUMN IF NOT EXISTS disbursement_attempts INT NOT NULL DEFAULT 0;